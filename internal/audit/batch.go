@@ -0,0 +1,133 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/model"
+)
+
+// NewBatchProvider creates a new *BatchProvider which writes the recorded authorization decisions to the writer in
+// the background.
+func NewBatchProvider(writer Writer) (provider *BatchProvider) {
+	provider = &BatchProvider{
+		writer: writer,
+		queue:  make(chan model.AuthorizationDecision, batchQueueSize),
+		done:   make(chan struct{}),
+		log:    logging.Logger().WithFields(map[string]any{"provider": "audit"}),
+	}
+
+	go provider.run()
+
+	return provider
+}
+
+// BatchProvider is a Provider which queues the authorization decisions and writes them to a Writer in batches so the
+// authorization endpoints never wait for the destination of the audit log.
+type BatchProvider struct {
+	writer Writer
+	queue  chan model.AuthorizationDecision
+	done   chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+
+	log *logrus.Entry
+}
+
+// StartupCheck implements the model.StartupCheck interface by performing the startup check of the writer.
+func (p *BatchProvider) StartupCheck() (err error) {
+	return p.writer.StartupCheck()
+}
+
+// RecordAuthorizationDecision queues an authorization decision to be written. It returns an error when the decision
+// is dropped as the queue is full or the provider is closed.
+func (p *BatchProvider) RecordAuthorizationDecision(_ context.Context, decision model.AuthorizationDecision) (err error) {
+	p.mu.RLock()
+
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return errors.New("error recording authorization decision: the audit log is closed")
+	}
+
+	select {
+	case p.queue <- decision:
+		return nil
+	default:
+		return errors.New("error recording authorization decision: the audit log queue is full")
+	}
+}
+
+// Prune implements the Pruner interface by pruning the writer.
+func (p *BatchProvider) Prune(ctx context.Context, before time.Time) (count int64, err error) {
+	return p.writer.Prune(ctx, before)
+}
+
+// Close writes the queued authorization decisions and closes the writer if it implements io.Closer.
+func (p *BatchProvider) Close() (err error) {
+	p.mu.Lock()
+
+	if !p.closed {
+		p.closed = true
+
+		close(p.queue)
+	}
+
+	p.mu.Unlock()
+
+	<-p.done
+
+	if closer, ok := p.writer.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
+
+func (p *BatchProvider) run() {
+	defer close(p.done)
+
+	ticker := time.NewTicker(batchInterval)
+
+	defer ticker.Stop()
+
+	batch := make([]model.AuthorizationDecision, 0, batchMaxSize)
+
+	for {
+		select {
+		case decision, ok := <-p.queue:
+			if !ok {
+				p.write(batch)
+
+				return
+			}
+
+			if batch = append(batch, decision); len(batch) >= batchMaxSize {
+				p.write(batch)
+
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			p.write(batch)
+
+			batch = batch[:0]
+		}
+	}
+}
+
+func (p *BatchProvider) write(batch []model.AuthorizationDecision) {
+	if len(batch) == 0 {
+		return
+	}
+
+	if err := p.writer.WriteAuthorizationDecisions(context.Background(), batch); err != nil {
+		p.log.WithError(err).WithField("count", len(batch)).Error("Error occurred writing authorization decisions to the audit log")
+	}
+}
@@ -0,0 +1,30 @@
+package audit
+
+import (
+	"time"
+)
+
+const (
+	// ModeStorage is the mode which records authorization decisions in the storage backend.
+	ModeStorage = "storage"
+
+	// ModeFile is the mode which appends authorization decisions to a file as JSON lines.
+	ModeFile = "file"
+)
+
+const (
+	// batchQueueSize is the maximum number of authorization decisions waiting to be written. Decisions which exceed
+	// this are dropped so the authorization endpoints are never blocked by the audit log.
+	batchQueueSize = 4096
+
+	// batchMaxSize is the maximum number of authorization decisions written in a single batch.
+	batchMaxSize = 256
+
+	// batchInterval is the maximum amount of time an authorization decision waits before it's written.
+	batchInterval = time.Second
+
+	// fileRotateInterval is the minimum amount of time between each rotation of the file.
+	fileRotateInterval = time.Hour * 24
+
+	fileRotatedTimeLayout = "20060102T150405Z"
+)
@@ -0,0 +1,209 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/model"
+)
+
+// NewFileWriter creates a new *FileWriter.
+func NewFileWriter(path string, clock clock.Provider) (writer *FileWriter) {
+	return &FileWriter{path: path, clock: clock}
+}
+
+// FileWriter is a Writer which appends authorization decisions to a file with one JSON object per line. The file is
+// rotated by Prune at most once per day, the rotated files have the time of the rotation appended to the path.
+type FileWriter struct {
+	path  string
+	clock clock.Provider
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+// StartupCheck implements the model.StartupCheck interface by opening the file so the path is known to be writable.
+func (w *FileWriter) StartupCheck() (err error) {
+	w.mu.Lock()
+
+	defer w.mu.Unlock()
+
+	return w.open()
+}
+
+// WriteAuthorizationDecisions appends a batch of authorization decisions to the file.
+func (w *FileWriter) WriteAuthorizationDecisions(_ context.Context, decisions []model.AuthorizationDecision) (err error) {
+	buf := &bytes.Buffer{}
+
+	for _, decision := range decisions {
+		var data []byte
+
+		if data, err = json.Marshal(decision); err != nil {
+			return fmt.Errorf("error marshalling authorization decision: %w", err)
+		}
+
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+
+	w.mu.Lock()
+
+	defer w.mu.Unlock()
+
+	if err = w.open(); err != nil {
+		return err
+	}
+
+	if _, err = w.file.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("error writing authorization decisions to file '%s': %w", w.path, err)
+	}
+
+	return nil
+}
+
+// Prune rotates the file if it wasn't rotated during the last day and deletes the rotated files which were rotated
+// before a time, as all of their decisions were made before the time of the rotation. It returns the number of rotated
+// files which were deleted.
+func (w *FileWriter) Prune(_ context.Context, before time.Time) (count int64, err error) {
+	w.mu.Lock()
+
+	defer w.mu.Unlock()
+
+	var rotated map[string]time.Time
+
+	if rotated, err = w.rotated(); err != nil {
+		return 0, err
+	}
+
+	var latest time.Time
+
+	for _, t := range rotated {
+		if t.After(latest) {
+			latest = t
+		}
+	}
+
+	now := w.clock.Now().UTC()
+
+	if now.Sub(latest) >= fileRotateInterval {
+		var name string
+
+		if name, err = w.rotate(now); err != nil {
+			return 0, err
+		}
+
+		if name != "" {
+			rotated[name] = now
+		}
+	}
+
+	for name, t := range rotated {
+		if !t.Before(before) {
+			continue
+		}
+
+		if err = os.Remove(name); err != nil {
+			return count, fmt.Errorf("error removing rotated audit log file '%s': %w", name, err)
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+// Close the file.
+func (w *FileWriter) Close() (err error) {
+	w.mu.Lock()
+
+	defer w.mu.Unlock()
+
+	return w.close()
+}
+
+func (w *FileWriter) open() (err error) {
+	if w.file != nil {
+		return nil
+	}
+
+	if w.file, err = os.OpenFile(w.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600); err != nil {
+		return fmt.Errorf("error opening audit log file '%s': %w", w.path, err)
+	}
+
+	return nil
+}
+
+func (w *FileWriter) close() (err error) {
+	if w.file == nil {
+		return nil
+	}
+
+	err = w.file.Close()
+
+	w.file = nil
+
+	return err
+}
+
+// rotate renames the file if it's not empty so the next write creates a new file, and returns the new name.
+func (w *FileWriter) rotate(now time.Time) (name string, err error) {
+	var info os.FileInfo
+
+	switch info, err = os.Stat(w.path); {
+	case errors.Is(err, os.ErrNotExist):
+		return "", nil
+	case err != nil:
+		return "", fmt.Errorf("error rotating audit log file '%s': %w", w.path, err)
+	case info.Size() == 0:
+		return "", nil
+	}
+
+	if err = w.close(); err != nil {
+		return "", fmt.Errorf("error rotating audit log file '%s': %w", w.path, err)
+	}
+
+	name = w.path + "." + now.Format(fileRotatedTimeLayout)
+
+	if err = os.Rename(w.path, name); err != nil {
+		return "", fmt.Errorf("error rotating audit log file '%s': %w", w.path, err)
+	}
+
+	return name, nil
+}
+
+// rotated returns the paths of the rotated files and the time they were rotated.
+func (w *FileWriter) rotated() (rotated map[string]time.Time, err error) {
+	dir, prefix := filepath.Dir(w.path), filepath.Base(w.path)+"."
+
+	var entries []os.DirEntry
+
+	if entries, err = os.ReadDir(dir); err != nil {
+		return nil, fmt.Errorf("error reading the rotated audit log files: %w", err)
+	}
+
+	rotated = map[string]time.Time{}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), prefix) {
+			continue
+		}
+
+		t, err := time.Parse(fileRotatedTimeLayout, strings.TrimPrefix(entry.Name(), prefix))
+		if err != nil {
+			continue
+		}
+
+		rotated[filepath.Join(dir, entry.Name())] = t
+	}
+
+	return rotated, nil
+}
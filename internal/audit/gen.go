@@ -0,0 +1,6 @@
+package audit
+
+// This file is used to generate mocks. You can generate all mocks using the
+// command `go generate github.com/authelia/authelia/v4/internal/audit`.
+
+//go:generate mockgen -package audit -destination storage_mock_test.go -mock_names Storage=MockStorage github.com/authelia/authelia/v4/internal/audit Storage
@@ -0,0 +1,51 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+)
+
+// Provider records authorization decisions to the audit log.
+type Provider interface {
+	model.StartupCheck
+
+	// RecordAuthorizationDecision records an authorization decision to the audit log.
+	RecordAuthorizationDecision(ctx context.Context, decision model.AuthorizationDecision) (err error)
+}
+
+// Pruner is implemented by the providers which can delete the authorization decisions which exceed the retention.
+type Pruner interface {
+	// Prune deletes the authorization decisions made before a time returning the number of entries deleted.
+	Prune(ctx context.Context, before time.Time) (count int64, err error)
+}
+
+// Writer writes batches of authorization decisions to the destination of the audit log.
+type Writer interface {
+	model.StartupCheck
+	Pruner
+
+	// WriteAuthorizationDecisions writes a batch of authorization decisions to the destination.
+	WriteAuthorizationDecisions(ctx context.Context, decisions []model.AuthorizationDecision) (err error)
+}
+
+// Storage is a cut down version of the storage.Provider interface with just the methods the StorageWriter uses.
+type Storage interface {
+	AppendAuthorizationDecisions(ctx context.Context, decisions []model.AuthorizationDecision) (err error)
+	PruneAuthorizationDecisions(ctx context.Context, before time.Time) (count int64, err error)
+}
+
+// NewProvider creates a new Provider for the configured mode. It returns nil when the audit log is disabled.
+func NewProvider(config schema.AccessControlAudit, store Storage, clock clock.Provider) (provider Provider) {
+	switch config.Mode {
+	case ModeStorage:
+		return NewBatchProvider(NewStorageWriter(store))
+	case ModeFile:
+		return NewBatchProvider(NewFileWriter(config.Path, clock))
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,241 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+)
+
+func TestNewProvider(t *testing.T) {
+	assert.Nil(t, NewProvider(schema.AccessControlAudit{}, nil, nil))
+
+	provider := NewProvider(schema.AccessControlAudit{Mode: ModeStorage}, nil, nil)
+
+	require.IsType(t, &BatchProvider{}, provider)
+	assert.IsType(t, &StorageWriter{}, provider.(*BatchProvider).writer)
+	assert.NoError(t, provider.(*BatchProvider).Close())
+
+	provider = NewProvider(schema.AccessControlAudit{Mode: ModeFile, Path: "audit.log"}, nil, nil)
+
+	require.IsType(t, &BatchProvider{}, provider)
+	assert.IsType(t, &FileWriter{}, provider.(*BatchProvider).writer)
+	assert.NoError(t, provider.(*BatchProvider).Close())
+}
+
+func TestBatchProvider(t *testing.T) {
+	writer := &testWriter{}
+
+	provider := NewBatchProvider(writer)
+
+	assert.NoError(t, provider.StartupCheck())
+
+	decision := newTestDecision()
+
+	for i := 0; i < batchMaxSize+2; i++ {
+		require.NoError(t, provider.RecordAuthorizationDecision(context.Background(), decision))
+	}
+
+	require.NoError(t, provider.Close())
+	require.NoError(t, provider.Close())
+
+	writer.mu.Lock()
+
+	defer writer.mu.Unlock()
+
+	require.Len(t, writer.batches, 2)
+	assert.Len(t, writer.batches[0], batchMaxSize)
+	assert.Len(t, writer.batches[1], 2)
+	assert.Equal(t, decision, writer.batches[1][1])
+
+	assert.EqualError(t, provider.RecordAuthorizationDecision(context.Background(), decision), "error recording authorization decision: the audit log is closed")
+}
+
+func TestBatchProviderShouldDropWhenQueueIsFull(t *testing.T) {
+	provider := &BatchProvider{writer: &testWriter{}, queue: make(chan model.AuthorizationDecision, 1), done: make(chan struct{})}
+
+	require.NoError(t, provider.RecordAuthorizationDecision(context.Background(), newTestDecision()))
+	assert.EqualError(t, provider.RecordAuthorizationDecision(context.Background(), newTestDecision()), "error recording authorization decision: the audit log queue is full")
+}
+
+func TestStorageWriter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	store := NewMockStorage(ctrl)
+
+	writer := NewStorageWriter(store)
+
+	decisions := []model.AuthorizationDecision{newTestDecision()}
+	before := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	gomock.InOrder(
+		store.EXPECT().AppendAuthorizationDecisions(gomock.Any(), decisions).Return(nil),
+		store.EXPECT().AppendAuthorizationDecisions(gomock.Any(), decisions).Return(errors.New("bad conn")),
+		store.EXPECT().PruneAuthorizationDecisions(gomock.Any(), before).Return(int64(5), nil),
+	)
+
+	assert.NoError(t, writer.StartupCheck())
+	assert.NoError(t, writer.WriteAuthorizationDecisions(context.Background(), decisions))
+	assert.EqualError(t, writer.WriteAuthorizationDecisions(context.Background(), decisions), "bad conn")
+
+	count, err := writer.Prune(context.Background(), before)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), count)
+}
+
+func TestFileWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	writer := NewFileWriter(path, nil)
+
+	require.NoError(t, writer.StartupCheck())
+
+	first, second := newTestDecision(), newTestDecision()
+
+	second.Decision, second.StatusCode, second.Username, second.Groups, second.RemoteIP = model.AuthorizationDecisionRedirect, 302, "", nil, model.NullIP{}
+
+	require.NoError(t, writer.WriteAuthorizationDecisions(context.Background(), []model.AuthorizationDecision{first, second}))
+	require.NoError(t, writer.Close())
+	require.NoError(t, writer.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	require.Len(t, lines, 2)
+
+	assert.Equal(t, `{"time":"2024-01-01T10:00:00Z","decision":"allow","status_code":200,"username":"john","groups":["admins","dev"],"remote_ip":"192.168.1.10","request_method":"GET","request_uri":"https://app.example.com/admin","domain":"app.example.com","rule_position":2,"required_level":"two_factor","current_level":"two_factor","implementation":"ForwardAuth"}`, lines[0])
+
+	actual := map[string]any{}
+
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &actual))
+	assert.Equal(t, "redirect", actual["decision"])
+	assert.Nil(t, actual["remote_ip"])
+	assert.NotContains(t, actual, "username")
+
+	// The file is opened again when a decision is recorded after it's closed.
+	require.NoError(t, writer.WriteAuthorizationDecisions(context.Background(), []model.AuthorizationDecision{first}))
+	require.NoError(t, writer.Close())
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestFileWriterShouldErrorOnInvalidPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "audit.log")
+
+	writer := NewFileWriter(path, nil)
+
+	assert.ErrorContains(t, writer.StartupCheck(), "error opening audit log file '"+path+"'")
+	assert.ErrorContains(t, writer.WriteAuthorizationDecisions(context.Background(), []model.AuthorizationDecision{newTestDecision()}), "error opening audit log file '"+path+"'")
+}
+
+func TestFileWriterPrune(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.log")
+
+	now := time.Date(2024, time.January, 10, 10, 0, 0, 0, time.UTC)
+
+	c := clock.NewFixed(now)
+
+	writer := NewFileWriter(path, c)
+
+	// Nothing is rotated when the file doesn't exist.
+	count, err := writer.Prune(context.Background(), now.Add(-time.Hour*24*7))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "audit.log.20240101T000000Z"), []byte("{}\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "audit.log.20240105T000000Z"), []byte("{}\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "audit.log.invalid"), []byte("{}\n"), 0600))
+	require.NoError(t, writer.WriteAuthorizationDecisions(context.Background(), []model.AuthorizationDecision{newTestDecision()}))
+
+	count, err = writer.Prune(context.Background(), now.Add(-time.Hour*24*7))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	assert.NoFileExists(t, filepath.Join(dir, "audit.log.20240101T000000Z"))
+	assert.FileExists(t, filepath.Join(dir, "audit.log.20240105T000000Z"))
+	assert.FileExists(t, filepath.Join(dir, "audit.log.invalid"))
+	assert.FileExists(t, filepath.Join(dir, "audit.log.20240110T100000Z"))
+	assert.NoFileExists(t, path)
+
+	// The file is not rotated again within a day of the last rotation.
+	require.NoError(t, writer.WriteAuthorizationDecisions(context.Background(), []model.AuthorizationDecision{newTestDecision()}))
+
+	c.Set(now.Add(time.Hour * 23))
+
+	count, err = writer.Prune(context.Background(), now.Add(-time.Hour*24*7))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+	assert.FileExists(t, path)
+
+	c.Set(now.Add(time.Hour * 24))
+
+	count, err = writer.Prune(context.Background(), now.Add(-time.Hour*24*2))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	assert.NoFileExists(t, filepath.Join(dir, "audit.log.20240105T000000Z"))
+	assert.FileExists(t, filepath.Join(dir, "audit.log.20240111T100000Z"))
+	assert.NoFileExists(t, path)
+
+	require.NoError(t, writer.Close())
+}
+
+func newTestDecision() model.AuthorizationDecision {
+	return model.AuthorizationDecision{
+		Time:           time.Date(2024, time.January, 1, 10, 0, 0, 0, time.UTC),
+		Decision:       model.AuthorizationDecisionAllow,
+		StatusCode:     200,
+		Username:       "john",
+		Groups:         []string{"admins", "dev"},
+		RemoteIP:       model.NewNullIP(net.ParseIP("192.168.1.10")),
+		RequestMethod:  "GET",
+		RequestURI:     "https://app.example.com/admin",
+		Domain:         "app.example.com",
+		RulePosition:   2,
+		RequiredLevel:  "two_factor",
+		CurrentLevel:   "two_factor",
+		Implementation: "ForwardAuth",
+	}
+}
+
+type testWriter struct {
+	mu      sync.Mutex
+	batches [][]model.AuthorizationDecision
+}
+
+func (w *testWriter) StartupCheck() error {
+	return nil
+}
+
+func (w *testWriter) WriteAuthorizationDecisions(_ context.Context, decisions []model.AuthorizationDecision) error {
+	w.mu.Lock()
+
+	defer w.mu.Unlock()
+
+	w.batches = append(w.batches, append([]model.AuthorizationDecision(nil), decisions...))
+
+	return nil
+}
+
+func (w *testWriter) Prune(_ context.Context, _ time.Time) (int64, error) {
+	return 0, nil
+}
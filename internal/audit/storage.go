@@ -0,0 +1,34 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/authelia/authelia/v4/internal/model"
+)
+
+// NewStorageWriter creates a new *StorageWriter.
+func NewStorageWriter(store Storage) (writer *StorageWriter) {
+	return &StorageWriter{storage: store}
+}
+
+// StorageWriter is a Writer which records authorization decisions in the storage backend.
+type StorageWriter struct {
+	storage Storage
+}
+
+// StartupCheck implements the model.StartupCheck interface. The storage backend has its own startup check so this
+// always succeeds.
+func (w *StorageWriter) StartupCheck() (err error) {
+	return nil
+}
+
+// WriteAuthorizationDecisions records a batch of authorization decisions in the storage backend.
+func (w *StorageWriter) WriteAuthorizationDecisions(ctx context.Context, decisions []model.AuthorizationDecision) (err error) {
+	return w.storage.AppendAuthorizationDecisions(ctx, decisions)
+}
+
+// Prune deletes the authorization decisions made before a time from the storage backend.
+func (w *StorageWriter) Prune(ctx context.Context, before time.Time) (count int64, err error) {
+	return w.storage.PruneAuthorizationDecisions(ctx, before)
+}
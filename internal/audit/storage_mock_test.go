@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/authelia/authelia/v4/internal/audit (interfaces: Storage)
+//
+// Generated by this command:
+//
+//	mockgen -package audit -destination storage_mock_test.go -mock_names Storage=MockStorage github.com/authelia/authelia/v4/internal/audit Storage
+//
+
+// Package audit is a generated GoMock package.
+package audit
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	model "github.com/authelia/authelia/v4/internal/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStorage is a mock of Storage interface.
+type MockStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockStorageMockRecorder is the mock recorder for MockStorage.
+type MockStorageMockRecorder struct {
+	mock *MockStorage
+}
+
+// NewMockStorage creates a new mock instance.
+func NewMockStorage(ctrl *gomock.Controller) *MockStorage {
+	mock := &MockStorage{ctrl: ctrl}
+	mock.recorder = &MockStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStorage) EXPECT() *MockStorageMockRecorder {
+	return m.recorder
+}
+
+// AppendAuthorizationDecisions mocks base method.
+func (m *MockStorage) AppendAuthorizationDecisions(ctx context.Context, decisions []model.AuthorizationDecision) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendAuthorizationDecisions", ctx, decisions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AppendAuthorizationDecisions indicates an expected call of AppendAuthorizationDecisions.
+func (mr *MockStorageMockRecorder) AppendAuthorizationDecisions(ctx, decisions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendAuthorizationDecisions", reflect.TypeOf((*MockStorage)(nil).AppendAuthorizationDecisions), ctx, decisions)
+}
+
+// PruneAuthorizationDecisions mocks base method.
+func (m *MockStorage) PruneAuthorizationDecisions(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneAuthorizationDecisions", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneAuthorizationDecisions indicates an expected call of PruneAuthorizationDecisions.
+func (mr *MockStorageMockRecorder) PruneAuthorizationDecisions(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneAuthorizationDecisions", reflect.TypeOf((*MockStorage)(nil).PruneAuthorizationDecisions), ctx, before)
+}
@@ -59,6 +59,13 @@ const (
 	ldapBaseObjectFilter = "(objectClass=*)"
 )
 
+// User Account Control flags which indicate the account can't be used.
+// See https://learn.microsoft.com/en-us/troubleshoot/windows-server/active-directory/useraccountcontrol-manipulate-account-properties.
+const (
+	ldapUserAccountControlAccountDisable = 0x0002
+	ldapUserAccountControlLockout        = 0x0010
+)
+
 const (
 	ldapPlaceholderInput                             = "{input}"
 	ldapPlaceholderDistinguishedName                 = "{dn}"
@@ -97,6 +104,12 @@ var (
 
 	// ErrNoContent is returned when the file is empty.
 	ErrNoContent = errors.New("no file content")
+
+	// ErrUserExists indicates the user already exists in the authentication backend.
+	ErrUserExists = errors.New("user already exists")
+
+	// ErrUserDisabled indicates the user exists in the authentication backend but the account is disabled or locked.
+	ErrUserDisabled = errors.New("user account is disabled")
 )
 
 const fileAuthenticationMode = 0600
@@ -73,7 +73,7 @@ func (p *FileUserProvider) CheckUserPassword(username string, password string) (
 	}
 
 	if details.Disabled {
-		return false, ErrUserNotFound
+		return false, ErrUserDisabled
 	}
 
 	return details.Password.MatchAdvanced(password)
@@ -88,7 +88,7 @@ func (p *FileUserProvider) GetDetails(username string) (details *UserDetails, er
 	}
 
 	if d.Disabled {
-		return nil, ErrUserNotFound
+		return nil, ErrUserDisabled
 	}
 
 	return d.ToUserDetails(), nil
@@ -103,7 +103,7 @@ func (p *FileUserProvider) UpdatePassword(username string, newPassword string) (
 	}
 
 	if details.Disabled {
-		return ErrUserNotFound
+		return ErrUserDisabled
 	}
 
 	var digest algorithm.Digest
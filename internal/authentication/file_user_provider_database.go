@@ -3,6 +3,8 @@ package authentication
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 
@@ -19,6 +21,11 @@ type FileUserProviderDatabase interface {
 	Load() (err error)
 	GetUserDetails(username string) (user FileUserDatabaseUserDetails, err error)
 	SetUserDetails(username string, details *FileUserDatabaseUserDetails)
+	CreateUser(details FileUserDatabaseUserDetails) (err error)
+	UpdateUser(details FileUserDatabaseUserDetails) (err error)
+	DeleteUser(username string) (err error)
+	DisableUser(username string, disabled bool) (err error)
+	ListUsers() (users []FileUserDatabaseUserDetails)
 }
 
 // NewFileUserDatabase creates a new FileUserDatabase.
@@ -194,6 +201,120 @@ func (m *FileUserDatabase) SetUserDetails(username string, details *FileUserData
 	m.Unlock()
 }
 
+// CreateUser adds a new user to the database. The user must not already exist and the resulting database must be
+// valid with the configured search options.
+func (m *FileUserDatabase) CreateUser(details FileUserDatabaseUserDetails) (err error) {
+	if err = details.Validate(); err != nil {
+		return err
+	}
+
+	return m.modify(func(users map[string]FileUserDatabaseUserDetails) error {
+		if _, ok := users[details.Username]; ok {
+			return fmt.Errorf("error creating user '%s': %w", details.Username, ErrUserExists)
+		}
+
+		users[details.Username] = details
+
+		return nil
+	})
+}
+
+// UpdateUser replaces the details of an existing user in the database. The resulting database must be valid with the
+// configured search options.
+func (m *FileUserDatabase) UpdateUser(details FileUserDatabaseUserDetails) (err error) {
+	if err = details.Validate(); err != nil {
+		return err
+	}
+
+	return m.modify(func(users map[string]FileUserDatabaseUserDetails) error {
+		if _, ok := users[details.Username]; !ok {
+			return fmt.Errorf("error updating user '%s': %w", details.Username, ErrUserNotFound)
+		}
+
+		users[details.Username] = details
+
+		return nil
+	})
+}
+
+// DeleteUser removes an existing user from the database.
+func (m *FileUserDatabase) DeleteUser(username string) (err error) {
+	return m.modify(func(users map[string]FileUserDatabaseUserDetails) error {
+		if _, ok := users[username]; !ok {
+			return fmt.Errorf("error deleting user '%s': %w", username, ErrUserNotFound)
+		}
+
+		delete(users, username)
+
+		return nil
+	})
+}
+
+// DisableUser sets the disabled status of an existing user in the database.
+func (m *FileUserDatabase) DisableUser(username string, disabled bool) (err error) {
+	return m.modify(func(users map[string]FileUserDatabaseUserDetails) error {
+		details, ok := users[username]
+		if !ok {
+			return fmt.Errorf("error setting the disabled status of user '%s': %w", username, ErrUserNotFound)
+		}
+
+		details.Disabled = disabled
+
+		users[username] = details
+
+		return nil
+	})
+}
+
+// ListUsers returns all users in the database sorted by username.
+func (m *FileUserDatabase) ListUsers() (users []FileUserDatabaseUserDetails) {
+	m.RLock()
+
+	defer m.RUnlock()
+
+	users = make([]FileUserDatabaseUserDetails, 0, len(m.Users))
+
+	for _, details := range m.Users {
+		users = append(users, details)
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].Username < users[j].Username
+	})
+
+	return users
+}
+
+// modify applies a change to a copy of the users and only replaces the current users if the aliases for the changed
+// users load successfully.
+func (m *FileUserDatabase) modify(fn func(users map[string]FileUserDatabaseUserDetails) error) (err error) {
+	m.Lock()
+
+	defer m.Unlock()
+
+	users := make(map[string]FileUserDatabaseUserDetails, len(m.Users))
+
+	for username, details := range m.Users {
+		users[username] = details
+	}
+
+	if err = fn(users); err != nil {
+		return err
+	}
+
+	previous, emails, aliases := m.Users, m.Emails, m.Aliases
+
+	m.Users, m.Emails, m.Aliases = users, map[string]string{}, map[string]string{}
+
+	if err = m.LoadAliases(); err != nil {
+		m.Users, m.Emails, m.Aliases = previous, emails, aliases
+
+		return err
+	}
+
+	return nil
+}
+
 // ToDatabaseModel converts the FileUserDatabase into the FileDatabaseModel for saving.
 func (m *FileUserDatabase) ToDatabaseModel() (model *FileDatabaseModel) {
 	model = &FileDatabaseModel{
@@ -218,7 +339,27 @@ type FileUserDatabaseUserDetails struct {
 	DisplayName string                 `json:"displayname" jsonschema:"required,title=Display Name" jsonschema_description:"The display name for the user."`
 	Email       string                 `json:"email" jsonschema:"title=Email" jsonschema_description:"The email for the user."`
 	Groups      []string               `json:"groups" jsonschema:"title=Groups" jsonschema_description:"The groups list for the user."`
+	GivenName   string                 `json:"given_name" jsonschema:"title=Given Name" jsonschema_description:"The given name for the user."`
+	FamilyName  string                 `json:"family_name" jsonschema:"title=Family Name" jsonschema_description:"The family name for the user."`
+	PhoneNumber string                 `json:"phone_number" jsonschema:"title=Phone Number" jsonschema_description:"The phone number for the user."`
+	Locale      string                 `json:"locale" jsonschema:"title=Locale" jsonschema_description:"The locale for the user."`
+	Picture     string                 `json:"picture" jsonschema:"title=Picture" jsonschema_description:"The picture URL for the user."`
 	Disabled    bool                   `json:"disabled" jsonschema:"default=false,title=Disabled" jsonschema_description:"The disabled status for the user."`
+	Extra       map[string]any         `json:"extra" jsonschema:"title=Extra" jsonschema_description:"The extra attributes for the user."`
+}
+
+// Validate ensures the FileUserDatabaseUserDetails can be written to the file database.
+func (m FileUserDatabaseUserDetails) Validate() (err error) {
+	switch {
+	case m.Username == "":
+		return fmt.Errorf("the username must not be empty")
+	case m.Password == nil || m.Password.Digest == nil:
+		return fmt.Errorf("the password for user '%s' must be set", m.Username)
+	case m.DisplayName == "":
+		return fmt.Errorf("the display name for user '%s' must not be empty", m.Username)
+	}
+
+	return nil
 }
 
 // ToUserDetails converts FileUserDatabaseUserDetails into a *UserDetails given a username.
@@ -228,6 +369,12 @@ func (m FileUserDatabaseUserDetails) ToUserDetails() (details *UserDetails) {
 		DisplayName: m.DisplayName,
 		Emails:      []string{m.Email},
 		Groups:      m.Groups,
+		GivenName:   m.GivenName,
+		FamilyName:  m.FamilyName,
+		PhoneNumber: m.PhoneNumber,
+		Locale:      m.Locale,
+		Picture:     m.Picture,
+		Extra:       m.Extra,
 	}
 }
 
@@ -238,6 +385,13 @@ func (m FileUserDatabaseUserDetails) ToUserDetailsModel() (model FileDatabaseUse
 		DisplayName: m.DisplayName,
 		Email:       m.Email,
 		Groups:      m.Groups,
+		Disabled:    m.Disabled,
+		GivenName:   m.GivenName,
+		FamilyName:  m.FamilyName,
+		PhoneNumber: m.PhoneNumber,
+		Locale:      m.Locale,
+		Picture:     m.Picture,
+		Extra:       m.Extra,
 	}
 }
 
@@ -295,26 +449,70 @@ func (m *FileDatabaseModel) Read(filePath string) (err error) {
 	return nil
 }
 
-// Write a FileDatabaseModel to disk.
+// Write a FileDatabaseModel to disk. The file is written to a temporary file in the same directory which is then
+// renamed over the original file so readers never observe a partially written database.
 func (m *FileDatabaseModel) Write(fileName string) (err error) {
 	var (
 		data []byte
+		file *os.File
 	)
 
 	if data, err = yaml.Marshal(m); err != nil {
 		return err
 	}
 
-	return os.WriteFile(fileName, data, fileAuthenticationMode)
+	if file, err = os.CreateTemp(filepath.Dir(fileName), fmt.Sprintf(".%s.*.tmp", filepath.Base(fileName))); err != nil {
+		return fmt.Errorf("failed to create the temporary file for '%s': %w", fileName, err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = os.Remove(file.Name())
+		}
+	}()
+
+	if _, err = file.Write(data); err != nil {
+		_ = file.Close()
+
+		return fmt.Errorf("failed to write the temporary file for '%s': %w", fileName, err)
+	}
+
+	if err = file.Chmod(fileAuthenticationMode); err != nil {
+		_ = file.Close()
+
+		return fmt.Errorf("failed to set the permissions of the temporary file for '%s': %w", fileName, err)
+	}
+
+	if err = file.Sync(); err != nil {
+		_ = file.Close()
+
+		return fmt.Errorf("failed to sync the temporary file for '%s': %w", fileName, err)
+	}
+
+	if err = file.Close(); err != nil {
+		return fmt.Errorf("failed to close the temporary file for '%s': %w", fileName, err)
+	}
+
+	if err = os.Rename(file.Name(), fileName); err != nil {
+		return fmt.Errorf("failed to replace the file '%s': %w", fileName, err)
+	}
+
+	return nil
 }
 
 // FileDatabaseUserDetailsModel is the model of user details in the file database.
 type FileDatabaseUserDetailsModel struct {
-	Password    string   `yaml:"password" valid:"required"`
-	DisplayName string   `yaml:"displayname" valid:"required"`
-	Email       string   `yaml:"email"`
-	Groups      []string `yaml:"groups"`
-	Disabled    bool     `yaml:"disabled"`
+	Password    string         `yaml:"password" valid:"required"`
+	DisplayName string         `yaml:"displayname" valid:"required"`
+	Email       string         `yaml:"email"`
+	Groups      []string       `yaml:"groups"`
+	Disabled    bool           `yaml:"disabled"`
+	GivenName   string         `yaml:"given_name,omitempty"`
+	FamilyName  string         `yaml:"family_name,omitempty"`
+	PhoneNumber string         `yaml:"phone_number,omitempty"`
+	Locale      string         `yaml:"locale,omitempty"`
+	Picture     string         `yaml:"picture,omitempty"`
+	Extra       map[string]any `yaml:"extra,omitempty"`
 }
 
 // ToDatabaseUserDetailsModel converts a FileDatabaseUserDetailsModel into a *FileUserDatabaseUserDetails.
@@ -332,5 +530,11 @@ func (m FileDatabaseUserDetailsModel) ToDatabaseUserDetailsModel(username string
 		DisplayName: m.DisplayName,
 		Email:       m.Email,
 		Groups:      m.Groups,
+		GivenName:   m.GivenName,
+		FamilyName:  m.FamilyName,
+		PhoneNumber: m.PhoneNumber,
+		Locale:      m.Locale,
+		Picture:     m.Picture,
+		Extra:       m.Extra,
 	}, nil
 }
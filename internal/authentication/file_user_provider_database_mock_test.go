@@ -39,6 +39,48 @@ func (m *MockFileUserDatabase) EXPECT() *MockFileUserDatabaseMockRecorder {
 	return m.recorder
 }
 
+// CreateUser mocks base method.
+func (m *MockFileUserDatabase) CreateUser(details FileUserDatabaseUserDetails) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", details)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockFileUserDatabaseMockRecorder) CreateUser(details any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockFileUserDatabase)(nil).CreateUser), details)
+}
+
+// DeleteUser mocks base method.
+func (m *MockFileUserDatabase) DeleteUser(username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockFileUserDatabaseMockRecorder) DeleteUser(username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockFileUserDatabase)(nil).DeleteUser), username)
+}
+
+// DisableUser mocks base method.
+func (m *MockFileUserDatabase) DisableUser(username string, disabled bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableUser", username, disabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableUser indicates an expected call of DisableUser.
+func (mr *MockFileUserDatabaseMockRecorder) DisableUser(username, disabled any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableUser", reflect.TypeOf((*MockFileUserDatabase)(nil).DisableUser), username, disabled)
+}
+
 // GetUserDetails mocks base method.
 func (m *MockFileUserDatabase) GetUserDetails(username string) (FileUserDatabaseUserDetails, error) {
 	m.ctrl.T.Helper()
@@ -54,6 +96,20 @@ func (mr *MockFileUserDatabaseMockRecorder) GetUserDetails(username any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserDetails", reflect.TypeOf((*MockFileUserDatabase)(nil).GetUserDetails), username)
 }
 
+// ListUsers mocks base method.
+func (m *MockFileUserDatabase) ListUsers() []FileUserDatabaseUserDetails {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers")
+	ret0, _ := ret[0].([]FileUserDatabaseUserDetails)
+	return ret0
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockFileUserDatabaseMockRecorder) ListUsers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockFileUserDatabase)(nil).ListUsers))
+}
+
 // Load mocks base method.
 func (m *MockFileUserDatabase) Load() error {
 	m.ctrl.T.Helper()
@@ -93,3 +149,17 @@ func (mr *MockFileUserDatabaseMockRecorder) SetUserDetails(username, details any
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserDetails", reflect.TypeOf((*MockFileUserDatabase)(nil).SetUserDetails), username, details)
 }
+
+// UpdateUser mocks base method.
+func (m *MockFileUserDatabase) UpdateUser(details FileUserDatabaseUserDetails) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", details)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockFileUserDatabaseMockRecorder) UpdateUser(details any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockFileUserDatabase)(nil).UpdateUser), details)
+}
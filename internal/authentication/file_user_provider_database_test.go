@@ -7,6 +7,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
 )
 
 func TestDatabaseModel_Read(t *testing.T) {
@@ -37,3 +40,114 @@ func TestDatabaseModel_Read(t *testing.T) {
 
 	assert.EqualError(t, model.Read(f), "could not parse the YAML database: yaml: line 2: found character that cannot start any token")
 }
+
+func TestFileUserDatabase_ShouldManageUsers(t *testing.T) {
+	dir := t.TempDir()
+
+	f := filepath.Join(dir, "users.yml")
+
+	require.NoError(t, os.WriteFile(f, UserDatabaseContent, 0600))
+
+	db := NewFileUserDatabase(f, true, false)
+
+	require.NoError(t, db.Load())
+
+	digest, err := schema.DecodePasswordDigest("$argon2id$v=19$m=65536,t=3,p=2$BpLnfgDsc2WD8F2q$o/vzA4myCqZZ36bUGsDY//8mKUYNZZaR0t4MFFSs+iM")
+	require.NoError(t, err)
+
+	alice := FileUserDatabaseUserDetails{
+		Username:    "alice",
+		Password:    digest,
+		DisplayName: "Alice",
+		Email:       "alice@authelia.com",
+		Groups:      []string{"dev"},
+		GivenName:   "Alice",
+		Locale:      "en-GB",
+		Extra:       map[string]any{"department": "Engineering"},
+	}
+
+	require.NoError(t, db.CreateUser(alice))
+	assert.ErrorIs(t, db.CreateUser(alice), ErrUserExists)
+
+	details, err := db.GetUserDetails("alice@authelia.com")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", details.Username)
+
+	alice.Email = "john.doe@authelia.com"
+
+	assert.ErrorContains(t, db.UpdateUser(alice), "error loading authentication database: email 'john.doe@authelia.com' is configured for for more than one user")
+
+	details, err = db.GetUserDetails("alice@authelia.com")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", details.Username)
+
+	alice.Email = "alice.smith@authelia.com"
+	alice.Groups = []string{"admins"}
+
+	require.NoError(t, db.UpdateUser(alice))
+	require.NoError(t, db.DisableUser("alice", true))
+
+	_, err = db.GetUserDetails("alice@authelia.com")
+	assert.ErrorIs(t, err, ErrUserNotFound)
+
+	details, err = db.GetUserDetails("alice.smith@authelia.com")
+	require.NoError(t, err)
+	assert.True(t, details.Disabled)
+
+	require.NoError(t, db.Save())
+
+	db = NewFileUserDatabase(f, true, false)
+
+	require.NoError(t, db.Load())
+
+	details, err = db.GetUserDetails("alice.smith@authelia.com")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admins"}, details.Groups)
+	assert.Equal(t, map[string]any{"department": "Engineering"}, details.Extra)
+	assert.Equal(t, map[string]any{"department": "Engineering"}, details.ToUserDetails().Extra)
+	assert.Equal(t, "Alice", details.ToUserDetails().GivenName)
+	assert.Equal(t, "en-GB", details.Locale)
+	assert.Equal(t, "", details.FamilyName)
+	assert.True(t, details.Disabled)
+
+	require.NoError(t, db.DeleteUser("alice"))
+	assert.ErrorIs(t, db.DeleteUser("alice"), ErrUserNotFound)
+	assert.ErrorIs(t, db.DisableUser("alice", false), ErrUserNotFound)
+	assert.ErrorIs(t, db.UpdateUser(alice), ErrUserNotFound)
+
+	users := db.ListUsers()
+
+	require.Len(t, users, 6)
+	assert.Equal(t, "bob", users[0].Username)
+	assert.Equal(t, "john", users[5].Username)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestFileUserDatabaseUserDetails_Validate(t *testing.T) {
+	digest, err := schema.DecodePasswordDigest("$plaintext$example")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		have     FileUserDatabaseUserDetails
+		expected string
+	}{
+		{"ShouldPass", FileUserDatabaseUserDetails{Username: "john", Password: digest, DisplayName: "John"}, ""},
+		{"ShouldFailNoUsername", FileUserDatabaseUserDetails{Password: digest, DisplayName: "John"}, "the username must not be empty"},
+		{"ShouldFailNoPassword", FileUserDatabaseUserDetails{Username: "john", DisplayName: "John"}, "the password for user 'john' must be set"},
+		{"ShouldFailNoDisplayName", FileUserDatabaseUserDetails{Username: "john", Password: digest}, "the display name for user 'john' must not be empty"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expected == "" {
+				assert.NoError(t, tc.have.Validate())
+			} else {
+				assert.EqualError(t, tc.have.Validate(), tc.expected)
+			}
+		})
+	}
+}
@@ -271,7 +271,7 @@ func TestShouldErrOnUserDetailsNoUser(t *testing.T) {
 
 		details, err = provider.GetDetails("dis")
 		assert.Nil(t, details)
-		assert.Equal(t, err, ErrUserNotFound)
+		assert.Equal(t, err, ErrUserDisabled)
 	})
 }
 
@@ -369,7 +369,7 @@ func TestShouldErrOnUpdatePasswordNoUser(t *testing.T) {
 		assert.NoError(t, provider.StartupCheck())
 
 		assert.Equal(t, provider.UpdatePassword("nousers", "newpassword"), ErrUserNotFound)
-		assert.Equal(t, provider.UpdatePassword("dis", "example"), ErrUserNotFound)
+		assert.Equal(t, provider.UpdatePassword("dis", "example"), ErrUserDisabled)
 	})
 }
 
@@ -467,7 +467,7 @@ func TestShouldNotAllowLoginOfDisabledUsers(t *testing.T) {
 		ok, err := provider.CheckUserPassword("dis", "password")
 
 		assert.False(t, ok)
-		assert.EqualError(t, err, "user not found")
+		assert.EqualError(t, err, "user account is disabled")
 	})
 }
 
@@ -110,6 +110,10 @@ func (p *LDAPUserProvider) CheckUserPassword(username string, password string) (
 		return false, err
 	}
 
+	if profile.Disabled {
+		return false, ErrUserDisabled
+	}
+
 	if clientUser, err = p.connectCustom(p.config.Address.String(), profile.DN, password, p.config.StartTLS, p.dialOpts...); err != nil {
 		return false, fmt.Errorf("authentication failed. Cause: %w", err)
 	}
@@ -136,6 +140,10 @@ func (p *LDAPUserProvider) GetDetails(username string) (details *UserDetails, er
 		return nil, err
 	}
 
+	if profile.Disabled {
+		return nil, ErrUserDisabled
+	}
+
 	var (
 		groups []string
 	)
@@ -149,6 +157,12 @@ func (p *LDAPUserProvider) GetDetails(username string) (details *UserDetails, er
 		DisplayName: profile.DisplayName,
 		Emails:      profile.Emails,
 		Groups:      groups,
+		GivenName:   profile.GivenName,
+		FamilyName:  profile.FamilyName,
+		PhoneNumber: profile.PhoneNumber,
+		Locale:      profile.Locale,
+		Picture:     profile.Picture,
+		Extra:       profile.Extra,
 	}, nil
 }
 
@@ -169,6 +183,10 @@ func (p *LDAPUserProvider) UpdatePassword(username, password string) (err error)
 		return fmt.Errorf("unable to update password. Cause: %w", err)
 	}
 
+	if profile.Disabled {
+		return fmt.Errorf("unable to update password. Cause: %w", ErrUserDisabled)
+	}
+
 	var controls []ldap.Control
 
 	switch {
@@ -334,6 +352,41 @@ func (p *LDAPUserProvider) getUserProfile(client LDAPClient, username string) (p
 	return p.getUserProfileResultToProfile(username, result)
 }
 
+// getUserProfileResultToProfileAttributes sets the optional profile attributes and extra attributes which are sourced
+// from the provided attribute. The attribute must have at least one value.
+func (p *LDAPUserProvider) getUserProfileResultToProfileAttributes(profile *ldapUserProfile, attr *ldap.EntryAttribute) {
+	for _, attribute := range []struct {
+		name  string
+		value *string
+	}{
+		{p.config.Attributes.GivenName, &profile.GivenName},
+		{p.config.Attributes.FamilyName, &profile.FamilyName},
+		{p.config.Attributes.PhoneNumber, &profile.PhoneNumber},
+		{p.config.Attributes.Locale, &profile.Locale},
+		{p.config.Attributes.Picture, &profile.Picture},
+	} {
+		if len(attribute.name) != 0 && strings.EqualFold(attr.Name, attribute.name) {
+			*attribute.value = attr.Values[0]
+		}
+	}
+
+	for name, attribute := range p.config.Attributes.Extra {
+		if !strings.EqualFold(attr.Name, attribute.Name) {
+			continue
+		}
+
+		if profile.Extra == nil {
+			profile.Extra = map[string]any{}
+		}
+
+		if attribute.MultiValued {
+			profile.Extra[name] = attr.Values
+		} else {
+			profile.Extra[name] = attr.Values[0]
+		}
+	}
+}
+
 //nolint:gocyclo // Not overly complex.
 func (p *LDAPUserProvider) getUserProfileResultToProfile(username string, result *ldap.SearchResult) (profile *ldapUserProfile, err error) {
 	userProfile := ldapUserProfile{
@@ -381,7 +434,34 @@ func (p *LDAPUserProvider) getUserProfileResultToProfile(username string, result
 			}
 
 			userProfile.MemberOf = attr.Values
+		case strings.ToLower(p.config.Attributes.UserAccountControl):
+			if attrs == 0 {
+				continue
+			}
+
+			var flags int64
+
+			if flags, err = strconv.ParseInt(attr.Values[0], 10, 64); err != nil {
+				return nil, fmt.Errorf("user '%s' has an invalid value for attribute '%s': %w",
+					username, p.config.Attributes.UserAccountControl, err)
+			}
+
+			if flags&(ldapUserAccountControlAccountDisable|ldapUserAccountControlLockout) != 0 {
+				userProfile.Disabled = true
+			}
+		case strings.ToLower(p.config.Attributes.AccountLockedTime):
+			if attrs == 0 || attr.Values[0] == "" {
+				continue
+			}
+
+			userProfile.Disabled = true
 		}
+
+		if attrs == 0 {
+			continue
+		}
+
+		p.getUserProfileResultToProfileAttributes(&userProfile, attr)
 	}
 
 	if userProfile.Username == "" {
@@ -2,6 +2,7 @@ package authentication
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/go-ldap/ldap/v3"
@@ -111,6 +112,34 @@ func (p *LDAPUserProvider) parseDynamicUsersConfiguration() {
 		}
 	}
 
+	if len(p.config.Attributes.UserAccountControl) != 0 && !utils.IsStringInSlice(p.config.Attributes.UserAccountControl, p.usersAttributes) {
+		p.usersAttributes = append(p.usersAttributes, p.config.Attributes.UserAccountControl)
+	}
+
+	if len(p.config.Attributes.AccountLockedTime) != 0 && !utils.IsStringInSlice(p.config.Attributes.AccountLockedTime, p.usersAttributes) {
+		p.usersAttributes = append(p.usersAttributes, p.config.Attributes.AccountLockedTime)
+	}
+
+	for _, attribute := range []string{p.config.Attributes.GivenName, p.config.Attributes.FamilyName, p.config.Attributes.PhoneNumber, p.config.Attributes.Locale, p.config.Attributes.Picture} {
+		if len(attribute) != 0 && !utils.IsStringInSlice(attribute, p.usersAttributes) {
+			p.usersAttributes = append(p.usersAttributes, attribute)
+		}
+	}
+
+	names := make([]string, 0, len(p.config.Attributes.Extra))
+
+	for name := range p.config.Attributes.Extra {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if attribute := p.config.Attributes.Extra[name].Name; len(attribute) != 0 && !utils.IsStringInSlice(attribute, p.usersAttributes) {
+			p.usersAttributes = append(p.usersAttributes, attribute)
+		}
+	}
+
 	if p.config.AdditionalUsersDN != "" {
 		p.usersBaseDN = p.config.AdditionalUsersDN + "," + p.config.BaseDN
 	} else {
@@ -5412,3 +5412,242 @@ func TestShouldReturnLDAPSAlreadySecuredWhenStartTLSAttempted(t *testing.T) {
 	_, err := provider.GetDetails("john")
 	assert.EqualError(t, err, "starttls failed with error: LDAP Result Code 200 \"Network Error\": ldap: already encrypted")
 }
+
+func TestLDAPUserProvider_GetDetails_ShouldReturnDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFactory := NewMockLDAPClientFactory(ctrl)
+	mockClient := NewMockLDAPClient(ctrl)
+
+	provider := NewLDAPUserProviderWithFactory(
+		schema.AuthenticationBackendLDAP{
+			Address:  testLDAPAddress,
+			User:     "cn=admin,dc=example,dc=com",
+			Password: "password",
+			Attributes: schema.AuthenticationBackendLDAPAttributes{
+				Username:           "sAMAccountName",
+				Mail:               "mail",
+				DisplayName:        "displayName",
+				UserAccountControl: "userAccountControl",
+			},
+			UsersFilter:       "sAMAccountName={input}",
+			AdditionalUsersDN: "ou=users",
+			BaseDN:            "dc=example,dc=com",
+		},
+		false,
+		nil,
+		mockFactory)
+
+	assert.Equal(t, []string{"sAMAccountName", "mail", "displayName", "userAccountControl"}, provider.usersAttributes)
+
+	dialURL := mockFactory.EXPECT().
+		DialURL(gomock.Eq("ldap://127.0.0.1:389"), gomock.Any()).
+		Return(mockClient, nil)
+
+	connBind := mockClient.EXPECT().
+		Bind(gomock.Eq("cn=admin,dc=example,dc=com"), gomock.Eq("password")).
+		Return(nil)
+
+	connClose := mockClient.EXPECT().Close()
+
+	searchProfile := mockClient.EXPECT().
+		Search(gomock.Any()).
+		Return(&ldap.SearchResult{
+			Entries: []*ldap.Entry{
+				{
+					DN: "CN=John,OU=users,DC=example,DC=com",
+					Attributes: []*ldap.EntryAttribute{
+						{
+							Name:   "sAMAccountName",
+							Values: []string{"john"},
+						},
+						{
+							Name:   "userAccountControl",
+							Values: []string{"514"},
+						},
+					},
+				},
+			},
+		}, nil)
+
+	gomock.InOrder(dialURL, connBind, searchProfile, connClose)
+
+	details, err := provider.GetDetails("john")
+	assert.Nil(t, details)
+	assert.ErrorIs(t, err, ErrUserDisabled)
+}
+
+func TestLDAPUserProvider_getUserProfileResultToProfile_ShouldParseDisabled(t *testing.T) {
+	testCases := []struct {
+		name     string
+		attr     *ldap.EntryAttribute
+		expected bool
+		err      string
+	}{
+		{
+			name:     "ShouldParseNormalAccount",
+			attr:     &ldap.EntryAttribute{Name: "userAccountControl", Values: []string{"512"}},
+			expected: false,
+		},
+		{
+			name:     "ShouldParseDisabledAccount",
+			attr:     &ldap.EntryAttribute{Name: "userAccountControl", Values: []string{"514"}},
+			expected: true,
+		},
+		{
+			name:     "ShouldParseLockedAccount",
+			attr:     &ldap.EntryAttribute{Name: "userAccountControl", Values: []string{"528"}},
+			expected: true,
+		},
+		{
+			name: "ShouldErrorInvalidAccountControl",
+			attr: &ldap.EntryAttribute{Name: "userAccountControl", Values: []string{"abc"}},
+			err:  "user 'john' has an invalid value for attribute 'userAccountControl': strconv.ParseInt: parsing \"abc\": invalid syntax",
+		},
+		{
+			name:     "ShouldParseLockedTime",
+			attr:     &ldap.EntryAttribute{Name: "pwdAccountLockedTime", Values: []string{"000001010000Z"}},
+			expected: true,
+		},
+		{
+			name:     "ShouldIgnoreEmptyLockedTime",
+			attr:     &ldap.EntryAttribute{Name: "pwdAccountLockedTime"},
+			expected: false,
+		},
+	}
+
+	provider := NewLDAPUserProviderWithFactory(
+		schema.AuthenticationBackendLDAP{
+			Address: testLDAPAddress,
+			Attributes: schema.AuthenticationBackendLDAPAttributes{
+				Username:           "uid",
+				UserAccountControl: "userAccountControl",
+				AccountLockedTime:  "pwdAccountLockedTime",
+			},
+		},
+		false,
+		nil,
+		nil)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := &ldap.SearchResult{
+				Entries: []*ldap.Entry{
+					{
+						DN: "uid=john,dc=example,dc=com",
+						Attributes: []*ldap.EntryAttribute{
+							{Name: "uid", Values: []string{"john"}},
+							tc.attr,
+						},
+					},
+				},
+			}
+
+			profile, err := provider.getUserProfileResultToProfile("john", result)
+
+			if tc.err != "" {
+				assert.Nil(t, profile)
+				assert.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expected, profile.Disabled)
+			}
+		})
+	}
+}
+
+func TestLDAPUserProvider_getUserProfileResultToProfile_ShouldParseExtra(t *testing.T) {
+	provider := NewLDAPUserProviderWithFactory(
+		schema.AuthenticationBackendLDAP{
+			Address: testLDAPAddress,
+			Attributes: schema.AuthenticationBackendLDAPAttributes{
+				Username: "uid",
+				Mail:     "mail",
+				Extra: map[string]schema.AuthenticationBackendLDAPAttributesAttribute{
+					"department":  {Name: "departmentNumber"},
+					"employee_id": {Name: "employeeNumber"},
+					"aliases":     {Name: "mail", MultiValued: true},
+					"missing":     {Name: "missingAttribute"},
+					"locale":      {Name: "preferredLanguage", MultiValued: true},
+				},
+			},
+		},
+		false,
+		nil,
+		nil)
+
+	assert.Equal(t, []string{"uid", "mail", "departmentNumber", "employeeNumber", "preferredLanguage", "missingAttribute"}, provider.usersAttributes)
+
+	result := &ldap.SearchResult{
+		Entries: []*ldap.Entry{
+			{
+				DN: "uid=john,dc=example,dc=com",
+				Attributes: []*ldap.EntryAttribute{
+					{Name: "uid", Values: []string{"john"}},
+					{Name: "mail", Values: []string{"john@example.com", "j.smith@example.com"}},
+					{Name: "DepartmentNumber", Values: []string{"Engineering", "Sales"}},
+					{Name: "employeeNumber"},
+					{Name: "preferredLanguage", Values: []string{"en"}},
+				},
+			},
+		},
+	}
+
+	profile, err := provider.getUserProfileResultToProfile("john", result)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"john@example.com", "j.smith@example.com"}, profile.Emails)
+	assert.Equal(t, map[string]any{
+		"department": "Engineering",
+		"aliases":    []string{"john@example.com", "j.smith@example.com"},
+		"locale":     []string{"en"},
+	}, profile.Extra)
+}
+
+func TestLDAPUserProvider_getUserProfileResultToProfile_ShouldParseAttributes(t *testing.T) {
+	provider := NewLDAPUserProviderWithFactory(
+		schema.AuthenticationBackendLDAP{
+			Address: testLDAPAddress,
+			Attributes: schema.AuthenticationBackendLDAPAttributes{
+				Username:    "uid",
+				DisplayName: "cn",
+				GivenName:   "givenName",
+				FamilyName:  "sn",
+				PhoneNumber: "telephoneNumber",
+				Locale:      "preferredLanguage",
+				Picture:     "cn",
+			},
+		},
+		false,
+		nil,
+		nil)
+
+	assert.Equal(t, []string{"uid", "cn", "givenName", "sn", "telephoneNumber", "preferredLanguage"}, provider.usersAttributes)
+
+	result := &ldap.SearchResult{
+		Entries: []*ldap.Entry{
+			{
+				DN: "uid=john,dc=example,dc=com",
+				Attributes: []*ldap.EntryAttribute{
+					{Name: "uid", Values: []string{"john"}},
+					{Name: "cn", Values: []string{"John Smith"}},
+					{Name: "GivenName", Values: []string{"John", "Johnny"}},
+					{Name: "sn", Values: []string{"Smith"}},
+					{Name: "telephoneNumber"},
+					{Name: "preferredLanguage", Values: []string{"en-AU"}},
+				},
+			},
+		},
+	}
+
+	profile, err := provider.getUserProfileResultToProfile("john", result)
+	require.NoError(t, err)
+
+	assert.Equal(t, "John Smith", profile.DisplayName)
+	assert.Equal(t, "John", profile.GivenName)
+	assert.Equal(t, "Smith", profile.FamilyName)
+	assert.Equal(t, "", profile.PhoneNumber)
+	assert.Equal(t, "en-AU", profile.Locale)
+	assert.Equal(t, "John Smith", profile.Picture)
+}
@@ -0,0 +1,117 @@
+package authentication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-crypt/crypt/algorithm"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+// SQLUserProviderStorage is a cut down version of the storage.Provider interface with just the methods the
+// SQLUserProvider uses.
+type SQLUserProviderStorage interface {
+	LoadUser(ctx context.Context, username string) (user *model.User, err error)
+	UpdateUserPassword(ctx context.Context, username, password string, changedAt time.Time) (err error)
+}
+
+// SQLUserProvider is a provider reading details from the storage database.
+type SQLUserProvider struct {
+	config  *schema.AuthenticationBackendSQL
+	hash    algorithm.Hash
+	storage SQLUserProviderStorage
+	clock   clock.Provider
+}
+
+// NewSQLUserProvider creates a new instance of SQLUserProvider.
+func NewSQLUserProvider(config *schema.AuthenticationBackendSQL, store SQLUserProviderStorage) (provider *SQLUserProvider) {
+	return &SQLUserProvider{
+		config:  config,
+		storage: store,
+		clock:   clock.New(),
+	}
+}
+
+// CheckUserPassword checks if provided password matches for the given user.
+func (p *SQLUserProvider) CheckUserPassword(username string, password string) (match bool, err error) {
+	var user *model.User
+
+	if user, err = p.load(username); err != nil {
+		return false, err
+	}
+
+	var digest *schema.PasswordDigest
+
+	if digest, err = schema.DecodePasswordDigest(user.Password); err != nil {
+		return false, fmt.Errorf("error decoding the password digest for user '%s': %w", username, err)
+	}
+
+	return digest.MatchAdvanced(password)
+}
+
+// GetDetails retrieve the groups a user belongs to.
+func (p *SQLUserProvider) GetDetails(username string) (details *UserDetails, err error) {
+	var user *model.User
+
+	if user, err = p.load(username); err != nil {
+		return nil, err
+	}
+
+	details = &UserDetails{
+		Username:    user.Username,
+		DisplayName: user.DisplayName,
+		Groups:      user.Groups,
+	}
+
+	if user.Email != "" {
+		details.Emails = []string{user.Email}
+	}
+
+	return details, nil
+}
+
+// UpdatePassword update the password of the given user.
+func (p *SQLUserProvider) UpdatePassword(username string, newPassword string) (err error) {
+	if _, err = p.load(username); err != nil {
+		return err
+	}
+
+	var digest algorithm.Digest
+
+	if digest, err = p.hash.Hash(newPassword); err != nil {
+		return err
+	}
+
+	return p.storage.UpdateUserPassword(context.Background(), username, digest.Encode(), p.clock.Now())
+}
+
+// StartupCheck implements the startup check provider interface.
+func (p *SQLUserProvider) StartupCheck() (err error) {
+	if p.hash, err = NewFileCryptoHashFromConfig(p.config.Password); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *SQLUserProvider) load(username string) (user *model.User, err error) {
+	if user, err = p.storage.LoadUser(context.Background(), username); err != nil {
+		if errors.Is(err, storage.ErrNoUser) {
+			return nil, ErrUserNotFound
+		}
+
+		return nil, err
+	}
+
+	if user.Disabled {
+		return nil, ErrUserDisabled
+	}
+
+	return user, nil
+}
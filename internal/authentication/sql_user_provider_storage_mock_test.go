@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/authelia/authelia/v4/internal/authentication (interfaces: SQLUserProviderStorage)
+//
+// Generated by this command:
+//
+//	mockgen -package authentication -destination sql_user_provider_storage_mock_test.go -mock_names SQLUserProviderStorage=MockSQLUserProviderStorage github.com/authelia/authelia/v4/internal/authentication SQLUserProviderStorage
+//
+
+// Package authentication is a generated GoMock package.
+package authentication
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	model "github.com/authelia/authelia/v4/internal/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSQLUserProviderStorage is a mock of SQLUserProviderStorage interface.
+type MockSQLUserProviderStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockSQLUserProviderStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockSQLUserProviderStorageMockRecorder is the mock recorder for MockSQLUserProviderStorage.
+type MockSQLUserProviderStorageMockRecorder struct {
+	mock *MockSQLUserProviderStorage
+}
+
+// NewMockSQLUserProviderStorage creates a new mock instance.
+func NewMockSQLUserProviderStorage(ctrl *gomock.Controller) *MockSQLUserProviderStorage {
+	mock := &MockSQLUserProviderStorage{ctrl: ctrl}
+	mock.recorder = &MockSQLUserProviderStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSQLUserProviderStorage) EXPECT() *MockSQLUserProviderStorageMockRecorder {
+	return m.recorder
+}
+
+// LoadUser mocks base method.
+func (m *MockSQLUserProviderStorage) LoadUser(ctx context.Context, username string) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadUser", ctx, username)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadUser indicates an expected call of LoadUser.
+func (mr *MockSQLUserProviderStorageMockRecorder) LoadUser(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadUser", reflect.TypeOf((*MockSQLUserProviderStorage)(nil).LoadUser), ctx, username)
+}
+
+// UpdateUserPassword mocks base method.
+func (m *MockSQLUserProviderStorage) UpdateUserPassword(ctx context.Context, username, password string, changedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPassword", ctx, username, password, changedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserPassword indicates an expected call of UpdateUserPassword.
+func (mr *MockSQLUserProviderStorageMockRecorder) UpdateUserPassword(ctx, username, password, changedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPassword", reflect.TypeOf((*MockSQLUserProviderStorage)(nil).UpdateUserPassword), ctx, username, password, changedAt)
+}
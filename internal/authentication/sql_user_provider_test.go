@@ -0,0 +1,127 @@
+package authentication
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+const (
+	testSQLUserPasswordDigest = "$argon2id$v=19$m=65536,t=3,p=2$BpLnfgDsc2WD8F2q$o/vzA4myCqZZ36bUGsDY//8mKUYNZZaR0t4MFFSs+iM" //nolint:gosec // This is a test digest for the password 'password'.
+)
+
+func TestSQLUserProviderShouldCheckUserPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mock := NewMockSQLUserProviderStorage(ctrl)
+
+	provider := NewSQLUserProvider(&schema.AuthenticationBackendSQL{Password: schema.DefaultPasswordConfig}, mock)
+
+	require.NoError(t, provider.StartupCheck())
+
+	gomock.InOrder(
+		mock.EXPECT().LoadUser(gomock.Any(), "john").Return(&model.User{Username: "john", Password: testSQLUserPasswordDigest}, nil),
+		mock.EXPECT().LoadUser(gomock.Any(), "john").Return(&model.User{Username: "john", Password: testSQLUserPasswordDigest}, nil),
+	)
+
+	match, err := provider.CheckUserPassword("john", "password")
+
+	assert.NoError(t, err)
+	assert.True(t, match)
+
+	match, err = provider.CheckUserPassword("john", "wrong")
+
+	assert.NoError(t, err)
+	assert.False(t, match)
+}
+
+func TestSQLUserProviderShouldHandleMissingAndDisabledUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mock := NewMockSQLUserProviderStorage(ctrl)
+
+	provider := NewSQLUserProvider(&schema.AuthenticationBackendSQL{Password: schema.DefaultPasswordConfig}, mock)
+
+	require.NoError(t, provider.StartupCheck())
+
+	gomock.InOrder(
+		mock.EXPECT().LoadUser(gomock.Any(), "john").Return(nil, storage.ErrNoUser),
+		mock.EXPECT().LoadUser(gomock.Any(), "harry").Return(&model.User{Username: "harry", Password: testSQLUserPasswordDigest, Disabled: true}, nil),
+		mock.EXPECT().LoadUser(gomock.Any(), "bob").Return(nil, errors.New("bad conn")),
+	)
+
+	match, err := provider.CheckUserPassword("john", "password")
+
+	assert.ErrorIs(t, err, ErrUserNotFound)
+	assert.False(t, match)
+
+	details, err := provider.GetDetails("harry")
+
+	assert.ErrorIs(t, err, ErrUserDisabled)
+	assert.Nil(t, details)
+
+	assert.EqualError(t, provider.UpdatePassword("bob", "password"), "bad conn")
+}
+
+func TestSQLUserProviderShouldGetDetails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mock := NewMockSQLUserProviderStorage(ctrl)
+
+	provider := NewSQLUserProvider(&schema.AuthenticationBackendSQL{Password: schema.DefaultPasswordConfig}, mock)
+
+	mock.EXPECT().LoadUser(gomock.Any(), "john").Return(&model.User{Username: "john", DisplayName: "John Smith", Email: "john@example.com", Groups: []string{"admins", "dev"}}, nil)
+
+	details, err := provider.GetDetails("john")
+
+	require.NoError(t, err)
+	assert.Equal(t, "john", details.Username)
+	assert.Equal(t, "John Smith", details.DisplayName)
+	assert.Equal(t, []string{"john@example.com"}, details.Emails)
+	assert.Equal(t, []string{"admins", "dev"}, details.Groups)
+}
+
+func TestSQLUserProviderShouldUpdatePassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mock := NewMockSQLUserProviderStorage(ctrl)
+
+	provider := NewSQLUserProvider(&schema.AuthenticationBackendSQL{Password: schema.DefaultPasswordConfig}, mock)
+
+	provider.clock = clock.NewFixed(time.Unix(1701295903, 0))
+
+	require.NoError(t, provider.StartupCheck())
+
+	var encoded string
+
+	gomock.InOrder(
+		mock.EXPECT().LoadUser(gomock.Any(), "john").Return(&model.User{Username: "john", Password: testSQLUserPasswordDigest}, nil),
+		mock.EXPECT().UpdateUserPassword(gomock.Any(), "john", gomock.Any(), time.Unix(1701295903, 0)).DoAndReturn(func(_ any, _ string, password string, _ any) error {
+			encoded = password
+
+			return nil
+		}),
+	)
+
+	require.NoError(t, provider.UpdatePassword("john", "newpassword"))
+
+	digest, err := schema.DecodePasswordDigest(encoded)
+
+	require.NoError(t, err)
+
+	match, err := digest.MatchAdvanced("newpassword")
+
+	assert.NoError(t, err)
+	assert.True(t, match)
+}
+
+func TestSQLUserProviderShouldErrorBadPasswordConfig(t *testing.T) {
+	provider := NewSQLUserProvider(&schema.AuthenticationBackendSQL{}, nil)
+
+	assert.EqualError(t, provider.StartupCheck(), "failed to initialize hash settings: argon2 validation error: parameter is invalid: parameter 't' must be between 1 and 2147483647 but is set to '0'")
+}
@@ -57,6 +57,12 @@ type UserDetails struct {
 	DisplayName string
 	Emails      []string
 	Groups      []string
+	GivenName   string
+	FamilyName  string
+	PhoneNumber string
+	Locale      string
+	Picture     string
+	Extra       map[string]any
 }
 
 // Addresses returns the Emails []string as []mail.Address formatted with DisplayName as the Name attribute.
@@ -93,12 +99,43 @@ func (d UserDetails) GetEmails() (emails []string) {
 	return d.Emails
 }
 
+func (d UserDetails) GetGivenName() (name string) {
+	return d.GivenName
+}
+
+func (d UserDetails) GetFamilyName() (name string) {
+	return d.FamilyName
+}
+
+func (d UserDetails) GetPhoneNumber() (number string) {
+	return d.PhoneNumber
+}
+
+func (d UserDetails) GetLocale() (locale string) {
+	return d.Locale
+}
+
+func (d UserDetails) GetPicture() (picture string) {
+	return d.Picture
+}
+
+func (d UserDetails) GetExtra() (extra map[string]any) {
+	return d.Extra
+}
+
 type ldapUserProfile struct {
 	DN          string
 	Emails      []string
 	DisplayName string
 	Username    string
 	MemberOf    []string
+	GivenName   string
+	FamilyName  string
+	PhoneNumber string
+	Locale      string
+	Picture     string
+	Extra       map[string]any
+	Disabled    bool
 }
 
 // LDAPSupportedFeatures represents features which a server may support which are implemented in code.
@@ -0,0 +1,107 @@
+package authorization
+
+import (
+	"net/url"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestNewAccessControlHeaders(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     [][]schema.AccessControlRuleHeader
+		expected []AccessControlHeaders
+	}{
+		{
+			"ShouldSkipInvalidTypeEqual",
+			[][]schema.AccessControlRuleHeader{
+				{
+					{Operator: operatorEqual, Name: "X-Example", Value: 1},
+				},
+			},
+			[]AccessControlHeaders{{Rules: []ObjectMatcher(nil)}},
+		},
+		{
+			"ShouldSkipInvalidTypePattern",
+			[][]schema.AccessControlRuleHeader{
+				{
+					{Operator: operatorPattern, Name: "X-Example", Value: 1},
+				},
+			},
+			[]AccessControlHeaders{{Rules: []ObjectMatcher(nil)}},
+		},
+		{
+			"ShouldSkipInvalidOperator",
+			[][]schema.AccessControlRuleHeader{
+				{
+					{Operator: "nop", Name: "X-Example", Value: 1},
+				},
+			},
+			[]AccessControlHeaders{{Rules: []ObjectMatcher(nil)}},
+		},
+		{
+			"ShouldReturnNilEmpty",
+			nil,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, NewAccessControlHeaders(tc.have))
+		})
+	}
+}
+
+func TestAccessControlHeaders_IsMatch(t *testing.T) {
+	header := &fasthttp.RequestHeader{}
+
+	header.SetUserAgent("Mozilla/5.0 (Android) Nextcloud-android/3.26.0")
+	header.Set("X-Api-Version", "2")
+	header.Add("X-Client-Cert-Subject", "CN=alice")
+	header.Add("X-Client-Cert-Subject", "CN=bob")
+
+	object := NewObject(&url.URL{Scheme: "https", Host: "example.com", Path: "/"}, fasthttp.MethodGet)
+	object.Headers = header
+
+	empty := NewObject(&url.URL{Scheme: "https", Host: "example.com", Path: "/"}, fasthttp.MethodGet)
+
+	testCases := []struct {
+		name     string
+		have     schema.AccessControlRuleHeader
+		expected bool
+		empty    bool
+	}{
+		{"ShouldMatchPresent", schema.AccessControlRuleHeader{Operator: operatorPresent, Name: "x-api-version"}, true, false},
+		{"ShouldMatchPresentUserAgent", schema.AccessControlRuleHeader{Operator: operatorPresent, Name: "User-Agent"}, true, false},
+		{"ShouldNotMatchPresent", schema.AccessControlRuleHeader{Operator: operatorPresent, Name: "X-Other"}, false, false},
+		{"ShouldMatchAbsent", schema.AccessControlRuleHeader{Operator: operatorAbsent, Name: "X-Other"}, true, true},
+		{"ShouldNotMatchAbsent", schema.AccessControlRuleHeader{Operator: operatorAbsent, Name: "X-Api-Version"}, false, true},
+		{"ShouldMatchEqual", schema.AccessControlRuleHeader{Operator: operatorEqual, Name: "X-Api-Version", Value: "2"}, true, false},
+		{"ShouldNotMatchEqual", schema.AccessControlRuleHeader{Operator: operatorEqual, Name: "X-Api-Version", Value: "1"}, false, false},
+		{"ShouldMatchEqualAnyValue", schema.AccessControlRuleHeader{Operator: operatorEqual, Name: "X-Client-Cert-Subject", Value: "CN=bob"}, true, false},
+		{"ShouldMatchNotEqual", schema.AccessControlRuleHeader{Operator: operatorNotEqual, Name: "X-Api-Version", Value: "1"}, true, true},
+		{"ShouldNotMatchNotEqualAnyValue", schema.AccessControlRuleHeader{Operator: operatorNotEqual, Name: "X-Client-Cert-Subject", Value: "CN=alice"}, false, true},
+		{"ShouldMatchPattern", schema.AccessControlRuleHeader{Operator: operatorPattern, Name: "User-Agent", Value: regexp.MustCompile(`Nextcloud-android/3\.`)}, true, false},
+		{"ShouldNotMatchPattern", schema.AccessControlRuleHeader{Operator: operatorPattern, Name: "User-Agent", Value: regexp.MustCompile(`^DAVx5/`)}, false, false},
+		{"ShouldMatchPatternEmpty", schema.AccessControlRuleHeader{Operator: operatorPattern, Name: "X-Other", Value: regexp.MustCompile(`^$`)}, true, true},
+		{"ShouldMatchNotPattern", schema.AccessControlRuleHeader{Operator: operatorNotPattern, Name: "User-Agent", Value: regexp.MustCompile(`^DAVx5/`)}, true, true},
+		{"ShouldNotMatchNotPattern", schema.AccessControlRuleHeader{Operator: operatorNotPattern, Name: "X-Client-Cert-Subject", Value: regexp.MustCompile(`^CN=bob$`)}, false, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rules := NewAccessControlHeaders([][]schema.AccessControlRuleHeader{{tc.have}})
+
+			assert.Len(t, rules, 1)
+			assert.Len(t, rules[0].Rules, 1)
+			assert.Equal(t, tc.expected, rules[0].IsMatch(object))
+			assert.Equal(t, tc.empty, rules[0].IsMatch(empty))
+		})
+	}
+}
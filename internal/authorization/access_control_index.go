@@ -0,0 +1,183 @@
+package authorization
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// NewAccessControlIndex creates a new *AccessControlIndex from a list of rules. Rules which only have literal or
+// wildcard domains are indexed by those domains, and all other rules are candidates for every domain. The candidates
+// of each literal domain and each wildcard domain are merged when the index is created so they don't need to be
+// merged for each request.
+func NewAccessControlIndex(rules []*AccessControlRule) (index *AccessControlIndex) {
+	var (
+		always   []int
+		exact    = map[string][]int{}
+		wildcard = map[string][]int{}
+	)
+
+	for i, rule := range rules {
+		if !isAccessControlRuleIndexable(rule) {
+			always = append(always, i)
+
+			continue
+		}
+
+		for _, domain := range rule.Domains {
+			m := domain.Matcher.(*AccessControlDomainMatcher)
+
+			switch {
+			case m.Wildcard:
+				wildcard[m.Name] = appendAccessControlIndex(wildcard[m.Name], i)
+			default:
+				exact[m.Name] = appendAccessControlIndex(exact[m.Name], i)
+			}
+		}
+	}
+
+	index = &AccessControlIndex{
+		rules:    rules,
+		always:   make([]*AccessControlRule, len(always)),
+		exact:    make(map[string][]*AccessControlRule, len(exact)),
+		wildcard: make(map[string][]*AccessControlRule, len(wildcard)),
+	}
+
+	for i, position := range always {
+		index.always[i] = rules[position]
+	}
+
+	// The suffixes of each literal domain and each wildcard domain are known, so their candidates include the rules of
+	// every wildcard domain which matches them.
+	for name, positions := range exact {
+		index.exact[name] = index.merge(name, always, positions, wildcard)
+	}
+
+	for suffix, positions := range wildcard {
+		index.wildcard[suffix] = index.merge(suffix[1:], always, positions, wildcard)
+	}
+
+	return index
+}
+
+// AccessControlIndex narrows the rules which may match a domain using the literal and wildcard domains of each rule.
+// The candidates are always returned in the order the rules are configured so the first matching rule is unchanged.
+// The returned candidates are shared between requests and must not be modified.
+type AccessControlIndex struct {
+	rules []*AccessControlRule
+
+	// exact is the candidates for each literal domain.
+	exact map[string][]*AccessControlRule
+
+	// wildcard is the candidates for the suffix of each wildcard domain including the leading period.
+	wildcard map[string][]*AccessControlRule
+
+	// always is the rules which can't be indexed and are candidates for every domain.
+	always []*AccessControlRule
+}
+
+// Candidates returns the rules which may match the domain in the order they're configured.
+func (idx *AccessControlIndex) Candidates(domain string) (rules []*AccessControlRule) {
+	if idx == nil {
+		return nil
+	}
+
+	// Literal domains are compared case-insensitively via strings.EqualFold which is only equivalent to a lookup of the
+	// lowercase domain for ASCII, so all rules are candidates for domains which are not ASCII.
+	if !isASCII(domain) {
+		return idx.rules
+	}
+
+	domain = strings.ToLower(domain)
+
+	if rules, ok := idx.exact[domain]; ok {
+		return rules
+	}
+
+	// The candidates of the longest wildcard suffix include the candidates of the shorter wildcard suffixes.
+	for i := 0; i < len(domain); i++ {
+		if domain[i] != '.' {
+			continue
+		}
+
+		if rules, ok := idx.wildcard[domain[i:]]; ok {
+			return rules
+		}
+	}
+
+	return idx.always
+}
+
+// merge returns the candidates of a domain given the position of the rules which always apply, the position of the
+// rules for the domain itself, and the position of the rules for each wildcard suffix.
+func (idx *AccessControlIndex) merge(domain string, always, positions []int, wildcard map[string][]int) (rules []*AccessControlRule) {
+	merged := mergeAccessControlIndex(always, positions)
+
+	for i := 0; i < len(domain); i++ {
+		if domain[i] == '.' {
+			merged = mergeAccessControlIndex(merged, wildcard[domain[i:]])
+		}
+	}
+
+	rules = make([]*AccessControlRule, len(merged))
+
+	for i, position := range merged {
+		rules[i] = idx.rules[position]
+	}
+
+	return rules
+}
+
+// isAccessControlRuleIndexable returns true if the rule has at least one domain and all of the domains are ASCII literal
+// or wildcard domains.
+func isAccessControlRuleIndexable(rule *AccessControlRule) bool {
+	if len(rule.Domains) == 0 {
+		return false
+	}
+
+	for _, domain := range rule.Domains {
+		m, ok := domain.Matcher.(*AccessControlDomainMatcher)
+		if !ok || m.UserWildcard || m.GroupWildcard || !isASCII(m.Name) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func appendAccessControlIndex(positions []int, position int) []int {
+	if n := len(positions); n != 0 && positions[n-1] == position {
+		return positions
+	}
+
+	return append(positions, position)
+}
+
+// mergeAccessControlIndex returns the ordered union of two ordered lists of positions.
+func mergeAccessControlIndex(a, b []int) (positions []int) {
+	positions = make([]int, 0, len(a)+len(b))
+
+	i, j := 0, 0
+
+	for i < len(a) || j < len(b) {
+		switch {
+		case j == len(b) || (i < len(a) && a[i] < b[j]):
+			positions = appendAccessControlIndex(positions, a[i])
+			i++
+		default:
+			positions = appendAccessControlIndex(positions, b[j])
+			j++
+		}
+	}
+
+	return positions
+}
+
+func isASCII(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+
+	return true
+}
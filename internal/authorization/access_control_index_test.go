@@ -0,0 +1,174 @@
+package authorization
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestAccessControlIndex_Candidates(t *testing.T) {
+	rules := NewAccessControlRules(schema.AccessControl{
+		Rules: []schema.AccessControlRule{
+			{Domains: []string{"public.example.com"}, Policy: bypass},
+			{Domains: []string{"*.example.com"}, Policy: oneFactor},
+			{DomainsRegex: []regexp.Regexp{*regexp.MustCompile(`^api\.`)}, Policy: bypass},
+			{Domains: []string{"{user}.home.example.com"}, Policy: twoFactor},
+			{Domains: []string{"Admin.example.com", "*.admin.example.com"}, Policy: twoFactor},
+			{Policy: deny},
+			{Domains: []string{"*.example.com", "example.com"}, Policy: twoFactor},
+		},
+	})
+
+	index := NewAccessControlIndex(rules)
+
+	testCases := []struct {
+		name     string
+		domain   string
+		expected []int
+	}{
+		{"ShouldReturnExactWildcardAndAlways", "public.example.com", []int{1, 2, 3, 4, 6, 7}},
+		{"ShouldReturnExactCaseInsensitive", "ADMIN.example.com", []int{2, 3, 4, 5, 6, 7}},
+		{"ShouldReturnNestedWildcards", "x.admin.example.com", []int{2, 3, 4, 5, 6, 7}},
+		{"ShouldReturnExactWithoutWildcard", "example.com", []int{3, 4, 6, 7}},
+		{"ShouldReturnAlwaysOnly", "example.org", []int{3, 4, 6}},
+		{"ShouldReturnAllNonASCII", "ünicode.example.com", []int{1, 2, 3, 4, 5, 6, 7}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var actual []int
+
+			for _, rule := range index.Candidates(tc.domain) {
+				actual = append(actual, rule.Position)
+			}
+
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+
+	assert.Nil(t, (*AccessControlIndex)(nil).Candidates("example.com"))
+}
+
+func TestAccessControlIndex_CandidatesShouldNotAllocate(t *testing.T) {
+	index := NewAccessControlIndex(NewAccessControlRules(newAccessControlIndexTestConfiguration(50).AccessControl))
+
+	for _, domain := range []string{"app5.example.com", "x.app5.example.com", "other.org"} {
+		assert.Equal(t, float64(0), testing.AllocsPerRun(100, func() {
+			index.Candidates(domain)
+		}), domain)
+	}
+}
+
+func TestAuthorizerIndexShouldMatchLinearEvaluation(t *testing.T) {
+	config := newAccessControlIndexTestConfiguration(200)
+
+	authorizer := NewAuthorizer(config, clock.New())
+
+	subjects := []Subject{
+		{},
+		{Username: "user5", Groups: []string{"group5"}},
+		{Username: "john", Groups: []string{"admins"}},
+	}
+
+	domains := []string{
+		"app0.example.com", "app5.example.com", "APP7.example.com", "x.app9.example.com", "app199.example.com",
+		"user5.home.example.com", "group5.groups.example.com", "api.example.com", "example.com", "other.org",
+	}
+
+	for _, domain := range domains {
+		for _, subject := range subjects {
+			object := NewObject(&url.URL{Scheme: "https", Host: domain, Path: "/admin"}, fasthttp.MethodGet)
+
+			hasSubjects, level := getRequiredLevelLinear(authorizer, subject, object)
+
+			actual := authorizer.GetRequiredPolicy(subject, object)
+
+			assert.Equal(t, hasSubjects, actual.HasSubjects, "domain %s subject %s", domain, subject)
+			assert.Equal(t, level, actual.Level, "domain %s subject %s", domain, subject)
+		}
+	}
+}
+
+func BenchmarkAuthorizerGetRequiredLevel(b *testing.B) {
+	for _, n := range []int{10, 100, 800} {
+		authorizer := NewAuthorizer(newAccessControlIndexTestConfiguration(n), clock.New())
+
+		subject := Subject{Username: "john", Groups: []string{"admins"}}
+		object := NewObject(&url.URL{Scheme: "https", Host: fmt.Sprintf("app%d.example.com", n-1), Path: "/"}, fasthttp.MethodGet)
+
+		b.Run(fmt.Sprintf("Indexed/Rules%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				authorizer.GetRequiredLevel(subject, object)
+			}
+		})
+
+		b.Run(fmt.Sprintf("Linear/Rules%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				getRequiredLevelLinear(authorizer, subject, object)
+			}
+		})
+	}
+}
+
+// getRequiredLevelLinear evaluates every rule in order without the index.
+func getRequiredLevelLinear(authorizer *Authorizer, subject Subject, object Object) (hasSubjects bool, level Level) {
+	defaultPolicy, rules := authorizer.policies()
+
+	now := authorizer.clock.Now()
+
+	for _, rule := range rules {
+		if rule.IsMatch(subject, object, now) {
+			return rule.HasSubjects, rule.Policy
+		}
+	}
+
+	return false, defaultPolicy
+}
+
+func newAccessControlIndexTestConfiguration(n int) *schema.Configuration {
+	rules := []schema.AccessControlRule{
+		{DomainsRegex: []regexp.Regexp{*regexp.MustCompile(`^api\.example\.com$`)}, Policy: bypass},
+		{Domains: []string{"{user}.home.example.com"}, Policy: twoFactor},
+		{Domains: []string{"{group}.groups.example.com"}, Policy: oneFactor},
+	}
+
+	for i := 0; i < n; i++ {
+		rules = append(rules,
+			schema.AccessControlRule{
+				Domains:   []string{fmt.Sprintf("app%d.example.com", i)},
+				Resources: []regexp.Regexp{*regexp.MustCompile(`^/admin([/?].*)?$`)},
+				Subjects:  [][]string{{"group:admins"}},
+				Policy:    twoFactor,
+			},
+			schema.AccessControlRule{
+				Domains:   []string{fmt.Sprintf("*.app%d.example.com", i)},
+				Resources: []regexp.Regexp{*regexp.MustCompile(`^/public([/?].*)?$`)},
+				Policy:    bypass,
+			},
+			schema.AccessControlRule{
+				Domains: []string{fmt.Sprintf("app%d.example.com", i), fmt.Sprintf("*.app%d.example.com", i)},
+				Policy:  oneFactor,
+			},
+		)
+	}
+
+	rules = append(rules, schema.AccessControlRule{Domains: []string{"*.example.com"}, Policy: twoFactor})
+
+	return &schema.Configuration{
+		AccessControl: schema.AccessControl{
+			DefaultPolicy: deny,
+			Rules:         rules,
+		},
+	}
+}
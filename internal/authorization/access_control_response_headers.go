@@ -0,0 +1,33 @@
+package authorization
+
+import (
+	"text/template"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/templates"
+)
+
+// NewAccessControlResponseHeaders parses the schema response headers into a []AccessControlResponseHeader. Headers
+// with an invalid template are logged and skipped as they're expected to be validated beforehand.
+func NewAccessControlResponseHeaders(config []schema.AccessControlRuleResponseHeader) (headers []AccessControlResponseHeader) {
+	for _, header := range config {
+		t, err := templates.ParseStringTemplate(header.Name, header.Value)
+		if err != nil {
+			logging.Logger().WithError(err).Errorf("Error occurred parsing the template of the access control response header '%s', the header will not be included in responses", header.Name)
+
+			continue
+		}
+
+		headers = append(headers, AccessControlResponseHeader{Name: header.Name, Template: t})
+	}
+
+	return headers
+}
+
+// AccessControlResponseHeader is a response header which is rendered from a template when a request which matches the
+// rule is authorized.
+type AccessControlResponseHeader struct {
+	Name     string
+	Template *template.Template
+}
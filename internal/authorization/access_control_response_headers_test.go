@@ -0,0 +1,39 @@
+package authorization
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestNewAccessControlResponseHeaders(t *testing.T) {
+	hook := test.NewGlobal()
+
+	defer hook.Reset()
+
+	headers := NewAccessControlResponseHeaders([]schema.AccessControlRuleResponseHeader{
+		{Name: "Remote-User", Value: "{{ .Username }}"},
+		{Name: "Remote-Invalid", Value: "{{ .Username "},
+		{Name: "Remote-Static", Value: "static"},
+	})
+
+	require.Len(t, headers, 2)
+
+	assert.Equal(t, "Remote-User", headers[0].Name)
+	assert.NotNil(t, headers[0].Template)
+	assert.Equal(t, "Remote-Static", headers[1].Name)
+	assert.NotNil(t, headers[1].Template)
+
+	require.Len(t, hook.AllEntries(), 1)
+
+	entry := hook.LastEntry()
+
+	assert.Equal(t, logrus.ErrorLevel, entry.Level)
+	assert.Equal(t, "Error occurred parsing the template of the access control response header 'Remote-Invalid', the header will not be included in responses", entry.Message)
+	assert.EqualError(t, entry.Data[logrus.ErrorKey].(error), "template: Remote-Invalid:1: unclosed action")
+}
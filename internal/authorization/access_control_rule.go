@@ -2,6 +2,7 @@ package authorization
 
 import (
 	"net"
+	"time"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/utils"
@@ -23,13 +24,25 @@ func NewAccessControlRule(pos int, rule schema.AccessControlRule, networksMap ma
 	r := &AccessControlRule{
 		Position: pos,
 		Query:    NewAccessControlQuery(rule.Query),
+		Headers:  NewAccessControlHeaders(rule.Headers),
 		Methods:  schemaMethodsToACL(rule.Methods),
 		Networks: schemaNetworksToACL(rule.Networks, networksMap, networksCacheMap),
 		Subjects: schemaSubjectsToACL(rule.Subjects),
+		Schedule: NewAccessControlSchedule(rule.Schedule),
 		Policy:   NewLevel(rule.Policy),
+
+		MaxAuthenticationAge: rule.MaxAuthenticationAge,
+		SecondFactorMethods:  rule.SecondFactorMethods,
+		ResponseHeaders:      NewAccessControlResponseHeaders(rule.ResponseHeaders),
+	}
+
+	if rule.Policy == webhook {
+		r.Webhook = NewAccessControlWebhook(rule.Webhook)
 	}
 
-	if len(r.Subjects) != 0 {
+	// The decision of a webhook depends on the subject so anonymous subjects may be able to access the object once
+	// authenticated.
+	if len(r.Subjects) != 0 || r.Webhook != nil {
 		r.HasSubjects = true
 	}
 
@@ -48,14 +61,32 @@ type AccessControlRule struct {
 	Domains   []AccessControlDomain
 	Resources []AccessControlResource
 	Query     []AccessControlQuery
+	Headers   []AccessControlHeaders
 	Methods   []string
 	Networks  []*net.IPNet
 	Subjects  []AccessControlSubjects
+	Schedule  *AccessControlSchedule
 	Policy    Level
+
+	MaxAuthenticationAge time.Duration
+	SecondFactorMethods  []string
+	ResponseHeaders      []AccessControlResponseHeader
+
+	// Webhook decides the required level instead of the Policy when it's not nil.
+	Webhook *AccessControlWebhook
+}
+
+// PolicyName returns the name of the policy of the rule.
+func (acr *AccessControlRule) PolicyName() string {
+	if acr.Webhook != nil {
+		return webhook
+	}
+
+	return acr.Policy.String()
 }
 
-// IsMatch returns true if all elements of an AccessControlRule match the object and subject.
-func (acr *AccessControlRule) IsMatch(subject Subject, object Object) (match bool) {
+// IsMatch returns true if all elements of an AccessControlRule match the object and subject at the given time.
+func (acr *AccessControlRule) IsMatch(subject Subject, object Object, now time.Time) (match bool) {
 	if !acr.MatchesDomains(subject, object) {
 		return false
 	}
@@ -68,6 +99,10 @@ func (acr *AccessControlRule) IsMatch(subject Subject, object Object) (match boo
 		return false
 	}
 
+	if !acr.MatchesHeaders(object) {
+		return false
+	}
+
 	if !acr.MatchesMethods(object) {
 		return false
 	}
@@ -80,6 +115,10 @@ func (acr *AccessControlRule) IsMatch(subject Subject, object Object) (match boo
 		return false
 	}
 
+	if !acr.MatchesSchedule(now) {
+		return false
+	}
+
 	return true
 }
 
@@ -134,6 +173,23 @@ func (acr *AccessControlRule) MatchesQuery(object Object) (match bool) {
 	return false
 }
 
+// MatchesHeaders returns true if the rule matches the request headers.
+func (acr *AccessControlRule) MatchesHeaders(object Object) (match bool) {
+	// If there are no header rules in this rule then the header condition is a match.
+	if len(acr.Headers) == 0 {
+		return true
+	}
+
+	// Iterate over the headers until we find a match (return true) or until we exit the loop (return false).
+	for _, headers := range acr.Headers {
+		if headers.IsMatch(object) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // MatchesMethods returns true if the rule matches the method.
 func (acr *AccessControlRule) MatchesMethods(object Object) (match bool) {
 	// If there are no methods in this rule then the method condition is a match.
@@ -188,3 +244,9 @@ func (acr *AccessControlRule) MatchesSubjectExact(subject Subject) (match bool)
 
 	return false
 }
+
+// MatchesSchedule returns true if the rule matches the schedule at the given time.
+func (acr *AccessControlRule) MatchesSchedule(now time.Time) (match bool) {
+	// If there is no schedule in this rule then the schedule condition is a match.
+	return acr.Schedule.IsMatch(now)
+}
@@ -0,0 +1,210 @@
+package authorization
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// NewAccessControlSchedule creates a new *AccessControlSchedule from a schema.AccessControlRuleSchedule. If the schedule
+// has no windows it returns nil which always matches.
+func NewAccessControlSchedule(config schema.AccessControlRuleSchedule) (schedule *AccessControlSchedule) {
+	if len(config.Windows) == 0 {
+		return nil
+	}
+
+	location, err := time.LoadLocation(config.TimeZone)
+	if err != nil {
+		location = time.UTC
+	}
+
+	schedule = &AccessControlSchedule{
+		Location: location,
+		Windows:  make([]AccessControlScheduleWindow, 0, len(config.Windows)),
+	}
+
+	for _, w := range config.Windows {
+		window := AccessControlScheduleWindow{
+			Start: 0,
+			End:   minutesPerDay,
+		}
+
+		for _, day := range w.Days {
+			if weekday, err := ParseScheduleWeekday(day); err == nil {
+				window.Days = append(window.Days, weekday)
+			}
+		}
+
+		if w.Start != "" {
+			window.Start, _ = ParseScheduleTimeOfDay(w.Start)
+		}
+
+		if w.End != "" {
+			window.End, _ = ParseScheduleTimeOfDay(w.End)
+		}
+
+		if w.NotBefore != "" {
+			window.NotBefore, _ = ParseScheduleDateTime(w.NotBefore, location)
+		}
+
+		if w.NotAfter != "" {
+			window.NotAfter, _ = ParseScheduleDateTime(w.NotAfter, location)
+		}
+
+		schedule.Windows = append(schedule.Windows, window)
+	}
+
+	return schedule
+}
+
+// AccessControlSchedule represents the time windows an ACL rule applies during.
+type AccessControlSchedule struct {
+	Location *time.Location
+	Windows  []AccessControlScheduleWindow
+}
+
+// IsMatch returns true if the time is within any of the windows of this schedule.
+func (s *AccessControlSchedule) IsMatch(now time.Time) (match bool) {
+	if s == nil {
+		return true
+	}
+
+	now = now.In(s.Location)
+
+	for _, window := range s.Windows {
+		if window.IsMatch(now) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String returns a string representation of the schedule.
+func (s *AccessControlSchedule) String() string {
+	if s == nil {
+		return ""
+	}
+
+	windows := make([]string, len(s.Windows))
+
+	for i, window := range s.Windows {
+		windows[i] = window.String()
+	}
+
+	return fmt.Sprintf("%s (%s)", strings.Join(windows, ", "), s.Location)
+}
+
+// AccessControlScheduleWindow represents a single time window within an AccessControlSchedule. The Start and End are
+// the number of minutes since midnight. If the Start is after the End the window wraps past midnight, and the Days
+// refer to the day the window starts on.
+type AccessControlScheduleWindow struct {
+	Days      []time.Weekday
+	Start     int
+	End       int
+	NotBefore time.Time
+	NotAfter  time.Time
+}
+
+// IsMatch returns true if the time, which must already be in the location of the schedule, is within this window.
+func (w AccessControlScheduleWindow) IsMatch(now time.Time) (match bool) {
+	if !w.NotBefore.IsZero() && now.Before(w.NotBefore) {
+		return false
+	}
+
+	if !w.NotAfter.IsZero() && now.After(w.NotAfter) {
+		return false
+	}
+
+	minute := now.Hour()*60 + now.Minute()
+
+	switch {
+	case w.Start <= w.End:
+		return minute >= w.Start && minute < w.End && w.matchesDay(now.Weekday())
+	case minute >= w.Start:
+		return w.matchesDay(now.Weekday())
+	case minute < w.End:
+		return w.matchesDay((now.Weekday() + 6) % 7)
+	default:
+		return false
+	}
+}
+
+func (w AccessControlScheduleWindow) matchesDay(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+
+	for _, d := range w.Days {
+		if d == day {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String returns a string representation of the window.
+func (w AccessControlScheduleWindow) String() string {
+	days := "every day"
+
+	if len(w.Days) != 0 {
+		names := make([]string, len(w.Days))
+
+		for i, day := range w.Days {
+			names[i] = day.String()[:3]
+		}
+
+		days = strings.Join(names, "/")
+	}
+
+	return fmt.Sprintf("%s %02d:%02d-%02d:%02d", days, w.Start/60, w.Start%60, w.End/60, w.End%60)
+}
+
+// ParseScheduleWeekday parses the case-insensitive english name of a day of the week.
+func ParseScheduleWeekday(value string) (weekday time.Weekday, err error) {
+	for weekday = time.Sunday; weekday <= time.Saturday; weekday++ {
+		if strings.EqualFold(value, weekday.String()) {
+			return weekday, nil
+		}
+	}
+
+	return time.Sunday, fmt.Errorf("'%s' is not a known day of the week", value)
+}
+
+// ParseScheduleTimeOfDay parses a time of day in the HH:MM format between 00:00 and 24:00 inclusive and returns the
+// number of minutes since midnight.
+func ParseScheduleTimeOfDay(value string) (minutes int, err error) {
+	hh, mm, ok := strings.Cut(value, ":")
+	if !ok || len(hh) != 2 || len(mm) != 2 {
+		return 0, fmt.Errorf("'%s' is not in the HH:MM format", value)
+	}
+
+	var hours int
+
+	if hours, err = strconv.Atoi(hh); err != nil {
+		return 0, fmt.Errorf("'%s' is not in the HH:MM format", value)
+	}
+
+	if minutes, err = strconv.Atoi(mm); err != nil {
+		return 0, fmt.Errorf("'%s' is not in the HH:MM format", value)
+	}
+
+	if hours < 0 || minutes < 0 || minutes > 59 || hours > 24 || (hours == 24 && minutes != 0) {
+		return 0, fmt.Errorf("'%s' is not a time between 00:00 and 24:00", value)
+	}
+
+	return hours*60 + minutes, nil
+}
+
+// ParseScheduleDateTime parses a date and time in the YYYY-MM-DD HH:MM format in the given location.
+func ParseScheduleDateTime(value string, location *time.Location) (t time.Time, err error) {
+	if t, err = time.ParseInLocation(layoutScheduleDateTime, value, location); err != nil {
+		return time.Time{}, fmt.Errorf("'%s' is not in the YYYY-MM-DD HH:MM format", value)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,166 @@
+package authorization
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestNewAccessControlSchedule(t *testing.T) {
+	assert.Nil(t, NewAccessControlSchedule(schema.AccessControlRuleSchedule{TimeZone: "UTC"}))
+	assert.True(t, NewAccessControlSchedule(schema.AccessControlRuleSchedule{}).IsMatch(time.Now()))
+
+	schedule := NewAccessControlSchedule(schema.AccessControlRuleSchedule{
+		TimeZone: "Australia/Melbourne",
+		Windows: []schema.AccessControlRuleScheduleWindow{
+			{Days: []string{"monday", "Friday"}, Start: "09:00", End: "17:30", NotAfter: "2024-12-31 23:59"},
+		},
+	})
+
+	require.NotNil(t, schedule)
+	require.Len(t, schedule.Windows, 1)
+
+	assert.Equal(t, "Australia/Melbourne", schedule.Location.String())
+	assert.Equal(t, []time.Weekday{time.Monday, time.Friday}, schedule.Windows[0].Days)
+	assert.Equal(t, 9*60, schedule.Windows[0].Start)
+	assert.Equal(t, 17*60+30, schedule.Windows[0].End)
+	assert.True(t, schedule.Windows[0].NotBefore.IsZero())
+	assert.Equal(t, time.Date(2024, 12, 31, 23, 59, 0, 0, schedule.Location), schedule.Windows[0].NotAfter)
+	assert.Equal(t, "Mon/Fri 09:00-17:30 (Australia/Melbourne)", schedule.String())
+
+	schedule = NewAccessControlSchedule(schema.AccessControlRuleSchedule{
+		Windows: []schema.AccessControlRuleScheduleWindow{{}},
+	})
+
+	require.NotNil(t, schedule)
+	assert.Equal(t, time.UTC, schedule.Location)
+	assert.Equal(t, "every day 00:00-24:00 (UTC)", schedule.String())
+}
+
+func TestAccessControlSchedule_IsMatch(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     schema.AccessControlRuleSchedule
+		time     time.Time
+		expected bool
+	}{
+		{
+			"ShouldMatchBusinessHours",
+			schema.AccessControlRuleSchedule{Windows: []schema.AccessControlRuleScheduleWindow{{Days: []string{"monday", "tuesday", "wednesday", "thursday", "friday"}, Start: "08:00", End: "18:00"}}},
+			time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC),
+			true,
+		},
+		{
+			"ShouldNotMatchBusinessHoursEnd",
+			schema.AccessControlRuleSchedule{Windows: []schema.AccessControlRuleScheduleWindow{{Days: []string{"monday", "tuesday", "wednesday", "thursday", "friday"}, Start: "08:00", End: "18:00"}}},
+			time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC),
+			false,
+		},
+		{
+			"ShouldNotMatchBusinessHoursWeekend",
+			schema.AccessControlRuleSchedule{Windows: []schema.AccessControlRuleScheduleWindow{{Days: []string{"monday", "tuesday", "wednesday", "thursday", "friday"}, Start: "08:00", End: "18:00"}}},
+			time.Date(2024, 1, 6, 12, 0, 0, 0, time.UTC),
+			false,
+		},
+		{
+			"ShouldMatchTimeZone",
+			schema.AccessControlRuleSchedule{TimeZone: "America/New_York", Windows: []schema.AccessControlRuleScheduleWindow{{Start: "08:00", End: "18:00"}}},
+			time.Date(2024, 1, 1, 22, 0, 0, 0, time.UTC),
+			true,
+		},
+		{
+			"ShouldNotMatchTimeZone",
+			schema.AccessControlRuleSchedule{TimeZone: "America/New_York", Windows: []schema.AccessControlRuleScheduleWindow{{Start: "08:00", End: "18:00"}}},
+			time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+			false,
+		},
+		{
+			"ShouldMatchOvernightStartDay",
+			schema.AccessControlRuleSchedule{Windows: []schema.AccessControlRuleScheduleWindow{{Days: []string{"saturday"}, Start: "22:00", End: "02:00"}}},
+			time.Date(2024, 1, 6, 23, 0, 0, 0, time.UTC),
+			true,
+		},
+		{
+			"ShouldMatchOvernightFollowingDay",
+			schema.AccessControlRuleSchedule{Windows: []schema.AccessControlRuleScheduleWindow{{Days: []string{"saturday"}, Start: "22:00", End: "02:00"}}},
+			time.Date(2024, 1, 7, 1, 59, 0, 0, time.UTC),
+			true,
+		},
+		{
+			"ShouldNotMatchOvernightWrongDay",
+			schema.AccessControlRuleSchedule{Windows: []schema.AccessControlRuleScheduleWindow{{Days: []string{"saturday"}, Start: "22:00", End: "02:00"}}},
+			time.Date(2024, 1, 6, 1, 0, 0, 0, time.UTC),
+			false,
+		},
+		{
+			"ShouldNotMatchOvernightGap",
+			schema.AccessControlRuleSchedule{Windows: []schema.AccessControlRuleScheduleWindow{{Start: "22:00", End: "02:00"}}},
+			time.Date(2024, 1, 6, 12, 0, 0, 0, time.UTC),
+			false,
+		},
+		{
+			"ShouldNotMatchBeforeNotBefore",
+			schema.AccessControlRuleSchedule{Windows: []schema.AccessControlRuleScheduleWindow{{NotBefore: "2024-01-06 22:00", NotAfter: "2024-01-07 02:00"}}},
+			time.Date(2024, 1, 6, 21, 59, 0, 0, time.UTC),
+			false,
+		},
+		{
+			"ShouldMatchBetweenNotBeforeNotAfter",
+			schema.AccessControlRuleSchedule{Windows: []schema.AccessControlRuleScheduleWindow{{NotBefore: "2024-01-06 22:00", NotAfter: "2024-01-07 02:00"}}},
+			time.Date(2024, 1, 7, 0, 30, 0, 0, time.UTC),
+			true,
+		},
+		{
+			"ShouldNotMatchAfterNotAfter",
+			schema.AccessControlRuleSchedule{Windows: []schema.AccessControlRuleScheduleWindow{{NotBefore: "2024-01-06 22:00", NotAfter: "2024-01-07 02:00"}}},
+			time.Date(2024, 1, 7, 2, 1, 0, 0, time.UTC),
+			false,
+		},
+		{
+			"ShouldMatchAnyWindow",
+			schema.AccessControlRuleSchedule{Windows: []schema.AccessControlRuleScheduleWindow{{Start: "01:00", End: "02:00"}, {Start: "03:00", End: "04:00"}}},
+			time.Date(2024, 1, 7, 3, 30, 0, 0, time.UTC),
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, NewAccessControlSchedule(tc.have).IsMatch(tc.time))
+		})
+	}
+}
+
+func TestParseScheduleTimeOfDay(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected int
+		err      string
+	}{
+		{"ShouldParseMidnight", "00:00", 0, ""},
+		{"ShouldParseEndOfDay", "24:00", 1440, ""},
+		{"ShouldParseAfternoon", "17:45", 1065, ""},
+		{"ShouldErrorPastEndOfDay", "24:01", 0, "'24:01' is not a time between 00:00 and 24:00"},
+		{"ShouldErrorMinutes", "10:60", 0, "'10:60' is not a time between 00:00 and 24:00"},
+		{"ShouldErrorFormat", "1000", 0, "'1000' is not in the HH:MM format"},
+		{"ShouldErrorNotNumber", "ab:00", 0, "'ab:00' is not in the HH:MM format"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := ParseScheduleTimeOfDay(tc.have)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, actual)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
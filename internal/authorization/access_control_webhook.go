@@ -0,0 +1,202 @@
+package authorization
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// NewAccessControlWebhook creates a new *AccessControlWebhook from the schema configuration.
+func NewAccessControlWebhook(config schema.AccessControlRuleWebhook) (webhook *AccessControlWebhook) {
+	return &AccessControlWebhook{
+		URL:           config.URL,
+		CacheTTL:      config.CacheTTL,
+		FailurePolicy: NewLevel(config.FailurePolicy),
+
+		client: &http.Client{Timeout: config.Timeout},
+		cache:  map[string]accessControlWebhookCacheEntry{},
+	}
+}
+
+// AccessControlWebhook delegates the decision of the required level to an external HTTP endpoint.
+type AccessControlWebhook struct {
+	URL           *url.URL
+	CacheTTL      time.Duration
+	FailurePolicy Level
+
+	client *http.Client
+
+	mu    sync.Mutex
+	cache map[string]accessControlWebhookCacheEntry
+}
+
+type accessControlWebhookCacheEntry struct {
+	level   Level
+	expires time.Time
+}
+
+// AccessControlWebhookRequest is the body sent to the webhook endpoint.
+type AccessControlWebhookRequest struct {
+	Subject AccessControlWebhookSubject `json:"subject"`
+	Object  AccessControlWebhookObject  `json:"object"`
+}
+
+// AccessControlWebhookSubject is the Subject representation sent to the webhook endpoint.
+type AccessControlWebhookSubject struct {
+	Username string   `json:"username"`
+	Groups   []string `json:"groups"`
+	ClientID string   `json:"client_id"`
+	IP       string   `json:"ip"`
+}
+
+// AccessControlWebhookObject is the Object representation sent to the webhook endpoint. The request headers are never
+// sent as they may contain credentials.
+type AccessControlWebhookObject struct {
+	URL    string `json:"url"`
+	Domain string `json:"domain"`
+	Path   string `json:"path"`
+	Method string `json:"method"`
+}
+
+// AccessControlWebhookResponse is the body expected from the webhook endpoint.
+type AccessControlWebhookResponse struct {
+	Decision string `json:"decision"`
+	Level    string `json:"level"`
+}
+
+// GetRequiredLevel returns the level the webhook endpoint decided for the subject and object. If the endpoint fails to
+// respond with a valid decision the failure policy is returned along with the error. Only valid decisions are cached.
+func (w *AccessControlWebhook) GetRequiredLevel(subject Subject, object Object, now time.Time) (level Level, err error) {
+	var body []byte
+
+	if body, err = json.Marshal(newAccessControlWebhookRequest(subject, object)); err != nil {
+		return w.FailurePolicy, fmt.Errorf("failed to marshal the request: %w", err)
+	}
+
+	key := string(body)
+
+	if level, ok := w.cached(key, now); ok {
+		return level, nil
+	}
+
+	if level, err = w.request(body); err != nil {
+		return w.FailurePolicy, err
+	}
+
+	w.store(key, level, now)
+
+	return level, nil
+}
+
+func (w *AccessControlWebhook) request(body []byte) (level Level, err error) {
+	var resp *http.Response
+
+	if resp, err = w.client.Post(w.URL.String(), "application/json", bytes.NewReader(body)); err != nil {
+		return w.FailurePolicy, fmt.Errorf("failed to perform the request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return w.FailurePolicy, fmt.Errorf("failed to perform the request: the endpoint responded with status code %d", resp.StatusCode)
+	}
+
+	response := AccessControlWebhookResponse{}
+
+	if err = json.NewDecoder(io.LimitReader(resp.Body, webhookMaxResponseSize)).Decode(&response); err != nil {
+		return w.FailurePolicy, fmt.Errorf("failed to parse the response: %w", err)
+	}
+
+	return response.level()
+}
+
+func (w *AccessControlWebhook) cached(key string, now time.Time) (level Level, ok bool) {
+	if w.CacheTTL <= 0 {
+		return level, false
+	}
+
+	w.mu.Lock()
+
+	defer w.mu.Unlock()
+
+	entry, ok := w.cache[key]
+	if !ok || !now.Before(entry.expires) {
+		return level, false
+	}
+
+	return entry.level, true
+}
+
+func (w *AccessControlWebhook) store(key string, level Level, now time.Time) {
+	if w.CacheTTL <= 0 {
+		return
+	}
+
+	w.mu.Lock()
+
+	defer w.mu.Unlock()
+
+	if len(w.cache) >= webhookMaxCacheEntries {
+		for k, entry := range w.cache {
+			if !now.Before(entry.expires) {
+				delete(w.cache, k)
+			}
+		}
+
+		if len(w.cache) >= webhookMaxCacheEntries {
+			w.cache = map[string]accessControlWebhookCacheEntry{}
+		}
+	}
+
+	w.cache[key] = accessControlWebhookCacheEntry{level: level, expires: now.Add(w.CacheTTL)}
+}
+
+func (r AccessControlWebhookResponse) level() (level Level, err error) {
+	switch r.Decision {
+	case webhookDecisionAllow:
+		return Bypass, nil
+	case webhookDecisionDeny:
+		return Denied, nil
+	case webhookDecisionLevel:
+		switch r.Level {
+		case bypass, oneFactor, twoFactor, deny:
+			return NewLevel(r.Level), nil
+		default:
+			return Denied, fmt.Errorf("failed to parse the response: the level '%s' is not one of '%s', '%s', '%s', or '%s'", r.Level, bypass, oneFactor, twoFactor, deny)
+		}
+	default:
+		return Denied, fmt.Errorf("failed to parse the response: the decision '%s' is not one of '%s', '%s', or '%s'", r.Decision, webhookDecisionAllow, webhookDecisionDeny, webhookDecisionLevel)
+	}
+}
+
+func newAccessControlWebhookRequest(subject Subject, object Object) AccessControlWebhookRequest {
+	request := AccessControlWebhookRequest{
+		Subject: AccessControlWebhookSubject{
+			Username: subject.Username,
+			Groups:   subject.Groups,
+			ClientID: subject.ClientID,
+		},
+		Object: AccessControlWebhookObject{
+			Domain: object.Domain,
+			Path:   object.Path,
+			Method: object.Method,
+		},
+	}
+
+	if subject.IP != nil {
+		request.Subject.IP = subject.IP.String()
+	}
+
+	if object.URL != nil {
+		request.Object.URL = object.URL.String()
+	}
+
+	return request
+}
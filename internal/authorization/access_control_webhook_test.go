@@ -0,0 +1,185 @@
+package authorization
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestAccessControlWebhook_GetRequiredLevel(t *testing.T) {
+	testCases := []struct {
+		name     string
+		status   int
+		response string
+		failure  string
+		expected Level
+		err      string
+	}{
+		{"ShouldAllow", http.StatusOK, `{"decision":"allow"}`, deny, Bypass, ""},
+		{"ShouldDeny", http.StatusOK, `{"decision":"deny"}`, bypass, Denied, ""},
+		{"ShouldReturnLevel", http.StatusOK, `{"decision":"level","level":"two_factor"}`, deny, TwoFactor, ""},
+		{"ShouldFailClosedInvalidLevel", http.StatusOK, `{"decision":"level","level":"three_factor"}`, deny, Denied, "failed to parse the response: the level 'three_factor' is not one of 'bypass', 'one_factor', 'two_factor', or 'deny'"},
+		{"ShouldFailOpenInvalidDecision", http.StatusOK, `{"decision":"maybe"}`, oneFactor, OneFactor, "failed to parse the response: the decision 'maybe' is not one of 'allow', 'deny', or 'level'"},
+		{"ShouldFailOpenInvalidJSON", http.StatusOK, `{"decision":`, bypass, Bypass, "failed to parse the response: unexpected EOF"},
+		{"ShouldFailClosedStatusCode", http.StatusInternalServerError, `{"decision":"allow"}`, deny, Denied, "failed to perform the request: the endpoint responded with status code 500"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.response))
+			}))
+
+			defer server.Close()
+
+			webhook := NewAccessControlWebhook(schema.AccessControlRuleWebhook{URL: mustParseURL(t, server.URL), Timeout: time.Second, FailurePolicy: tc.failure})
+
+			level, err := webhook.GetRequiredLevel(Subject{Username: "john"}, newAccessControlWebhookTestObject(), time.Unix(1700000000, 0))
+
+			assert.Equal(t, tc.expected, level)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestAccessControlWebhook_ShouldSendSubjectAndObject(t *testing.T) {
+	var actual AccessControlWebhookRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&actual))
+
+		_, _ = w.Write([]byte(`{"decision":"allow"}`))
+	}))
+
+	defer server.Close()
+
+	webhook := NewAccessControlWebhook(schema.AccessControlRuleWebhook{URL: mustParseURL(t, server.URL), Timeout: time.Second, FailurePolicy: deny})
+
+	_, err := webhook.GetRequiredLevel(Subject{Username: "john", Groups: []string{"admins", "dev"}, IP: net.ParseIP("192.168.1.10")}, newAccessControlWebhookTestObject(), time.Unix(1700000000, 0))
+	require.NoError(t, err)
+
+	assert.Equal(t, AccessControlWebhookRequest{
+		Subject: AccessControlWebhookSubject{Username: "john", Groups: []string{"admins", "dev"}, IP: "192.168.1.10"},
+		Object:  AccessControlWebhookObject{URL: "https://app.example.com/admin?x=1", Domain: "app.example.com", Path: "/admin?x=1", Method: fasthttp.MethodGet},
+	}, actual)
+}
+
+func TestAccessControlWebhook_ShouldCacheDecisions(t *testing.T) {
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"decision":"level","level":"one_factor"}`))
+	}))
+
+	defer server.Close()
+
+	webhook := NewAccessControlWebhook(schema.AccessControlRuleWebhook{URL: mustParseURL(t, server.URL), Timeout: time.Second, CacheTTL: time.Minute, FailurePolicy: deny})
+
+	now := time.Unix(1700000000, 0)
+	object := newAccessControlWebhookTestObject()
+
+	for i := 0; i < 3; i++ {
+		level, err := webhook.GetRequiredLevel(Subject{Username: "john"}, object, now.Add(time.Second*time.Duration(i)))
+		assert.NoError(t, err)
+		assert.Equal(t, OneFactor, level)
+	}
+
+	assert.Equal(t, int32(1), requests.Load())
+
+	level, err := webhook.GetRequiredLevel(Subject{Username: "harry"}, object, now)
+	assert.NoError(t, err)
+	assert.Equal(t, OneFactor, level)
+	assert.Equal(t, int32(2), requests.Load())
+
+	level, err = webhook.GetRequiredLevel(Subject{Username: "john"}, object, now.Add(time.Minute))
+	assert.EqualError(t, err, "failed to perform the request: the endpoint responded with status code 503")
+	assert.Equal(t, Denied, level)
+
+	level, err = webhook.GetRequiredLevel(Subject{Username: "john"}, object, now.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, OneFactor, level)
+	assert.Equal(t, int32(4), requests.Load())
+}
+
+func TestAuthorizerShouldApplyWebhookPolicy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := AccessControlWebhookRequest{}
+
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		switch request.Subject.Username {
+		case "john":
+			_, _ = w.Write([]byte(`{"decision":"allow"}`))
+		default:
+			_, _ = w.Write([]byte(`{"decision":"deny"}`))
+		}
+	}))
+
+	config := &schema.Configuration{
+		AccessControl: schema.AccessControl{
+			DefaultPolicy: deny,
+			Rules: []schema.AccessControlRule{
+				{
+					Domains: []string{"app.example.com"},
+					Policy:  webhook,
+					Webhook: schema.AccessControlRuleWebhook{URL: mustParseURL(t, server.URL), Timeout: time.Second, FailurePolicy: twoFactor},
+				},
+			},
+		},
+	}
+
+	authorizer := NewAuthorizer(config, clock.New())
+
+	assert.True(t, authorizer.IsSecondFactorEnabled())
+
+	object := newAccessControlWebhookTestObject()
+
+	policy := authorizer.GetRequiredPolicy(Subject{Username: "john"}, object)
+	assert.Equal(t, RequiredPolicy{Position: 1, HasSubjects: true, Level: Bypass}, policy)
+
+	policy = authorizer.GetRequiredPolicy(Subject{}, object)
+	assert.Equal(t, RequiredPolicy{Position: 1, HasSubjects: true, Level: Denied}, policy)
+
+	server.Close()
+
+	policy = authorizer.GetRequiredPolicy(Subject{Username: "john"}, object)
+	assert.Equal(t, RequiredPolicy{Position: 1, HasSubjects: true, Level: TwoFactor}, policy)
+}
+
+func newAccessControlWebhookTestObject() Object {
+	return NewObject(&url.URL{Scheme: "https", Host: "app.example.com", Path: "/admin", RawQuery: "x=1"}, fasthttp.MethodGet)
+}
+
+func mustParseURL(t *testing.T, raw string) *url.URL {
+	u, err := url.Parse(raw)
+
+	require.NoError(t, err)
+
+	return u
+}
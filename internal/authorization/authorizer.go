@@ -1,8 +1,11 @@
 package authorization
 
 import (
+	"sync"
+
 	"github.com/sirupsen/logrus"
 
+	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/logging"
 )
@@ -11,69 +14,143 @@ import (
 type Authorizer struct {
 	defaultPolicy Level
 	rules         []*AccessControlRule
+	index         *AccessControlIndex
 	mfa           bool
+	clock         clock.Provider
 	log           *logrus.Logger
+
+	mu sync.RWMutex
 }
 
-// NewAuthorizer create an instance of authorizer with a given access control config.
-func NewAuthorizer(config *schema.Configuration) (authorizer *Authorizer) {
+// NewAuthorizer create an instance of authorizer with a given access control config. The clock.Provider is used to
+// evaluate the schedules of rules.
+func NewAuthorizer(config *schema.Configuration, clock clock.Provider) (authorizer *Authorizer) {
 	authorizer = &Authorizer{
-		defaultPolicy: NewLevel(config.AccessControl.DefaultPolicy),
-		rules:         NewAccessControlRules(config.AccessControl),
-		log:           logging.Logger(),
+		clock: clock,
+		log:   logging.Logger(),
 	}
 
-	if authorizer.defaultPolicy == TwoFactor {
-		authorizer.mfa = true
+	authorizer.defaultPolicy, authorizer.rules, authorizer.index, authorizer.mfa = newAuthorizerPolicies(config)
 
-		return authorizer
-	}
+	return authorizer
+}
+
+func newAuthorizerPolicies(config *schema.Configuration) (defaultPolicy Level, rules []*AccessControlRule, index *AccessControlIndex, mfa bool) {
+	defaultPolicy, rules = NewLevel(config.AccessControl.DefaultPolicy), NewAccessControlRules(config.AccessControl)
+	index = NewAccessControlIndex(rules)
 
-	for _, rule := range authorizer.rules {
-		if rule.Policy == TwoFactor {
-			authorizer.mfa = true
+	if defaultPolicy == TwoFactor {
+		return defaultPolicy, rules, index, true
+	}
 
-			return authorizer
+	for _, rule := range rules {
+		// The level decided by a webhook is not known ahead of time so it's assumed it may require two factor.
+		if rule.Policy == TwoFactor || rule.Webhook != nil {
+			return defaultPolicy, rules, index, true
 		}
 	}
 
-	authorizer.mfa = isOpenIDConnectMFA(config)
+	return defaultPolicy, rules, index, isOpenIDConnectMFA(config)
+}
+
+// Update atomically replaces the default policy and rules of the authorizer with the ones from the provided
+// configuration. The configuration must be validated before it's provided to this function. Requests being authorized
+// while the update occurs are checked against either the previous or the updated rules but never a mix of both.
+func (p *Authorizer) Update(config *schema.Configuration) {
+	defaultPolicy, rules, index, mfa := newAuthorizerPolicies(config)
 
-	return authorizer
+	p.mu.Lock()
+
+	p.defaultPolicy, p.rules, p.index, p.mfa = defaultPolicy, rules, index, mfa
+
+	p.mu.Unlock()
+}
+
+func (p *Authorizer) policies() (defaultPolicy Level, rules []*AccessControlRule) {
+	p.mu.RLock()
+
+	defer p.mu.RUnlock()
+
+	return p.defaultPolicy, p.rules
+}
+
+func (p *Authorizer) candidates(domain string) (defaultPolicy Level, rules []*AccessControlRule) {
+	p.mu.RLock()
+
+	defer p.mu.RUnlock()
+
+	return p.defaultPolicy, p.index.Candidates(domain)
 }
 
 // IsSecondFactorEnabled return true if at least one policy is set to second factor.
 func (p *Authorizer) IsSecondFactorEnabled() bool {
+	p.mu.RLock()
+
+	defer p.mu.RUnlock()
+
 	return p.mfa
 }
 
 // GetRequiredLevel retrieve the required level of authorization to access the object.
 func (p *Authorizer) GetRequiredLevel(subject Subject, object Object) (hasSubjects bool, level Level) {
+	policy := p.GetRequiredPolicy(subject, object)
+
+	return policy.HasSubjects, policy.Level
+}
+
+// GetRequiredPolicy retrieve the policy which applies to the object, which includes the required level of authorization
+// and any additional requirements of the matching rule.
+func (p *Authorizer) GetRequiredPolicy(subject Subject, object Object) (policy RequiredPolicy) {
 	p.log.Debugf("Check authorization of subject %s and object %s (method %s).",
 		subject.String(), object.String(), object.Method)
 
-	for _, rule := range p.rules {
-		if rule.IsMatch(subject, object) {
-			p.log.Tracef(traceFmtACLHitMiss, "HIT", rule.Position, subject, object, object.Method, rule.Policy)
+	defaultPolicy, rules := p.candidates(object.Domain)
+
+	now := p.clock.Now()
+
+	for _, rule := range rules {
+		if rule.IsMatch(subject, object, now) {
+			p.log.Tracef(traceFmtACLHitMiss, "HIT", rule.Position, subject, object, object.Method, rule.PolicyName())
+
+			level := rule.Policy
 
-			return rule.HasSubjects, rule.Policy
+			if rule.Webhook != nil {
+				var err error
+
+				if level, err = rule.Webhook.GetRequiredLevel(subject, object, now); err != nil {
+					p.log.WithError(err).Errorf("Error occurred requesting the policy from the webhook of rule #%d, the failure policy '%s' will be applied", rule.Position, level)
+				}
+			}
+
+			return RequiredPolicy{
+				Position:             rule.Position,
+				HasSubjects:          rule.HasSubjects,
+				Level:                level,
+				MaxAuthenticationAge: rule.MaxAuthenticationAge,
+				SecondFactorMethods:  rule.SecondFactorMethods,
+				ResponseHeaders:      rule.ResponseHeaders,
+			}
 		}
 
-		p.log.Tracef(traceFmtACLHitMiss, "MISS", rule.Position, subject, object, object.Method, rule.Policy)
+		p.log.Tracef(traceFmtACLHitMiss, "MISS", rule.Position, subject, object, object.Method, rule.PolicyName())
 	}
 
 	p.log.Debugf("No matching rule for subject %s and url %s (method %s) applying default policy", subject, object, object.Method)
 
-	return false, p.defaultPolicy
+	return RequiredPolicy{Level: defaultPolicy}
 }
 
 // GetRuleMatchResults iterates through the rules and produces a list of RuleMatchResult provided a subject and object.
 func (p *Authorizer) GetRuleMatchResults(subject Subject, object Object) (results []RuleMatchResult) {
 	skipped := false
 
-	results = make([]RuleMatchResult, len(p.rules))
+	_, rules := p.policies()
+
+	now := p.clock.Now()
+
+	results = make([]RuleMatchResult, len(rules))
 
-	for i, rule := range p.rules {
+	for i, rule := range rules {
 		results[i] = RuleMatchResult{
 			Rule:    rule,
 			Skipped: skipped,
@@ -81,10 +158,12 @@ func (p *Authorizer) GetRuleMatchResults(subject Subject, object Object) (result
 			MatchDomain:        rule.MatchesDomains(subject, object),
 			MatchResources:     rule.MatchesResources(subject, object),
 			MatchQuery:         rule.MatchesQuery(object),
+			MatchHeaders:       rule.MatchesHeaders(object),
 			MatchMethods:       rule.MatchesMethods(object),
 			MatchNetworks:      rule.MatchesNetworks(subject),
 			MatchSubjects:      rule.MatchesSubjects(subject),
 			MatchSubjectsExact: rule.MatchesSubjectExact(subject),
+			MatchSchedule:      rule.MatchesSchedule(now),
 		}
 
 		skipped = skipped || results[i].IsMatch()
@@ -5,12 +5,14 @@ import (
 	"net/url"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/valyala/fasthttp"
 
+	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 )
 
@@ -28,7 +30,7 @@ func NewAuthorizerTester(config schema.AccessControl) *AuthorizerTester {
 	}
 
 	return &AuthorizerTester{
-		NewAuthorizer(fullConfig),
+		NewAuthorizer(fullConfig, clock.New()),
 	}
 }
 
@@ -1017,7 +1019,7 @@ func TestNewAuthorizer(t *testing.T) {
 		},
 	}
 
-	authorizer := NewAuthorizer(config)
+	authorizer := NewAuthorizer(config, clock.New())
 
 	assert.Equal(t, Denied, authorizer.defaultPolicy)
 	assert.Equal(t, TwoFactor, authorizer.rules[0].Policy)
@@ -1044,11 +1046,11 @@ func TestAuthorizerIsSecondFactorEnabledRuleWithNoOIDC(t *testing.T) {
 		},
 	}
 
-	authorizer := NewAuthorizer(config)
+	authorizer := NewAuthorizer(config, clock.New())
 	assert.False(t, authorizer.IsSecondFactorEnabled())
 
 	config.AccessControl.Rules[0].Policy = twoFactor
-	authorizer = NewAuthorizer(config)
+	authorizer = NewAuthorizer(config, clock.New())
 	assert.True(t, authorizer.IsSecondFactorEnabled())
 }
 
@@ -1074,27 +1076,152 @@ func TestAuthorizerIsSecondFactorEnabledRuleWithOIDC(t *testing.T) {
 		},
 	}
 
-	authorizer := NewAuthorizer(config)
+	authorizer := NewAuthorizer(config, clock.New())
 	assert.False(t, authorizer.IsSecondFactorEnabled())
 
 	config.AccessControl.Rules[0].Policy = twoFactor
-	authorizer = NewAuthorizer(config)
+	authorizer = NewAuthorizer(config, clock.New())
 	assert.True(t, authorizer.IsSecondFactorEnabled())
 
 	config.AccessControl.Rules[0].Policy = oneFactor
-	authorizer = NewAuthorizer(config)
+	authorizer = NewAuthorizer(config, clock.New())
 	assert.False(t, authorizer.IsSecondFactorEnabled())
 
 	config.IdentityProviders.OIDC.Clients[0].AuthorizationPolicy = twoFactor
-	authorizer = NewAuthorizer(config)
+	authorizer = NewAuthorizer(config, clock.New())
 	assert.True(t, authorizer.IsSecondFactorEnabled())
 
 	config.AccessControl.Rules[0].Policy = oneFactor
 	config.IdentityProviders.OIDC.Clients[0].AuthorizationPolicy = oneFactor
-	authorizer = NewAuthorizer(config)
+	authorizer = NewAuthorizer(config, clock.New())
 	assert.False(t, authorizer.IsSecondFactorEnabled())
 
 	config.AccessControl.DefaultPolicy = twoFactor
-	authorizer = NewAuthorizer(config)
+	authorizer = NewAuthorizer(config, clock.New())
 	assert.True(t, authorizer.IsSecondFactorEnabled())
 }
+
+func TestAuthorizerUpdate(t *testing.T) {
+	config := &schema.Configuration{
+		AccessControl: schema.AccessControl{
+			DefaultPolicy: deny,
+			Rules: []schema.AccessControlRule{
+				{
+					Domains: []string{"example.com"},
+					Policy:  oneFactor,
+				},
+			},
+		},
+	}
+
+	authorizer := NewAuthorizer(config, clock.New())
+
+	object := NewObject(&url.URL{Scheme: "https", Host: "example.com", Path: "/"}, fasthttp.MethodGet)
+
+	_, level := authorizer.GetRequiredLevel(John, object)
+	assert.Equal(t, OneFactor, level)
+	assert.False(t, authorizer.IsSecondFactorEnabled())
+
+	authorizer.Update(&schema.Configuration{
+		AccessControl: schema.AccessControl{
+			DefaultPolicy: twoFactor,
+			Rules: []schema.AccessControlRule{
+				{
+					Domains: []string{"public.example.com"},
+					Policy:  bypass,
+				},
+			},
+		},
+	})
+
+	_, level = authorizer.GetRequiredLevel(John, object)
+	assert.Equal(t, TwoFactor, level)
+	assert.True(t, authorizer.IsSecondFactorEnabled())
+
+	results := authorizer.GetRuleMatchResults(John, NewObject(&url.URL{Scheme: "https", Host: "public.example.com", Path: "/"}, fasthttp.MethodGet))
+	require.Len(t, results, 1)
+	assert.True(t, results[0].IsMatch())
+}
+
+func TestAuthorizerSchedule(t *testing.T) {
+	config := &schema.Configuration{
+		AccessControl: schema.AccessControl{
+			DefaultPolicy: oneFactor,
+			Rules: []schema.AccessControlRule{
+				{
+					Domains:  []string{"example.com"},
+					Subjects: [][]string{{"user:john"}},
+					Policy:   deny,
+					Schedule: schema.AccessControlRuleSchedule{
+						TimeZone: "Europe/Paris",
+						Windows: []schema.AccessControlRuleScheduleWindow{
+							{Days: []string{"monday", "tuesday", "wednesday", "thursday", "friday"}, Start: "18:00", End: "08:00"},
+							{Days: []string{"saturday", "sunday"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	provider := clock.NewFixed(time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC))
+
+	authorizer := NewAuthorizer(config, provider)
+
+	object := NewObject(&url.URL{Scheme: "https", Host: "example.com", Path: "/"}, fasthttp.MethodGet)
+
+	_, level := authorizer.GetRequiredLevel(John, object)
+	assert.Equal(t, OneFactor, level)
+
+	results := authorizer.GetRuleMatchResults(John, object)
+	require.Len(t, results, 1)
+	assert.False(t, results[0].MatchSchedule)
+	assert.False(t, results[0].IsMatch())
+
+	provider.Set(time.Date(2024, 1, 2, 17, 30, 0, 0, time.UTC))
+
+	_, level = authorizer.GetRequiredLevel(John, object)
+	assert.Equal(t, Denied, level)
+
+	provider.Set(time.Date(2024, 1, 6, 12, 0, 0, 0, time.UTC))
+
+	_, level = authorizer.GetRequiredLevel(John, object)
+	assert.Equal(t, Denied, level)
+
+	results = authorizer.GetRuleMatchResults(John, object)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].MatchSchedule)
+	assert.True(t, results[0].IsMatch())
+}
+
+func TestAuthorizerGetRequiredPolicy(t *testing.T) {
+	config := &schema.Configuration{
+		AccessControl: schema.AccessControl{
+			DefaultPolicy: oneFactor,
+			Rules: []schema.AccessControlRule{
+				{
+					Domains:              []string{"admin.example.com"},
+					Subjects:             [][]string{{"group:admins"}},
+					Policy:               twoFactor,
+					MaxAuthenticationAge: time.Hour,
+				},
+				{
+					Domains:             []string{"vault.example.com"},
+					Policy:              twoFactor,
+					SecondFactorMethods: []string{"webauthn_hardware"},
+				},
+			},
+		},
+	}
+
+	authorizer := NewAuthorizer(config, clock.New())
+
+	object := NewObject(&url.URL{Scheme: "https", Host: "admin.example.com", Path: "/"}, fasthttp.MethodGet)
+
+	assert.Equal(t, RequiredPolicy{Position: 1, HasSubjects: true, Level: TwoFactor, MaxAuthenticationAge: time.Hour}, authorizer.GetRequiredPolicy(Subject{Username: "john", Groups: []string{"admins"}}, object))
+	assert.Equal(t, RequiredPolicy{Level: OneFactor}, authorizer.GetRequiredPolicy(Subject{Username: "john", Groups: []string{"dev"}}, object))
+
+	object = NewObject(&url.URL{Scheme: "https", Host: "vault.example.com", Path: "/"}, fasthttp.MethodGet)
+
+	assert.Equal(t, RequiredPolicy{Position: 2, Level: TwoFactor, SecondFactorMethods: []string{"webauthn_hardware"}}, authorizer.GetRequiredPolicy(Subject{Username: "john", Groups: []string{"dev"}}, object))
+}
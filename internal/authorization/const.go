@@ -34,6 +34,7 @@ const (
 	oneFactor = "one_factor"
 	twoFactor = "two_factor"
 	deny      = "deny"
+	webhook   = "webhook"
 )
 
 const (
@@ -56,3 +57,18 @@ var (
 )
 
 const traceFmtACLHitMiss = "ACL %s Position %d for subject %s and object %s (method %s, policy %s)"
+
+const (
+	minutesPerDay = 24 * 60
+
+	layoutScheduleDateTime = "2006-01-02 15:04"
+)
+
+const (
+	webhookDecisionAllow = "allow"
+	webhookDecisionDeny  = "deny"
+	webhookDecisionLevel = "level"
+
+	webhookMaxResponseSize = 1024 * 1024
+	webhookMaxCacheEntries = 10000
+)
@@ -5,6 +5,7 @@ import (
 	"net"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/authelia/authelia/v4/internal/utils"
 )
@@ -54,6 +55,31 @@ type Object struct {
 	Domain string
 	Path   string
 	Method string
+
+	Headers ObjectHeaders
+}
+
+// ObjectHeaders represents the request headers of a protected object for the purposes of ACL matching. It's satisfied
+// by *fasthttp.RequestHeader.
+type ObjectHeaders interface {
+	PeekAll(key string) [][]byte
+}
+
+// HeaderValues returns the values of a request header. If the header is absent or the object has no headers a single
+// empty value is returned, which is consistent with how query parameters are treated.
+func (o Object) HeaderValues(name string) (values [][]byte) {
+	if o.Headers != nil {
+		if values = o.Headers.PeekAll(name); len(values) != 0 {
+			return values
+		}
+	}
+
+	return [][]byte{nil}
+}
+
+// HasHeader returns true if the object has a request header with the given name.
+func (o Object) HasHeader(name string) bool {
+	return o.Headers != nil && len(o.Headers.PeekAll(name)) != 0
 }
 
 // String is a string representation of the Object.
@@ -76,6 +102,29 @@ func NewObject(targetURL *url.URL, method string) (object Object) {
 	}
 }
 
+// RequiredPolicy describes the requirements of the rule or default policy which applies to an object.
+type RequiredPolicy struct {
+	// Position is the position of the matching rule starting at 1, zero means the default policy applies.
+	Position int
+
+	// HasSubjects is true if the matching rule has subject criteria.
+	HasSubjects bool
+
+	// Level is the required authorization level.
+	Level Level
+
+	// MaxAuthenticationAge is the maximum amount of time since the user last authenticated, zero means there is no
+	// maximum.
+	MaxAuthenticationAge time.Duration
+
+	// SecondFactorMethods are the second factor methods which satisfy the TwoFactor level, empty means any method
+	// satisfies it.
+	SecondFactorMethods []string
+
+	// ResponseHeaders are the additional headers rendered from templates when the request is authorized.
+	ResponseHeaders []AccessControlResponseHeader
+}
+
 // RuleMatchResult describes how well a rule matched a subject/object combo.
 type RuleMatchResult struct {
 	Rule *AccessControlRule
@@ -85,18 +134,20 @@ type RuleMatchResult struct {
 	MatchDomain        bool
 	MatchResources     bool
 	MatchQuery         bool
+	MatchHeaders       bool
 	MatchMethods       bool
 	MatchNetworks      bool
 	MatchSubjects      bool
 	MatchSubjectsExact bool
+	MatchSchedule      bool
 }
 
 // IsMatch returns true if all the criteria matched.
 func (r RuleMatchResult) IsMatch() (match bool) {
-	return r.MatchDomain && r.MatchResources && r.MatchQuery && r.MatchMethods && r.MatchNetworks && r.MatchSubjectsExact
+	return r.MatchDomain && r.MatchResources && r.MatchQuery && r.MatchHeaders && r.MatchMethods && r.MatchNetworks && r.MatchSubjectsExact && r.MatchSchedule
 }
 
 // IsPotentialMatch returns true if the rule is potentially a match.
 func (r RuleMatchResult) IsPotentialMatch() (match bool) {
-	return r.MatchDomain && r.MatchResources && r.MatchQuery && r.MatchMethods && r.MatchNetworks && r.MatchSubjects && !r.MatchSubjectsExact
+	return r.MatchDomain && r.MatchResources && r.MatchQuery && r.MatchHeaders && r.MatchMethods && r.MatchNetworks && r.MatchSubjects && !r.MatchSubjectsExact && r.MatchSchedule
 }
@@ -99,12 +99,17 @@ func TestRuleMatchResult_IsPotentialMatch(t *testing.T) {
 		},
 		{
 			"ShouldMatch",
-			RuleMatchResult{nil, true, true, true, true, true, true, true, false},
+			RuleMatchResult{nil, true, true, true, true, true, true, true, true, false, true},
 			true,
 		},
 		{
 			"ShouldMatchExact",
-			RuleMatchResult{nil, true, true, true, true, true, true, true, true},
+			RuleMatchResult{nil, true, true, true, true, true, true, true, true, true, true},
+			false,
+		},
+		{
+			"ShouldNotMatchSchedule",
+			RuleMatchResult{nil, true, true, true, true, true, true, true, true, false, false},
 			false,
 		},
 	}
@@ -8,11 +8,13 @@ import (
 	"os"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/valyala/fasthttp"
 
 	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration/validator"
 )
 
@@ -52,7 +54,11 @@ func newAccessControlCheckCommand(ctx *CmdCtx) (cmd *cobra.Command) {
 	cmd.Flags().String("username", "", "the username of the subject")
 	cmd.Flags().StringSlice("groups", nil, "the groups of the subject")
 	cmd.Flags().String("ip", "", "the ip of the subject")
+	cmd.Flags().StringArray("header", nil, "a header of the object in the 'Name: Value' format, can be specified multiple times")
+	cmd.Flags().String("time", "", "the time of the request in the RFC3339 format, defaults to the current time")
 	cmd.Flags().Bool("verbose", false, "enables verbose output")
+	cmd.Flags().String("suite", "", "the path to a YAML file of requests and their expected policies to test instead of a single request")
+	cmd.Flags().String("format", accessControlSuiteFormatText, fmt.Sprintf("the output format of the suite results, options are '%s', '%s', and '%s'", accessControlSuiteFormatText, accessControlSuiteFormatJSON, accessControlSuiteFormatJUnit))
 
 	return cmd
 }
@@ -64,13 +70,27 @@ func (ctx *CmdCtx) AccessControlCheckRunE(cmd *cobra.Command, _ []string) (err e
 		return errors.New("failed to execute command due to errors in the configuration")
 	}
 
-	authorizer := authorization.NewAuthorizer(ctx.config)
+	suite, err := cmd.Flags().GetString("suite")
+	if err != nil {
+		return err
+	}
+
+	if suite != "" {
+		return ctx.accessControlCheckSuite(cmd, suite)
+	}
 
 	subject, object, err := getSubjectAndObjectFromFlags(cmd)
 	if err != nil {
 		return err
 	}
 
+	provider, err := getClockFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	authorizer := authorization.NewAuthorizer(ctx.config, provider)
+
 	results := authorizer.GetRuleMatchResults(subject, object)
 
 	if len(results) == 0 {
@@ -89,6 +109,42 @@ func (ctx *CmdCtx) AccessControlCheckRunE(cmd *cobra.Command, _ []string) (err e
 	return nil
 }
 
+func (ctx *CmdCtx) accessControlCheckSuite(cmd *cobra.Command, path string) (err error) {
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case accessControlSuiteFormatText, accessControlSuiteFormatJSON, accessControlSuiteFormatJUnit:
+		break
+	default:
+		return fmt.Errorf("the format flag value '%s' is invalid: must be one of '%s', '%s', or '%s'", format, accessControlSuiteFormatText, accessControlSuiteFormatJSON, accessControlSuiteFormatJUnit)
+	}
+
+	provider, err := getClockFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	suite, err := loadAccessControlSuite(path)
+	if err != nil {
+		return err
+	}
+
+	results := runAccessControlSuite(ctx.config, suite, provider.Now())
+
+	if err = writeAccessControlSuiteResults(os.Stdout, results, format); err != nil {
+		return err
+	}
+
+	if results.Failures != 0 {
+		return fmt.Errorf("%d of %d access control tests failed", results.Failures, results.Tests)
+	}
+
+	return nil
+}
+
 func accessControlCheckWriteObjectSubject(object authorization.Object, subject authorization.Subject) {
 	output := strings.Builder{}
 
@@ -120,7 +176,7 @@ func accessControlCheckWriteOutput(object authorization.Object, subject authoriz
 
 	w := tabwriter.NewWriter(os.Stdout, 1, 1, 4, ' ', 0)
 
-	_, _ = fmt.Fprintln(w, "  #\tDomain\tResource\tQuery\tMethod\tNetwork\tSubject")
+	_, _ = fmt.Fprintln(w, "  #\tDomain\tResource\tQuery\tHeaders\tMethod\tNetwork\tSubject\tSchedule")
 
 	var (
 		appliedPos int
@@ -138,15 +194,15 @@ func accessControlCheckWriteOutput(object authorization.Object, subject authoriz
 		switch {
 		case result.IsMatch() && !result.Skipped:
 			appliedPos, applied = i+1, result
-			_, _ = fmt.Fprintf(w, "* %d\t%s\t%s\t%s\t%s\t%s\t%s\n", i+1, hitMissMay(result.MatchDomain), hitMissMay(result.MatchResources), hitMissMay(result.MatchQuery), hitMissMay(result.MatchMethods), hitMissMay(result.MatchNetworks), hitMissMay(result.MatchSubjects, result.MatchSubjectsExact))
+			_, _ = fmt.Fprintf(w, "* %d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", i+1, hitMissMay(result.MatchDomain), hitMissMay(result.MatchResources), hitMissMay(result.MatchQuery), hitMissMay(result.MatchHeaders), hitMissMay(result.MatchMethods), hitMissMay(result.MatchNetworks), hitMissMay(result.MatchSubjects, result.MatchSubjectsExact), hitMissMay(result.MatchSchedule))
 		case result.IsPotentialMatch() && !result.Skipped:
 			if potentialPos == 0 {
 				potentialPos, potential = i+1, result
 			}
 
-			_, _ = fmt.Fprintf(w, "~ %d\t%s\t%s\t%s\t%s\t%s\t%s\n", i+1, hitMissMay(result.MatchDomain), hitMissMay(result.MatchResources), hitMissMay(result.MatchQuery), hitMissMay(result.MatchMethods), hitMissMay(result.MatchNetworks), hitMissMay(result.MatchSubjects, result.MatchSubjectsExact))
+			_, _ = fmt.Fprintf(w, "~ %d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", i+1, hitMissMay(result.MatchDomain), hitMissMay(result.MatchResources), hitMissMay(result.MatchQuery), hitMissMay(result.MatchHeaders), hitMissMay(result.MatchMethods), hitMissMay(result.MatchNetworks), hitMissMay(result.MatchSubjects, result.MatchSubjectsExact), hitMissMay(result.MatchSchedule))
 		default:
-			_, _ = fmt.Fprintf(w, "  %d\t%s\t%s\t%s\t%s\t%s\t%s\n", i+1, hitMissMay(result.MatchDomain), hitMissMay(result.MatchResources), hitMissMay(result.MatchQuery), hitMissMay(result.MatchMethods), hitMissMay(result.MatchNetworks), hitMissMay(result.MatchSubjects, result.MatchSubjectsExact))
+			_, _ = fmt.Fprintf(w, "  %d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", i+1, hitMissMay(result.MatchDomain), hitMissMay(result.MatchResources), hitMissMay(result.MatchQuery), hitMissMay(result.MatchHeaders), hitMissMay(result.MatchMethods), hitMissMay(result.MatchNetworks), hitMissMay(result.MatchSubjects, result.MatchSubjectsExact), hitMissMay(result.MatchSchedule))
 		}
 	}
 
@@ -154,11 +210,11 @@ func accessControlCheckWriteOutput(object authorization.Object, subject authoriz
 
 	switch {
 	case appliedPos != 0 && (potentialPos == 0 || (potentialPos > appliedPos)):
-		fmt.Printf("\nThe policy '%s' from rule #%d will be applied to this request.\n\n", applied.Rule.Policy, appliedPos)
+		fmt.Printf("\nThe policy '%s' from rule #%d will be applied to this request.\n\n", applied.Rule.PolicyName(), appliedPos)
 	case potentialPos != 0 && appliedPos != 0:
-		fmt.Printf("\nThe policy '%s' from rule #%d will potentially be applied to this request. If not policy '%s' from rule #%d will be.\n\n", potential.Rule.Policy, potentialPos, applied.Rule.Policy, appliedPos)
+		fmt.Printf("\nThe policy '%s' from rule #%d will potentially be applied to this request. If not policy '%s' from rule #%d will be.\n\n", potential.Rule.PolicyName(), potentialPos, applied.Rule.PolicyName(), appliedPos)
 	case potentialPos != 0:
-		fmt.Printf("\nThe policy '%s' from rule #%d will potentially be applied to this request. Otherwise the policy '%s' from the default policy will be.\n\n", potential.Rule.Policy, potentialPos, defaultPolicy)
+		fmt.Printf("\nThe policy '%s' from rule #%d will potentially be applied to this request. Otherwise the policy '%s' from the default policy will be.\n\n", potential.Rule.PolicyName(), potentialPos, defaultPolicy)
 	default:
 		fmt.Printf("\nThe policy '%s' from the default policy will be applied to this request as no rules matched the request.\n\n", defaultPolicy)
 	}
@@ -226,5 +282,47 @@ func getSubjectAndObjectFromFlags(cmd *cobra.Command) (subject authorization.Sub
 
 	object = authorization.NewObject(parsedURL, method)
 
+	if object.Headers, err = getObjectHeadersFromFlags(cmd); err != nil {
+		return subject, object, err
+	}
+
 	return subject, object, nil
 }
+
+func getObjectHeadersFromFlags(cmd *cobra.Command) (headers *fasthttp.RequestHeader, err error) {
+	values, err := cmd.Flags().GetStringArray("header")
+	if err != nil {
+		return nil, err
+	}
+
+	headers = &fasthttp.RequestHeader{}
+
+	for _, value := range values {
+		name, v, ok := strings.Cut(value, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("failed to parse the header flag value '%s': must be in the 'Name: Value' format", value)
+		}
+
+		headers.Add(strings.TrimSpace(name), strings.TrimSpace(v))
+	}
+
+	return headers, nil
+}
+
+func getClockFromFlags(cmd *cobra.Command) (provider clock.Provider, err error) {
+	value, err := cmd.Flags().GetString("time")
+	if err != nil {
+		return nil, err
+	}
+
+	if value == "" {
+		return clock.New(), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the time flag value: %w", err)
+	}
+
+	return clock.NewFixed(t), nil
+}
@@ -0,0 +1,363 @@
+package commands
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/valyala/fasthttp"
+	"gopkg.in/yaml.v3"
+
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// AccessControlSuite is a list of access control tests loaded from a YAML file.
+type AccessControlSuite struct {
+	Tests []AccessControlSuiteTest `yaml:"tests"`
+}
+
+// AccessControlSuiteTest is a single request and the policy it's expected to be applied.
+type AccessControlSuiteTest struct {
+	Name     string            `yaml:"name"`
+	URL      string            `yaml:"url"`
+	Method   string            `yaml:"method"`
+	Username string            `yaml:"username"`
+	Groups   []string          `yaml:"groups"`
+	IP       string            `yaml:"ip"`
+	Headers  map[string]string `yaml:"headers"`
+	Time     string            `yaml:"time"`
+	Policy   string            `yaml:"policy"`
+	Rule     *int              `yaml:"rule"`
+
+	// Webhook is the policy the webhook of the applied rule is assumed to respond with. The webhooks are never
+	// requested, when this is empty the policy of a rule with a webhook is reported as webhook.
+	Webhook string `yaml:"webhook"`
+}
+
+// AccessControlSuiteResults is the outcome of running an AccessControlSuite.
+type AccessControlSuiteResults struct {
+	Tests    int                        `json:"tests"`
+	Failures int                        `json:"failures"`
+	Results  []AccessControlSuiteResult `json:"results"`
+}
+
+// AccessControlSuiteResult is the outcome of a single AccessControlSuiteTest. A Rule of 0 represents the default policy.
+type AccessControlSuiteResult struct {
+	Name         string   `json:"name"`
+	Passed       bool     `json:"passed"`
+	Expected     string   `json:"expected_policy"`
+	Actual       string   `json:"actual_policy,omitempty"`
+	ExpectedRule *int     `json:"expected_rule,omitempty"`
+	Rule         int      `json:"rule"`
+	Explanation  []string `json:"explanation,omitempty"`
+	Error        string   `json:"error,omitempty"`
+}
+
+// Message returns a description of the failure of the result.
+func (r AccessControlSuiteResult) Message() string {
+	switch {
+	case r.Passed:
+		return ""
+	case r.Error != "":
+		return r.Error
+	case r.Expected != r.Actual:
+		return fmt.Sprintf("expected policy '%s' but policy '%s' was applied", r.Expected, r.Actual)
+	default:
+		return fmt.Sprintf("expected rule #%d to be applied but rule #%d was applied", *r.ExpectedRule, r.Rule)
+	}
+}
+
+func loadAccessControlSuite(path string) (suite *AccessControlSuite, err error) {
+	var data []byte
+
+	if data, err = os.ReadFile(path); err != nil {
+		return nil, fmt.Errorf("failed to read the access control suite file '%s': %w", path, err)
+	}
+
+	suite = &AccessControlSuite{}
+
+	if err = yaml.Unmarshal(data, suite); err != nil {
+		return nil, fmt.Errorf("failed to parse the access control suite file '%s': %w", path, err)
+	}
+
+	if len(suite.Tests) == 0 {
+		return nil, fmt.Errorf("failed to parse the access control suite file '%s': no tests are defined", path)
+	}
+
+	return suite, nil
+}
+
+func runAccessControlSuite(config *schema.Configuration, suite *AccessControlSuite, now time.Time) (results *AccessControlSuiteResults) {
+	provider := clock.NewFixed(now)
+
+	authorizer := authorization.NewAuthorizer(config, provider)
+
+	defaultPolicy := authorization.NewLevel(config.AccessControl.DefaultPolicy).String()
+
+	results = &AccessControlSuiteResults{
+		Tests:   len(suite.Tests),
+		Results: make([]AccessControlSuiteResult, len(suite.Tests)),
+	}
+
+	for i, test := range suite.Tests {
+		result := &results.Results[i]
+
+		result.Name, result.Expected, result.ExpectedRule = test.Name, test.Policy, test.Rule
+
+		if result.Name == "" {
+			result.Name = fmt.Sprintf("test #%d", i+1)
+		}
+
+		subject, object, t, err := test.parse(now)
+		if err != nil {
+			result.Error = err.Error()
+			results.Failures++
+
+			continue
+		}
+
+		provider.Set(t)
+
+		matches := authorizer.GetRuleMatchResults(subject, object)
+
+		result.Actual = defaultPolicy
+
+		for j, match := range matches {
+			if match.IsMatch() {
+				result.Rule, result.Actual = j+1, test.policy(match.Rule)
+
+				break
+			}
+		}
+
+		result.Passed = result.Actual == result.Expected && (test.Rule == nil || *test.Rule == result.Rule)
+
+		if !result.Passed {
+			result.Explanation = explainAccessControlSuiteResult(matches, result.Rule, config.AccessControl.DefaultPolicy)
+			results.Failures++
+		}
+	}
+
+	return results
+}
+
+// policy returns the name of the policy applied by a rule without requesting the webhook of the rule.
+func (t AccessControlSuiteTest) policy(rule *authorization.AccessControlRule) string {
+	if rule.Webhook != nil && t.Webhook != "" {
+		return t.Webhook
+	}
+
+	return rule.PolicyName()
+}
+
+func (t AccessControlSuiteTest) parse(now time.Time) (subject authorization.Subject, object authorization.Object, at time.Time, err error) {
+	if !utils.IsStringInSlice(t.Policy, []string{"bypass", "one_factor", "two_factor", "deny", "webhook"}) {
+		return subject, object, at, fmt.Errorf("the expected policy must be one of 'bypass', 'one_factor', 'two_factor', 'deny', or 'webhook' but it's configured as '%s'", t.Policy)
+	}
+
+	if t.Webhook != "" && !utils.IsStringInSlice(t.Webhook, []string{"bypass", "one_factor", "two_factor", "deny"}) {
+		return subject, object, at, fmt.Errorf("the webhook policy must be one of 'bypass', 'one_factor', 'two_factor', or 'deny' but it's configured as '%s'", t.Webhook)
+	}
+
+	var u *url.URL
+
+	if u, err = url.ParseRequestURI(t.URL); err != nil {
+		return subject, object, at, fmt.Errorf("failed to parse the url '%s': %w", t.URL, err)
+	}
+
+	if t.IP != "" && net.ParseIP(t.IP) == nil {
+		return subject, object, at, fmt.Errorf("failed to parse the ip '%s'", t.IP)
+	}
+
+	at = now
+
+	if t.Time != "" {
+		if at, err = time.Parse(time.RFC3339, t.Time); err != nil {
+			return subject, object, at, fmt.Errorf("failed to parse the time '%s': %w", t.Time, err)
+		}
+	}
+
+	method := t.Method
+
+	if method == "" {
+		method = fasthttp.MethodGet
+	}
+
+	subject = authorization.Subject{
+		Username: t.Username,
+		Groups:   t.Groups,
+		IP:       net.ParseIP(t.IP),
+	}
+
+	object = authorization.NewObject(u, method)
+
+	headers := &fasthttp.RequestHeader{}
+
+	for name, value := range t.Headers {
+		headers.Add(name, value)
+	}
+
+	object.Headers = headers
+
+	return subject, object, at, nil
+}
+
+// explainAccessControlSuiteResult describes why each rule before the applied rule didn't match, and which rule or the
+// default policy was applied.
+func explainAccessControlSuiteResult(matches []authorization.RuleMatchResult, applied int, defaultPolicy string) (explanation []string) {
+	for i, match := range matches {
+		if applied != 0 && i+1 >= applied {
+			break
+		}
+
+		if !match.MatchDomain {
+			continue
+		}
+
+		if match.IsPotentialMatch() {
+			explanation = append(explanation, fmt.Sprintf("rule #%d with policy '%s' potentially matches if the subject is authenticated", i+1, match.Rule.PolicyName()))
+
+			continue
+		}
+
+		explanation = append(explanation, fmt.Sprintf("rule #%d with policy '%s' matched the domain but not the criteria %s", i+1, match.Rule.PolicyName(), strings.Join(accessControlSuiteMissedCriteria(match), ", ")))
+	}
+
+	if applied == 0 {
+		return append(explanation, fmt.Sprintf("no rule matched so the default policy '%s' was applied", defaultPolicy))
+	}
+
+	return append(explanation, fmt.Sprintf("rule #%d with policy '%s' was applied", applied, matches[applied-1].Rule.PolicyName()))
+}
+
+func accessControlSuiteMissedCriteria(match authorization.RuleMatchResult) (missed []string) {
+	criteria := []struct {
+		name  string
+		match bool
+	}{
+		{"resources", match.MatchResources},
+		{"query", match.MatchQuery},
+		{"headers", match.MatchHeaders},
+		{"methods", match.MatchMethods},
+		{"networks", match.MatchNetworks},
+		{"subject", match.MatchSubjects},
+		{"schedule", match.MatchSchedule},
+	}
+
+	for _, c := range criteria {
+		if !c.match {
+			missed = append(missed, c.name)
+		}
+	}
+
+	return missed
+}
+
+func writeAccessControlSuiteResults(w io.Writer, results *AccessControlSuiteResults, format string) (err error) {
+	switch format {
+	case accessControlSuiteFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(results)
+	case accessControlSuiteFormatJUnit:
+		return writeAccessControlSuiteResultsJUnit(w, results)
+	default:
+		return writeAccessControlSuiteResultsText(w, results)
+	}
+}
+
+func writeAccessControlSuiteResultsText(w io.Writer, results *AccessControlSuiteResults) (err error) {
+	for _, result := range results.Results {
+		if result.Passed {
+			if _, err = fmt.Fprintf(w, "PASS %s\n", result.Name); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if _, err = fmt.Fprintf(w, "FAIL %s: %s\n", result.Name, result.Message()); err != nil {
+			return err
+		}
+
+		for _, line := range result.Explanation {
+			if _, err = fmt.Fprintf(w, "    %s\n", line); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "\n%d tests, %d passed, %d failed\n", results.Tests, results.Tests-results.Failures, results.Failures)
+
+	return err
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Content string `xml:",chardata"`
+}
+
+func writeAccessControlSuiteResultsJUnit(w io.Writer, results *AccessControlSuiteResults) (err error) {
+	suite := junitTestSuite{
+		Name:      "access-control",
+		Tests:     results.Tests,
+		Failures:  results.Failures,
+		TestCases: make([]junitTestCase, len(results.Results)),
+	}
+
+	for i, result := range results.Results {
+		suite.TestCases[i] = junitTestCase{Name: result.Name, ClassName: suite.Name}
+
+		if !result.Passed {
+			suite.TestCases[i].Failure = &junitFailure{
+				Message: result.Message(),
+				Content: strings.Join(result.Explanation, "\n"),
+			}
+		}
+	}
+
+	if _, err = io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	if err = encoder.Encode(junitTestSuites{Tests: results.Tests, Failures: results.Failures, Suites: []junitTestSuite{suite}}); err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "\n")
+
+	return err
+}
@@ -0,0 +1,174 @@
+package commands
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestRunAccessControlSuite(t *testing.T) {
+	config := &schema.Configuration{
+		AccessControl: schema.AccessControl{
+			DefaultPolicy: "deny",
+			Rules: []schema.AccessControlRule{
+				{Domains: []string{"public.example.com"}, Policy: "bypass"},
+				{Domains: []string{"admin.example.com"}, Subjects: [][]string{{"group:admins"}}, Policy: "two_factor"},
+				{Domains: []string{"*.example.com"}, Policy: "one_factor"},
+			},
+		},
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "suite.yml")
+
+	require.NoError(t, os.WriteFile(path, []byte(`---
+tests:
+  - name: 'public is bypassed'
+    url: 'https://public.example.com/'
+    policy: 'bypass'
+  - name: 'admins use two factor'
+    url: 'https://admin.example.com/'
+    username: 'john'
+    groups: ['admins']
+    policy: 'two_factor'
+    rule: 2
+  - name: 'non-admins are denied'
+    url: 'https://admin.example.com/'
+    username: 'harry'
+    groups: ['dev']
+    policy: 'deny'
+  - url: 'https://app.example.com/'
+    policy: 'one_factor'
+    rule: 1
+  - name: 'invalid policy'
+    url: 'https://app.example.com/'
+    policy: 'two-factor'
+...
+`), 0600))
+
+	suite, err := loadAccessControlSuite(path)
+	require.NoError(t, err)
+	require.Len(t, suite.Tests, 5)
+
+	results := runAccessControlSuite(config, suite, time.Unix(1700000000, 0))
+
+	assert.Equal(t, 5, results.Tests)
+	assert.Equal(t, 3, results.Failures)
+
+	assert.True(t, results.Results[0].Passed)
+	assert.Equal(t, 1, results.Results[0].Rule)
+	assert.True(t, results.Results[1].Passed)
+
+	assert.False(t, results.Results[2].Passed)
+	assert.Equal(t, "one_factor", results.Results[2].Actual)
+	assert.Equal(t, 3, results.Results[2].Rule)
+	assert.Equal(t, "expected policy 'deny' but policy 'one_factor' was applied", results.Results[2].Message())
+	assert.Equal(t, []string{
+		"rule #2 with policy 'two_factor' matched the domain but not the criteria subject",
+		"rule #3 with policy 'one_factor' was applied",
+	}, results.Results[2].Explanation)
+
+	assert.False(t, results.Results[3].Passed)
+	assert.Equal(t, "test #4", results.Results[3].Name)
+	assert.Equal(t, "expected rule #1 to be applied but rule #3 was applied", results.Results[3].Message())
+
+	assert.False(t, results.Results[4].Passed)
+	assert.Equal(t, "the expected policy must be one of 'bypass', 'one_factor', 'two_factor', 'deny', or 'webhook' but it's configured as 'two-factor'", results.Results[4].Message())
+
+	buf := &bytes.Buffer{}
+
+	require.NoError(t, writeAccessControlSuiteResults(buf, results, accessControlSuiteFormatText))
+	assert.Contains(t, buf.String(), "PASS public is bypassed\n")
+	assert.Contains(t, buf.String(), "FAIL non-admins are denied: expected policy 'deny' but policy 'one_factor' was applied\n    rule #2")
+	assert.Contains(t, buf.String(), "\n5 tests, 2 passed, 3 failed\n")
+
+	buf.Reset()
+
+	require.NoError(t, writeAccessControlSuiteResults(buf, results, accessControlSuiteFormatJSON))
+	assert.Contains(t, buf.String(), `"failures": 3`)
+	assert.Contains(t, buf.String(), `"actual_policy": "one_factor"`)
+
+	buf.Reset()
+
+	require.NoError(t, writeAccessControlSuiteResults(buf, results, accessControlSuiteFormatJUnit))
+	assert.Contains(t, buf.String(), `<testsuites tests="5" failures="3">`)
+	assert.Contains(t, buf.String(), `<testcase name="public is bypassed" classname="access-control"></testcase>`)
+	assert.Contains(t, buf.String(), `<failure message="expected policy &#39;deny&#39; but policy &#39;one_factor&#39; was applied">`)
+}
+
+func TestRunAccessControlSuiteShouldNotRequestWebhooks(t *testing.T) {
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	config := &schema.Configuration{
+		AccessControl: schema.AccessControl{
+			DefaultPolicy: "deny",
+			Rules: []schema.AccessControlRule{
+				{Domains: []string{"app.example.com"}, Policy: "webhook", Webhook: schema.AccessControlRuleWebhook{URL: u, Timeout: time.Second, FailurePolicy: "deny"}},
+			},
+		},
+	}
+
+	suite := &AccessControlSuite{Tests: []AccessControlSuiteTest{
+		{Name: "webhook is reported", URL: "https://app.example.com/", Username: "john", Policy: "webhook"},
+		{Name: "webhook is stubbed", URL: "https://app.example.com/", Username: "john", Webhook: "two_factor", Policy: "two_factor"},
+		{Name: "webhook is stubbed with another policy", URL: "https://app.example.com/", Username: "john", Webhook: "one_factor", Policy: "two_factor"},
+		{Name: "webhook is not applied", URL: "https://other.example.com/", Username: "john", Webhook: "one_factor", Policy: "deny"},
+		{Name: "invalid webhook policy", URL: "https://app.example.com/", Username: "john", Webhook: "webhook", Policy: "webhook"},
+	}}
+
+	results := runAccessControlSuite(config, suite, time.Unix(1700000000, 0))
+
+	assert.Equal(t, 0, requests)
+	assert.Equal(t, 2, results.Failures)
+
+	assert.True(t, results.Results[0].Passed)
+	assert.Equal(t, "webhook", results.Results[0].Actual)
+	assert.Equal(t, 1, results.Results[0].Rule)
+
+	assert.True(t, results.Results[1].Passed)
+	assert.Equal(t, "two_factor", results.Results[1].Actual)
+
+	assert.False(t, results.Results[2].Passed)
+	assert.Equal(t, "expected policy 'two_factor' but policy 'one_factor' was applied", results.Results[2].Message())
+
+	assert.True(t, results.Results[3].Passed)
+	assert.Equal(t, 0, results.Results[3].Rule)
+
+	assert.False(t, results.Results[4].Passed)
+	assert.Equal(t, "the webhook policy must be one of 'bypass', 'one_factor', 'two_factor', or 'deny' but it's configured as 'webhook'", results.Results[4].Message())
+}
+
+func TestLoadAccessControlSuiteErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := loadAccessControlSuite(filepath.Join(dir, "missing.yml"))
+	assert.ErrorContains(t, err, "failed to read the access control suite file")
+
+	path := filepath.Join(dir, "empty.yml")
+
+	require.NoError(t, os.WriteFile(path, []byte("tests: []\n"), 0600))
+
+	_, err = loadAccessControlSuite(path)
+	assert.EqualError(t, err, "failed to parse the access control suite file '"+path+"': no tests are defined")
+}
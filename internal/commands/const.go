@@ -53,6 +53,56 @@ your issue.
 `
 	cmdAutheliaBuildInfoExample = `authelia build-info`
 
+	cmdAutheliaUsersShort = "Manage users in the file authentication backend"
+
+	cmdAutheliaUsersLong = `Manage users in the file authentication backend.
+
+This subcommand allows adding, modifying, deleting, and listing the users in the users database file used by the file
+authentication backend. The file is validated and atomically rewritten on every change.`
+
+	cmdAutheliaUsersExample = `authelia users --help`
+
+	cmdAutheliaUsersAddShort = "Add a user to the users database file"
+
+	cmdAutheliaUsersAddLong = `Add a user to the users database file.
+
+This subcommand allows adding a user to the users database file used by the file authentication backend.`
+
+	cmdAutheliaUsersAddExample = `authelia users add john --display-name "John Smith" --email john@example.com --groups admins,dev
+authelia users add john --display-name "John Smith" --email john@example.com --config config.yml
+authelia users add john --display-name "John Smith" --email john@example.com --random --path /config/users.yml`
+
+	cmdAutheliaUsersModifyShort = "Modify a user in the users database file"
+
+	cmdAutheliaUsersModifyLong = `Modify a user in the users database file.
+
+This subcommand allows modifying a user in the users database file used by the file authentication backend. Only the
+attributes with a flag provided are changed.`
+
+	cmdAutheliaUsersModifyExample = `authelia users modify john --groups admins
+authelia users modify john --disabled --config config.yml
+authelia users modify john --random --path /config/users.yml`
+
+	cmdAutheliaUsersDeleteShort = "Delete a user from the users database file"
+
+	cmdAutheliaUsersDeleteLong = `Delete a user from the users database file.
+
+This subcommand allows deleting a user from the users database file used by the file authentication backend.`
+
+	cmdAutheliaUsersDeleteExample = `authelia users delete john
+authelia users delete john --config config.yml
+authelia users delete john --path /config/users.yml`
+
+	cmdAutheliaUsersListShort = "List the users in the users database file"
+
+	cmdAutheliaUsersListLong = `List the users in the users database file.
+
+This subcommand allows listing the users in the users database file used by the file authentication backend.`
+
+	cmdAutheliaUsersListExample = `authelia users list
+authelia users list --config config.yml
+authelia users list --path /config/users.yml`
+
 	cmdAutheliaAccessControlShort = "Helpers for the access control system"
 
 	cmdAutheliaAccessControlLong = `Helpers for the access control system.`
@@ -77,12 +127,38 @@ Notes:
 
 	A rule that potentially matches a request will cause a redirection to occur in order to perform one-factor
 	authentication. This is so Authelia can adequately determine if the rule actually matches.
+
+Suites:
+
+	The suite flag tests a YAML file of requests against the access control rules instead of a single request. The
+	command exits with a non-zero exit code if the policy applied to any request doesn't match the expected policy,
+	and the results can be output in the text, json, or junit formats using the format flag. For example:
+
+	tests:
+	  - name: 'admins can access the admin panel'
+	    url: 'https://admin.example.com/'
+	    method: 'GET'
+	    username: 'john'
+	    groups: ['admins']
+	    ip: '192.168.1.10'
+	    headers:
+	      User-Agent: 'Mozilla/5.0'
+	    time: '2024-01-01T08:00:00Z'
+	    policy: 'two_factor'
+	    rule: 3
+
+	The webhooks of rules with the webhook policy are never requested. The policy of a request matching one of these
+	rules is reported as webhook unless the webhook option declares the policy the webhook is expected to decide.
 `
 	cmdAutheliaAccessControlCheckPolicyExample = `authelia access-control check-policy --config config.yml --url https://example.com
 authelia access-control check-policy --config config.yml --url https://example.com --username john
 authelia access-control check-policy --config config.yml --url https://example.com --groups admin,public
 authelia access-control check-policy --config config.yml --url https://example.com --username john --method GET
-authelia access-control check-policy --config config.yml --url https://example.com --username john --method GET --verbose`
+authelia access-control check-policy --config config.yml --url https://example.com --username john --method GET --verbose
+authelia access-control check-policy --config config.yml --url https://example.com --username john --time 2024-01-01T08:00:00Z
+authelia access-control check-policy --config config.yml --url https://example.com --header 'User-Agent: Nextcloud-android/3.26.0'
+authelia access-control check-policy --config config.yml --suite acl-tests.yml
+authelia access-control check-policy --config config.yml --suite acl-tests.yml --format junit`
 
 	cmdAutheliaStorageShort = "Manage the Authelia storage"
 
@@ -130,6 +206,46 @@ This subcommand allows modifying and exporting user settings.`
 
 	cmdAutheliaStorageUserExample = `authelia storage user --help`
 
+	cmdAutheliaStorageUserAddShort = "Add a user to the SQL authentication backend"
+
+	cmdAutheliaStorageUserAddLong = `Add a user to the SQL authentication backend.
+
+This subcommand allows adding a user directly to the database for use with the SQL authentication backend.`
+
+	cmdAutheliaStorageUserAddExample = `authelia storage user add john --display-name "John Smith" --email john@example.com --groups admins,dev
+authelia storage user add john --display-name "John Smith" --email john@example.com --config config.yml
+authelia storage user add john --display-name "John Smith" --email john@example.com --random --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaStorageUserDisableShort = "Disable a user in the SQL authentication backend"
+
+	cmdAutheliaStorageUserDisableLong = `Disable a user in the SQL authentication backend.
+
+This subcommand allows disabling a user directly in the database for use with the SQL authentication backend.`
+
+	cmdAutheliaStorageUserDisableExample = `authelia storage user disable john
+authelia storage user disable john --config config.yml
+authelia storage user disable john --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaStorageUserEnableShort = "Enable a user in the SQL authentication backend"
+
+	cmdAutheliaStorageUserEnableLong = `Enable a user in the SQL authentication backend.
+
+This subcommand allows enabling a previously disabled user directly in the database for use with the SQL authentication backend.`
+
+	cmdAutheliaStorageUserEnableExample = `authelia storage user enable john
+authelia storage user enable john --config config.yml
+authelia storage user enable john --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaStorageUserPasswordShort = "Set the password of a user in the SQL authentication backend"
+
+	cmdAutheliaStorageUserPasswordLong = `Set the password of a user in the SQL authentication backend.
+
+This subcommand allows setting the password of a user directly in the database for use with the SQL authentication backend.`
+
+	cmdAutheliaStorageUserPasswordExample = `authelia storage user password john
+authelia storage user password john --config config.yml
+authelia storage user password john --random --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
 	cmdAutheliaStorageUserIdentifiersShort = "Manage user opaque identifiers"
 
 	cmdAutheliaStorageUserIdentifiersLong = `Manage user opaque identifiers.
@@ -244,6 +360,36 @@ authelia storage user webauthn delete --kid abc123
 authelia storage user webauthn delete --kid abc123 --config config.yml
 authelia storage user webauthn delete --kid abc123 --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
 
+	cmdAutheliaStorageUserSessionsShort = "Manage user sessions"
+
+	cmdAutheliaStorageUserSessionsLong = `Manage user sessions.
+
+This subcommand allows listing and revoking the sessions recorded in the user session index.`
+
+	cmdAutheliaStorageUserSessionsExample = `authelia storage user sessions --help`
+
+	cmdAutheliaStorageUserSessionsListShort = "List the active sessions of a user"
+
+	cmdAutheliaStorageUserSessionsListLong = `List the active sessions of a user.
+
+This subcommand allows listing the active sessions of a user recorded in the user session index.`
+
+	cmdAutheliaStorageUserSessionsListExample = `authelia storage user sessions list john
+authelia storage user sessions list john --config config.yml
+authelia storage user sessions list john --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaStorageUserSessionsRevokeShort = "Revoke all sessions of a user"
+
+	cmdAutheliaStorageUserSessionsRevokeLong = `Revoke all sessions of a user.
+
+This subcommand allows revoking all of the active sessions of a user recorded in the user session index. The sessions
+are terminated the next time they are used. When OpenID Connect 1.0 is configured each client which holds a session for
+the user and has a back-channel logout URI is also informed of the logout.`
+
+	cmdAutheliaStorageUserSessionsRevokeExample = `authelia storage user sessions revoke john
+authelia storage user sessions revoke john --config config.yml
+authelia storage user sessions revoke john --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
 	cmdAutheliaStorageUserTOTPShort = "Manage TOTP configurations"
 
 	cmdAutheliaStorageUserTOTPLong = `Manage TOTP configurations.
@@ -337,6 +483,70 @@ This subcommand shows advanced information about the storage schema useful in so
 authelia storage schema-info --config config.yml
 authelia storage schema-info --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
 
+	cmdAutheliaStorageAuditShort = "Query the authorization decision audit log"
+
+	cmdAutheliaStorageAuditLong = `Query the authorization decision audit log.
+
+This subcommand allows querying the authorization decisions recorded in the storage backend when the audit log mode is
+storage. The most recent decisions are shown first and can be filtered by the username, domain, and decision.`
+
+	cmdAutheliaStorageAuditExample = `authelia storage audit
+authelia storage audit --username john --since 168h
+authelia storage audit --domain app.example.com --decision deny --format json
+authelia storage audit --config config.yml
+authelia storage audit --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaStorageBansShort = "Manage regulation bans"
+
+	cmdAutheliaStorageBansLong = `Manage regulation bans.
+
+This subcommand allows listing, adding, and revoking the bans of users, remote IP addresses, and subnets which are
+enforced by the regulator.`
+
+	cmdAutheliaStorageBansExample = `authelia storage bans --help`
+
+	cmdAutheliaStorageBansListShort = "List the active bans"
+
+	cmdAutheliaStorageBansListLong = `List the active bans.
+
+This subcommand allows listing the bans which are currently active including the automatic bans made by the regulator
+and the manual bans. The most recent bans are shown first.`
+
+	cmdAutheliaStorageBansListExample = `authelia storage bans list
+authelia storage bans list --limit 20 --page 1
+authelia storage bans list --config config.yml
+authelia storage bans list --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaStorageBansAddShort = "Ban a user, remote IP address, or subnet"
+
+	cmdAutheliaStorageBansAddLong = `Ban a user, remote IP address, or subnet.
+
+This subcommand allows manually banning a user, remote IP address, or subnet for a duration or permanently. Exactly one
+of the username, ip, or subnet flags must be provided. Manual bans are enforced even when regulation is disabled or
+the remote IP address is within the allowed networks. A subnet ban applies to every remote IP address within the subnet
+regardless of the configured regulation prefix lengths.`
+
+	cmdAutheliaStorageBansAddExample = `authelia storage bans add --username john --reason "Compromised account"
+authelia storage bans add --ip 192.168.1.10 --duration "1 week"
+authelia storage bans add --subnet 192.168.1.0/24 --permanent
+authelia storage bans add --subnet 10.0.0.0/16 --duration "1 day"
+authelia storage bans add --username john --config config.yml
+authelia storage bans add --username john --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaStorageBansRevokeShort = "Revoke the bans of a user, remote IP address, or subnet"
+
+	cmdAutheliaStorageBansRevokeLong = `Revoke the bans of a user, remote IP address, or subnet.
+
+This subcommand allows revoking the active bans of a user, remote IP address, or subnet before they expire. Exactly one
+of the username, ip, or subnet flags must be provided. The failed authentication attempts which occurred before the bans
+were revoked no longer count towards a ban.`
+
+	cmdAutheliaStorageBansRevokeExample = `authelia storage bans revoke --username john
+authelia storage bans revoke --ip 192.168.1.10
+authelia storage bans revoke --subnet 2001:db8::/64
+authelia storage bans revoke --username john --config config.yml
+authelia storage bans revoke --username john --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
 	cmdAutheliaStorageMigrateShort = "Perform or list migrations"
 
 	cmdAutheliaStorageMigrateLong = `Perform or list migrations.
@@ -657,6 +867,21 @@ const (
 	cmdFlagNamePath        = "path"
 	cmdFlagNameTarget      = "target"
 	cmdFlagNameDestroyData = "destroy-data"
+	cmdFlagNameDisplayName = "display-name"
+	cmdFlagNameEmail       = "email"
+	cmdFlagNameGroups      = "groups"
+	cmdFlagNameDisabled    = "disabled"
+	cmdFlagNameUsername    = "username"
+	cmdFlagNameDomain      = "domain"
+	cmdFlagNameDecision    = "decision"
+	cmdFlagNameSince       = "since"
+	cmdFlagNameLimit       = "limit"
+	cmdFlagNamePage        = "page"
+	cmdFlagNameFormat      = "format"
+	cmdFlagNameIP          = "ip"
+	cmdFlagNameSubnet      = "subnet"
+	cmdFlagNameReason      = "reason"
+	cmdFlagNamePermanent   = "permanent"
 
 	cmdFlagNameEncryptionKey      = "encryption-key"
 	cmdFlagNameSQLite3Path        = "sqlite.path"
@@ -841,9 +1066,12 @@ const (
 	logFieldService = "service"
 	logFieldFile    = "file"
 	logFieldOP      = "op"
+	logFieldSignal  = "signal"
 
 	serviceTypeServer  = "server"
 	serviceTypeWatcher = "watcher"
+	serviceTypeSignal  = "signal"
+	serviceTypeTicker  = "ticker"
 
 	logFieldProvider            = "provider"
 	logMessageStartupCheckError = "Error occurred running a startup check"
@@ -852,6 +1080,8 @@ const (
 	providerNameStorage      = "storage"
 	providerNameUser         = "user"
 	providerNameNotification = "notification"
+	providerNameAudit        = "audit"
+	providerNameEvents       = "security_events"
 )
 
 const (
@@ -878,6 +1108,17 @@ const (
 	suffixArgon2SaltLength    = ".argon2.salt_length"
 )
 
+const (
+	accessControlSuiteFormatText  = "text"
+	accessControlSuiteFormatJSON  = "json"
+	accessControlSuiteFormatJUnit = "junit"
+)
+
+const (
+	storageAuditFormatText = "text"
+	storageAuditFormatJSON = "json"
+)
+
 var (
 	reYAMLComment = regexp.MustCompile(`^---\n([.\n]*)`)
 )
@@ -15,12 +15,14 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"github.com/authelia/authelia/v4/internal/audit"
 	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/configuration/validator"
+	"github.com/authelia/authelia/v4/internal/events"
 	"github.com/authelia/authelia/v4/internal/logging"
 	"github.com/authelia/authelia/v4/internal/metrics"
 	"github.com/authelia/authelia/v4/internal/middlewares"
@@ -43,6 +45,7 @@ func NewCmdCtx() *CmdCtx {
 	return &CmdCtx{
 		Context: ctx,
 		log:     logging.Logger(),
+		clock:   clock.New(),
 		providers: middlewares.Providers{
 			Random: &random.Cryptographical{},
 		},
@@ -54,7 +57,8 @@ func NewCmdCtx() *CmdCtx {
 type CmdCtx struct {
 	context.Context
 
-	log *logrus.Logger
+	log   *logrus.Logger
+	clock clock.Provider
 
 	config    *schema.Configuration
 	providers middlewares.Providers
@@ -143,10 +147,11 @@ func (ctx *CmdCtx) LoadProviders() (warns, errs []error) {
 
 	ctx.providers.StorageProvider = getStorageProvider(ctx)
 
-	ctx.providers.Authorizer = authorization.NewAuthorizer(ctx.config)
+	ctx.providers.Authorizer = authorization.NewAuthorizer(ctx.config, ctx.clock)
+	ctx.providers.Audit = audit.NewProvider(ctx.config.AccessControl.Audit, ctx.providers.StorageProvider, ctx.clock)
 	ctx.providers.NTP = ntp.NewProvider(&ctx.config.NTP)
 	ctx.providers.PasswordPolicy = middlewares.NewPasswordPolicyProvider(ctx.config.PasswordPolicy)
-	ctx.providers.Regulator = regulation.NewRegulator(ctx.config.Regulation, ctx.providers.StorageProvider, clock.New())
+	ctx.providers.Regulator = regulation.NewRegulator(ctx.config.Regulation, ctx.providers.StorageProvider, ctx.clock)
 	ctx.providers.SessionProvider = session.NewProvider(ctx.config.Session, ctx.trusted)
 	ctx.providers.TOTP = totp.NewTimeBasedProvider(ctx.config.TOTP)
 
@@ -157,6 +162,8 @@ func (ctx *CmdCtx) LoadProviders() (warns, errs []error) {
 		ctx.providers.UserProvider = authentication.NewFileUserProvider(ctx.config.AuthenticationBackend.File)
 	case ctx.config.AuthenticationBackend.LDAP != nil:
 		ctx.providers.UserProvider = authentication.NewLDAPUserProvider(ctx.config.AuthenticationBackend, ctx.trusted)
+	case ctx.config.AuthenticationBackend.SQL != nil:
+		ctx.providers.UserProvider = authentication.NewSQLUserProvider(ctx.config.AuthenticationBackend.SQL, ctx.providers.StorageProvider)
 	}
 
 	if ctx.providers.Templates, err = templates.New(templates.Config{EmailTemplatesPath: ctx.config.Notifier.TemplatePath}); err != nil {
@@ -168,8 +175,12 @@ func (ctx *CmdCtx) LoadProviders() (warns, errs []error) {
 		ctx.providers.Notifier = notification.NewSMTPNotifier(ctx.config.Notifier.SMTP, ctx.trusted)
 	case ctx.config.Notifier.FileSystem != nil:
 		ctx.providers.Notifier = notification.NewFileNotifier(*ctx.config.Notifier.FileSystem)
+	case ctx.config.Notifier.Webhook != nil:
+		ctx.providers.Notifier = notification.NewWebhookNotifier(ctx.config.Notifier.Webhook, ctx.config.Server.Timeouts.Write, ctx.trusted)
 	}
 
+	ctx.providers.Events = events.NewBus(&ctx.config.SecurityEvents, ctx.providers.Notifier, ctx.providers.Templates, ctx.trusted)
+
 	ctx.providers.OpenIDConnect = oidc.NewOpenIDConnectProvider(ctx.config.IdentityProviders.OIDC, ctx.providers.StorageProvider, ctx.providers.Templates)
 
 	if ctx.config.Telemetry.Metrics.Enabled {
@@ -12,6 +12,7 @@ import (
 	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/configuration"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/configuration/validator"
 )
 
 func newCryptoHashCmd(ctx *CmdCtx) (cmd *cobra.Command) {
@@ -304,6 +305,46 @@ func (ctx *CmdCtx) CryptoHashGenerateRunE(cmd *cobra.Command, args []string) (er
 	return nil
 }
 
+// cmdPasswordDigest reads the password using the password flags or terminal and hashes it using the provided password
+// configuration after applying the defaults and validating it.
+func cmdPasswordDigest(cmd *cobra.Command, args []string, config schema.AuthenticationBackendFilePassword) (digest algorithm.Digest, err error) {
+	var (
+		hash     algorithm.Hash
+		password string
+		random   bool
+	)
+
+	if password, random, err = cmdCryptoHashGetPassword(cmd, args, false, true); err != nil {
+		return nil, err
+	}
+
+	if len(password) == 0 {
+		return nil, fmt.Errorf("no password provided")
+	}
+
+	val := &schema.StructValidator{}
+
+	validator.ValidatePasswordConfiguration(&config, val)
+
+	if errs := val.Errors(); len(errs) != 0 {
+		return nil, fmt.Errorf("failed to validate the password configuration: %w", errs[0])
+	}
+
+	if hash, err = authentication.NewFileCryptoHashFromConfig(config); err != nil {
+		return nil, err
+	}
+
+	if digest, err = hash.Hash(password); err != nil {
+		return nil, err
+	}
+
+	if random {
+		fmt.Printf("Random Password: %s\n", password)
+	}
+
+	return digest, nil
+}
+
 func cmdCryptoHashGetPassword(cmd *cobra.Command, args []string, useArgs, useRandom bool) (password string, random bool, err error) {
 	if useRandom {
 		if random, err = cmd.Flags().GetBool(cmdFlagNameRandom); err != nil {
@@ -46,6 +46,7 @@ func NewRootCmd() (cmd *cobra.Command) {
 		newBuildInfoCmd(ctx),
 		newCryptoCmd(ctx),
 		newStorageCmd(ctx),
+		newUsersCmd(ctx),
 		newConfigCmd(ctx),
 		newConfigValidateLegacyCmd(ctx),
 
@@ -146,6 +147,30 @@ func doStartupChecks(ctx *CmdCtx) {
 		ctx.log.WithFields(map[string]any{logFieldProvider: providerNameNTP}).Trace("Startup Check Completed Successfully")
 	}
 
+	if ctx.providers.Audit != nil {
+		ctx.log.WithFields(map[string]any{logFieldProvider: providerNameAudit}).Trace("Performing Startup Check")
+
+		if err = doStartupCheck(ctx, providerNameAudit, ctx.providers.Audit, false); err != nil {
+			ctx.log.WithError(err).WithField(logFieldProvider, providerNameAudit).Error(logMessageStartupCheckError)
+
+			failures = append(failures, providerNameAudit)
+		} else {
+			ctx.log.WithFields(map[string]any{logFieldProvider: providerNameAudit}).Trace("Startup Check Completed Successfully")
+		}
+	}
+
+	if ctx.providers.Events != nil {
+		ctx.log.WithFields(map[string]any{logFieldProvider: providerNameEvents}).Trace("Performing Startup Check")
+
+		if err = doStartupCheck(ctx, providerNameEvents, ctx.providers.Events, false); err != nil {
+			ctx.log.WithError(err).WithField(logFieldProvider, providerNameEvents).Error(logMessageStartupCheckError)
+
+			failures = append(failures, providerNameEvents)
+		} else {
+			ctx.log.WithFields(map[string]any{logFieldProvider: providerNameEvents}).Trace("Startup Check Completed Successfully")
+		}
+	}
+
 	if len(failures) != 0 {
 		ctx.log.WithField("providers", failures).Fatalf("One or more providers had fatal failures performing startup checks, for more detail check the error level logs")
 	}
@@ -3,10 +3,12 @@ package commands
 import (
 	"context"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"sync"
 	"syscall"
@@ -17,7 +19,11 @@ import (
 	"github.com/valyala/fasthttp"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/authelia/authelia/v4/internal/audit"
 	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/configuration/validator"
 	"github.com/authelia/authelia/v4/internal/server"
 )
 
@@ -83,6 +89,29 @@ func NewFileWatcherService(name, path string, reload ProviderReload, log *logrus
 	return service, nil
 }
 
+// NewSignalService creates a new SignalService with the appropriate logger etc.
+func NewSignalService(name string, reload ProviderReload, log *logrus.Logger, signals ...os.Signal) (service *SignalService) {
+	return &SignalService{
+		name:    name,
+		signals: signals,
+		reload:  reload,
+		notify:  make(chan os.Signal, 1),
+		quit:    make(chan struct{}),
+		log:     log.WithFields(map[string]any{logFieldService: serviceTypeSignal, serviceTypeSignal: name}),
+	}
+}
+
+// NewTickerService creates a new TickerService with the appropriate logger etc.
+func NewTickerService(name string, interval time.Duration, task func() (err error), log *logrus.Logger) (service *TickerService) {
+	return &TickerService{
+		name:     name,
+		interval: interval,
+		task:     task,
+		quit:     make(chan struct{}),
+		log:      log.WithFields(map[string]any{logFieldService: serviceTypeTicker, serviceTypeTicker: name}),
+	}
+}
+
 // ProviderReload represents the required methods to support reloading a provider.
 type ProviderReload interface {
 	Reload() (reloaded bool, err error)
@@ -246,6 +275,216 @@ func (service *FileWatcherService) Log() *logrus.Entry {
 	return service.log
 }
 
+// SignalService is a Service that reloads a provider when a process signal is received.
+type SignalService struct {
+	name string
+
+	signals []os.Signal
+	reload  ProviderReload
+
+	notify chan os.Signal
+	quit   chan struct{}
+
+	log *logrus.Entry
+}
+
+// ServiceType returns the service type for this service, which is always 'signal'.
+func (service *SignalService) ServiceType() string {
+	return serviceTypeSignal
+}
+
+// ServiceName returns the individual name for this service.
+func (service *SignalService) ServiceName() string {
+	return service.name
+}
+
+// Run the SignalService.
+func (service *SignalService) Run() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			service.log.WithError(recoverErr(r)).Error("Critical error caught (recovered)")
+		}
+	}()
+
+	signal.Notify(service.notify, service.signals...)
+
+	service.log.Info("Listening for process signals")
+
+	for {
+		select {
+		case <-service.quit:
+			return nil
+		case s := <-service.notify:
+			log := service.log.WithField(logFieldSignal, s.String())
+
+			log.Debug("Process signal was received")
+
+			var reloaded bool
+
+			switch reloaded, err = service.reload.Reload(); {
+			case err != nil:
+				log.WithError(err).Error("Error occurred during reload")
+			case reloaded:
+				log.Info("Reloaded successfully")
+			default:
+				log.Debug("Reload was triggered but it was skipped")
+			}
+		}
+	}
+}
+
+// Shutdown the SignalService.
+func (service *SignalService) Shutdown() {
+	signal.Stop(service.notify)
+
+	close(service.quit)
+}
+
+// Log returns the *logrus.Entry of the SignalService.
+func (service *SignalService) Log() *logrus.Entry {
+	return service.log
+}
+
+// TickerService is a Service which runs a task at startup and then at a regular interval.
+type TickerService struct {
+	name string
+
+	interval time.Duration
+	task     func() (err error)
+
+	quit chan struct{}
+
+	log *logrus.Entry
+}
+
+// ServiceType returns the service type for this service, which is always 'ticker'.
+func (service *TickerService) ServiceType() string {
+	return serviceTypeTicker
+}
+
+// ServiceName returns the individual name for this service.
+func (service *TickerService) ServiceName() string {
+	return service.name
+}
+
+// Run the TickerService.
+func (service *TickerService) Run() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			service.log.WithError(recoverErr(r)).Error("Critical error caught (recovered)")
+		}
+	}()
+
+	ticker := time.NewTicker(service.interval)
+
+	defer ticker.Stop()
+
+	service.log.WithField("interval", service.interval.String()).Info("Running task on an interval")
+
+	for {
+		if err = service.task(); err != nil {
+			service.log.WithError(err).Error("Error occurred running the task")
+		}
+
+		select {
+		case <-service.quit:
+			return nil
+		case <-ticker.C:
+			continue
+		}
+	}
+}
+
+// Shutdown the TickerService.
+func (service *TickerService) Shutdown() {
+	close(service.quit)
+}
+
+// Log returns the *logrus.Entry of the TickerService.
+func (service *TickerService) Log() *logrus.Entry {
+	return service.log
+}
+
+// NewAccessControlReloader creates a new AccessControlReloader for the provided CmdCtx.
+func NewAccessControlReloader(ctx *CmdCtx) (reloader *AccessControlReloader) {
+	return &AccessControlReloader{
+		ctx:     ctx,
+		current: ctx.config.AccessControl,
+		log:     ctx.log.WithField(logFieldProvider, "access_control"),
+	}
+}
+
+// AccessControlReloader is a ProviderReload which loads the access control configuration from the configuration
+// sources and atomically replaces the rules of the authorizer.
+type AccessControlReloader struct {
+	ctx     *CmdCtx
+	current schema.AccessControl
+	log     *logrus.Entry
+
+	mu sync.Mutex
+}
+
+// Reload the access control configuration. The configuration is validated before it's applied and the current rules
+// are kept when it's invalid.
+func (reloader *AccessControlReloader) Reload() (reloaded bool, err error) {
+	reloader.mu.Lock()
+
+	defer reloader.mu.Unlock()
+
+	var filters []configuration.BytesFilter
+
+	if filters, err = configuration.NewFileFilters(reloader.ctx.cconfig.filters); err != nil {
+		return false, fmt.Errorf("error occurred loading the configuration filters: %w", err)
+	}
+
+	sources := configuration.NewDefaultSourcesWithDefaults(
+		reloader.ctx.cconfig.files,
+		filters,
+		configuration.DefaultEnvPrefix,
+		configuration.DefaultEnvDelimiter,
+		reloader.ctx.cconfig.defaults)
+
+	config := &schema.Configuration{}
+	val := schema.NewStructValidator()
+
+	if _, err = configuration.LoadAdvanced(val, "", config, sources...); err != nil {
+		return false, fmt.Errorf("error occurred loading the configuration: %w", err)
+	}
+
+	validator.ValidateAccessControl(config, val)
+	validator.ValidateRules(config, val)
+
+	for _, warning := range val.Warnings() {
+		reloader.log.Warnf("Configuration: %+v", warning)
+	}
+
+	if errs := val.Errors(); len(errs) != 0 {
+		for i, e := range errs {
+			if i == 0 {
+				err = e
+				continue
+			}
+
+			err = fmt.Errorf("%v, %w", err, e)
+		}
+
+		return false, fmt.Errorf("error occurred validating the access control configuration, the current rules will continue to be used: %w", err)
+	}
+
+	if reflect.DeepEqual(reloader.current, config.AccessControl) {
+		return false, nil
+	}
+
+	reloader.ctx.providers.Authorizer.Update(&schema.Configuration{
+		AccessControl:     config.AccessControl,
+		IdentityProviders: reloader.ctx.config.IdentityProviders,
+	})
+
+	reloader.current = config.AccessControl
+
+	return true, nil
+}
+
 func svcSvrMainFunc(ctx *CmdCtx) (service Service) {
 	switch svr, listener, paths, isTLS, err := server.CreateDefaultServer(ctx.config, ctx.providers); {
 	case err != nil:
@@ -286,6 +525,61 @@ func svcWatcherUsersFunc(ctx *CmdCtx) (service Service) {
 	return service
 }
 
+func svcTickerAuditRetentionFunc(ctx *CmdCtx) (service Service) {
+	config := ctx.config.AccessControl.Audit
+
+	pruner, ok := ctx.providers.Audit.(audit.Pruner)
+
+	if !ok || config.Retention <= 0 {
+		return nil
+	}
+
+	log := ctx.log.WithField(logFieldProvider, providerNameAudit)
+
+	return NewTickerService("audit_retention", time.Hour, func() (err error) {
+		var count int64
+
+		if count, err = pruner.Prune(ctx, ctx.clock.Now().Add(-config.Retention)); err != nil {
+			return err
+		}
+
+		log.WithField("count", count).Debug("Pruned authorization decisions which exceeded the retention")
+
+		return nil
+	}, ctx.log)
+}
+
+func svcTickerSecurityEventsDigestFunc(ctx *CmdCtx) (service Service) {
+	if !ctx.providers.Events.Digest() {
+		return nil
+	}
+
+	return NewTickerService("security_events_digest", ctx.config.SecurityEvents.EmailDigest.Interval, func() (err error) {
+		return ctx.providers.Events.FlushDigest(ctx)
+	}, ctx.log)
+}
+
+func svcAccessControlFuncs(ctx *CmdCtx) (services []Service) {
+	reloader := NewAccessControlReloader(ctx)
+
+	services = append(services, NewSignalService("access_control", reloader, ctx.log, syscall.SIGHUP))
+
+	if !ctx.config.AccessControl.Watch {
+		return services
+	}
+
+	for _, path := range ctx.cconfig.files {
+		service, err := NewFileWatcherService("access_control", path, reloader, ctx.log)
+		if err != nil {
+			ctx.log.WithError(err).Fatal("Create Watcher Service (access_control) returned error")
+		}
+
+		services = append(services, service)
+	}
+
+	return services
+}
+
 func connectionType(isTLS bool) string {
 	if isTLS {
 		return "TLS"
@@ -313,7 +607,7 @@ func servicesRun(ctx *CmdCtx) {
 
 	for _, serviceFunc := range []func(ctx *CmdCtx) Service{
 		svcSvrMainFunc, svcSvrMetricsFunc,
-		svcWatcherUsersFunc,
+		svcWatcherUsersFunc, svcTickerAuditRetentionFunc, svcTickerSecurityEventsDigestFunc,
 	} {
 		if service := serviceFunc(ctx); service != nil {
 			service.Log().Trace("Service Loaded")
@@ -324,6 +618,14 @@ func servicesRun(ctx *CmdCtx) {
 		}
 	}
 
+	for _, service := range svcAccessControlFuncs(ctx) {
+		service.Log().Trace("Service Loaded")
+
+		services = append(services, service)
+
+		group.Go(service.Run)
+	}
+
 	ctx.log.Info("Startup complete")
 
 	select {
@@ -359,6 +661,16 @@ func servicesRun(ctx *CmdCtx) {
 
 	var err error
 
+	if closer, ok := ctx.providers.Audit.(io.Closer); ok {
+		if err = closer.Close(); err != nil {
+			ctx.log.WithError(err).Error("Error occurred closing the audit log")
+		}
+	}
+
+	if err = ctx.providers.Events.Close(); err != nil {
+		ctx.log.WithError(err).Error("Error occurred closing the security event subscribers")
+	}
+
 	if err = ctx.providers.StorageProvider.Close(); err != nil {
 		ctx.log.WithError(err).Error("Error occurred closing database connections")
 	}
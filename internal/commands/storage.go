@@ -3,6 +3,7 @@ package commands
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -50,6 +51,8 @@ func newStorageCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 	cmd.AddCommand(
 		newStorageMigrateCmd(ctx),
 		newStorageSchemaInfoCmd(ctx),
+		newStorageAuditCmd(ctx),
+		newStorageBansCmd(ctx),
 		newStorageEncryptionCmd(ctx),
 		newStorageUserCmd(ctx),
 	)
@@ -122,7 +125,12 @@ func newStorageUserCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 	}
 
 	cmd.AddCommand(
+		newStorageUserAddCmd(ctx),
+		newStorageUserDisableCmd(ctx),
+		newStorageUserEnableCmd(ctx),
+		newStorageUserPasswordCmd(ctx),
 		newStorageUserIdentifiersCmd(ctx),
+		newStorageUserSessionsCmd(ctx),
 		newStorageUserTOTPCmd(ctx),
 		newStorageUserWebAuthnCmd(ctx),
 	)
@@ -130,6 +138,76 @@ func newStorageUserCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 	return cmd
 }
 
+func newStorageUserAddCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "add <username>",
+		Short:   cmdAutheliaStorageUserAddShort,
+		Long:    cmdAutheliaStorageUserAddLong,
+		Example: cmdAutheliaStorageUserAddExample,
+		RunE:    ctx.StorageUserAddRunE,
+		Args:    cobra.ExactArgs(1),
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().String(cmdFlagNameDisplayName, "", "the display name of the user")
+	cmd.Flags().String(cmdFlagNameEmail, "", "the email address of the user")
+	cmd.Flags().StringSlice(cmdFlagNameGroups, nil, "the groups the user is a member of")
+
+	cmdFlagPassword(cmd, true)
+	cmdFlagRandomPassword(cmd)
+
+	return cmd
+}
+
+func newStorageUserDisableCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "disable <username>",
+		Short:   cmdAutheliaStorageUserDisableShort,
+		Long:    cmdAutheliaStorageUserDisableLong,
+		Example: cmdAutheliaStorageUserDisableExample,
+		RunE:    ctx.StorageUserDisableRunE,
+		Args:    cobra.ExactArgs(1),
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
+func newStorageUserEnableCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "enable <username>",
+		Short:   cmdAutheliaStorageUserEnableShort,
+		Long:    cmdAutheliaStorageUserEnableLong,
+		Example: cmdAutheliaStorageUserEnableExample,
+		RunE:    ctx.StorageUserEnableRunE,
+		Args:    cobra.ExactArgs(1),
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
+func newStorageUserPasswordCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "password <username>",
+		Short:   cmdAutheliaStorageUserPasswordShort,
+		Long:    cmdAutheliaStorageUserPasswordLong,
+		Example: cmdAutheliaStorageUserPasswordExample,
+		RunE:    ctx.StorageUserPasswordRunE,
+		Args:    cobra.ExactArgs(1),
+
+		DisableAutoGenTag: true,
+	}
+
+	cmdFlagPassword(cmd, true)
+	cmdFlagRandomPassword(cmd)
+
+	return cmd
+}
+
 func newStorageUserIdentifiersCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 	cmd = &cobra.Command{
 		Use:     "identifiers",
@@ -308,6 +386,55 @@ func newStorageUserWebAuthnDeleteCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 	return cmd
 }
 
+func newStorageUserSessionsCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "sessions",
+		Short:   cmdAutheliaStorageUserSessionsShort,
+		Long:    cmdAutheliaStorageUserSessionsLong,
+		Example: cmdAutheliaStorageUserSessionsExample,
+		Args:    cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.AddCommand(
+		newStorageUserSessionsListCmd(ctx),
+		newStorageUserSessionsRevokeCmd(ctx),
+	)
+
+	return cmd
+}
+
+func newStorageUserSessionsListCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "list <username>",
+		Short:   cmdAutheliaStorageUserSessionsListShort,
+		Long:    cmdAutheliaStorageUserSessionsListLong,
+		Example: cmdAutheliaStorageUserSessionsListExample,
+		RunE:    ctx.StorageUserSessionsListRunE,
+		Args:    cobra.ExactArgs(1),
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
+func newStorageUserSessionsRevokeCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "revoke <username>",
+		Short:   cmdAutheliaStorageUserSessionsRevokeShort,
+		Long:    cmdAutheliaStorageUserSessionsRevokeLong,
+		Example: cmdAutheliaStorageUserSessionsRevokeExample,
+		RunE:    ctx.StorageUserSessionsRevokeRunE,
+		Args:    cobra.ExactArgs(1),
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
 func newStorageUserTOTPCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 	cmd = &cobra.Command{
 		Use:     "totp",
@@ -470,6 +597,120 @@ func newStorageSchemaInfoCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 	return cmd
 }
 
+func newStorageAuditCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "audit",
+		Short:   cmdAutheliaStorageAuditShort,
+		Long:    cmdAutheliaStorageAuditLong,
+		Example: cmdAutheliaStorageAuditExample,
+		RunE:    ctx.StorageAuditRunE,
+		Args:    cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().String(cmdFlagNameUsername, "", "only show decisions for this username")
+	cmd.Flags().String(cmdFlagNameDomain, "", "only show decisions for this domain")
+	cmd.Flags().String(cmdFlagNameDecision, "", "only show decisions of this type, options are 'allow', 'deny', and 'redirect'")
+	cmd.Flags().Duration(cmdFlagNameSince, time.Hour*24, "only show decisions made within this duration")
+	cmd.Flags().Int(cmdFlagNameLimit, 100, "the maximum number of decisions to show")
+	cmd.Flags().Int(cmdFlagNamePage, 0, "the page of decisions to show starting at 0")
+	cmd.Flags().String(cmdFlagNameFormat, storageAuditFormatText, fmt.Sprintf("the output format, options are '%s' and '%s'", storageAuditFormatText, storageAuditFormatJSON))
+
+	return cmd
+}
+
+func newStorageBansCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "bans",
+		Short:   cmdAutheliaStorageBansShort,
+		Long:    cmdAutheliaStorageBansLong,
+		Example: cmdAutheliaStorageBansExample,
+		Args:    cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.AddCommand(
+		newStorageBansListCmd(ctx),
+		newStorageBansAddCmd(ctx),
+		newStorageBansRevokeCmd(ctx),
+	)
+
+	return cmd
+}
+
+func newStorageBansListCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "list",
+		Short:   cmdAutheliaStorageBansListShort,
+		Long:    cmdAutheliaStorageBansListLong,
+		Example: cmdAutheliaStorageBansListExample,
+		RunE:    ctx.StorageBansListRunE,
+		Args:    cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().Int(cmdFlagNameLimit, 100, "the maximum number of bans to show")
+	cmd.Flags().Int(cmdFlagNamePage, 0, "the page of bans to show starting at 0")
+	cmd.Flags().String("time", "", "the time to list the active bans at in the RFC3339 format, defaults to the current time")
+
+	return cmd
+}
+
+func newStorageBansAddCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "add",
+		Short:   cmdAutheliaStorageBansAddShort,
+		Long:    cmdAutheliaStorageBansAddLong,
+		Example: cmdAutheliaStorageBansAddExample,
+		RunE:    ctx.StorageBansAddRunE,
+		Args:    cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	storageBansTargetFlags(cmd, "ban")
+
+	cmd.Flags().String(cmdFlagNameReason, "", "the reason for the ban")
+	cmd.Flags().String(cmdFlagNameDuration, "1 day", "the duration of the ban")
+	cmd.Flags().Bool(cmdFlagNamePermanent, false, "ban permanently until the ban is revoked")
+	cmd.Flags().String("time", "", "the time the ban starts at in the RFC3339 format, defaults to the current time")
+
+	cmd.MarkFlagsMutuallyExclusive(cmdFlagNameDuration, cmdFlagNamePermanent)
+
+	return cmd
+}
+
+func newStorageBansRevokeCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "revoke",
+		Short:   cmdAutheliaStorageBansRevokeShort,
+		Long:    cmdAutheliaStorageBansRevokeLong,
+		Example: cmdAutheliaStorageBansRevokeExample,
+		RunE:    ctx.StorageBansRevokeRunE,
+		Args:    cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	storageBansTargetFlags(cmd, "revoke the bans of")
+
+	cmd.Flags().String("time", "", "the time the bans are revoked at in the RFC3339 format, defaults to the current time")
+
+	return cmd
+}
+
+func storageBansTargetFlags(cmd *cobra.Command, action string) {
+	cmd.Flags().String(cmdFlagNameUsername, "", fmt.Sprintf("the username to %s", action))
+	cmd.Flags().String(cmdFlagNameIP, "", fmt.Sprintf("the remote ip address to %s", action))
+	cmd.Flags().String(cmdFlagNameSubnet, "", fmt.Sprintf("the subnet in CIDR notation to %s", action))
+
+	cmd.MarkFlagsOneRequired(cmdFlagNameUsername, cmdFlagNameIP, cmdFlagNameSubnet)
+	cmd.MarkFlagsMutuallyExclusive(cmdFlagNameUsername, cmdFlagNameIP, cmdFlagNameSubnet)
+}
+
 // newStorageMigrateCmd returns a new Migration Cmd.
 func newStorageMigrateCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 	cmd = &cobra.Command{
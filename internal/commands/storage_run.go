@@ -3,23 +3,30 @@ package commands
 import (
 	"bytes"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"image"
 	"image/png"
+	"io"
+	"net"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
 	"text/tabwriter"
+	"time"
 
+	"github.com/go-crypt/crypt/algorithm"
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 
 	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/configuration/validator"
 	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/oidc"
 	"github.com/authelia/authelia/v4/internal/random"
 	"github.com/authelia/authelia/v4/internal/storage"
 	"github.com/authelia/authelia/v4/internal/totp"
@@ -427,6 +434,348 @@ func (ctx *CmdCtx) StorageSchemaInfoRunE(_ *cobra.Command, _ []string) (err erro
 	return nil
 }
 
+// StorageAuditRunE is the RunE for the authelia storage audit command.
+func (ctx *CmdCtx) StorageAuditRunE(cmd *cobra.Command, _ []string) (err error) {
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	var (
+		username, domain, decision, format string
+		since                              time.Duration
+		limit, page                        int
+		decisions                          []model.AuthorizationDecision
+	)
+
+	if username, err = cmd.Flags().GetString(cmdFlagNameUsername); err != nil {
+		return err
+	}
+
+	if domain, err = cmd.Flags().GetString(cmdFlagNameDomain); err != nil {
+		return err
+	}
+
+	if decision, err = cmd.Flags().GetString(cmdFlagNameDecision); err != nil {
+		return err
+	}
+
+	switch decision {
+	case "", model.AuthorizationDecisionAllow, model.AuthorizationDecisionDeny, model.AuthorizationDecisionRedirect:
+		break
+	default:
+		return fmt.Errorf("the decision flag value '%s' is invalid: must be one of '%s', '%s', or '%s'", decision, model.AuthorizationDecisionAllow, model.AuthorizationDecisionDeny, model.AuthorizationDecisionRedirect)
+	}
+
+	if since, err = cmd.Flags().GetDuration(cmdFlagNameSince); err != nil {
+		return err
+	}
+
+	if limit, err = cmd.Flags().GetInt(cmdFlagNameLimit); err != nil {
+		return err
+	}
+
+	if page, err = cmd.Flags().GetInt(cmdFlagNamePage); err != nil {
+		return err
+	}
+
+	if limit <= 0 || page < 0 {
+		return fmt.Errorf("the limit flag value must be greater than 0 and the page flag value must not be negative")
+	}
+
+	if format, err = cmd.Flags().GetString(cmdFlagNameFormat); err != nil {
+		return err
+	}
+
+	if format != storageAuditFormatText && format != storageAuditFormatJSON {
+		return fmt.Errorf("the format flag value '%s' is invalid: must be one of '%s' or '%s'", format, storageAuditFormatText, storageAuditFormatJSON)
+	}
+
+	if err = ctx.CheckSchema(); err != nil {
+		return storageWrapCheckSchemaErr(err)
+	}
+
+	if decisions, err = ctx.providers.StorageProvider.LoadAuthorizationDecisions(ctx, username, domain, decision, ctx.clock.Now().Add(-since), limit, page); err != nil {
+		return fmt.Errorf("can't load authorization decisions: %w", err)
+	}
+
+	return writeStorageAuditDecisions(os.Stdout, decisions, format)
+}
+
+func writeStorageAuditDecisions(w io.Writer, decisions []model.AuthorizationDecision, format string) (err error) {
+	if format == storageAuditFormatJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(decisions)
+	}
+
+	if len(decisions) == 0 {
+		_, err = fmt.Fprintln(w, "No authorization decisions were found")
+
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 1, 1, 4, ' ', 0)
+
+	_, _ = fmt.Fprintln(tw, "Time\tDecision\tStatus\tUsername\tRemote IP\tMethod\tURL\tRule\tRequired\tCurrent\tImplementation")
+
+	for _, d := range decisions {
+		rule := "default"
+
+		if d.RulePosition != 0 {
+			rule = fmt.Sprintf("#%d", d.RulePosition)
+		}
+
+		username := d.Username
+
+		if username == "" {
+			username = "<anonymous>"
+		}
+
+		ip := "N/A"
+
+		if d.RemoteIP.IP != nil {
+			ip = d.RemoteIP.IP.String()
+		}
+
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", d.Time.Format(time.RFC3339), d.Decision, d.StatusCode,
+			username, ip, d.RequestMethod, d.RequestURI, rule, d.RequiredLevel, d.CurrentLevel, d.Implementation)
+	}
+
+	return tw.Flush()
+}
+
+// StorageBansListRunE is the RunE for the authelia storage bans list command.
+func (ctx *CmdCtx) StorageBansListRunE(cmd *cobra.Command, _ []string) (err error) {
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	var (
+		limit, page int
+		bans        []model.RegulationBan
+		provider    clock.Provider
+	)
+
+	if limit, err = cmd.Flags().GetInt(cmdFlagNameLimit); err != nil {
+		return err
+	}
+
+	if page, err = cmd.Flags().GetInt(cmdFlagNamePage); err != nil {
+		return err
+	}
+
+	if limit <= 0 || page < 0 {
+		return fmt.Errorf("the limit flag value must be greater than 0 and the page flag value must not be negative")
+	}
+
+	if provider, err = getClockFromFlags(cmd); err != nil {
+		return err
+	}
+
+	if err = ctx.CheckSchema(); err != nil {
+		return storageWrapCheckSchemaErr(err)
+	}
+
+	if bans, err = ctx.providers.StorageProvider.LoadActiveRegulationBans(ctx, provider.Now(), limit, page); err != nil {
+		return fmt.Errorf("can't list bans: %w", err)
+	}
+
+	return writeStorageBans(os.Stdout, bans)
+}
+
+func writeStorageBans(w io.Writer, bans []model.RegulationBan) (err error) {
+	if len(bans) == 0 {
+		_, err = fmt.Fprintln(w, "No active bans were found")
+
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 1, 1, 4, ' ', 0)
+
+	_, _ = fmt.Fprintln(tw, "ID\tKind\tValue\tSource\tCreated\tExpires\tReason")
+
+	for _, ban := range bans {
+		expires := "never"
+
+		if !ban.IsPermanent() {
+			expires = ban.ExpiresAt.Time.Format(time.RFC3339)
+		}
+
+		reason := ban.Reason
+
+		if reason == "" {
+			reason = "N/A"
+		}
+
+		_, _ = fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n", ban.ID, ban.Kind, ban.Value, ban.Source, ban.CreatedAt.Format(time.RFC3339), expires, reason)
+	}
+
+	return tw.Flush()
+}
+
+// StorageBansAddRunE is the RunE for the authelia storage bans add command.
+func (ctx *CmdCtx) StorageBansAddRunE(cmd *cobra.Command, _ []string) (err error) {
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	var (
+		kind, value, reason, durationStr string
+		permanent                        bool
+		duration                         time.Duration
+		provider                         clock.Provider
+	)
+
+	if kind, value, err = storageBansTargetFromFlags(cmd); err != nil {
+		return err
+	}
+
+	if reason, err = cmd.Flags().GetString(cmdFlagNameReason); err != nil {
+		return err
+	}
+
+	if permanent, err = cmd.Flags().GetBool(cmdFlagNamePermanent); err != nil {
+		return err
+	}
+
+	if provider, err = getClockFromFlags(cmd); err != nil {
+		return err
+	}
+
+	now := provider.Now()
+
+	ban := model.RegulationBan{
+		CreatedAt: now,
+		Kind:      kind,
+		Value:     value,
+		Source:    model.RegulationBanSourceManual,
+		Reason:    reason,
+	}
+
+	if !permanent {
+		if durationStr, err = cmd.Flags().GetString(cmdFlagNameDuration); err != nil {
+			return err
+		}
+
+		if duration, err = utils.ParseDurationString(durationStr); err != nil {
+			return fmt.Errorf("failed to parse duration string: %w", err)
+		}
+
+		if duration <= 0 {
+			return fmt.Errorf("the duration flag value must be greater than 0")
+		}
+
+		ban.ExpiresAt = sql.NullTime{Time: now.Add(duration), Valid: true}
+	}
+
+	if err = ctx.CheckSchema(); err != nil {
+		return storageWrapCheckSchemaErr(err)
+	}
+
+	if err = ctx.providers.StorageProvider.SaveRegulationBan(ctx, ban); err != nil {
+		return fmt.Errorf("can't ban %s '%s': %w", kind, value, err)
+	}
+
+	if permanent {
+		fmt.Printf("Successfully banned %s '%s' permanently\n", kind, value)
+	} else {
+		fmt.Printf("Successfully banned %s '%s' until %s\n", kind, value, ban.ExpiresAt.Time.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// StorageBansRevokeRunE is the RunE for the authelia storage bans revoke command.
+func (ctx *CmdCtx) StorageBansRevokeRunE(cmd *cobra.Command, _ []string) (err error) {
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	var (
+		kind, value string
+		count       int64
+		provider    clock.Provider
+	)
+
+	if kind, value, err = storageBansTargetFromFlags(cmd); err != nil {
+		return err
+	}
+
+	if provider, err = getClockFromFlags(cmd); err != nil {
+		return err
+	}
+
+	if err = ctx.CheckSchema(); err != nil {
+		return storageWrapCheckSchemaErr(err)
+	}
+
+	now := provider.Now()
+
+	if count, err = ctx.providers.StorageProvider.RevokeRegulationBans(ctx, kind, value, now); err != nil {
+		return fmt.Errorf("can't revoke bans of %s '%s': %w", kind, value, err)
+	}
+
+	// The regulator ignores the failed authentication attempts which occurred before a ban was revoked. When there is no
+	// active ban a revoked ban is saved so the failed authentication attempts which occurred so far are also ignored.
+	if count == 0 {
+		if err = ctx.providers.StorageProvider.SaveRegulationBan(ctx, model.RegulationBan{
+			CreatedAt: now,
+			ExpiresAt: sql.NullTime{Time: now, Valid: true},
+			RevokedAt: sql.NullTime{Time: now, Valid: true},
+			Kind:      kind,
+			Value:     value,
+			Source:    model.RegulationBanSourceManual,
+		}); err != nil {
+			return fmt.Errorf("can't revoke bans of %s '%s': %w", kind, value, err)
+		}
+	}
+
+	fmt.Printf("Successfully revoked %d bans of %s '%s'\n", count, kind, value)
+
+	return nil
+}
+
+// storageBansTargetFromFlags returns the kind and the normalized value of the user, remote ip, or subnet from the flags.
+func storageBansTargetFromFlags(cmd *cobra.Command) (kind, value string, err error) {
+	var username, ip, subnet string
+
+	if username, err = cmd.Flags().GetString(cmdFlagNameUsername); err != nil {
+		return "", "", err
+	}
+
+	if ip, err = cmd.Flags().GetString(cmdFlagNameIP); err != nil {
+		return "", "", err
+	}
+
+	if subnet, err = cmd.Flags().GetString(cmdFlagNameSubnet); err != nil {
+		return "", "", err
+	}
+
+	switch {
+	case username != "":
+		return model.RegulationBanKindUser, username, nil
+	case ip != "":
+		var parsed net.IP
+
+		if parsed = net.ParseIP(ip); parsed == nil {
+			return "", "", fmt.Errorf("the ip flag value '%s' is not a valid ip address", ip)
+		}
+
+		return model.RegulationBanKindIP, parsed.String(), nil
+	case subnet != "":
+		var network *net.IPNet
+
+		if _, network, err = net.ParseCIDR(subnet); err != nil {
+			return "", "", fmt.Errorf("the subnet flag value '%s' is not a valid subnet in CIDR notation", subnet)
+		}
+
+		return model.RegulationBanKindSubnet, network.String(), nil
+	default:
+		return "", "", fmt.Errorf("one of the username, ip, or subnet flags must be provided")
+	}
+}
+
 func (ctx *CmdCtx) StorageUserWebAuthnExportRunE(cmd *cobra.Command, args []string) (err error) {
 	defer func() {
 		_ = ctx.providers.StorageProvider.Close()
@@ -542,6 +891,118 @@ func (ctx *CmdCtx) StorageUserWebAuthnImportRunE(cmd *cobra.Command, args []stri
 	return nil
 }
 
+// StorageUserSessionsListRunE is the RunE for the authelia storage user sessions list command.
+func (ctx *CmdCtx) StorageUserSessionsListRunE(_ *cobra.Command, args []string) (err error) {
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	if err = ctx.CheckSchema(); err != nil {
+		return storageWrapCheckSchemaErr(err)
+	}
+
+	var sessions []model.UserSession
+
+	user := args[0]
+
+	if sessions, err = ctx.providers.StorageProvider.LoadUserSessions(ctx, user, ctx.clock.Now()); err != nil {
+		return fmt.Errorf("can't list sessions for user '%s': %w", user, err)
+	}
+
+	if len(sessions) == 0 {
+		return fmt.Errorf("user '%s' has no active sessions", user)
+	}
+
+	fmt.Printf("Sessions for user '%s':\n\n", user)
+
+	w := tabwriter.NewWriter(os.Stdout, 1, 1, 4, ' ', 0)
+
+	_, _ = fmt.Fprintln(w, "ID\tDevice\tIP\tCookie Domain\tCreated\tLast Active\tExpires")
+
+	for _, session := range sessions {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", session.PublicID, session.Device, session.IP.IP, session.CookieDomain,
+			session.CreatedAt.Format(time.RFC3339), session.LastActiveAt.Format(time.RFC3339), session.ExpiresAt.Format(time.RFC3339))
+	}
+
+	return w.Flush()
+}
+
+// StorageUserSessionsRevokeRunE is the RunE for the authelia storage user sessions revoke command.
+func (ctx *CmdCtx) StorageUserSessionsRevokeRunE(_ *cobra.Command, args []string) (err error) {
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	if err = ctx.CheckSchema(); err != nil {
+		return storageWrapCheckSchemaErr(err)
+	}
+
+	var count int64
+
+	user := args[0]
+
+	if count, err = ctx.providers.StorageProvider.RevokeUserSessions(ctx, user, ctx.clock.Now()); err != nil {
+		return fmt.Errorf("can't revoke sessions for user '%s': %w", user, err)
+	}
+
+	fmt.Printf("Successfully revoked %d sessions for user '%s'\n", count, user)
+
+	if ctx.config.IdentityProviders.OIDC != nil {
+		ctx.storageUserSessionsRevokeOpenIDConnect(user)
+	}
+
+	return nil
+}
+
+// storageUserSessionsRevokeOpenIDConnect performs the OpenID Connect 1.0 Back-Channel Logout for every client which
+// holds a session for the user. Failures are reported but do not fail the command as the sessions are already revoked.
+func (ctx *CmdCtx) storageUserSessionsRevokeOpenIDConnect(user string) {
+	val := schema.NewStructValidator()
+
+	validator.ValidateIdentityProviders(validator.NewValidateCtx(), &ctx.config.IdentityProviders, val)
+
+	if errs := val.Errors(); len(errs) != 0 {
+		fmt.Printf("Skipped informing OpenID Connect 1.0 clients of the logout for user '%s' as the identity providers configuration is invalid: %v\n", user, errors.Join(errs...))
+
+		return
+	}
+
+	var (
+		sessions []model.OAuth2ClientSession
+		err      error
+	)
+
+	if sessions, err = ctx.providers.StorageProvider.LoadOAuth2ClientSessionsByUsername(ctx, user); err != nil {
+		fmt.Printf("Failed to load the OpenID Connect 1.0 client sessions for user '%s': %v\n", user, err)
+
+		return
+	}
+
+	if len(sessions) == 0 {
+		return
+	}
+
+	provider := oidc.NewOpenIDConnectProvider(ctx.config.IdentityProviders.OIDC, ctx.providers.StorageProvider, nil)
+
+	var backchannel <-chan error
+
+	_, backchannel, err = provider.LogoutClientSessions(ctx, sessions, ctx.clock.Now())
+
+	errs := []error{err}
+
+	for e := range backchannel {
+		errs = append(errs, e)
+	}
+
+	if err = errors.Join(errs...); err != nil {
+		fmt.Printf("Failed to inform one or more OpenID Connect 1.0 clients of the logout for user '%s': %v\n", user, err)
+
+		return
+	}
+
+	fmt.Printf("Successfully informed %d OpenID Connect 1.0 client sessions of the logout for user '%s'\n", len(sessions), user)
+}
+
 // StorageUserWebAuthnListRunE is the RunE for the authelia storage user webauthn list command.
 func (ctx *CmdCtx) StorageUserWebAuthnListRunE(cmd *cobra.Command, args []string) (err error) {
 	defer func() {
@@ -1052,6 +1513,141 @@ func (ctx *CmdCtx) StorageUserTOTPExportPNGRunE(cmd *cobra.Command, _ []string)
 	return nil
 }
 
+// StorageUserAddRunE is the RunE for the authelia storage user add command.
+func (ctx *CmdCtx) StorageUserAddRunE(cmd *cobra.Command, args []string) (err error) {
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	if err = ctx.CheckSchema(); err != nil {
+		return storageWrapCheckSchemaErr(err)
+	}
+
+	user := model.User{
+		CreatedAt: ctx.clock.Now(),
+		Username:  args[0],
+	}
+
+	if user.DisplayName, err = cmd.Flags().GetString(cmdFlagNameDisplayName); err != nil {
+		return err
+	}
+
+	if user.Email, err = cmd.Flags().GetString(cmdFlagNameEmail); err != nil {
+		return err
+	}
+
+	if user.Groups, err = cmd.Flags().GetStringSlice(cmdFlagNameGroups); err != nil {
+		return err
+	}
+
+	if _, err = ctx.providers.StorageProvider.LoadUser(ctx, user.Username); err == nil {
+		return fmt.Errorf("failed to add user '%s': user already exists", user.Username)
+	} else if !errors.Is(err, storage.ErrNoUser) {
+		return fmt.Errorf("failed to add user '%s': %w", user.Username, err)
+	}
+
+	if user.Password, err = ctx.storageUserPasswordDigest(cmd, args); err != nil {
+		return err
+	}
+
+	if err = ctx.providers.StorageProvider.SaveUser(ctx, user); err != nil {
+		return fmt.Errorf("failed to add user '%s': %w", user.Username, err)
+	}
+
+	fmt.Printf("Successfully added user '%s'\n", user.Username)
+
+	return nil
+}
+
+// StorageUserDisableRunE is the RunE for the authelia storage user disable command.
+func (ctx *CmdCtx) StorageUserDisableRunE(_ *cobra.Command, args []string) (err error) {
+	return ctx.storageUserSetDisabled(args[0], true)
+}
+
+// StorageUserEnableRunE is the RunE for the authelia storage user enable command.
+func (ctx *CmdCtx) StorageUserEnableRunE(_ *cobra.Command, args []string) (err error) {
+	return ctx.storageUserSetDisabled(args[0], false)
+}
+
+func (ctx *CmdCtx) storageUserSetDisabled(username string, disabled bool) (err error) {
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	if err = ctx.CheckSchema(); err != nil {
+		return storageWrapCheckSchemaErr(err)
+	}
+
+	action := "enable"
+
+	if disabled {
+		action = "disable"
+	}
+
+	var user *model.User
+
+	if user, err = ctx.providers.StorageProvider.LoadUser(ctx, username); err != nil {
+		return fmt.Errorf("failed to %s user '%s': %w", action, username, err)
+	}
+
+	user.UpdatedAt, user.Disabled = ctx.clock.Now(), disabled
+
+	if err = ctx.providers.StorageProvider.UpdateUser(ctx, *user); err != nil {
+		return fmt.Errorf("failed to %s user '%s': %w", action, username, err)
+	}
+
+	fmt.Printf("Successfully %sd user '%s'\n", action, username)
+
+	return nil
+}
+
+// StorageUserPasswordRunE is the RunE for the authelia storage user password command.
+func (ctx *CmdCtx) StorageUserPasswordRunE(cmd *cobra.Command, args []string) (err error) {
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	if err = ctx.CheckSchema(); err != nil {
+		return storageWrapCheckSchemaErr(err)
+	}
+
+	username := args[0]
+
+	if _, err = ctx.providers.StorageProvider.LoadUser(ctx, username); err != nil {
+		return fmt.Errorf("failed to set the password for user '%s': %w", username, err)
+	}
+
+	var digest string
+
+	if digest, err = ctx.storageUserPasswordDigest(cmd, args); err != nil {
+		return err
+	}
+
+	if err = ctx.providers.StorageProvider.UpdateUserPassword(ctx, username, digest, ctx.clock.Now()); err != nil {
+		return fmt.Errorf("failed to set the password for user '%s': %w", username, err)
+	}
+
+	fmt.Printf("Successfully set the password for user '%s'\n", username)
+
+	return nil
+}
+
+func (ctx *CmdCtx) storageUserPasswordDigest(cmd *cobra.Command, args []string) (digest string, err error) {
+	config := schema.DefaultPasswordConfig
+
+	if ctx.config.AuthenticationBackend.SQL != nil {
+		config = ctx.config.AuthenticationBackend.SQL.Password
+	}
+
+	var d algorithm.Digest
+
+	if d, err = cmdPasswordDigest(cmd, args, config); err != nil {
+		return "", err
+	}
+
+	return d.Encode(), nil
+}
+
 // StorageUserIdentifiersExportRunE is the RunE for the authelia storage user identifiers export command.
 func (ctx *CmdCtx) StorageUserIdentifiersExportRunE(cmd *cobra.Command, _ []string) (err error) {
 	defer func() {
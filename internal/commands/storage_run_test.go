@@ -0,0 +1,151 @@
+package commands
+
+import (
+	"bytes"
+	"database/sql"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/model"
+)
+
+func TestWriteStorageAuditDecisions(t *testing.T) {
+	decisions := []model.AuthorizationDecision{
+		{
+			Time:           time.Date(2024, time.January, 1, 10, 0, 0, 0, time.UTC),
+			Decision:       model.AuthorizationDecisionAllow,
+			StatusCode:     200,
+			Username:       "john",
+			RemoteIP:       model.NewNullIP(net.ParseIP("192.168.1.10")),
+			RequestMethod:  "GET",
+			RequestURI:     "https://app.example.com/",
+			Domain:         "app.example.com",
+			RulePosition:   2,
+			RequiredLevel:  "one_factor",
+			CurrentLevel:   "two_factor",
+			Implementation: "ForwardAuth",
+		},
+		{
+			Time:           time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC),
+			Decision:       model.AuthorizationDecisionRedirect,
+			StatusCode:     302,
+			RequestMethod:  "GET",
+			RequestURI:     "https://app.example.com/",
+			Domain:         "app.example.com",
+			RequiredLevel:  "one_factor",
+			CurrentLevel:   "not_authenticated",
+			Implementation: "ForwardAuth",
+		},
+	}
+
+	buf := &bytes.Buffer{}
+
+	require.NoError(t, writeStorageAuditDecisions(buf, decisions, storageAuditFormatText))
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 3)
+
+	assert.Equal(t, []string{"Time", "Decision", "Status", "Username", "Remote", "IP", "Method", "URL", "Rule", "Required", "Current", "Implementation"}, fields(lines[0]))
+	assert.Equal(t, []string{"2024-01-01T10:00:00Z", "allow", "200", "john", "192.168.1.10", "GET", "https://app.example.com/", "#2", "one_factor", "two_factor", "ForwardAuth"}, fields(lines[1]))
+	assert.Equal(t, []string{"2024-01-01T09:00:00Z", "redirect", "302", "<anonymous>", "N/A", "GET", "https://app.example.com/", "default", "one_factor", "not_authenticated", "ForwardAuth"}, fields(lines[2]))
+
+	buf.Reset()
+
+	require.NoError(t, writeStorageAuditDecisions(buf, nil, storageAuditFormatText))
+	assert.Equal(t, "No authorization decisions were found\n", buf.String())
+
+	buf.Reset()
+
+	require.NoError(t, writeStorageAuditDecisions(buf, decisions[:1], storageAuditFormatJSON))
+	assert.Contains(t, buf.String(), `"remote_ip": "192.168.1.10"`)
+	assert.Contains(t, buf.String(), `"rule_position": 2`)
+}
+
+func TestWriteStorageBans(t *testing.T) {
+	bans := []model.RegulationBan{
+		{
+			ID:        2,
+			CreatedAt: time.Date(2024, time.January, 1, 10, 0, 0, 0, time.UTC),
+			ExpiresAt: sql.NullTime{Time: time.Date(2024, time.January, 1, 10, 5, 0, 0, time.UTC), Valid: true},
+			Kind:      model.RegulationBanKindUser,
+			Value:     "john",
+			Source:    model.RegulationBanSourceAutomatic,
+		},
+		{
+			ID:        1,
+			CreatedAt: time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC),
+			Kind:      model.RegulationBanKindSubnet,
+			Value:     "192.168.1.0/24",
+			Source:    model.RegulationBanSourceManual,
+			Reason:    "Abuse",
+		},
+	}
+
+	buf := &bytes.Buffer{}
+
+	require.NoError(t, writeStorageBans(buf, bans))
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 3)
+
+	assert.Equal(t, []string{"ID", "Kind", "Value", "Source", "Created", "Expires", "Reason"}, fields(lines[0]))
+	assert.Equal(t, []string{"2", "user", "john", "automatic", "2024-01-01T10:00:00Z", "2024-01-01T10:05:00Z", "N/A"}, fields(lines[1]))
+	assert.Equal(t, []string{"1", "subnet", "192.168.1.0/24", "manual", "2024-01-01T09:00:00Z", "never", "Abuse"}, fields(lines[2]))
+
+	buf.Reset()
+
+	require.NoError(t, writeStorageBans(buf, nil))
+	assert.Equal(t, "No active bans were found\n", buf.String())
+}
+
+func TestStorageBansTargetFromFlags(t *testing.T) {
+	testCases := []struct {
+		name  string
+		args  []string
+		kind  string
+		value string
+		err   string
+	}{
+		{"ShouldParseUsername", []string{"--username", "john"}, model.RegulationBanKindUser, "john", ""},
+		{"ShouldParseIPv4", []string{"--ip", "192.168.1.10"}, model.RegulationBanKindIP, "192.168.1.10", ""},
+		{"ShouldNormalizeIPv6", []string{"--ip", "2001:DB8:0::1"}, model.RegulationBanKindIP, "2001:db8::1", ""},
+		{"ShouldNormalizeSubnet", []string{"--subnet", "192.168.1.10/24"}, model.RegulationBanKindSubnet, "192.168.1.0/24", ""},
+		{"ShouldErrInvalidIP", []string{"--ip", "abc"}, "", "", "the ip flag value 'abc' is not a valid ip address"},
+		{"ShouldErrInvalidSubnet", []string{"--subnet", "192.168.1.10"}, "", "", "the subnet flag value '192.168.1.10' is not a valid subnet in CIDR notation"},
+		{"ShouldErrNoFlags", nil, "", "", "one of the username, ip, or subnet flags must be provided"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+
+			storageBansTargetFlags(cmd, "ban")
+
+			require.NoError(t, cmd.Flags().Parse(tc.args))
+
+			kind, value, err := storageBansTargetFromFlags(cmd)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+
+			assert.Equal(t, tc.kind, kind)
+			assert.Equal(t, tc.value, value)
+		})
+	}
+}
+
+func fields(line []byte) (values []string) {
+	for _, field := range bytes.Fields(line) {
+		values = append(values, string(field))
+	}
+
+	return values
+}
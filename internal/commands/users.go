@@ -0,0 +1,316 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/spf13/cobra"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func newUsersCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "users",
+		Short:   cmdAutheliaUsersShort,
+		Long:    cmdAutheliaUsersLong,
+		Example: cmdAutheliaUsersExample,
+		PersistentPreRunE: ctx.ChainRunE(
+			ctx.HelperConfigLoadRunE,
+		),
+		Args: cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.PersistentFlags().String(cmdFlagNamePath, "", "the path to the users database file, defaults to the path configured for the file authentication backend")
+
+	cmd.AddCommand(
+		newUsersAddCmd(ctx),
+		newUsersModifyCmd(ctx),
+		newUsersDeleteCmd(ctx),
+		newUsersListCmd(ctx),
+	)
+
+	return cmd
+}
+
+func newUsersAddCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "add <username>",
+		Short:   cmdAutheliaUsersAddShort,
+		Long:    cmdAutheliaUsersAddLong,
+		Example: cmdAutheliaUsersAddExample,
+		RunE:    ctx.UsersAddRunE,
+		Args:    cobra.ExactArgs(1),
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().String(cmdFlagNameDisplayName, "", "the display name of the user, defaults to the username")
+	cmd.Flags().String(cmdFlagNameEmail, "", "the email address of the user")
+	cmd.Flags().StringSlice(cmdFlagNameGroups, nil, "the groups the user is a member of")
+	cmd.Flags().Bool(cmdFlagNameDisabled, false, "adds the user in a disabled state")
+
+	cmdFlagPassword(cmd, true)
+	cmdFlagRandomPassword(cmd)
+
+	return cmd
+}
+
+func newUsersModifyCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "modify <username>",
+		Short:   cmdAutheliaUsersModifyShort,
+		Long:    cmdAutheliaUsersModifyLong,
+		Example: cmdAutheliaUsersModifyExample,
+		RunE:    ctx.UsersModifyRunE,
+		Args:    cobra.ExactArgs(1),
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().String(cmdFlagNameDisplayName, "", "the new display name of the user")
+	cmd.Flags().String(cmdFlagNameEmail, "", "the new email address of the user")
+	cmd.Flags().StringSlice(cmdFlagNameGroups, nil, "the new groups the user is a member of")
+	cmd.Flags().Bool(cmdFlagNameDisabled, false, "sets the disabled state of the user")
+
+	cmdFlagPassword(cmd, true)
+	cmdFlagRandomPassword(cmd)
+
+	return cmd
+}
+
+func newUsersDeleteCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "delete <username>",
+		Short:   cmdAutheliaUsersDeleteShort,
+		Long:    cmdAutheliaUsersDeleteLong,
+		Example: cmdAutheliaUsersDeleteExample,
+		RunE:    ctx.UsersDeleteRunE,
+		Args:    cobra.ExactArgs(1),
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
+func newUsersListCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "list",
+		Short:   cmdAutheliaUsersListShort,
+		Long:    cmdAutheliaUsersListLong,
+		Example: cmdAutheliaUsersListExample,
+		RunE:    ctx.UsersListRunE,
+		Args:    cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
+// UsersAddRunE is the RunE for the authelia users add command.
+func (ctx *CmdCtx) UsersAddRunE(cmd *cobra.Command, args []string) (err error) {
+	var (
+		database *authentication.FileUserDatabase
+		config   schema.AuthenticationBackendFilePassword
+		digest   algorithm.Digest
+	)
+
+	if database, config, err = ctx.usersLoadDatabase(cmd); err != nil {
+		return err
+	}
+
+	details := authentication.FileUserDatabaseUserDetails{
+		Username: args[0],
+	}
+
+	if details.DisplayName, err = cmd.Flags().GetString(cmdFlagNameDisplayName); err != nil {
+		return err
+	}
+
+	if details.DisplayName == "" {
+		details.DisplayName = details.Username
+	}
+
+	if details.Email, err = cmd.Flags().GetString(cmdFlagNameEmail); err != nil {
+		return err
+	}
+
+	if details.Groups, err = cmd.Flags().GetStringSlice(cmdFlagNameGroups); err != nil {
+		return err
+	}
+
+	if details.Disabled, err = cmd.Flags().GetBool(cmdFlagNameDisabled); err != nil {
+		return err
+	}
+
+	if _, err = database.GetUserDetails(details.Username); err == nil {
+		return fmt.Errorf("failed to add user '%s': %w", details.Username, authentication.ErrUserExists)
+	}
+
+	if digest, err = cmdPasswordDigest(cmd, nil, config); err != nil {
+		return err
+	}
+
+	details.Password = schema.NewPasswordDigest(digest)
+
+	if err = database.CreateUser(details); err != nil {
+		return fmt.Errorf("failed to add user '%s': %w", details.Username, err)
+	}
+
+	if err = database.Save(); err != nil {
+		return fmt.Errorf("failed to save the users database: %w", err)
+	}
+
+	fmt.Printf("Successfully added user '%s'\n", details.Username)
+
+	return nil
+}
+
+// UsersModifyRunE is the RunE for the authelia users modify command.
+func (ctx *CmdCtx) UsersModifyRunE(cmd *cobra.Command, args []string) (err error) {
+	var (
+		database *authentication.FileUserDatabase
+		config   schema.AuthenticationBackendFilePassword
+		details  authentication.FileUserDatabaseUserDetails
+		digest   algorithm.Digest
+	)
+
+	if database, config, err = ctx.usersLoadDatabase(cmd); err != nil {
+		return err
+	}
+
+	if details, err = database.GetUserDetails(args[0]); err != nil {
+		return fmt.Errorf("failed to modify user '%s': %w", args[0], err)
+	}
+
+	flags := cmd.Flags()
+
+	if flags.Changed(cmdFlagNameDisplayName) {
+		if details.DisplayName, err = flags.GetString(cmdFlagNameDisplayName); err != nil {
+			return err
+		}
+	}
+
+	if flags.Changed(cmdFlagNameEmail) {
+		if details.Email, err = flags.GetString(cmdFlagNameEmail); err != nil {
+			return err
+		}
+	}
+
+	if flags.Changed(cmdFlagNameGroups) {
+		if details.Groups, err = flags.GetStringSlice(cmdFlagNameGroups); err != nil {
+			return err
+		}
+	}
+
+	if flags.Changed(cmdFlagNameDisabled) {
+		if details.Disabled, err = flags.GetBool(cmdFlagNameDisabled); err != nil {
+			return err
+		}
+	}
+
+	if flags.Changed(cmdFlagNamePassword) || flags.Changed(cmdFlagNameRandom) {
+		if digest, err = cmdPasswordDigest(cmd, nil, config); err != nil {
+			return err
+		}
+
+		details.Password = schema.NewPasswordDigest(digest)
+	}
+
+	if err = database.UpdateUser(details); err != nil {
+		return fmt.Errorf("failed to modify user '%s': %w", details.Username, err)
+	}
+
+	if err = database.Save(); err != nil {
+		return fmt.Errorf("failed to save the users database: %w", err)
+	}
+
+	fmt.Printf("Successfully modified user '%s'\n", details.Username)
+
+	return nil
+}
+
+// UsersDeleteRunE is the RunE for the authelia users delete command.
+func (ctx *CmdCtx) UsersDeleteRunE(cmd *cobra.Command, args []string) (err error) {
+	var database *authentication.FileUserDatabase
+
+	if database, _, err = ctx.usersLoadDatabase(cmd); err != nil {
+		return err
+	}
+
+	if err = database.DeleteUser(args[0]); err != nil {
+		return fmt.Errorf("failed to delete user '%s': %w", args[0], err)
+	}
+
+	if err = database.Save(); err != nil {
+		return fmt.Errorf("failed to save the users database: %w", err)
+	}
+
+	fmt.Printf("Successfully deleted user '%s'\n", args[0])
+
+	return nil
+}
+
+// UsersListRunE is the RunE for the authelia users list command.
+func (ctx *CmdCtx) UsersListRunE(cmd *cobra.Command, _ []string) (err error) {
+	var database *authentication.FileUserDatabase
+
+	if database, _, err = ctx.usersLoadDatabase(cmd); err != nil {
+		return err
+	}
+
+	return writeUsersList(os.Stdout, database.ListUsers())
+}
+
+func writeUsersList(w io.Writer, users []authentication.FileUserDatabaseUserDetails) (err error) {
+	tw := tabwriter.NewWriter(w, 1, 1, 4, ' ', 0)
+
+	_, _ = fmt.Fprintln(tw, "Username\tDisplay Name\tEmail\tGroups\tDisabled")
+
+	for _, details := range users {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%t\n", details.Username, details.DisplayName, details.Email, strings.Join(details.Groups, ","), details.Disabled)
+	}
+
+	return tw.Flush()
+}
+
+func (ctx *CmdCtx) usersLoadDatabase(cmd *cobra.Command) (database *authentication.FileUserDatabase, config schema.AuthenticationBackendFilePassword, err error) {
+	var path string
+
+	if path, err = cmd.Flags().GetString(cmdFlagNamePath); err != nil {
+		return nil, config, err
+	}
+
+	var searchEmail, searchCI bool
+
+	config = schema.DefaultPasswordConfig
+
+	if file := ctx.config.AuthenticationBackend.File; file != nil {
+		if path == "" {
+			path = file.Path
+		}
+
+		searchEmail, searchCI, config = file.Search.Email, file.Search.CaseInsensitive, file.Password
+	}
+
+	if path == "" {
+		return nil, config, fmt.Errorf("the users database path must be provided either via the --%s flag or the file authentication backend configuration", cmdFlagNamePath)
+	}
+
+	database = authentication.NewFileUserDatabase(path, searchEmail, searchCI)
+
+	if err = database.Load(); err != nil {
+		return nil, config, err
+	}
+
+	return database, config, nil
+}
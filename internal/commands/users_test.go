@@ -0,0 +1,170 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestUsersCommands(t *testing.T) {
+	path := newUsersTestDatabase(t)
+
+	ctx := NewCmdCtx()
+	ctx.config.AuthenticationBackend.File = &schema.AuthenticationBackendFile{
+		Path:     path,
+		Password: schema.DefaultCIPasswordConfig,
+	}
+
+	require.NoError(t, runUsersCmd(ctx, "add", "john", "--password", "foo", "--email", "john@example.com", "--groups", "admins,dev"))
+
+	details := loadUsersTestDetails(t, path, "john")
+
+	assert.Equal(t, "john", details.DisplayName)
+	assert.Equal(t, "john@example.com", details.Email)
+	assert.Equal(t, []string{"admins", "dev"}, details.Groups)
+	assert.False(t, details.Disabled)
+	assert.True(t, details.Password.Match("foo"))
+
+	err := runUsersCmd(ctx, "add", "john", "--password", "foo")
+
+	assert.ErrorIs(t, err, authentication.ErrUserExists)
+	assert.EqualError(t, err, "failed to add user 'john': user already exists")
+
+	require.NoError(t, runUsersCmd(ctx, "modify", "john", "--display-name", "John Smith", "--disabled"))
+
+	details = loadUsersTestDetails(t, path, "john")
+
+	assert.Equal(t, "John Smith", details.DisplayName)
+	assert.Equal(t, "john@example.com", details.Email)
+	assert.Equal(t, []string{"admins", "dev"}, details.Groups)
+	assert.True(t, details.Disabled)
+	assert.True(t, details.Password.Match("foo"))
+
+	require.NoError(t, runUsersCmd(ctx, "modify", "john", "--password", "bar", "--groups", "dev", "--disabled=false"))
+
+	details = loadUsersTestDetails(t, path, "john")
+
+	assert.Equal(t, []string{"dev"}, details.Groups)
+	assert.False(t, details.Disabled)
+	assert.False(t, details.Password.Match("foo"))
+	assert.True(t, details.Password.Match("bar"))
+
+	err = runUsersCmd(ctx, "modify", "alice", "--display-name", "Alice")
+
+	assert.ErrorIs(t, err, authentication.ErrUserNotFound)
+	assert.EqualError(t, err, "failed to modify user 'alice': user not found")
+
+	require.NoError(t, runUsersCmd(ctx, "list"))
+
+	require.NoError(t, runUsersCmd(ctx, "delete", "john"))
+
+	database := authentication.NewFileUserDatabase(path, false, false)
+
+	require.NoError(t, database.Load())
+
+	_, err = database.GetUserDetails("john")
+
+	assert.ErrorIs(t, err, authentication.ErrUserNotFound)
+
+	_, err = database.GetUserDetails("harry")
+
+	assert.NoError(t, err)
+
+	err = runUsersCmd(ctx, "delete", "john")
+
+	assert.ErrorIs(t, err, authentication.ErrUserNotFound)
+	assert.EqualError(t, err, "failed to delete user 'john': error deleting user 'john': user not found")
+}
+
+func TestUsersCommandsPath(t *testing.T) {
+	path := newUsersTestDatabase(t)
+
+	ctx := NewCmdCtx()
+
+	assert.EqualError(t, runUsersCmd(ctx, "list"), "the users database path must be provided either via the --path flag or the file authentication backend configuration")
+
+	require.NoError(t, runUsersCmd(ctx, "delete", "harry", "--path", path))
+
+	database := authentication.NewFileUserDatabase(path, false, false)
+
+	require.NoError(t, database.Load())
+
+	users := database.ListUsers()
+
+	require.Len(t, users, 1)
+	assert.Equal(t, "bob", users[0].Username)
+
+	assert.ErrorContains(t, runUsersCmd(ctx, "list", "--path", filepath.Join(t.TempDir(), "missing.yml")), "error reading the authentication database")
+}
+
+func TestWriteUsersList(t *testing.T) {
+	users := []authentication.FileUserDatabaseUserDetails{
+		{Username: "harry", DisplayName: "Harry", Email: "harry@example.com", Groups: []string{"admins", "dev"}},
+		{Username: "john", DisplayName: "John", Disabled: true},
+	}
+
+	buf := &bytes.Buffer{}
+
+	require.NoError(t, writeUsersList(buf, users))
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 3)
+
+	assert.Equal(t, []string{"Username", "Display", "Name", "Email", "Groups", "Disabled"}, fields(lines[0]))
+	assert.Equal(t, []string{"harry", "Harry", "harry@example.com", "admins,dev", "false"}, fields(lines[1]))
+	assert.Equal(t, []string{"john", "John", "true"}, fields(lines[2]))
+}
+
+// runUsersCmd runs a users subcommand without the configuration loading of the parent command.
+func runUsersCmd(ctx *CmdCtx, args ...string) (err error) {
+	cmd, args, err := newUsersCmd(ctx).Find(args)
+	if err != nil {
+		return err
+	}
+
+	if err = cmd.ParseFlags(args); err != nil {
+		return err
+	}
+
+	return cmd.RunE(cmd, cmd.Flags().Args())
+}
+
+func newUsersTestDatabase(t *testing.T) (path string) {
+	path = filepath.Join(t.TempDir(), "users.yml")
+
+	require.NoError(t, os.WriteFile(path, []byte(`---
+users:
+  harry:
+    displayname: 'Harry Potter'
+    password: '$argon2id$v=19$m=65536,t=3,p=2$BpLnfgDsc2WD8F2q$o/vzA4myCqZZ36bUGsDY//8mKUYNZZaR0t4MFFSs+iM'
+    email: 'harry.potter@authelia.com'
+    groups: []
+  bob:
+    displayname: 'Bob Dylan'
+    password: '$argon2id$v=19$m=65536,t=3,p=2$BpLnfgDsc2WD8F2q$o/vzA4myCqZZ36bUGsDY//8mKUYNZZaR0t4MFFSs+iM'
+    email: 'bob.dylan@authelia.com'
+    groups: ['dev']
+...
+`), 0600))
+
+	return path
+}
+
+func loadUsersTestDetails(t *testing.T, path, username string) (details authentication.FileUserDatabaseUserDetails) {
+	database := authentication.NewFileUserDatabase(path, false, false)
+
+	require.NoError(t, database.Load())
+
+	details, err := database.GetUserDetails(username)
+
+	require.NoError(t, err)
+
+	return details
+}
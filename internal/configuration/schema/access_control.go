@@ -1,5 +1,10 @@
 package schema
 
+import (
+	"net/url"
+	"time"
+)
+
 // AccessControl represents the configuration related to ACLs.
 type AccessControl struct {
 	// The default policy if no other policy matches the request.
@@ -10,6 +15,19 @@ type AccessControl struct {
 
 	// The ACL rules list.
 	Rules []AccessControlRule `koanf:"rules" json:"rules" jsonschema:"title=Rules List" jsonschema_description:"The list of ACL rules to enumerate for requests."`
+
+	// Reload the ACL when the configuration files are modified.
+	Watch bool `koanf:"watch" json:"watch" jsonschema:"default=false,title=Watch" jsonschema_description:"Enables reloading the access control configuration when the configuration files are modified."`
+
+	// The authorization decision audit log.
+	Audit AccessControlAudit `koanf:"audit" json:"audit" jsonschema:"title=Audit" jsonschema_description:"The authorization decision audit log configuration."`
+}
+
+// AccessControlAudit represents the configuration related to the authorization decision audit log.
+type AccessControlAudit struct {
+	Mode      string        `koanf:"mode" json:"mode" jsonschema:"enum=storage,enum=file,title=Mode" jsonschema_description:"The destination of the authorization decision audit log. When not configured the audit log is disabled."`
+	Path      string        `koanf:"path" json:"path" jsonschema:"title=Path" jsonschema_description:"The path of the file the authorization decisions are appended to as JSON lines when the mode is file."`
+	Retention time.Duration `koanf:"retention" json:"retention" jsonschema:"title=Retention" jsonschema_description:"The amount of time authorization decisions are retained. When the mode is file the file is rotated daily and the rotated files are deleted once they exceed this. When not configured they are retained indefinitely."`
 }
 
 // AccessControlNetwork represents one ACL network group entry.
@@ -20,14 +38,56 @@ type AccessControlNetwork struct {
 
 // AccessControlRule represents one ACL rule entry.
 type AccessControlRule struct {
-	Domains      AccessControlRuleDomains   `koanf:"domain" json:"domain" jsonschema:"oneof_required=Domain,uniqueItems,title=Domain Literals" jsonschema_description:"The literal domains to match the domain against that this rule applies to."`
-	DomainsRegex AccessControlRuleRegex     `koanf:"domain_regex" json:"domain_regex" jsonschema:"oneof_required=Domain Regex,title=Domain Regex Patterns" jsonschema_description:"The regex patterns to match the domain against that this rule applies to."`
-	Policy       string                     `koanf:"policy" json:"policy" jsonschema:"required,enum=bypass,enum=deny,enum=one_factor,enum=two_factor,title=Rule Policy" jsonschema_description:"The policy this rule applies when all criteria match."`
-	Subjects     AccessControlRuleSubjects  `koanf:"subject" json:"subject" jsonschema:"title=AccessControlRuleSubjects" jsonschema_description:"The users or groups that this rule applies to."`
-	Networks     AccessControlRuleNetworks  `koanf:"networks" json:"networks" jsonschema:"title=Networks" jsonschema_description:"The remote IP's, network ranges in CIDR notation, or network names that this rule applies to."`
-	Resources    AccessControlRuleRegex     `koanf:"resources" json:"resources" jsonschema:"title=Resources or Paths" jsonschema_description:"The regex patterns to match the resource paths that this rule applies to."`
-	Methods      AccessControlRuleMethods   `koanf:"methods" json:"methods" jsonschema:"enum=GET,enum=HEAD,enum=POST,enum=PUT,enum=DELETE,enum=CONNECT,enum=OPTIONS,enum=TRACE,enum=PATCH,enum=PROPFIND,enum=PROPPATCH,enum=MKCOL,enum=COPY,enum=MOVE,enum=LOCK,enum=UNLOCK" jsonschema_description:"The list of request methods this rule applies to."`
-	Query        [][]AccessControlRuleQuery `koanf:"query" json:"query" jsonschema:"title=Query Rules" jsonschema_description:"The list of query parameter rules this rule applies to."`
+	Domains              AccessControlRuleDomains          `koanf:"domain" json:"domain" jsonschema:"oneof_required=Domain,uniqueItems,title=Domain Literals" jsonschema_description:"The literal domains to match the domain against that this rule applies to."`
+	DomainsRegex         AccessControlRuleRegex            `koanf:"domain_regex" json:"domain_regex" jsonschema:"oneof_required=Domain Regex,title=Domain Regex Patterns" jsonschema_description:"The regex patterns to match the domain against that this rule applies to."`
+	Policy               string                            `koanf:"policy" json:"policy" jsonschema:"required,enum=bypass,enum=deny,enum=one_factor,enum=two_factor,enum=webhook,title=Rule Policy" jsonschema_description:"The policy this rule applies when all criteria match."`
+	Subjects             AccessControlRuleSubjects         `koanf:"subject" json:"subject" jsonschema:"title=AccessControlRuleSubjects" jsonschema_description:"The users or groups that this rule applies to."`
+	Networks             AccessControlRuleNetworks         `koanf:"networks" json:"networks" jsonschema:"title=Networks" jsonschema_description:"The remote IP's, network ranges in CIDR notation, or network names that this rule applies to."`
+	Resources            AccessControlRuleRegex            `koanf:"resources" json:"resources" jsonschema:"title=Resources or Paths" jsonschema_description:"The regex patterns to match the resource paths that this rule applies to."`
+	Methods              AccessControlRuleMethods          `koanf:"methods" json:"methods" jsonschema:"enum=GET,enum=HEAD,enum=POST,enum=PUT,enum=DELETE,enum=CONNECT,enum=OPTIONS,enum=TRACE,enum=PATCH,enum=PROPFIND,enum=PROPPATCH,enum=MKCOL,enum=COPY,enum=MOVE,enum=LOCK,enum=UNLOCK" jsonschema_description:"The list of request methods this rule applies to."`
+	Query                [][]AccessControlRuleQuery        `koanf:"query" json:"query" jsonschema:"title=Query Rules" jsonschema_description:"The list of query parameter rules this rule applies to."`
+	Headers              [][]AccessControlRuleHeader       `koanf:"headers" json:"headers" jsonschema:"title=Header Rules" jsonschema_description:"The list of request header rules this rule applies to."`
+	MaxAuthenticationAge time.Duration                     `koanf:"max_authentication_age" json:"max_authentication_age" jsonschema:"title=Maximum Authentication Age" jsonschema_description:"The maximum amount of time since the user last authenticated before they must authenticate again to access resources matching this rule."`
+	SecondFactorMethods  []string                          `koanf:"second_factor_methods" json:"second_factor_methods" jsonschema:"uniqueItems,enum=totp,enum=webauthn,enum=webauthn_hardware,enum=mobile_push,title=Second Factor Methods" jsonschema_description:"The second factor methods which are able to satisfy the two_factor policy of this rule."`
+	Schedule             AccessControlRuleSchedule         `koanf:"schedule" json:"schedule" jsonschema:"title=Schedule" jsonschema_description:"The schedule which restricts the times this rule applies."`
+	Webhook              AccessControlRuleWebhook          `koanf:"webhook" json:"webhook" jsonschema:"title=Webhook" jsonschema_description:"The external endpoint which decides the policy when the policy of this rule is webhook."`
+	ResponseHeaders      []AccessControlRuleResponseHeader `koanf:"response_headers" json:"response_headers" jsonschema:"title=Response Headers" jsonschema_description:"The additional response headers rendered from templates when a request matching this rule is authorized."`
+}
+
+// AccessControlRuleResponseHeader represents an ACL templated response header.
+type AccessControlRuleResponseHeader struct {
+	Name  string `koanf:"name" json:"name" jsonschema:"required,title=Name" jsonschema_description:"The name of the response header."`
+	Value string `koanf:"value" json:"value" jsonschema:"required,title=Value" jsonschema_description:"The template rendered as the value of the response header."`
+}
+
+// AccessControlRuleWebhook represents the ACL external policy decision endpoint.
+type AccessControlRuleWebhook struct {
+	URL           *url.URL      `koanf:"url" json:"url" jsonschema:"format=uri,title=URL" jsonschema_description:"The URL of the endpoint the subject and object are sent to."`
+	Timeout       time.Duration `koanf:"timeout" json:"timeout" jsonschema:"default=5 seconds,title=Timeout" jsonschema_description:"The amount of time to wait for the endpoint to respond."`
+	CacheTTL      time.Duration `koanf:"cache_ttl" json:"cache_ttl" jsonschema:"title=Cache TTL" jsonschema_description:"The amount of time the decision for a subject and object is cached. When not configured decisions are not cached."`
+	FailurePolicy string        `koanf:"failure_policy" json:"failure_policy" jsonschema:"default=deny,enum=bypass,enum=deny,enum=one_factor,enum=two_factor,title=Failure Policy" jsonschema_description:"The policy applied when the endpoint fails to respond with a valid decision."`
+}
+
+// AccessControlRuleHeader represents the ACL request header criteria.
+type AccessControlRuleHeader struct {
+	Operator string `koanf:"operator" json:"operator" jsonschema:"enum=equal,enum=not equal,enum=present,enum=absent,enum=pattern,enum=not pattern,title=Operator" jsonschema_description:"The operator this request header rule uses."`
+	Name     string `koanf:"name" json:"name" jsonschema:"required,title=Name" jsonschema_description:"The Request Header name this rule applies to."`
+	Value    any    `koanf:"value" json:"value" jsonschema:"title=Value" jsonschema_description:"The Request Header value for this rule."`
+}
+
+// AccessControlRuleSchedule represents the ACL schedule criteria.
+type AccessControlRuleSchedule struct {
+	TimeZone string                            `koanf:"time_zone" json:"time_zone" jsonschema:"default=UTC,title=Time Zone" jsonschema_description:"The IANA time zone the schedule windows are evaluated in."`
+	Windows  []AccessControlRuleScheduleWindow `koanf:"windows" json:"windows" jsonschema:"title=Windows" jsonschema_description:"The list of windows this rule applies during."`
+}
+
+// AccessControlRuleScheduleWindow represents a single ACL schedule window.
+type AccessControlRuleScheduleWindow struct {
+	Days      []string `koanf:"days" json:"days" jsonschema:"uniqueItems,enum=monday,enum=tuesday,enum=wednesday,enum=thursday,enum=friday,enum=saturday,enum=sunday,title=Days" jsonschema_description:"The days of the week this window applies to."`
+	Start     string   `koanf:"start" json:"start" jsonschema:"default=00:00,title=Start" jsonschema_description:"The time of day in the HH:MM format this window starts."`
+	End       string   `koanf:"end" json:"end" jsonschema:"default=24:00,title=End" jsonschema_description:"The time of day in the HH:MM format this window ends."`
+	NotBefore string   `koanf:"not_before" json:"not_before" jsonschema:"title=Not Before" jsonschema_description:"The date and time in the YYYY-MM-DD HH:MM format before which this window does not apply."`
+	NotAfter  string   `koanf:"not_after" json:"not_after" jsonschema:"title=Not After" jsonschema_description:"The date and time in the YYYY-MM-DD HH:MM format after which this window does not apply."`
 }
 
 // AccessControlRuleQuery represents the ACL query criteria.
@@ -49,6 +109,12 @@ var DefaultACLNetwork = []AccessControlNetwork{
 	},
 }
 
+// DefaultACLRuleWebhook represents the default configuration related to access control rule webhook configuration.
+var DefaultACLRuleWebhook = AccessControlRuleWebhook{
+	Timeout:       time.Second * 5,
+	FailurePolicy: policyDeny,
+}
+
 // DefaultACLRule represents the default configuration related to access control rule configuration.
 var DefaultACLRule = []AccessControlRule{
 	{
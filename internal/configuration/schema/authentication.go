@@ -15,6 +15,7 @@ type AuthenticationBackend struct {
 	// The file authentication backend configuration.
 	File *AuthenticationBackendFile `koanf:"file" json:"file" jsonschema:"title=File Backend" jsonschema_description:"The file authentication backend configuration."`
 	LDAP *AuthenticationBackendLDAP `koanf:"ldap" json:"ldap" jsonschema:"title=LDAP Backend" jsonschema_description:"The LDAP authentication backend configuration."`
+	SQL  *AuthenticationBackendSQL  `koanf:"sql" json:"sql" jsonschema:"title=SQL Backend" jsonschema_description:"The SQL authentication backend configuration which uses the storage database."`
 }
 
 // AuthenticationBackendPasswordReset represents the configuration related to password reset functionality.
@@ -33,6 +34,12 @@ type AuthenticationBackendFile struct {
 	Search AuthenticationBackendFileSearch `koanf:"search" json:"search" jsonschema:"title=Search" jsonschema_description:"Configures the user searching behaviour."`
 }
 
+// AuthenticationBackendSQL represents the configuration related to the SQL backend which stores users in the
+// storage database.
+type AuthenticationBackendSQL struct {
+	Password AuthenticationBackendFilePassword `koanf:"password" json:"password" jsonschema:"title=Password Options" jsonschema_description:"Allows configuration of the password hashing options when the user passwords are changed directly by Authelia."`
+}
+
 // AuthenticationBackendFileSearch represents the configuration related to file-based backend searching.
 type AuthenticationBackendFileSearch struct {
 	Email           bool `koanf:"email" json:"email" jsonschema:"default=false,title=Email Searching" jsonschema_description:"Allows users to either use their username or their configured email as a username."`
@@ -133,12 +140,27 @@ type AuthenticationBackendLDAP struct {
 
 // AuthenticationBackendLDAPAttributes represents the configuration related to LDAP server attributes.
 type AuthenticationBackendLDAPAttributes struct {
-	DistinguishedName string `koanf:"distinguished_name" json:"distinguished_name" jsonschema:"title=Attribute: Distinguished Name" jsonschema_description:"The directory server attribute which contains the distinguished name for all objects."`
-	Username          string `koanf:"username" json:"username" jsonschema:"title=Attribute: User Username" jsonschema_description:"The directory server attribute which contains the username for all users."`
-	DisplayName       string `koanf:"display_name" json:"display_name" jsonschema:"title=Attribute: User Display Name" jsonschema_description:"The directory server attribute which contains the display name for all users."`
-	Mail              string `koanf:"mail" json:"mail" jsonschema:"title=Attribute: User Mail" jsonschema_description:"The directory server attribute which contains the mail address for all users and groups."`
-	MemberOf          string `koanf:"member_of" jsonschema:"title=Attribute: Member Of" jsonschema_description:"The directory server attribute which contains the objects that an object is a member of."`
-	GroupName         string `koanf:"group_name" json:"group_name" jsonschema:"title=Attribute: Group Name" jsonschema_description:"The directory server attribute which contains the group name for all groups."`
+	DistinguishedName  string `koanf:"distinguished_name" json:"distinguished_name" jsonschema:"title=Attribute: Distinguished Name" jsonschema_description:"The directory server attribute which contains the distinguished name for all objects."`
+	Username           string `koanf:"username" json:"username" jsonschema:"title=Attribute: User Username" jsonschema_description:"The directory server attribute which contains the username for all users."`
+	DisplayName        string `koanf:"display_name" json:"display_name" jsonschema:"title=Attribute: User Display Name" jsonschema_description:"The directory server attribute which contains the display name for all users."`
+	Mail               string `koanf:"mail" json:"mail" jsonschema:"title=Attribute: User Mail" jsonschema_description:"The directory server attribute which contains the mail address for all users and groups."`
+	MemberOf           string `koanf:"member_of" jsonschema:"title=Attribute: Member Of" jsonschema_description:"The directory server attribute which contains the objects that an object is a member of."`
+	GroupName          string `koanf:"group_name" json:"group_name" jsonschema:"title=Attribute: Group Name" jsonschema_description:"The directory server attribute which contains the group name for all groups."`
+	UserAccountControl string `koanf:"user_account_control" json:"user_account_control" jsonschema:"title=Attribute: User Account Control" jsonschema_description:"The directory server attribute which contains the Active Directory style user account control flags used to determine if a user is disabled or locked."`
+	AccountLockedTime  string `koanf:"account_locked_time" json:"account_locked_time" jsonschema:"title=Attribute: Account Locked Time" jsonschema_description:"The directory server attribute which, when present, indicates the user account is locked."`
+	GivenName          string `koanf:"given_name" json:"given_name" jsonschema:"title=Attribute: User Given Name" jsonschema_description:"The directory server attribute which contains the given name for all users."`
+	FamilyName         string `koanf:"family_name" json:"family_name" jsonschema:"title=Attribute: User Family Name" jsonschema_description:"The directory server attribute which contains the family name for all users."`
+	PhoneNumber        string `koanf:"phone_number" json:"phone_number" jsonschema:"title=Attribute: User Phone Number" jsonschema_description:"The directory server attribute which contains the phone number for all users."`
+	Locale             string `koanf:"locale" json:"locale" jsonschema:"title=Attribute: User Locale" jsonschema_description:"The directory server attribute which contains the locale for all users."`
+	Picture            string `koanf:"picture" json:"picture" jsonschema:"title=Attribute: User Picture" jsonschema_description:"The directory server attribute which contains the picture URL for all users."`
+
+	Extra map[string]AuthenticationBackendLDAPAttributesAttribute `koanf:"extra" json:"extra" jsonschema:"title=Attribute: Extra" jsonschema_description:"The directory server attributes which are retrieved as extra attributes for all users keyed by the name of the extra attribute."`
+}
+
+// AuthenticationBackendLDAPAttributesAttribute represents the configuration of an extra LDAP attribute.
+type AuthenticationBackendLDAPAttributesAttribute struct {
+	Name        string `koanf:"name" json:"name" jsonschema:"title=Name" jsonschema_description:"The directory server attribute which contains the extra attribute."`
+	MultiValued bool   `koanf:"multi_valued" json:"multi_valued" jsonschema:"default=false,title=Multi-Valued" jsonschema_description:"Retrieves all values of the directory server attribute as a list instead of only the first value."`
 }
 
 var DefaultAuthenticationBackendConfig = AuthenticationBackend{
@@ -217,12 +239,13 @@ var DefaultLDAPAuthenticationBackendConfigurationImplementationActiveDirectory =
 	GroupsFilter:    "(&(member={dn})(|(sAMAccountType=268435456)(sAMAccountType=536870912)))",
 	GroupSearchMode: ldapGroupSearchModeFilter,
 	Attributes: AuthenticationBackendLDAPAttributes{
-		DistinguishedName: ldapAttrDistinguishedName,
-		Username:          ldapAttrSAMAccountName,
-		DisplayName:       ldapAttrDisplayName,
-		Mail:              ldapAttrMail,
-		MemberOf:          ldapAttrMemberOf,
-		GroupName:         ldapAttrCommonName,
+		DistinguishedName:  ldapAttrDistinguishedName,
+		Username:           ldapAttrSAMAccountName,
+		DisplayName:        ldapAttrDisplayName,
+		Mail:               ldapAttrMail,
+		MemberOf:           ldapAttrMemberOf,
+		GroupName:          ldapAttrCommonName,
+		UserAccountControl: ldapAttrUserAccountControl,
 	},
 	Timeout: time.Second * 5,
 	TLS: &TLS{
@@ -236,11 +259,12 @@ var DefaultLDAPAuthenticationBackendConfigurationImplementationRFC2307bis = Auth
 	GroupsFilter:    "(&(|(member={dn})(uniqueMember={dn}))(|(objectClass=groupOfNames)(objectClass=groupOfUniqueNames)(objectClass=groupOfMembers))(!(pwdReset=TRUE)))",
 	GroupSearchMode: ldapGroupSearchModeFilter,
 	Attributes: AuthenticationBackendLDAPAttributes{
-		Username:    ldapAttrUserID,
-		DisplayName: ldapAttrDisplayName,
-		Mail:        ldapAttrMail,
-		MemberOf:    ldapAttrMemberOf,
-		GroupName:   ldapAttrCommonName,
+		Username:          ldapAttrUserID,
+		DisplayName:       ldapAttrDisplayName,
+		Mail:              ldapAttrMail,
+		MemberOf:          ldapAttrMemberOf,
+		GroupName:         ldapAttrCommonName,
+		AccountLockedTime: ldapAttrPwdAccountLockedTime,
 	},
 	Timeout: time.Second * 5,
 	TLS: &TLS{
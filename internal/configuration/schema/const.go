@@ -118,6 +118,7 @@ var (
 
 const (
 	policyTwoFactor = "two_factor"
+	policyDeny      = "deny"
 )
 
 const (
@@ -153,6 +154,18 @@ const (
 	AuthzStrategyHeaderLegacy                        = "HeaderLegacy"
 )
 
+// Security Event Types.
+const (
+	SecurityEventAuthenticationSuccess = "authentication.success"
+	SecurityEventAuthenticationFailure = "authentication.failure"
+	SecurityEventRegulationBan         = "regulation.ban"
+	SecurityEventPasswordReset         = "password.reset"
+	SecurityEventCredentialRegistered  = "credential.registered"
+	SecurityEventCredentialRemoved     = "credential.removed"
+	SecurityEventSessionElevated       = "session.elevated"
+	SecurityEventOpenIDConnectConsent  = "oidc.consent.granted"
+)
+
 const (
 	ldapGroupSearchModeFilter = "filter"
 )
@@ -166,6 +179,9 @@ const (
 	ldapAttrDescription       = "description"
 	ldapAttrCommonName        = "cn"
 	ldapAttrMemberOf          = "memberOf"
+
+	ldapAttrUserAccountControl   = "userAccountControl"
+	ldapAttrPwdAccountLockedTime = "pwdAccountLockedTime"
 )
 
 // Address Schemes.
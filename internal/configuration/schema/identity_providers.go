@@ -31,8 +31,12 @@ type IdentityProvidersOpenIDConnect struct {
 
 	CORS IdentityProvidersOpenIDConnectCORS `koanf:"cors" json:"cors" jsonschema:"title=CORS" jsonschema_description:"Configuration options for Cross-Origin Request Sharing."`
 
+	DynamicClientRegistration IdentityProvidersOpenIDConnectDynamicClientRegistration `koanf:"dynamic_client_registration" json:"dynamic_client_registration" jsonschema:"title=Dynamic Client Registration" jsonschema_description:"Configuration options for OAuth 2.0 Dynamic Client Registration."`
+
 	Clients []IdentityProvidersOpenIDConnectClient `koanf:"clients" json:"clients" jsonschema:"title=Clients" jsonschema_description:"OpenID Connect 1.0 clients registry."`
 
+	Scopes map[string]IdentityProvidersOpenIDConnectScope `koanf:"scopes" json:"scopes" jsonschema:"title=Scopes" jsonschema_description:"Custom scopes which clients can request to obtain additional claims."`
+
 	AuthorizationPolicies map[string]IdentityProvidersOpenIDConnectPolicy `koanf:"authorization_policies" json:"authorization_policies" jsonschema:"title=Authorization Policies" jsonschema_description:"Custom client authorization policies."`
 	Lifespans             IdentityProvidersOpenIDConnectLifespans         `koanf:"lifespans" json:"lifespans" jsonschema:"title=Lifespans" jsonschema_description:"Token lifespans configuration."`
 
@@ -55,6 +59,17 @@ type IdentityProvidersOpenIDConnectPolicyRule struct {
 	Subjects AccessControlRuleSubjects `koanf:"subject" json:"subject" jsonschema:"title=Subject" jsonschema_description:"Allows tuning the token lifespans for the authorize code grant."`
 }
 
+// IdentityProvidersOpenIDConnectScope configuration for OpenID Connect 1.0 custom scopes.
+type IdentityProvidersOpenIDConnectScope struct {
+	Claims map[string]IdentityProvidersOpenIDConnectScopeClaim `koanf:"claims" json:"claims" jsonschema:"required,title=Claims" jsonschema_description:"The claims granted by this scope."`
+}
+
+// IdentityProvidersOpenIDConnectScopeClaim configuration for OpenID Connect 1.0 custom scope claims.
+type IdentityProvidersOpenIDConnectScopeClaim struct {
+	Attribute string `koanf:"attribute" json:"attribute" jsonschema:"title=Attribute" jsonschema_description:"The user attribute which is the source of the claim value."`
+	Value     any    `koanf:"value" json:"value" jsonschema:"title=Value" jsonschema_description:"The static value of the claim."`
+}
+
 // IdentityProvidersOpenIDConnectDiscovery is information discovered during validation reused for the discovery handlers.
 type IdentityProvidersOpenIDConnectDiscovery struct {
 	AuthorizationPolicies       []string
@@ -66,6 +81,8 @@ type IdentityProvidersOpenIDConnectDiscovery struct {
 	RequestObjectSigningAlgs    []string
 	JWTResponseAccessTokens     bool
 	BearerAuthorization         bool
+	Scopes                      []string
+	Claims                      []string
 }
 
 type IdentityProvidersOpenIDConnectLifespans struct {
@@ -107,6 +124,16 @@ type IdentityProvidersOpenIDConnectCORS struct {
 	AllowedOriginsFromClientRedirectURIs bool `koanf:"allowed_origins_from_client_redirect_uris" json:"allowed_origins_from_client_redirect_uris" jsonschema:"default=false,title=Allowed Origins From Client Redirect URIs" jsonschema_description:"Automatically include the redirect URIs from the registered clients."`
 }
 
+// IdentityProvidersOpenIDConnectDynamicClientRegistration represents an OAuth 2.0 Dynamic Client Registration config.
+type IdentityProvidersOpenIDConnectDynamicClientRegistration struct {
+	Enable             bool   `koanf:"enable" json:"enable" jsonschema:"default=false,title=Enable" jsonschema_description:"Enables the OAuth 2.0 Dynamic Client Registration and Management endpoints."`
+	InitialAccessToken string `koanf:"initial_access_token" json:"initial_access_token" jsonschema:"title=Initial Access Token" jsonschema_description:"The Initial Access Token which must be presented as a bearer token to register a client."`
+
+	ClientLifespan      time.Duration `koanf:"client_lifespan" json:"client_lifespan" jsonschema:"default=0 seconds,title=Client Lifespan" jsonschema_description:"The duration a registered client is valid for after which it is removed, a value of 0 disables expiration."`
+	AuthorizationPolicy string        `koanf:"authorization_policy" json:"authorization_policy" jsonschema:"default=two_factor,title=Authorization Policy" jsonschema_description:"The Authorization Policy to apply to registered clients."`
+	Scopes              []string      `koanf:"scopes" json:"scopes" jsonschema:"uniqueItems,title=Scopes" jsonschema_description:"The scopes registered clients are allowed to request."`
+}
+
 // IdentityProvidersOpenIDConnectClient represents a configuration for an OpenID Connect 1.0 client.
 type IdentityProvidersOpenIDConnectClient struct {
 	ID                  string          `koanf:"client_id" json:"client_id" jsonschema:"required,minLength=1,title=Client ID" jsonschema_description:"The Client ID."`
@@ -118,9 +145,15 @@ type IdentityProvidersOpenIDConnectClient struct {
 	RedirectURIs IdentityProvidersOpenIDConnectClientURIs `koanf:"redirect_uris" json:"redirect_uris" jsonschema:"title=Redirect URIs" jsonschema_description:"List of whitelisted redirect URIs."`
 	RequestURIs  IdentityProvidersOpenIDConnectClientURIs `koanf:"request_uris" json:"request_uris" jsonschema:"title=Request URIs" jsonschema_description:"List of whitelisted request URIs."`
 
+	PostLogoutRedirectURIs            IdentityProvidersOpenIDConnectClientURIs `koanf:"post_logout_redirect_uris" json:"post_logout_redirect_uris" jsonschema:"title=Post Logout Redirect URIs" jsonschema_description:"List of whitelisted post logout redirect URIs."`
+	FrontChannelLogoutURI             *url.URL                                 `koanf:"frontchannel_logout_uri" json:"frontchannel_logout_uri" jsonschema:"title=Front-Channel Logout URI" jsonschema_description:"The URI rendered in an iframe by the provider to log the End-User out of this client."`
+	FrontChannelLogoutSessionRequired bool                                     `koanf:"frontchannel_logout_session_required" json:"frontchannel_logout_session_required" jsonschema:"default=false,title=Front-Channel Logout Session Required" jsonschema_description:"Includes the iss and sid query parameters when rendering the Front-Channel Logout URI."`
+	BackChannelLogoutURI              *url.URL                                 `koanf:"backchannel_logout_uri" json:"backchannel_logout_uri" jsonschema:"title=Back-Channel Logout URI" jsonschema_description:"The URI the provider sends Logout Tokens to in order to log the End-User out of this client."`
+	BackChannelLogoutSessionRequired  bool                                     `koanf:"backchannel_logout_session_required" json:"backchannel_logout_session_required" jsonschema:"default=false,title=Back-Channel Logout Session Required" jsonschema_description:"Requires the sid claim to be included in the Logout Tokens sent to the Back-Channel Logout URI."`
+
 	Audience      []string `koanf:"audience" json:"audience" jsonschema:"uniqueItems,title=Audience" jsonschema_description:"List of authorized audiences."`
-	Scopes        []string `koanf:"scopes" json:"scopes" jsonschema:"required,enum=openid,enum=offline_access,enum=groups,enum=email,enum=profile,enum=authelia.bearer.authz,uniqueItems,title=Scopes" jsonschema_description:"The Scopes this client is allowed request and be granted."`
-	GrantTypes    []string `koanf:"grant_types" json:"grant_types" jsonschema:"enum=authorization_code,enum=implicit,enum=refresh_token,enum=client_credentials,uniqueItems,title=Grant Types" jsonschema_description:"The Grant Types this client is allowed to use for the protected endpoints."`
+	Scopes        []string `koanf:"scopes" json:"scopes" jsonschema:"required,uniqueItems,title=Scopes" jsonschema_description:"The Scopes this client is allowed request and be granted."`
+	GrantTypes    []string `koanf:"grant_types" json:"grant_types" jsonschema:"enum=authorization_code,enum=implicit,enum=refresh_token,enum=client_credentials,enum=urn:ietf:params:oauth:grant-type:device_code,enum=urn:ietf:params:oauth:grant-type:token-exchange,uniqueItems,title=Grant Types" jsonschema_description:"The Grant Types this client is allowed to use for the protected endpoints."`
 	ResponseTypes []string `koanf:"response_types" json:"response_types" jsonschema:"enum=code,enum=id_token token,enum=id_token,enum=token,enum=code token,enum=code id_token,enum=code id_token token,uniqueItems,title=Response Types" jsonschema_description:"The Response Types the client is authorized to request."`
 	ResponseModes []string `koanf:"response_modes" json:"response_modes" jsonschema:"enum=form_post,enum=form_post.jwt,enum=query,enum=query.jwt,enum=fragment,enum=fragment.jwt,enum=jwt,uniqueItems,title=Response Modes" jsonschema_description:"The Response Modes this client is authorized request."`
 
@@ -155,9 +188,19 @@ type IdentityProvidersOpenIDConnectClient struct {
 	JSONWebKeysURI *url.URL `koanf:"jwks_uri" json:"jwks_uri" jsonschema:"title=JSON Web Keys URI" jsonschema_description:"URI of the JWKS endpoint which contains the Public Keys used to validate request objects and the 'private_key_jwt' client authentication method for this client."`
 	JSONWebKeys    []JWK    `koanf:"jwks" json:"jwks" jsonschema:"title=JSON Web Keys" jsonschema_description:"List of arbitrary Public Keys used to validate request objects and the 'private_key_jwt' client authentication method for this client."`
 
+	TokenExchange IdentityProvidersOpenIDConnectClientTokenExchange `koanf:"token_exchange" json:"token_exchange" jsonschema:"title=Token Exchange" jsonschema_description:"The Token Exchange policy for this client."`
+
 	Discovery IdentityProvidersOpenIDConnectDiscovery `json:"-"` // MetaData value. Not configurable by users.
 }
 
+// IdentityProvidersOpenIDConnectClientTokenExchange represents the Token Exchange policy for an OpenID Connect 1.0
+// client which restricts the subject tokens it may exchange and the tokens it may request in exchange.
+type IdentityProvidersOpenIDConnectClientTokenExchange struct {
+	SubjectClients []string `koanf:"subject_clients" json:"subject_clients" jsonschema:"uniqueItems,title=Subject Clients" jsonschema_description:"List of clients whose access tokens this client may exchange in addition to access tokens which include this client in their audience."`
+	Audience       []string `koanf:"audience" json:"audience" jsonschema:"uniqueItems,title=Audience" jsonschema_description:"List of audiences this client may request for exchanged tokens."`
+	Scopes         []string `koanf:"scopes" json:"scopes" jsonschema:"uniqueItems,title=Scopes" jsonschema_description:"List of scopes this client may request for exchanged tokens."`
+}
+
 // DefaultOpenIDConnectConfiguration contains defaults for OIDC.
 var DefaultOpenIDConnectConfiguration = IdentityProvidersOpenIDConnect{
 	Lifespans: IdentityProvidersOpenIDConnectLifespans{
@@ -169,6 +212,10 @@ var DefaultOpenIDConnectConfiguration = IdentityProvidersOpenIDConnect{
 		},
 	},
 	EnforcePKCE: "public_clients_only",
+	DynamicClientRegistration: IdentityProvidersOpenIDConnectDynamicClientRegistration{
+		AuthorizationPolicy: policyTwoFactor,
+		Scopes:              []string{"openid", "offline_access", "groups", "profile", "email"},
+	},
 }
 
 var DefaultOpenIDConnectPolicyConfiguration = IdentityProvidersOpenIDConnectPolicy{
@@ -33,6 +33,11 @@ var Keys = []string{
 	"identity_providers.oidc.cors.endpoints",
 	"identity_providers.oidc.cors.allowed_origins",
 	"identity_providers.oidc.cors.allowed_origins_from_client_redirect_uris",
+	"identity_providers.oidc.dynamic_client_registration.enable",
+	"identity_providers.oidc.dynamic_client_registration.initial_access_token",
+	"identity_providers.oidc.dynamic_client_registration.client_lifespan",
+	"identity_providers.oidc.dynamic_client_registration.authorization_policy",
+	"identity_providers.oidc.dynamic_client_registration.scopes",
 	"identity_providers.oidc.clients",
 	"identity_providers.oidc.clients[].client_id",
 	"identity_providers.oidc.clients[].client_name",
@@ -41,6 +46,11 @@ var Keys = []string{
 	"identity_providers.oidc.clients[].public",
 	"identity_providers.oidc.clients[].redirect_uris",
 	"identity_providers.oidc.clients[].request_uris",
+	"identity_providers.oidc.clients[].post_logout_redirect_uris",
+	"identity_providers.oidc.clients[].frontchannel_logout_uri",
+	"identity_providers.oidc.clients[].frontchannel_logout_session_required",
+	"identity_providers.oidc.clients[].backchannel_logout_uri",
+	"identity_providers.oidc.clients[].backchannel_logout_session_required",
 	"identity_providers.oidc.clients[].audience",
 	"identity_providers.oidc.clients[].scopes",
 	"identity_providers.oidc.clients[].grant_types",
@@ -75,7 +85,14 @@ var Keys = []string{
 	"identity_providers.oidc.clients[].jwks[].algorithm",
 	"identity_providers.oidc.clients[].jwks[].key",
 	"identity_providers.oidc.clients[].jwks[].certificate_chain",
+	"identity_providers.oidc.clients[].token_exchange.subject_clients",
+	"identity_providers.oidc.clients[].token_exchange.audience",
+	"identity_providers.oidc.clients[].token_exchange.scopes",
 	"identity_providers.oidc.clients[]",
+	"identity_providers.oidc.scopes",
+	"identity_providers.oidc.scopes.*.claims",
+	"identity_providers.oidc.scopes.*.claims.*.attribute",
+	"identity_providers.oidc.scopes.*.claims.*.value",
 	"identity_providers.oidc.authorization_policies",
 	"identity_providers.oidc.authorization_policies.*.default_policy",
 	"identity_providers.oidc.authorization_policies.*.rules",
@@ -168,11 +185,46 @@ var Keys = []string{
 	"authentication_backend.ldap.attributes.mail",
 	"authentication_backend.ldap.attributes.member_of",
 	"authentication_backend.ldap.attributes.group_name",
+	"authentication_backend.ldap.attributes.user_account_control",
+	"authentication_backend.ldap.attributes.account_locked_time",
+	"authentication_backend.ldap.attributes.given_name",
+	"authentication_backend.ldap.attributes.family_name",
+	"authentication_backend.ldap.attributes.phone_number",
+	"authentication_backend.ldap.attributes.locale",
+	"authentication_backend.ldap.attributes.picture",
+	"authentication_backend.ldap.attributes.extra",
+	"authentication_backend.ldap.attributes.extra.*.name",
+	"authentication_backend.ldap.attributes.extra.*.multi_valued",
 	"authentication_backend.ldap.permit_referrals",
 	"authentication_backend.ldap.permit_unauthenticated_bind",
 	"authentication_backend.ldap.permit_feature_detection_failure",
 	"authentication_backend.ldap.user",
 	"authentication_backend.ldap.password",
+	"authentication_backend.sql.password.algorithm",
+	"authentication_backend.sql.password.argon2.variant",
+	"authentication_backend.sql.password.argon2.iterations",
+	"authentication_backend.sql.password.argon2.memory",
+	"authentication_backend.sql.password.argon2.parallelism",
+	"authentication_backend.sql.password.argon2.key_length",
+	"authentication_backend.sql.password.argon2.salt_length",
+	"authentication_backend.sql.password.sha2crypt.variant",
+	"authentication_backend.sql.password.sha2crypt.iterations",
+	"authentication_backend.sql.password.sha2crypt.salt_length",
+	"authentication_backend.sql.password.pbkdf2.variant",
+	"authentication_backend.sql.password.pbkdf2.iterations",
+	"authentication_backend.sql.password.pbkdf2.salt_length",
+	"authentication_backend.sql.password.bcrypt.variant",
+	"authentication_backend.sql.password.bcrypt.cost",
+	"authentication_backend.sql.password.scrypt.iterations",
+	"authentication_backend.sql.password.scrypt.block_size",
+	"authentication_backend.sql.password.scrypt.parallelism",
+	"authentication_backend.sql.password.scrypt.key_length",
+	"authentication_backend.sql.password.scrypt.salt_length",
+	"authentication_backend.sql.password.iterations",
+	"authentication_backend.sql.password.memory",
+	"authentication_backend.sql.password.parallelism",
+	"authentication_backend.sql.password.key_length",
+	"authentication_backend.sql.password.salt_length",
 	"session.name",
 	"session.same_site",
 	"session.expiration",
@@ -247,6 +299,30 @@ var Keys = []string{
 	"access_control.rules[].query[][].key",
 	"access_control.rules[].query[][].value",
 	"access_control.rules[].query",
+	"access_control.rules[].headers[][].operator",
+	"access_control.rules[].headers[][].name",
+	"access_control.rules[].headers[][].value",
+	"access_control.rules[].headers",
+	"access_control.rules[].max_authentication_age",
+	"access_control.rules[].second_factor_methods",
+	"access_control.rules[].schedule.time_zone",
+	"access_control.rules[].schedule.windows",
+	"access_control.rules[].schedule.windows[].days",
+	"access_control.rules[].schedule.windows[].start",
+	"access_control.rules[].schedule.windows[].end",
+	"access_control.rules[].schedule.windows[].not_before",
+	"access_control.rules[].schedule.windows[].not_after",
+	"access_control.rules[].webhook.url",
+	"access_control.rules[].webhook.timeout",
+	"access_control.rules[].webhook.cache_ttl",
+	"access_control.rules[].webhook.failure_policy",
+	"access_control.rules[].response_headers",
+	"access_control.rules[].response_headers[].name",
+	"access_control.rules[].response_headers[].value",
+	"access_control.watch",
+	"access_control.audit.mode",
+	"access_control.audit.path",
+	"access_control.audit.retention",
 	"ntp.address",
 	"ntp.version",
 	"ntp.max_desync",
@@ -255,6 +331,20 @@ var Keys = []string{
 	"regulation.max_retries",
 	"regulation.find_time",
 	"regulation.ban_time",
+	"regulation.ip.max_retries",
+	"regulation.ip.find_time",
+	"regulation.ip.ban_time",
+	"regulation.subnet.max_retries",
+	"regulation.subnet.find_time",
+	"regulation.subnet.ban_time",
+	"regulation.subnet.ipv4_prefix",
+	"regulation.subnet.ipv6_prefix",
+	"regulation.allowed_networks",
+	"known_devices.enabled",
+	"known_devices.cookie_name",
+	"known_devices.lifespan",
+	"known_devices.ipv4_subnet",
+	"known_devices.ipv6_subnet",
 	"storage.local.path",
 	"storage.mysql.address",
 	"storage.mysql.database",
@@ -305,6 +395,19 @@ var Keys = []string{
 	"notifier.smtp.tls.certificate_chain",
 	"notifier.smtp.host",
 	"notifier.smtp.port",
+	"notifier.webhook.url",
+	"notifier.webhook.timeout",
+	"notifier.webhook.secret",
+	"notifier.webhook.payload",
+	"notifier.webhook.content_type",
+	"notifier.webhook.retries",
+	"notifier.webhook.retry_interval",
+	"notifier.webhook.tls.minimum_version",
+	"notifier.webhook.tls.maximum_version",
+	"notifier.webhook.tls.skip_verify",
+	"notifier.webhook.tls.server_name",
+	"notifier.webhook.tls.private_key",
+	"notifier.webhook.tls.certificate_chain",
 	"notifier.template_path",
 	"server.address",
 	"server.asset_path",
@@ -320,6 +423,9 @@ var Keys = []string{
 	"server.endpoints.authz.*.authn_strategies",
 	"server.endpoints.authz.*.authn_strategies[].name",
 	"server.endpoints.authz.*.authn_strategies[].schemes",
+	"server.endpoints.authz.*.headers",
+	"server.endpoints.authz.*.headers[].name",
+	"server.endpoints.authz.*.headers[].value",
 	"server.buffers.read",
 	"server.buffers.write",
 	"server.timeouts.read",
@@ -357,5 +463,20 @@ var Keys = []string{
 	"identity_validation.elevated_session.characters",
 	"identity_validation.elevated_session.require_second_factor",
 	"identity_validation.elevated_session.skip_second_factor",
+	"security_events.email_digest.recipients",
+	"security_events.email_digest.interval",
+	"security_events.email_digest.events",
+	"security_events.webhook.url",
+	"security_events.webhook.timeout",
+	"security_events.webhook.secret",
+	"security_events.webhook.events",
+	"security_events.webhook.tls.minimum_version",
+	"security_events.webhook.tls.maximum_version",
+	"security_events.webhook.tls.skip_verify",
+	"security_events.webhook.tls.server_name",
+	"security_events.webhook.tls.private_key",
+	"security_events.webhook.tls.certificate_chain",
+	"security_events.log.path",
+	"security_events.log.events",
 	"default_redirection_url",
 }
@@ -0,0 +1,23 @@
+package schema
+
+import (
+	"time"
+)
+
+// KnownDevices represents the configuration related to the known devices of each user which are used to alert users
+// when a sign-in occurs from a new device or location.
+type KnownDevices struct {
+	Enabled    bool          `koanf:"enabled" json:"enabled" jsonschema:"default=false,title=Enabled" jsonschema_description:"Enables the new device sign-in alerts."`
+	CookieName string        `koanf:"cookie_name" json:"cookie_name" jsonschema:"default=authelia_device,title=Cookie Name" jsonschema_description:"The name of the cookie which identifies the device."`
+	Lifespan   time.Duration `koanf:"lifespan" json:"lifespan" jsonschema:"default=1 year,title=Lifespan" jsonschema_description:"The amount of time a device is known for after it was last used to sign in."`
+	IPv4Subnet int           `koanf:"ipv4_subnet" json:"ipv4_subnet" jsonschema:"default=24,minimum=1,maximum=32,title=IPv4 Subnet" jsonschema_description:"The prefix length of the IPv4 subnet which is considered the same location."`
+	IPv6Subnet int           `koanf:"ipv6_subnet" json:"ipv6_subnet" jsonschema:"default=64,minimum=1,maximum=128,title=IPv6 Subnet" jsonschema_description:"The prefix length of the IPv6 subnet which is considered the same location."`
+}
+
+// DefaultKnownDevicesConfiguration represents default configuration parameters for the known devices.
+var DefaultKnownDevicesConfiguration = KnownDevices{
+	CookieName: "authelia_device",
+	Lifespan:   time.Hour * 24 * 365,
+	IPv4Subnet: 24,
+	IPv6Subnet: 64,
+}
@@ -12,6 +12,7 @@ type Notifier struct {
 	DisableStartupCheck bool                `koanf:"disable_startup_check" json:"disable_startup_check" jsonschema:"default=false,title=Disable Startup Check" jsonschema_description:"Disables the notifier startup checks."`
 	FileSystem          *NotifierFileSystem `koanf:"filesystem" json:"filesystem" jsonschema:"title=File System" jsonschema_description:"The File System notifier."`
 	SMTP                *NotifierSMTP       `koanf:"smtp" json:"smtp" jsonschema:"title=SMTP" jsonschema_description:"The SMTP notifier."`
+	Webhook             *NotifierWebhook    `koanf:"webhook" json:"webhook" jsonschema:"title=Webhook" jsonschema_description:"The Webhook notifier."`
 	TemplatePath        string              `koanf:"template_path" json:"template_path" jsonschema:"title=Template Path" jsonschema_description:"The path for notifier template overrides."`
 }
 
@@ -42,6 +43,18 @@ type NotifierSMTP struct {
 	Port int `koanf:"port" json:"port" jsonschema:"deprecated"`
 }
 
+// NotifierWebhook represents the configuration of the HTTP endpoint to send notifications to.
+type NotifierWebhook struct {
+	URL           *url.URL      `koanf:"url" json:"url" jsonschema:"title=URL" jsonschema_description:"The URL notifications are sent to."`
+	Timeout       time.Duration `koanf:"timeout" json:"timeout" jsonschema:"default=5 seconds,title=Timeout" jsonschema_description:"The timeout of each request."`
+	Secret        string        `koanf:"secret" json:"secret" jsonschema:"title=Secret" jsonschema_description:"The secret used to sign the payload with HMAC-SHA256."`
+	Payload       string        `koanf:"payload" json:"payload" jsonschema:"title=Payload" jsonschema_description:"The template used to render the payload instead of the default JSON payload."`
+	ContentType   string        `koanf:"content_type" json:"content_type" jsonschema:"default=application/json,title=Content Type" jsonschema_description:"The content type of the payload."`
+	Retries       int           `koanf:"retries" json:"retries" jsonschema:"default=3,title=Retries" jsonschema_description:"The number of times a failed request is retried."`
+	RetryInterval time.Duration `koanf:"retry_interval" json:"retry_interval" jsonschema:"default=1 second,title=Retry Interval" jsonschema_description:"The amount of time to wait before the first retry which doubles for each subsequent retry."`
+	TLS           *TLS          `koanf:"tls" json:"tls" jsonschema:"title=TLS" jsonschema_description:"The TLS connection properties."`
+}
+
 // DefaultSMTPNotifierConfiguration represents default configuration parameters for the SMTP notifier.
 var DefaultSMTPNotifierConfiguration = NotifierSMTP{
 	Address:             &AddressSMTP{Address{true, false, -1, 25, &url.URL{Scheme: AddressSchemeSMTP, Host: "localhost:25"}}},
@@ -53,3 +66,14 @@ var DefaultSMTPNotifierConfiguration = NotifierSMTP{
 		MinimumVersion: TLSVersion{tls.VersionTLS12},
 	},
 }
+
+// DefaultWebhookNotifierConfiguration represents default configuration parameters for the Webhook notifier.
+var DefaultWebhookNotifierConfiguration = NotifierWebhook{
+	Timeout:       time.Second * 5,
+	ContentType:   "application/json",
+	Retries:       3,
+	RetryInterval: time.Second,
+	TLS: &TLS{
+		MinimumVersion: TLSVersion{tls.VersionTLS12},
+	},
+}
@@ -9,6 +9,26 @@ type Regulation struct {
 	MaxRetries int           `koanf:"max_retries" json:"max_retries" jsonschema:"default=3,title=Maximum Retries" jsonschema_description:"The maximum number of failed attempts permitted before banning a user."`
 	FindTime   time.Duration `koanf:"find_time" json:"find_time" jsonschema:"default=2 minutes,title=Find Time" jsonschema_description:"The amount of time to consider when determining the number of failed attempts."`
 	BanTime    time.Duration `koanf:"ban_time" json:"ban_time" jsonschema:"default=5 minutes,title=Ban Time" jsonschema_description:"The amount of time to ban the user for when it's determined the maximum retries has been exceeded."`
+
+	IP              RegulationIP     `koanf:"ip" json:"ip" jsonschema:"title=IP" jsonschema_description:"The regulation of the failed attempts from each remote IP."`
+	Subnet          RegulationSubnet `koanf:"subnet" json:"subnet" jsonschema:"title=Subnet" jsonschema_description:"The regulation of the failed attempts from each subnet."`
+	AllowedNetworks []string         `koanf:"allowed_networks" json:"allowed_networks" jsonschema:"title=Allowed Networks" jsonschema_description:"The remote IP's or network ranges in CIDR notation which are never banned."`
+}
+
+// RegulationIP represents the configuration related to the regulation of each remote IP.
+type RegulationIP struct {
+	MaxRetries int           `koanf:"max_retries" json:"max_retries" jsonschema:"default=0,title=Maximum Retries" jsonschema_description:"The maximum number of failed attempts permitted from a remote IP before banning it."`
+	FindTime   time.Duration `koanf:"find_time" json:"find_time" jsonschema:"title=Find Time" jsonschema_description:"The amount of time to consider when determining the number of failed attempts."`
+	BanTime    time.Duration `koanf:"ban_time" json:"ban_time" jsonschema:"title=Ban Time" jsonschema_description:"The amount of time to ban the remote IP for when it's determined the maximum retries has been exceeded."`
+}
+
+// RegulationSubnet represents the configuration related to the regulation of each subnet.
+type RegulationSubnet struct {
+	MaxRetries int           `koanf:"max_retries" json:"max_retries" jsonschema:"default=0,title=Maximum Retries" jsonschema_description:"The maximum number of failed attempts permitted from a subnet before banning it."`
+	FindTime   time.Duration `koanf:"find_time" json:"find_time" jsonschema:"title=Find Time" jsonschema_description:"The amount of time to consider when determining the number of failed attempts."`
+	BanTime    time.Duration `koanf:"ban_time" json:"ban_time" jsonschema:"title=Ban Time" jsonschema_description:"The amount of time to ban the subnet for when it's determined the maximum retries has been exceeded."`
+	IPv4Prefix int           `koanf:"ipv4_prefix" json:"ipv4_prefix" jsonschema:"default=24,minimum=1,maximum=32,title=IPv4 Prefix" jsonschema_description:"The prefix length of the subnet of IPv4 addresses."`
+	IPv6Prefix int           `koanf:"ipv6_prefix" json:"ipv6_prefix" jsonschema:"default=64,minimum=1,maximum=128,title=IPv6 Prefix" jsonschema_description:"The prefix length of the subnet of IPv6 addresses."`
 }
 
 // DefaultRegulationConfiguration represents default configuration parameters for the regulator.
@@ -16,4 +36,8 @@ var DefaultRegulationConfiguration = Regulation{
 	MaxRetries: 3,
 	FindTime:   time.Minute * 2,
 	BanTime:    time.Minute * 5,
+	Subnet: RegulationSubnet{
+		IPv4Prefix: 24,
+		IPv6Prefix: 64,
+	},
 }
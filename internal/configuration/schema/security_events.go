@@ -0,0 +1,50 @@
+package schema
+
+import (
+	"net/mail"
+	"net/url"
+	"time"
+)
+
+// SecurityEvents represents the configuration of the security event feed which delivers security events such as
+// sign-ins, bans, and second factor changes to the administrators.
+type SecurityEvents struct {
+	EmailDigest SecurityEventsEmailDigest `koanf:"email_digest" json:"email_digest" jsonschema:"title=Email Digest" jsonschema_description:"The email digest subscriber."`
+	Webhook     SecurityEventsWebhook     `koanf:"webhook" json:"webhook" jsonschema:"title=Webhook" jsonschema_description:"The webhook subscriber."`
+	Log         SecurityEventsLog         `koanf:"log" json:"log" jsonschema:"title=Log" jsonschema_description:"The JSON log subscriber."`
+}
+
+// SecurityEventsEmailDigest represents the configuration of the subscriber which periodically sends the security
+// events to the administrators via the notifier.
+type SecurityEventsEmailDigest struct {
+	Recipients []mail.Address `koanf:"recipients" json:"recipients" jsonschema:"title=Recipients" jsonschema_description:"The addresses the digest is sent to. When not configured the email digest is disabled."`
+	Interval   time.Duration  `koanf:"interval" json:"interval" jsonschema:"default=1 hour,title=Interval" jsonschema_description:"The interval the digest is sent at."`
+	Events     []string       `koanf:"events" json:"events" jsonschema:"title=Events" jsonschema_description:"The security event types included in the digest. When not configured all types are included."`
+}
+
+// SecurityEventsWebhook represents the configuration of the subscriber which sends each security event to a HTTP
+// endpoint.
+type SecurityEventsWebhook struct {
+	URL     *url.URL      `koanf:"url" json:"url" jsonschema:"title=URL" jsonschema_description:"The URL the security events are sent to. When not configured the webhook is disabled."`
+	Timeout time.Duration `koanf:"timeout" json:"timeout" jsonschema:"default=5 seconds,title=Timeout" jsonschema_description:"The timeout of each request."`
+	Secret  string        `koanf:"secret" json:"secret" jsonschema:"title=Secret" jsonschema_description:"The secret used to sign the payload with HMAC-SHA256."`
+	Events  []string      `koanf:"events" json:"events" jsonschema:"title=Events" jsonschema_description:"The security event types sent to the webhook. When not configured all types are sent."`
+	TLS     *TLS          `koanf:"tls" json:"tls" jsonschema:"title=TLS" jsonschema_description:"The TLS connection properties."`
+}
+
+// SecurityEventsLog represents the configuration of the subscriber which appends each security event to a file as
+// JSON lines.
+type SecurityEventsLog struct {
+	Path   string   `koanf:"path" json:"path" jsonschema:"title=Path" jsonschema_description:"The path of the file the security events are appended to. When not configured the log is disabled."`
+	Events []string `koanf:"events" json:"events" jsonschema:"title=Events" jsonschema_description:"The security event types appended to the log. When not configured all types are appended."`
+}
+
+// DefaultSecurityEventsConfiguration represents the default configuration related to the security event feed.
+var DefaultSecurityEventsConfiguration = SecurityEvents{
+	EmailDigest: SecurityEventsEmailDigest{
+		Interval: time.Hour,
+	},
+	Webhook: SecurityEventsWebhook{
+		Timeout: time.Second * 5,
+	},
+}
@@ -32,6 +32,8 @@ type ServerEndpointsAuthz struct {
 	Implementation string `koanf:"implementation" json:"implementation" jsonschema:"enum=ForwardAuth,enum=AuthRequest,enum=ExtAuthz,enum=Legacy,title=Implementation" jsonschema_description:"The specific Authorization implementation to use for this endpoint."`
 
 	AuthnStrategies []ServerEndpointsAuthzAuthnStrategy `koanf:"authn_strategies" json:"authn_strategies" jsonschema:"title=Authn Strategies" jsonschema_description:"The specific Authorization strategies to use for this endpoint."`
+
+	Headers []ServerEndpointsAuthzHeader `koanf:"headers" json:"headers" jsonschema:"title=Headers" jsonschema_description:"The additional response headers rendered from templates for this endpoint."`
 }
 
 // ServerEndpointsAuthzAuthnStrategy is the Authz endpoints configuration for the HTTP server.
@@ -40,6 +42,12 @@ type ServerEndpointsAuthzAuthnStrategy struct {
 	Schemes []string `koanf:"schemes" json:"schemes" jsonschema:"enum=basic,enum=bearer,default=basic,title=Authorization Schemes" jsonschema_description:"The name of the authorization schemes to allow with the header strategies."`
 }
 
+// ServerEndpointsAuthzHeader is the Authz endpoints templated header configuration for the HTTP server.
+type ServerEndpointsAuthzHeader struct {
+	Name  string `koanf:"name" json:"name" jsonschema:"title=Name" jsonschema_description:"The name of the response header."`
+	Value string `koanf:"value" json:"value" jsonschema:"title=Value" jsonschema_description:"The template rendered as the value of the response header."`
+}
+
 // ServerTLS represents the configuration of the http servers TLS options.
 type ServerTLS struct {
 	Certificate        string   `koanf:"certificate" json:"certificate" jsonschema:"title=Certificate" jsonschema_description:"Path to the Certificate."`
@@ -1,13 +1,16 @@
 package validator
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/templates"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
@@ -69,6 +72,32 @@ func ValidateAccessControl(config *schema.Configuration, validator *schema.Struc
 			}
 		}
 	}
+
+	validateAccessControlAudit(config, validator)
+}
+
+func validateAccessControlAudit(config *schema.Configuration, validator *schema.StructValidator) {
+	audit := &config.AccessControl.Audit
+
+	switch audit.Mode {
+	case "":
+		break
+	case "file":
+		if audit.Path == "" {
+			validator.Push(errors.New(errFmtAccessControlAuditFileNoPath))
+		}
+	case "storage":
+		break
+	default:
+		validator.Push(fmt.Errorf(errFmtAccessControlAuditMode, utils.StringJoinOr(validACLAuditModes), audit.Mode))
+	}
+
+	switch {
+	case audit.Retention < 0:
+		validator.Push(fmt.Errorf(errFmtAccessControlAuditRetention, audit.Retention))
+	case audit.Retention > 0 && audit.Mode == "":
+		validator.Push(errors.New(errFmtAccessControlAuditRetentionNoMode))
+	}
 }
 
 // ValidateRules validates an ACL Rule configuration.
@@ -93,9 +122,15 @@ func ValidateRules(config *schema.Configuration, validator *schema.StructValidat
 		switch rule.Policy {
 		case "":
 			validator.Push(fmt.Errorf(errFmtAccessControlRuleNoPolicy, ruleDescriptor(rulePosition, rule)))
+		case policyWebhook:
+			validateWebhook(rulePosition, &config.AccessControl.Rules[i], validator)
 		default:
 			if !IsPolicyValid(rule.Policy) {
-				validator.Push(fmt.Errorf(errFmtAccessControlRuleInvalidPolicy, ruleDescriptor(rulePosition, rule), utils.StringJoinOr(validACLRulePolicies), rule.Policy))
+				validator.Push(fmt.Errorf(errFmtAccessControlRuleInvalidPolicy, ruleDescriptor(rulePosition, rule), utils.StringJoinOr(validACLRuleWebhookPolicies), rule.Policy))
+			}
+
+			if rule.Webhook != (schema.AccessControlRuleWebhook{}) {
+				validator.Push(fmt.Errorf(errFmtAccessControlRuleWebhookPolicy, ruleDescriptor(rulePosition, rule), rule.Policy))
 			}
 		}
 
@@ -107,12 +142,214 @@ func ValidateRules(config *schema.Configuration, validator *schema.StructValidat
 
 		validateQuery(i, rule, config, validator)
 
+		validateHeaders(i, rule, config, validator)
+
+		validateSchedule(rulePosition, rule, validator)
+
+		validateMaxAuthenticationAge(rulePosition, rule, validator)
+
+		validateSecondFactorMethods(rulePosition, rule, config, validator)
+
+		validateResponseHeaders(rulePosition, rule, validator)
+
 		if rule.Policy == policyBypass {
 			validateBypass(rulePosition, rule, validator)
 		}
 	}
 }
 
+func validateWebhook(rulePosition int, rule *schema.AccessControlRule, validator *schema.StructValidator) {
+	webhook := &rule.Webhook
+
+	switch {
+	case webhook.URL == nil:
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleWebhookNoURL, ruleDescriptor(rulePosition, *rule)))
+	case webhook.URL.Scheme != schemeHTTP && webhook.URL.Scheme != schemeHTTPS:
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleWebhookURLScheme, ruleDescriptor(rulePosition, *rule), utils.StringJoinOr([]string{schemeHTTP, schemeHTTPS}), webhook.URL.String()))
+	}
+
+	switch {
+	case webhook.Timeout < 0:
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleWebhookNegative, ruleDescriptor(rulePosition, *rule), "timeout", webhook.Timeout))
+	case webhook.Timeout == 0:
+		webhook.Timeout = schema.DefaultACLRuleWebhook.Timeout
+	}
+
+	if webhook.CacheTTL < 0 {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleWebhookNegative, ruleDescriptor(rulePosition, *rule), "cache_ttl", webhook.CacheTTL))
+	}
+
+	switch webhook.FailurePolicy {
+	case "":
+		webhook.FailurePolicy = schema.DefaultACLRuleWebhook.FailurePolicy
+	default:
+		if !IsPolicyValid(webhook.FailurePolicy) {
+			validator.Push(fmt.Errorf(errFmtAccessControlRuleWebhookFailurePolicy, ruleDescriptor(rulePosition, *rule), utils.StringJoinOr(validACLRulePolicies), webhook.FailurePolicy))
+		}
+	}
+}
+
+func validateResponseHeaders(rulePosition int, rule schema.AccessControlRule, validator *schema.StructValidator) {
+	if len(rule.ResponseHeaders) == 0 {
+		return
+	}
+
+	if rule.Policy == policyDeny {
+		validator.PushWarning(fmt.Errorf(errFmtAccessControlRuleResponseHeadersNoEffect, ruleDescriptor(rulePosition, rule), rule.Policy))
+	}
+
+	names := make([]string, 0, len(rule.ResponseHeaders))
+
+	for i, header := range rule.ResponseHeaders {
+		switch {
+		case header.Name == "":
+			validator.Push(fmt.Errorf(errFmtAccessControlRuleResponseHeadersNoName, ruleDescriptor(rulePosition, rule), i+1))
+		case !reHTTPHeaderName.MatchString(header.Name):
+			validator.Push(fmt.Errorf(errFmtAccessControlRuleResponseHeadersName, ruleDescriptor(rulePosition, rule), i+1, header.Name))
+		case utils.IsStringInSliceFold(header.Name, names):
+			validator.Push(fmt.Errorf(errFmtAccessControlRuleResponseHeadersDuplicate, ruleDescriptor(rulePosition, rule), header.Name))
+		}
+
+		names = append(names, header.Name)
+
+		if header.Value == "" {
+			validator.Push(fmt.Errorf(errFmtAccessControlRuleResponseHeadersNoValue, ruleDescriptor(rulePosition, rule), i+1, header.Name))
+
+			continue
+		}
+
+		if _, err := templates.ParseStringTemplate(header.Name, header.Value); err != nil {
+			validator.Push(fmt.Errorf(errFmtAccessControlRuleResponseHeadersValue, ruleDescriptor(rulePosition, rule), i+1, header.Name, err))
+		}
+	}
+}
+
+func validateSchedule(rulePosition int, rule schema.AccessControlRule, validator *schema.StructValidator) {
+	location, err := time.LoadLocation(rule.Schedule.TimeZone)
+	if err != nil {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleScheduleTimeZoneInvalid, ruleDescriptor(rulePosition, rule), err))
+
+		location = time.UTC
+	}
+
+	if len(rule.Schedule.Windows) == 0 {
+		if rule.Schedule.TimeZone != "" {
+			validator.Push(fmt.Errorf(errFmtAccessControlRuleScheduleNoWindows, ruleDescriptor(rulePosition, rule)))
+		}
+
+		return
+	}
+
+	for i, window := range rule.Schedule.Windows {
+		validateScheduleWindow(rulePosition, i+1, rule, window, location, validator)
+	}
+}
+
+func validateScheduleWindow(rulePosition, windowPosition int, rule schema.AccessControlRule, window schema.AccessControlRuleScheduleWindow, location *time.Location, validator *schema.StructValidator) {
+	var err error
+
+	for _, day := range window.Days {
+		if _, err = authorization.ParseScheduleWeekday(day); err != nil {
+			validator.Push(fmt.Errorf(errFmtAccessControlRuleScheduleWindowInvalid, ruleDescriptor(rulePosition, rule), windowPosition, "days", err))
+		}
+	}
+
+	start, end := 0, 24*60
+
+	if window.Start != "" {
+		if start, err = authorization.ParseScheduleTimeOfDay(window.Start); err != nil {
+			validator.Push(fmt.Errorf(errFmtAccessControlRuleScheduleWindowInvalid, ruleDescriptor(rulePosition, rule), windowPosition, "start", err))
+		} else if start == 24*60 {
+			validator.Push(fmt.Errorf(errFmtAccessControlRuleScheduleWindowStartMidnight, ruleDescriptor(rulePosition, rule), windowPosition, window.Start))
+		}
+	}
+
+	if window.End != "" {
+		if end, err = authorization.ParseScheduleTimeOfDay(window.End); err != nil {
+			validator.Push(fmt.Errorf(errFmtAccessControlRuleScheduleWindowInvalid, ruleDescriptor(rulePosition, rule), windowPosition, "end", err))
+		} else if window.Start == "" && end == 0 {
+			validator.Push(fmt.Errorf(errFmtAccessControlRuleScheduleWindowEndMidnight, ruleDescriptor(rulePosition, rule), windowPosition, window.End))
+		}
+	}
+
+	if window.Start != "" && window.End != "" && start == end {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleScheduleWindowStartEnd, ruleDescriptor(rulePosition, rule), windowPosition, window.Start))
+	}
+
+	var notBefore, notAfter time.Time
+
+	if window.NotBefore != "" {
+		if notBefore, err = authorization.ParseScheduleDateTime(window.NotBefore, location); err != nil {
+			validator.Push(fmt.Errorf(errFmtAccessControlRuleScheduleWindowInvalid, ruleDescriptor(rulePosition, rule), windowPosition, "not_before", err))
+		}
+	}
+
+	if window.NotAfter != "" {
+		if notAfter, err = authorization.ParseScheduleDateTime(window.NotAfter, location); err != nil {
+			validator.Push(fmt.Errorf(errFmtAccessControlRuleScheduleWindowInvalid, ruleDescriptor(rulePosition, rule), windowPosition, "not_after", err))
+		}
+	}
+
+	if !notBefore.IsZero() && !notAfter.IsZero() && !notBefore.Before(notAfter) {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleScheduleWindowNotBeforeNotAfter, ruleDescriptor(rulePosition, rule), windowPosition, window.NotBefore, window.NotAfter))
+	}
+}
+
+func validateMaxAuthenticationAge(rulePosition int, rule schema.AccessControlRule, validator *schema.StructValidator) {
+	switch {
+	case rule.MaxAuthenticationAge < 0:
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleMaxAuthenticationAgeNegative, ruleDescriptor(rulePosition, rule), rule.MaxAuthenticationAge))
+	case rule.MaxAuthenticationAge == 0:
+		return
+	case rule.Policy == policyBypass || rule.Policy == policyDeny:
+		validator.PushWarning(fmt.Errorf(errFmtAccessControlRuleMaxAuthenticationAgeNoEffect, ruleDescriptor(rulePosition, rule), rule.Policy))
+	}
+}
+
+func validateSecondFactorMethods(rulePosition int, rule schema.AccessControlRule, config *schema.Configuration, validator *schema.StructValidator) {
+	if len(rule.SecondFactorMethods) == 0 {
+		return
+	}
+
+	if rule.Policy != policyTwoFactor {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleSecondFactorMethodsPolicy, ruleDescriptor(rulePosition, rule), rule.Policy))
+	}
+
+	invalid, duplicates := validateList(rule.SecondFactorMethods, validACLRuleSecondFactorMethods, true)
+
+	if len(invalid) != 0 {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleInvalidEntries, ruleDescriptor(rulePosition, rule), "second_factor_methods", utils.StringJoinOr(validACLRuleSecondFactorMethods), utils.StringJoinAnd(invalid)))
+
+		return
+	}
+
+	if len(duplicates) != 0 {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleInvalidDuplicates, ruleDescriptor(rulePosition, rule), "second_factor_methods", utils.StringJoinAnd(duplicates)))
+	}
+
+	var enabled []string
+
+	if !config.TOTP.Disable {
+		enabled = append(enabled, "totp")
+	}
+
+	if !config.WebAuthn.Disable {
+		enabled = append(enabled, "webauthn", "webauthn_hardware")
+	}
+
+	if !config.DuoAPI.Disable {
+		enabled = append(enabled, "mobile_push")
+	}
+
+	for _, method := range rule.SecondFactorMethods {
+		if utils.IsStringInSlice(method, enabled) {
+			return
+		}
+	}
+
+	validator.Push(fmt.Errorf(errFmtAccessControlRuleSecondFactorMethodsDisabled, ruleDescriptor(rulePosition, rule), utils.StringJoinOr(enabled), utils.StringJoinAnd(rule.SecondFactorMethods)))
+}
+
 func validateBypass(rulePosition int, rule schema.AccessControlRule, validator *schema.StructValidator) {
 	if len(rule.Subjects) != 0 {
 		validator.Push(fmt.Errorf(errAccessControlRuleBypassPolicyInvalidWithSubjects, ruleDescriptor(rulePosition, rule)))
@@ -226,3 +463,62 @@ func validateQuery(i int, rule schema.AccessControlRule, config *schema.Configur
 		}
 	}
 }
+
+func validateHeaders(i int, rule schema.AccessControlRule, config *schema.Configuration, validator *schema.StructValidator) {
+	for j := 0; j < len(config.AccessControl.Rules[i].Headers); j++ {
+		for k := 0; k < len(config.AccessControl.Rules[i].Headers[j]); k++ {
+			header := &config.AccessControl.Rules[i].Headers[j][k]
+
+			if header.Operator == "" {
+				if header.Name != "" {
+					switch header.Value {
+					case "", nil:
+						header.Operator = operatorPresent
+					default:
+						header.Operator = operatorEqual
+					}
+				}
+			} else if !utils.IsStringInSliceFold(header.Operator, validACLRuleOperators) {
+				validator.Push(fmt.Errorf(errFmtAccessControlRuleHeadersInvalid, ruleDescriptor(i+1, rule), utils.StringJoinOr(validACLRuleOperators), header.Operator))
+			}
+
+			switch {
+			case header.Name == "":
+				validator.Push(fmt.Errorf(errFmtAccessControlRuleHeadersInvalidNoValue, ruleDescriptor(i+1, rule), "name"))
+			case !reHTTPHeaderName.MatchString(header.Name):
+				validator.Push(fmt.Errorf(errFmtAccessControlRuleHeadersInvalidName, ruleDescriptor(i+1, rule), header.Name))
+			}
+
+			if header.Operator == "" {
+				continue
+			}
+
+			switch v := header.Value.(type) {
+			case nil:
+				if header.Operator != operatorAbsent && header.Operator != operatorPresent {
+					validator.Push(fmt.Errorf(errFmtAccessControlRuleHeadersInvalidNoValueOperator, ruleDescriptor(i+1, rule), "value", header.Operator))
+				}
+			case string:
+				switch header.Operator {
+				case operatorPresent, operatorAbsent:
+					if v != "" {
+						validator.Push(fmt.Errorf(errFmtAccessControlRuleHeadersInvalidValue, ruleDescriptor(i+1, rule), "value", header.Operator))
+					}
+				case operatorPattern, operatorNotPattern:
+					var (
+						pattern *regexp.Regexp
+						err     error
+					)
+
+					if pattern, err = regexp.Compile(v); err != nil {
+						validator.Push(fmt.Errorf(errFmtAccessControlRuleHeadersInvalidValueParse, ruleDescriptor(i+1, rule), "value", err))
+					} else {
+						header.Value = pattern
+					}
+				}
+			default:
+				validator.Push(fmt.Errorf(errFmtAccessControlRuleHeadersInvalidValueType, ruleDescriptor(i+1, rule), v))
+			}
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -89,6 +90,72 @@ func (suite *AccessControl) TestShouldRaiseErrorInvalidNetworkGroupNetwork() {
 	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: networks: network group 'internal' is invalid: the network 'abc.def.ghi.jkl' is not a valid IP or CIDR notation")
 }
 
+func (suite *AccessControl) TestShouldValidateAudit() {
+	testCases := []struct {
+		name     string
+		have     schema.AccessControlAudit
+		expected []string
+	}{
+		{
+			"ShouldAllowDisabled",
+			schema.AccessControlAudit{},
+			nil,
+		},
+		{
+			"ShouldAllowStorageWithRetention",
+			schema.AccessControlAudit{Mode: "storage", Retention: time.Hour * 24 * 90},
+			nil,
+		},
+		{
+			"ShouldAllowFile",
+			schema.AccessControlAudit{Mode: "file", Path: "/var/log/authelia/audit.log"},
+			nil,
+		},
+		{
+			"ShouldRaiseErrorInvalidMode",
+			schema.AccessControlAudit{Mode: "syslog"},
+			[]string{"access_control: audit: option 'mode' must be one of 'storage' or 'file' but it's configured as 'syslog'"},
+		},
+		{
+			"ShouldRaiseErrorFileWithoutPath",
+			schema.AccessControlAudit{Mode: "file"},
+			[]string{"access_control: audit: option 'path' must be present when the option 'mode' is 'file' but it's absent"},
+		},
+		{
+			"ShouldAllowFileWithRetention",
+			schema.AccessControlAudit{Mode: "file", Path: "/var/log/authelia/audit.log", Retention: time.Hour * 24 * 30},
+			nil,
+		},
+		{
+			"ShouldRaiseErrorRetentionWithoutMode",
+			schema.AccessControlAudit{Retention: time.Hour},
+			[]string{"access_control: audit: option 'retention' must only be configured when the option 'mode' is configured but it's absent"},
+		},
+		{
+			"ShouldRaiseErrorNegativeRetention",
+			schema.AccessControlAudit{Mode: "storage", Retention: -time.Hour},
+			[]string{"access_control: audit: option 'retention' must not be negative but it's configured as '-1h0m0s'"},
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+
+			suite.config.AccessControl.Audit = tc.have
+
+			ValidateAccessControl(suite.config, suite.validator)
+
+			suite.Assert().Len(suite.validator.Warnings(), 0)
+			suite.Require().Len(suite.validator.Errors(), len(tc.expected))
+
+			for i, expected := range tc.expected {
+				suite.Assert().EqualError(suite.validator.Errors()[i], expected)
+			}
+		})
+	}
+}
+
 func (suite *AccessControl) TestShouldRaiseWarningOnBadDomain() {
 	suite.config.AccessControl.Rules = []schema.AccessControlRule{
 		{
@@ -145,7 +212,7 @@ func (suite *AccessControl) TestShouldRaiseErrorsWithEmptyRules() {
 	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #1: option 'domain' or 'domain_regex' must be present but are both absent")
 	suite.Assert().EqualError(suite.validator.Errors()[1], "access_control: rule #1: option 'policy' must be present but it's absent")
 	suite.Assert().EqualError(suite.validator.Errors()[2], "access_control: rule #2: option 'domain' or 'domain_regex' must be present but are both absent")
-	suite.Assert().EqualError(suite.validator.Errors()[3], "access_control: rule #2: option 'policy' must be one of 'bypass', 'one_factor', 'two_factor', or 'deny' but it's configured as 'wrong'")
+	suite.Assert().EqualError(suite.validator.Errors()[3], "access_control: rule #2: option 'policy' must be one of 'bypass', 'one_factor', 'two_factor', 'deny', or 'webhook' but it's configured as 'wrong'")
 }
 
 func (suite *AccessControl) TestShouldRaiseErrorInvalidPolicy() {
@@ -161,7 +228,7 @@ func (suite *AccessControl) TestShouldRaiseErrorInvalidPolicy() {
 	suite.Assert().Len(suite.validator.Warnings(), 0)
 	suite.Require().Len(suite.validator.Errors(), 1)
 
-	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #1 (domain 'public.example.com'): option 'policy' must be one of 'bypass', 'one_factor', 'two_factor', or 'deny' but it's configured as 'invalid'")
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #1 (domain 'public.example.com'): option 'policy' must be one of 'bypass', 'one_factor', 'two_factor', 'deny', or 'webhook' but it's configured as 'invalid'")
 }
 
 func (suite *AccessControl) TestShouldRaiseErrorInvalidNetwork() {
@@ -394,6 +461,289 @@ func (suite *AccessControl) TestShouldErrorOnInvalidRulesQuery() {
 	suite.Assert().EqualError(suite.validator.Errors()[6], "access_control: rule #9 (domain 'public.example.com'): query: option 'value' is invalid: expected type was string but got int")
 }
 
+func (suite *AccessControl) TestShouldErrorOnInvalidRulesHeaders() {
+	domains := []string{"public.example.com"}
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains: domains,
+			Policy:  "bypass",
+			Headers: [][]schema.AccessControlRuleHeader{
+				{
+					{Name: "User-Agent", Value: "^Nextcloud-android", Operator: "pattern"},
+					{Name: "X-Api-Version"},
+					{Name: "X-Client", Value: "sync"},
+				},
+			},
+		},
+		{
+			Domains: domains,
+			Policy:  "bypass",
+			Headers: [][]schema.AccessControlRuleHeader{
+				{
+					{Operator: "equal", Name: "X-Api-Version"},
+					{Operator: "present"},
+					{Operator: "present", Name: "X Bad"},
+				},
+			},
+		},
+		{
+			Domains: domains,
+			Policy:  "bypass",
+			Headers: [][]schema.AccessControlRuleHeader{
+				{
+					{Operator: "not", Name: "a", Value: "a"},
+					{Operator: "pattern", Name: "a", Value: "(bad pattern"},
+					{Operator: "absent", Name: "a", Value: "not good"},
+					{Operator: "equal", Name: "a", Value: 5},
+				},
+			},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 7)
+
+	suite.Assert().IsType(&regexp.Regexp{}, suite.config.AccessControl.Rules[0].Headers[0][0].Value)
+	suite.Assert().Equal("present", suite.config.AccessControl.Rules[0].Headers[0][1].Operator)
+	suite.Assert().Equal("equal", suite.config.AccessControl.Rules[0].Headers[0][2].Operator)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #2 (domain 'public.example.com'): headers: option 'value' must be present when the option 'operator' is 'equal' but it's absent")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "access_control: rule #2 (domain 'public.example.com'): headers: option 'name' is required but it's absent")
+	suite.Assert().EqualError(suite.validator.Errors()[2], "access_control: rule #2 (domain 'public.example.com'): headers: option 'name' must only contain valid header name characters but it's configured as 'X Bad'")
+	suite.Assert().EqualError(suite.validator.Errors()[3], "access_control: rule #3 (domain 'public.example.com'): headers: option 'operator' must be one of 'present', 'absent', 'equal', 'not equal', 'pattern', or 'not pattern' but it's configured as 'not'")
+	suite.Assert().EqualError(suite.validator.Errors()[4], "access_control: rule #3 (domain 'public.example.com'): headers: option 'value' is invalid: error parsing regexp: missing closing ): `(bad pattern`")
+	suite.Assert().EqualError(suite.validator.Errors()[5], "access_control: rule #3 (domain 'public.example.com'): headers: option 'value' must not be present when the option 'operator' is 'absent' but it's present")
+	suite.Assert().EqualError(suite.validator.Errors()[6], "access_control: rule #3 (domain 'public.example.com'): headers: option 'value' is invalid: expected type was string but got int")
+}
+
+func (suite *AccessControl) TestShouldValidateMaxAuthenticationAge() {
+	domains := []string{"public.example.com"}
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains:              domains,
+			Policy:               "two_factor",
+			MaxAuthenticationAge: time.Hour,
+		},
+		{
+			Domains:              domains,
+			Policy:               "one_factor",
+			MaxAuthenticationAge: -time.Minute,
+		},
+		{
+			Domains:              domains,
+			Policy:               "bypass",
+			MaxAuthenticationAge: time.Minute,
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Require().Len(suite.validator.Warnings(), 1)
+	suite.Require().Len(suite.validator.Errors(), 1)
+
+	suite.Assert().EqualError(suite.validator.Warnings()[0], "access_control: rule #3 (domain 'public.example.com'): option 'max_authentication_age' has no effect when the option 'policy' is 'bypass'")
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #2 (domain 'public.example.com'): option 'max_authentication_age' must not be negative but it's configured as '-1m0s'")
+}
+
+func (suite *AccessControl) TestShouldValidateSecondFactorMethods() {
+	domains := []string{"public.example.com"}
+	suite.config.TOTP.Disable = true
+	suite.config.DuoAPI.Disable = true
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains:             domains,
+			Policy:              "two_factor",
+			SecondFactorMethods: []string{"webauthn", "webauthn_hardware"},
+		},
+		{
+			Domains:             domains,
+			Policy:              "one_factor",
+			SecondFactorMethods: []string{"webauthn"},
+		},
+		{
+			Domains:             domains,
+			Policy:              "two_factor",
+			SecondFactorMethods: []string{"webauthn", "sms"},
+		},
+		{
+			Domains:             domains,
+			Policy:              "two_factor",
+			SecondFactorMethods: []string{"webauthn", "webauthn"},
+		},
+		{
+			Domains:             domains,
+			Policy:              "two_factor",
+			SecondFactorMethods: []string{"totp", "mobile_push"},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 4)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #2 (domain 'public.example.com'): option 'second_factor_methods' must only be configured when the option 'policy' is 'two_factor' but it's configured as 'one_factor'")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "access_control: rule #3 (domain 'public.example.com'): option 'second_factor_methods' must only have the values 'totp', 'webauthn', 'webauthn_hardware', or 'mobile_push' but the values 'sms' are present")
+	suite.Assert().EqualError(suite.validator.Errors()[2], "access_control: rule #4 (domain 'public.example.com'): option 'second_factor_methods' must have unique values but the values 'webauthn' are duplicated")
+	suite.Assert().EqualError(suite.validator.Errors()[3], "access_control: rule #5 (domain 'public.example.com'): option 'second_factor_methods' must contain at least one enabled method of 'webauthn' or 'webauthn_hardware' but it's configured as 'totp' and 'mobile_push'")
+}
+
+func (suite *AccessControl) TestShouldValidateWebhook() {
+	domains := []string{"public.example.com"}
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains: domains,
+			Policy:  "webhook",
+			Webhook: schema.AccessControlRuleWebhook{URL: MustParseURL("https://entitlements.example.com/authorize")},
+		},
+		{
+			Domains: domains,
+			Policy:  "webhook",
+		},
+		{
+			Domains: domains,
+			Policy:  "webhook",
+			Webhook: schema.AccessControlRuleWebhook{
+				URL:           MustParseURL("ftp://entitlements.example.com/authorize"),
+				Timeout:       -time.Second,
+				CacheTTL:      -time.Minute,
+				FailurePolicy: "webhook",
+			},
+		},
+		{
+			Domains: domains,
+			Policy:  "one_factor",
+			Webhook: schema.AccessControlRuleWebhook{URL: MustParseURL("https://entitlements.example.com/authorize")},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 6)
+
+	suite.Assert().Equal(time.Second*5, suite.config.AccessControl.Rules[0].Webhook.Timeout)
+	suite.Assert().Equal("deny", suite.config.AccessControl.Rules[0].Webhook.FailurePolicy)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #2 (domain 'public.example.com'): webhook: option 'url' must be present when the option 'policy' is 'webhook' but it's absent")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "access_control: rule #3 (domain 'public.example.com'): webhook: option 'url' must have the scheme 'http' or 'https' but it's configured as 'ftp://entitlements.example.com/authorize'")
+	suite.Assert().EqualError(suite.validator.Errors()[2], "access_control: rule #3 (domain 'public.example.com'): webhook: option 'timeout' must not be negative but it's configured as '-1s'")
+	suite.Assert().EqualError(suite.validator.Errors()[3], "access_control: rule #3 (domain 'public.example.com'): webhook: option 'cache_ttl' must not be negative but it's configured as '-1m0s'")
+	suite.Assert().EqualError(suite.validator.Errors()[4], "access_control: rule #3 (domain 'public.example.com'): webhook: option 'failure_policy' must be one of 'bypass', 'one_factor', 'two_factor', or 'deny' but it's configured as 'webhook'")
+	suite.Assert().EqualError(suite.validator.Errors()[5], "access_control: rule #4 (domain 'public.example.com'): option 'webhook' must only be configured when the option 'policy' is 'webhook' but it's configured as 'one_factor'")
+}
+
+func (suite *AccessControl) TestShouldValidateResponseHeaders() {
+	domains := []string{"public.example.com"}
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains: domains,
+			Policy:  "two_factor",
+			ResponseHeaders: []schema.AccessControlRuleResponseHeader{
+				{Name: "X-Forwarded-User", Value: "{{ .Username }}"},
+				{Name: "X-Forwarded-Groups", Value: `{{ join "," .Groups }}`},
+			},
+		},
+		{
+			Domains: domains,
+			Policy:  "one_factor",
+			ResponseHeaders: []schema.AccessControlRuleResponseHeader{
+				{Value: "{{ .Username }}"},
+				{Name: "X Forwarded User", Value: "{{ .Username }}"},
+				{Name: "X-Forwarded-Email"},
+				{Name: "X-Forwarded-Groups", Value: "{{ nope .Groups }}"},
+				{Name: "Remote-Name", Value: "{{ .DisplayName }}"},
+				{Name: "remote-name", Value: "{{ .DisplayName }}"},
+			},
+		},
+		{
+			Domains: domains,
+			Policy:  "deny",
+			ResponseHeaders: []schema.AccessControlRuleResponseHeader{
+				{Name: "X-Forwarded-User", Value: "{{ .Username }}"},
+			},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Require().Len(suite.validator.Warnings(), 1)
+	suite.Require().Len(suite.validator.Errors(), 5)
+
+	suite.Assert().EqualError(suite.validator.Warnings()[0], "access_control: rule #3 (domain 'public.example.com'): option 'response_headers' has no effect when the option 'policy' is 'deny'")
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #2 (domain 'public.example.com'): response_headers: header #1: option 'name' must be present but it's absent")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "access_control: rule #2 (domain 'public.example.com'): response_headers: header #2: option 'name' must only contain valid header name characters but it's configured as 'X Forwarded User'")
+	suite.Assert().EqualError(suite.validator.Errors()[2], "access_control: rule #2 (domain 'public.example.com'): response_headers: header #3 (X-Forwarded-Email): option 'value' must be present but it's absent")
+	suite.Assert().EqualError(suite.validator.Errors()[3], "access_control: rule #2 (domain 'public.example.com'): response_headers: header #4 (X-Forwarded-Groups): option 'value' is not a valid template: template: X-Forwarded-Groups:1: function \"nope\" not defined")
+	suite.Assert().EqualError(suite.validator.Errors()[4], "access_control: rule #2 (domain 'public.example.com'): response_headers: option 'name' must be unique but the name 'remote-name' is duplicated")
+}
+
+func (suite *AccessControl) TestShouldErrorOnInvalidRulesSchedule() {
+	domains := []string{"public.example.com"}
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains: domains,
+			Policy:  "deny",
+			Schedule: schema.AccessControlRuleSchedule{
+				TimeZone: "Europe/Berlin",
+				Windows: []schema.AccessControlRuleScheduleWindow{
+					{Days: []string{"Monday", "friday"}, Start: "18:00", End: "08:00", NotBefore: "2024-01-01 00:00"},
+				},
+			},
+		},
+		{
+			Domains: domains,
+			Policy:  "deny",
+			Schedule: schema.AccessControlRuleSchedule{
+				TimeZone: "Not/AZone",
+				Windows: []schema.AccessControlRuleScheduleWindow{
+					{Days: []string{"funday"}, Start: "8:00", End: "25:00"},
+				},
+			},
+		},
+		{
+			Domains: domains,
+			Policy:  "deny",
+			Schedule: schema.AccessControlRuleSchedule{
+				TimeZone: "UTC",
+			},
+		},
+		{
+			Domains: domains,
+			Policy:  "deny",
+			Schedule: schema.AccessControlRuleSchedule{
+				Windows: []schema.AccessControlRuleScheduleWindow{
+					{Start: "10:00", End: "10:00"},
+					{Start: "24:00"},
+					{End: "00:00"},
+					{NotBefore: "2024-02-01 00:00", NotAfter: "2024-01-01 00:00"},
+					{NotBefore: "2024-02-01", NotAfter: "tomorrow"},
+				},
+			},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 11)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #2 (domain 'public.example.com'): schedule: option 'time_zone' is invalid: unknown time zone Not/AZone")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "access_control: rule #2 (domain 'public.example.com'): schedule: window #1: option 'days' is invalid: 'funday' is not a known day of the week")
+	suite.Assert().EqualError(suite.validator.Errors()[2], "access_control: rule #2 (domain 'public.example.com'): schedule: window #1: option 'start' is invalid: '8:00' is not in the HH:MM format")
+	suite.Assert().EqualError(suite.validator.Errors()[3], "access_control: rule #2 (domain 'public.example.com'): schedule: window #1: option 'end' is invalid: '25:00' is not a time between 00:00 and 24:00")
+	suite.Assert().EqualError(suite.validator.Errors()[4], "access_control: rule #3 (domain 'public.example.com'): schedule: option 'windows' must be present when the option 'time_zone' is configured but it's absent")
+	suite.Assert().EqualError(suite.validator.Errors()[5], "access_control: rule #4 (domain 'public.example.com'): schedule: window #1: option 'start' must not be equal to the option 'end' but both are configured as '10:00'")
+	suite.Assert().EqualError(suite.validator.Errors()[6], "access_control: rule #4 (domain 'public.example.com'): schedule: window #2: option 'start' must be before '24:00' but it's configured as '24:00'")
+	suite.Assert().EqualError(suite.validator.Errors()[7], "access_control: rule #4 (domain 'public.example.com'): schedule: window #3: option 'end' must be after '00:00' when the option 'start' is absent but it's configured as '00:00'")
+	suite.Assert().EqualError(suite.validator.Errors()[8], "access_control: rule #4 (domain 'public.example.com'): schedule: window #4: option 'not_before' must be before the option 'not_after' but it's configured as '2024-02-01 00:00' and 'not_after' is configured as '2024-01-01 00:00'")
+	suite.Assert().EqualError(suite.validator.Errors()[9], "access_control: rule #4 (domain 'public.example.com'): schedule: window #5: option 'not_before' is invalid: '2024-02-01' is not in the YYYY-MM-DD HH:MM format")
+	suite.Assert().EqualError(suite.validator.Errors()[10], "access_control: rule #4 (domain 'public.example.com'): schedule: window #5: option 'not_after' is invalid: 'tomorrow' is not in the YYYY-MM-DD HH:MM format")
+}
+
 func TestAccessControl(t *testing.T) {
 	suite.Run(t, new(AccessControl))
 }
@@ -3,6 +3,7 @@ package validator
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/go-crypt/crypt/algorithm/argon2"
@@ -17,7 +18,7 @@ import (
 
 // ValidateAuthenticationBackend validates and updates the authentication backend configuration.
 func ValidateAuthenticationBackend(config *schema.AuthenticationBackend, validator *schema.StructValidator) {
-	if config.LDAP == nil && config.File == nil {
+	if config.LDAP == nil && config.File == nil && config.SQL == nil {
 		validator.Push(errors.New(errFmtAuthBackendNotConfigured))
 	}
 
@@ -38,7 +39,7 @@ func ValidateAuthenticationBackend(config *schema.AuthenticationBackend, validat
 		}
 	}
 
-	if config.LDAP != nil && config.File != nil {
+	if countAuthenticationBackends(config) > 1 {
 		validator.Push(errors.New(errFmtAuthBackendMultipleConfigured))
 	}
 
@@ -46,11 +47,31 @@ func ValidateAuthenticationBackend(config *schema.AuthenticationBackend, validat
 		validateFileAuthenticationBackend(config.File, validator)
 	}
 
+	if config.SQL != nil {
+		ValidatePasswordConfiguration(&config.SQL.Password, validator)
+	}
+
 	if config.LDAP != nil {
 		validateLDAPAuthenticationBackend(config, validator)
 	}
 }
 
+func countAuthenticationBackends(config *schema.AuthenticationBackend) (n int) {
+	if config.File != nil {
+		n++
+	}
+
+	if config.LDAP != nil {
+		n++
+	}
+
+	if config.SQL != nil {
+		n++
+	}
+
+	return n
+}
+
 // validateFileAuthenticationBackend validates and updates the file authentication backend configuration.
 func validateFileAuthenticationBackend(config *schema.AuthenticationBackendFile, validator *schema.StructValidator) {
 	if config.Path == "" {
@@ -433,6 +454,14 @@ func setDefaultImplementationLDAPAuthenticationBackendProfileAttributes(config *
 	if ldapImplementationShouldSetStr(config.Attributes.GroupName, implementation.Attributes.GroupName) {
 		config.Attributes.GroupName = implementation.Attributes.GroupName
 	}
+
+	if ldapImplementationShouldSetStr(config.Attributes.UserAccountControl, implementation.Attributes.UserAccountControl) {
+		config.Attributes.UserAccountControl = implementation.Attributes.UserAccountControl
+	}
+
+	if ldapImplementationShouldSetStr(config.Attributes.AccountLockedTime, implementation.Attributes.AccountLockedTime) {
+		config.Attributes.AccountLockedTime = implementation.Attributes.AccountLockedTime
+	}
 }
 
 func validateLDAPAuthenticationAddress(config *schema.AuthenticationBackendLDAP, validator *schema.StructValidator) (hostname string) {
@@ -500,6 +529,23 @@ func validateLDAPRequiredParameters(config *schema.AuthenticationBackend, valida
 	}
 
 	validateLDAPGroupFilter(config, validator)
+	validateLDAPAttributesExtra(config, validator)
+}
+
+func validateLDAPAttributesExtra(config *schema.AuthenticationBackend, validator *schema.StructValidator) {
+	names := make([]string, 0, len(config.LDAP.Attributes.Extra))
+
+	for name := range config.LDAP.Attributes.Extra {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if config.LDAP.Attributes.Extra[name].Name == "" {
+			validator.Push(fmt.Errorf(errFmtLDAPAuthBackendExtraAttributeEmpty, name))
+		}
+	}
 }
 
 func validateLDAPGroupFilter(config *schema.AuthenticationBackend, validator *schema.StructValidator) {
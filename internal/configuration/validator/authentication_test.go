@@ -25,7 +25,7 @@ func TestShouldRaiseErrorWhenBothBackendsProvided(t *testing.T) {
 	ValidateAuthenticationBackend(&backendConfig, validator)
 
 	require.Len(t, validator.Errors(), 7)
-	assert.EqualError(t, validator.Errors()[0], "authentication_backend: please ensure only one of the 'file' or 'ldap' backend is configured")
+	assert.EqualError(t, validator.Errors()[0], "authentication_backend: please ensure only one of the 'file', 'ldap', or 'sql' backend is configured")
 	assert.EqualError(t, validator.Errors()[1], "authentication_backend: ldap: option 'address' is required")
 	assert.EqualError(t, validator.Errors()[2], "authentication_backend: ldap: option 'user' is required")
 	assert.EqualError(t, validator.Errors()[3], "authentication_backend: ldap: option 'password' is required")
@@ -41,7 +41,39 @@ func TestShouldRaiseErrorWhenNoBackendProvided(t *testing.T) {
 	ValidateAuthenticationBackend(&backendConfig, validator)
 
 	require.Len(t, validator.Errors(), 1)
-	assert.EqualError(t, validator.Errors()[0], "authentication_backend: you must ensure either the 'file' or 'ldap' authentication backend is configured")
+	assert.EqualError(t, validator.Errors()[0], "authentication_backend: you must ensure either the 'file', 'ldap', or 'sql' authentication backend is configured")
+}
+
+func TestShouldRaiseErrorWhenSQLAndFileBackendsProvided(t *testing.T) {
+	validator := schema.NewStructValidator()
+	backendConfig := schema.AuthenticationBackend{}
+
+	backendConfig.SQL = &schema.AuthenticationBackendSQL{}
+	backendConfig.File = &schema.AuthenticationBackendFile{
+		Path: "/tmp",
+	}
+
+	ValidateAuthenticationBackend(&backendConfig, validator)
+
+	require.Len(t, validator.Errors(), 1)
+	assert.EqualError(t, validator.Errors()[0], "authentication_backend: please ensure only one of the 'file', 'ldap', or 'sql' backend is configured")
+}
+
+func TestShouldSetDefaultPasswordConfigurationSQLBackend(t *testing.T) {
+	validator := schema.NewStructValidator()
+	backendConfig := schema.AuthenticationBackend{
+		SQL: &schema.AuthenticationBackendSQL{},
+	}
+
+	ValidateAuthenticationBackend(&backendConfig, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	assert.Len(t, validator.Errors(), 0)
+
+	assert.Equal(t, schema.DefaultPasswordConfig.Algorithm, backendConfig.SQL.Password.Algorithm)
+	assert.Equal(t, schema.DefaultPasswordConfig.Argon2.Variant, backendConfig.SQL.Password.Argon2.Variant)
+	assert.Equal(t, schema.DefaultPasswordConfig.Argon2.Iterations, backendConfig.SQL.Password.Argon2.Iterations)
+	assert.Equal(t, schema.RefreshIntervalDefault, backendConfig.RefreshInterval.Value())
 }
 
 type FileBasedAuthenticationBackend struct {
@@ -755,6 +787,20 @@ func (suite *LDAPAuthenticationBackendSuite) TestShouldRaiseOnEmptyUsersFilter()
 	suite.EqualError(suite.validator.Errors()[0], "authentication_backend: ldap: option 'users_filter' is required")
 }
 
+func (suite *LDAPAuthenticationBackendSuite) TestShouldRaiseOnEmptyExtraAttribute() {
+	suite.config.LDAP.Attributes.Extra = map[string]schema.AuthenticationBackendLDAPAttributesAttribute{
+		"department":  {},
+		"employee_id": {Name: "employeeNumber"},
+	}
+
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 1)
+
+	suite.EqualError(suite.validator.Errors()[0], "authentication_backend: ldap: attributes: extra: attribute 'department': option 'name' is required")
+}
+
 func (suite *LDAPAuthenticationBackendSuite) TestShouldNotRaiseOnEmptyUsernameAttribute() {
 	suite.config.LDAP.Attributes.Username = ""
 
@@ -1256,6 +1302,8 @@ func (suite *LDAPImplementationSuite) EqualImplementationDefaults(expected schem
 	suite.Equal(expected.Attributes.Mail, suite.config.LDAP.Attributes.Mail)
 	suite.Equal(expected.Attributes.MemberOf, suite.config.LDAP.Attributes.MemberOf)
 	suite.Equal(expected.Attributes.GroupName, suite.config.LDAP.Attributes.GroupName)
+	suite.Equal(expected.Attributes.UserAccountControl, suite.config.LDAP.Attributes.UserAccountControl)
+	suite.Equal(expected.Attributes.AccountLockedTime, suite.config.LDAP.Attributes.AccountLockedTime)
 }
 
 func (suite *LDAPImplementationSuite) NotEqualImplementationDefaults(expected schema.AuthenticationBackendLDAP) {
@@ -55,6 +55,8 @@ func ValidateConfiguration(config *schema.Configuration, validator *schema.Struc
 
 	ValidateRegulation(config, validator)
 
+	ValidateKnownDevices(config, validator)
+
 	ValidateServer(config, validator)
 
 	ValidateTelemetry(config, validator)
@@ -72,6 +74,8 @@ func ValidateConfiguration(config *schema.Configuration, validator *schema.Struc
 	ValidatePasswordPolicy(&config.PasswordPolicy, validator)
 
 	ValidatePrivacyPolicy(&config.PrivacyPolicy, validator)
+
+	ValidateSecurityEvents(&config.SecurityEvents, validator)
 }
 
 func validateDefault2FAMethod(config *schema.Configuration, validator *schema.StructValidator) {
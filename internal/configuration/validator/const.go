@@ -22,6 +22,7 @@ const (
 	policyOneFactor = "one_factor"
 	policyTwoFactor = "two_factor"
 	policyDeny      = "deny"
+	policyWebhook   = "webhook"
 )
 
 const (
@@ -62,8 +63,8 @@ const (
 
 // Notifier Error constants.
 const (
-	errFmtNotifierMultipleConfigured = "notifier: please ensure only one of the 'smtp' or 'filesystem' notifier is configured"
-	errFmtNotifierNotConfigured      = "notifier: you must ensure either the 'smtp' or 'filesystem' notifier " +
+	errFmtNotifierMultipleConfigured = "notifier: please ensure only one of the 'smtp', 'filesystem', or 'webhook' notifier is configured"
+	errFmtNotifierNotConfigured      = "notifier: you must ensure either the 'smtp', 'filesystem', or 'webhook' notifier " +
 		"is configured"
 	errFmtNotifierTemplatePathNotExist            = "notifier: option 'template_path' refers to location '%s' which does not exist"
 	errFmtNotifierTemplatePathUnknownError        = "notifier: option 'template_path' refers to location '%s' which couldn't be opened: %w"
@@ -73,6 +74,15 @@ const (
 	errFmtNotifierSMTPAddress                     = "notifier: smtp: option 'address' with value '%s' is invalid: %w"
 	errFmtNotifierSMTPAddressLegacyAndModern      = "notifier: smtp: option 'host' and 'port' can't be configured at the same time as 'address'"
 
+	errFmtNotifierWebhookNotConfigured    = "notifier: webhook: option '%s' is required"
+	errFmtNotifierWebhookURLScheme        = "notifier: webhook: option 'url' must have the scheme %s but it's configured as '%s'"
+	errFmtNotifierWebhookNegative         = "notifier: webhook: option '%s' must not be negative but it's configured as '%s'"
+	errFmtNotifierWebhookRetries          = "notifier: webhook: option 'retries' must be -1 or greater but it's configured as '%d'"
+	errFmtNotifierWebhookPayload          = "notifier: webhook: option 'payload' is not a valid template: %w"
+	errFmtNotifierWebhookTLSConfigInvalid = "notifier: webhook: tls: %w"
+	errFmtNotifierWebhookNoSecret         = "notifier: webhook: option 'secret' is not configured: the payload is not signed " +
+		"which means the receiver can't verify the notifications were sent by Authelia"
+
 	errFmtNotifierStartTlsDisabled = "notifier: smtp: option 'disable_starttls' is enabled: " +
 		"opportunistic STARTTLS is explicitly disabled which means all emails will be sent insecurely over plaintext " +
 		"and this setting is only necessary for non-compliant SMTP servers which advertise they support STARTTLS " +
@@ -85,9 +95,9 @@ const (
 
 // Authentication Backend Error constants.
 const (
-	errFmtAuthBackendNotConfigured = "authentication_backend: you must ensure either the 'file' or 'ldap' " +
+	errFmtAuthBackendNotConfigured = "authentication_backend: you must ensure either the 'file', 'ldap', or 'sql' " +
 		"authentication backend is configured"
-	errFmtAuthBackendMultipleConfigured = "authentication_backend: please ensure only one of the 'file' or 'ldap' " +
+	errFmtAuthBackendMultipleConfigured = "authentication_backend: please ensure only one of the 'file', 'ldap', or 'sql' " +
 		"backend is configured"
 	errFmtAuthBackendRefreshInterval = "authentication_backend: option 'refresh_interval' is configured to '%s' but " +
 		"it must be either in duration common syntax or one of 'disable', or 'always': %w"
@@ -126,6 +136,7 @@ const (
 		"must contain one of the %s placeholders when using a group_search_mode of '%s' but they're absent"
 	errFmtLDAPAuthBackendFilterMissingAttribute = "authentication_backend: ldap: attributes: option '%s' " +
 		"must be provided when using the %s placeholder but it's absent"
+	errFmtLDAPAuthBackendExtraAttributeEmpty = "authentication_backend: ldap: attributes: extra: attribute '%s': option 'name' is required"
 )
 
 // TOTP Error constants.
@@ -190,6 +201,12 @@ const (
 	errFmtOIDCProviderInvalidValue                       = "identity_providers: oidc: option " +
 		errFmtMustBeOneOf
 
+	errFmtOIDCDynamicClientRegistrationMissingOption = "identity_providers: oidc: dynamic_client_registration: option '%s' is required when registration is enabled"
+	errFmtOIDCDynamicClientRegistrationInvalidValue  = "identity_providers: oidc: dynamic_client_registration: option " +
+		errFmtMustBeOneOf
+	errFmtOIDCDynamicClientRegistrationInvalidScope = "identity_providers: oidc: dynamic_client_registration: option 'scopes' must only have the values %s but the values %s are present"
+	errFmtOIDCDynamicClientRegistrationNegative     = "identity_providers: oidc: dynamic_client_registration: option 'client_lifespan' must be 0 or more but it's configured as '%s'"
+
 	errFmtOIDCCORSInvalidOrigin                    = "identity_providers: oidc: cors: option 'allowed_origins' contains an invalid value '%s' as it has a %s: origins must only be scheme, hostname, and an optional port"
 	errFmtOIDCCORSInvalidOriginWildcard            = "identity_providers: oidc: cors: option 'allowed_origins' contains the wildcard origin '*' with more than one origin but the wildcard origin must be defined by itself"
 	errFmtOIDCCORSInvalidOriginWildcardWithClients = "identity_providers: oidc: cors: option 'allowed_origins' contains the wildcard origin '*' cannot be specified with option 'allowed_origins_from_client_redirect_uris' enabled"
@@ -202,6 +219,12 @@ const (
 	errFmtOIDCPolicyInvalidDefaultPolicy = "identity_providers: oidc: authorization_policies: policy '%s': option 'default_policy' must be one of %s but it's configured as '%s'"
 	errFmtOIDCPolicyRuleInvalidPolicy    = "identity_providers: oidc: authorization_policies: policy '%s': rules: rule #%d: option 'policy' must be one of %s but it's configured as '%s'"
 
+	errFmtOIDCScopeInvalidNameStandard = "identity_providers: oidc: scopes: scope '%s' must not be one of %s as these are standard scopes"
+	errFmtOIDCScopeInvalidName         = "identity_providers: oidc: scopes: scope '%s' must only contain printable ASCII characters excluding spaces, double quotes, and backslashes"
+	errFmtOIDCScopeMissingClaims       = "identity_providers: oidc: scopes: scope '%s': option 'claims' must have at least one claim"
+	errFmtOIDCScopeClaimReserved       = "identity_providers: oidc: scopes: scope '%s': claims: claim '%s' must not be one of %s as these are reserved claims"
+	errFmtOIDCScopeClaimSource         = "identity_providers: oidc: scopes: scope '%s': claims: claim '%s': exactly one of the options 'attribute' or 'value' must be configured"
+
 	errFmtOIDCClientsDuplicateID = "identity_providers: oidc: clients: option 'id' must be unique for every client but one or more clients share the following 'id' values %s"
 	errFmtOIDCClientsWithEmptyID = "identity_providers: oidc: clients: option 'id' is required but was absent on the clients in positions %s"
 	errFmtOIDCClientsDeprecated  = "identity_providers: oidc: clients: warnings for clients above indicate deprecated functionality and it's strongly suggested these issues are checked and fixed if they're legitimate issues or reported if they are not as in a future version these warnings will become errors"
@@ -230,6 +253,17 @@ const (
 	errFmtOIDCClientRedirectURIAbsolute = errFmtOIDCClientRedirectURIHas +
 		"an invalid value: redirect uri '%s' must have a scheme but it's absent"
 
+	errFmtOIDCClientPostLogoutRedirectURIHas          = errFmtOIDCClientOption + "'post_logout_redirect_uris' has "
+	errFmtOIDCClientPostLogoutRedirectURICantBeParsed = errFmtOIDCClientPostLogoutRedirectURIHas +
+		"an invalid value: post logout redirect uri '%s' could not be parsed: %v"
+	errFmtOIDCClientPostLogoutRedirectURIInvalid = errFmtOIDCClientPostLogoutRedirectURIHas +
+		"an invalid value: post logout redirect uri '%s' must be an absolute uri without a fragment"
+
+	errFmtOIDCClientLogoutURIInvalid = errFmtOIDCClientOption + "'%s' must be an absolute uri with the 'http' or " +
+		"'https' scheme and without a fragment but it's configured as '%s'"
+	errFmtOIDCClientLogoutSessionRequiredWithoutURI = errFmtOIDCClientOption + "'%s' is only used when the option " +
+		"'%s' is configured but it's absent"
+
 	errFmtOIDCClientRequestURIHas          = errFmtOIDCClientOption + "'request_uris' has "
 	errFmtOIDCClientRequestURICantBeParsed = errFmtOIDCClientRequestURIHas +
 		"an invalid value: request uri '%s' could not be parsed: %v"
@@ -240,6 +274,10 @@ const (
 
 	errFmtOIDCClientInvalidConsentMode = "identity_providers: oidc: clients: client '%s': consent: option 'mode' must be one of " +
 		"%s but it's configured as '%s'"
+	errFmtOIDCClientTokenExchangeInvalidEntries = "identity_providers: oidc: clients: client '%s': token_exchange: option '%s' " +
+		"must only have values which are also present in the client option '%s' but the values %s are not present"
+	errFmtOIDCClientTokenExchangeWithoutGrantType = "identity_providers: oidc: clients: client '%s': token_exchange: " +
+		"options are only used when the client option 'grant_types' has the value '%s' but it's absent"
 	errFmtOIDCClientInvalidEntries = errFmtOIDCClientOption + errFmtMustOnlyHaveValues +
 		"but the values %s are present"
 	errFmtOIDCClientUnknownScopeEntries = errFmtOIDCClientOption + "'%s' only expects the values " +
@@ -318,6 +356,11 @@ const (
 		"network '%s' is not a valid IP or CIDR notation"
 	errFmtAccessControlWarnNoRulesDefaultPolicy = "access_control: no rules have been specified so the " +
 		"'default_policy' of '%s' is going to be applied to all requests"
+	errFmtAccessControlAuditMode            = "access_control: audit: option 'mode' must be one of %s but it's configured as '%s'"
+	errFmtAccessControlAuditFileNoPath      = "access_control: audit: option 'path' must be present when the option 'mode' is 'file' but it's absent"
+	errFmtAccessControlAuditRetention       = "access_control: audit: option 'retention' must not be negative but it's configured as '%s'"
+	errFmtAccessControlAuditRetentionNoMode = "access_control: audit: option 'retention' must only be configured when the option 'mode' is configured but it's absent"
+
 	errFmtAccessControlRuleNoDomains                    = "access_control: rule %s: option 'domain' or 'domain_regex' must be present but are both absent"
 	errFmtAccessControlRuleNoPolicy                     = "access_control: rule %s: option 'policy' must be present but it's absent"
 	errFmtAccessControlRuleInvalidPolicy                = "access_control: rule %s: option 'policy' must be one of %s but it's configured as '%s'"
@@ -342,6 +385,42 @@ const (
 		"invalid: %w"
 	errFmtAccessControlRuleQueryInvalidValueType = "access_control: rule %s: query: option 'value' is " +
 		"invalid: expected type was string but got %T"
+	errFmtAccessControlRuleMaxAuthenticationAgeNegative  = "access_control: rule %s: option 'max_authentication_age' must not be negative but it's configured as '%s'"
+	errFmtAccessControlRuleMaxAuthenticationAgeNoEffect  = "access_control: rule %s: option 'max_authentication_age' has no effect when the option 'policy' is '%s'"
+	errFmtAccessControlRuleSecondFactorMethodsPolicy     = "access_control: rule %s: option 'second_factor_methods' must only be configured when the option 'policy' is 'two_factor' but it's configured as '%s'"
+	errFmtAccessControlRuleSecondFactorMethodsDisabled   = "access_control: rule %s: option 'second_factor_methods' must contain at least one enabled method of %s but it's configured as %s"
+	errFmtAccessControlRuleHeadersInvalid                = "access_control: rule %s: headers: option 'operator' must be one of %s but it's configured as '%s'"
+	errFmtAccessControlRuleHeadersInvalidNoValue         = "access_control: rule %s: headers: option '%s' is required but it's absent"
+	errFmtAccessControlRuleHeadersInvalidName            = "access_control: rule %s: headers: option 'name' must only contain valid header name characters but it's configured as '%s'"
+	errFmtAccessControlRuleHeadersInvalidNoValueOperator = "access_control: rule %s: headers: option '%s' must be present when the option 'operator' is '%s' but it's absent"
+	errFmtAccessControlRuleHeadersInvalidValue           = "access_control: rule %s: headers: option '%s' must not be present when the option 'operator' is '%s' but it's present"
+	errFmtAccessControlRuleHeadersInvalidValueParse      = "access_control: rule %s: headers: option '%s' is " +
+		"invalid: %w"
+	errFmtAccessControlRuleHeadersInvalidValueType = "access_control: rule %s: headers: option 'value' is " +
+		"invalid: expected type was string but got %T"
+	errFmtAccessControlRuleScheduleTimeZoneInvalid = "access_control: rule %s: schedule: option 'time_zone' is " +
+		"invalid: %w"
+	errFmtAccessControlRuleScheduleNoWindows     = "access_control: rule %s: schedule: option 'windows' must be present when the option 'time_zone' is configured but it's absent"
+	errFmtAccessControlRuleScheduleWindowInvalid = "access_control: rule %s: schedule: window #%d: option '%s' is " +
+		"invalid: %w"
+	errFmtAccessControlRuleScheduleWindowStartEnd          = "access_control: rule %s: schedule: window #%d: option 'start' must not be equal to the option 'end' but both are configured as '%s'"
+	errFmtAccessControlRuleScheduleWindowStartMidnight     = "access_control: rule %s: schedule: window #%d: option 'start' must be before '24:00' but it's configured as '%s'"
+	errFmtAccessControlRuleScheduleWindowEndMidnight       = "access_control: rule %s: schedule: window #%d: option 'end' must be after '00:00' when the option 'start' is absent but it's configured as '%s'"
+	errFmtAccessControlRuleScheduleWindowNotBeforeNotAfter = "access_control: rule %s: schedule: window #%d: option 'not_before' must be before the option 'not_after' but it's configured as '%s' and " +
+		"'not_after' is configured as '%s'"
+
+	errFmtAccessControlRuleWebhookPolicy        = "access_control: rule %s: option 'webhook' must only be configured when the option 'policy' is 'webhook' but it's configured as '%s'"
+	errFmtAccessControlRuleWebhookNoURL         = "access_control: rule %s: webhook: option 'url' must be present when the option 'policy' is 'webhook' but it's absent"
+	errFmtAccessControlRuleWebhookURLScheme     = "access_control: rule %s: webhook: option 'url' must have the scheme %s but it's configured as '%s'"
+	errFmtAccessControlRuleWebhookNegative      = "access_control: rule %s: webhook: option '%s' must not be negative but it's configured as '%s'"
+	errFmtAccessControlRuleWebhookFailurePolicy = "access_control: rule %s: webhook: option 'failure_policy' must be one of %s but it's configured as '%s'"
+
+	errFmtAccessControlRuleResponseHeadersNoName    = "access_control: rule %s: response_headers: header #%d: option 'name' must be present but it's absent"
+	errFmtAccessControlRuleResponseHeadersName      = "access_control: rule %s: response_headers: header #%d: option 'name' must only contain valid header name characters but it's configured as '%s'"
+	errFmtAccessControlRuleResponseHeadersNoValue   = "access_control: rule %s: response_headers: header #%d (%s): option 'value' must be present but it's absent"
+	errFmtAccessControlRuleResponseHeadersValue     = "access_control: rule %s: response_headers: header #%d (%s): option 'value' is not a valid template: %w"
+	errFmtAccessControlRuleResponseHeadersDuplicate = "access_control: rule %s: response_headers: option 'name' must be unique but the name '%s' is duplicated"
+	errFmtAccessControlRuleResponseHeadersNoEffect  = "access_control: rule %s: option 'response_headers' has no effect when the option 'policy' is '%s'"
 )
 
 // Theme Error constants.
@@ -388,7 +467,18 @@ const (
 
 // Regulation Error Consts.
 const (
-	errFmtRegulationFindTimeGreaterThanBanTime = "regulation: option 'find_time' must be less than or equal to option 'ban_time'"
+	errFmtRegulationFindTimeGreaterThanBanTime        = "regulation: option 'find_time' must be less than or equal to option 'ban_time'"
+	errFmtRegulationSectionFindTimeGreaterThanBanTime = "regulation: %s: option 'find_time' must be less than or equal to option 'ban_time'"
+	errFmtRegulationSubnetPrefix                      = "regulation: subnet: option '%s' must be between 1 and %d but it's configured as '%d'"
+	errFmtRegulationAllowedNetworks                   = "regulation: option 'allowed_networks' must only contain valid IP addresses or networks in CIDR notation but it contains '%s'"
+)
+
+// Known Devices Error Consts.
+const (
+	errFmtKnownDevicesCookieName        = "known_devices: option 'cookie_name' must only contain valid cookie name characters but it's configured as '%s'"
+	errFmtKnownDevicesCookieNameSession = "known_devices: option 'cookie_name' must not be the same as the name of a session cookie but it's configured as '%s'"
+	errFmtKnownDevicesLifespan          = "known_devices: option 'lifespan' must not be negative but it's configured as '%s'"
+	errFmtKnownDevicesSubnet            = "known_devices: option '%s' must be between 1 and %d but it's configured as '%d'"
 )
 
 // Server Error constants.
@@ -410,6 +500,11 @@ const (
 	errFmtServerEndpointsAuthzStrategyDuplicate         = "server: endpoints: authz: %s: authn_strategies: duplicate strategy name detected with name '%s'"
 	errFmtServerEndpointsAuthzPrefixDuplicate           = "server: endpoints: authz: %s: endpoint starts with the same prefix as the '%s' endpoint with the '%s' implementation which accepts prefixes as part of its implementation"
 	errFmtServerEndpointsAuthzInvalidName               = "server: endpoints: authz: %s: contains invalid characters"
+	errFmtServerEndpointsAuthzHeaderNoName              = "server: endpoints: authz: %s: headers: header #%d: option 'name' must be configured"
+	errFmtServerEndpointsAuthzHeaderName                = "server: endpoints: authz: %s: headers: header #%d (%s): option 'name' must only contain valid header name characters"
+	errFmtServerEndpointsAuthzHeaderNoValue             = "server: endpoints: authz: %s: headers: header #%d (%s): option 'value' must be configured"
+	errFmtServerEndpointsAuthzHeaderValue               = "server: endpoints: authz: %s: headers: header #%d (%s): option 'value' is not a valid template: %w"
+	errFmtServerEndpointsAuthzHeaderDuplicate           = "server: endpoints: authz: %s: headers: duplicate header name detected with name '%s'"
 
 	errFmtServerEndpointsAuthzLegacyInvalidImplementation = "server: endpoints: authz: %s: option 'implementation' is invalid: the endpoint with the name 'legacy' must use the 'Legacy' implementation"
 )
@@ -425,6 +520,16 @@ const (
 	errFmtPrivacyPolicyURLNotHTTPS    = "privacy_policy: option 'policy_url' must have the 'https' scheme but it's configured as '%s'"
 )
 
+// Security Events Error constants.
+const (
+	errFmtSecurityEventsEvents           = "security_events: %s: option 'events' must only have the values %s but it has the value '%s'"
+	errFmtSecurityEventsNegative         = "security_events: %s: option '%s' must not be negative but it's configured as '%s'"
+	errFmtSecurityEventsWebhookURLScheme = "security_events: webhook: option 'url' must have the scheme %s but it's configured as '%s'"
+	errFmtSecurityEventsWebhookTLSConfig = "security_events: webhook: tls: %w"
+	errFmtSecurityEventsWebhookNoSecret  = "security_events: webhook: option 'secret' is not configured: the payload is not signed " +
+		"and the endpoint can't verify it was sent by Authelia"
+)
+
 const (
 	errFmtDuoMissingOption = "duo_api: option '%s' is required when duo is enabled but it's absent"
 )
@@ -512,42 +617,63 @@ var (
 )
 
 var (
-	validACLHTTPMethodVerbs = append(validRFC7231HTTPMethodVerbs, validRFC4918HTTPMethodVerbs...)
-	validACLRulePolicies    = []string{policyBypass, policyOneFactor, policyTwoFactor, policyDeny}
-	validACLRuleOperators   = []string{operatorPresent, operatorAbsent, operatorEqual, operatorNotEqual, operatorPattern, operatorNotPattern}
+	validACLHTTPMethodVerbs     = append(validRFC7231HTTPMethodVerbs, validRFC4918HTTPMethodVerbs...)
+	validACLRulePolicies        = []string{policyBypass, policyOneFactor, policyTwoFactor, policyDeny}
+	validACLRuleWebhookPolicies = []string{policyBypass, policyOneFactor, policyTwoFactor, policyDeny, policyWebhook}
+	validACLRuleOperators       = []string{operatorPresent, operatorAbsent, operatorEqual, operatorNotEqual, operatorPattern, operatorNotPattern}
 )
 
 var validDefault2FAMethods = []string{"totp", "webauthn", "mobile_push"}
 
+var validACLRuleSecondFactorMethods = []string{"totp", "webauthn", "webauthn_hardware", "mobile_push"}
+
+var validACLAuditModes = []string{"storage", "file"}
+
+var validSecurityEventTypes = []string{
+	schema.SecurityEventAuthenticationSuccess,
+	schema.SecurityEventAuthenticationFailure,
+	schema.SecurityEventRegulationBan,
+	schema.SecurityEventPasswordReset,
+	schema.SecurityEventCredentialRegistered,
+	schema.SecurityEventCredentialRemoved,
+	schema.SecurityEventSessionElevated,
+	schema.SecurityEventOpenIDConnectConsent,
+}
+
 const (
-	attrOIDCKey                   = "key"
-	attrOIDCKeyID                 = "key_id"
-	attrOIDCKeyUse                = "use"
-	attrOIDCAlgorithm             = "algorithm"
-	attrOIDCScopes                = "scopes"
-	attrOIDCResponseTypes         = "response_types"
-	attrOIDCResponseModes         = "response_modes"
-	attrOIDCGrantTypes            = "grant_types"
-	attrOIDCRedirectURIs          = "redirect_uris"
-	attrOIDCRequestURIs           = "request_uris"
-	attrOIDCTokenAuthMethod       = "token_endpoint_auth_method"
-	attrOIDCDiscoSigAlg           = "discovery_signed_response_alg"
-	attrOIDCDiscoSigKID           = "discovery_signed_response_key_id"
-	attrOIDCUsrSigAlg             = "userinfo_signed_response_alg"
-	attrOIDCUsrSigKID             = "userinfo_signed_response_key_id"
-	attrOIDCIntrospectionSigAlg   = "introspection_signed_response_alg"
-	attrOIDCIntrospectionSigKID   = "introspection_signed_response_key_id"
-	attrOIDCAuthorizationSigAlg   = "authorization_signed_response_alg"
-	attrOIDCAuthorizationSigKID   = "authorization_signed_response_key_id"
-	attrOIDCIDTokenSigAlg         = "id_token_signed_response_alg"
-	attrOIDCIDTokenSigKID         = "id_token_signed_response_key_id"
-	attrOIDCAccessTokenSigAlg     = "access_token_signed_response_alg"
-	attrOIDCAccessTokenSigKID     = "access_token_signed_response_key_id"
-	attrOIDCPKCEChallengeMethod   = "pkce_challenge_method"
-	attrOIDCRequestedAudienceMode = "requested_audience_mode"
-	attrSessionAutheliaURL        = "authelia_url"
-	attrSessionDomain             = "domain"
-	attrDefaultRedirectionURL     = "default_redirection_url"
+	attrOIDCKey                               = "key"
+	attrOIDCKeyID                             = "key_id"
+	attrOIDCKeyUse                            = "use"
+	attrOIDCAlgorithm                         = "algorithm"
+	attrOIDCScopes                            = "scopes"
+	attrOIDCResponseTypes                     = "response_types"
+	attrOIDCResponseModes                     = "response_modes"
+	attrOIDCGrantTypes                        = "grant_types"
+	attrOIDCRedirectURIs                      = "redirect_uris"
+	attrOIDCRequestURIs                       = "request_uris"
+	attrOIDCPostLogoutRedirectURIs            = "post_logout_redirect_uris"
+	attrOIDCFrontChannelLogoutURI             = "frontchannel_logout_uri"
+	attrOIDCFrontChannelLogoutSessionRequired = "frontchannel_logout_session_required"
+	attrOIDCBackChannelLogoutURI              = "backchannel_logout_uri"
+	attrOIDCBackChannelLogoutSessionRequired  = "backchannel_logout_session_required"
+	attrOIDCTokenAuthMethod                   = "token_endpoint_auth_method"
+	attrOIDCDiscoSigAlg                       = "discovery_signed_response_alg"
+	attrOIDCDiscoSigKID                       = "discovery_signed_response_key_id"
+	attrOIDCUsrSigAlg                         = "userinfo_signed_response_alg"
+	attrOIDCUsrSigKID                         = "userinfo_signed_response_key_id"
+	attrOIDCIntrospectionSigAlg               = "introspection_signed_response_alg"
+	attrOIDCIntrospectionSigKID               = "introspection_signed_response_key_id"
+	attrOIDCAuthorizationSigAlg               = "authorization_signed_response_alg"
+	attrOIDCAuthorizationSigKID               = "authorization_signed_response_key_id"
+	attrOIDCIDTokenSigAlg                     = "id_token_signed_response_alg"
+	attrOIDCIDTokenSigKID                     = "id_token_signed_response_key_id"
+	attrOIDCAccessTokenSigAlg                 = "access_token_signed_response_alg"
+	attrOIDCAccessTokenSigKID                 = "access_token_signed_response_key_id"
+	attrOIDCPKCEChallengeMethod               = "pkce_challenge_method"
+	attrOIDCRequestedAudienceMode             = "requested_audience_mode"
+	attrSessionAutheliaURL                    = "authelia_url"
+	attrSessionDomain                         = "domain"
+	attrDefaultRedirectionURL                 = "default_redirection_url"
 )
 
 var (
@@ -557,14 +683,16 @@ var (
 var (
 	validOIDCCORSEndpoints = []string{oidc.EndpointAuthorization, oidc.EndpointPushedAuthorizationRequest, oidc.EndpointToken, oidc.EndpointIntrospection, oidc.EndpointRevocation, oidc.EndpointUserinfo}
 
-	validOIDCClientScopes                    = []string{oidc.ScopeOpenID, oidc.ScopeEmail, oidc.ScopeProfile, oidc.ScopeGroups, oidc.ScopeOfflineAccess, oidc.ScopeOffline, oidc.ScopeAutheliaBearerAuthz}
+	validOIDCClientScopes                    = []string{oidc.ScopeOpenID, oidc.ScopeEmail, oidc.ScopePhone, oidc.ScopeProfile, oidc.ScopeGroups, oidc.ScopeOfflineAccess, oidc.ScopeOffline, oidc.ScopeAutheliaBearerAuthz}
+	validOIDCDynamicClientRegistrationScopes = []string{oidc.ScopeOpenID, oidc.ScopeEmail, oidc.ScopePhone, oidc.ScopeProfile, oidc.ScopeGroups, oidc.ScopeOfflineAccess, oidc.ScopeOffline}
+	validOIDCReservedClaims                  = []string{oidc.ClaimJWTID, oidc.ClaimSessionID, oidc.ClaimAccessTokenHash, oidc.ClaimCodeHash, oidc.ClaimStateHash, oidc.ClaimIssuedAt, oidc.ClaimNotBefore, oidc.ClaimRequestedAt, oidc.ClaimExpirationTime, oidc.ClaimAuthenticationTime, oidc.ClaimIssuer, oidc.ClaimSubject, oidc.ClaimNonce, oidc.ClaimAudience, oidc.ClaimAuthorizedParty, oidc.ClaimAuthenticationContextClassReference, oidc.ClaimAuthenticationMethodsReference, oidc.ClaimClientIdentifier, oidc.ClaimScope, oidc.ClaimScopeNonStandard, oidc.ClaimExtra, oidc.ClaimActor, oidc.ClaimEvents}
 	validOIDCClientConsentModes              = []string{auto, oidc.ClientConsentModeImplicit.String(), oidc.ClientConsentModeExplicit.String(), oidc.ClientConsentModePreConfigured.String()}
 	validOIDCClientResponseModes             = []string{oidc.ResponseModeFormPost, oidc.ResponseModeQuery, oidc.ResponseModeFragment, oidc.ResponseModeJWT, oidc.ResponseModeFormPostJWT, oidc.ResponseModeQueryJWT, oidc.ResponseModeFragmentJWT}
 	validOIDCClientResponseTypes             = []string{oidc.ResponseTypeAuthorizationCodeFlow, oidc.ResponseTypeImplicitFlowIDToken, oidc.ResponseTypeImplicitFlowToken, oidc.ResponseTypeImplicitFlowBoth, oidc.ResponseTypeHybridFlowIDToken, oidc.ResponseTypeHybridFlowToken, oidc.ResponseTypeHybridFlowBoth}
 	validOIDCClientResponseTypesImplicitFlow = []string{oidc.ResponseTypeImplicitFlowIDToken, oidc.ResponseTypeImplicitFlowToken, oidc.ResponseTypeImplicitFlowBoth}
 	validOIDCClientResponseTypesHybridFlow   = []string{oidc.ResponseTypeHybridFlowIDToken, oidc.ResponseTypeHybridFlowToken, oidc.ResponseTypeHybridFlowBoth}
 	validOIDCClientResponseTypesRefreshToken = []string{oidc.ResponseTypeAuthorizationCodeFlow, oidc.ResponseTypeHybridFlowIDToken, oidc.ResponseTypeHybridFlowToken, oidc.ResponseTypeHybridFlowBoth}
-	validOIDCClientGrantTypes                = []string{oidc.GrantTypeAuthorizationCode, oidc.GrantTypeImplicit, oidc.GrantTypeClientCredentials, oidc.GrantTypeRefreshToken}
+	validOIDCClientGrantTypes                = []string{oidc.GrantTypeAuthorizationCode, oidc.GrantTypeImplicit, oidc.GrantTypeClientCredentials, oidc.GrantTypeRefreshToken, oidc.GrantTypeDeviceCode, oidc.GrantTypeTokenExchange}
 
 	validOIDCClientTokenEndpointAuthMethods                = []string{oidc.ClientAuthMethodNone, oidc.ClientAuthMethodClientSecretPost, oidc.ClientAuthMethodClientSecretBasic, oidc.ClientAuthMethodPrivateKeyJWT, oidc.ClientAuthMethodClientSecretJWT}
 	validOIDCClientTokenEndpointAuthMethodsConfidential    = []string{oidc.ClientAuthMethodClientSecretPost, oidc.ClientAuthMethodClientSecretBasic, oidc.ClientAuthMethodPrivateKeyJWT}
@@ -583,6 +711,7 @@ var (
 	reAuthzEndpointName = regexp.MustCompile(`^[a-zA-Z](([a-zA-Z0-9/._-]*)([a-zA-Z]))?$`)
 	reOpenIDConnectKID  = regexp.MustCompile(`^([a-zA-Z0-9](([a-zA-Z0-9._~-]*)([a-zA-Z0-9]))?)?$`)
 	reRFC3986Unreserved = regexp.MustCompile(`^[a-zA-Z0-9._~-]+$`)
+	reHTTPHeaderName    = regexp.MustCompile("^[a-zA-Z0-9!#$%&'*+.^_`|~-]+$")
 )
 
 var replacedKeys = map[string]string{
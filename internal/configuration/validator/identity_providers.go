@@ -51,14 +51,158 @@ func validateOIDC(ctx *ValidateCtx, config *schema.IdentityProvidersOpenIDConnec
 	}
 
 	validateOIDCOptionsCORS(config, validator)
+	validateOIDCScopes(config, validator)
+	validateOIDCDynamicClientRegistration(config, validator)
 
-	if len(config.Clients) == 0 {
-		validator.Push(errors.New(errFmtOIDCProviderNoClientsConfigured))
-	} else {
+	switch {
+	case len(config.Clients) != 0:
 		validateOIDCClients(ctx, config, validator)
+	case !config.DynamicClientRegistration.Enable:
+		validator.Push(errors.New(errFmtOIDCProviderNoClientsConfigured))
 	}
 }
 
+// ValidateIdentityProvidersOpenIDConnectClient validates a single client which was not provided by the configuration
+// such as a client being registered via the OAuth 2.0 Dynamic Client Registration endpoint. The client has the
+// defaults applied in the same way as a client provided by the configuration.
+func ValidateIdentityProvidersOpenIDConnectClient(ctx *ValidateCtx, config *schema.IdentityProvidersOpenIDConnect, client *schema.IdentityProvidersOpenIDConnectClient) (errs []error) {
+	validator := schema.NewStructValidator()
+
+	c := *config
+	c.Clients = []schema.IdentityProvidersOpenIDConnectClient{*client}
+
+	ctx.cacheSectorIdentifierURIs = map[string][]string{}
+
+	validateOIDCClient(ctx, 0, &c, validator, func() {})
+
+	ctx.cacheSectorIdentifierURIs = nil
+
+	*client = c.Clients[0]
+
+	return validator.Errors()
+}
+
+func validateOIDCDynamicClientRegistration(config *schema.IdentityProvidersOpenIDConnect, validator *schema.StructValidator) {
+	if !config.DynamicClientRegistration.Enable {
+		return
+	}
+
+	if config.DynamicClientRegistration.InitialAccessToken == "" {
+		validator.Push(fmt.Errorf(errFmtOIDCDynamicClientRegistrationMissingOption, "initial_access_token"))
+	}
+
+	if config.DynamicClientRegistration.ClientLifespan < 0 {
+		validator.Push(fmt.Errorf(errFmtOIDCDynamicClientRegistrationNegative, config.DynamicClientRegistration.ClientLifespan))
+	}
+
+	switch {
+	case config.DynamicClientRegistration.AuthorizationPolicy == "":
+		config.DynamicClientRegistration.AuthorizationPolicy = schema.DefaultOpenIDConnectConfiguration.DynamicClientRegistration.AuthorizationPolicy
+	case utils.IsStringInSlice(config.DynamicClientRegistration.AuthorizationPolicy, config.Discovery.AuthorizationPolicies):
+		break
+	default:
+		validator.Push(fmt.Errorf(errFmtOIDCDynamicClientRegistrationInvalidValue, "authorization_policy", utils.StringJoinOr(config.Discovery.AuthorizationPolicies), config.DynamicClientRegistration.AuthorizationPolicy))
+	}
+
+	if len(config.DynamicClientRegistration.Scopes) == 0 {
+		config.DynamicClientRegistration.Scopes = schema.DefaultOpenIDConnectConfiguration.DynamicClientRegistration.Scopes
+
+		return
+	}
+
+	var invalid []string
+
+	valid := append(append([]string{}, validOIDCDynamicClientRegistrationScopes...), config.Discovery.Scopes...)
+
+	for _, scope := range config.DynamicClientRegistration.Scopes {
+		if !utils.IsStringInSlice(scope, valid) {
+			invalid = append(invalid, scope)
+		}
+	}
+
+	if len(invalid) != 0 {
+		validator.Push(fmt.Errorf(errFmtOIDCDynamicClientRegistrationInvalidScope, utils.StringJoinOr(valid), utils.StringJoinAnd(invalid)))
+	}
+}
+
+func validateOIDCScopes(config *schema.IdentityProvidersOpenIDConnect, validator *schema.StructValidator) {
+	config.Discovery.Scopes, config.Discovery.Claims = nil, nil
+
+	names := make([]string, 0, len(config.Scopes))
+
+	for name := range config.Scopes {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		switch {
+		case utils.IsStringInSlice(name, validOIDCClientScopes):
+			validator.Push(fmt.Errorf(errFmtOIDCScopeInvalidNameStandard, name, utils.StringJoinOr(validOIDCClientScopes)))
+
+			continue
+		case !isOIDCScopeTokenValid(name):
+			validator.Push(fmt.Errorf(errFmtOIDCScopeInvalidName, name))
+
+			continue
+		}
+
+		scope := config.Scopes[name]
+
+		if len(scope.Claims) == 0 {
+			validator.Push(fmt.Errorf(errFmtOIDCScopeMissingClaims, name))
+
+			continue
+		}
+
+		claims := make([]string, 0, len(scope.Claims))
+
+		for claim := range scope.Claims {
+			claims = append(claims, claim)
+		}
+
+		sort.Strings(claims)
+
+		for _, claim := range claims {
+			if utils.IsStringInSlice(claim, validOIDCReservedClaims) {
+				validator.Push(fmt.Errorf(errFmtOIDCScopeClaimReserved, name, claim, utils.StringJoinOr(validOIDCReservedClaims)))
+
+				continue
+			}
+
+			if source := scope.Claims[claim]; (source.Attribute == "") == (source.Value == nil) {
+				validator.Push(fmt.Errorf(errFmtOIDCScopeClaimSource, name, claim))
+
+				continue
+			}
+
+			if !utils.IsStringInSlice(claim, config.Discovery.Claims) {
+				config.Discovery.Claims = append(config.Discovery.Claims, claim)
+			}
+		}
+
+		config.Discovery.Scopes = append(config.Discovery.Scopes, name)
+	}
+
+	sort.Strings(config.Discovery.Claims)
+}
+
+// isOIDCScopeTokenValid returns true if the scope is a valid scope-token as defined by RFC6749 Section 3.3.
+func isOIDCScopeTokenValid(scope string) bool {
+	if scope == "" {
+		return false
+	}
+
+	for _, r := range scope {
+		if r < 0x21 || r > 0x7E || r == '"' || r == '\\' {
+			return false
+		}
+	}
+
+	return true
+}
+
 func validateOIDCAuthorizationPolicies(config *schema.IdentityProvidersOpenIDConnect, validator *schema.StructValidator) {
 	config.Discovery.AuthorizationPolicies = []string{policyOneFactor, policyTwoFactor}
 
@@ -478,8 +622,10 @@ func validateOIDCClient(ctx *ValidateCtx, c int, config *schema.IdentityProvider
 	validateOIDCClientResponseTypes(c, config, validator, setDefaults, errDeprecatedFunc)
 	validateOIDCClientResponseModes(c, config, validator, setDefaults, errDeprecatedFunc)
 	validateOIDCClientGrantTypes(c, config, validator, setDefaults, errDeprecatedFunc)
+	validateOIDCClientTokenExchange(c, config, validator)
 	validateOIDCClientRedirectURIs(c, config, validator, errDeprecatedFunc)
 	validateOIDCClientRequestURIs(c, config, validator)
+	validateOIDCClientLogout(c, config, validator)
 
 	validateOIDDClientSigningAlgs(c, config, validator)
 
@@ -677,7 +823,9 @@ func validateOIDCClientScopes(c int, config *schema.IdentityProvidersOpenIDConne
 		config.Clients[c].Scopes = schema.DefaultOpenIDConnectClientConfiguration.Scopes
 	}
 
-	invalid, duplicates := validateList(config.Clients[c].Scopes, validOIDCClientScopes, true)
+	valid := append(append([]string{}, validOIDCClientScopes...), config.Discovery.Scopes...)
+
+	invalid, duplicates := validateList(config.Clients[c].Scopes, valid, true)
 
 	if len(duplicates) != 0 {
 		errDeprecatedFunc()
@@ -688,11 +836,12 @@ func validateOIDCClientScopes(c int, config *schema.IdentityProvidersOpenIDConne
 	if ccg {
 		validateOIDCClientScopesClientCredentialsGrant(c, config, validator)
 	} else if len(invalid) != 0 {
-		validator.PushWarning(fmt.Errorf(errFmtOIDCClientUnknownScopeEntries, config.Clients[c].ID, attrOIDCScopes, utils.StringJoinOr(validOIDCClientScopes), utils.StringJoinAnd(invalid)))
+		validator.PushWarning(fmt.Errorf(errFmtOIDCClientUnknownScopeEntries, config.Clients[c].ID, attrOIDCScopes, utils.StringJoinOr(valid), utils.StringJoinAnd(invalid)))
 	}
 
 	if utils.IsStringSliceContainsAny([]string{oidc.ScopeOfflineAccess, oidc.ScopeOffline}, config.Clients[c].Scopes) &&
-		!utils.IsStringSliceContainsAny(validOIDCClientResponseTypesRefreshToken, config.Clients[c].ResponseTypes) {
+		!utils.IsStringSliceContainsAny(validOIDCClientResponseTypesRefreshToken, config.Clients[c].ResponseTypes) &&
+		!utils.IsStringInSlice(oidc.GrantTypeDeviceCode, config.Clients[c].GrantTypes) {
 		errDeprecatedFunc()
 
 		validator.PushWarning(fmt.Errorf(errFmtOIDCClientInvalidRefreshTokenOptionWithoutCodeResponseType,
@@ -905,9 +1054,9 @@ func validateOIDCClientGrantTypesCheckRelated(c int, config *schema.IdentityProv
 
 				validator.PushWarning(fmt.Errorf(errFmtOIDCClientInvalidGrantTypeMatch, config.Clients[c].ID, grantType, "for either the implicit or hybrid flow", utils.StringJoinOr(append(append([]string{}, validOIDCClientResponseTypesImplicitFlow...), validOIDCClientResponseTypesHybridFlow...)), utils.StringJoinAnd(config.Clients[c].ResponseTypes)))
 			}
-		case oidc.GrantTypeClientCredentials:
+		case oidc.GrantTypeClientCredentials, oidc.GrantTypeTokenExchange:
 			if config.Clients[c].Public {
-				validator.Push(fmt.Errorf(errFmtOIDCClientInvalidGrantTypePublic, config.Clients[c].ID, oidc.GrantTypeClientCredentials))
+				validator.Push(fmt.Errorf(errFmtOIDCClientInvalidGrantTypePublic, config.Clients[c].ID, grantType))
 			}
 		case oidc.GrantTypeRefreshToken:
 			if !utils.IsStringSliceContainsAny([]string{oidc.ScopeOfflineAccess, oidc.ScopeOffline}, config.Clients[c].Scopes) {
@@ -916,7 +1065,8 @@ func validateOIDCClientGrantTypesCheckRelated(c int, config *schema.IdentityProv
 				validator.PushWarning(fmt.Errorf(errFmtOIDCClientInvalidGrantTypeRefresh, config.Clients[c].ID))
 			}
 
-			if !utils.IsStringSliceContainsAny(validOIDCClientResponseTypesRefreshToken, config.Clients[c].ResponseTypes) {
+			if !utils.IsStringSliceContainsAny(validOIDCClientResponseTypesRefreshToken, config.Clients[c].ResponseTypes) &&
+				!utils.IsStringInSlice(oidc.GrantTypeDeviceCode, config.Clients[c].GrantTypes) {
 				errDeprecatedFunc()
 
 				validator.PushWarning(fmt.Errorf(errFmtOIDCClientInvalidRefreshTokenOptionWithoutCodeResponseType,
@@ -929,6 +1079,26 @@ func validateOIDCClientGrantTypesCheckRelated(c int, config *schema.IdentityProv
 	}
 }
 
+func validateOIDCClientTokenExchange(c int, config *schema.IdentityProvidersOpenIDConnect, validator *schema.StructValidator) {
+	exchange := config.Clients[c].TokenExchange
+
+	if !utils.IsStringInSlice(oidc.GrantTypeTokenExchange, config.Clients[c].GrantTypes) {
+		if len(exchange.SubjectClients) != 0 || len(exchange.Audience) != 0 || len(exchange.Scopes) != 0 {
+			validator.PushWarning(fmt.Errorf(errFmtOIDCClientTokenExchangeWithoutGrantType, config.Clients[c].ID, oidc.GrantTypeTokenExchange))
+		}
+
+		return
+	}
+
+	if invalid, _ := utils.StringSlicesDelta(config.Clients[c].Audience, exchange.Audience); len(invalid) != 0 {
+		validator.Push(fmt.Errorf(errFmtOIDCClientTokenExchangeInvalidEntries, config.Clients[c].ID, "audience", "audience", utils.StringJoinAnd(invalid)))
+	}
+
+	if invalid, _ := utils.StringSlicesDelta(config.Clients[c].Scopes, exchange.Scopes); len(invalid) != 0 {
+		validator.Push(fmt.Errorf(errFmtOIDCClientTokenExchangeInvalidEntries, config.Clients[c].ID, attrOIDCScopes, attrOIDCScopes, utils.StringJoinAnd(invalid)))
+	}
+}
+
 func validateOIDCClientRedirectURIs(c int, config *schema.IdentityProvidersOpenIDConnect, validator *schema.StructValidator, errDeprecatedFunc func()) {
 	var (
 		parsedRedirectURI *url.URL
@@ -991,6 +1161,52 @@ func validateOIDCClientRequestURIs(c int, config *schema.IdentityProvidersOpenID
 	}
 }
 
+func validateOIDCClientLogout(c int, config *schema.IdentityProvidersOpenIDConnect, validator *schema.StructValidator) {
+	var (
+		parsedPostLogoutRedirectURI *url.URL
+		err                         error
+	)
+
+	for _, postLogoutRedirectURI := range config.Clients[c].PostLogoutRedirectURIs {
+		if parsedPostLogoutRedirectURI, err = url.Parse(postLogoutRedirectURI); err != nil {
+			validator.Push(fmt.Errorf(errFmtOIDCClientPostLogoutRedirectURICantBeParsed, config.Clients[c].ID, postLogoutRedirectURI, err))
+			continue
+		}
+
+		if !parsedPostLogoutRedirectURI.IsAbs() || parsedPostLogoutRedirectURI.Fragment != "" {
+			validator.Push(fmt.Errorf(errFmtOIDCClientPostLogoutRedirectURIInvalid, config.Clients[c].ID, postLogoutRedirectURI))
+		}
+	}
+
+	_, duplicates := validateList(config.Clients[c].PostLogoutRedirectURIs, nil, true)
+
+	if len(duplicates) != 0 {
+		validator.Push(fmt.Errorf(errFmtOIDCClientInvalidEntryDuplicates, config.Clients[c].ID, attrOIDCPostLogoutRedirectURIs, utils.StringJoinAnd(duplicates)))
+	}
+
+	config.Clients[c].FrontChannelLogoutURI = validateOIDCClientLogoutURI(c, config, attrOIDCFrontChannelLogoutURI, attrOIDCFrontChannelLogoutSessionRequired,
+		config.Clients[c].FrontChannelLogoutURI, config.Clients[c].FrontChannelLogoutSessionRequired, validator)
+	config.Clients[c].BackChannelLogoutURI = validateOIDCClientLogoutURI(c, config, attrOIDCBackChannelLogoutURI, attrOIDCBackChannelLogoutSessionRequired,
+		config.Clients[c].BackChannelLogoutURI, config.Clients[c].BackChannelLogoutSessionRequired, validator)
+}
+
+func validateOIDCClientLogoutURI(c int, config *schema.IdentityProvidersOpenIDConnect, attrURI, attrSessionRequired string, uri *url.URL, sessionRequired bool, validator *schema.StructValidator) *url.URL {
+	if uri != nil && uri.String() == "" {
+		uri = nil
+	}
+
+	switch {
+	case uri == nil:
+		if sessionRequired {
+			validator.PushWarning(fmt.Errorf(errFmtOIDCClientLogoutSessionRequiredWithoutURI, config.Clients[c].ID, attrSessionRequired, attrURI))
+		}
+	case !uri.IsAbs(), uri.Scheme != schemeHTTPS && uri.Scheme != schemeHTTP, uri.Fragment != "":
+		validator.Push(fmt.Errorf(errFmtOIDCClientLogoutURIInvalid, config.Clients[c].ID, attrURI, uri.String()))
+	}
+
+	return uri
+}
+
 //nolint:gocyclo
 func validateOIDCClientTokenEndpointAuth(c int, config *schema.IdentityProvidersOpenIDConnect, validator *schema.StructValidator) {
 	implicit := len(config.Clients[c].ResponseTypes) != 0 && utils.IsStringSliceContainsAll(config.Clients[c].ResponseTypes, validOIDCClientResponseTypesImplicitFlow)
@@ -658,7 +658,7 @@ func TestShouldRaiseErrorWhenOIDCClientConfiguredWithBadGrantTypes(t *testing.T)
 	ValidateIdentityProviders(NewValidateCtx(), config, validator)
 
 	require.Len(t, validator.Errors(), 1)
-	assert.EqualError(t, validator.Errors()[0], "identity_providers: oidc: clients: client 'good_id': option 'grant_types' must only have the values 'authorization_code', 'implicit', 'client_credentials', or 'refresh_token' but the values 'bad_grant_type' are present")
+	assert.EqualError(t, validator.Errors()[0], "identity_providers: oidc: clients: client 'good_id': option 'grant_types' must only have the values 'authorization_code', 'implicit', 'client_credentials', 'refresh_token', 'urn:ietf:params:oauth:grant-type:device_code', or 'urn:ietf:params:oauth:grant-type:token-exchange' but the values 'bad_grant_type' are present")
 }
 
 func TestShouldNotErrorOnCertificateValid(t *testing.T) {
@@ -1203,7 +1203,7 @@ func TestValidateOIDCClients(t *testing.T) {
 				[]string{oidc.GrantTypeAuthorizationCode},
 			},
 			[]string{
-				"identity_providers: oidc: clients: client 'test': option 'scopes' only expects the values 'openid', 'email', 'profile', 'groups', 'offline_access', 'offline', or 'authelia.bearer.authz' but the unknown values 'group' are present and should generally only be used if a particular client requires a scope outside of our standard scopes",
+				"identity_providers: oidc: clients: client 'test': option 'scopes' only expects the values 'openid', 'email', 'phone', 'profile', 'groups', 'offline_access', 'offline', or 'authelia.bearer.authz' but the unknown values 'group' are present and should generally only be used if a particular client requires a scope outside of our standard scopes",
 			},
 			nil,
 		},
@@ -1352,7 +1352,7 @@ func TestValidateOIDCClients(t *testing.T) {
 			},
 			nil,
 			[]string{
-				"identity_providers: oidc: clients: client 'test': option 'grant_types' must only have the values 'authorization_code', 'implicit', 'client_credentials', or 'refresh_token' but the values 'invalid' are present",
+				"identity_providers: oidc: clients: client 'test': option 'grant_types' must only have the values 'authorization_code', 'implicit', 'client_credentials', 'refresh_token', 'urn:ietf:params:oauth:grant-type:device_code', or 'urn:ietf:params:oauth:grant-type:token-exchange' but the values 'invalid' are present",
 			},
 		},
 		{
@@ -1470,6 +1470,219 @@ func TestValidateOIDCClients(t *testing.T) {
 			},
 			nil,
 		},
+		{
+			"ShouldAllowGrantTypeDeviceCodeWithRefreshTokenWithoutAuthorizationCodeFlow",
+			func(have *schema.IdentityProvidersOpenIDConnect) {
+				have.Clients[0].Public = true
+				have.Clients[0].Secret = nil
+				have.Clients[0].TokenEndpointAuthMethod = oidc.ClientAuthMethodNone
+			},
+			nil,
+			tcv{
+				[]string{oidc.ScopeOpenID, oidc.ScopeOfflineAccess},
+				[]string{oidc.ResponseTypeImplicitFlowIDToken},
+				nil,
+				[]string{oidc.GrantTypeDeviceCode, oidc.GrantTypeRefreshToken},
+			},
+			tcv{
+				[]string{oidc.ScopeOpenID, oidc.ScopeOfflineAccess},
+				[]string{oidc.ResponseTypeImplicitFlowIDToken},
+				[]string{oidc.ResponseModeFormPost, oidc.ResponseModeFragment},
+				[]string{oidc.GrantTypeDeviceCode, oidc.GrantTypeRefreshToken},
+			},
+			nil,
+			nil,
+		},
+		{
+			"ShouldRaiseErrorOnGrantTypeTokenExchangeForPublicClient",
+			func(have *schema.IdentityProvidersOpenIDConnect) {
+				have.Clients[0].Public = true
+				have.Clients[0].Secret = nil
+			},
+			nil,
+			tcv{
+				nil,
+				nil,
+				nil,
+				[]string{oidc.GrantTypeTokenExchange},
+			},
+			tcv{
+				[]string{oidc.ScopeOpenID, oidc.ScopeGroups, oidc.ScopeProfile, oidc.ScopeEmail},
+				[]string{oidc.ResponseTypeAuthorizationCodeFlow},
+				[]string{oidc.ResponseModeFormPost, oidc.ResponseModeQuery},
+				[]string{oidc.GrantTypeTokenExchange},
+			},
+			nil,
+			[]string{
+				"identity_providers: oidc: clients: client 'test': option 'grant_types' should only have the 'urn:ietf:params:oauth:grant-type:token-exchange' value if it is of the confidential client type but it's of the public client type",
+			},
+		},
+		{
+			"ShouldNotRaiseErrorOnValidTokenExchangePolicy",
+			func(have *schema.IdentityProvidersOpenIDConnect) {
+				have.Clients[0].Audience = []string{"https://api.example.com", "https://other.example.com"}
+				have.Clients[0].TokenExchange = schema.IdentityProvidersOpenIDConnectClientTokenExchange{
+					SubjectClients: []string{"frontend"},
+					Audience:       []string{"https://api.example.com"},
+					Scopes:         []string{oidc.ScopeGroups},
+				}
+			},
+			nil,
+			tcv{
+				nil,
+				nil,
+				nil,
+				[]string{oidc.GrantTypeTokenExchange},
+			},
+			tcv{
+				[]string{oidc.ScopeOpenID, oidc.ScopeGroups, oidc.ScopeProfile, oidc.ScopeEmail},
+				[]string{oidc.ResponseTypeAuthorizationCodeFlow},
+				[]string{oidc.ResponseModeFormPost, oidc.ResponseModeQuery},
+				[]string{oidc.GrantTypeTokenExchange},
+			},
+			nil,
+			nil,
+		},
+		{
+			"ShouldRaiseErrorOnTokenExchangePolicyOutsideClient",
+			func(have *schema.IdentityProvidersOpenIDConnect) {
+				have.Clients[0].Audience = []string{"https://api.example.com"}
+				have.Clients[0].TokenExchange = schema.IdentityProvidersOpenIDConnectClientTokenExchange{
+					Audience: []string{"https://api.example.com", "https://other.example.com"},
+					Scopes:   []string{oidc.ScopeGroups, oidc.ScopeOfflineAccess},
+				}
+			},
+			nil,
+			tcv{
+				nil,
+				nil,
+				nil,
+				[]string{oidc.GrantTypeTokenExchange},
+			},
+			tcv{
+				[]string{oidc.ScopeOpenID, oidc.ScopeGroups, oidc.ScopeProfile, oidc.ScopeEmail},
+				[]string{oidc.ResponseTypeAuthorizationCodeFlow},
+				[]string{oidc.ResponseModeFormPost, oidc.ResponseModeQuery},
+				[]string{oidc.GrantTypeTokenExchange},
+			},
+			nil,
+			[]string{
+				"identity_providers: oidc: clients: client 'test': token_exchange: option 'audience' must only have values which are also present in the client option 'audience' but the values 'https://other.example.com' are not present",
+				"identity_providers: oidc: clients: client 'test': token_exchange: option 'scopes' must only have values which are also present in the client option 'scopes' but the values 'offline_access' are not present",
+			},
+		},
+		{
+			"ShouldWarnOnTokenExchangePolicyWithoutGrantType",
+			func(have *schema.IdentityProvidersOpenIDConnect) {
+				have.Clients[0].TokenExchange = schema.IdentityProvidersOpenIDConnectClientTokenExchange{
+					Scopes: []string{oidc.ScopeGroups},
+				}
+			},
+			nil,
+			tcv{
+				nil,
+				nil,
+				nil,
+				nil,
+			},
+			tcv{
+				[]string{oidc.ScopeOpenID, oidc.ScopeGroups, oidc.ScopeProfile, oidc.ScopeEmail},
+				[]string{oidc.ResponseTypeAuthorizationCodeFlow},
+				[]string{oidc.ResponseModeFormPost, oidc.ResponseModeQuery},
+				[]string{oidc.GrantTypeAuthorizationCode},
+			},
+			[]string{
+				"identity_providers: oidc: clients: client 'test': token_exchange: options are only used when the client option 'grant_types' has the value 'urn:ietf:params:oauth:grant-type:token-exchange' but it's absent",
+			},
+			nil,
+		},
+		{
+			"ShouldNotRaiseErrorOnValidLogoutOptions",
+			func(have *schema.IdentityProvidersOpenIDConnect) {
+				have.Clients[0].PostLogoutRedirectURIs = []string{"https://app.example.com/logged-out"}
+				have.Clients[0].FrontChannelLogoutURI = MustParseURL("https://app.example.com/frontchannel-logout")
+				have.Clients[0].FrontChannelLogoutSessionRequired = true
+				have.Clients[0].BackChannelLogoutURI = MustParseURL("https://app.example.com/backchannel-logout")
+				have.Clients[0].BackChannelLogoutSessionRequired = true
+			},
+			func(t *testing.T, have *schema.IdentityProvidersOpenIDConnect) {
+				assert.Equal(t, "https://app.example.com/frontchannel-logout", have.Clients[0].FrontChannelLogoutURI.String())
+				assert.Equal(t, "https://app.example.com/backchannel-logout", have.Clients[0].BackChannelLogoutURI.String())
+			},
+			tcv{
+				nil,
+				nil,
+				nil,
+				nil,
+			},
+			tcv{
+				[]string{oidc.ScopeOpenID, oidc.ScopeGroups, oidc.ScopeProfile, oidc.ScopeEmail},
+				[]string{oidc.ResponseTypeAuthorizationCodeFlow},
+				[]string{oidc.ResponseModeFormPost, oidc.ResponseModeQuery},
+				[]string{oidc.GrantTypeAuthorizationCode},
+			},
+			nil,
+			nil,
+		},
+		{
+			"ShouldRaiseErrorOnInvalidLogoutOptions",
+			func(have *schema.IdentityProvidersOpenIDConnect) {
+				have.Clients[0].PostLogoutRedirectURIs = []string{"https://app.example.com/logged-out#fragment", "app.example.com", "http://abc@%two", "app.example.com"}
+				have.Clients[0].FrontChannelLogoutURI = MustParseURL("/frontchannel-logout")
+				have.Clients[0].BackChannelLogoutURI = MustParseURL("urn:example:logout")
+			},
+			nil,
+			tcv{
+				nil,
+				nil,
+				nil,
+				nil,
+			},
+			tcv{
+				[]string{oidc.ScopeOpenID, oidc.ScopeGroups, oidc.ScopeProfile, oidc.ScopeEmail},
+				[]string{oidc.ResponseTypeAuthorizationCodeFlow},
+				[]string{oidc.ResponseModeFormPost, oidc.ResponseModeQuery},
+				[]string{oidc.GrantTypeAuthorizationCode},
+			},
+			nil,
+			[]string{
+				"identity_providers: oidc: clients: client 'test': option 'post_logout_redirect_uris' has an invalid value: post logout redirect uri 'https://app.example.com/logged-out#fragment' must be an absolute uri without a fragment",
+				"identity_providers: oidc: clients: client 'test': option 'post_logout_redirect_uris' has an invalid value: post logout redirect uri 'app.example.com' must be an absolute uri without a fragment",
+				"identity_providers: oidc: clients: client 'test': option 'post_logout_redirect_uris' has an invalid value: post logout redirect uri 'http://abc@%two' could not be parsed: parse \"http://abc@%two\": invalid URL escape \"%tw\"",
+				"identity_providers: oidc: clients: client 'test': option 'post_logout_redirect_uris' has an invalid value: post logout redirect uri 'app.example.com' must be an absolute uri without a fragment",
+				"identity_providers: oidc: clients: client 'test': option 'post_logout_redirect_uris' must have unique values but the values 'app.example.com' are duplicated",
+				"identity_providers: oidc: clients: client 'test': option 'frontchannel_logout_uri' must be an absolute uri with the 'http' or 'https' scheme and without a fragment but it's configured as '/frontchannel-logout'",
+				"identity_providers: oidc: clients: client 'test': option 'backchannel_logout_uri' must be an absolute uri with the 'http' or 'https' scheme and without a fragment but it's configured as 'urn:example:logout'",
+			},
+		},
+		{
+			"ShouldWarnOnLogoutSessionRequiredWithoutURI",
+			func(have *schema.IdentityProvidersOpenIDConnect) {
+				have.Clients[0].FrontChannelLogoutSessionRequired = true
+				have.Clients[0].BackChannelLogoutURI = &url.URL{}
+				have.Clients[0].BackChannelLogoutSessionRequired = true
+			},
+			func(t *testing.T, have *schema.IdentityProvidersOpenIDConnect) {
+				assert.Nil(t, have.Clients[0].BackChannelLogoutURI)
+			},
+			tcv{
+				nil,
+				nil,
+				nil,
+				nil,
+			},
+			tcv{
+				[]string{oidc.ScopeOpenID, oidc.ScopeGroups, oidc.ScopeProfile, oidc.ScopeEmail},
+				[]string{oidc.ResponseTypeAuthorizationCodeFlow},
+				[]string{oidc.ResponseModeFormPost, oidc.ResponseModeQuery},
+				[]string{oidc.GrantTypeAuthorizationCode},
+			},
+			[]string{
+				"identity_providers: oidc: clients: client 'test': option 'frontchannel_logout_session_required' is only used when the option 'frontchannel_logout_uri' is configured but it's absent",
+				"identity_providers: oidc: clients: client 'test': option 'backchannel_logout_session_required' is only used when the option 'backchannel_logout_uri' is configured but it's absent",
+			},
+			nil,
+		},
 		{
 			"ShouldRaiseErrorOnGrantTypeAuthorizationCodeWithoutAuthorizationCodeOrHybridFlow",
 			nil,
@@ -4048,6 +4261,228 @@ func TestValidateOIDCAuthorizationPolicies(t *testing.T) {
 	}
 }
 
+func TestValidateOIDCDynamicClientRegistration(t *testing.T) {
+	testCases := []struct {
+		name    string
+		have    schema.IdentityProvidersOpenIDConnectDynamicClientRegistration
+		expectf func(t *testing.T, actual schema.IdentityProvidersOpenIDConnectDynamicClientRegistration)
+		errors  []string
+	}{
+		{
+			"ShouldSkipDisabled",
+			schema.IdentityProvidersOpenIDConnectDynamicClientRegistration{
+				AuthorizationPolicy: "abc",
+			},
+			nil,
+			nil,
+		},
+		{
+			"ShouldSetDefaults",
+			schema.IdentityProvidersOpenIDConnectDynamicClientRegistration{
+				Enable:             true,
+				InitialAccessToken: "example-token",
+			},
+			func(t *testing.T, actual schema.IdentityProvidersOpenIDConnectDynamicClientRegistration) {
+				assert.Equal(t, "two_factor", actual.AuthorizationPolicy)
+				assert.Equal(t, []string{"openid", "offline_access", "groups", "profile", "email"}, actual.Scopes)
+			},
+			nil,
+		},
+		{
+			"ShouldErrorBadValues",
+			schema.IdentityProvidersOpenIDConnectDynamicClientRegistration{
+				Enable:              true,
+				ClientLifespan:      -time.Minute,
+				AuthorizationPolicy: "abc",
+				Scopes:              []string{"openid", "authelia.bearer.authz", "bad"},
+			},
+			nil,
+			[]string{
+				"identity_providers: oidc: dynamic_client_registration: option 'authorization_policy' must be one of 'one_factor' or 'two_factor' but it's configured as 'abc'",
+				"identity_providers: oidc: dynamic_client_registration: option 'client_lifespan' must be 0 or more but it's configured as '-1m0s'",
+				"identity_providers: oidc: dynamic_client_registration: option 'initial_access_token' is required when registration is enabled",
+				"identity_providers: oidc: dynamic_client_registration: option 'scopes' must only have the values 'openid', 'email', 'phone', 'profile', 'groups', 'offline_access', or 'offline' but the values 'authelia.bearer.authz' and 'bad' are present",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validator := schema.NewStructValidator()
+
+			config := &schema.IdentityProvidersOpenIDConnect{
+				DynamicClientRegistration: tc.have,
+			}
+
+			validateOIDCAuthorizationPolicies(config, validator)
+			validateOIDCDynamicClientRegistration(config, validator)
+
+			errs := validator.Errors()
+			sort.Sort(utils.ErrSliceSortAlphabetical(errs))
+
+			require.Len(t, errs, len(tc.errors))
+
+			for i, err := range tc.errors {
+				t.Run(fmt.Sprintf("Error%d", i+1), func(t *testing.T) {
+					assert.EqualError(t, errs[i], err)
+				})
+			}
+
+			if tc.expectf != nil {
+				tc.expectf(t, config.DynamicClientRegistration)
+			}
+		})
+	}
+}
+
+func TestValidateOIDCScopes(t *testing.T) {
+	testCases := []struct {
+		name           string
+		have           map[string]schema.IdentityProvidersOpenIDConnectScope
+		expectedScopes []string
+		expectedClaims []string
+		errors         []string
+	}{
+		{
+			"ShouldAllowEmpty",
+			nil,
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"ShouldAllowValid",
+			map[string]schema.IdentityProvidersOpenIDConnectScope{
+				"hr": {
+					Claims: map[string]schema.IdentityProvidersOpenIDConnectScopeClaim{
+						"department":  {Attribute: "department"},
+						"employee_id": {Attribute: "employee_id"},
+					},
+				},
+				"org": {
+					Claims: map[string]schema.IdentityProvidersOpenIDConnectScopeClaim{
+						"department":         {Value: "Engineering"},
+						"preferred_username": {Attribute: "email"},
+					},
+				},
+			},
+			[]string{"hr", "org"},
+			[]string{"department", "employee_id", "preferred_username"},
+			nil,
+		},
+		{
+			"ShouldErrorBadValues",
+			map[string]schema.IdentityProvidersOpenIDConnectScope{
+				"profile":   {Claims: map[string]schema.IdentityProvidersOpenIDConnectScopeClaim{"example": {Value: "abc"}}},
+				"bad scope": {Claims: map[string]schema.IdentityProvidersOpenIDConnectScopeClaim{"example": {Value: "abc"}}},
+				"empty":     {},
+				"hr": {
+					Claims: map[string]schema.IdentityProvidersOpenIDConnectScopeClaim{
+						"sub":        {Attribute: "username"},
+						"department": {},
+						"both":       {Attribute: "department", Value: "abc"},
+						"valid":      {Value: "abc"},
+					},
+				},
+			},
+			[]string{"hr"},
+			[]string{"valid"},
+			[]string{
+				"identity_providers: oidc: scopes: scope 'bad scope' must only contain printable ASCII characters excluding spaces, double quotes, and backslashes",
+				"identity_providers: oidc: scopes: scope 'empty': option 'claims' must have at least one claim",
+				"identity_providers: oidc: scopes: scope 'hr': claims: claim 'both': exactly one of the options 'attribute' or 'value' must be configured",
+				"identity_providers: oidc: scopes: scope 'hr': claims: claim 'department': exactly one of the options 'attribute' or 'value' must be configured",
+				"identity_providers: oidc: scopes: scope 'hr': claims: claim 'sub' must not be one of 'jti', 'sid', 'at_hash', 'c_hash', 's_hash', 'iat', 'nbf', 'rat', 'exp', 'auth_time', 'iss', 'sub', 'nonce', 'aud', 'azp', 'acr', 'amr', 'client_id', 'scope', 'scp', 'ext', 'act', or 'events' as these are reserved claims",
+				"identity_providers: oidc: scopes: scope 'profile' must not be one of 'openid', 'email', 'phone', 'profile', 'groups', 'offline_access', 'offline', or 'authelia.bearer.authz' as these are standard scopes",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validator := schema.NewStructValidator()
+
+			config := &schema.IdentityProvidersOpenIDConnect{
+				Scopes: tc.have,
+			}
+
+			validateOIDCScopes(config, validator)
+
+			errs := validator.Errors()
+			sort.Sort(utils.ErrSliceSortAlphabetical(errs))
+
+			require.Len(t, errs, len(tc.errors))
+
+			for i, err := range tc.errors {
+				t.Run(fmt.Sprintf("Error%d", i+1), func(t *testing.T) {
+					assert.EqualError(t, errs[i], err)
+				})
+			}
+
+			assert.Equal(t, tc.expectedScopes, config.Discovery.Scopes)
+			assert.Equal(t, tc.expectedClaims, config.Discovery.Claims)
+		})
+	}
+}
+
+func TestValidateOIDCClientScopesCustom(t *testing.T) {
+	validator := schema.NewStructValidator()
+
+	config := &schema.IdentityProvidersOpenIDConnect{
+		Scopes: map[string]schema.IdentityProvidersOpenIDConnectScope{
+			"hr": {Claims: map[string]schema.IdentityProvidersOpenIDConnectScopeClaim{"department": {Attribute: "department"}}},
+		},
+		Clients: []schema.IdentityProvidersOpenIDConnectClient{
+			{
+				ID:     "abc",
+				Scopes: []string{oidc.ScopeOpenID, "hr", "bad"},
+			},
+		},
+		DynamicClientRegistration: schema.IdentityProvidersOpenIDConnectDynamicClientRegistration{
+			Enable:             true,
+			InitialAccessToken: "example-token",
+			Scopes:             []string{oidc.ScopeOpenID, "hr"},
+		},
+	}
+
+	validateOIDCAuthorizationPolicies(config, validator)
+	validateOIDCScopes(config, validator)
+	validateOIDCDynamicClientRegistration(config, validator)
+	validateOIDCClientScopes(0, config, validator, false, func() {})
+
+	assert.Len(t, validator.Errors(), 0)
+	require.Len(t, validator.Warnings(), 1)
+	assert.EqualError(t, validator.Warnings()[0], "identity_providers: oidc: clients: client 'abc': option 'scopes' only expects the values 'openid', 'email', 'phone', 'profile', 'groups', 'offline_access', 'offline', 'authelia.bearer.authz', or 'hr' but the unknown values 'bad' are present and should generally only be used if a particular client requires a scope outside of our standard scopes")
+}
+
+func TestValidateIdentityProvidersOpenIDConnectClient(t *testing.T) {
+	config := &schema.IdentityProvidersOpenIDConnect{}
+
+	validateOIDCAuthorizationPolicies(config, schema.NewStructValidator())
+
+	client := &schema.IdentityProvidersOpenIDConnectClient{
+		ID:           "abc",
+		Public:       true,
+		RedirectURIs: []string{"https://app.example.com/callback"},
+	}
+
+	assert.Len(t, ValidateIdentityProvidersOpenIDConnectClient(NewValidateCtx(), config, client), 0)
+	assert.Equal(t, "two_factor", client.AuthorizationPolicy)
+	assert.Equal(t, []string{"authorization_code"}, client.GrantTypes)
+	assert.Equal(t, "none", client.TokenEndpointAuthMethod)
+
+	client = &schema.IdentityProvidersOpenIDConnectClient{
+		ID:           "abc",
+		Public:       true,
+		RedirectURIs: []string{"not a uri"},
+	}
+
+	errs := ValidateIdentityProvidersOpenIDConnectClient(NewValidateCtx(), config, client)
+
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "identity_providers: oidc: clients: client 'abc': option 'redirect_uris'")
+}
+
 func MustDecodeSecret(value string) *schema.PasswordDigest {
 	if secret, err := schema.DecodePasswordDigest(value); err != nil {
 		panic(err)
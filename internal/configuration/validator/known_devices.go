@@ -0,0 +1,54 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// ValidateKnownDevices validates and updates the known devices configuration.
+func ValidateKnownDevices(config *schema.Configuration, validator *schema.StructValidator) {
+	if !config.KnownDevices.Enabled {
+		return
+	}
+
+	switch {
+	case config.KnownDevices.CookieName == "":
+		config.KnownDevices.CookieName = schema.DefaultKnownDevicesConfiguration.CookieName
+	case !reHTTPHeaderName.MatchString(config.KnownDevices.CookieName):
+		validator.Push(fmt.Errorf(errFmtKnownDevicesCookieName, config.KnownDevices.CookieName))
+	}
+
+	if config.KnownDevices.CookieName == config.Session.Name {
+		validator.Push(fmt.Errorf(errFmtKnownDevicesCookieNameSession, config.KnownDevices.CookieName))
+	} else {
+		for _, cookie := range config.Session.Cookies {
+			if config.KnownDevices.CookieName == cookie.Name {
+				validator.Push(fmt.Errorf(errFmtKnownDevicesCookieNameSession, config.KnownDevices.CookieName))
+
+				break
+			}
+		}
+	}
+
+	switch {
+	case config.KnownDevices.Lifespan < 0:
+		validator.Push(fmt.Errorf(errFmtKnownDevicesLifespan, config.KnownDevices.Lifespan))
+	case config.KnownDevices.Lifespan == 0:
+		config.KnownDevices.Lifespan = schema.DefaultKnownDevicesConfiguration.Lifespan
+	}
+
+	switch {
+	case config.KnownDevices.IPv4Subnet < 0 || config.KnownDevices.IPv4Subnet > 32:
+		validator.Push(fmt.Errorf(errFmtKnownDevicesSubnet, "ipv4_subnet", 32, config.KnownDevices.IPv4Subnet))
+	case config.KnownDevices.IPv4Subnet == 0:
+		config.KnownDevices.IPv4Subnet = schema.DefaultKnownDevicesConfiguration.IPv4Subnet
+	}
+
+	switch {
+	case config.KnownDevices.IPv6Subnet < 0 || config.KnownDevices.IPv6Subnet > 128:
+		validator.Push(fmt.Errorf(errFmtKnownDevicesSubnet, "ipv6_subnet", 128, config.KnownDevices.IPv6Subnet))
+	case config.KnownDevices.IPv6Subnet == 0:
+		config.KnownDevices.IPv6Subnet = schema.DefaultKnownDevicesConfiguration.IPv6Subnet
+	}
+}
@@ -0,0 +1,85 @@
+package validator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestValidateKnownDevices(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     schema.KnownDevices
+		expected schema.KnownDevices
+		errs     []string
+	}{
+		{
+			"ShouldNotSetDefaultsWhenDisabled",
+			schema.KnownDevices{},
+			schema.KnownDevices{},
+			nil,
+		},
+		{
+			"ShouldSetDefaults",
+			schema.KnownDevices{Enabled: true},
+			schema.KnownDevices{Enabled: true, CookieName: "authelia_device", Lifespan: time.Hour * 24 * 365, IPv4Subnet: 24, IPv6Subnet: 64},
+			nil,
+		},
+		{
+			"ShouldNotOverrideValues",
+			schema.KnownDevices{Enabled: true, CookieName: "device", Lifespan: time.Hour, IPv4Subnet: 16, IPv6Subnet: 48},
+			schema.KnownDevices{Enabled: true, CookieName: "device", Lifespan: time.Hour, IPv4Subnet: 16, IPv6Subnet: 48},
+			nil,
+		},
+		{
+			"ShouldErrorOnInvalidValues",
+			schema.KnownDevices{Enabled: true, CookieName: "a device", Lifespan: -time.Hour, IPv4Subnet: 33, IPv6Subnet: -1},
+			schema.KnownDevices{Enabled: true, CookieName: "a device", Lifespan: -time.Hour, IPv4Subnet: 33, IPv6Subnet: -1},
+			[]string{
+				"known_devices: option 'cookie_name' must only contain valid cookie name characters but it's configured as 'a device'",
+				"known_devices: option 'lifespan' must not be negative but it's configured as '-1h0m0s'",
+				"known_devices: option 'ipv4_subnet' must be between 1 and 32 but it's configured as '33'",
+				"known_devices: option 'ipv6_subnet' must be between 1 and 128 but it's configured as '-1'",
+			},
+		},
+		{
+			"ShouldErrorOnSessionCookieName",
+			schema.KnownDevices{Enabled: true, CookieName: "authelia_session"},
+			schema.KnownDevices{Enabled: true, CookieName: "authelia_session", Lifespan: time.Hour * 24 * 365, IPv4Subnet: 24, IPv6Subnet: 64},
+			[]string{
+				"known_devices: option 'cookie_name' must not be the same as the name of a session cookie but it's configured as 'authelia_session'",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validator := schema.NewStructValidator()
+
+			config := &schema.Configuration{
+				KnownDevices: tc.have,
+				Session: schema.Session{
+					SessionCookieCommon: schema.SessionCookieCommon{Name: "session"},
+					Cookies: []schema.SessionCookie{
+						{SessionCookieCommon: schema.SessionCookieCommon{Name: "authelia_session"}},
+					},
+				},
+			}
+
+			ValidateKnownDevices(config, validator)
+
+			assert.Len(t, validator.Warnings(), 0)
+			require.Len(t, validator.Errors(), len(tc.errs))
+
+			for i, expected := range tc.errs {
+				assert.EqualError(t, validator.Errors()[i], expected)
+			}
+
+			assert.Equal(t, tc.expected, config.KnownDevices)
+		})
+	}
+}
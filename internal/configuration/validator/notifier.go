@@ -6,30 +6,43 @@ import (
 	"os"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/templates"
+	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 // ValidateNotifier validates and update notifier configuration.
 func ValidateNotifier(config *schema.Notifier, validator *schema.StructValidator) {
-	if config.SMTP == nil && config.FileSystem == nil {
+	configured := 0
+
+	for _, enabled := range []bool{config.SMTP != nil, config.FileSystem != nil, config.Webhook != nil} {
+		if enabled {
+			configured++
+		}
+	}
+
+	if configured == 0 {
 		validator.Push(errors.New(errFmtNotifierNotConfigured))
 
 		return
-	} else if config.SMTP != nil && config.FileSystem != nil {
+	} else if configured > 1 {
 		validator.Push(errors.New(errFmtNotifierMultipleConfigured))
 
 		return
 	}
 
-	if config.FileSystem != nil {
+	switch {
+	case config.FileSystem != nil:
 		if config.FileSystem.Filename == "" {
 			validator.Push(errors.New(errFmtNotifierFileSystemFileNameNotConfigured))
 		}
 
 		return
+	case config.Webhook != nil:
+		validateWebhookNotifier(config.Webhook, validator)
+	default:
+		validateSMTPNotifier(config.SMTP, validator)
 	}
 
-	validateSMTPNotifier(config.SMTP, validator)
-
 	validateNotifierTemplates(config, validator)
 }
 
@@ -119,3 +132,64 @@ func validateSMTPNotifierAddress(config *schema.NotifierSMTP, validator *schema.
 		}
 	}
 }
+
+func validateWebhookNotifier(config *schema.NotifierWebhook, validator *schema.StructValidator) {
+	switch {
+	case config.URL == nil:
+		validator.Push(fmt.Errorf(errFmtNotifierWebhookNotConfigured, "url"))
+	case config.URL.Scheme != schemeHTTP && config.URL.Scheme != schemeHTTPS:
+		validator.Push(fmt.Errorf(errFmtNotifierWebhookURLScheme, utils.StringJoinOr([]string{schemeHTTP, schemeHTTPS}), config.URL.String()))
+	}
+
+	switch {
+	case config.Timeout < 0:
+		validator.Push(fmt.Errorf(errFmtNotifierWebhookNegative, "timeout", config.Timeout))
+	case config.Timeout == 0:
+		config.Timeout = schema.DefaultWebhookNotifierConfiguration.Timeout
+	}
+
+	switch {
+	case config.Retries < -1:
+		validator.Push(fmt.Errorf(errFmtNotifierWebhookRetries, config.Retries))
+	case config.Retries == 0:
+		config.Retries = schema.DefaultWebhookNotifierConfiguration.Retries
+	}
+
+	switch {
+	case config.RetryInterval < 0:
+		validator.Push(fmt.Errorf(errFmtNotifierWebhookNegative, "retry_interval", config.RetryInterval))
+	case config.RetryInterval == 0:
+		config.RetryInterval = schema.DefaultWebhookNotifierConfiguration.RetryInterval
+	}
+
+	if config.ContentType == "" {
+		config.ContentType = schema.DefaultWebhookNotifierConfiguration.ContentType
+	}
+
+	if config.Payload != "" {
+		if _, err := templates.ParseStringTemplate("payload", config.Payload); err != nil {
+			validator.Push(fmt.Errorf(errFmtNotifierWebhookPayload, err))
+		}
+	}
+
+	if config.Secret == "" {
+		validator.PushWarning(errors.New(errFmtNotifierWebhookNoSecret))
+	}
+
+	if config.TLS == nil {
+		config.TLS = &schema.TLS{}
+	}
+
+	configDefaultTLS := &schema.TLS{
+		MinimumVersion: schema.DefaultWebhookNotifierConfiguration.TLS.MinimumVersion,
+		MaximumVersion: schema.DefaultWebhookNotifierConfiguration.TLS.MaximumVersion,
+	}
+
+	if config.URL != nil {
+		configDefaultTLS.ServerName = config.URL.Hostname()
+	}
+
+	if err := ValidateTLSConfig(config.TLS, configDefaultTLS); err != nil {
+		validator.Push(fmt.Errorf(errFmtNotifierWebhookTLSConfigInvalid, err))
+	}
+}
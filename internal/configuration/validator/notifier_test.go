@@ -6,6 +6,7 @@ import (
 	"net/mail"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -29,6 +30,7 @@ func (suite *NotifierSuite) SetupTest() {
 		Sender:   mail.Address{Name: "Authelia", Address: "authelia@example.com"},
 	}
 	suite.config.FileSystem = nil
+	suite.config.Webhook = nil
 }
 
 /*
@@ -282,6 +284,69 @@ func (suite *NotifierSuite) TestFileShouldEnsureFilenameIsProvided() {
 	suite.EqualError(suite.validator.Errors()[0], errFmtNotifierFileSystemFileNameNotConfigured)
 }
 
+/*
+Webhook Tests.
+*/
+func (suite *NotifierSuite) TestWebhookShouldSetDefaults() {
+	suite.config.SMTP = nil
+	suite.config.Webhook = &schema.NotifierWebhook{
+		URL:    MustParseURL("https://notifications.example.com/authelia"),
+		Secret: "a-very-long-secret",
+	}
+
+	ValidateNotifier(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Len(suite.validator.Errors(), 0)
+
+	suite.Equal(time.Second*5, suite.config.Webhook.Timeout)
+	suite.Equal(3, suite.config.Webhook.Retries)
+	suite.Equal(time.Second, suite.config.Webhook.RetryInterval)
+	suite.Equal("application/json", suite.config.Webhook.ContentType)
+	suite.Equal(uint16(tls.VersionTLS12), suite.config.Webhook.TLS.MinimumVersion.Value)
+	suite.Equal("notifications.example.com", suite.config.Webhook.TLS.ServerName)
+}
+
+func (suite *NotifierSuite) TestWebhookShouldErrorOnInvalidOptions() {
+	suite.config.SMTP = nil
+	suite.config.Webhook = &schema.NotifierWebhook{
+		URL:           MustParseURL("ftp://notifications.example.com/authelia"),
+		Timeout:       -time.Second,
+		Retries:       -2,
+		RetryInterval: -time.Second,
+		Payload:       `{"text": {{ nope .Body }}}`,
+	}
+
+	ValidateNotifier(&suite.config, suite.validator)
+
+	suite.Require().Len(suite.validator.Warnings(), 1)
+	suite.Require().Len(suite.validator.Errors(), 5)
+
+	suite.EqualError(suite.validator.Warnings()[0], errFmtNotifierWebhookNoSecret)
+
+	suite.EqualError(suite.validator.Errors()[0], "notifier: webhook: option 'url' must have the scheme 'http' or 'https' but it's configured as 'ftp://notifications.example.com/authelia'")
+	suite.EqualError(suite.validator.Errors()[1], "notifier: webhook: option 'timeout' must not be negative but it's configured as '-1s'")
+	suite.EqualError(suite.validator.Errors()[2], "notifier: webhook: option 'retries' must be -1 or greater but it's configured as '-2'")
+	suite.EqualError(suite.validator.Errors()[3], "notifier: webhook: option 'retry_interval' must not be negative but it's configured as '-1s'")
+	suite.EqualError(suite.validator.Errors()[4], "notifier: webhook: option 'payload' is not a valid template: template: payload:1: function \"nope\" not defined")
+}
+
+func (suite *NotifierSuite) TestWebhookShouldEnsureURLIsProvided() {
+	suite.config.SMTP = nil
+	suite.config.Webhook = &schema.NotifierWebhook{
+		Secret:  "a-very-long-secret",
+		Retries: -1,
+	}
+
+	ValidateNotifier(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 1)
+
+	suite.EqualError(suite.validator.Errors()[0], "notifier: webhook: option 'url' is required")
+	suite.Equal(-1, suite.config.Webhook.Retries)
+}
+
 func TestNotifierSuite(t *testing.T) {
 	suite.Run(t, new(NotifierSuite))
 }
@@ -2,6 +2,7 @@ package validator
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 )
@@ -19,4 +20,55 @@ func ValidateRegulation(config *schema.Configuration, validator *schema.StructVa
 	if config.Regulation.FindTime > config.Regulation.BanTime {
 		validator.Push(errors.New(errFmtRegulationFindTimeGreaterThanBanTime))
 	}
+
+	validateRegulationIP(config, validator)
+	validateRegulationSubnet(config, validator)
+
+	for _, network := range config.Regulation.AllowedNetworks {
+		if !IsNetworkValid(network) {
+			validator.Push(fmt.Errorf(errFmtRegulationAllowedNetworks, network))
+		}
+	}
+}
+
+func validateRegulationIP(config *schema.Configuration, validator *schema.StructValidator) {
+	if config.Regulation.IP.FindTime <= 0 {
+		config.Regulation.IP.FindTime = config.Regulation.FindTime
+	}
+
+	if config.Regulation.IP.BanTime <= 0 {
+		config.Regulation.IP.BanTime = config.Regulation.BanTime
+	}
+
+	if config.Regulation.IP.FindTime > config.Regulation.IP.BanTime {
+		validator.Push(fmt.Errorf(errFmtRegulationSectionFindTimeGreaterThanBanTime, "ip"))
+	}
+}
+
+func validateRegulationSubnet(config *schema.Configuration, validator *schema.StructValidator) {
+	if config.Regulation.Subnet.FindTime <= 0 {
+		config.Regulation.Subnet.FindTime = config.Regulation.FindTime
+	}
+
+	if config.Regulation.Subnet.BanTime <= 0 {
+		config.Regulation.Subnet.BanTime = config.Regulation.BanTime
+	}
+
+	if config.Regulation.Subnet.FindTime > config.Regulation.Subnet.BanTime {
+		validator.Push(fmt.Errorf(errFmtRegulationSectionFindTimeGreaterThanBanTime, "subnet"))
+	}
+
+	switch {
+	case config.Regulation.Subnet.IPv4Prefix == 0:
+		config.Regulation.Subnet.IPv4Prefix = schema.DefaultRegulationConfiguration.Subnet.IPv4Prefix
+	case config.Regulation.Subnet.IPv4Prefix < 1 || config.Regulation.Subnet.IPv4Prefix > 32:
+		validator.Push(fmt.Errorf(errFmtRegulationSubnetPrefix, "ipv4_prefix", 32, config.Regulation.Subnet.IPv4Prefix))
+	}
+
+	switch {
+	case config.Regulation.Subnet.IPv6Prefix == 0:
+		config.Regulation.Subnet.IPv6Prefix = schema.DefaultRegulationConfiguration.Subnet.IPv6Prefix
+	case config.Regulation.Subnet.IPv6Prefix < 1 || config.Regulation.Subnet.IPv6Prefix > 128:
+		validator.Push(fmt.Errorf(errFmtRegulationSubnetPrefix, "ipv6_prefix", 128, config.Regulation.Subnet.IPv6Prefix))
+	}
 }
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 )
@@ -52,3 +53,42 @@ func TestShouldRaiseErrorWhenFindTimeLessThanBanTime(t *testing.T) {
 	assert.Len(t, validator.Errors(), 1)
 	assert.EqualError(t, validator.Errors()[0], "regulation: option 'find_time' must be less than or equal to option 'ban_time'")
 }
+
+func TestShouldSetDefaultRegulationIPAndSubnetFromRegulation(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultRegulationConfig()
+	config.Regulation.FindTime = time.Minute
+	config.Regulation.BanTime = time.Minute * 10
+
+	ValidateRegulation(&config, validator)
+
+	assert.Len(t, validator.Errors(), 0)
+	assert.Equal(t, time.Minute, config.Regulation.IP.FindTime)
+	assert.Equal(t, time.Minute*10, config.Regulation.IP.BanTime)
+	assert.Equal(t, time.Minute, config.Regulation.Subnet.FindTime)
+	assert.Equal(t, time.Minute*10, config.Regulation.Subnet.BanTime)
+	assert.Equal(t, 24, config.Regulation.Subnet.IPv4Prefix)
+	assert.Equal(t, 64, config.Regulation.Subnet.IPv6Prefix)
+}
+
+func TestShouldRaiseErrorsWhenRegulationIPAndSubnetInvalid(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultRegulationConfig()
+	config.Regulation.IP = schema.RegulationIP{MaxRetries: 10, FindTime: time.Hour, BanTime: time.Minute}
+	config.Regulation.Subnet = schema.RegulationSubnet{MaxRetries: 50, FindTime: time.Hour, BanTime: time.Minute, IPv4Prefix: 33, IPv6Prefix: -1}
+	config.Regulation.AllowedNetworks = []string{"10.0.0.0/8", "192.168.1.1", "::1/128", "10.0.0.0/33", "abc"}
+
+	ValidateRegulation(&config, validator)
+
+	errs := validator.Errors()
+
+	assert.Len(t, validator.Warnings(), 0)
+	require.Len(t, errs, 6)
+
+	assert.EqualError(t, errs[0], "regulation: ip: option 'find_time' must be less than or equal to option 'ban_time'")
+	assert.EqualError(t, errs[1], "regulation: subnet: option 'find_time' must be less than or equal to option 'ban_time'")
+	assert.EqualError(t, errs[2], "regulation: subnet: option 'ipv4_prefix' must be between 1 and 32 but it's configured as '33'")
+	assert.EqualError(t, errs[3], "regulation: subnet: option 'ipv6_prefix' must be between 1 and 128 but it's configured as '-1'")
+	assert.EqualError(t, errs[4], "regulation: option 'allowed_networks' must only contain valid IP addresses or networks in CIDR notation but it contains '10.0.0.0/33'")
+	assert.EqualError(t, errs[5], "regulation: option 'allowed_networks' must only contain valid IP addresses or networks in CIDR notation but it contains 'abc'")
+}
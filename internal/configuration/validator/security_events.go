@@ -0,0 +1,76 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// ValidateSecurityEvents validates and updates the Security Events configuration.
+func ValidateSecurityEvents(config *schema.SecurityEvents, validator *schema.StructValidator) {
+	if len(config.EmailDigest.Recipients) != 0 {
+		validateSecurityEventsEmailDigest(&config.EmailDigest, validator)
+	}
+
+	if config.Webhook.URL != nil {
+		validateSecurityEventsWebhook(&config.Webhook, validator)
+	}
+
+	if config.Log.Path != "" {
+		validateSecurityEventsTypes("log", config.Log.Events, validator)
+	}
+}
+
+func validateSecurityEventsEmailDigest(config *schema.SecurityEventsEmailDigest, validator *schema.StructValidator) {
+	switch {
+	case config.Interval < 0:
+		validator.Push(fmt.Errorf(errFmtSecurityEventsNegative, "email_digest", "interval", config.Interval))
+	case config.Interval == 0:
+		config.Interval = schema.DefaultSecurityEventsConfiguration.EmailDigest.Interval
+	}
+
+	validateSecurityEventsTypes("email_digest", config.Events, validator)
+}
+
+func validateSecurityEventsWebhook(config *schema.SecurityEventsWebhook, validator *schema.StructValidator) {
+	if config.URL.Scheme != schemeHTTP && config.URL.Scheme != schemeHTTPS {
+		validator.Push(fmt.Errorf(errFmtSecurityEventsWebhookURLScheme, utils.StringJoinOr([]string{schemeHTTP, schemeHTTPS}), config.URL.String()))
+	}
+
+	switch {
+	case config.Timeout < 0:
+		validator.Push(fmt.Errorf(errFmtSecurityEventsNegative, "webhook", "timeout", config.Timeout))
+	case config.Timeout == 0:
+		config.Timeout = schema.DefaultSecurityEventsConfiguration.Webhook.Timeout
+	}
+
+	if config.Secret == "" {
+		validator.PushWarning(errors.New(errFmtSecurityEventsWebhookNoSecret))
+	}
+
+	validateSecurityEventsTypes("webhook", config.Events, validator)
+
+	if config.TLS == nil {
+		config.TLS = &schema.TLS{}
+	}
+
+	configDefaultTLS := &schema.TLS{
+		ServerName:     config.URL.Hostname(),
+		MinimumVersion: schema.DefaultWebhookNotifierConfiguration.TLS.MinimumVersion,
+		MaximumVersion: schema.DefaultWebhookNotifierConfiguration.TLS.MaximumVersion,
+	}
+
+	if err := ValidateTLSConfig(config.TLS, configDefaultTLS); err != nil {
+		validator.Push(fmt.Errorf(errFmtSecurityEventsWebhookTLSConfig, err))
+	}
+}
+
+func validateSecurityEventsTypes(name string, events []string, validator *schema.StructValidator) {
+	for _, event := range events {
+		if !utils.IsStringInSlice(event, validSecurityEventTypes) {
+			validator.Push(fmt.Errorf(errFmtSecurityEventsEvents, name, utils.StringJoinOr(validSecurityEventTypes), event))
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package validator
+
+import (
+	"net/mail"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestValidateSecurityEvents(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     *schema.SecurityEvents
+		expected func(t *testing.T, actual *schema.SecurityEvents)
+		warnings []string
+		errors   []string
+	}{
+		{
+			"ShouldValidateDefaultConfig",
+			&schema.SecurityEvents{},
+			func(t *testing.T, actual *schema.SecurityEvents) {
+				assert.Equal(t, time.Duration(0), actual.EmailDigest.Interval)
+				assert.Nil(t, actual.Webhook.TLS)
+			},
+			nil,
+			nil,
+		},
+		{
+			"ShouldSetDefaults",
+			&schema.SecurityEvents{
+				EmailDigest: schema.SecurityEventsEmailDigest{Recipients: []mail.Address{{Address: "admin@example.com"}}},
+				Webhook:     schema.SecurityEventsWebhook{URL: MustParseURL("https://events.example.com/authelia"), Secret: "secret"},
+				Log:         schema.SecurityEventsLog{Path: "/config/security-events.log", Events: []string{"regulation.ban"}},
+			},
+			func(t *testing.T, actual *schema.SecurityEvents) {
+				assert.Equal(t, time.Hour, actual.EmailDigest.Interval)
+				assert.Equal(t, time.Second*5, actual.Webhook.Timeout)
+				require.NotNil(t, actual.Webhook.TLS)
+				assert.Equal(t, "events.example.com", actual.Webhook.TLS.ServerName)
+			},
+			nil,
+			nil,
+		},
+		{
+			"ShouldWarnWebhookNoSecret",
+			&schema.SecurityEvents{
+				Webhook: schema.SecurityEventsWebhook{URL: MustParseURL("http://events.example.com/authelia")},
+			},
+			nil,
+			[]string{
+				"security_events: webhook: option 'secret' is not configured: the payload is not signed and the endpoint can't verify it was sent by Authelia",
+			},
+			nil,
+		},
+		{
+			"ShouldErrorOnInvalidOptions",
+			&schema.SecurityEvents{
+				EmailDigest: schema.SecurityEventsEmailDigest{Recipients: []mail.Address{{Address: "admin@example.com"}}, Interval: -time.Minute, Events: []string{"authentication.success", "login"}},
+				Webhook:     schema.SecurityEventsWebhook{URL: MustParseURL("ftp://events.example.com"), Secret: "secret", Timeout: -time.Second, Events: []string{"ban"}},
+				Log:         schema.SecurityEventsLog{Path: "/config/security-events.log", Events: []string{"oidc.consent"}},
+			},
+			nil,
+			nil,
+			[]string{
+				"security_events: email_digest: option 'interval' must not be negative but it's configured as '-1m0s'",
+				"security_events: email_digest: option 'events' must only have the values 'authentication.success', 'authentication.failure', 'regulation.ban', 'password.reset', 'credential.registered', 'credential.removed', 'session.elevated', or 'oidc.consent.granted' but it has the value 'login'",
+				"security_events: webhook: option 'url' must have the scheme 'http' or 'https' but it's configured as 'ftp://events.example.com'",
+				"security_events: webhook: option 'timeout' must not be negative but it's configured as '-1s'",
+				"security_events: webhook: option 'events' must only have the values 'authentication.success', 'authentication.failure', 'regulation.ban', 'password.reset', 'credential.registered', 'credential.removed', 'session.elevated', or 'oidc.consent.granted' but it has the value 'ban'",
+				"security_events: log: option 'events' must only have the values 'authentication.success', 'authentication.failure', 'regulation.ban', 'password.reset', 'credential.registered', 'credential.removed', 'session.elevated', or 'oidc.consent.granted' but it has the value 'oidc.consent'",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validator := schema.NewStructValidator()
+
+			ValidateSecurityEvents(tc.have, validator)
+
+			warnings, errs := validator.Warnings(), validator.Errors()
+
+			require.Len(t, warnings, len(tc.warnings))
+			require.Len(t, errs, len(tc.errors))
+
+			for i, expected := range tc.warnings {
+				assert.EqualError(t, warnings[i], expected)
+			}
+
+			for i, expected := range tc.errors {
+				assert.EqualError(t, errs[i], expected)
+			}
+
+			if tc.expected != nil {
+				tc.expected(t, tc.have)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/templates"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
@@ -155,6 +156,7 @@ func ValidateServerEndpoints(config *schema.Configuration, validator *schema.Str
 		}
 
 		validateServerEndpointsAuthzStrategies(name, endpoint.Implementation, endpoint.AuthnStrategies, validator)
+		validateServerEndpointsAuthzHeaders(name, endpoint.Headers, validator)
 	}
 }
 
@@ -183,6 +185,33 @@ func validateServerEndpointsAuthzEndpoint(config *schema.Configuration, name str
 	}
 }
 
+func validateServerEndpointsAuthzHeaders(name string, headers []schema.ServerEndpointsAuthzHeader, validator *schema.StructValidator) {
+	names := make([]string, 0, len(headers))
+
+	for i, header := range headers {
+		switch {
+		case header.Name == "":
+			validator.Push(fmt.Errorf(errFmtServerEndpointsAuthzHeaderNoName, name, i+1))
+		case !reHTTPHeaderName.MatchString(header.Name):
+			validator.Push(fmt.Errorf(errFmtServerEndpointsAuthzHeaderName, name, i+1, header.Name))
+		case utils.IsStringInSliceFold(header.Name, names):
+			validator.Push(fmt.Errorf(errFmtServerEndpointsAuthzHeaderDuplicate, name, header.Name))
+		}
+
+		names = append(names, header.Name)
+
+		if header.Value == "" {
+			validator.Push(fmt.Errorf(errFmtServerEndpointsAuthzHeaderNoValue, name, i+1, header.Name))
+
+			continue
+		}
+
+		if _, err := templates.ParseStringTemplate(header.Name, header.Value); err != nil {
+			validator.Push(fmt.Errorf(errFmtServerEndpointsAuthzHeaderValue, name, i+1, header.Name, err))
+		}
+	}
+}
+
 //nolint:gocyclo
 func validateServerEndpointsAuthzStrategies(name, implementation string, strategies []schema.ServerEndpointsAuthzAuthnStrategy, validator *schema.StructValidator) {
 	var defaults []schema.ServerEndpointsAuthzAuthnStrategy
@@ -480,6 +480,43 @@ func TestServerAuthzEndpointErrors(t *testing.T) {
 				"server: endpoints: authz: pear/abc: endpoint starts with the same prefix as the 'pear' endpoint with the 'ExtAuthz' implementation which accepts prefixes as part of its implementation",
 			},
 		},
+		{
+			"ShouldAllowHeaders",
+			map[string]schema.ServerEndpointsAuthz{
+				"example": {
+					Implementation: "ForwardAuth",
+					Headers: []schema.ServerEndpointsAuthzHeader{
+						{Name: "X-Forwarded-User", Value: "{{ .Username }}"},
+						{Name: "Remote-Groups", Value: `{{ join "|" .Groups }}`},
+						{Name: "X-Authelia-JWT", Value: "{{ .JWT }}"},
+					},
+				},
+			},
+			nil,
+		},
+		{
+			"ShouldErrorOnInvalidHeaders",
+			map[string]schema.ServerEndpointsAuthz{
+				"example": {
+					Implementation: "ForwardAuth",
+					Headers: []schema.ServerEndpointsAuthzHeader{
+						{Value: "{{ .Username }}"},
+						{Name: "X Forwarded User", Value: "{{ .Username }}"},
+						{Name: "X-Forwarded-Email"},
+						{Name: "X-Forwarded-Groups", Value: "{{ nope .Groups }}"},
+						{Name: "Remote-Name", Value: "{{ .DisplayName }}"},
+						{Name: "remote-name", Value: "{{ .DisplayName }}"},
+					},
+				},
+			},
+			[]string{
+				"server: endpoints: authz: example: headers: header #1: option 'name' must be configured",
+				"server: endpoints: authz: example: headers: header #2 (X Forwarded User): option 'name' must only contain valid header name characters",
+				"server: endpoints: authz: example: headers: header #3 (X-Forwarded-Email): option 'value' must be configured",
+				"server: endpoints: authz: example: headers: header #4 (X-Forwarded-Groups): option 'value' is not a valid template: template: X-Forwarded-Groups:1: function \"nope\" not defined",
+				"server: endpoints: authz: example: headers: duplicate header name detected with name 'remote-name'",
+			},
+		},
 	}
 
 	validator := schema.NewStructValidator()
@@ -0,0 +1,230 @@
+package events
+
+import (
+	"context"
+	"crypto/x509"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/notification"
+	"github.com/authelia/authelia/v4/internal/templates"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// Subscriber handles the security events published to the Bus.
+type Subscriber interface {
+	Handle(ctx context.Context, event model.SecurityEvent) (err error)
+}
+
+// Templates is a cut down version of the templates.Provider with just the methods the DigestSubscriber uses.
+type Templates interface {
+	GetEventEmailTemplate() (t *templates.EmailTemplate)
+}
+
+// NewBus creates a new *Bus with the configured subscribers. It returns nil when no subscribers are configured, all
+// methods of the *Bus are safe to call on a nil *Bus.
+func NewBus(config *schema.SecurityEvents, notifier notification.Notifier, templates Templates, certPool *x509.CertPool) (bus *Bus) {
+	bus = &Bus{
+		log: logging.Logger().WithFields(map[string]any{"provider": "security_events"}),
+	}
+
+	if len(config.EmailDigest.Recipients) != 0 {
+		bus.digest = NewDigestSubscriber(config.EmailDigest.Recipients, notifier, templates)
+
+		bus.subscribe("email_digest", config.EmailDigest.Events, bus.digest)
+	}
+
+	if config.Webhook.URL != nil {
+		bus.subscribe("webhook", config.Webhook.Events, NewWebhookSubscriber(&config.Webhook, certPool))
+	}
+
+	if config.Log.Path != "" {
+		bus.subscribe("log", config.Log.Events, NewLogSubscriber(config.Log.Path))
+	}
+
+	if len(bus.subscriptions) == 0 {
+		return nil
+	}
+
+	bus.start()
+
+	return bus
+}
+
+// Bus delivers the published security events to each subscriber which is subscribed to the event type. The deliveries
+// are queued and handled by a fixed number of workers.
+type Bus struct {
+	subscriptions []subscription
+	digest        *DigestSubscriber
+
+	queue   chan delivery
+	mu      sync.RWMutex
+	closed  bool
+	workers sync.WaitGroup
+
+	log *logrus.Entry
+	wg  sync.WaitGroup
+}
+
+type delivery struct {
+	subscription subscription
+	event        model.SecurityEvent
+}
+
+type subscription struct {
+	name       string
+	events     []string
+	subscriber Subscriber
+}
+
+func (s subscription) subscribed(event string) bool {
+	return len(s.events) == 0 || utils.IsStringInSlice(event, s.events)
+}
+
+func (b *Bus) subscribe(name string, events []string, subscriber Subscriber) {
+	b.subscriptions = append(b.subscriptions, subscription{name: name, events: events, subscriber: subscriber})
+}
+
+func (b *Bus) start() {
+	b.queue = make(chan delivery, busQueueSize)
+
+	for i := 0; i < busWorkers; i++ {
+		b.workers.Add(1)
+
+		go b.work()
+	}
+}
+
+func (b *Bus) work() {
+	defer b.workers.Done()
+
+	for d := range b.queue {
+		if err := d.subscription.subscriber.Handle(context.Background(), d.event); err != nil {
+			b.log.WithError(err).WithFields(map[string]any{"subscriber": d.subscription.name, "type": d.event.Type, "id": d.event.ID}).Error("Error occurred handling security event")
+		}
+
+		b.wg.Done()
+	}
+}
+
+// Publish a security event to the subscribers. The subscribers handle the event in the background so the caller is
+// never blocked by a slow subscriber. The event is logged and dropped for a subscriber when the queue is full.
+func (b *Bus) Publish(event model.SecurityEvent) {
+	if b == nil {
+		return
+	}
+
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	b.mu.RLock()
+
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return
+	}
+
+	for _, s := range b.subscriptions {
+		if !s.subscribed(event.Type) {
+			continue
+		}
+
+		b.wg.Add(1)
+
+		select {
+		case b.queue <- delivery{subscription: s, event: event}:
+		default:
+			b.wg.Done()
+
+			b.log.WithFields(map[string]any{"subscriber": s.name, "type": event.Type, "id": event.ID}).Warn("Dropped security event as the queue is full")
+		}
+	}
+}
+
+// Wait until the subscribers have handled all of the published security events.
+func (b *Bus) Wait() {
+	if b == nil {
+		return
+	}
+
+	b.wg.Wait()
+}
+
+// FlushDigest sends the security events buffered by the email digest subscriber if it's configured.
+func (b *Bus) FlushDigest(ctx context.Context) (err error) {
+	if b == nil || b.digest == nil {
+		return nil
+	}
+
+	return b.digest.Flush(ctx)
+}
+
+// Digest returns true if the email digest subscriber is configured.
+func (b *Bus) Digest() bool {
+	return b != nil && b.digest != nil
+}
+
+// StartupCheck implements the model.StartupCheck interface by performing the startup check of each subscriber which
+// implements it.
+func (b *Bus) StartupCheck() (err error) {
+	if b == nil {
+		return nil
+	}
+
+	for _, s := range b.subscriptions {
+		if check, ok := s.subscriber.(model.StartupCheck); ok {
+			if err = check.StartupCheck(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Close waits for the published security events to be handled, sends the remaining buffered digest events, and closes
+// each subscriber which implements io.Closer.
+func (b *Bus) Close() (err error) {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+
+	if !b.closed {
+		b.closed = true
+
+		close(b.queue)
+	}
+
+	b.mu.Unlock()
+
+	b.workers.Wait()
+
+	if err = b.FlushDigest(context.Background()); err != nil {
+		b.log.WithError(err).Error("Error occurred sending the security events digest")
+	}
+
+	for _, s := range b.subscriptions {
+		if closer, ok := s.subscriber.(io.Closer); ok {
+			if err = closer.Close(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
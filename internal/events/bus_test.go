@@ -0,0 +1,245 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/notification"
+	"github.com/authelia/authelia/v4/internal/templates"
+)
+
+func TestNewBus(t *testing.T) {
+	assert.Nil(t, NewBus(&schema.SecurityEvents{}, nil, nil, nil))
+
+	var bus *Bus
+
+	bus.Publish(model.SecurityEvent{Type: schema.SecurityEventAuthenticationSuccess})
+	bus.Wait()
+
+	assert.False(t, bus.Digest())
+	assert.NoError(t, bus.FlushDigest(context.Background()))
+	assert.NoError(t, bus.StartupCheck())
+	assert.NoError(t, bus.Close())
+}
+
+func TestBus_ShouldFilterEvents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.log")
+
+	bus := NewBus(&schema.SecurityEvents{
+		Log: schema.SecurityEventsLog{Path: path, Events: []string{schema.SecurityEventRegulationBan}},
+	}, nil, nil, nil)
+
+	require.NotNil(t, bus)
+	require.NoError(t, bus.StartupCheck())
+
+	bus.Publish(model.SecurityEvent{Type: schema.SecurityEventAuthenticationFailure, Username: "john"})
+	bus.Publish(model.SecurityEvent{Type: schema.SecurityEventRegulationBan, Username: "john", RemoteIP: "192.168.1.10", Details: map[string]any{"banned_until": "2026-10-17T10:05:00Z"}})
+
+	require.NoError(t, bus.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 1)
+
+	event := model.SecurityEvent{}
+
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &event))
+
+	assert.NotEqual(t, uuid.Nil, event.ID)
+	assert.False(t, event.Time.IsZero())
+	assert.Equal(t, schema.SecurityEventRegulationBan, event.Type)
+	assert.Equal(t, "john", event.Username)
+	assert.Equal(t, "192.168.1.10", event.RemoteIP)
+	assert.Equal(t, map[string]any{"banned_until": "2026-10-17T10:05:00Z"}, event.Details)
+}
+
+func TestBus_ShouldDropEventsWhenQueueIsFull(t *testing.T) {
+	subscriber := &testBlockingSubscriber{started: make(chan struct{}, busWorkers+busQueueSize), release: make(chan struct{})}
+
+	bus := &Bus{log: logrus.NewEntry(logrus.New())}
+
+	bus.subscribe("blocking", nil, subscriber)
+	bus.start()
+
+	for i := 0; i < busWorkers; i++ {
+		bus.Publish(model.SecurityEvent{Type: schema.SecurityEventAuthenticationFailure})
+	}
+
+	for i := 0; i < busWorkers; i++ {
+		<-subscriber.started
+	}
+
+	for i := 0; i < busQueueSize+5; i++ {
+		bus.Publish(model.SecurityEvent{Type: schema.SecurityEventAuthenticationFailure})
+	}
+
+	close(subscriber.release)
+
+	require.NoError(t, bus.Close())
+
+	assert.Equal(t, int64(busWorkers+busQueueSize), subscriber.handled.Load())
+
+	// The events published after the bus is closed are dropped.
+	bus.Publish(model.SecurityEvent{Type: schema.SecurityEventAuthenticationFailure})
+	bus.Wait()
+
+	assert.Equal(t, int64(busWorkers+busQueueSize), subscriber.handled.Load())
+}
+
+func TestWebhookSubscriber_Handle(t *testing.T) {
+	var (
+		headers http.Header
+		body    []byte
+		status  = http.StatusNoContent
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header
+
+		var err error
+
+		body, err = io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		w.WriteHeader(status)
+	}))
+
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	subscriber := NewWebhookSubscriber(&schema.SecurityEventsWebhook{URL: u, Secret: "secret", Timeout: time.Second}, nil)
+
+	event := model.SecurityEvent{
+		ID:       uuid.MustParse("4b0d1e9a-2f0c-4a4e-9b8f-0a6f0e3d7c21"),
+		Type:     schema.SecurityEventPasswordReset,
+		Time:     time.Unix(1792224000, 0).UTC(),
+		Username: "john",
+	}
+
+	require.NoError(t, subscriber.Handle(context.Background(), event))
+
+	assert.Equal(t, "application/json", headers.Get("Content-Type"))
+	assert.Equal(t, "4b0d1e9a-2f0c-4a4e-9b8f-0a6f0e3d7c21", headers.Get("X-Authelia-Event-ID"))
+	assert.Equal(t, "1792224000", headers.Get("X-Authelia-Timestamp"))
+	assert.Equal(t, "sha256="+notification.WebhookSignature([]byte("secret"), "1792224000", body), headers.Get("X-Authelia-Signature"))
+	assert.JSONEq(t, `{"id":"4b0d1e9a-2f0c-4a4e-9b8f-0a6f0e3d7c21","type":"password.reset","time":"2026-10-17T08:00:00Z","username":"john"}`, string(body))
+
+	status = http.StatusBadRequest
+
+	assert.EqualError(t, subscriber.Handle(context.Background(), event), "error sending security event: failed to send request after 1 attempt(s): the endpoint responded with status code 400")
+}
+
+func TestDigestSubscriber_Flush(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	defer ctrl.Finish()
+
+	notifier := NewMockNotifier(ctrl)
+
+	et := &templates.EmailTemplate{Text: template.Must(template.New("text").Parse("{{ .Title }}"))}
+
+	bus := NewBus(&schema.SecurityEvents{
+		EmailDigest: schema.SecurityEventsEmailDigest{
+			Recipients: []mail.Address{{Name: "Admin", Address: "admin@example.com"}, {Address: "security@example.com"}},
+			Events:     []string{schema.SecurityEventAuthenticationFailure, schema.SecurityEventRegulationBan},
+		},
+	}, notifier, &testTemplates{et}, nil)
+
+	require.NotNil(t, bus)
+	assert.True(t, bus.Digest())
+
+	now := time.Unix(1792224000, 0).UTC()
+
+	bus.Publish(model.SecurityEvent{Type: schema.SecurityEventRegulationBan, Time: now.Add(time.Second), Username: "john", RemoteIP: "192.168.1.10", Details: map[string]any{"banned_until": "2026-10-17T08:05:01Z"}})
+	bus.Publish(model.SecurityEvent{Type: schema.SecurityEventAuthenticationFailure, Time: now, Username: "john", RemoteIP: "192.168.1.10", Details: map[string]any{"method": "1FA"}})
+	bus.Publish(model.SecurityEvent{Type: schema.SecurityEventAuthenticationSuccess, Time: now, Username: "fred"})
+	bus.Wait()
+
+	expected := templates.EmailEventValues{
+		Title:      "Security Events Digest",
+		BodyPrefix: "2 security event(s)",
+		BodyEvent:  "occurred",
+		BodySuffix: "between 2026-10-17T08:00:00Z and 2026-10-17T08:00:01Z.",
+		Details: map[string]any{
+			"2026-10-17T08:00:00Z #0001": "authentication.failure by 'john' from 192.168.1.10 (method=1FA)",
+			"2026-10-17T08:00:01Z #0002": "regulation.ban by 'john' from 192.168.1.10 (banned_until=2026-10-17T08:05:01Z)",
+		},
+		RemoteIP: "192.168.1.10",
+	}
+
+	first, second := expected, expected
+
+	first.DisplayName, second.DisplayName = "Admin", "security@example.com"
+
+	gomock.InOrder(
+		notifier.EXPECT().Send(gomock.Any(), mail.Address{Name: "Admin", Address: "admin@example.com"}, "Security Events Digest", et, first).Return(nil),
+		notifier.EXPECT().Send(gomock.Any(), mail.Address{Address: "security@example.com"}, "Security Events Digest", et, second).Return(nil),
+	)
+
+	require.NoError(t, bus.FlushDigest(context.Background()))
+
+	// The buffer is emptied by the flush so nothing is sent.
+	require.NoError(t, bus.FlushDigest(context.Background()))
+}
+
+func TestDigestSubscriber_ShouldDropExcessEvents(t *testing.T) {
+	subscriber := NewDigestSubscriber(nil, nil, nil)
+
+	for i := 0; i < digestMaxEvents+5; i++ {
+		require.NoError(t, subscriber.Handle(context.Background(), model.SecurityEvent{Type: schema.SecurityEventAuthenticationFailure}))
+	}
+
+	data := newDigestValues(subscriber.events, subscriber.dropped)
+
+	assert.Equal(t, "1005 security event(s)", data.BodyPrefix)
+	assert.Contains(t, data.BodySuffix, "Only the first 1000 event(s) are included, 5 event(s) were dropped.")
+	assert.Len(t, data.Details, digestMaxEvents)
+}
+
+type testTemplates struct {
+	event *templates.EmailTemplate
+}
+
+func (t *testTemplates) GetEventEmailTemplate() *templates.EmailTemplate {
+	return t.event
+}
+
+type testBlockingSubscriber struct {
+	started chan struct{}
+	release chan struct{}
+	handled atomic.Int64
+}
+
+func (s *testBlockingSubscriber) Handle(ctx context.Context, event model.SecurityEvent) error {
+	s.started <- struct{}{}
+
+	<-s.release
+
+	s.handled.Add(1)
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package events
+
+import (
+	"time"
+)
+
+const (
+	headerEventID = "X-Authelia-Event-ID"
+
+	contentTypeJSON = "application/json"
+)
+
+const (
+	// digestMaxEvents is the maximum number of events buffered between each digest. Events which exceed this are
+	// counted but otherwise dropped.
+	digestMaxEvents = 1000
+
+	// busQueueSize is the maximum number of deliveries waiting for a worker. Events which exceed this are logged and
+	// dropped so a slow subscriber never blocks the caller.
+	busQueueSize = 1024
+
+	// busWorkers is the number of workers which deliver the queued events to the subscribers.
+	busWorkers = 4
+
+	webhookRetries       = 3
+	webhookRetryInterval = time.Second
+)
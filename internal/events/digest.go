@@ -0,0 +1,158 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/notification"
+	"github.com/authelia/authelia/v4/internal/templates"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// NewDigestSubscriber creates a new *DigestSubscriber.
+func NewDigestSubscriber(recipients []mail.Address, notifier notification.Notifier, templates Templates) (subscriber *DigestSubscriber) {
+	return &DigestSubscriber{
+		recipients: recipients,
+		notifier:   notifier,
+		templates:  templates,
+	}
+}
+
+// DigestSubscriber is a Subscriber which buffers the security events and periodically sends them to the recipients as
+// a single notification.
+type DigestSubscriber struct {
+	recipients []mail.Address
+	notifier   notification.Notifier
+	templates  Templates
+
+	mu      sync.Mutex
+	events  []model.SecurityEvent
+	dropped int
+}
+
+// Handle implements the Subscriber interface by buffering the event until the next digest.
+func (s *DigestSubscriber) Handle(_ context.Context, event model.SecurityEvent) (err error) {
+	s.mu.Lock()
+
+	defer s.mu.Unlock()
+
+	if len(s.events) >= digestMaxEvents {
+		s.dropped++
+
+		return nil
+	}
+
+	s.events = append(s.events, event)
+
+	return nil
+}
+
+// Flush sends the buffered events to each recipient. Nothing is sent when no events have been buffered since the last
+// digest.
+func (s *DigestSubscriber) Flush(ctx context.Context) (err error) {
+	s.mu.Lock()
+
+	events, dropped := s.events, s.dropped
+
+	s.events, s.dropped = nil, 0
+
+	s.mu.Unlock()
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	data := newDigestValues(events, dropped)
+
+	for _, recipient := range s.recipients {
+		data.DisplayName = recipient.Name
+
+		if data.DisplayName == "" {
+			data.DisplayName = recipient.Address
+		}
+
+		if err = s.notifier.Send(ctx, recipient, data.Title, s.templates.GetEventEmailTemplate(), data); err != nil {
+			return fmt.Errorf("error sending security events digest to '%s': %w", recipient.Address, err)
+		}
+	}
+
+	return nil
+}
+
+func newDigestValues(events []model.SecurityEvent, dropped int) (data templates.EmailEventValues) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+
+	data = templates.EmailEventValues{
+		Title:      "Security Events Digest",
+		BodyPrefix: fmt.Sprintf("%d security event(s)", len(events)+dropped),
+		BodyEvent:  "occurred",
+		BodySuffix: fmt.Sprintf("between %s and %s.", events[0].Time.UTC().Format(time.RFC3339), events[len(events)-1].Time.UTC().Format(time.RFC3339)),
+		Details:    make(map[string]any, len(events)),
+	}
+
+	if dropped != 0 {
+		data.BodySuffix += fmt.Sprintf(" Only the first %d event(s) are included, %d event(s) were dropped.", len(events), dropped)
+	}
+
+	var ips []string
+
+	for i, event := range events {
+		// The template sorts the details by key so the keys are prefixed with the time and position of the event.
+		data.Details[fmt.Sprintf("%s #%04d", event.Time.UTC().Format(time.RFC3339), i+1)] = digestEventLine(event)
+
+		if event.RemoteIP != "" && !utils.IsStringInSlice(event.RemoteIP, ips) {
+			ips = append(ips, event.RemoteIP)
+		}
+	}
+
+	data.RemoteIP = strings.Join(ips, ", ")
+
+	return data
+}
+
+func digestEventLine(event model.SecurityEvent) string {
+	buf := &strings.Builder{}
+
+	buf.WriteString(event.Type)
+
+	if event.Username != "" {
+		buf.WriteString(" by '")
+		buf.WriteString(event.Username)
+		buf.WriteString("'")
+	}
+
+	if event.RemoteIP != "" {
+		buf.WriteString(" from ")
+		buf.WriteString(event.RemoteIP)
+	}
+
+	if len(event.Details) != 0 {
+		keys := make([]string, 0, len(event.Details))
+
+		for key := range event.Details {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		pairs := make([]string, len(keys))
+
+		for i, key := range keys {
+			pairs[i] = fmt.Sprintf("%s=%v", key, event.Details[key])
+		}
+
+		buf.WriteString(" (")
+		buf.WriteString(strings.Join(pairs, ", "))
+		buf.WriteString(")")
+	}
+
+	return buf.String()
+}
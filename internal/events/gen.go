@@ -0,0 +1,6 @@
+package events
+
+// This file is used to generate mocks. You can generate all mocks using the
+// command `go generate github.com/authelia/authelia/v4/internal/events`.
+
+//go:generate mockgen -package events -destination notifier_mock_test.go -mock_names Notifier=MockNotifier github.com/authelia/authelia/v4/internal/notification Notifier
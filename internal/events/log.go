@@ -0,0 +1,87 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/authelia/authelia/v4/internal/model"
+)
+
+// NewLogSubscriber creates a new *LogSubscriber.
+func NewLogSubscriber(path string) (subscriber *LogSubscriber) {
+	return &LogSubscriber{path: path}
+}
+
+// LogSubscriber is a Subscriber which appends each security event to a file with one JSON object per line.
+type LogSubscriber struct {
+	path string
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+// StartupCheck implements the model.StartupCheck interface by opening the file so the path is known to be writable.
+func (s *LogSubscriber) StartupCheck() (err error) {
+	s.mu.Lock()
+
+	defer s.mu.Unlock()
+
+	return s.open()
+}
+
+// Handle implements the Subscriber interface by appending the event to the file.
+func (s *LogSubscriber) Handle(_ context.Context, event model.SecurityEvent) (err error) {
+	var data []byte
+
+	if data, err = json.Marshal(event); err != nil {
+		return fmt.Errorf("error marshalling security event: %w", err)
+	}
+
+	data = append(data, '\n')
+
+	s.mu.Lock()
+
+	defer s.mu.Unlock()
+
+	if err = s.open(); err != nil {
+		return err
+	}
+
+	if _, err = s.file.Write(data); err != nil {
+		return fmt.Errorf("error writing security event to file '%s': %w", s.path, err)
+	}
+
+	return nil
+}
+
+// Close the file.
+func (s *LogSubscriber) Close() (err error) {
+	s.mu.Lock()
+
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+
+	err = s.file.Close()
+
+	s.file = nil
+
+	return err
+}
+
+func (s *LogSubscriber) open() (err error) {
+	if s.file != nil {
+		return nil
+	}
+
+	if s.file, err = os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600); err != nil {
+		return fmt.Errorf("error opening security events log file '%s': %w", s.path, err)
+	}
+
+	return nil
+}
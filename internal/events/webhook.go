@@ -0,0 +1,49 @@
+package events
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/notification"
+)
+
+// NewWebhookSubscriber creates a new *WebhookSubscriber.
+func NewWebhookSubscriber(config *schema.SecurityEventsWebhook, certPool *x509.CertPool) (subscriber *WebhookSubscriber) {
+	return &WebhookSubscriber{
+		client: notification.NewWebhookClient(notification.WebhookClientConfig{
+			URL:           config.URL,
+			Timeout:       config.Timeout,
+			Secret:        config.Secret,
+			ContentType:   contentTypeJSON,
+			HeaderID:      headerEventID,
+			Retries:       webhookRetries,
+			RetryInterval: webhookRetryInterval,
+			TLS:           config.TLS,
+		}, logging.Logger().WithFields(map[string]any{"provider": "security_events", "subscriber": "webhook"}), certPool),
+	}
+}
+
+// WebhookSubscriber is a Subscriber which sends each security event to a HTTP endpoint as a JSON object.
+type WebhookSubscriber struct {
+	client *notification.WebhookClient
+}
+
+// Handle implements the Subscriber interface by sending the event to the endpoint.
+func (s *WebhookSubscriber) Handle(ctx context.Context, event model.SecurityEvent) (err error) {
+	var body []byte
+
+	if body, err = json.Marshal(event); err != nil {
+		return fmt.Errorf("error marshalling security event: %w", err)
+	}
+
+	if err = s.client.Send(ctx, event.ID.String(), event.Time, body); err != nil {
+		return fmt.Errorf("error sending security event: %w", err)
+	}
+
+	return nil
+}
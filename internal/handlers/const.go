@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"errors"
+	"time"
 
 	"github.com/valyala/fasthttp"
 )
@@ -15,6 +16,10 @@ const (
 	anonymous = "<anonymous>"
 )
 
+const (
+	knownDeviceSignatureContext = "authelia.known_devices.cookie."
+)
+
 var (
 	headerAuthorization   = []byte(fasthttp.HeaderAuthorization)
 	headerWWWAuthenticate = []byte(fasthttp.HeaderWWWAuthenticate)
@@ -45,6 +50,25 @@ const (
 	queryArgConsentID  = "consent_id"
 	queryArgWorkflow   = "workflow"
 	queryArgWorkflowID = "workflow_id"
+	queryArgMaxAge     = "max_age"
+	queryArgUserCode   = "user_code"
+
+	queryArgSecondFactorMethods = "second_factor_methods"
+)
+
+const (
+	// authzHeadersJWTType is the typ header of the JWT rendered by the authz response header templates which prevents
+	// it being confused with the JWTs issued by the OpenID Connect 1.0 provider.
+	authzHeadersJWTType = "authz+jwt"
+
+	// authzHeadersJWTLifespan is the lifespan of the JWT rendered by the authz response header templates.
+	authzHeadersJWTLifespan = time.Minute
+)
+
+const (
+	// secondFactorMethodWebAuthnHardware is the access control rule second factor method which is only satisfied by
+	// WebAuthn credentials which are roaming hardware authenticators.
+	secondFactorMethodWebAuthnHardware = "webauthn_hardware"
 )
 
 var (
@@ -52,6 +76,7 @@ var (
 	qryArgRD        = []byte(queryArgRD)
 	qryArgAuth      = []byte(queryArgAuth)
 	qryArgConsentID = []byte(queryArgConsentID)
+	qryArgUserCode  = []byte(queryArgUserCode)
 )
 
 var (
@@ -87,6 +112,7 @@ const (
 	logFmtErrSessionReset         = "Could not reset session during %s authentication for user '%s'"
 	logFmtErrSessionSave          = "Could not save session with the %s during %s %s for user '%s'"
 	logFmtErrObtainProfileDetails = "Could not obtain profile details during %s authentication for user '%s'"
+	logFmtErrSessionIndex         = "Could not add the session to the user session index during %s authentication for user '%s'"
 	logFmtTraceProfileDetails     = "Profile details for user '%s' => groups: %s, emails %s"
 )
 
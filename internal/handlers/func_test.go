@@ -7,8 +7,10 @@ import (
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 
 	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/mocks"
 )
 
 func AssertLogEntryMessageAndError(t *testing.T, entry *logrus.Entry, message, err string) {
@@ -33,6 +35,19 @@ func AssertLogEntryMessageAndError(t *testing.T, entry *logrus.Entry, message, e
 	}
 }
 
+// ExpectNoRegulationBans expects the regulator to look up the manual bans any number of times and find none.
+func ExpectNoRegulationBans(mock *mocks.MockAutheliaCtx) {
+	mock.StorageMock.EXPECT().
+		LoadRegulationBans(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil).
+		AnyTimes()
+
+	mock.StorageMock.EXPECT().
+		LoadActiveRegulationBansByKind(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil).
+		AnyTimes()
+}
+
 func MustGetLogLastSeq(t *testing.T, hook *test.Hook, seq int) *logrus.Entry {
 	require.Greater(t, len(hook.Entries), seq)
 
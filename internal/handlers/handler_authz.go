@@ -3,10 +3,14 @@ package handlers
 import (
 	"fmt"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/session"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
@@ -62,7 +66,7 @@ func (authz *Authz) Handler(ctx *middlewares.AutheliaCtx) {
 	authn.Object = object
 	authn.Method = friendlyMethod(authn.Object.Method)
 
-	ruleHasSubject, required := ctx.Providers.Authorizer.GetRequiredLevel(
+	policy := ctx.Providers.Authorizer.GetRequiredPolicy(
 		authorization.Subject{
 			Username: authn.Details.Username,
 			Groups:   authn.Details.Groups,
@@ -72,6 +76,8 @@ func (authz *Authz) Handler(ctx *middlewares.AutheliaCtx) {
 		object,
 	)
 
+	ruleHasSubject, required := policy.HasSubjects, policy.Level
+
 	if err != nil {
 		authn.Object = object
 
@@ -84,6 +90,8 @@ func (authz *Authz) Handler(ctx *middlewares.AutheliaCtx) {
 
 				strategy.HandleUnauthorized(ctx, authn, authz.getRedirectionURL(&object, autheliaURL))
 
+				authz.audit(ctx, authn, policy, model.AuthorizationDecisionRedirect)
+
 				return
 			}
 		}
@@ -95,21 +103,89 @@ func (authz *Authz) Handler(ctx *middlewares.AutheliaCtx) {
 	case AuthzResultForbidden:
 		ctx.Logger.Infof("Access to '%s' is forbidden to user '%s'", object.URL.String(), authn.Username)
 		ctx.ReplyForbidden()
+
+		authz.audit(ctx, authn, policy, model.AuthorizationDecisionDeny)
 	case AuthzResultUnauthorized:
-		var handler HandlerAuthzUnauthorized
+		authz.getUnauthorizedHandler(strategy)(ctx, authn, authz.getRedirectionURLRequirements(&object, autheliaURL, 0, policy.SecondFactorMethods))
+
+		authz.audit(ctx, authn, policy, model.AuthorizationDecisionRedirect)
+	case AuthzResultAuthorized:
+		if isAuthzAuthenticationStale(ctx.Clock.Now(), authn, policy) {
+			ctx.Logger.Infof("Access to '%s' requires user '%s' to authenticate again as they last authenticated at %s which exceeds the maximum authentication age of %s", object.URL.String(), authn.Username, authn.AuthenticatedAt, policy.MaxAuthenticationAge)
+
+			authz.getUnauthorizedHandler(strategy)(ctx, authn, authz.getRedirectionURLRequirements(&object, autheliaURL, policy.MaxAuthenticationAge, policy.SecondFactorMethods))
 
-		if strategy != nil {
-			handler = strategy.HandleUnauthorized
-		} else {
-			handler = authz.handleUnauthorized
+			authz.audit(ctx, authn, policy, model.AuthorizationDecisionRedirect)
+
+			return
+		}
+
+		if isAuthzSecondFactorMethodMissing(authn, policy) {
+			if authn.Type != AuthnTypeCookie {
+				ctx.Logger.Infof("Access to '%s' is forbidden to user '%s' as the second factor methods %s can only be used with a session cookie", object.URL.String(), authn.Username, strings.Join(policy.SecondFactorMethods, ", "))
+
+				ctx.ReplyForbidden()
+
+				authz.audit(ctx, authn, policy, model.AuthorizationDecisionDeny)
+
+				return
+			}
+
+			ctx.Logger.Infof("Access to '%s' requires user '%s' to authenticate with one of the second factor methods %s", object.URL.String(), authn.Username, strings.Join(policy.SecondFactorMethods, ", "))
+
+			authz.getUnauthorizedHandler(strategy)(ctx, authn, authz.getRedirectionURLRequirements(&object, autheliaURL, 0, policy.SecondFactorMethods))
+
+			authz.audit(ctx, authn, policy, model.AuthorizationDecisionRedirect)
+
+			return
 		}
 
-		handler(ctx, authn, authz.getRedirectionURL(&object, autheliaURL))
-	case AuthzResultAuthorized:
 		authz.handleAuthorized(ctx, authn)
+		authz.handleAuthorizedHeaders(ctx, authn, autheliaURL, policy)
+
+		authz.audit(ctx, authn, policy, model.AuthorizationDecisionAllow)
 	}
 }
 
+// audit records the authorization decision to the audit log if it's enabled. It must be called after the response
+// status code is set. The query of the URL is not recorded as it may contain sensitive values such as tokens.
+func (authz *Authz) audit(ctx *middlewares.AutheliaCtx, authn *Authn, policy authorization.RequiredPolicy, decision string) {
+	if ctx.Providers.Audit == nil {
+		return
+	}
+
+	uri := url.URL{Scheme: authn.Object.URL.Scheme, Host: authn.Object.URL.Host, Path: authn.Object.URL.Path, RawPath: authn.Object.URL.RawPath}
+
+	entry := model.AuthorizationDecision{
+		Time:           ctx.Clock.Now(),
+		Decision:       decision,
+		StatusCode:     ctx.Response.StatusCode(),
+		Username:       authn.Username,
+		Groups:         authn.Details.Groups,
+		ClientID:       authn.ClientID,
+		RemoteIP:       model.NewNullIP(ctx.RemoteIP()),
+		RequestMethod:  authn.Object.Method,
+		RequestURI:     uri.String(),
+		Domain:         authn.Object.Domain,
+		RulePosition:   policy.Position,
+		RequiredLevel:  policy.Level.String(),
+		CurrentLevel:   authn.Level.String(),
+		Implementation: authz.implementation.String(),
+	}
+
+	if err := ctx.Providers.Audit.RecordAuthorizationDecision(ctx, entry); err != nil {
+		ctx.Logger.WithError(err).Error("Error occurred recording the authorization decision to the audit log")
+	}
+}
+
+func (authz *Authz) getUnauthorizedHandler(strategy AuthnStrategy) HandlerAuthzUnauthorized {
+	if strategy != nil {
+		return strategy.HandleUnauthorized
+	}
+
+	return authz.handleUnauthorized
+}
+
 func (authz *Authz) getAutheliaURL(ctx *middlewares.AutheliaCtx, provider *session.Session) (autheliaURL *url.URL, err error) {
 	if autheliaURL, err = authz.handleGetAutheliaURL(ctx); err != nil {
 		return nil, err
@@ -158,6 +234,28 @@ func (authz *Authz) getRedirectionURL(object *authorization.Object, autheliaURL
 	return redirectionURL
 }
 
+// getRedirectionURLRequirements returns the redirection URL with the additional requirements of the policy which the
+// portal must enforce, i.e. the maximum authentication age and the acceptable second factor methods.
+func (authz *Authz) getRedirectionURLRequirements(object *authorization.Object, autheliaURL *url.URL, maxAge time.Duration, methods []string) (redirectionURL *url.URL) {
+	if redirectionURL = authz.getRedirectionURL(object, autheliaURL); redirectionURL == nil || (maxAge <= 0 && len(methods) == 0) {
+		return redirectionURL
+	}
+
+	qry := redirectionURL.Query()
+
+	if maxAge > 0 {
+		qry.Set(queryArgMaxAge, strconv.Itoa(int(maxAge.Seconds())))
+	}
+
+	if len(methods) != 0 {
+		qry.Set(queryArgSecondFactorMethods, strings.Join(methods, ","))
+	}
+
+	redirectionURL.RawQuery = qry.Encode()
+
+	return redirectionURL
+}
+
 func (authz *Authz) authn(ctx *middlewares.AutheliaCtx, provider *session.Session, object *authorization.Object) (authn *Authn, strategy AuthnStrategy, err error) {
 	for _, strategy = range authz.strategies {
 		if authn, err = strategy.Get(ctx, provider, object); err != nil {
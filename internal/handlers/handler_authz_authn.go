@@ -135,9 +135,18 @@ func (s *CookieSessionAuthnStrategy) Get(ctx *middlewares.AutheliaCtx, provider
 			DisplayName: userSession.DisplayName,
 			Emails:      userSession.Emails,
 			Groups:      userSession.Groups,
+			GivenName:   userSession.GivenName,
+			FamilyName:  userSession.FamilyName,
+			PhoneNumber: userSession.PhoneNumber,
+			Locale:      userSession.Locale,
+			Picture:     userSession.Picture,
+			Extra:       userSession.Extra,
 		},
 		Level: userSession.AuthenticationLevel,
 		Type:  AuthnTypeCookie,
+
+		AuthenticatedAt:          userSession.LastAuthenticatedTime(),
+		AuthenticationMethodRefs: userSession.AuthenticationMethodRefs,
 	}, nil
 }
 
@@ -244,6 +253,12 @@ func (s *HeaderAuthnStrategy) Get(ctx *middlewares.AutheliaCtx, _ *session.Sessi
 				return authn, err
 			}
 
+			if errors.Is(err, authentication.ErrUserDisabled) {
+				ctx.Logger.WithField("username", username).Error("Error occurred while attempting to get user details for user: the user account is disabled or locked")
+
+				return authn, err
+			}
+
 			return authn, fmt.Errorf("unable to retrieve details for user '%s': %w", username, err)
 		}
 
@@ -355,6 +370,12 @@ func (s *HeaderLegacyAuthnStrategy) Get(ctx *middlewares.AutheliaCtx, _ *session
 			return authn, err
 		}
 
+		if errors.Is(err, authentication.ErrUserDisabled) {
+			ctx.Logger.WithField("username", username).Error("Error occurred while attempting to get user details for user: the user account is disabled or locked")
+
+			return authn, err
+		}
+
 		return authn, fmt.Errorf("unable to retrieve details for user '%s': %w", username, err)
 	}
 
@@ -399,6 +420,10 @@ func handleAuthnCookieValidate(ctx *middlewares.AutheliaCtx, provider *session.S
 		return true
 	}
 
+	if ctx.IsUserSessionRevoked(provider, userSession) {
+		return true
+	}
+
 	if username := ctx.Request.Header.PeekBytes(headerSessionUsername); username != nil && !strings.EqualFold(string(username), userSession.Username) {
 		ctx.Logger.WithField("username", userSession.Username).Warnf("Session for user does not match the Session-Username header with value '%s' which could be a sign of a cookie hijack", username)
 
@@ -447,23 +472,30 @@ func handleSessionValidateRefresh(ctx *middlewares.AutheliaCtx, userSession *ses
 			return true
 		}
 
+		if errors.Is(err, authentication.ErrUserDisabled) {
+			ctx.Logger.WithField("username", userSession.Username).Error("Error occurred while attempting to update user details for user: the user account is disabled or locked")
+
+			return true
+		}
+
 		ctx.Logger.WithError(err).WithField("username", userSession.Username).Error("Error occurred while attempting to update user details for user")
 
 		return false
 	}
 
 	var (
-		diffEmails, diffGroups, diffDisplayName bool
+		diffEmails, diffGroups, diffDisplayName, diffAttributes bool
 	)
 
 	diffEmails, diffGroups = utils.IsStringSlicesDifferent(userSession.Emails, details.Emails), utils.IsStringSlicesDifferent(userSession.Groups, details.Groups)
 	diffDisplayName = userSession.DisplayName != details.DisplayName
+	diffAttributes = isUserSessionAttributesDifferent(userSession, details)
 
 	if !refresh.Always() {
 		userSession.RefreshTTL = ctx.Clock.Now().Add(refresh.Value())
 	}
 
-	if !diffEmails && !diffGroups && !diffDisplayName {
+	if !diffEmails && !diffGroups && !diffDisplayName && !diffAttributes {
 		ctx.Logger.WithField("username", userSession.Username).Trace("Updated profile not detected for user")
 
 		return false
@@ -477,6 +509,8 @@ func handleSessionValidateRefresh(ctx *middlewares.AutheliaCtx, userSession *ses
 
 	userSession.Emails, userSession.Groups, userSession.DisplayName = details.Emails, details.Groups, details.DisplayName
 
+	userSession.SetAttributes(details)
+
 	return false
 }
 
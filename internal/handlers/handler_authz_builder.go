@@ -5,6 +5,7 @@ import (
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/templates"
 )
 
 // NewAuthzBuilder creates a new AuthzBuilder.
@@ -83,6 +84,8 @@ func (b *AuthzBuilder) WithEndpointConfig(config schema.ServerEndpointsAuthz) *A
 
 	b.WithStrategies()
 
+	b.config.Headers = config.Headers
+
 	for _, strategy := range config.AuthnStrategies {
 		switch strategy.Name {
 		case AuthnStrategyCookieSession:
@@ -112,6 +115,12 @@ func (b *AuthzBuilder) Build() (authz *Authz) {
 
 	authz.config.StatusCodeBadRequest = fasthttp.StatusBadRequest
 
+	for _, header := range authz.config.Headers {
+		if t, err := templates.ParseStringTemplate(header.Name, header.Value); err == nil {
+			authz.headers = append(authz.headers, AuthzHeader{Name: header.Name, Template: t})
+		}
+	}
+
 	if len(authz.strategies) == 0 {
 		switch b.implementation {
 		case AuthzImplLegacy:
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"authelia.com/provider/oauth2/token/jwt"
+	"github.com/google/uuid"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/oidc"
+)
+
+// AuthzHeadersTemplateData is the data available to the response header templates of the authz endpoints and the
+// access control rules.
+type AuthzHeadersTemplateData struct {
+	authentication.UserDetails
+
+	// Email is the primary email address of the user.
+	Email string
+
+	// ClientID is the id of the OAuth 2.0 client when the request was authorized with a bearer token.
+	ClientID string
+
+	// Level is the authentication level of the user which is either one_factor or two_factor.
+	Level string
+
+	// AuthenticationMethods are the RFC8176 authentication method references of the user. It's only set for session
+	// cookies.
+	AuthenticationMethods []string
+
+	// AuthenticatedAt is the time the user most recently authenticated. It's only set for session cookies.
+	AuthenticatedAt time.Time
+
+	// URL, Domain, and Method describe the request which was authorized.
+	URL    string
+	Domain string
+	Method string
+
+	ctx    *middlewares.AutheliaCtx
+	issuer *url.URL
+	origin string
+}
+
+// JWT returns a JWT which asserts the identity of the user signed with the default key of the OpenID Connect 1.0
+// provider. The audience is the origin of the authorized request.
+func (d AuthzHeadersTemplateData) JWT() (token string, err error) {
+	if d.ctx.Providers.OpenIDConnect == nil || d.ctx.Providers.OpenIDConnect.KeyManager == nil {
+		return "", errors.New("the OpenID Connect 1.0 provider must be configured to sign the JWT")
+	}
+
+	if d.issuer == nil {
+		return "", errors.New("the Authelia URL must be known to sign the JWT")
+	}
+
+	var jti uuid.UUID
+
+	if jti, err = uuid.NewRandom(); err != nil {
+		return "", fmt.Errorf("failed to generate the jti: %w", err)
+	}
+
+	now := d.ctx.Clock.Now().UTC()
+
+	claims := jwt.MapClaims{
+		oidc.ClaimJWTID:                          jti.String(),
+		oidc.ClaimIssuer:                         d.issuer.String(),
+		oidc.ClaimSubject:                        d.Username,
+		oidc.ClaimAudience:                       []string{d.origin},
+		oidc.ClaimIssuedAt:                       now.Unix(),
+		oidc.ClaimExpirationTime:                 now.Add(authzHeadersJWTLifespan).Unix(),
+		oidc.ClaimPreferredUsername:              d.Username,
+		oidc.ClaimFullName:                       d.DisplayName,
+		oidc.ClaimGroups:                         d.Groups,
+		oidc.ClaimAuthenticationMethodsReference: d.AuthenticationMethods,
+	}
+
+	if d.Email != "" {
+		claims[oidc.ClaimPreferredEmail] = d.Email
+	}
+
+	if !d.AuthenticatedAt.IsZero() {
+		claims[oidc.ClaimAuthenticationTime] = d.AuthenticatedAt.Unix()
+	}
+
+	keys := d.ctx.Providers.OpenIDConnect.KeyManager
+
+	var jwk *oidc.JWK
+
+	if jwk = keys.GetByKID(d.ctx, keys.GetDefaultKeyID(d.ctx)); jwk == nil {
+		return "", errors.New("the default key of the OpenID Connect 1.0 provider could not be found")
+	}
+
+	headers := &jwt.Headers{
+		Extra: map[string]any{
+			oidc.JWTHeaderKeyIdentifier: jwk.KeyID(),
+			oidc.JWTHeaderKeyType:       authzHeadersJWTType,
+		},
+	}
+
+	if token, _, err = jwk.Strategy().Generate(d.ctx, claims, headers); err != nil {
+		return "", fmt.Errorf("failed to sign the JWT: %w", err)
+	}
+
+	return token, nil
+}
+
+func newAuthzHeadersTemplateData(ctx *middlewares.AutheliaCtx, authn *Authn, autheliaURL *url.URL) (data AuthzHeadersTemplateData) {
+	data = AuthzHeadersTemplateData{
+		UserDetails:           authn.Details,
+		ClientID:              authn.ClientID,
+		Level:                 authn.Level.String(),
+		AuthenticationMethods: authn.AuthenticationMethodRefs.MarshalRFC8176(),
+		AuthenticatedAt:       authn.AuthenticatedAt,
+		Domain:                authn.Object.Domain,
+		Method:                authn.Object.Method,
+
+		ctx:    ctx,
+		issuer: autheliaURL,
+	}
+
+	if len(authn.Details.Emails) != 0 {
+		data.Email = authn.Details.Emails[0]
+	}
+
+	if authn.Object.URL != nil {
+		data.URL = authn.Object.URL.String()
+		data.origin = (&url.URL{Scheme: authn.Object.URL.Scheme, Host: authn.Object.URL.Host}).String()
+	}
+
+	return data
+}
+
+// handleAuthorizedHeaders renders the response headers of the endpoint followed by the response headers of the access
+// control rule which authorized the request. The headers replace any header with the same name, and headers which
+// fail to render or render as an empty value are omitted.
+func (authz *Authz) handleAuthorizedHeaders(ctx *middlewares.AutheliaCtx, authn *Authn, autheliaURL *url.URL, policy authorization.RequiredPolicy) {
+	if authn.Details.Username == "" || (len(authz.headers) == 0 && len(policy.ResponseHeaders) == 0) {
+		return
+	}
+
+	data := newAuthzHeadersTemplateData(ctx, authn, autheliaURL)
+
+	buf := &bytes.Buffer{}
+
+	for _, header := range authz.headers {
+		setAuthzTemplatedHeader(ctx, buf, header.Name, header.Template, data)
+	}
+
+	for _, header := range policy.ResponseHeaders {
+		setAuthzTemplatedHeader(ctx, buf, header.Name, header.Template, data)
+	}
+}
+
+func setAuthzTemplatedHeader(ctx *middlewares.AutheliaCtx, buf *bytes.Buffer, name string, t *template.Template, data AuthzHeadersTemplateData) {
+	buf.Reset()
+
+	if err := t.Execute(buf, data); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred rendering the template of the response header '%s'", name)
+
+		return
+	}
+
+	value := buf.String()
+
+	switch {
+	case value == "":
+		return
+	case strings.ContainsAny(value, "\r\n"):
+		ctx.Logger.Errorf("Error occurred rendering the template of the response header '%s': the value must not contain line breaks", name)
+
+		return
+	}
+
+	ctx.Response.Header.Set(name, value)
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/oidc"
+)
+
+func TestAuthzHandleAuthorizedHeaders(t *testing.T) {
+	authz := NewAuthzBuilder().WithEndpointConfig(schema.ServerEndpointsAuthz{
+		Implementation: AuthzImplForwardAuth.String(),
+		Headers: []schema.ServerEndpointsAuthzHeader{
+			{Name: "X-Forwarded-User", Value: "{{ .Username }}"},
+			{Name: "Remote-Groups", Value: `{{ join "|" .Groups }}`},
+			{Name: "X-Auth-Level", Value: "{{ .Level }}"},
+			{Name: "X-Auth-Methods", Value: `{{ join "," .AuthenticationMethods }}`},
+			{Name: "X-Phone", Value: "{{ .PhoneNumber }}"},
+			{Name: "Remote-Given-Name", Value: "{{ .GivenName }}"},
+			{Name: "Remote-Department", Value: `{{ with index .Extra "department" }}{{ . }}{{ end }}`},
+			{Name: "Remote-Roles", Value: `{{ with index .Extra "roles" }}{{ join "," . }}{{ end }}`},
+			{Name: "Remote-Cost-Center", Value: `{{ with index .Extra "cost_center" }}{{ . }}{{ end }}`},
+			{Name: "X-Multiline", Value: "{{ .DisplayName }}\n"},
+			{Name: "X-JWT", Value: "{{ .JWT }}"},
+		},
+	}).Build()
+
+	policy := authorization.NewAccessControlRules(schema.AccessControl{
+		Rules: []schema.AccessControlRule{
+			{
+				Policy: "two_factor",
+				ResponseHeaders: []schema.AccessControlRuleResponseHeader{
+					{Name: "X-Emails", Value: `{{ join "," .Emails }}`},
+					{Name: "X-Auth-Level", Value: "{{ .Level }}@{{ .Domain }}"},
+				},
+			},
+		},
+	})[0]
+
+	object := authorization.NewObject(&url.URL{Scheme: "https", Host: "app.example.com", Path: "/"}, fasthttp.MethodGet)
+
+	testCases := []struct {
+		name     string
+		authn    *Authn
+		expected map[string]string
+	}{
+		{
+			"ShouldRenderHeaders",
+			&Authn{
+				Details: authentication.UserDetails{
+					Username:    "john",
+					DisplayName: "John Smith",
+					Emails:      []string{"john@example.com", "js@example.com"},
+					Groups:      []string{"admins", "dev"},
+					GivenName:   "John",
+					Extra: map[string]any{
+						"department": "Engineering",
+						"roles":      []any{"admin", "dev"},
+					},
+				},
+				Level:                    authentication.TwoFactor,
+				Object:                   object,
+				AuthenticatedAt:          time.Unix(1700000000, 0),
+				AuthenticationMethodRefs: oidc.AuthenticationMethodsReferences{UsernameAndPassword: true, TOTP: true},
+			},
+			map[string]string{
+				"X-Forwarded-User":   "john",
+				"Remote-Groups":      "admins|dev",
+				"Remote-User":        "john",
+				"X-Auth-Level":       "two_factor@app.example.com",
+				"X-Auth-Methods":     "pwd,otp,mfa",
+				"X-Emails":           "john@example.com,js@example.com",
+				"X-Phone":            "",
+				"Remote-Given-Name":  "John",
+				"Remote-Department":  "Engineering",
+				"Remote-Roles":       "admin,dev",
+				"Remote-Cost-Center": "",
+				"X-Multiline":        "",
+				"X-JWT":              "",
+			},
+		},
+		{
+			"ShouldNotRenderHeadersAnonymous",
+			&Authn{
+				Object: object,
+			},
+			map[string]string{
+				"X-Forwarded-User": "",
+				"Remote-Groups":    "",
+				"X-Emails":         "",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			mock.Ctx.Providers.OpenIDConnect = nil
+
+			authz.handleAuthorized(mock.Ctx, tc.authn)
+			authz.handleAuthorizedHeaders(mock.Ctx, tc.authn, &url.URL{Scheme: "https", Host: "auth.example.com"}, authorization.RequiredPolicy{Position: 1, Level: authorization.TwoFactor, ResponseHeaders: policy.ResponseHeaders})
+
+			assert.Equal(t, fasthttp.StatusOK, mock.Ctx.Response.StatusCode())
+
+			for name, value := range tc.expected {
+				assert.Equal(t, value, string(mock.Ctx.Response.Header.Peek(name)), name)
+			}
+		})
+	}
+}
+
+func TestNewAuthzHeadersTemplateData(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+
+	defer mock.Close()
+
+	data := newAuthzHeadersTemplateData(mock.Ctx, &Authn{
+		Details:  authentication.UserDetails{Username: "john", Emails: []string{"john@example.com"}},
+		ClientID: "app",
+		Level:    authentication.OneFactor,
+		Object:   authorization.NewObject(&url.URL{Scheme: "https", Host: "app.example.com:8443", Path: "/admin"}, fasthttp.MethodPost),
+	}, nil)
+
+	assert.Equal(t, "john", data.Username)
+	assert.Equal(t, "john@example.com", data.Email)
+	assert.Equal(t, "app", data.ClientID)
+	assert.Equal(t, "one_factor", data.Level)
+	assert.Equal(t, "https://app.example.com:8443/admin", data.URL)
+	assert.Equal(t, "app.example.com", data.Domain)
+	assert.Equal(t, fasthttp.MethodPost, data.Method)
+	assert.Equal(t, "https://app.example.com:8443", data.origin)
+
+	mock.Ctx.Providers.OpenIDConnect = nil
+
+	_, err := data.JWT()
+	require.EqualError(t, err, "the OpenID Connect 1.0 provider must be configured to sign the JWT")
+}
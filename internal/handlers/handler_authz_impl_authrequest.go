@@ -33,7 +33,10 @@ func handleAuthzGetObjectAuthRequest(ctx *middlewares.AutheliaCtx) (object autho
 		return object, fmt.Errorf("header 'X-Original-Method' with value '%s' has invalid characters", method)
 	}
 
-	return authorization.NewObjectRaw(targetURL, method), nil
+	object = authorization.NewObjectRaw(targetURL, method)
+	object.Headers = &ctx.Request.Header
+
+	return object, nil
 }
 
 func handleAuthzUnauthorizedAuthRequest(ctx *middlewares.AutheliaCtx, authn *Authn, redirectionURL *url.URL) {
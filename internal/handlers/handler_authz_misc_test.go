@@ -8,6 +8,7 @@ import (
 
 	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/oidc"
 	"github.com/authelia/authelia/v4/internal/session"
 )
 
@@ -16,6 +17,30 @@ func TestAuthzImplementation(t *testing.T) {
 	assert.Equal(t, "", AuthzImplementation(-1).String())
 }
 
+func TestHasSecondFactorMethod(t *testing.T) {
+	testCases := []struct {
+		name     string
+		amr      oidc.AuthenticationMethodsReferences
+		methods  []string
+		expected bool
+	}{
+		{"ShouldMatchTOTP", oidc.AuthenticationMethodsReferences{TOTP: true}, []string{"webauthn", "totp"}, true},
+		{"ShouldMatchDuo", oidc.AuthenticationMethodsReferences{Duo: true}, []string{"mobile_push"}, true},
+		{"ShouldMatchWebAuthnSoftware", oidc.AuthenticationMethodsReferences{WebAuthn: true, WebAuthnSoftware: true}, []string{"webauthn"}, true},
+		{"ShouldMatchWebAuthnHardware", oidc.AuthenticationMethodsReferences{WebAuthn: true, WebAuthnHardware: true}, []string{"webauthn_hardware"}, true},
+		{"ShouldNotMatchWebAuthnSoftware", oidc.AuthenticationMethodsReferences{WebAuthn: true, WebAuthnSoftware: true}, []string{"webauthn_hardware"}, false},
+		{"ShouldNotMatchTOTP", oidc.AuthenticationMethodsReferences{UsernameAndPassword: true, TOTP: true}, []string{"webauthn", "mobile_push"}, false},
+		{"ShouldNotMatchUnknown", oidc.AuthenticationMethodsReferences{TOTP: true}, []string{"sms"}, false},
+		{"ShouldNotMatchEmpty", oidc.AuthenticationMethodsReferences{TOTP: true}, nil, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, hasSecondFactorMethod(tc.amr, tc.methods))
+		})
+	}
+}
+
 func TestFriendlyMethod(t *testing.T) {
 	assert.Equal(t, "unknown", friendlyMethod(""))
 	assert.Equal(t, "GET", friendlyMethod(fasthttp.MethodGet))
@@ -29,3 +54,26 @@ func TestGenerateVerifySessionHasUpToDateProfileTraceLogs(t *testing.T) {
 	generateVerifySessionHasUpToDateProfileTraceLogs(mock.Ctx, &session.UserSession{Username: "john", DisplayName: "example", Emails: []string{"abc@example.com"}}, &authentication.UserDetails{Username: "john", DisplayName: "example"})
 	generateVerifySessionHasUpToDateProfileTraceLogs(mock.Ctx, &session.UserSession{Username: "john", DisplayName: "example"}, &authentication.UserDetails{Username: "john", DisplayName: "example", Emails: []string{"abc@example.com"}})
 }
+
+func TestIsUserSessionAttributesDifferent(t *testing.T) {
+	testCases := []struct {
+		name     string
+		session  *session.UserSession
+		details  *authentication.UserDetails
+		expected bool
+	}{
+		{"ShouldNotBeDifferentWhenEmpty", &session.UserSession{}, &authentication.UserDetails{}, false},
+		{"ShouldNotBeDifferentWhenEqual", &session.UserSession{GivenName: "John", Extra: map[string]any{"roles": []any{"admin"}, "id": float64(1)}}, &authentication.UserDetails{GivenName: "John", Extra: map[string]any{"roles": []string{"admin"}, "id": 1}}, false},
+		{"ShouldBeDifferentGivenName", &session.UserSession{GivenName: "John"}, &authentication.UserDetails{GivenName: "Jane"}, true},
+		{"ShouldBeDifferentLocale", &session.UserSession{}, &authentication.UserDetails{Locale: "en-US"}, true},
+		{"ShouldBeDifferentExtraValue", &session.UserSession{Extra: map[string]any{"department": "Sales"}}, &authentication.UserDetails{Extra: map[string]any{"department": "Engineering"}}, true},
+		{"ShouldBeDifferentExtraName", &session.UserSession{Extra: map[string]any{"team": "Engineering"}}, &authentication.UserDetails{Extra: map[string]any{"department": "Engineering"}}, true},
+		{"ShouldBeDifferentExtraLength", &session.UserSession{}, &authentication.UserDetails{Extra: map[string]any{"department": "Engineering"}}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isUserSessionAttributesDifferent(tc.session, tc.details))
+		})
+	}
+}
@@ -1,21 +1,25 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/valyala/fasthttp"
 	"go.uber.org/mock/gomock"
 
 	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/mocks"
 	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/oidc"
 	"github.com/authelia/authelia/v4/internal/session"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
@@ -877,6 +881,242 @@ func (s *AuthzSuite) TestShouldDestroySessionWhenInactiveForTooLong() {
 	s.Equal(mock.Clock.Now().Unix(), userSession.LastActivity)
 }
 
+func (s *AuthzSuite) TestShouldRequireAuthenticationWhenMaxAuthenticationAgeExceeded() {
+	if s.setRequest == nil {
+		s.T().Skip()
+	}
+
+	testCases := []struct {
+		name     string
+		age      time.Duration
+		expected bool
+	}{
+		{"ShouldAllowFresh", time.Minute * 30, true},
+		{"ShouldRedirectStale", time.Hour * 2, false},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			authz := s.Builder().WithStrategies(
+				NewCookieSessionAuthnStrategy(schema.NewRefreshIntervalDurationNever()),
+			).Build()
+
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			mock.Ctx.Clock = &mock.Clock
+
+			mock.Clock.Set(time.Now())
+
+			mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&schema.Configuration{
+				AccessControl: schema.AccessControl{
+					DefaultPolicy: "deny",
+					Rules: []schema.AccessControlRule{
+						{
+							Domains:              []string{"two-factor.example.com"},
+							Policy:               "two_factor",
+							MaxAuthenticationAge: time.Hour,
+						},
+					},
+				},
+			}, &mock.Clock)
+
+			s.ConfigureMockSessionProviderWithAutomaticAutheliaURLs(mock)
+
+			targetURI := s.RequireParseRequestURI("https://two-factor.example.com")
+
+			s.setRequest(mock.Ctx, fasthttp.MethodGet, targetURI, true, false)
+
+			userSession, err := mock.Ctx.GetSession()
+			require.NoError(t, err)
+
+			userSession.Username = testUsername
+			userSession.AuthenticationLevel = authentication.TwoFactor
+			userSession.LastActivity = mock.Clock.Now().Unix()
+			userSession.FirstFactorAuthnTimestamp = mock.Clock.Now().Add(-tc.age).Unix()
+			userSession.SecondFactorAuthnTimestamp = mock.Clock.Now().Add(-tc.age).Unix()
+
+			require.NoError(t, mock.Ctx.SaveSession(userSession))
+
+			authz.Handler(mock.Ctx)
+
+			if tc.expected {
+				assert.Equal(t, fasthttp.StatusOK, mock.Ctx.Response.StatusCode())
+
+				return
+			}
+
+			assert.NotEqual(t, fasthttp.StatusOK, mock.Ctx.Response.StatusCode())
+
+			if location := mock.Ctx.Response.Header.Peek(fasthttp.HeaderLocation); len(location) != 0 {
+				redirection, err := url.ParseRequestURI(string(location))
+				require.NoError(t, err)
+
+				assert.Equal(t, "3600", redirection.Query().Get(queryArgMaxAge))
+				assert.Equal(t, targetURI.String(), redirection.Query().Get(queryArgRD))
+			}
+
+			userSession, err = mock.Ctx.GetSession()
+			require.NoError(t, err)
+
+			assert.Equal(t, authentication.TwoFactor, userSession.AuthenticationLevel)
+		})
+	}
+}
+
+func (s *AuthzSuite) TestShouldRequireAuthenticationWhenSecondFactorMethodMissing() {
+	if s.setRequest == nil {
+		s.T().Skip()
+	}
+
+	testCases := []struct {
+		name     string
+		amr      oidc.AuthenticationMethodsReferences
+		expected bool
+	}{
+		{"ShouldAllowHardwareKey", oidc.AuthenticationMethodsReferences{UsernameAndPassword: true, WebAuthn: true, WebAuthnHardware: true}, true},
+		{"ShouldRedirectSoftwareKey", oidc.AuthenticationMethodsReferences{UsernameAndPassword: true, WebAuthn: true, WebAuthnSoftware: true}, false},
+		{"ShouldRedirectTOTP", oidc.AuthenticationMethodsReferences{UsernameAndPassword: true, TOTP: true}, false},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			authz := s.Builder().WithStrategies(
+				NewCookieSessionAuthnStrategy(schema.NewRefreshIntervalDurationNever()),
+			).Build()
+
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			mock.Ctx.Clock = &mock.Clock
+
+			mock.Clock.Set(time.Now())
+
+			mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&schema.Configuration{
+				AccessControl: schema.AccessControl{
+					DefaultPolicy: "deny",
+					Rules: []schema.AccessControlRule{
+						{
+							Domains:             []string{"two-factor.example.com"},
+							Policy:              "two_factor",
+							SecondFactorMethods: []string{secondFactorMethodWebAuthnHardware},
+						},
+					},
+				},
+			}, &mock.Clock)
+
+			s.ConfigureMockSessionProviderWithAutomaticAutheliaURLs(mock)
+
+			targetURI := s.RequireParseRequestURI("https://two-factor.example.com")
+
+			s.setRequest(mock.Ctx, fasthttp.MethodGet, targetURI, true, false)
+
+			userSession, err := mock.Ctx.GetSession()
+			require.NoError(t, err)
+
+			userSession.Username = testUsername
+			userSession.AuthenticationLevel = authentication.TwoFactor
+			userSession.AuthenticationMethodRefs = tc.amr
+			userSession.LastActivity = mock.Clock.Now().Unix()
+
+			require.NoError(t, mock.Ctx.SaveSession(userSession))
+
+			authz.Handler(mock.Ctx)
+
+			if tc.expected {
+				assert.Equal(t, fasthttp.StatusOK, mock.Ctx.Response.StatusCode())
+
+				return
+			}
+
+			assert.NotEqual(t, fasthttp.StatusOK, mock.Ctx.Response.StatusCode())
+
+			if location := mock.Ctx.Response.Header.Peek(fasthttp.HeaderLocation); len(location) != 0 {
+				redirection, err := url.ParseRequestURI(string(location))
+				require.NoError(t, err)
+
+				assert.Equal(t, secondFactorMethodWebAuthnHardware, redirection.Query().Get(queryArgSecondFactorMethods))
+				assert.Equal(t, targetURI.String(), redirection.Query().Get(queryArgRD))
+			}
+		})
+	}
+}
+
+func (s *AuthzSuite) TestShouldRecordAuthorizationDecisions() {
+	if s.setRequest == nil {
+		s.T().Skip()
+	}
+
+	testCases := []struct {
+		name     string
+		uri      string
+		expected string
+		decision string
+		position int
+		required string
+	}{
+		{"ShouldRecordAllow", "https://bypass.example.com", "https://bypass.example.com", model.AuthorizationDecisionAllow, 1, "bypass"},
+		{"ShouldRecordAllowWithoutQuery", "https://bypass.example.com/path/?token=abc", "https://bypass.example.com/path/", model.AuthorizationDecisionAllow, 1, "bypass"},
+		{"ShouldRecordDeny", "https://deny.example.com", "https://deny.example.com", model.AuthorizationDecisionDeny, 2, "deny"},
+		{"ShouldRecordRedirect", "https://one-factor.example.com?code=abc", "https://one-factor.example.com", model.AuthorizationDecisionRedirect, 0, "one_factor"},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			authz := s.Builder().Build()
+
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&schema.Configuration{
+				AccessControl: schema.AccessControl{
+					DefaultPolicy: "one_factor",
+					Rules: []schema.AccessControlRule{
+						{Domains: []string{"bypass.example.com"}, Policy: "bypass"},
+						{Domains: []string{"deny.example.com"}, Policy: "deny"},
+					},
+				},
+			}, &mock.Clock)
+
+			auditMock := mocks.NewMockAudit(mock.Ctrl)
+
+			mock.Ctx.Providers.Audit = auditMock
+
+			s.ConfigureMockSessionProviderWithAutomaticAutheliaURLs(mock)
+
+			targetURI := s.RequireParseRequestURI(tc.uri)
+
+			s.setRequest(mock.Ctx, fasthttp.MethodGet, targetURI, true, false)
+
+			var actual model.AuthorizationDecision
+
+			auditMock.EXPECT().
+				RecordAuthorizationDecision(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, decision model.AuthorizationDecision) error {
+					actual = decision
+
+					return nil
+				})
+
+			authz.Handler(mock.Ctx)
+
+			assert.Equal(t, tc.decision, actual.Decision)
+			assert.Equal(t, mock.Ctx.Response.StatusCode(), actual.StatusCode)
+			assert.Equal(t, tc.position, actual.RulePosition)
+			assert.Equal(t, tc.required, actual.RequiredLevel)
+			assert.Equal(t, "not_authenticated", actual.CurrentLevel)
+			assert.Equal(t, targetURI.Hostname(), actual.Domain)
+			assert.Equal(t, tc.expected, actual.RequestURI)
+			assert.Equal(t, fasthttp.MethodGet, actual.RequestMethod)
+			assert.Equal(t, s.implementation.String(), actual.Implementation)
+			assert.Equal(t, "", actual.Username)
+		})
+	}
+}
+
 func (s *AuthzSuite) TestShouldNotDestroySessionWhenInactiveForTooLongRememberMe() {
 	if s.setRequest == nil {
 		s.T().Skip()
@@ -1210,6 +1450,94 @@ func (s *AuthzSuite) TestShouldDestroySessionWhenUserDoesNotExist() {
 	s.True(userSession.IsAnonymous())
 }
 
+func (s *AuthzSuite) TestShouldDestroySessionWhenUserIsDisabled() {
+	if s.setRequest == nil {
+		s.T().Skip()
+	}
+
+	builder := s.Builder()
+
+	builder = builder.WithStrategies(
+		NewCookieSessionAuthnStrategy(schema.NewRefreshIntervalDuration(5 * time.Minute)),
+	)
+
+	authz := builder.Build()
+
+	mock := mocks.NewMockAutheliaCtx(s.T())
+
+	defer mock.Close()
+
+	mock.Ctx.Clock = &mock.Clock
+
+	mock.Clock.Set(time.Now())
+
+	mock.Ctx.Configuration.Session.Cookies[0].Inactivity = testInactivity
+
+	s.ConfigureMockSessionProviderWithAutomaticAutheliaURLs(mock)
+
+	targetURI := s.RequireParseRequestURI("https://two-factor.example.com")
+
+	s.setRequest(mock.Ctx, fasthttp.MethodGet, targetURI, true, false)
+
+	user := &authentication.UserDetails{
+		Username: "john",
+		Groups: []string{
+			"admin",
+			"users",
+		},
+		Emails: []string{
+			"john@example.com",
+		},
+	}
+
+	userSession, err := mock.Ctx.GetSession()
+	s.Require().NoError(err)
+
+	userSession.Username = user.Username
+	userSession.AuthenticationLevel = authentication.TwoFactor
+	userSession.LastActivity = mock.Clock.Now().Unix()
+	userSession.RefreshTTL = mock.Clock.Now().Add(-1 * time.Minute)
+	userSession.Groups = user.Groups
+	userSession.Emails = user.Emails
+	userSession.KeepMeLoggedIn = true
+
+	s.Require().NoError(mock.Ctx.SaveSession(userSession))
+
+	gomock.InOrder(
+		mock.UserProviderMock.EXPECT().GetDetails("john").Return(user, nil).Times(1),
+		mock.UserProviderMock.EXPECT().GetDetails("john").Return(nil, authentication.ErrUserDisabled).Times(1),
+	)
+
+	authz.Handler(mock.Ctx)
+
+	s.Equal(fasthttp.StatusOK, mock.Ctx.Response.StatusCode())
+
+	userSession, err = mock.Ctx.GetSession()
+	s.Require().NoError(err)
+
+	s.Equal(mock.Clock.Now().Add(5*time.Minute).Unix(), userSession.RefreshTTL.Unix())
+
+	userSession.RefreshTTL = mock.Clock.Now().Add(-1 * time.Minute)
+
+	s.Require().NoError(mock.Ctx.SaveSession(userSession))
+
+	authz.Handler(mock.Ctx)
+
+	switch s.implementation {
+	case AuthzImplAuthRequest, AuthzImplLegacy:
+		s.Equal(fasthttp.StatusUnauthorized, mock.Ctx.Response.StatusCode())
+	default:
+		s.Equal(fasthttp.StatusFound, mock.Ctx.Response.StatusCode())
+	}
+
+	userSession, err = mock.Ctx.GetSession()
+	s.Require().NoError(err)
+
+	s.Equal("", userSession.Username)
+	s.Equal(authentication.NotAuthenticated, userSession.AuthenticationLevel)
+	s.True(userSession.IsAnonymous())
+}
+
 func (s *AuthzSuite) TestShouldUpdateRemovedUserGroupsFromBackendAndDeny() {
 	if s.setRequest == nil {
 		s.T().Skip()
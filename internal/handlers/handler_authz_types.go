@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"net/url"
+	"text/template"
+	"time"
 
 	oauthelia2 "authelia.com/provider/oauth2"
 
@@ -30,6 +32,9 @@ type Authz struct {
 	handleAuthorized   HandlerAuthzAuthorized
 	handleUnauthorized HandlerAuthzUnauthorized
 
+	// headers are the response headers rendered from templates for this endpoint.
+	headers []AuthzHeader
+
 	implementation AuthzImplementation
 }
 
@@ -79,6 +84,12 @@ type Authn struct {
 	Object  authorization.Object
 	Type    AuthnType
 
+	// AuthenticatedAt is the time the most recent factor was authenticated. It's only set for session cookies.
+	AuthenticatedAt time.Time
+
+	// AuthenticationMethodRefs are the methods used to authenticate. It's only set for session cookies.
+	AuthenticationMethodRefs oidc.AuthenticationMethodsReferences
+
 	Header HeaderAuthorization
 }
 
@@ -89,10 +100,19 @@ type HeaderAuthorization struct {
 	Error         *oauthelia2.RFC6749Error
 }
 
+// AuthzHeader is a response header of an authz endpoint which is rendered from a template when a request is authorized.
+type AuthzHeader struct {
+	Name     string
+	Template *template.Template
+}
+
 // AuthzConfig represents the configuration elements of the Authz type.
 type AuthzConfig struct {
 	RefreshInterval schema.RefreshIntervalDuration
 
+	// Headers are the additional response headers rendered from templates. It's set by the builder.
+	Headers []schema.ServerEndpointsAuthzHeader
+
 	// StatusCodeBadRequest is sent for configuration issues prior to performing authorization checks. It's set by the
 	// builder.
 	StatusCodeBadRequest int
@@ -1,9 +1,14 @@
 package handlers
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/oidc"
 	"github.com/authelia/authelia/v4/internal/session"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
@@ -43,6 +48,58 @@ func isAuthzResult(level authentication.Level, required authorization.Level, rul
 	}
 }
 
+// isAuthzAuthenticationStale returns true if the authorized request must authenticate again due to the maximum
+// authentication age of the policy. This only applies to session cookies as other methods are either authenticated on
+// every request or are not able to authenticate again via the portal.
+func isAuthzAuthenticationStale(now time.Time, authn *Authn, policy authorization.RequiredPolicy) bool {
+	if policy.MaxAuthenticationAge <= 0 || authn.Type != AuthnTypeCookie || authn.Level == authentication.NotAuthenticated {
+		return false
+	}
+
+	switch policy.Level {
+	case authorization.OneFactor, authorization.TwoFactor:
+		return now.Sub(authn.AuthenticatedAt) > policy.MaxAuthenticationAge
+	default:
+		return false
+	}
+}
+
+// isAuthzSecondFactorMethodMissing returns true if the authorized request must authenticate again with one of the
+// second factor methods required by the policy.
+func isAuthzSecondFactorMethodMissing(authn *Authn, policy authorization.RequiredPolicy) bool {
+	if len(policy.SecondFactorMethods) == 0 || policy.Level != authorization.TwoFactor {
+		return false
+	}
+
+	return !hasSecondFactorMethod(authn.AuthenticationMethodRefs, policy.SecondFactorMethods)
+}
+
+// hasSecondFactorMethod returns true if any of the second factor methods were used to authenticate.
+func hasSecondFactorMethod(amr oidc.AuthenticationMethodsReferences, methods []string) bool {
+	for _, method := range methods {
+		switch method {
+		case model.SecondFactorMethodTOTP:
+			if amr.TOTP {
+				return true
+			}
+		case model.SecondFactorMethodWebAuthn:
+			if amr.WebAuthn {
+				return true
+			}
+		case secondFactorMethodWebAuthnHardware:
+			if amr.WebAuthnHardware {
+				return true
+			}
+		case model.SecondFactorMethodDuo:
+			if amr.Duo {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 // generateVerifySessionHasUpToDateProfileTraceLogs is used to generate trace logs only when trace logging is enabled.
 // The information calculated in this function is completely useless other than trace for now.
 func generateVerifySessionHasUpToDateProfileTraceLogs(ctx *middlewares.AutheliaCtx, userSession *session.UserSession,
@@ -103,3 +160,22 @@ func generateVerifySessionHasUpToDateProfileTraceLogs(ctx *middlewares.AutheliaC
 		ctx.Logger.Trace("User session display name is current")
 	}
 }
+
+// isUserSessionAttributesDifferent returns true if the optional profile attributes or extra attributes of the session
+// differ from the user details. The extra attributes are compared by their formatted value as the session storage does
+// not retain the exact types of the values.
+func isUserSessionAttributesDifferent(userSession *session.UserSession, details *authentication.UserDetails) bool {
+	if userSession.GivenName != details.GivenName || userSession.FamilyName != details.FamilyName ||
+		userSession.PhoneNumber != details.PhoneNumber || userSession.Locale != details.Locale ||
+		userSession.Picture != details.Picture || len(userSession.Extra) != len(details.Extra) {
+		return true
+	}
+
+	for name, value := range details.Extra {
+		if current, ok := userSession.Extra[name]; !ok || fmt.Sprint(current) != fmt.Sprint(value) {
+			return true
+		}
+	}
+
+	return false
+}
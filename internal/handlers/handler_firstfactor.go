@@ -47,6 +47,12 @@ func FirstFactorPOST(delayFunc middlewares.TimingAttackDelayFunc) middlewares.Re
 			return
 		}
 
+		if ctxRegulateRemoteIP(ctx, bodyJSON.Username, regulation.AuthType1FA) {
+			respondUnauthorized(ctx, messageAuthenticationFailed)
+
+			return
+		}
+
 		userPasswordOk, err := ctx.Providers.UserProvider.CheckUserPassword(bodyJSON.Username, bodyJSON.Password)
 		if err != nil {
 			_ = markAuthenticationAttempt(ctx, false, nil, bodyJSON.Username, regulation.AuthType1FA, err)
@@ -140,6 +146,10 @@ func FirstFactorPOST(delayFunc middlewares.TimingAttackDelayFunc) middlewares.Re
 			userSession.RefreshTTL = ctx.Clock.Now().Add(ctx.Configuration.AuthenticationBackend.RefreshInterval.Value())
 		}
 
+		if err = ctx.SaveUserSessionIndex(provider, &userSession); err != nil {
+			ctx.Logger.WithError(err).Errorf(logFmtErrSessionIndex, regulation.AuthType1FA, bodyJSON.Username)
+		}
+
 		if err = ctx.SaveSession(userSession); err != nil {
 			ctx.Logger.WithError(err).Errorf(logFmtErrSessionSave, "updated profile", regulation.AuthType1FA, logFmtActionAuthentication, bodyJSON.Username)
 
@@ -148,6 +158,10 @@ func FirstFactorPOST(delayFunc middlewares.TimingAttackDelayFunc) middlewares.Re
 			return
 		}
 
+		if ctx.Configuration.KnownDevices.Enabled {
+			handleKnownDevice(ctx, provider.Config.Domain, userSession.Username)
+		}
+
 		successful = true
 
 		if bodyJSON.Workflow == workflowOpenIDConnect {
@@ -1,10 +1,19 @@
 package handlers
 
 import (
+	"context"
+	"database/sql"
+	"encoding/json"
 	"fmt"
+	"net"
 	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"github.com/valyala/fasthttp"
@@ -13,6 +22,7 @@ import (
 	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/events"
 	"github.com/authelia/authelia/v4/internal/mocks"
 	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/regulation"
@@ -52,6 +62,8 @@ func (s *FirstFactorSuite) TestShouldFailIfBodyIsInBadFormat() {
 }
 
 func (s *FirstFactorSuite) TestShouldFailIfUserProviderCheckPasswordFail() {
+	ExpectNoRegulationBans(s.mock)
+
 	s.mock.UserProviderMock.
 		EXPECT().
 		CheckUserPassword(gomock.Eq("test"), gomock.Eq("hello")).
@@ -80,7 +92,279 @@ func (s *FirstFactorSuite) TestShouldFailIfUserProviderCheckPasswordFail() {
 	s.mock.Assert401KO(s.T(), "Authentication failed. Check your credentials.")
 }
 
+func (s *FirstFactorSuite) TestShouldFailIfUserIsDisabled() {
+	ExpectNoRegulationBans(s.mock)
+
+	s.mock.UserProviderMock.
+		EXPECT().
+		CheckUserPassword(gomock.Eq("test"), gomock.Eq("hello")).
+		Return(false, authentication.ErrUserDisabled)
+
+	s.mock.StorageMock.
+		EXPECT().
+		AppendAuthenticationLog(s.mock.Ctx, gomock.Eq(model.AuthenticationAttempt{
+			Username:   "test",
+			Successful: false,
+			Banned:     false,
+			Time:       s.mock.Clock.Now(),
+			Type:       regulation.AuthType1FA,
+			RemoteIP:   model.NewNullIPFromString("0.0.0.0"),
+		}))
+
+	s.mock.Ctx.Request.SetBodyString(`{
+		"username": "test",
+		"password": "hello",
+		"keepMeLoggedIn": true
+	}`)
+	FirstFactorPOST(nil)(s.mock.Ctx)
+
+	AssertLogEntryMessageAndError(s.T(), s.mock.Hook.LastEntry(), "Unsuccessful 1FA authentication attempt by user 'test'", "user account is disabled")
+
+	s.mock.Assert401KO(s.T(), "Authentication failed. Check your credentials.")
+}
+
+func (s *FirstFactorSuite) TestShouldPublishSecurityEventsWhenUserIsBanned() {
+	path := filepath.Join(s.T().TempDir(), "security-events.log")
+
+	s.mock.Ctx.Providers.Events = events.NewBus(&schema.SecurityEvents{Log: schema.SecurityEventsLog{Path: path}}, nil, nil, nil)
+	s.mock.Ctx.Providers.Regulator = regulation.NewRegulator(schema.Regulation{MaxRetries: 1, FindTime: time.Minute, BanTime: time.Minute * 5, Subnet: schema.RegulationSubnet{IPv4Prefix: 24, IPv6Prefix: 64}}, s.mock.StorageMock, &s.mock.Clock)
+
+	ban := model.RegulationBan{
+		CreatedAt: s.mock.Clock.Now(),
+		ExpiresAt: sql.NullTime{Time: s.mock.Clock.Now().Add(time.Minute * 5), Valid: true},
+		Kind:      model.RegulationBanKindUser,
+		Value:     "test",
+		Source:    model.RegulationBanSourceAutomatic,
+		Reason:    "Exceeded the maximum number of failed authentication attempts",
+	}
+
+	gomock.InOrder(
+		s.mock.StorageMock.
+			EXPECT().
+			LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindUser, "test", s.mock.Clock.Now(), s.mock.Clock.Now().Add(-time.Minute*5)).
+			Return(nil, nil),
+		s.mock.StorageMock.
+			EXPECT().
+			LoadAuthenticationLogs(s.mock.Ctx, "test", gomock.Any(), 10, 0).
+			Return(nil, nil),
+		s.mock.StorageMock.
+			EXPECT().
+			LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindIP, "0.0.0.0", s.mock.Clock.Now(), s.mock.Clock.Now()).
+			Return(nil, nil),
+		s.mock.StorageMock.
+			EXPECT().
+			LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindSubnet, "0.0.0.0/24", s.mock.Clock.Now(), s.mock.Clock.Now()).
+			Return(nil, nil),
+		s.mock.StorageMock.
+			EXPECT().
+			LoadActiveRegulationBansByKind(s.mock.Ctx, model.RegulationBanKindSubnet, s.mock.Clock.Now()).
+			Return(nil, nil),
+		s.mock.UserProviderMock.
+			EXPECT().
+			CheckUserPassword(gomock.Eq("test"), gomock.Eq("hello")).
+			Return(false, nil),
+		s.mock.StorageMock.
+			EXPECT().
+			AppendAuthenticationLog(s.mock.Ctx, gomock.Any()),
+		s.mock.StorageMock.
+			EXPECT().
+			LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindUser, "test", s.mock.Clock.Now(), s.mock.Clock.Now().Add(-time.Minute*5)).
+			Return(nil, nil),
+		s.mock.StorageMock.
+			EXPECT().
+			LoadAuthenticationLogs(s.mock.Ctx, "test", gomock.Any(), 10, 0).
+			Return([]model.AuthenticationAttempt{{Username: "test", Time: s.mock.Clock.Now(), Type: regulation.AuthType1FA}}, nil),
+		s.mock.StorageMock.
+			EXPECT().
+			SaveRegulationBan(s.mock.Ctx, ban).
+			Return(nil),
+		s.mock.StorageMock.
+			EXPECT().
+			LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindIP, "0.0.0.0", s.mock.Clock.Now(), s.mock.Clock.Now()).
+			Return(nil, nil),
+		s.mock.StorageMock.
+			EXPECT().
+			LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindSubnet, "0.0.0.0/24", s.mock.Clock.Now(), s.mock.Clock.Now()).
+			Return(nil, nil),
+	)
+
+	s.mock.Ctx.Request.SetBodyString(`{
+		"username": "test",
+		"password": "hello",
+		"keepMeLoggedIn": true
+	}`)
+	FirstFactorPOST(nil)(s.mock.Ctx)
+
+	s.mock.Assert401KO(s.T(), "Authentication failed. Check your credentials.")
+
+	s.Require().NoError(s.mock.Ctx.Providers.Events.Close())
+
+	data, err := os.ReadFile(path)
+	s.Require().NoError(err)
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	s.Require().Len(lines, 2)
+
+	published := map[string]model.SecurityEvent{}
+
+	for _, line := range lines {
+		event := model.SecurityEvent{}
+
+		s.Require().NoError(json.Unmarshal([]byte(line), &event))
+
+		published[event.Type] = event
+	}
+
+	s.Equal(map[string]any{"method": regulation.AuthType1FA}, published[schema.SecurityEventAuthenticationFailure].Details)
+	s.Equal("test", published[schema.SecurityEventRegulationBan].Username)
+	s.Equal("0.0.0.0", published[schema.SecurityEventRegulationBan].RemoteIP)
+	s.Equal(map[string]any{"method": regulation.AuthType1FA, "kind": model.RegulationBanKindUser, "value": "test", "banned_until": s.mock.Clock.Now().Add(time.Minute * 5).UTC().Format(time.RFC3339)}, published[schema.SecurityEventRegulationBan].Details)
+}
+
+func (s *FirstFactorSuite) TestShouldPublishSecurityEventsWhenRemoteIPIsBanned() {
+	path := filepath.Join(s.T().TempDir(), "security-events.log")
+
+	s.mock.Ctx.Request.Header.Set(fasthttp.HeaderXForwardedFor, "192.168.1.10")
+	s.mock.Ctx.Providers.Events = events.NewBus(&schema.SecurityEvents{Log: schema.SecurityEventsLog{Path: path}}, nil, nil, nil)
+	s.mock.Ctx.Providers.Regulator = regulation.NewRegulator(schema.Regulation{IP: schema.RegulationIP{MaxRetries: 1, FindTime: time.Minute, BanTime: time.Minute * 5}, Subnet: schema.RegulationSubnet{IPv4Prefix: 24, IPv6Prefix: 64}}, s.mock.StorageMock, &s.mock.Clock)
+
+	gomock.InOrder(
+		s.mock.StorageMock.
+			EXPECT().
+			LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindUser, "test", s.mock.Clock.Now(), s.mock.Clock.Now()).
+			Return(nil, nil),
+		s.mock.StorageMock.
+			EXPECT().
+			LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindIP, "192.168.1.10", s.mock.Clock.Now(), s.mock.Clock.Now().Add(-time.Minute*5)).
+			Return(nil, nil),
+		s.mock.StorageMock.
+			EXPECT().
+			LoadFailedAuthenticationLogsByRemoteIP(s.mock.Ctx, net.ParseIP("192.168.1.10"), s.mock.Clock.Now().Add(-time.Minute*5), 1, 0).
+			Return(nil, nil),
+		s.mock.StorageMock.
+			EXPECT().
+			LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindSubnet, "192.168.1.0/24", s.mock.Clock.Now(), s.mock.Clock.Now()).
+			Return(nil, nil),
+		s.mock.StorageMock.
+			EXPECT().
+			LoadActiveRegulationBansByKind(s.mock.Ctx, model.RegulationBanKindSubnet, s.mock.Clock.Now()).
+			Return(nil, nil),
+		s.mock.UserProviderMock.
+			EXPECT().
+			CheckUserPassword(gomock.Eq("test"), gomock.Eq("hello")).
+			Return(false, nil),
+		s.mock.StorageMock.
+			EXPECT().
+			AppendAuthenticationLog(s.mock.Ctx, gomock.Any()),
+		s.mock.StorageMock.
+			EXPECT().
+			LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindUser, "test", s.mock.Clock.Now(), s.mock.Clock.Now()).
+			Return(nil, nil),
+		s.mock.StorageMock.
+			EXPECT().
+			LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindIP, "192.168.1.10", s.mock.Clock.Now(), s.mock.Clock.Now().Add(-time.Minute*5)).
+			Return(nil, nil),
+		s.mock.StorageMock.
+			EXPECT().
+			LoadFailedAuthenticationLogsByRemoteIP(s.mock.Ctx, net.ParseIP("192.168.1.10"), s.mock.Clock.Now().Add(-time.Minute*5), 1, 0).
+			Return([]model.AuthenticationAttempt{{Username: "test", Time: s.mock.Clock.Now()}}, nil),
+		s.mock.StorageMock.
+			EXPECT().
+			SaveRegulationBan(s.mock.Ctx, model.RegulationBan{
+				CreatedAt: s.mock.Clock.Now(),
+				ExpiresAt: sql.NullTime{Time: s.mock.Clock.Now().Add(time.Minute * 5), Valid: true},
+				Kind:      model.RegulationBanKindIP,
+				Value:     "192.168.1.10",
+				Source:    model.RegulationBanSourceAutomatic,
+				Reason:    "Exceeded the maximum number of failed authentication attempts",
+			}).
+			Return(nil),
+	)
+
+	s.mock.Ctx.Request.SetBodyString(`{
+		"username": "test",
+		"password": "hello",
+		"keepMeLoggedIn": true
+	}`)
+	FirstFactorPOST(nil)(s.mock.Ctx)
+
+	s.mock.Assert401KO(s.T(), "Authentication failed. Check your credentials.")
+
+	s.Require().NoError(s.mock.Ctx.Providers.Events.Close())
+
+	data, err := os.ReadFile(path)
+	s.Require().NoError(err)
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	s.Require().Len(lines, 2)
+
+	published := map[string]model.SecurityEvent{}
+
+	for _, line := range lines {
+		event := model.SecurityEvent{}
+
+		s.Require().NoError(json.Unmarshal([]byte(line), &event))
+
+		published[event.Type] = event
+	}
+
+	s.Equal("192.168.1.10", published[schema.SecurityEventRegulationBan].RemoteIP)
+	s.Equal(map[string]any{"method": regulation.AuthType1FA, "kind": model.RegulationBanKindIP, "value": "192.168.1.10", "banned_until": s.mock.Clock.Now().Add(time.Minute * 5).UTC().Format(time.RFC3339)}, published[schema.SecurityEventRegulationBan].Details)
+}
+
+func (s *FirstFactorSuite) TestShouldRejectWhenRemoteIPIsBanned() {
+	s.mock.Ctx.Request.Header.Set(fasthttp.HeaderXForwardedFor, "192.168.1.10")
+	s.mock.Ctx.Providers.Regulator = regulation.NewRegulator(schema.Regulation{IP: schema.RegulationIP{MaxRetries: 2, FindTime: time.Minute, BanTime: time.Minute * 5}}, s.mock.StorageMock, &s.mock.Clock)
+
+	gomock.InOrder(
+		s.mock.StorageMock.
+			EXPECT().
+			LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindUser, "test", s.mock.Clock.Now(), s.mock.Clock.Now()).
+			Return(nil, nil),
+		s.mock.StorageMock.
+			EXPECT().
+			LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindIP, "192.168.1.10", s.mock.Clock.Now(), s.mock.Clock.Now().Add(-time.Minute*5)).
+			Return(nil, nil),
+		s.mock.StorageMock.
+			EXPECT().
+			LoadFailedAuthenticationLogsByRemoteIP(s.mock.Ctx, net.ParseIP("192.168.1.10"), s.mock.Clock.Now().Add(-time.Minute*5), 2, 0).
+			Return([]model.AuthenticationAttempt{{Username: "bob", Time: s.mock.Clock.Now()}, {Username: "alice", Time: s.mock.Clock.Now().Add(-time.Second)}}, nil),
+		s.mock.StorageMock.
+			EXPECT().
+			SaveRegulationBan(s.mock.Ctx, model.RegulationBan{
+				CreatedAt: s.mock.Clock.Now(),
+				ExpiresAt: sql.NullTime{Time: s.mock.Clock.Now().Add(time.Minute * 5), Valid: true},
+				Kind:      model.RegulationBanKindIP,
+				Value:     "192.168.1.10",
+				Source:    model.RegulationBanSourceAutomatic,
+				Reason:    "Exceeded the maximum number of failed authentication attempts",
+			}).
+			Return(nil),
+		s.mock.StorageMock.
+			EXPECT().
+			AppendAuthenticationLog(s.mock.Ctx, model.AuthenticationAttempt{
+				Time:       s.mock.Clock.Now(),
+				Successful: false,
+				Banned:     true,
+				Username:   "test",
+				Type:       regulation.AuthType1FA,
+				RemoteIP:   model.NewNullIP(net.ParseIP("192.168.1.10")),
+			}),
+	)
+
+	s.mock.Ctx.Request.SetBodyString(`{
+		"username": "test",
+		"password": "hello",
+		"keepMeLoggedIn": true
+	}`)
+	FirstFactorPOST(nil)(s.mock.Ctx)
+
+	s.mock.Assert401KO(s.T(), "Authentication failed. Check your credentials.")
+}
+
 func (s *FirstFactorSuite) TestShouldCheckAuthenticationIsNotMarkedWhenProviderCheckPasswordError() {
+	ExpectNoRegulationBans(s.mock)
+
 	s.mock.UserProviderMock.
 		EXPECT().
 		CheckUserPassword(gomock.Eq("test"), gomock.Eq("hello")).
@@ -107,6 +391,8 @@ func (s *FirstFactorSuite) TestShouldCheckAuthenticationIsNotMarkedWhenProviderC
 }
 
 func (s *FirstFactorSuite) TestShouldCheckAuthenticationIsMarkedWhenInvalidCredentials() {
+	ExpectNoRegulationBans(s.mock)
+
 	s.mock.UserProviderMock.
 		EXPECT().
 		CheckUserPassword(gomock.Eq("test"), gomock.Eq("hello")).
@@ -133,6 +419,8 @@ func (s *FirstFactorSuite) TestShouldCheckAuthenticationIsMarkedWhenInvalidCrede
 }
 
 func (s *FirstFactorSuite) TestShouldFailIfUserProviderGetDetailsFail() {
+	ExpectNoRegulationBans(s.mock)
+
 	s.mock.UserProviderMock.
 		EXPECT().
 		CheckUserPassword(gomock.Eq("test"), gomock.Eq("hello")).
@@ -160,6 +448,8 @@ func (s *FirstFactorSuite) TestShouldFailIfUserProviderGetDetailsFail() {
 }
 
 func (s *FirstFactorSuite) TestShouldFailIfAuthenticationMarkFail() {
+	ExpectNoRegulationBans(s.mock)
+
 	s.mock.UserProviderMock.
 		EXPECT().
 		CheckUserPassword(gomock.Eq("test"), gomock.Eq("hello")).
@@ -182,6 +472,8 @@ func (s *FirstFactorSuite) TestShouldFailIfAuthenticationMarkFail() {
 }
 
 func (s *FirstFactorSuite) TestShouldAuthenticateUserWithRememberMeChecked() {
+	ExpectNoRegulationBans(s.mock)
+
 	s.mock.UserProviderMock.
 		EXPECT().
 		CheckUserPassword(gomock.Eq("test"), gomock.Eq("hello")).
@@ -201,6 +493,17 @@ func (s *FirstFactorSuite) TestShouldAuthenticateUserWithRememberMeChecked() {
 		AppendAuthenticationLog(s.mock.Ctx, gomock.Any()).
 		Return(nil)
 
+	var record model.UserSession
+
+	s.mock.StorageMock.
+		EXPECT().
+		SaveUserSession(s.mock.Ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, session model.UserSession) error {
+			record = session
+
+			return nil
+		})
+
 	s.mock.Ctx.Request.SetBodyString(`{
 		"username": "test",
 		"password": "hello",
@@ -220,9 +523,17 @@ func (s *FirstFactorSuite) TestShouldAuthenticateUserWithRememberMeChecked() {
 	assert.Equal(s.T(), authentication.OneFactor, userSession.AuthenticationLevel)
 	assert.Equal(s.T(), []string{"test@example.com"}, userSession.Emails)
 	assert.Equal(s.T(), []string{"dev", "admins"}, userSession.Groups)
+
+	assert.NotEqual(s.T(), uuid.Nil, userSession.PublicID)
+	assert.Equal(s.T(), userSession.PublicID, record.PublicID)
+	assert.Equal(s.T(), "test", record.Username)
+	assert.Equal(s.T(), userSession.CookieDomain, record.CookieDomain)
+	assert.Equal(s.T(), s.mock.Clock.Now().Add(s.mock.Ctx.Configuration.Session.Cookies[0].RememberMe), record.ExpiresAt)
 }
 
 func (s *FirstFactorSuite) TestShouldAuthenticateUserWithRememberMeUnchecked() {
+	ExpectNoRegulationBans(s.mock)
+
 	s.mock.UserProviderMock.
 		EXPECT().
 		CheckUserPassword(gomock.Eq("test"), gomock.Eq("hello")).
@@ -242,6 +553,11 @@ func (s *FirstFactorSuite) TestShouldAuthenticateUserWithRememberMeUnchecked() {
 		AppendAuthenticationLog(s.mock.Ctx, gomock.Any()).
 		Return(nil)
 
+	s.mock.StorageMock.
+		EXPECT().
+		SaveUserSession(s.mock.Ctx, gomock.Any()).
+		Return(nil)
+
 	s.mock.Ctx.Request.SetBodyString(`{
 		"username": "test",
 		"password": "hello",
@@ -265,6 +581,8 @@ func (s *FirstFactorSuite) TestShouldAuthenticateUserWithRememberMeUnchecked() {
 }
 
 func (s *FirstFactorSuite) TestShouldSaveUsernameFromAuthenticationBackendInSession() {
+	ExpectNoRegulationBans(s.mock)
+
 	s.mock.UserProviderMock.
 		EXPECT().
 		CheckUserPassword(gomock.Eq("test"), gomock.Eq("hello")).
@@ -287,6 +605,11 @@ func (s *FirstFactorSuite) TestShouldSaveUsernameFromAuthenticationBackendInSess
 		AppendAuthenticationLog(s.mock.Ctx, gomock.Any()).
 		Return(nil)
 
+	s.mock.StorageMock.
+		EXPECT().
+		SaveUserSession(s.mock.Ctx, gomock.Any()).
+		Return(nil)
+
 	s.mock.Ctx.Request.SetBodyString(`{
 		"username": "test",
 		"password": "hello",
@@ -317,6 +640,8 @@ type FirstFactorRedirectionSuite struct {
 
 func (s *FirstFactorRedirectionSuite) SetupTest() {
 	s.mock = mocks.NewMockAutheliaCtx(s.T())
+
+	ExpectNoRegulationBans(s.mock)
 	s.mock.Ctx.Configuration.Session.Cookies[0].DefaultRedirectionURL = &url.URL{Scheme: "https", Host: "default.local"}
 	s.mock.Ctx.Configuration.AccessControl.DefaultPolicy = testBypass
 	s.mock.Ctx.Configuration.AccessControl.Rules = []schema.AccessControlRule{
@@ -325,7 +650,7 @@ func (s *FirstFactorRedirectionSuite) SetupTest() {
 			Policy:  "one_factor",
 		},
 	}
-	s.mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&s.mock.Ctx.Configuration)
+	s.mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&s.mock.Ctx.Configuration, &s.mock.Clock)
 
 	s.mock.UserProviderMock.
 		EXPECT().
@@ -345,6 +670,11 @@ func (s *FirstFactorRedirectionSuite) SetupTest() {
 		EXPECT().
 		AppendAuthenticationLog(s.mock.Ctx, gomock.Any()).
 		Return(nil)
+
+	s.mock.StorageMock.
+		EXPECT().
+		SaveUserSession(s.mock.Ctx, gomock.Any()).
+		Return(nil)
 }
 
 func (s *FirstFactorRedirectionSuite) TearDownTest() {
@@ -409,7 +739,7 @@ func (s *FirstFactorRedirectionSuite) TestShouldReply200WhenNoTargetURLProvidedA
 		AccessControl: schema.AccessControl{
 			DefaultPolicy: "two_factor",
 		},
-	})
+	}, &s.mock.Clock)
 	s.mock.Ctx.Request.SetBodyString(`{
 		"username": "test",
 		"password": "hello",
@@ -444,7 +774,7 @@ func (s *FirstFactorRedirectionSuite) TestShouldReply200WhenUnsafeTargetURLProvi
 					Policy:  "two_factor",
 				},
 			},
-		}})
+		}}, &s.mock.Clock)
 	s.mock.Ctx.Request.SetBodyString(`{
 		"username": "test",
 		"password": "hello",
@@ -0,0 +1,294 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/random"
+	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/storage"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// KnownDevicesGET returns the known devices of the current user.
+func KnownDevicesGET(ctx *middlewares.AutheliaCtx) {
+	var (
+		userSession session.UserSession
+		devices     []model.KnownDevice
+		err         error
+	)
+
+	if userSession, err = ctx.GetSession(); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred listing known devices: %s", errStrUserSessionData)
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if userSession.IsAnonymous() {
+		ctx.Logger.WithError(errUserAnonymous).Error("Error occurred listing known devices")
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if devices, err = ctx.Providers.StorageProvider.LoadKnownDevices(ctx, userSession.Username, ctx.Clock.Now().Add(-ctx.Configuration.KnownDevices.Lifespan)); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred listing known devices for user '%s': error occurred loading the devices from the storage backend", userSession.Username)
+
+		ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	var current string
+
+	if deviceID, ok := knownDeviceCookieID(ctx); ok {
+		current = knownDeviceDigest(deviceID)
+	}
+
+	data := make([]KnownDeviceResponse, len(devices))
+
+	for i, d := range devices {
+		data[i] = KnownDeviceResponse{
+			ID:         d.ID,
+			Current:    current != "" && d.DeviceID == current,
+			Device:     d.Device,
+			Subnet:     d.Subnet,
+			UserAgent:  d.UserAgent,
+			CreatedAt:  d.CreatedAt,
+			LastUsedAt: d.LastUsedAt,
+		}
+	}
+
+	if err = ctx.SetJSONBody(data); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred listing known devices for user '%s': %s", userSession.Username, errStrRespBody)
+	}
+}
+
+// KnownDeviceDELETE forgets a known device of the current user. The next sign in from the device is alerted.
+func KnownDeviceDELETE(ctx *middlewares.AutheliaCtx) {
+	var (
+		userSession session.UserSession
+		id          int
+		err         error
+	)
+
+	if userSession, err = ctx.GetSession(); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred forgetting known device: %s", errStrUserSessionData)
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if userSession.IsAnonymous() {
+		ctx.Logger.WithError(errUserAnonymous).Error("Error occurred forgetting known device")
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	value, _ := ctx.UserValue("id").(string)
+
+	if id, err = strconv.Atoi(value); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred forgetting known device for user '%s': error occurred parsing the identifier", userSession.Username)
+
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if err = ctx.Providers.StorageProvider.DeleteKnownDevice(ctx, userSession.Username, id); err != nil {
+		if errors.Is(err, storage.ErrNoKnownDevice) {
+			ctx.Logger.WithError(fmt.Errorf("the known device '%d' does not exist", id)).Errorf("Error occurred forgetting known device for user '%s'", userSession.Username)
+
+			ctx.SetStatusCode(fasthttp.StatusForbidden)
+		} else {
+			ctx.Logger.WithError(err).Errorf("Error occurred forgetting known device for user '%s': error occurred deleting the device from the storage backend", userSession.Username)
+
+			ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+		}
+
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	ctx.ReplyOK()
+}
+
+// handleKnownDevice records the device the user successfully completed the first factor from, and alerts the user when
+// it's not one of their known devices. The first device of a user is recorded without an alert. Errors are logged and
+// do not affect the authentication.
+func handleKnownDevice(ctx *middlewares.AutheliaCtx, domain, username string) {
+	config := ctx.Configuration.KnownDevices
+
+	var (
+		devices []model.KnownDevice
+		err     error
+	)
+
+	now := ctx.Clock.Now()
+
+	if devices, err = ctx.Providers.StorageProvider.LoadKnownDevices(ctx, username, now.Add(-config.Lifespan)); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred checking known devices for user '%s': error occurred loading the devices from the storage backend", username)
+
+		return
+	}
+
+	deviceID, ok := knownDeviceCookieID(ctx)
+	if !ok {
+		deviceID = ctx.Providers.Random.StringCustom(64, random.CharSetAlphaNumeric)
+	}
+
+	userAgent := string(ctx.UserAgent())
+
+	device := model.KnownDevice{
+		CreatedAt:  now,
+		LastUsedAt: now,
+		Username:   username,
+		DeviceID:   knownDeviceDigest(deviceID),
+		Subnet:     knownDeviceSubnet(ctx, ctx.RemoteIP()),
+		UserAgent:  userAgent,
+		Device:     utils.UserAgentDevice(userAgent),
+	}
+
+	var action string
+
+	if len(devices) != 0 {
+		action = eventLogActionNewDevice
+	}
+
+	for _, d := range devices {
+		if d.DeviceID != device.DeviceID {
+			continue
+		}
+
+		if d.Subnet == device.Subnet {
+			action = ""
+			device.ID = d.ID
+
+			break
+		}
+
+		action = eventLogActionNewLocation
+	}
+
+	if device.ID == 0 {
+		err = ctx.Providers.StorageProvider.SaveKnownDevice(ctx, device)
+	} else {
+		err = ctx.Providers.StorageProvider.UpdateKnownDeviceActivity(ctx, device)
+	}
+
+	if err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred checking known devices for user '%s': error occurred saving the device to the storage backend", username)
+
+		return
+	}
+
+	knownDeviceSetCookie(ctx, domain, deviceID)
+
+	if action == "" {
+		return
+	}
+
+	ctx.Logger.Debugf("User '%s' signed in from an unknown device or location with subnet '%s'", username, device.Subnet)
+
+	body := emailEventBody{
+		Prefix: eventEmailActionKnownDevicePrefix,
+		Body:   action,
+		Suffix: eventEmailActionKnownDeviceSuffix,
+	}
+
+	ctxLogEvent(ctx, username, action, body, map[string]any{eventLogKeyAction: action, eventLogKeyDevice: device.Device, eventLogKeyLocation: device.Subnet})
+}
+
+// knownDeviceCookieID returns the device id from the device cookie if it's present and the signature is valid.
+func knownDeviceCookieID(ctx *middlewares.AutheliaCtx) (deviceID string, ok bool) {
+	value := string(ctx.Request.Header.Cookie(ctx.Configuration.KnownDevices.CookieName))
+
+	deviceID, signature, found := strings.Cut(value, ".")
+	if !found || deviceID == "" {
+		return "", false
+	}
+
+	expected := knownDeviceSignature(ctx, deviceID)
+
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return "", false
+	}
+
+	return deviceID, true
+}
+
+// knownDeviceSetCookie sets the signed device cookie which expires after the configured lifespan.
+func knownDeviceSetCookie(ctx *middlewares.AutheliaCtx, domain, deviceID string) {
+	cookie := fasthttp.AcquireCookie()
+
+	defer fasthttp.ReleaseCookie(cookie)
+
+	cookie.SetKey(ctx.Configuration.KnownDevices.CookieName)
+	cookie.SetValue(deviceID + "." + knownDeviceSignature(ctx, deviceID))
+	cookie.SetDomain(domain)
+	cookie.SetPath("/")
+	cookie.SetMaxAge(int(ctx.Configuration.KnownDevices.Lifespan.Seconds()))
+	cookie.SetHTTPOnly(true)
+	cookie.SetSecure(true)
+	cookie.SetSameSite(fasthttp.CookieSameSiteLaxMode)
+
+	ctx.Response.Header.SetCookie(cookie)
+}
+
+// knownDeviceSignature returns the hex encoded HMAC-SHA256 signature of the device id. The key is derived from the
+// storage encryption key so the signatures are valid across restarts and instances.
+func knownDeviceSignature(ctx *middlewares.AutheliaCtx, deviceID string) string {
+	key := sha256.Sum256([]byte(knownDeviceSignatureContext + ctx.Configuration.Storage.EncryptionKey))
+
+	mac := hmac.New(sha256.New, key[:])
+
+	mac.Write([]byte(deviceID))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// knownDeviceDigest returns the value stored in the storage backend for a device id, the device id itself is only
+// stored in the device cookie.
+func knownDeviceDigest(deviceID string) string {
+	sum := sha256.Sum256([]byte(deviceID))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// knownDeviceSubnet returns the subnet of the remote ip using the configured prefix lengths.
+func knownDeviceSubnet(ctx *middlewares.AutheliaCtx, ip net.IP) string {
+	var mask net.IPMask
+
+	if ip4 := ip.To4(); ip4 != nil {
+		ip, mask = ip4, net.CIDRMask(ctx.Configuration.KnownDevices.IPv4Subnet, net.IPv4len*8)
+	} else {
+		mask = net.CIDRMask(ctx.Configuration.KnownDevices.IPv6Subnet, net.IPv6len*8)
+	}
+
+	subnet := &net.IPNet{IP: ip.Mask(mask), Mask: mask}
+
+	return subnet.String()
+}
@@ -0,0 +1,383 @@
+package handlers
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+	"go.uber.org/mock/gomock"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+func TestKnownDevicesGET(t *testing.T) {
+	at := time.Unix(1700000000, 0).UTC()
+
+	testCases := []struct {
+		name           string
+		setup          func(t *testing.T, mock *mocks.MockAutheliaCtx)
+		expected       string
+		expectedStatus int
+		expectedf      func(t *testing.T, mock *mocks.MockAutheliaCtx)
+	}{
+		{
+			"ShouldHandleAnonymous",
+			nil,
+			`{"status":"KO","message":"Operation failed."}`,
+			fasthttp.StatusForbidden,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred listing known devices", "user is anonymous")
+			},
+		},
+		{
+			"ShouldHandleStorageError",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				setKnownDevicesTestSession(t, mock)
+
+				mock.StorageMock.EXPECT().LoadKnownDevices(mock.Ctx, testUsername, mock.Clock.Now().Add(-schema.DefaultKnownDevicesConfiguration.Lifespan)).Return(nil, fmt.Errorf("bad block"))
+			},
+			`{"status":"KO","message":"Operation failed."}`,
+			fasthttp.StatusInternalServerError,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred listing known devices for user 'john': error occurred loading the devices from the storage backend", "bad block")
+			},
+		},
+		{
+			"ShouldHandleDevices",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				setKnownDevicesTestSession(t, mock)
+				setKnownDevicesTestCookie(mock, "abc")
+
+				mock.StorageMock.EXPECT().LoadKnownDevices(mock.Ctx, testUsername, mock.Clock.Now().Add(-schema.DefaultKnownDevicesConfiguration.Lifespan)).Return([]model.KnownDevice{
+					{ID: 2, Username: testUsername, DeviceID: knownDeviceDigest("abc"), Subnet: "192.168.1.0/24", UserAgent: "Mozilla/5.0", Device: "Unknown on Unknown", CreatedAt: at, LastUsedAt: at},
+					{ID: 1, Username: testUsername, DeviceID: knownDeviceDigest("xyz"), Subnet: "10.0.0.0/24", CreatedAt: at, LastUsedAt: at},
+				}, nil)
+			},
+			`{"status":"OK","data":[{"id":2,"current":true,"device":"Unknown on Unknown","subnet":"192.168.1.0/24","user_agent":"Mozilla/5.0","created_at":"2023-11-14T22:13:20Z","last_used_at":"2023-11-14T22:13:20Z"},{"id":1,"current":false,"device":"","subnet":"10.0.0.0/24","user_agent":"","created_at":"2023-11-14T22:13:20Z","last_used_at":"2023-11-14T22:13:20Z"}]}`,
+			fasthttp.StatusOK,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			mock.Ctx.Configuration.KnownDevices = newKnownDevicesTestConfig()
+
+			if tc.setup != nil {
+				tc.setup(t, mock)
+			}
+
+			KnownDevicesGET(mock.Ctx)
+
+			assert.Equal(t, tc.expectedStatus, mock.Ctx.Response.StatusCode())
+			assert.Equal(t, tc.expected, string(mock.Ctx.Response.Body()))
+
+			if tc.expectedf != nil {
+				tc.expectedf(t, mock)
+			}
+		})
+	}
+}
+
+func TestKnownDeviceDELETE(t *testing.T) {
+	testCases := []struct {
+		name           string
+		setup          func(t *testing.T, mock *mocks.MockAutheliaCtx)
+		have           any
+		expected       string
+		expectedStatus int
+		expectedf      func(t *testing.T, mock *mocks.MockAutheliaCtx)
+	}{
+		{
+			"ShouldHandleAnonymous",
+			nil,
+			"1",
+			`{"status":"KO","message":"Operation failed."}`,
+			fasthttp.StatusForbidden,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred forgetting known device", "user is anonymous")
+			},
+		},
+		{
+			"ShouldHandleBadID",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				setKnownDevicesTestSession(t, mock)
+			},
+			"abc",
+			`{"status":"KO","message":"Operation failed."}`,
+			fasthttp.StatusBadRequest,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred forgetting known device for user 'john': error occurred parsing the identifier", "strconv.Atoi: parsing \"abc\": invalid syntax")
+			},
+		},
+		{
+			"ShouldHandleNotFound",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				setKnownDevicesTestSession(t, mock)
+
+				mock.StorageMock.EXPECT().DeleteKnownDevice(mock.Ctx, testUsername, 1).Return(storage.ErrNoKnownDevice)
+			},
+			"1",
+			`{"status":"KO","message":"Operation failed."}`,
+			fasthttp.StatusForbidden,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred forgetting known device for user 'john'", "the known device '1' does not exist")
+			},
+		},
+		{
+			"ShouldHandleStorageError",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				setKnownDevicesTestSession(t, mock)
+
+				mock.StorageMock.EXPECT().DeleteKnownDevice(mock.Ctx, testUsername, 1).Return(fmt.Errorf("bad block"))
+			},
+			"1",
+			`{"status":"KO","message":"Operation failed."}`,
+			fasthttp.StatusInternalServerError,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred forgetting known device for user 'john': error occurred deleting the device from the storage backend", "bad block")
+			},
+		},
+		{
+			"ShouldForgetDevice",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				setKnownDevicesTestSession(t, mock)
+
+				mock.StorageMock.EXPECT().DeleteKnownDevice(mock.Ctx, testUsername, 1).Return(nil)
+			},
+			"1",
+			`{"status":"OK"}`,
+			fasthttp.StatusOK,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			mock.Ctx.Configuration.KnownDevices = newKnownDevicesTestConfig()
+
+			if tc.setup != nil {
+				tc.setup(t, mock)
+			}
+
+			mock.Ctx.SetUserValue("id", tc.have)
+
+			KnownDeviceDELETE(mock.Ctx)
+
+			assert.Equal(t, tc.expectedStatus, mock.Ctx.Response.StatusCode())
+			assert.Equal(t, tc.expected, string(mock.Ctx.Response.Body()))
+
+			if tc.expectedf != nil {
+				tc.expectedf(t, mock)
+			}
+		})
+	}
+}
+
+func TestHandleKnownDevice(t *testing.T) {
+	at := time.Unix(1700000000, 0).UTC()
+
+	testCases := []struct {
+		name      string
+		setup     func(t *testing.T, mock *mocks.MockAutheliaCtx)
+		expectedf func(t *testing.T, mock *mocks.MockAutheliaCtx)
+	}{
+		{
+			"ShouldRecordFirstDeviceWithoutAlert",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				gomock.InOrder(
+					mock.StorageMock.EXPECT().LoadKnownDevices(mock.Ctx, testUsername, mock.Clock.Now().Add(-schema.DefaultKnownDevicesConfiguration.Lifespan)).Return(nil, nil),
+					mock.StorageMock.EXPECT().SaveKnownDevice(mock.Ctx, gomock.Any()).DoAndReturn(func(_ any, device model.KnownDevice) error {
+						assert.Equal(t, testUsername, device.Username)
+						assert.Equal(t, "192.168.1.0/24", device.Subnet)
+						assert.Len(t, device.DeviceID, 64)
+
+						return nil
+					}),
+				)
+			},
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				cookie := string(mock.Ctx.Response.Header.PeekCookie("authelia_device"))
+
+				assert.Regexp(t, `^authelia_device=[a-zA-Z0-9]{64}\.[a-f0-9]{64}; max-age=31536000; domain=example\.com; path=/; HttpOnly; secure; SameSite=Lax$`, cookie)
+			},
+		},
+		{
+			"ShouldUpdateKnownDeviceWithoutAlert",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				setKnownDevicesTestCookie(mock, "abc")
+
+				gomock.InOrder(
+					mock.StorageMock.EXPECT().LoadKnownDevices(mock.Ctx, testUsername, mock.Clock.Now().Add(-schema.DefaultKnownDevicesConfiguration.Lifespan)).Return([]model.KnownDevice{
+						{ID: 2, Username: testUsername, DeviceID: knownDeviceDigest("xyz"), Subnet: "10.0.0.0/24", CreatedAt: at, LastUsedAt: at},
+						{ID: 1, Username: testUsername, DeviceID: knownDeviceDigest("abc"), Subnet: "192.168.1.0/24", CreatedAt: at, LastUsedAt: at},
+					}, nil),
+					mock.StorageMock.EXPECT().UpdateKnownDeviceActivity(mock.Ctx, model.KnownDevice{ID: 1, CreatedAt: mock.Clock.Now(), LastUsedAt: mock.Clock.Now(), Username: testUsername, DeviceID: knownDeviceDigest("abc"), Subnet: "192.168.1.0/24", UserAgent: "Mozilla/5.0", Device: "Unknown on Unknown"}).Return(nil),
+				)
+			},
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				assert.Contains(t, string(mock.Ctx.Response.Header.PeekCookie("authelia_device")), "authelia_device=abc."+knownDeviceSignature(mock.Ctx, "abc")+";")
+			},
+		},
+		{
+			"ShouldAlertNewDevice",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				setKnownDevicesTestCookie(mock, "abc")
+
+				gomock.InOrder(
+					mock.StorageMock.EXPECT().LoadKnownDevices(mock.Ctx, testUsername, mock.Clock.Now().Add(-schema.DefaultKnownDevicesConfiguration.Lifespan)).Return([]model.KnownDevice{
+						{ID: 1, Username: testUsername, DeviceID: knownDeviceDigest("xyz"), Subnet: "192.168.1.0/24", CreatedAt: at, LastUsedAt: at},
+					}, nil),
+					mock.StorageMock.EXPECT().SaveKnownDevice(mock.Ctx, model.KnownDevice{CreatedAt: mock.Clock.Now(), LastUsedAt: mock.Clock.Now(), Username: testUsername, DeviceID: knownDeviceDigest("abc"), Subnet: "192.168.1.0/24", UserAgent: "Mozilla/5.0", Device: "Unknown on Unknown"}).Return(nil),
+					mock.UserProviderMock.EXPECT().GetDetails(testUsername).Return(&authentication.UserDetails{Username: testUsername, DisplayName: testDisplayName, Emails: []string{"john@example.com"}}, nil),
+					mock.NotifierMock.EXPECT().Send(mock.Ctx, mail.Address{Name: testDisplayName, Address: "john@example.com"}, "Sign In From a New Device", gomock.Any(), gomock.Any()).Return(nil),
+				)
+			},
+			nil,
+		},
+		{
+			"ShouldAlertNewLocation",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				setKnownDevicesTestCookie(mock, "abc")
+
+				gomock.InOrder(
+					mock.StorageMock.EXPECT().LoadKnownDevices(mock.Ctx, testUsername, mock.Clock.Now().Add(-schema.DefaultKnownDevicesConfiguration.Lifespan)).Return([]model.KnownDevice{
+						{ID: 1, Username: testUsername, DeviceID: knownDeviceDigest("abc"), Subnet: "10.0.0.0/24", CreatedAt: at, LastUsedAt: at},
+					}, nil),
+					mock.StorageMock.EXPECT().SaveKnownDevice(mock.Ctx, model.KnownDevice{CreatedAt: mock.Clock.Now(), LastUsedAt: mock.Clock.Now(), Username: testUsername, DeviceID: knownDeviceDigest("abc"), Subnet: "192.168.1.0/24", UserAgent: "Mozilla/5.0", Device: "Unknown on Unknown"}).Return(nil),
+					mock.UserProviderMock.EXPECT().GetDetails(testUsername).Return(&authentication.UserDetails{Username: testUsername, DisplayName: testDisplayName, Emails: []string{"john@example.com"}}, nil),
+					mock.NotifierMock.EXPECT().Send(mock.Ctx, mail.Address{Name: testDisplayName, Address: "john@example.com"}, "Sign In From a New Location", gomock.Any(), gomock.Any()).Return(nil),
+				)
+			},
+			nil,
+		},
+		{
+			"ShouldAlertNewDeviceWithForgedCookie",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.Ctx.Request.Header.SetCookie("authelia_device", "abc.bad")
+
+				gomock.InOrder(
+					mock.StorageMock.EXPECT().LoadKnownDevices(mock.Ctx, testUsername, mock.Clock.Now().Add(-schema.DefaultKnownDevicesConfiguration.Lifespan)).Return([]model.KnownDevice{
+						{ID: 1, Username: testUsername, DeviceID: knownDeviceDigest("abc"), Subnet: "192.168.1.0/24", CreatedAt: at, LastUsedAt: at},
+					}, nil),
+					mock.StorageMock.EXPECT().SaveKnownDevice(mock.Ctx, gomock.Any()).Return(nil),
+					mock.UserProviderMock.EXPECT().GetDetails(testUsername).Return(&authentication.UserDetails{Username: testUsername, DisplayName: testDisplayName, Emails: []string{"john@example.com"}}, nil),
+					mock.NotifierMock.EXPECT().Send(mock.Ctx, mail.Address{Name: testDisplayName, Address: "john@example.com"}, "Sign In From a New Device", gomock.Any(), gomock.Any()).Return(nil),
+				)
+			},
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				assert.NotContains(t, string(mock.Ctx.Response.Header.PeekCookie("authelia_device")), "authelia_device=abc.")
+			},
+		},
+		{
+			"ShouldHandleLoadError",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().LoadKnownDevices(mock.Ctx, testUsername, mock.Clock.Now().Add(-schema.DefaultKnownDevicesConfiguration.Lifespan)).Return(nil, fmt.Errorf("bad block"))
+			},
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred checking known devices for user 'john': error occurred loading the devices from the storage backend", "bad block")
+				assert.Len(t, mock.Ctx.Response.Header.PeekCookie("authelia_device"), 0)
+			},
+		},
+		{
+			"ShouldHandleSaveError",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				gomock.InOrder(
+					mock.StorageMock.EXPECT().LoadKnownDevices(mock.Ctx, testUsername, mock.Clock.Now().Add(-schema.DefaultKnownDevicesConfiguration.Lifespan)).Return([]model.KnownDevice{
+						{ID: 1, Username: testUsername, DeviceID: knownDeviceDigest("xyz"), Subnet: "192.168.1.0/24", CreatedAt: at, LastUsedAt: at},
+					}, nil),
+					mock.StorageMock.EXPECT().SaveKnownDevice(mock.Ctx, gomock.Any()).Return(fmt.Errorf("bad block")),
+				)
+			},
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred checking known devices for user 'john': error occurred saving the device to the storage backend", "bad block")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			mock.Ctx.Configuration.KnownDevices = newKnownDevicesTestConfig()
+			mock.Ctx.Request.Header.Set(fasthttp.HeaderXForwardedFor, "192.168.1.10")
+			mock.Ctx.Request.Header.SetUserAgent("Mozilla/5.0")
+
+			if tc.setup != nil {
+				tc.setup(t, mock)
+			}
+
+			handleKnownDevice(mock.Ctx, exampleDotCom, testUsername)
+
+			if tc.expectedf != nil {
+				tc.expectedf(t, mock)
+			}
+		})
+	}
+}
+
+func TestKnownDeviceSubnet(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected string
+	}{
+		{"ShouldMaskIPv4", "192.168.1.10", "192.168.1.0/24"},
+		{"ShouldMaskIPv4MappedIPv6", "::ffff:192.168.1.10", "192.168.1.0/24"},
+		{"ShouldMaskIPv6", "2001:db8:abcd:12:1:2:3:4", "2001:db8:abcd:12::/64"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			mock.Ctx.Configuration.KnownDevices = newKnownDevicesTestConfig()
+
+			assert.Equal(t, tc.expected, knownDeviceSubnet(mock.Ctx, net.ParseIP(tc.have)))
+		})
+	}
+}
+
+func newKnownDevicesTestConfig() schema.KnownDevices {
+	config := schema.DefaultKnownDevicesConfiguration
+
+	config.Enabled = true
+
+	return config
+}
+
+func setKnownDevicesTestSession(t *testing.T, mock *mocks.MockAutheliaCtx) {
+	us, err := mock.Ctx.GetSession()
+
+	require.NoError(t, err)
+
+	us.Username = testUsername
+	us.AuthenticationLevel = authentication.OneFactor
+
+	require.NoError(t, mock.Ctx.SaveSession(us))
+}
+
+func setKnownDevicesTestCookie(mock *mocks.MockAutheliaCtx, deviceID string) {
+	mock.Ctx.Request.Header.SetCookie("authelia_device", deviceID+"."+knownDeviceSignature(mock.Ctx, deviceID))
+}
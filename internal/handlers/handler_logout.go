@@ -1,10 +1,14 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
 
+	"github.com/google/uuid"
+
 	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/storage"
 )
 
 type logoutBody struct {
@@ -25,6 +29,14 @@ func LogoutPOST(ctx *middlewares.AutheliaCtx) {
 		ctx.Error(fmt.Errorf("unable to parse body during logout: %w", err), messageOperationFailed)
 	}
 
+	if userSession, err := ctx.GetSession(); err == nil && userSession.PublicID != uuid.Nil {
+		if err = ctx.Providers.StorageProvider.RevokeUserSession(ctx, userSession.Username, userSession.PublicID, ctx.Clock.Now()); err != nil && !errors.Is(err, storage.ErrNoUserSession) {
+			ctx.Logger.WithError(err).Errorf("Error occurred revoking the session index entry for user '%s' during logout", userSession.Username)
+		}
+
+		handleOpenIDConnectLogout(ctx, userSession.Username, userSession.PublicID)
+	}
+
 	err = ctx.DestroySession()
 	if err != nil {
 		ctx.Error(fmt.Errorf("unable to destroy session during logout: %w", err), messageOperationFailed)
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 
@@ -41,6 +42,26 @@ func (s *LogoutSuite) TestShouldDestroySession() {
 	assert.True(s.T(), strings.HasPrefix(string(b), "authelia_session=;"))
 }
 
+func (s *LogoutSuite) TestShouldRevokeSessionIndex() {
+	provider, err := s.mock.Ctx.GetSessionProvider()
+	s.Require().NoError(err)
+
+	userSession, err := provider.GetSession(s.mock.Ctx.RequestCtx)
+	s.Require().NoError(err)
+
+	userSession.PublicID = uuid.MustParse("5d2ffd3b-c8fd-4f0c-9e35-f2d2b0f0a0c1")
+	s.Require().NoError(provider.SaveSession(s.mock.Ctx.RequestCtx, userSession))
+
+	s.mock.StorageMock.EXPECT().
+		RevokeUserSession(s.mock.Ctx, testUsername, userSession.PublicID, s.mock.Clock.Now()).
+		Return(nil)
+
+	LogoutPOST(s.mock.Ctx)
+	b := s.mock.Ctx.Response.Header.PeekCookie("authelia_session")
+
+	assert.True(s.T(), strings.HasPrefix(string(b), "authelia_session=;"))
+}
+
 func TestRunLogoutSuite(t *testing.T) {
 	s := new(LogoutSuite)
 	suite.Run(t, s)
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	oauthelia2 "authelia.com/provider/oauth2"
+	"github.com/google/uuid"
 
 	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/authorization"
@@ -90,6 +91,8 @@ func OpenIDConnectAuthorization(ctx *middlewares.AutheliaCtx, rw http.ResponseWr
 		return
 	}
 
+	oidcCtxHandleRevokedUserSession(ctx, &userSession)
+
 	if requester.GetRequestForm().Get(oidc.FormParameterPrompt) == oidc.PromptNone {
 		if userSession.IsAnonymous() {
 			ctx.Logger.Errorf("Authorization Request with id '%s' on client with id '%s' could not be processed: the 'prompt' type of 'none' was requested but the user is not logged in", requester.GetID(), client.GetID())
@@ -122,7 +125,7 @@ func OpenIDConnectAuthorization(ctx *middlewares.AutheliaCtx, rw http.ResponseWr
 		return
 	}
 
-	extraClaims := oidcGrantRequests(requester, consent, details)
+	extraClaims := oidcGrantRequests(requester, consent, details, oidcCtxScopeDefinitions(ctx))
 
 	if authTime, err = userSession.AuthenticatedTime(client.GetAuthorizationPolicyRequiredLevel(authorization.Subject{Username: details.Username, Groups: details.Groups, IP: ctx.RemoteIP()})); err != nil {
 		ctx.Logger.Errorf("Authorization Request with id '%s' on client with id '%s' could not be processed: error occurred checking authentication time: %+v", requester.GetID(), client.GetID(), err)
@@ -136,6 +139,10 @@ func OpenIDConnectAuthorization(ctx *middlewares.AutheliaCtx, rw http.ResponseWr
 
 	session := oidc.NewSessionWithAuthorizeRequest(ctx, issuer, ctx.Providers.OpenIDConnect.KeyManager.GetKeyID(ctx, client.GetIDTokenSignedResponseKeyID(), client.GetIDTokenSignedResponseAlg()), details.Username, userSession.AuthenticationMethodRefs.MarshalRFC8176(), extraClaims, authTime, consent, requester)
 
+	if userSession.PublicID != uuid.Nil {
+		session.Claims.Add(oidc.ClaimSessionID, userSession.PublicID.String())
+	}
+
 	ctx.Logger.Tracef("Authorization Request with id '%s' on client with id '%s' creating session for Authorization Response for subject '%s' with username '%s' with claims: %+v",
 		requester.GetID(), session.ClientID, session.Subject, session.Username, session.Claims)
 
@@ -157,11 +164,37 @@ func OpenIDConnectAuthorization(ctx *middlewares.AutheliaCtx, rw http.ResponseWr
 		return
 	}
 
+	handleOIDCAuthorizationClientSession(ctx, issuer, client, userSession, consent)
+
 	responder.GetParameters().Set(oidc.FormParameterIssuer, issuer.String())
 
 	ctx.Providers.OpenIDConnect.WriteAuthorizeResponse(ctx, rw, requester, responder)
 }
 
+// handleOIDCAuthorizationClientSession records that the client holds a session for the user session so it can be
+// informed via Front-Channel or Back-Channel Logout when the user session ends. Failures are logged but do not prevent
+// the Authorization Response from being written.
+func handleOIDCAuthorizationClientSession(ctx *middlewares.AutheliaCtx, issuer *url.URL, client oidc.Client, userSession session.UserSession, consent *model.OAuth2ConsentSession) {
+	if userSession.PublicID == uuid.Nil || !consent.Subject.Valid {
+		return
+	}
+
+	if client.GetFrontChannelLogoutURI() == nil && client.GetBackChannelLogoutURI() == nil {
+		return
+	}
+
+	if err := ctx.Providers.StorageProvider.SaveOAuth2ClientSession(ctx, model.OAuth2ClientSession{
+		SessionID: userSession.PublicID,
+		ClientID:  client.GetID(),
+		Subject:   consent.Subject.UUID,
+		Username:  userSession.Username,
+		Issuer:    issuer.String(),
+		CreatedAt: ctx.Clock.Now(),
+	}); err != nil {
+		ctx.Logger.WithError(err).Errorf("Authorization Request on client with id '%s' could not record the client session for logout for user '%s'", client.GetID(), userSession.Username)
+	}
+}
+
 // OpenIDConnectPushedAuthorizationRequest handles POST requests to the OAuth 2.0 Pushed Authorization Requests endpoint.
 //
 // RFC9126 https://www.rfc-editor.org/rfc/rfc9126.html
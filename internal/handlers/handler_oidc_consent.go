@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/oidc"
@@ -134,6 +135,10 @@ func OpenIDConnectConsentPOST(ctx *middlewares.AutheliaCtx) {
 		return
 	}
 
+	if bodyJSON.Consent {
+		ctxPublishEvent(ctx, schema.SecurityEventOpenIDConnectConsent, userSession.Username, map[string]any{securityEventKeyClientID: consent.ClientID, securityEventKeyScopes: []string(consent.GrantedScopes)})
+	}
+
 	var (
 		redirectURI *url.URL
 		query       url.Values
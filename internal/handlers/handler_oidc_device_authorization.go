@@ -0,0 +1,449 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	oauthelia2 "authelia.com/provider/oauth2"
+	"authelia.com/provider/oauth2/x/errorsx"
+	"github.com/google/uuid"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/oidc"
+	"github.com/authelia/authelia/v4/internal/session"
+)
+
+// OpenIDConnectDeviceAuthorizationPOST handles POST requests to the OAuth 2.0 Device Authorization endpoint.
+//
+// https://datatracker.ietf.org/doc/html/rfc8628#section-3.1
+func OpenIDConnectDeviceAuthorizationPOST(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter, req *http.Request) {
+	var (
+		client               oidc.Client
+		deviceCode, userCode string
+		device               *model.OAuth2DeviceCodeSession
+		err                  error
+	)
+
+	if err = req.ParseForm(); err != nil {
+		ctx.Logger.Errorf("Device Authorization Request failed with error: error occurred parsing the form: %+v", err)
+
+		errorsx.WriteJSONError(rw, req, oauthelia2.ErrInvalidRequest.WithHint("Unable to parse HTTP body, make sure to send a properly formatted form request body.").WithWrap(err))
+
+		return
+	}
+
+	if client, err = oidcDeviceAuthorizationAuthenticateClient(ctx, req); err != nil {
+		ctx.Logger.Errorf("Device Authorization Request failed with error: %s", oauthelia2.ErrorToDebugRFC6749Error(err))
+
+		oidcDeviceAuthorizationWriteError(rw, req, err)
+
+		return
+	}
+
+	if !client.GetGrantTypes().Has(oidc.GrantTypeDeviceCode) {
+		ctx.Logger.Errorf("Device Authorization Request on client with id '%s' failed with error: the client is not permitted to use the '%s' grant type", client.GetID(), oidc.GrantTypeDeviceCode)
+
+		oidcDeviceAuthorizationWriteError(rw, req, oauthelia2.ErrUnauthorizedClient.WithHintf("The OAuth 2.0 Client is not allowed to use the authorization grant '%s'.", oidc.GrantTypeDeviceCode))
+
+		return
+	}
+
+	scopes := oauthelia2.RemoveEmpty(strings.Split(req.PostForm.Get(oidc.FormParameterScope), " "))
+
+	for _, scope := range scopes {
+		if !client.GetScopes().Has(scope) {
+			ctx.Logger.Errorf("Device Authorization Request on client with id '%s' failed with error: the client is not permitted to request the scope '%s'", client.GetID(), scope)
+
+			oidcDeviceAuthorizationWriteError(rw, req, oauthelia2.ErrInvalidScope.WithHintf("The OAuth 2.0 Client is not allowed to request scope '%s'.", scope))
+
+			return
+		}
+	}
+
+	if deviceCode, userCode, err = oidc.NewRFC8628Codes(ctx.Providers.Random); err != nil {
+		ctx.Logger.Errorf("Device Authorization Request on client with id '%s' failed with error: %+v", client.GetID(), err)
+
+		oidcDeviceAuthorizationWriteError(rw, req, oauthelia2.ErrServerError)
+
+		return
+	}
+
+	form := url.Values{}
+
+	for key, values := range req.PostForm {
+		if key == oidc.FormParameterClientSecret {
+			continue
+		}
+
+		form[key] = values
+	}
+
+	requester := &oauthelia2.Request{
+		ID:                uuid.NewString(),
+		RequestedAt:       ctx.Clock.Now().UTC(),
+		Client:            client,
+		RequestedScope:    scopes,
+		GrantedScope:      oauthelia2.Arguments{},
+		RequestedAudience: oauthelia2.Arguments{},
+		GrantedAudience:   oauthelia2.Arguments{},
+		Form:              form,
+		Session:           oidc.NewSession(),
+	}
+
+	if device, err = model.NewOAuth2DeviceCodeSessionFromRequest(oidc.RFC8628DeviceCodeSignature(deviceCode), oidc.RFC8628UserCodeSignature(userCode), requester); err != nil {
+		ctx.Logger.Errorf("Device Authorization Request with id '%s' on client with id '%s' failed with error: %+v", requester.GetID(), client.GetID(), err)
+
+		oidcDeviceAuthorizationWriteError(rw, req, oauthelia2.ErrServerError)
+
+		return
+	}
+
+	if err = ctx.Providers.StorageProvider.SaveOAuth2DeviceCodeSession(ctx, *device); err != nil {
+		ctx.Logger.Errorf("Device Authorization Request with id '%s' on client with id '%s' failed with error: error occurred saving the session to the storage backend: %+v", requester.GetID(), client.GetID(), err)
+
+		oidcDeviceAuthorizationWriteError(rw, req, oauthelia2.ErrServerError)
+
+		return
+	}
+
+	verification := ctx.RootURL()
+
+	verification.Path = path.Join(verification.Path, oidc.EndpointPathDevice)
+
+	complete := *verification
+
+	complete.RawQuery = url.Values{oidc.FormParameterUserCode: []string{userCode}}.Encode()
+
+	response := oidc.RFC8628DeviceAuthorizeResponse{
+		DeviceCode:              deviceCode,
+		UserCode:                userCode,
+		VerificationURI:         verification.String(),
+		VerificationURIComplete: complete.String(),
+		ExpiresIn:               int64(ctx.Providers.OpenIDConnect.GetRFC8628CodeLifespan(ctx) / time.Second),
+		Interval:                int64(ctx.Providers.OpenIDConnect.GetRFC8628TokenPollingInterval(ctx) / time.Second),
+	}
+
+	ctx.Logger.Debugf("Device Authorization Request with id '%s' on client with id '%s' has successfully been processed", requester.GetID(), client.GetID())
+
+	rw.Header().Set(fasthttp.HeaderContentType, "application/json; charset=utf-8")
+	rw.Header().Set(fasthttp.HeaderCacheControl, "no-store")
+	rw.Header().Set(fasthttp.HeaderPragma, "no-cache")
+	rw.WriteHeader(http.StatusOK)
+
+	_ = json.NewEncoder(rw).Encode(response)
+}
+
+// OpenIDConnectDeviceCodeUserVerificationGET handles requests to describe a pending OAuth 2.0 Device Authorization
+// Grant to the user who entered the user code.
+func OpenIDConnectDeviceCodeUserVerificationGET(ctx *middlewares.AutheliaCtx) {
+	var (
+		device  *model.OAuth2DeviceCodeSession
+		client  oidc.Client
+		handled bool
+		err     error
+	)
+
+	if _, device, client, handled = handleOpenIDConnectDeviceCodeGetSessionsAndClient(ctx, string(ctx.RequestCtx.QueryArgs().PeekBytes(qryArgUserCode))); handled {
+		return
+	}
+
+	body := client.GetConsentResponseBody(&model.OAuth2ConsentSession{RequestedScopes: device.RequestedScopes, RequestedAudience: device.RequestedAudience})
+
+	body.PreConfiguration = false
+
+	if err = ctx.SetJSONBody(body); err != nil {
+		ctx.Error(fmt.Errorf("unable to set JSON body: %w", err), messageOperationFailed)
+	}
+}
+
+// OpenIDConnectDeviceCodeUserVerificationPOST handles the user response to a pending OAuth 2.0 Device Authorization
+// Grant.
+//
+//nolint:gocyclo
+func OpenIDConnectDeviceCodeUserVerificationPOST(ctx *middlewares.AutheliaCtx) {
+	var (
+		bodyJSON oidc.DeviceCodeUserVerificationPostRequestBody
+		err      error
+	)
+
+	if err = json.Unmarshal(ctx.Request.Body(), &bodyJSON); err != nil {
+		ctx.Logger.Errorf("Failed to parse JSON body in device code user verification POST: %+v", err)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	var (
+		userSession session.UserSession
+		device      *model.OAuth2DeviceCodeSession
+		client      oidc.Client
+		handled     bool
+	)
+
+	if userSession, device, client, handled = handleOpenIDConnectDeviceCodeGetSessionsAndClient(ctx, bodyJSON.UserCode); handled {
+		return
+	}
+
+	subject := authorization.Subject{Username: userSession.Username, Groups: userSession.Groups, IP: ctx.RemoteIP()}
+
+	if !client.IsAuthenticationLevelSufficient(userSession.AuthenticationLevel, subject) {
+		ctx.Logger.Errorf("User '%s' can't respond to device authorization request with id '%s' for client with id '%s' as they are not sufficiently authenticated",
+			userSession.Username, device.RequestID, client.GetID())
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	var (
+		details   *authentication.UserDetails
+		requester *oauthelia2.Request
+		consent   *model.OAuth2ConsentSession
+		updated   *model.OAuth2DeviceCodeSession
+		sub       uuid.UUID
+		authTime  time.Time
+	)
+
+	if details, err = ctx.Providers.UserProvider.GetDetails(userSession.Username); err != nil {
+		ctx.Logger.WithError(err).Errorf("Device authorization request with id '%s' on client with id '%s' could not be processed: error occurred retrieving user details for '%s' from the backend", device.RequestID, client.GetID(), userSession.Username)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if sub, err = ctx.Providers.OpenIDConnect.Store.GetSubject(ctx, client.GetSectorIdentifierURI(), userSession.Username); err != nil {
+		ctx.Logger.Errorf("Device authorization request with id '%s' on client with id '%s' could not be processed: error occurred retrieving subject for user '%s': %+v", device.RequestID, client.GetID(), userSession.Username, err)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if authTime, err = userSession.AuthenticatedTime(client.GetAuthorizationPolicyRequiredLevel(subject)); err != nil {
+		ctx.Logger.Errorf("Device authorization request with id '%s' on client with id '%s' could not be processed: error occurred checking authentication time: %+v", device.RequestID, client.GetID(), err)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if requester, err = device.ToRequest(ctx, oidc.NewSession(), ctx.Providers.OpenIDConnect.Store); err != nil {
+		ctx.Logger.Errorf("Device authorization request with id '%s' on client with id '%s' could not be processed: %+v", device.RequestID, client.GetID(), err)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if consent, err = handleOpenIDConnectDeviceCodeConsent(ctx, sub, requester, bodyJSON.Consent); err != nil {
+		ctx.Logger.Errorf("Device authorization request with id '%s' on client with id '%s' could not be processed: %+v", device.RequestID, client.GetID(), err)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	extraClaims := oidcGrantRequests(nil, consent, details, oidcCtxScopeDefinitions(ctx))
+
+	for _, scope := range consent.GrantedScopes {
+		requester.GrantScope(scope)
+	}
+
+	for _, audience := range consent.GrantedAudience {
+		requester.GrantAudience(audience)
+	}
+
+	requester.SetSession(oidc.NewSessionWithRequester(ctx, ctx.RootURL(), ctx.Providers.OpenIDConnect.KeyManager.GetKeyID(ctx, client.GetIDTokenSignedResponseKeyID(), client.GetIDTokenSignedResponseAlg()),
+		details.Username, userSession.AuthenticationMethodRefs.MarshalRFC8176(), extraClaims, authTime, consent, requester))
+
+	if updated, err = model.NewOAuth2DeviceCodeSessionFromRequest(device.Signature, device.UserCodeSignature, requester); err != nil {
+		ctx.Logger.Errorf("Device authorization request with id '%s' on client with id '%s' could not be processed: %+v", device.RequestID, client.GetID(), err)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if bodyJSON.Consent {
+		updated.Status = model.OAuth2DeviceCodeStatusApproved
+	} else {
+		updated.Status = model.OAuth2DeviceCodeStatusDenied
+	}
+
+	if err = ctx.Providers.StorageProvider.UpdateOAuth2DeviceCodeSession(ctx, *updated); err != nil {
+		ctx.Logger.Errorf("Device authorization request with id '%s' on client with id '%s' could not be processed: error occurred saving the response: %+v", device.RequestID, client.GetID(), err)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	ctx.Logger.Debugf("Device authorization request with id '%s' on client with id '%s' was responded to by user '%s' (approved '%t')", device.RequestID, client.GetID(), userSession.Username, bodyJSON.Consent)
+
+	ctx.ReplyOK()
+}
+
+func handleOpenIDConnectDeviceCodeGetSessionsAndClient(ctx *middlewares.AutheliaCtx, userCode string) (userSession session.UserSession, device *model.OAuth2DeviceCodeSession, client oidc.Client, handled bool) {
+	var err error
+
+	if userSession, err = ctx.GetSession(); err != nil {
+		ctx.Logger.Errorf("Unable to load user session for device code user verification: %v", err)
+		ctx.ReplyForbidden()
+
+		return userSession, nil, nil, true
+	}
+
+	oidcCtxHandleRevokedUserSession(ctx, &userSession)
+
+	if userSession.IsAnonymous() {
+		ctx.Logger.Errorf("Unable to perform device code user verification: the user is not logged in")
+		ctx.ReplyForbidden()
+
+		return userSession, nil, nil, true
+	}
+
+	if len(oidc.NormalizeRFC8628UserCode(userCode)) == 0 {
+		ctx.Logger.Errorf("Unable to perform device code user verification for user '%s': the user code was not provided", userSession.Username)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return userSession, nil, nil, true
+	}
+
+	if device, err = ctx.Providers.StorageProvider.LoadOAuth2DeviceCodeSessionByUserCode(ctx, oidc.RFC8628UserCodeSignature(userCode)); err != nil {
+		ctx.Logger.Errorf("Unable to load device code session for user '%s': %v", userSession.Username, err)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return userSession, nil, nil, true
+	}
+
+	if !device.Active || device.Status != model.OAuth2DeviceCodeStatusPending || device.Expired(ctx.Clock.Now(), ctx.Providers.OpenIDConnect.GetRFC8628CodeLifespan(ctx)) {
+		ctx.Logger.Errorf("Unable to perform device code user verification for user '%s' with request id '%s': the device code session is no longer pending", userSession.Username, device.RequestID)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return userSession, nil, nil, true
+	}
+
+	if client, err = ctx.Providers.OpenIDConnect.GetRegisteredClient(ctx, device.ClientID); err != nil {
+		ctx.Logger.Errorf("Unable to find related client configuration with name '%s': %v", device.ClientID, err)
+		ctx.ReplyForbidden()
+
+		return userSession, nil, nil, true
+	}
+
+	return userSession, device, client, false
+}
+
+func handleOpenIDConnectDeviceCodeConsent(ctx *middlewares.AutheliaCtx, subject uuid.UUID, requester oauthelia2.Requester, authorized bool) (consent *model.OAuth2ConsentSession, err error) {
+	if consent, err = model.NewOAuth2ConsentSession(subject, requester); err != nil {
+		return nil, fmt.Errorf("error occurred generating consent: %w", err)
+	}
+
+	if err = ctx.Providers.StorageProvider.SaveOAuth2ConsentSession(ctx, *consent); err != nil {
+		return nil, fmt.Errorf("error occurred saving consent session: %w", err)
+	}
+
+	if consent, err = ctx.Providers.StorageProvider.LoadOAuth2ConsentSessionByChallengeID(ctx, consent.ChallengeID); err != nil {
+		return nil, fmt.Errorf("error occurred loading consent session: %w", err)
+	}
+
+	if authorized {
+		consent.Grant()
+	}
+
+	if err = ctx.Providers.StorageProvider.SaveOAuth2ConsentSessionResponse(ctx, *consent, authorized); err != nil {
+		return nil, fmt.Errorf("error occurred saving consent session response: %w", err)
+	}
+
+	if !authorized {
+		return consent, nil
+	}
+
+	if err = ctx.Providers.StorageProvider.SaveOAuth2ConsentSessionGranted(ctx, consent.ID); err != nil {
+		return nil, fmt.Errorf("error occurred saving consent session granted: %w", err)
+	}
+
+	return consent, nil
+}
+
+// oidcDeviceAuthorizationAuthenticateClient authenticates the client at the Device Authorization endpoint using the
+// client_secret_basic, client_secret_post, or none methods.
+//
+// https://datatracker.ietf.org/doc/html/rfc8628#section-3.1
+func oidcDeviceAuthorizationAuthenticateClient(ctx *middlewares.AutheliaCtx, req *http.Request) (client oidc.Client, err error) {
+	var (
+		id, secret, method string
+		basic              bool
+	)
+
+	if id, secret, basic = req.BasicAuth(); basic {
+		if id, err = url.QueryUnescape(id); err != nil {
+			return nil, oauthelia2.ErrInvalidClient.WithHint("The client id in the HTTP authorization header could not be decoded from 'application/x-www-form-urlencoded'.").WithWrap(err)
+		}
+
+		if secret, err = url.QueryUnescape(secret); err != nil {
+			return nil, oauthelia2.ErrInvalidClient.WithHint("The client secret in the HTTP authorization header could not be decoded from 'application/x-www-form-urlencoded'.").WithWrap(err)
+		}
+
+		method = oidc.ClientAuthMethodClientSecretBasic
+	} else {
+		id, secret = req.PostForm.Get(oidc.FormParameterClientID), req.PostForm.Get(oidc.FormParameterClientSecret)
+
+		if len(secret) == 0 {
+			method = oidc.ClientAuthMethodNone
+		} else {
+			method = oidc.ClientAuthMethodClientSecretPost
+		}
+	}
+
+	if len(id) == 0 {
+		return nil, oauthelia2.ErrInvalidClient.WithHint("Client authentication failed (e.g., unknown client, no client authentication included, or unsupported authentication method).").WithDebug("The client id was not provided.")
+	}
+
+	if client, err = ctx.Providers.OpenIDConnect.GetRegisteredClient(ctx, id); err != nil {
+		if errors.Is(err, oauthelia2.ErrNotFound) || errors.Is(err, oauthelia2.ErrInvalidClient) {
+			return nil, oauthelia2.ErrInvalidClient.WithWrap(err).WithDebugf("The client with id '%s' was not found.", id)
+		}
+
+		return nil, oauthelia2.ErrServerError.WithWrap(err).WithDebugf("Failed to retrieve the client with id '%s' with error: %s.", id, err.Error())
+	}
+
+	c, ok := client.(oidcClientSecretAuthenticationClient)
+
+	if !ok {
+		return nil, oauthelia2.ErrInvalidClient.WithDebugf("The client with id '%s' does not support authentication at this endpoint.", id)
+	}
+
+	if expected := c.GetTokenEndpointAuthMethod(); expected != method {
+		return nil, oauthelia2.ErrInvalidClient.WithHint("Client authentication failed (e.g., unknown client, no client authentication included, or unsupported authentication method).").WithDebugf("The client with id '%s' is registered with the '%s' authentication method but the '%s' authentication method was used.", id, expected, method)
+	}
+
+	if method == oidc.ClientAuthMethodNone {
+		if !client.IsPublic() {
+			return nil, oauthelia2.ErrInvalidClient.WithDebugf("The client with id '%s' is not a public client.", id)
+		}
+
+		return client, nil
+	}
+
+	if err = c.GetClientSecret().Compare(ctx, []byte(secret)); err != nil {
+		return nil, oauthelia2.ErrInvalidClient.WithWrap(err).WithDebugf("The client with id '%s' provided an invalid client secret.", id)
+	}
+
+	return client, nil
+}
+
+func oidcDeviceAuthorizationWriteError(rw http.ResponseWriter, req *http.Request, err error) {
+	if rfc := oauthelia2.ErrorToRFC6749Error(err); rfc.StatusCode() == http.StatusUnauthorized {
+		rw.Header().Set(fasthttp.HeaderWWWAuthenticate, `Basic realm="authelia"`)
+	}
+
+	errorsx.WriteJSONError(rw, req, err)
+}
+
+type oidcClientSecretAuthenticationClient interface {
+	GetTokenEndpointAuthMethod() (method string)
+	GetClientSecret() (secret oauthelia2.ClientSecret)
+}
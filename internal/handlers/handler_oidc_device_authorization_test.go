@@ -0,0 +1,605 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	oauthelia2 "authelia.com/provider/oauth2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+	"go.uber.org/mock/gomock"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/oidc"
+)
+
+const (
+	testDeviceClientIDPublic       = "device-public"
+	testDeviceClientIDConfidential = "device-confidential"
+	testDeviceClientIDNoGrant      = "device-no-grant"
+	testDeviceClientSecret         = "client-secret"
+	testDeviceUserCode             = "BCDF-GHJK"
+)
+
+func newDeviceAuthorizationTestMock(t *testing.T) *mocks.MockAutheliaCtx {
+	mock := mocks.NewMockAutheliaCtx(t)
+
+	secret, err := schema.DecodePasswordDigest("$plaintext$" + testDeviceClientSecret)
+
+	require.NoError(t, err)
+
+	config := &schema.IdentityProvidersOpenIDConnect{
+		Clients: []schema.IdentityProvidersOpenIDConnectClient{
+			{
+				ID:                      testDeviceClientIDPublic,
+				Public:                  true,
+				AuthorizationPolicy:     "one_factor",
+				Scopes:                  []string{oidc.ScopeOpenID, oidc.ScopeProfile},
+				GrantTypes:              []string{oidc.GrantTypeDeviceCode},
+				TokenEndpointAuthMethod: oidc.ClientAuthMethodNone,
+			},
+			{
+				ID:                      testDeviceClientIDConfidential,
+				Secret:                  secret,
+				AuthorizationPolicy:     "two_factor",
+				Scopes:                  []string{oidc.ScopeOpenID},
+				GrantTypes:              []string{oidc.GrantTypeDeviceCode},
+				TokenEndpointAuthMethod: oidc.ClientAuthMethodClientSecretBasic,
+			},
+			{
+				ID:                      testDeviceClientIDNoGrant,
+				Public:                  true,
+				AuthorizationPolicy:     "one_factor",
+				Scopes:                  []string{oidc.ScopeOpenID},
+				GrantTypes:              []string{oidc.GrantTypeAuthorizationCode},
+				TokenEndpointAuthMethod: oidc.ClientAuthMethodNone,
+			},
+		},
+	}
+
+	mock.Ctx.Providers.OpenIDConnect = oidc.NewOpenIDConnectProvider(config, mock.StorageMock, mock.Ctx.Providers.Templates)
+	mock.Ctx.Request.Header.Set(fasthttp.HeaderXForwardedProto, "https")
+
+	return mock
+}
+
+func newDeviceAuthorizationTestSession(t *testing.T, mock *mocks.MockAutheliaCtx, clientID string, requestedAt time.Time) *model.OAuth2DeviceCodeSession {
+	client, err := mock.Ctx.Providers.OpenIDConnect.GetRegisteredClient(context.Background(), clientID)
+
+	require.NoError(t, err)
+
+	requester := &oauthelia2.Request{
+		ID:                "4a8b3c2d-1e0f-4a5b-8c7d-6e5f4a3b2c1d",
+		RequestedAt:       requestedAt,
+		Client:            client,
+		RequestedScope:    oauthelia2.Arguments{oidc.ScopeOpenID},
+		GrantedScope:      oauthelia2.Arguments{},
+		RequestedAudience: oauthelia2.Arguments{},
+		GrantedAudience:   oauthelia2.Arguments{},
+		Form:              url.Values{oidc.FormParameterClientID: []string{clientID}},
+		Session:           oidc.NewSession(),
+	}
+
+	device, err := model.NewOAuth2DeviceCodeSessionFromRequest(oidc.RFC8628DeviceCodeSignature("device-code"), oidc.RFC8628UserCodeSignature(testDeviceUserCode), requester)
+
+	require.NoError(t, err)
+
+	return device
+}
+
+func setDeviceAuthorizationTestUserSession(t *testing.T, mock *mocks.MockAutheliaCtx, level authentication.Level) {
+	us, err := mock.Ctx.GetSession()
+
+	require.NoError(t, err)
+
+	us.Username = testUsername
+	us.AuthenticationLevel = level
+	us.FirstFactorAuthnTimestamp = mock.Clock.Now().Unix()
+
+	require.NoError(t, mock.Ctx.SaveSession(us))
+}
+
+func TestOpenIDConnectDeviceAuthorizationPOST(t *testing.T) {
+	testCases := []struct {
+		name      string
+		setup     func(t *testing.T, mock *mocks.MockAutheliaCtx)
+		form      url.Values
+		basic     []string
+		status    int
+		expected  string
+		expectedf func(t *testing.T, mock *mocks.MockAutheliaCtx, rw *httptest.ResponseRecorder)
+	}{
+		{
+			"ShouldFailWithoutClientID",
+			nil,
+			url.Values{},
+			nil,
+			http.StatusUnauthorized,
+			oauthelia2.ErrInvalidClient.ErrorField,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx, rw *httptest.ResponseRecorder) {
+				assert.Equal(t, `Basic realm="authelia"`, rw.Header().Get(fasthttp.HeaderWWWAuthenticate))
+			},
+		},
+		{
+			"ShouldFailUnknownClient",
+			nil,
+			url.Values{oidc.FormParameterClientID: []string{"unknown"}},
+			nil,
+			http.StatusUnauthorized,
+			oauthelia2.ErrInvalidClient.ErrorField,
+			nil,
+		},
+		{
+			"ShouldFailWrongAuthenticationMethod",
+			nil,
+			url.Values{oidc.FormParameterClientID: []string{testDeviceClientIDConfidential}, oidc.FormParameterClientSecret: []string{testDeviceClientSecret}},
+			nil,
+			http.StatusUnauthorized,
+			oauthelia2.ErrInvalidClient.ErrorField,
+			nil,
+		},
+		{
+			"ShouldFailBadClientSecret",
+			nil,
+			url.Values{},
+			[]string{testDeviceClientIDConfidential, "bad-secret"},
+			http.StatusUnauthorized,
+			oauthelia2.ErrInvalidClient.ErrorField,
+			nil,
+		},
+		{
+			"ShouldFailClientWithoutGrantType",
+			nil,
+			url.Values{oidc.FormParameterClientID: []string{testDeviceClientIDNoGrant}},
+			nil,
+			http.StatusBadRequest,
+			oauthelia2.ErrUnauthorizedClient.ErrorField,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx, rw *httptest.ResponseRecorder) {
+				assert.Equal(t, "Device Authorization Request on client with id 'device-no-grant' failed with error: the client is not permitted to use the 'urn:ietf:params:oauth:grant-type:device_code' grant type", mock.Hook.LastEntry().Message)
+			},
+		},
+		{
+			"ShouldFailScopeNotPermitted",
+			nil,
+			url.Values{oidc.FormParameterClientID: []string{testDeviceClientIDPublic}, oidc.FormParameterScope: []string{"openid groups"}},
+			nil,
+			http.StatusBadRequest,
+			oauthelia2.ErrInvalidScope.ErrorField,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx, rw *httptest.ResponseRecorder) {
+				assert.Equal(t, "Device Authorization Request on client with id 'device-public' failed with error: the client is not permitted to request the scope 'groups'", mock.Hook.LastEntry().Message)
+			},
+		},
+		{
+			"ShouldFailStorageError",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().SaveOAuth2DeviceCodeSession(gomock.Any(), gomock.Any()).Return(fmt.Errorf("bad block"))
+			},
+			url.Values{oidc.FormParameterClientID: []string{testDeviceClientIDPublic}, oidc.FormParameterScope: []string{oidc.ScopeOpenID}},
+			nil,
+			http.StatusInternalServerError,
+			oauthelia2.ErrServerError.ErrorField,
+			nil,
+		},
+		{
+			"ShouldSucceedPublicClient",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().SaveOAuth2DeviceCodeSession(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, session model.OAuth2DeviceCodeSession) error {
+						assert.Equal(t, testDeviceClientIDPublic, session.ClientID)
+						assert.Equal(t, model.OAuth2DeviceCodeStatusPending, session.Status)
+						assert.True(t, session.Active)
+						assert.Equal(t, model.StringSlicePipeDelimited{oidc.ScopeOpenID, oidc.ScopeProfile}, session.RequestedScopes)
+						assert.NotContains(t, session.Form, oidc.FormParameterClientSecret)
+
+						return nil
+					})
+			},
+			url.Values{oidc.FormParameterClientID: []string{testDeviceClientIDPublic}, oidc.FormParameterScope: []string{"openid profile"}},
+			nil,
+			http.StatusOK,
+			"",
+			nil,
+		},
+		{
+			"ShouldSucceedConfidentialClient",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().SaveOAuth2DeviceCodeSession(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			url.Values{oidc.FormParameterScope: []string{oidc.ScopeOpenID}},
+			[]string{testDeviceClientIDConfidential, testDeviceClientSecret},
+			http.StatusOK,
+			"",
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := newDeviceAuthorizationTestMock(t)
+
+			defer mock.Close()
+
+			if tc.setup != nil {
+				tc.setup(t, mock)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "https://example.com/api/oidc/device-authorization", strings.NewReader(tc.form.Encode()))
+			req.Header.Set(fasthttp.HeaderContentType, "application/x-www-form-urlencoded")
+
+			if tc.basic != nil {
+				req.SetBasicAuth(tc.basic[0], tc.basic[1])
+			}
+
+			rw := httptest.NewRecorder()
+
+			OpenIDConnectDeviceAuthorizationPOST(mock.Ctx, rw, req)
+
+			assert.Equal(t, tc.status, rw.Code)
+
+			body := map[string]any{}
+
+			require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &body))
+
+			if tc.expected != "" {
+				assert.Equal(t, tc.expected, body["error"])
+			} else {
+				assert.Equal(t, "no-store", rw.Header().Get(fasthttp.HeaderCacheControl))
+
+				userCode, ok := body["user_code"].(string)
+
+				require.True(t, ok)
+				assert.NotEmpty(t, body["device_code"])
+				assert.Equal(t, "https://example.com/device", body["verification_uri"])
+				assert.Equal(t, "https://example.com/device?user_code="+url.QueryEscape(userCode), body["verification_uri_complete"])
+				assert.Greater(t, body["expires_in"], float64(0))
+				assert.Greater(t, body["interval"], float64(0))
+			}
+
+			if tc.expectedf != nil {
+				tc.expectedf(t, mock, rw)
+			}
+		})
+	}
+}
+
+func TestOpenIDConnectDeviceCodeUserVerificationGET(t *testing.T) {
+	testCases := []struct {
+		name      string
+		setup     func(t *testing.T, mock *mocks.MockAutheliaCtx)
+		userCode  string
+		status    int
+		expected  string
+		expectedf func(t *testing.T, mock *mocks.MockAutheliaCtx)
+	}{
+		{
+			"ShouldFailAnonymous",
+			nil,
+			testDeviceUserCode,
+			fasthttp.StatusForbidden,
+			"",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				assert.Equal(t, "Unable to perform device code user verification: the user is not logged in", mock.Hook.LastEntry().Message)
+			},
+		},
+		{
+			"ShouldFailRevokedSession",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				id := uuid.MustParse("6a7b8c9d-0e1f-4a2b-9c3d-4e5f6a7b8c9d")
+
+				us, err := mock.Ctx.GetSession()
+
+				require.NoError(t, err)
+
+				us.Username = testUsername
+				us.AuthenticationLevel = authentication.OneFactor
+				us.PublicID = id
+
+				require.NoError(t, mock.Ctx.SaveSession(us))
+
+				mock.StorageMock.EXPECT().LoadUserSession(mock.Ctx, id).Return(&model.UserSession{PublicID: id, Username: testUsername, RevokedAt: sql.NullTime{Time: mock.Clock.Now(), Valid: true}}, nil)
+			},
+			testDeviceUserCode,
+			fasthttp.StatusForbidden,
+			"",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				assert.Equal(t, "Unable to perform device code user verification: the user is not logged in", mock.Hook.LastEntry().Message)
+			},
+		},
+		{
+			"ShouldFailWithoutUserCode",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				setDeviceAuthorizationTestUserSession(t, mock, authentication.OneFactor)
+			},
+			"",
+			fasthttp.StatusOK,
+			`{"status":"KO","message":"Operation failed."}`,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				assert.Equal(t, "Unable to perform device code user verification for user 'john': the user code was not provided", mock.Hook.LastEntry().Message)
+			},
+		},
+		{
+			"ShouldFailStorageError",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				setDeviceAuthorizationTestUserSession(t, mock, authentication.OneFactor)
+
+				mock.StorageMock.EXPECT().LoadOAuth2DeviceCodeSessionByUserCode(mock.Ctx, oidc.RFC8628UserCodeSignature(testDeviceUserCode)).Return(nil, fmt.Errorf("bad block"))
+			},
+			testDeviceUserCode,
+			fasthttp.StatusOK,
+			`{"status":"KO","message":"Operation failed."}`,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				assert.Equal(t, "Unable to load device code session for user 'john': bad block", mock.Hook.LastEntry().Message)
+			},
+		},
+		{
+			"ShouldFailNotPending",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				setDeviceAuthorizationTestUserSession(t, mock, authentication.OneFactor)
+
+				device := newDeviceAuthorizationTestSession(t, mock, testDeviceClientIDPublic, mock.Clock.Now())
+				device.Status = model.OAuth2DeviceCodeStatusApproved
+
+				mock.StorageMock.EXPECT().LoadOAuth2DeviceCodeSessionByUserCode(mock.Ctx, oidc.RFC8628UserCodeSignature(testDeviceUserCode)).Return(device, nil)
+			},
+			testDeviceUserCode,
+			fasthttp.StatusOK,
+			`{"status":"KO","message":"Operation failed."}`,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				assert.Equal(t, "Unable to perform device code user verification for user 'john' with request id '4a8b3c2d-1e0f-4a5b-8c7d-6e5f4a3b2c1d': the device code session is no longer pending", mock.Hook.LastEntry().Message)
+			},
+		},
+		{
+			"ShouldFailExpired",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				setDeviceAuthorizationTestUserSession(t, mock, authentication.OneFactor)
+
+				device := newDeviceAuthorizationTestSession(t, mock, testDeviceClientIDPublic, mock.Clock.Now().Add(-time.Hour))
+
+				mock.StorageMock.EXPECT().LoadOAuth2DeviceCodeSessionByUserCode(mock.Ctx, oidc.RFC8628UserCodeSignature(testDeviceUserCode)).Return(device, nil)
+			},
+			testDeviceUserCode,
+			fasthttp.StatusOK,
+			`{"status":"KO","message":"Operation failed."}`,
+			nil,
+		},
+		{
+			"ShouldSucceed",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				setDeviceAuthorizationTestUserSession(t, mock, authentication.OneFactor)
+
+				device := newDeviceAuthorizationTestSession(t, mock, testDeviceClientIDPublic, mock.Clock.Now())
+
+				mock.StorageMock.EXPECT().LoadOAuth2DeviceCodeSessionByUserCode(mock.Ctx, oidc.RFC8628UserCodeSignature(testDeviceUserCode)).Return(device, nil)
+			},
+			testDeviceUserCode,
+			fasthttp.StatusOK,
+			`{"status":"OK","data":{"client_id":"device-public","client_description":"","scopes":["openid"],"audience":[],"pre_configuration":false}}`,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := newDeviceAuthorizationTestMock(t)
+
+			defer mock.Close()
+
+			if tc.setup != nil {
+				tc.setup(t, mock)
+			}
+
+			mock.Ctx.Request.Header.SetMethod(fasthttp.MethodGet)
+			mock.Ctx.Request.URI().QueryArgs().Set(queryArgUserCode, tc.userCode)
+
+			OpenIDConnectDeviceCodeUserVerificationGET(mock.Ctx)
+
+			assert.Equal(t, tc.status, mock.Ctx.Response.StatusCode())
+
+			if tc.expected != "" {
+				assert.Equal(t, tc.expected, string(mock.Ctx.Response.Body()))
+			}
+
+			if tc.expectedf != nil {
+				tc.expectedf(t, mock)
+			}
+		})
+	}
+}
+
+func TestOpenIDConnectDeviceCodeUserVerificationPOST(t *testing.T) {
+	subject := uuid.MustParse("5b6c7d8e-9f0a-4b1c-8d2e-3f4a5b6c7d8e")
+
+	expectConsent := func(mock *mocks.MockAutheliaCtx, authorized bool) {
+		var saved model.OAuth2ConsentSession
+
+		mock.StorageMock.EXPECT().SaveOAuth2ConsentSession(mock.Ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, consent model.OAuth2ConsentSession) error {
+				saved = consent
+
+				return nil
+			})
+
+		mock.StorageMock.EXPECT().LoadOAuth2ConsentSessionByChallengeID(mock.Ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ uuid.UUID) (*model.OAuth2ConsentSession, error) {
+				saved.ID = 1
+
+				return &saved, nil
+			})
+
+		mock.StorageMock.EXPECT().SaveOAuth2ConsentSessionResponse(mock.Ctx, gomock.Any(), authorized).Return(nil)
+
+		if authorized {
+			mock.StorageMock.EXPECT().SaveOAuth2ConsentSessionGranted(mock.Ctx, 1).Return(nil)
+		}
+	}
+
+	testCases := []struct {
+		name      string
+		setup     func(t *testing.T, mock *mocks.MockAutheliaCtx)
+		body      string
+		expected  string
+		expectedf func(t *testing.T, mock *mocks.MockAutheliaCtx)
+	}{
+		{
+			"ShouldFailBadJSON",
+			nil,
+			"not json",
+			`{"status":"KO","message":"Operation failed."}`,
+			nil,
+		},
+		{
+			"ShouldFailAnonymous",
+			nil,
+			`{"user_code":"BCDF-GHJK","consent":true}`,
+			"",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				assert.Equal(t, fasthttp.StatusForbidden, mock.Ctx.Response.StatusCode())
+			},
+		},
+		{
+			"ShouldFailInsufficientAuthenticationLevel",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				setDeviceAuthorizationTestUserSession(t, mock, authentication.OneFactor)
+
+				device := newDeviceAuthorizationTestSession(t, mock, testDeviceClientIDConfidential, mock.Clock.Now())
+
+				mock.StorageMock.EXPECT().LoadOAuth2DeviceCodeSessionByUserCode(mock.Ctx, oidc.RFC8628UserCodeSignature(testDeviceUserCode)).Return(device, nil)
+			},
+			`{"user_code":"BCDF-GHJK","consent":true}`,
+			`{"status":"KO","message":"Operation failed."}`,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				assert.Equal(t, "User 'john' can't respond to device authorization request with id '4a8b3c2d-1e0f-4a5b-8c7d-6e5f4a3b2c1d' for client with id 'device-confidential' as they are not sufficiently authenticated", mock.Hook.LastEntry().Message)
+			},
+		},
+		{
+			"ShouldFailUserDetailsError",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				setDeviceAuthorizationTestUserSession(t, mock, authentication.OneFactor)
+
+				device := newDeviceAuthorizationTestSession(t, mock, testDeviceClientIDPublic, mock.Clock.Now())
+
+				gomock.InOrder(
+					mock.StorageMock.EXPECT().LoadOAuth2DeviceCodeSessionByUserCode(mock.Ctx, oidc.RFC8628UserCodeSignature(testDeviceUserCode)).Return(device, nil),
+					mock.UserProviderMock.EXPECT().GetDetails(testUsername).Return(nil, fmt.Errorf("bad user")),
+				)
+			},
+			`{"user_code":"BCDF-GHJK","consent":true}`,
+			`{"status":"KO","message":"Operation failed."}`,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Device authorization request with id '4a8b3c2d-1e0f-4a5b-8c7d-6e5f4a3b2c1d' on client with id 'device-public' could not be processed: error occurred retrieving user details for 'john' from the backend", "bad user")
+			},
+		},
+		{
+			"ShouldFailUpdateError",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				setDeviceAuthorizationTestUserSession(t, mock, authentication.OneFactor)
+
+				device := newDeviceAuthorizationTestSession(t, mock, testDeviceClientIDPublic, mock.Clock.Now())
+
+				mock.StorageMock.EXPECT().LoadOAuth2DeviceCodeSessionByUserCode(mock.Ctx, oidc.RFC8628UserCodeSignature(testDeviceUserCode)).Return(device, nil)
+				mock.UserProviderMock.EXPECT().GetDetails(testUsername).Return(&authentication.UserDetails{Username: testUsername}, nil)
+				mock.StorageMock.EXPECT().LoadUserOpaqueIdentifierBySignature(mock.Ctx, "openid", "", testUsername).Return(&model.UserOpaqueIdentifier{Identifier: subject}, nil)
+
+				expectConsent(mock, true)
+
+				mock.StorageMock.EXPECT().UpdateOAuth2DeviceCodeSession(mock.Ctx, gomock.Any()).Return(fmt.Errorf("bad block"))
+			},
+			`{"user_code":"BCDF-GHJK","consent":true}`,
+			`{"status":"KO","message":"Operation failed."}`,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				assert.Equal(t, "Device authorization request with id '4a8b3c2d-1e0f-4a5b-8c7d-6e5f4a3b2c1d' on client with id 'device-public' could not be processed: error occurred saving the response: bad block", mock.Hook.LastEntry().Message)
+			},
+		},
+		{
+			"ShouldSucceedApproved",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				setDeviceAuthorizationTestUserSession(t, mock, authentication.OneFactor)
+
+				device := newDeviceAuthorizationTestSession(t, mock, testDeviceClientIDPublic, mock.Clock.Now())
+
+				mock.StorageMock.EXPECT().LoadOAuth2DeviceCodeSessionByUserCode(mock.Ctx, oidc.RFC8628UserCodeSignature(testDeviceUserCode)).Return(device, nil)
+				mock.UserProviderMock.EXPECT().GetDetails(testUsername).Return(&authentication.UserDetails{Username: testUsername}, nil)
+				mock.StorageMock.EXPECT().LoadUserOpaqueIdentifierBySignature(mock.Ctx, "openid", "", testUsername).Return(&model.UserOpaqueIdentifier{Identifier: subject}, nil)
+
+				expectConsent(mock, true)
+
+				mock.StorageMock.EXPECT().UpdateOAuth2DeviceCodeSession(mock.Ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, session model.OAuth2DeviceCodeSession) error {
+						assert.Equal(t, device.Signature, session.Signature)
+						assert.Equal(t, device.UserCodeSignature, session.UserCodeSignature)
+						assert.Equal(t, model.OAuth2DeviceCodeStatusApproved, session.Status)
+						assert.Equal(t, model.StringSlicePipeDelimited{oidc.ScopeOpenID}, session.GrantedScopes)
+						assert.Equal(t, sql.NullString{String: subject.String(), Valid: true}, session.Subject)
+
+						return nil
+					})
+			},
+			`{"user_code":"BCDF-GHJK","consent":true}`,
+			`{"status":"OK"}`,
+			nil,
+		},
+		{
+			"ShouldSucceedDenied",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				setDeviceAuthorizationTestUserSession(t, mock, authentication.OneFactor)
+
+				device := newDeviceAuthorizationTestSession(t, mock, testDeviceClientIDPublic, mock.Clock.Now())
+
+				mock.StorageMock.EXPECT().LoadOAuth2DeviceCodeSessionByUserCode(mock.Ctx, oidc.RFC8628UserCodeSignature(testDeviceUserCode)).Return(device, nil)
+				mock.UserProviderMock.EXPECT().GetDetails(testUsername).Return(&authentication.UserDetails{Username: testUsername}, nil)
+				mock.StorageMock.EXPECT().LoadUserOpaqueIdentifierBySignature(mock.Ctx, "openid", "", testUsername).Return(&model.UserOpaqueIdentifier{Identifier: subject}, nil)
+
+				expectConsent(mock, false)
+
+				mock.StorageMock.EXPECT().UpdateOAuth2DeviceCodeSession(mock.Ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, session model.OAuth2DeviceCodeSession) error {
+						assert.Equal(t, model.OAuth2DeviceCodeStatusDenied, session.Status)
+						assert.Empty(t, session.GrantedScopes)
+
+						return nil
+					})
+			},
+			`{"user_code":"BCDF-GHJK","consent":false}`,
+			`{"status":"OK"}`,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := newDeviceAuthorizationTestMock(t)
+
+			defer mock.Close()
+
+			if tc.setup != nil {
+				tc.setup(t, mock)
+			}
+
+			mock.Ctx.Request.Header.SetMethod(fasthttp.MethodPost)
+			mock.Ctx.Request.SetBodyString(tc.body)
+
+			OpenIDConnectDeviceCodeUserVerificationPOST(mock.Ctx)
+
+			if tc.expected != "" {
+				assert.Equal(t, tc.expected, string(mock.Ctx.Response.Body()))
+			}
+
+			if tc.expectedf != nil {
+				tc.expectedf(t, mock)
+			}
+		})
+	}
+}
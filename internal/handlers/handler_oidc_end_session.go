@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/oidc"
+	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/storage"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// OpenIDConnectEndSession handles GET/POST requests to the OpenID Connect 1.0 RP-Initiated Logout endpoint.
+//
+// https://openid.net/specs/openid-connect-rpinitiated-1_0.html
+//
+//nolint:gocyclo
+func OpenIDConnectEndSession(ctx *middlewares.AutheliaCtx) {
+	var (
+		args        *fasthttp.Args
+		hint        *oidc.IDTokenHintClaims
+		client      oidc.Client
+		userSession session.UserSession
+		redirectURI *url.URL
+		err         error
+	)
+
+	if ctx.IsPost() {
+		args = ctx.PostArgs()
+	} else {
+		args = ctx.QueryArgs()
+	}
+
+	issuer := ctx.RootURL()
+
+	clientID := string(args.Peek(oidc.FormParameterClientID))
+
+	if value := string(args.Peek(oidc.FormParameterIDTokenHint)); value != "" {
+		if hint, err = ctx.Providers.OpenIDConnect.DecodeIDTokenHint(ctx, issuer.String(), value); err != nil {
+			ctx.Logger.WithError(err).Error("End Session Request could not be processed: the 'id_token_hint' is not valid")
+
+			ctx.ReplyBadRequest()
+
+			return
+		}
+
+		switch {
+		case clientID == "" && len(hint.Audience) == 1:
+			clientID = hint.Audience[0]
+		case clientID != "" && !utils.IsStringInSlice(clientID, hint.Audience):
+			ctx.Logger.Errorf("End Session Request could not be processed: the client with id '%s' is not an audience of the 'id_token_hint'", clientID)
+
+			ctx.ReplyBadRequest()
+
+			return
+		}
+	}
+
+	if clientID != "" {
+		if client, err = ctx.Providers.OpenIDConnect.GetRegisteredClient(ctx, clientID); err != nil {
+			ctx.Logger.WithError(err).Errorf("End Session Request could not be processed: failed to find client with id '%s'", clientID)
+
+			ctx.ReplyBadRequest()
+
+			return
+		}
+	}
+
+	if value := string(args.Peek(oidc.FormParameterPostLogoutRedirectURI)); value != "" {
+		if client == nil {
+			ctx.Logger.Errorf("End Session Request could not be processed: the 'post_logout_redirect_uri' value '%s' was provided without identifying the client", value)
+
+			ctx.ReplyBadRequest()
+
+			return
+		}
+
+		if !utils.IsStringInSlice(value, client.GetPostLogoutRedirectURIs()) {
+			ctx.Logger.Errorf("End Session Request could not be processed: the 'post_logout_redirect_uri' value '%s' is not registered for the client with id '%s'", value, client.GetID())
+
+			ctx.ReplyBadRequest()
+
+			return
+		}
+
+		if redirectURI, err = url.Parse(value); err != nil {
+			ctx.Logger.WithError(err).Errorf("End Session Request could not be processed: the 'post_logout_redirect_uri' value '%s' could not be parsed", value)
+
+			ctx.ReplyBadRequest()
+
+			return
+		}
+
+		if state := string(args.Peek(oidc.FormParameterState)); state != "" {
+			query := redirectURI.Query()
+
+			query.Set(oidc.FormParameterState, state)
+
+			redirectURI.RawQuery = query.Encode()
+		}
+	} else {
+		redirectURI = ctx.RootURLSlash()
+	}
+
+	if userSession, err = ctx.GetSession(); err != nil {
+		ctx.Logger.WithError(err).Error("End Session Request could not be processed: error occurred obtaining session information")
+
+		ctx.ReplyStatusCode(fasthttp.StatusInternalServerError)
+
+		return
+	}
+
+	// A GET request without an 'id_token_hint' may not have been initiated by the user, so the user is asked to confirm
+	// the logout which is then performed by a POST request. The session cookie is not sent with a cross-site POST request
+	// so the confirmation can't be submitted by another site.
+	if !ctx.IsPost() && hint == nil && !userSession.IsAnonymous() {
+		handleOpenIDConnectEndSessionConfirm(ctx, issuer, args)
+
+		return
+	}
+
+	var frontchannel []*url.URL
+
+	switch {
+	case userSession.IsAnonymous():
+		ctx.Logger.Debug("End Session Request did not log out a user as the user is not logged in")
+	case hint != nil && hint.SessionID != "" && hint.SessionID != userSession.PublicID.String():
+		ctx.Logger.Debugf("End Session Request did not log out user '%s' as the 'id_token_hint' was issued for a different session", userSession.Username)
+	default:
+		if userSession.PublicID != uuid.Nil {
+			if err = ctx.Providers.StorageProvider.RevokeUserSession(ctx, userSession.Username, userSession.PublicID, ctx.Clock.Now()); err != nil && !errors.Is(err, storage.ErrNoUserSession) {
+				ctx.Logger.WithError(err).Errorf("End Session Request could not revoke the session index entry for user '%s'", userSession.Username)
+			}
+		}
+
+		frontchannel = handleOpenIDConnectLogout(ctx, userSession.Username, userSession.PublicID)
+
+		if err = ctx.DestroySession(); err != nil {
+			ctx.Logger.WithError(err).Errorf("End Session Request could not destroy the session for user '%s'", userSession.Username)
+		}
+	}
+
+	if len(frontchannel) == 0 {
+		ctx.Redirect(redirectURI.String(), fasthttp.StatusFound)
+
+		return
+	}
+
+	var sources []string
+
+	for _, uri := range frontchannel {
+		if origin := fmt.Sprintf("%s://%s", uri.Scheme, uri.Host); !utils.IsStringInSlice(origin, sources) {
+			sources = append(sources, origin)
+		}
+	}
+
+	ctx.SetUserValue(middlewares.UserValueKeyOpenIDConnectFrameSources, sources)
+
+	ctx.SetContentTypeTextHTML()
+	ctx.SetStatusCode(fasthttp.StatusOK)
+
+	if err = ctx.Providers.Templates.GetOpenIDConnectEndSessionTemplate().Execute(ctx.Response.BodyWriter(), oidcEndSessionData{
+		RedirectURI:            redirectURI.String(),
+		FrontChannelLogoutURIs: frontchannel,
+	}); err != nil {
+		ctx.Logger.WithError(err).Error("End Session Request could not render the front-channel logout response")
+
+		ctx.Redirect(redirectURI.String(), fasthttp.StatusFound)
+	}
+}
+
+type oidcEndSessionData struct {
+	RedirectURI            string
+	FrontChannelLogoutURIs []*url.URL
+}
+
+// handleOpenIDConnectEndSessionConfirm renders a form which asks the user to confirm the logout and submits the
+// parameters of the End Session Request to the End Session Endpoint.
+func handleOpenIDConnectEndSessionConfirm(ctx *middlewares.AutheliaCtx, issuer *url.URL, args *fasthttp.Args) {
+	parameters := url.Values{}
+
+	args.VisitAll(func(key, value []byte) {
+		parameters.Add(string(key), string(value))
+	})
+
+	ctx.Logger.Debug("End Session Request requires confirmation from the user as it was not made with an 'id_token_hint'")
+
+	ctx.SetContentTypeTextHTML()
+	ctx.SetStatusCode(fasthttp.StatusOK)
+
+	if err := ctx.Providers.Templates.GetOpenIDConnectEndSessionConfirmTemplate().Execute(ctx.Response.BodyWriter(), oidcEndSessionConfirmData{
+		Action:     fmt.Sprintf("%s%s", issuer.String(), oidc.EndpointPathEndSession),
+		Parameters: parameters,
+		CancelURI:  ctx.RootURLSlash().String(),
+	}); err != nil {
+		ctx.Logger.WithError(err).Error("End Session Request could not render the logout confirmation response")
+
+		ctx.Response.ResetBody()
+		ctx.ReplyStatusCode(fasthttp.StatusInternalServerError)
+	}
+}
+
+type oidcEndSessionConfirmData struct {
+	Action     string
+	Parameters url.Values
+	CancelURI  string
+}
+
+// handleOpenIDConnectLogout informs each OpenID Connect 1.0 client which holds a session for the user session that the
+// user has logged out. The Back-Channel Logout is performed in the background so the response isn't delayed by the
+// clients, and the Front-Channel Logout URIs are returned for callers which are able to render them to the user agent.
+func handleOpenIDConnectLogout(ctx *middlewares.AutheliaCtx, username string, publicID uuid.UUID) (frontchannel []*url.URL) {
+	if publicID == uuid.Nil || ctx.Providers.OpenIDConnect == nil {
+		return nil
+	}
+
+	var (
+		sessions    []model.OAuth2ClientSession
+		backchannel <-chan error
+		err         error
+	)
+
+	if sessions, err = ctx.Providers.StorageProvider.LoadOAuth2ClientSessions(ctx, publicID); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred loading the OpenID Connect 1.0 client sessions for user '%s' during logout", username)
+
+		return nil
+	}
+
+	if frontchannel, backchannel, err = ctx.Providers.OpenIDConnect.LogoutClientSessions(ctx, sessions, ctx.Clock.Now()); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred informing one or more OpenID Connect 1.0 clients that user '%s' has logged out", username)
+	}
+
+	go func(log *logrus.Entry) {
+		for err := range backchannel {
+			log.WithError(err).Errorf("Error occurred performing the OpenID Connect 1.0 Back-Channel Logout for user '%s'", username)
+		}
+	}(ctx.Logger)
+
+	return frontchannel
+}
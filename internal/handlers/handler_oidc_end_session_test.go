@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/oidc"
+)
+
+func newEndSessionTestMock(t *testing.T, username string) *mocks.MockAutheliaCtx {
+	mock := mocks.NewMockAutheliaCtx(t)
+
+	config := &schema.IdentityProvidersOpenIDConnect{
+		Clients: []schema.IdentityProvidersOpenIDConnectClient{
+			{
+				ID:                     "app",
+				Public:                 true,
+				AuthorizationPolicy:    "one_factor",
+				PostLogoutRedirectURIs: []string{"https://app.example.com/logged-out"},
+			},
+		},
+	}
+
+	mock.Ctx.Providers.OpenIDConnect = oidc.NewOpenIDConnectProvider(config, mock.StorageMock, mock.Ctx.Providers.Templates)
+	mock.Ctx.Request.Header.Set(fasthttp.HeaderXForwardedProto, "https")
+
+	if username != "" {
+		us, err := mock.Ctx.GetSession()
+
+		require.NoError(t, err)
+
+		us.Username = username
+		us.AuthenticationLevel = authentication.OneFactor
+
+		require.NoError(t, mock.Ctx.SaveSession(us))
+	}
+
+	return mock
+}
+
+func TestOpenIDConnectEndSession(t *testing.T) {
+	testCases := []struct {
+		name     string
+		method   string
+		username string
+		args     string
+		status   int
+		location string
+		body     []string
+		loggedIn bool
+	}{
+		{
+			"ShouldAskForConfirmationGETWithoutHint",
+			fasthttp.MethodGet,
+			testUsername,
+			"client_id=app&post_logout_redirect_uri=https%3A%2F%2Fapp.example.com%2Flogged-out&state=abc",
+			fasthttp.StatusOK,
+			"",
+			[]string{
+				`<form method="post" action="https://example.com/api/oidc/end-session">`,
+				`<input type="hidden" name="client_id" value="app"/>`,
+				`<input type="hidden" name="post_logout_redirect_uri" value="https://app.example.com/logged-out"/>`,
+				`<input type="hidden" name="state" value="abc"/>`,
+				`<a href="https://example.com/">Cancel</a>`,
+			},
+			true,
+		},
+		{
+			"ShouldLogoutPOSTWithoutHint",
+			fasthttp.MethodPost,
+			testUsername,
+			"client_id=app&post_logout_redirect_uri=https%3A%2F%2Fapp.example.com%2Flogged-out&state=abc",
+			fasthttp.StatusFound,
+			"https://app.example.com/logged-out?state=abc",
+			nil,
+			false,
+		},
+		{
+			"ShouldRedirectAnonymousGETWithoutHint",
+			fasthttp.MethodGet,
+			"",
+			"",
+			fasthttp.StatusFound,
+			"https://example.com/",
+			nil,
+			false,
+		},
+		{
+			"ShouldFailGETUnregisteredRedirectURI",
+			fasthttp.MethodGet,
+			testUsername,
+			"client_id=app&post_logout_redirect_uri=https%3A%2F%2Fevil.example.com%2F",
+			fasthttp.StatusBadRequest,
+			"",
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := newEndSessionTestMock(t, tc.username)
+
+			defer mock.Close()
+
+			mock.Ctx.Request.Header.SetMethod(tc.method)
+
+			if tc.method == fasthttp.MethodPost {
+				mock.Ctx.Request.SetRequestURI("/api/oidc/end-session")
+				mock.Ctx.Request.Header.SetContentType("application/x-www-form-urlencoded")
+				mock.Ctx.Request.SetBodyString(tc.args)
+			} else {
+				mock.Ctx.Request.SetRequestURI("/api/oidc/end-session?" + tc.args)
+			}
+
+			OpenIDConnectEndSession(mock.Ctx)
+
+			assert.Equal(t, tc.status, mock.Ctx.Response.StatusCode())
+			assert.Equal(t, tc.location, string(mock.Ctx.Response.Header.Peek(fasthttp.HeaderLocation)))
+
+			for _, body := range tc.body {
+				assert.Contains(t, string(mock.Ctx.Response.Body()), body)
+			}
+
+			us, err := mock.Ctx.GetSession()
+
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.loggedIn, !us.IsAnonymous())
+		})
+	}
+}
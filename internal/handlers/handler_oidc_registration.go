@@ -0,0 +1,428 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	oauthelia2 "authelia.com/provider/oauth2"
+	"authelia.com/provider/oauth2/x/errorsx"
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/algorithm/pbkdf2"
+	"github.com/google/uuid"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/configuration/validator"
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/oidc"
+	"github.com/authelia/authelia/v4/internal/random"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+// OpenIDConnectRegistrationPOST handles POST requests to the OAuth 2.0 Dynamic Client Registration endpoint.
+//
+// https://datatracker.ietf.org/doc/html/rfc7591#section-3
+func OpenIDConnectRegistrationPOST(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter, req *http.Request) {
+	var (
+		metadata   oidc.ClientRegistrationMetadata
+		config     schema.IdentityProvidersOpenIDConnectClient
+		registered *model.OAuth2RegisteredClient
+		clientID   uuid.UUID
+		secret     string
+		token      string
+		err        error
+	)
+
+	initial := ctx.Configuration.IdentityProviders.OIDC.DynamicClientRegistration.InitialAccessToken
+
+	if value := oauthelia2.AccessTokenFromRequest(req); value == "" || subtle.ConstantTimeCompare([]byte(value), []byte(initial)) != 1 {
+		ctx.Logger.Errorf("Registration Request failed with error: the initial access token was missing or did not match the configured value")
+
+		oidcRegistrationWriteError(rw, req, oidc.ErrInvalidRegistrationToken)
+
+		return
+	}
+
+	if err = json.NewDecoder(req.Body).Decode(&metadata); err != nil {
+		ctx.Logger.Errorf("Registration Request failed with error: error occurred decoding the client metadata: %+v", err)
+
+		oidcRegistrationWriteError(rw, req, oidc.ErrInvalidClientMetadata.WithHint("The client metadata could not be decoded.").WithWrap(err))
+
+		return
+	}
+
+	if clientID, err = uuid.NewRandomFromReader(ctx.Providers.Random); err != nil {
+		ctx.Logger.Errorf("Registration Request failed with error: error occurred generating the client id: %+v", err)
+
+		oidcRegistrationWriteError(rw, req, oauthelia2.ErrServerError)
+
+		return
+	}
+
+	if metadata.TokenEndpointAuthMethod != oidc.ClientAuthMethodNone {
+		secret = ctx.Providers.Random.StringCustom(72, random.CharSetAlphaNumeric)
+	}
+
+	if config, err = oidcRegistrationClientConfiguration(ctx, &metadata, clientID.String(), secret, nil); err != nil {
+		ctx.Logger.Errorf("Registration Request failed with error: %s", oauthelia2.ErrorToDebugRFC6749Error(err))
+
+		oidcRegistrationWriteError(rw, req, err)
+
+		return
+	}
+
+	token = ctx.Providers.Random.StringCustom(64, random.CharSetAlphaNumeric)
+
+	if registered, err = oidcRegistrationNewModel(ctx, config, token); err != nil {
+		ctx.Logger.Errorf("Registration Request for client with id '%s' failed with error: %+v", config.ID, err)
+
+		oidcRegistrationWriteError(rw, req, oauthelia2.ErrServerError)
+
+		return
+	}
+
+	if err = ctx.Providers.StorageProvider.SaveOAuth2RegisteredClient(ctx, *registered); err != nil {
+		ctx.Logger.Errorf("Registration Request for client with id '%s' failed with error: error occurred saving the client to the storage backend: %+v", config.ID, err)
+
+		oidcRegistrationWriteError(rw, req, oauthelia2.ErrServerError)
+
+		return
+	}
+
+	ctx.Logger.Infof("Registration Request for client with id '%s' was successful", config.ID)
+
+	oidcRegistrationWriteResponse(ctx, rw, req, http.StatusCreated, registered, config, secret, token)
+}
+
+// OpenIDConnectRegistrationClientGET handles GET requests to the OAuth 2.0 Dynamic Client Registration Management
+// Protocol Client Configuration endpoint.
+//
+// https://datatracker.ietf.org/doc/html/rfc7592#section-2.1
+func OpenIDConnectRegistrationClientGET(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter, req *http.Request) {
+	var (
+		metadata   oidc.ClientRegistrationMetadata
+		config     schema.IdentityProvidersOpenIDConnectClient
+		registered *model.OAuth2RegisteredClient
+		ok         bool
+		err        error
+	)
+
+	if registered, ok = oidcRegistrationLoadClient(ctx, rw, req); !ok {
+		return
+	}
+
+	if err = json.Unmarshal(registered.Metadata, &metadata); err == nil {
+		config, err = metadata.ToClientConfiguration(registered.ClientID, nil, ctx.Configuration.IdentityProviders.OIDC.DynamicClientRegistration.AuthorizationPolicy)
+	}
+
+	if err != nil {
+		ctx.Logger.Errorf("Client Configuration Request for client with id '%s' failed with error: error occurred decoding the client metadata: %s", registered.ClientID, oauthelia2.ErrorToDebugRFC6749Error(err))
+
+		oidcRegistrationWriteError(rw, req, oauthelia2.ErrServerError)
+
+		return
+	}
+
+	oidcRegistrationWriteResponse(ctx, rw, req, http.StatusOK, registered, config, "", "")
+}
+
+// OpenIDConnectRegistrationClientPUT handles PUT requests to the OAuth 2.0 Dynamic Client Registration Management
+// Protocol Client Configuration endpoint.
+//
+// https://datatracker.ietf.org/doc/html/rfc7592#section-2.2
+func OpenIDConnectRegistrationClientPUT(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter, req *http.Request) {
+	var (
+		request    oidc.ClientRegistrationUpdateRequest
+		config     schema.IdentityProvidersOpenIDConnectClient
+		registered *model.OAuth2RegisteredClient
+		digest     *schema.PasswordDigest
+		secret     string
+		ok         bool
+		err        error
+	)
+
+	if registered, ok = oidcRegistrationLoadClient(ctx, rw, req); !ok {
+		return
+	}
+
+	if err = json.NewDecoder(req.Body).Decode(&request); err != nil {
+		ctx.Logger.Errorf("Client Update Request for client with id '%s' failed with error: error occurred decoding the client metadata: %+v", registered.ClientID, err)
+
+		oidcRegistrationWriteError(rw, req, oidc.ErrInvalidClientMetadata.WithHint("The client metadata could not be decoded.").WithWrap(err))
+
+		return
+	}
+
+	if request.ClientID != registered.ClientID {
+		ctx.Logger.Errorf("Client Update Request for client with id '%s' failed with error: the client id '%s' in the request body does not match", registered.ClientID, request.ClientID)
+
+		oidcRegistrationWriteError(rw, req, oauthelia2.ErrInvalidRequest.WithHint("The 'client_id' value does not match the client being updated."))
+
+		return
+	}
+
+	if registered.ClientSecret != "" {
+		if digest, err = schema.DecodePasswordDigest(registered.ClientSecret); err != nil {
+			ctx.Logger.Errorf("Client Update Request for client with id '%s' failed with error: error occurred decoding the client secret: %+v", registered.ClientID, err)
+
+			oidcRegistrationWriteError(rw, req, oauthelia2.ErrServerError)
+
+			return
+		}
+
+		if request.ClientSecret != "" && !digest.Match(request.ClientSecret) {
+			ctx.Logger.Errorf("Client Update Request for client with id '%s' failed with error: the client secret in the request body does not match", registered.ClientID)
+
+			oidcRegistrationWriteError(rw, req, oauthelia2.ErrInvalidRequest.WithHint("The 'client_secret' value does not match the currently issued client secret."))
+
+			return
+		}
+	}
+
+	switch {
+	case request.TokenEndpointAuthMethod == oidc.ClientAuthMethodNone:
+		digest = nil
+	case digest == nil:
+		secret = ctx.Providers.Random.StringCustom(72, random.CharSetAlphaNumeric)
+	}
+
+	if config, err = oidcRegistrationClientConfiguration(ctx, &request.ClientRegistrationMetadata, registered.ClientID, secret, digest); err != nil {
+		ctx.Logger.Errorf("Client Update Request for client with id '%s' failed with error: %s", registered.ClientID, oauthelia2.ErrorToDebugRFC6749Error(err))
+
+		oidcRegistrationWriteError(rw, req, err)
+
+		return
+	}
+
+	if registered.Metadata, err = json.Marshal(oidc.NewClientRegistrationRecord(config)); err != nil {
+		ctx.Logger.Errorf("Client Update Request for client with id '%s' failed with error: error occurred encoding the client metadata: %+v", registered.ClientID, err)
+
+		oidcRegistrationWriteError(rw, req, oauthelia2.ErrServerError)
+
+		return
+	}
+
+	registered.UpdatedAt = ctx.Clock.Now()
+	registered.ClientSecret = ""
+
+	if config.Secret != nil {
+		registered.ClientSecret = config.Secret.Encode()
+	}
+
+	if err = ctx.Providers.StorageProvider.UpdateOAuth2RegisteredClient(ctx, *registered); err != nil {
+		ctx.Logger.Errorf("Client Update Request for client with id '%s' failed with error: error occurred saving the client to the storage backend: %+v", registered.ClientID, err)
+
+		oidcRegistrationWriteError(rw, req, oauthelia2.ErrServerError)
+
+		return
+	}
+
+	ctx.Logger.Infof("Client Update Request for client with id '%s' was successful", registered.ClientID)
+
+	oidcRegistrationWriteResponse(ctx, rw, req, http.StatusOK, registered, config, secret, "")
+}
+
+// OpenIDConnectRegistrationClientDELETE handles DELETE requests to the OAuth 2.0 Dynamic Client Registration
+// Management Protocol Client Configuration endpoint.
+//
+// https://datatracker.ietf.org/doc/html/rfc7592#section-2.3
+func OpenIDConnectRegistrationClientDELETE(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter, req *http.Request) {
+	var (
+		registered *model.OAuth2RegisteredClient
+		ok         bool
+		err        error
+	)
+
+	if registered, ok = oidcRegistrationLoadClient(ctx, rw, req); !ok {
+		return
+	}
+
+	if err = ctx.Providers.StorageProvider.DeleteOAuth2RegisteredClient(ctx, registered.ClientID); err != nil && !errors.Is(err, storage.ErrNoOAuth2RegisteredClient) {
+		ctx.Logger.Errorf("Client Delete Request for client with id '%s' failed with error: error occurred deleting the client from the storage backend: %+v", registered.ClientID, err)
+
+		oidcRegistrationWriteError(rw, req, oauthelia2.ErrServerError)
+
+		return
+	}
+
+	ctx.Logger.Infof("Client Delete Request for client with id '%s' was successful", registered.ClientID)
+
+	rw.Header().Set(fasthttp.HeaderCacheControl, "no-store")
+	rw.Header().Set(fasthttp.HeaderPragma, "no-cache")
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// oidcRegistrationLoadClient loads the dynamically registered client identified by the path and ensures the
+// registration access token is valid for it. If the client does not exist, has expired, or the token is invalid then
+// the error response is written and ok is false.
+func oidcRegistrationLoadClient(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter, req *http.Request) (registered *model.OAuth2RegisteredClient, ok bool) {
+	var err error
+
+	clientID, _ := ctx.UserValue("client_id").(string)
+
+	if registered, err = ctx.Providers.StorageProvider.LoadOAuth2RegisteredClient(ctx, clientID); err != nil {
+		if errors.Is(err, storage.ErrNoOAuth2RegisteredClient) {
+			ctx.Logger.Errorf("Client Configuration Request for client with id '%s' failed with error: the client does not exist", clientID)
+
+			oidcRegistrationWriteError(rw, req, oidc.ErrInvalidRegistrationToken)
+		} else {
+			ctx.Logger.Errorf("Client Configuration Request for client with id '%s' failed with error: error occurred loading the client from the storage backend: %+v", clientID, err)
+
+			oidcRegistrationWriteError(rw, req, oauthelia2.ErrServerError)
+		}
+
+		return nil, false
+	}
+
+	if registered.Expired(ctx.Clock.Now()) {
+		ctx.Logger.Errorf("Client Configuration Request for client with id '%s' failed with error: the client has expired", clientID)
+
+		oidcRegistrationWriteError(rw, req, oidc.ErrInvalidRegistrationToken)
+
+		return nil, false
+	}
+
+	if !oidc.IsRegistrationAccessTokenValid(registered, oauthelia2.AccessTokenFromRequest(req)) {
+		ctx.Logger.Errorf("Client Configuration Request for client with id '%s' failed with error: the registration access token was missing or invalid", clientID)
+
+		oidcRegistrationWriteError(rw, req, oidc.ErrInvalidRegistrationToken)
+
+		return nil, false
+	}
+
+	return registered, true
+}
+
+// oidcRegistrationClientConfiguration converts and validates the client metadata using the same rules as the clients
+// in the configuration, returning the validated and defaulted client configuration.
+func oidcRegistrationClientConfiguration(ctx *middlewares.AutheliaCtx, metadata *oidc.ClientRegistrationMetadata, clientID, secret string, digest *schema.PasswordDigest) (config schema.IdentityProvidersOpenIDConnectClient, err error) {
+	dcr := ctx.Configuration.IdentityProviders.OIDC.DynamicClientRegistration
+
+	if err = metadata.ValidateScopes(dcr.Scopes); err != nil {
+		return config, err
+	}
+
+	for _, uri := range metadata.RedirectURIs {
+		var u *url.URL
+
+		if u, err = url.ParseRequestURI(uri); err != nil || !u.IsAbs() || u.Fragment != "" {
+			return config, oidc.ErrInvalidRedirectURI.WithHintf("The redirect URI '%s' is not an absolute URI without a fragment.", uri)
+		}
+	}
+
+	if secret != "" {
+		var d algorithm.Digest
+
+		if d, err = oidcRegistrationHashSecret(secret); err != nil {
+			return config, oauthelia2.ErrServerError.WithWrap(err).WithDebug("Error occurred hashing the client secret.")
+		}
+
+		digest = schema.NewPasswordDigest(d)
+	}
+
+	if config, err = metadata.ToClientConfiguration(clientID, digest, dcr.AuthorizationPolicy); err != nil {
+		return config, err
+	}
+
+	if errs := validator.ValidateIdentityProvidersOpenIDConnectClient(&validator.ValidateCtx{Context: ctx}, ctx.Configuration.IdentityProviders.OIDC, &config); len(errs) != 0 {
+		hints := make([]string, len(errs))
+
+		for i, e := range errs {
+			hints[i] = e.Error()
+		}
+
+		return config, oidc.ErrInvalidClientMetadata.WithHint(strings.Join(hints, ", "))
+	}
+
+	return config, nil
+}
+
+// oidcRegistrationNewModel returns a new model.OAuth2RegisteredClient for a validated client configuration.
+func oidcRegistrationNewModel(ctx *middlewares.AutheliaCtx, config schema.IdentityProvidersOpenIDConnectClient, token string) (registered *model.OAuth2RegisteredClient, err error) {
+	now := ctx.Clock.Now()
+
+	registered = &model.OAuth2RegisteredClient{
+		ClientID:                         config.ID,
+		CreatedAt:                        now,
+		UpdatedAt:                        now,
+		RegistrationAccessTokenSignature: oidc.RegistrationAccessTokenSignature(token),
+	}
+
+	if lifespan := ctx.Configuration.IdentityProviders.OIDC.DynamicClientRegistration.ClientLifespan; lifespan > 0 {
+		registered.ExpiresAt = sql.NullTime{Time: now.Add(lifespan), Valid: true}
+	}
+
+	if config.Secret != nil {
+		registered.ClientSecret = config.Secret.Encode()
+	}
+
+	if registered.Metadata, err = json.Marshal(oidc.NewClientRegistrationRecord(config)); err != nil {
+		return nil, fmt.Errorf("error occurred encoding the client metadata: %w", err)
+	}
+
+	return registered, nil
+}
+
+func oidcRegistrationHashSecret(secret string) (digest algorithm.Digest, err error) {
+	var hash algorithm.Hash
+
+	if hash, err = pbkdf2.New(
+		pbkdf2.WithVariantName(schema.DefaultPasswordConfig.PBKDF2.Variant),
+		pbkdf2.WithIterations(schema.DefaultPasswordConfig.PBKDF2.Iterations),
+		pbkdf2.WithSaltLength(schema.DefaultPasswordConfig.PBKDF2.SaltLength),
+	); err != nil {
+		return nil, err
+	}
+
+	return hash.Hash(secret)
+}
+
+func oidcRegistrationWriteResponse(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter, req *http.Request, status int, registered *model.OAuth2RegisteredClient, config schema.IdentityProvidersOpenIDConnectClient, secret, token string) {
+	var (
+		issuer *url.URL
+		err    error
+	)
+
+	if issuer, err = ctx.IssuerURL(); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred determining issuer")
+
+		oidcRegistrationWriteError(rw, req, oauthelia2.ErrServerError)
+
+		return
+	}
+
+	response := oidc.ClientRegistrationResponse{
+		ClientID:                   registered.ClientID,
+		ClientSecret:               secret,
+		ClientIDIssuedAt:           registered.CreatedAt.Unix(),
+		RegistrationAccessToken:    token,
+		RegistrationClientURI:      fmt.Sprintf("%s%s/%s", issuer.String(), oidc.EndpointPathRegistration, registered.ClientID),
+		ClientRegistrationMetadata: oidc.NewClientRegistrationMetadata(config),
+	}
+
+	if registered.ExpiresAt.Valid && registered.ClientSecret != "" {
+		response.ClientSecretExpiresAt = registered.ExpiresAt.Time.Unix()
+	}
+
+	rw.Header().Set(fasthttp.HeaderContentType, "application/json; charset=utf-8")
+	rw.Header().Set(fasthttp.HeaderCacheControl, "no-store")
+	rw.Header().Set(fasthttp.HeaderPragma, "no-cache")
+	rw.WriteHeader(status)
+
+	_ = json.NewEncoder(rw).Encode(response)
+}
+
+func oidcRegistrationWriteError(rw http.ResponseWriter, req *http.Request, err error) {
+	if rfc := oauthelia2.ErrorToRFC6749Error(err); rfc.StatusCode() == http.StatusUnauthorized {
+		rw.Header().Set(fasthttp.HeaderWWWAuthenticate, fmt.Sprintf(`Bearer %s`, oidc.RFC6750Header("", "", rfc)))
+	}
+
+	errorsx.WriteJSONError(rw, req, err)
+}
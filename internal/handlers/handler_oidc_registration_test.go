@@ -0,0 +1,737 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	oauthelia2 "authelia.com/provider/oauth2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+	"go.uber.org/mock/gomock"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/oidc"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+const (
+	testRegistrationInitialToken = "initial-token"
+	testRegistrationAccessToken  = "registration-token"
+	testRegistrationClientID     = "a0b1c2d3-e4f5-4a6b-8c7d-9e0f1a2b3c4d"
+	testRegistrationClientSecret = "client-secret"
+	testRegistrationRedirectURI  = "https://app.example.com/callback"
+)
+
+func newRegistrationTestMock(t *testing.T) *mocks.MockAutheliaCtx {
+	mock := mocks.NewMockAutheliaCtx(t)
+
+	mock.Ctx.Configuration.IdentityProviders.OIDC = &schema.IdentityProvidersOpenIDConnect{
+		Discovery: schema.IdentityProvidersOpenIDConnectDiscovery{
+			AuthorizationPolicies: []string{"one_factor", "two_factor"},
+		},
+		DynamicClientRegistration: schema.IdentityProvidersOpenIDConnectDynamicClientRegistration{
+			Enable:              true,
+			InitialAccessToken:  testRegistrationInitialToken,
+			AuthorizationPolicy: "two_factor",
+			Scopes:              []string{oidc.ScopeOpenID, oidc.ScopeOfflineAccess, oidc.ScopeProfile},
+		},
+	}
+
+	mock.Ctx.Request.Header.Set(fasthttp.HeaderXForwardedProto, "https")
+
+	return mock
+}
+
+func newRegistrationTestClient(t *testing.T, public bool) *model.OAuth2RegisteredClient {
+	metadata := oidc.ClientRegistrationMetadata{
+		ClientName:              "example",
+		RedirectURIs:            []string{testRegistrationRedirectURI},
+		Scope:                   oidc.ScopeOpenID,
+		TokenEndpointAuthMethod: oidc.ClientAuthMethodClientSecretBasic,
+	}
+
+	registered := &model.OAuth2RegisteredClient{
+		ClientID:                         testRegistrationClientID,
+		CreatedAt:                        time.Unix(1000000, 0),
+		UpdatedAt:                        time.Unix(1000000, 0),
+		RegistrationAccessTokenSignature: oidc.RegistrationAccessTokenSignature(testRegistrationAccessToken),
+	}
+
+	if public {
+		metadata.TokenEndpointAuthMethod = oidc.ClientAuthMethodNone
+	} else {
+		registered.ClientSecret = "$plaintext$" + testRegistrationClientSecret
+	}
+
+	var err error
+
+	registered.Metadata, err = json.Marshal(metadata)
+
+	require.NoError(t, err)
+
+	return registered
+}
+
+func doRegistrationTestRequest(t *testing.T, mock *mocks.MockAutheliaCtx, handler func(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter, req *http.Request), method, token string, body any) (rw *httptest.ResponseRecorder) {
+	var payload string
+
+	switch b := body.(type) {
+	case nil:
+		break
+	case string:
+		payload = b
+	default:
+		data, err := json.Marshal(b)
+
+		require.NoError(t, err)
+
+		payload = string(data)
+	}
+
+	req := httptest.NewRequest(method, "https://example.com/api/oidc/registration", strings.NewReader(payload))
+	req.Header.Set(fasthttp.HeaderContentType, "application/json")
+
+	if token != "" {
+		req.Header.Set(fasthttp.HeaderAuthorization, "Bearer "+token)
+	}
+
+	rw = httptest.NewRecorder()
+
+	handler(mock.Ctx, rw, req)
+
+	return rw
+}
+
+func assertRegistrationTestResponse(t *testing.T, rw *httptest.ResponseRecorder, status int, expected string) (body map[string]any) {
+	assert.Equal(t, status, rw.Code)
+
+	if status == http.StatusNoContent {
+		return nil
+	}
+
+	body = map[string]any{}
+
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &body))
+
+	if expected != "" {
+		assert.Equal(t, expected, body["error"])
+
+		if expected == oidc.ErrInvalidRegistrationToken.ErrorField {
+			assert.True(t, strings.HasPrefix(rw.Header().Get(fasthttp.HeaderWWWAuthenticate), "Bearer "))
+		}
+	} else {
+		assert.Equal(t, "no-store", rw.Header().Get(fasthttp.HeaderCacheControl))
+		assert.NotContains(t, body, "error")
+	}
+
+	return body
+}
+
+func TestOpenIDConnectRegistrationPOST(t *testing.T) {
+	testCases := []struct {
+		name      string
+		setup     func(t *testing.T, mock *mocks.MockAutheliaCtx)
+		token     string
+		body      any
+		status    int
+		expected  string
+		expectedf func(t *testing.T, mock *mocks.MockAutheliaCtx, body map[string]any)
+	}{
+		{
+			"ShouldFailMissingInitialAccessToken",
+			nil,
+			"",
+			oidc.ClientRegistrationMetadata{RedirectURIs: []string{testRegistrationRedirectURI}},
+			http.StatusUnauthorized,
+			oidc.ErrInvalidRegistrationToken.ErrorField,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx, body map[string]any) {
+				assert.Equal(t, "Registration Request failed with error: the initial access token was missing or did not match the configured value", mock.Hook.LastEntry().Message)
+			},
+		},
+		{
+			"ShouldFailBadInitialAccessToken",
+			nil,
+			"bad-token",
+			oidc.ClientRegistrationMetadata{RedirectURIs: []string{testRegistrationRedirectURI}},
+			http.StatusUnauthorized,
+			oidc.ErrInvalidRegistrationToken.ErrorField,
+			nil,
+		},
+		{
+			"ShouldFailBadJSON",
+			nil,
+			testRegistrationInitialToken,
+			"{bad json",
+			http.StatusBadRequest,
+			oidc.ErrInvalidClientMetadata.ErrorField,
+			nil,
+		},
+		{
+			"ShouldFailScopeNotPermitted",
+			nil,
+			testRegistrationInitialToken,
+			oidc.ClientRegistrationMetadata{RedirectURIs: []string{testRegistrationRedirectURI}, Scope: "openid groups"},
+			http.StatusBadRequest,
+			oidc.ErrInvalidClientMetadata.ErrorField,
+			nil,
+		},
+		{
+			"ShouldFailRedirectURIWithFragment",
+			nil,
+			testRegistrationInitialToken,
+			oidc.ClientRegistrationMetadata{RedirectURIs: []string{"https://app.example.com/callback#fragment"}, Scope: oidc.ScopeOpenID},
+			http.StatusBadRequest,
+			oidc.ErrInvalidRedirectURI.ErrorField,
+			nil,
+		},
+		{
+			"ShouldFailRelativeRedirectURI",
+			nil,
+			testRegistrationInitialToken,
+			oidc.ClientRegistrationMetadata{RedirectURIs: []string{"/callback"}, Scope: oidc.ScopeOpenID},
+			http.StatusBadRequest,
+			oidc.ErrInvalidRedirectURI.ErrorField,
+			nil,
+		},
+		{
+			"ShouldFailStorageError",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().SaveOAuth2RegisteredClient(gomock.Any(), gomock.Any()).Return(fmt.Errorf("bad block"))
+			},
+			testRegistrationInitialToken,
+			oidc.ClientRegistrationMetadata{RedirectURIs: []string{testRegistrationRedirectURI}, Scope: oidc.ScopeOpenID},
+			http.StatusInternalServerError,
+			oauthelia2.ErrServerError.ErrorField,
+			nil,
+		},
+		{
+			"ShouldSucceedConfidentialClient",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().SaveOAuth2RegisteredClient(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, registered model.OAuth2RegisteredClient) error {
+						assert.NotEmpty(t, registered.ClientID)
+						assert.NotEmpty(t, registered.ClientSecret)
+						assert.NotEmpty(t, registered.RegistrationAccessTokenSignature)
+						assert.Equal(t, mock.Clock.Now(), registered.CreatedAt)
+						assert.False(t, registered.ExpiresAt.Valid)
+
+						record := oidc.ClientRegistrationRecord{}
+
+						require.NoError(t, json.Unmarshal(registered.Metadata, &record))
+						require.NotNil(t, record.Configuration)
+						assert.Equal(t, []string{testRegistrationRedirectURI}, record.RedirectURIs)
+						assert.False(t, record.Configuration.RequirePKCE)
+
+						return nil
+					})
+			},
+			testRegistrationInitialToken,
+			oidc.ClientRegistrationMetadata{ClientName: "example", RedirectURIs: []string{testRegistrationRedirectURI}, Scope: "openid profile"},
+			http.StatusCreated,
+			"",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx, body map[string]any) {
+				assert.NotEmpty(t, body["client_id"])
+				assert.NotEmpty(t, body["client_secret"])
+				assert.NotEmpty(t, body["registration_access_token"])
+				assert.Equal(t, fmt.Sprintf("https://example.com%s/%s", oidc.EndpointPathRegistration, body["client_id"]), body["registration_client_uri"])
+				assert.Equal(t, "openid profile", body["scope"])
+			},
+		},
+		{
+			"ShouldSucceedPublicClient",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().SaveOAuth2RegisteredClient(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, registered model.OAuth2RegisteredClient) error {
+						assert.Empty(t, registered.ClientSecret)
+
+						record := oidc.ClientRegistrationRecord{}
+
+						require.NoError(t, json.Unmarshal(registered.Metadata, &record))
+						require.NotNil(t, record.Configuration)
+						assert.True(t, record.Configuration.RequirePKCE)
+						assert.Equal(t, oidc.PKCEChallengeMethodSHA256, record.Configuration.PKCEChallengeMethod)
+
+						return nil
+					})
+			},
+			testRegistrationInitialToken,
+			oidc.ClientRegistrationMetadata{RedirectURIs: []string{testRegistrationRedirectURI}, Scope: oidc.ScopeOpenID, TokenEndpointAuthMethod: oidc.ClientAuthMethodNone},
+			http.StatusCreated,
+			"",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx, body map[string]any) {
+				assert.NotContains(t, body, "client_secret")
+				assert.Equal(t, oidc.ClientAuthMethodNone, body["token_endpoint_auth_method"])
+			},
+		},
+		{
+			"ShouldSucceedWithLifespan",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.Ctx.Configuration.IdentityProviders.OIDC.DynamicClientRegistration.ClientLifespan = time.Hour
+
+				mock.StorageMock.EXPECT().SaveOAuth2RegisteredClient(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, registered model.OAuth2RegisteredClient) error {
+						assert.Equal(t, sql.NullTime{Time: mock.Clock.Now().Add(time.Hour), Valid: true}, registered.ExpiresAt)
+
+						return nil
+					})
+			},
+			testRegistrationInitialToken,
+			oidc.ClientRegistrationMetadata{RedirectURIs: []string{testRegistrationRedirectURI}, Scope: oidc.ScopeOpenID},
+			http.StatusCreated,
+			"",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx, body map[string]any) {
+				assert.Equal(t, float64(mock.Clock.Now().Add(time.Hour).Unix()), body["client_secret_expires_at"])
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := newRegistrationTestMock(t)
+
+			defer mock.Close()
+
+			if tc.setup != nil {
+				tc.setup(t, mock)
+			}
+
+			rw := doRegistrationTestRequest(t, mock, OpenIDConnectRegistrationPOST, http.MethodPost, tc.token, tc.body)
+
+			body := assertRegistrationTestResponse(t, rw, tc.status, tc.expected)
+
+			if tc.expectedf != nil {
+				tc.expectedf(t, mock, body)
+			}
+		})
+	}
+}
+
+func TestOpenIDConnectRegistrationClientLoad(t *testing.T) {
+	handlers := []struct {
+		name    string
+		method  string
+		handler func(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter, req *http.Request)
+	}{
+		{"GET", http.MethodGet, OpenIDConnectRegistrationClientGET},
+		{"PUT", http.MethodPut, OpenIDConnectRegistrationClientPUT},
+		{"DELETE", http.MethodDelete, OpenIDConnectRegistrationClientDELETE},
+	}
+
+	testCases := []struct {
+		name     string
+		setup    func(t *testing.T, mock *mocks.MockAutheliaCtx)
+		token    string
+		status   int
+		expected string
+	}{
+		{
+			"ShouldFailClientNotFound",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().LoadOAuth2RegisteredClient(gomock.Any(), testRegistrationClientID).Return(nil, storage.ErrNoOAuth2RegisteredClient)
+			},
+			testRegistrationAccessToken,
+			http.StatusUnauthorized,
+			oidc.ErrInvalidRegistrationToken.ErrorField,
+		},
+		{
+			"ShouldFailStorageError",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().LoadOAuth2RegisteredClient(gomock.Any(), testRegistrationClientID).Return(nil, fmt.Errorf("bad block"))
+			},
+			testRegistrationAccessToken,
+			http.StatusInternalServerError,
+			oauthelia2.ErrServerError.ErrorField,
+		},
+		{
+			"ShouldFailExpired",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				registered := newRegistrationTestClient(t, false)
+				registered.ExpiresAt = sql.NullTime{Time: mock.Clock.Now().Add(-time.Minute), Valid: true}
+
+				mock.StorageMock.EXPECT().LoadOAuth2RegisteredClient(gomock.Any(), testRegistrationClientID).Return(registered, nil)
+			},
+			testRegistrationAccessToken,
+			http.StatusUnauthorized,
+			oidc.ErrInvalidRegistrationToken.ErrorField,
+		},
+		{
+			"ShouldFailMissingRegistrationAccessToken",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().LoadOAuth2RegisteredClient(gomock.Any(), testRegistrationClientID).Return(newRegistrationTestClient(t, false), nil)
+			},
+			"",
+			http.StatusUnauthorized,
+			oidc.ErrInvalidRegistrationToken.ErrorField,
+		},
+		{
+			"ShouldFailBadRegistrationAccessToken",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().LoadOAuth2RegisteredClient(gomock.Any(), testRegistrationClientID).Return(newRegistrationTestClient(t, false), nil)
+			},
+			"bad-token",
+			http.StatusUnauthorized,
+			oidc.ErrInvalidRegistrationToken.ErrorField,
+		},
+		{
+			"ShouldFailInitialAccessTokenAsRegistrationAccessToken",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().LoadOAuth2RegisteredClient(gomock.Any(), testRegistrationClientID).Return(newRegistrationTestClient(t, false), nil)
+			},
+			testRegistrationInitialToken,
+			http.StatusUnauthorized,
+			oidc.ErrInvalidRegistrationToken.ErrorField,
+		},
+	}
+
+	for _, h := range handlers {
+		t.Run(h.name, func(t *testing.T) {
+			for _, tc := range testCases {
+				t.Run(tc.name, func(t *testing.T) {
+					mock := newRegistrationTestMock(t)
+
+					defer mock.Close()
+
+					mock.Ctx.SetUserValue("client_id", testRegistrationClientID)
+
+					tc.setup(t, mock)
+
+					rw := doRegistrationTestRequest(t, mock, h.handler, h.method, tc.token, nil)
+
+					assertRegistrationTestResponse(t, rw, tc.status, tc.expected)
+				})
+			}
+		})
+	}
+}
+
+func TestOpenIDConnectRegistrationClientGET(t *testing.T) {
+	testCases := []struct {
+		name      string
+		public    bool
+		metadata  []byte
+		expires   sql.NullTime
+		status    int
+		expected  string
+		expectedf func(t *testing.T, body map[string]any)
+	}{
+		{
+			"ShouldSucceedConfidentialClient",
+			false,
+			nil,
+			sql.NullTime{},
+			http.StatusOK,
+			"",
+			func(t *testing.T, body map[string]any) {
+				assert.Equal(t, testRegistrationClientID, body["client_id"])
+				assert.Equal(t, float64(1000000), body["client_id_issued_at"])
+				assert.Equal(t, fmt.Sprintf("https://example.com%s/%s", oidc.EndpointPathRegistration, testRegistrationClientID), body["registration_client_uri"])
+				assert.Equal(t, oidc.ClientAuthMethodClientSecretBasic, body["token_endpoint_auth_method"])
+				assert.NotContains(t, body, "client_secret")
+				assert.NotContains(t, body, "registration_access_token")
+				assert.Equal(t, float64(0), body["client_secret_expires_at"])
+			},
+		},
+		{
+			"ShouldSucceedConfidentialClientWithSecretExpiration",
+			false,
+			nil,
+			sql.NullTime{Time: time.Unix(2000000, 0), Valid: true},
+			http.StatusOK,
+			"",
+			func(t *testing.T, body map[string]any) {
+				assert.Equal(t, oidc.ClientAuthMethodClientSecretBasic, body["token_endpoint_auth_method"])
+				assert.NotContains(t, body, "client_secret")
+				assert.Equal(t, float64(2000000), body["client_secret_expires_at"])
+			},
+		},
+		{
+			"ShouldSucceedPublicClient",
+			true,
+			nil,
+			sql.NullTime{Time: time.Unix(2000000, 0), Valid: true},
+			http.StatusOK,
+			"",
+			func(t *testing.T, body map[string]any) {
+				assert.Equal(t, oidc.ClientAuthMethodNone, body["token_endpoint_auth_method"])
+				assert.Equal(t, float64(0), body["client_secret_expires_at"])
+			},
+		},
+		{
+			"ShouldFailBadMetadata",
+			false,
+			[]byte("{bad json"),
+			sql.NullTime{},
+			http.StatusInternalServerError,
+			oauthelia2.ErrServerError.ErrorField,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := newRegistrationTestMock(t)
+
+			defer mock.Close()
+
+			mock.Ctx.SetUserValue("client_id", testRegistrationClientID)
+
+			registered := newRegistrationTestClient(t, tc.public)
+
+			if tc.metadata != nil {
+				registered.Metadata = tc.metadata
+			}
+
+			registered.ExpiresAt = tc.expires
+
+			mock.StorageMock.EXPECT().LoadOAuth2RegisteredClient(gomock.Any(), testRegistrationClientID).Return(registered, nil)
+
+			rw := doRegistrationTestRequest(t, mock, OpenIDConnectRegistrationClientGET, http.MethodGet, testRegistrationAccessToken, nil)
+
+			body := assertRegistrationTestResponse(t, rw, tc.status, tc.expected)
+
+			if tc.expectedf != nil {
+				tc.expectedf(t, body)
+			}
+		})
+	}
+}
+
+func TestOpenIDConnectRegistrationClientPUT(t *testing.T) {
+	testCases := []struct {
+		name      string
+		public    bool
+		setup     func(t *testing.T, mock *mocks.MockAutheliaCtx)
+		body      any
+		status    int
+		expected  string
+		expectedf func(t *testing.T, mock *mocks.MockAutheliaCtx, body map[string]any)
+	}{
+		{
+			"ShouldFailBadJSON",
+			false,
+			nil,
+			"{bad json",
+			http.StatusBadRequest,
+			oidc.ErrInvalidClientMetadata.ErrorField,
+			nil,
+		},
+		{
+			"ShouldFailClientIDMismatch",
+			false,
+			nil,
+			oidc.ClientRegistrationUpdateRequest{
+				ClientID:                   "other",
+				ClientRegistrationMetadata: oidc.ClientRegistrationMetadata{RedirectURIs: []string{testRegistrationRedirectURI}, Scope: oidc.ScopeOpenID},
+			},
+			http.StatusBadRequest,
+			oauthelia2.ErrInvalidRequest.ErrorField,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx, body map[string]any) {
+				assert.Equal(t, "Client Update Request for client with id 'a0b1c2d3-e4f5-4a6b-8c7d-9e0f1a2b3c4d' failed with error: the client id 'other' in the request body does not match", mock.Hook.LastEntry().Message)
+			},
+		},
+		{
+			"ShouldFailClientSecretMismatch",
+			false,
+			nil,
+			oidc.ClientRegistrationUpdateRequest{
+				ClientID:                   testRegistrationClientID,
+				ClientSecret:               "bad-secret",
+				ClientRegistrationMetadata: oidc.ClientRegistrationMetadata{RedirectURIs: []string{testRegistrationRedirectURI}, Scope: oidc.ScopeOpenID},
+			},
+			http.StatusBadRequest,
+			oauthelia2.ErrInvalidRequest.ErrorField,
+			nil,
+		},
+		{
+			"ShouldFailScopeNotPermitted",
+			false,
+			nil,
+			oidc.ClientRegistrationUpdateRequest{
+				ClientID:                   testRegistrationClientID,
+				ClientRegistrationMetadata: oidc.ClientRegistrationMetadata{RedirectURIs: []string{testRegistrationRedirectURI}, Scope: "openid groups"},
+			},
+			http.StatusBadRequest,
+			oidc.ErrInvalidClientMetadata.ErrorField,
+			nil,
+		},
+		{
+			"ShouldFailStorageError",
+			false,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().UpdateOAuth2RegisteredClient(gomock.Any(), gomock.Any()).Return(fmt.Errorf("bad block"))
+			},
+			oidc.ClientRegistrationUpdateRequest{
+				ClientID:                   testRegistrationClientID,
+				ClientSecret:               testRegistrationClientSecret,
+				ClientRegistrationMetadata: oidc.ClientRegistrationMetadata{RedirectURIs: []string{testRegistrationRedirectURI}, Scope: oidc.ScopeOpenID},
+			},
+			http.StatusInternalServerError,
+			oauthelia2.ErrServerError.ErrorField,
+			nil,
+		},
+		{
+			"ShouldSucceedKeepingSecret",
+			false,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().UpdateOAuth2RegisteredClient(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, registered model.OAuth2RegisteredClient) error {
+						assert.Equal(t, "$plaintext$"+testRegistrationClientSecret, registered.ClientSecret)
+						assert.Equal(t, mock.Clock.Now(), registered.UpdatedAt)
+						assert.Equal(t, oidc.RegistrationAccessTokenSignature(testRegistrationAccessToken), registered.RegistrationAccessTokenSignature)
+
+						record := oidc.ClientRegistrationRecord{}
+
+						require.NoError(t, json.Unmarshal(registered.Metadata, &record))
+						require.NotNil(t, record.Configuration)
+						assert.Equal(t, "updated", record.ClientName)
+
+						return nil
+					})
+			},
+			oidc.ClientRegistrationUpdateRequest{
+				ClientID:                   testRegistrationClientID,
+				ClientSecret:               testRegistrationClientSecret,
+				ClientRegistrationMetadata: oidc.ClientRegistrationMetadata{ClientName: "updated", RedirectURIs: []string{testRegistrationRedirectURI}, Scope: oidc.ScopeOpenID},
+			},
+			http.StatusOK,
+			"",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx, body map[string]any) {
+				assert.Equal(t, "updated", body["client_name"])
+				assert.NotContains(t, body, "client_secret")
+			},
+		},
+		{
+			"ShouldSucceedConvertingToPublicClient",
+			false,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().UpdateOAuth2RegisteredClient(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, registered model.OAuth2RegisteredClient) error {
+						assert.Empty(t, registered.ClientSecret)
+
+						record := oidc.ClientRegistrationRecord{}
+
+						require.NoError(t, json.Unmarshal(registered.Metadata, &record))
+						require.NotNil(t, record.Configuration)
+						assert.True(t, record.Configuration.RequirePKCE)
+
+						return nil
+					})
+			},
+			oidc.ClientRegistrationUpdateRequest{
+				ClientID:                   testRegistrationClientID,
+				ClientRegistrationMetadata: oidc.ClientRegistrationMetadata{RedirectURIs: []string{testRegistrationRedirectURI}, Scope: oidc.ScopeOpenID, TokenEndpointAuthMethod: oidc.ClientAuthMethodNone},
+			},
+			http.StatusOK,
+			"",
+			nil,
+		},
+		{
+			"ShouldSucceedIssuingSecretToPublicClient",
+			true,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().UpdateOAuth2RegisteredClient(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, registered model.OAuth2RegisteredClient) error {
+						assert.NotEmpty(t, registered.ClientSecret)
+
+						return nil
+					})
+			},
+			oidc.ClientRegistrationUpdateRequest{
+				ClientID:                   testRegistrationClientID,
+				ClientRegistrationMetadata: oidc.ClientRegistrationMetadata{RedirectURIs: []string{testRegistrationRedirectURI}, Scope: oidc.ScopeOpenID},
+			},
+			http.StatusOK,
+			"",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx, body map[string]any) {
+				assert.NotEmpty(t, body["client_secret"])
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := newRegistrationTestMock(t)
+
+			defer mock.Close()
+
+			mock.Ctx.SetUserValue("client_id", testRegistrationClientID)
+
+			mock.StorageMock.EXPECT().LoadOAuth2RegisteredClient(gomock.Any(), testRegistrationClientID).Return(newRegistrationTestClient(t, tc.public), nil)
+
+			if tc.setup != nil {
+				tc.setup(t, mock)
+			}
+
+			rw := doRegistrationTestRequest(t, mock, OpenIDConnectRegistrationClientPUT, http.MethodPut, testRegistrationAccessToken, tc.body)
+
+			body := assertRegistrationTestResponse(t, rw, tc.status, tc.expected)
+
+			if tc.expectedf != nil {
+				tc.expectedf(t, mock, body)
+			}
+		})
+	}
+}
+
+func TestOpenIDConnectRegistrationClientDELETE(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		status   int
+		expected string
+	}{
+		{
+			"ShouldSucceed",
+			nil,
+			http.StatusNoContent,
+			"",
+		},
+		{
+			"ShouldSucceedAlreadyDeleted",
+			storage.ErrNoOAuth2RegisteredClient,
+			http.StatusNoContent,
+			"",
+		},
+		{
+			"ShouldFailStorageError",
+			fmt.Errorf("bad block"),
+			http.StatusInternalServerError,
+			oauthelia2.ErrServerError.ErrorField,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := newRegistrationTestMock(t)
+
+			defer mock.Close()
+
+			mock.Ctx.SetUserValue("client_id", testRegistrationClientID)
+
+			gomock.InOrder(
+				mock.StorageMock.EXPECT().LoadOAuth2RegisteredClient(gomock.Any(), testRegistrationClientID).Return(newRegistrationTestClient(t, false), nil),
+				mock.StorageMock.EXPECT().DeleteOAuth2RegisteredClient(gomock.Any(), testRegistrationClientID).Return(tc.err),
+			)
+
+			rw := doRegistrationTestRequest(t, mock, OpenIDConnectRegistrationClientDELETE, http.MethodDelete, testRegistrationAccessToken, nil)
+
+			assertRegistrationTestResponse(t, rw, tc.status, tc.expected)
+
+			if tc.status == http.StatusNoContent {
+				assert.Equal(t, "no-store", rw.Header().Get(fasthttp.HeaderCacheControl))
+				assert.Empty(t, rw.Body.Bytes())
+			}
+		})
+	}
+}
@@ -1,10 +1,12 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	oauthelia2 "authelia.com/provider/oauth2"
 
+	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/oidc"
 )
@@ -51,6 +53,17 @@ func OpenIDConnectTokenPOST(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter
 		}
 	}
 
+	if requester.GetGrantTypes().ExactOne(oidc.GrantTypeRefreshToken) || requester.GetGrantTypes().ExactOne(oidc.GrantTypeDeviceCode) ||
+		requester.GetGrantTypes().ExactOne(oidc.GrantTypeTokenExchange) {
+		if err = handleOpenIDConnectTokenRefreshUser(ctx, requester); err != nil {
+			ctx.Logger.Errorf("Access Response for Request with id '%s' failed to be created with error: %s", requester.GetID(), oauthelia2.ErrorToDebugRFC6749Error(err))
+
+			ctx.Providers.OpenIDConnect.WriteAccessError(ctx, rw, requester, err)
+
+			return
+		}
+	}
+
 	ctx.Logger.Tracef("Access Request with id '%s' on client with id '%s' response is being generated for session with type '%T'", requester.GetID(), client.GetID(), requester.GetSession())
 
 	if responder, err = ctx.Providers.OpenIDConnect.NewAccessResponse(ctx, requester); err != nil {
@@ -67,3 +80,30 @@ func OpenIDConnectTokenPOST(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter
 
 	ctx.Providers.OpenIDConnect.WriteAccessResponse(ctx, rw, requester, responder)
 }
+
+// handleOpenIDConnectTokenRefreshUser ensures the user a refresh token, device code, or subject token was issued to is
+// still permitted to login.
+func handleOpenIDConnectTokenRefreshUser(ctx *middlewares.AutheliaCtx, requester oauthelia2.AccessRequester) (err error) {
+	var username string
+
+	if session := requester.GetSession(); session != nil {
+		username = session.GetUsername()
+	}
+
+	if len(username) == 0 {
+		return nil
+	}
+
+	if _, err = ctx.Providers.UserProvider.GetDetails(username); err != nil {
+		switch {
+		case errors.Is(err, authentication.ErrUserDisabled):
+			return oauthelia2.ErrInvalidGrant.WithWrap(err).WithDebugf("The user '%s' account is disabled or locked.", username)
+		case errors.Is(err, authentication.ErrUserNotFound):
+			return oauthelia2.ErrInvalidGrant.WithWrap(err).WithDebugf("The user '%s' was not found.", username)
+		default:
+			return oauthelia2.ErrServerError.WithWrap(err).WithDebugf("Failed to retrieve the details for user '%s' with error: %s.", username, err.Error())
+		}
+	}
+
+	return nil
+}
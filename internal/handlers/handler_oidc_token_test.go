@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	oauthelia2 "authelia.com/provider/oauth2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+	"go.uber.org/mock/gomock"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/oidc"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+const (
+	testTokenClientID = "token-public"
+)
+
+func newTokenRefreshTestMock(t *testing.T) *mocks.MockAutheliaCtx {
+	mock := mocks.NewMockAutheliaCtx(t)
+
+	config := &schema.IdentityProvidersOpenIDConnect{
+		HMACSecret: "abcdefghijklmnopqrstuvwxyz123456",
+		Clients: []schema.IdentityProvidersOpenIDConnectClient{
+			{
+				ID:                      testTokenClientID,
+				Public:                  true,
+				AuthorizationPolicy:     "one_factor",
+				Scopes:                  []string{oidc.ScopeOpenID, oidc.ScopeOfflineAccess},
+				GrantTypes:              []string{oidc.GrantTypeAuthorizationCode, oidc.GrantTypeRefreshToken},
+				ResponseTypes:           []string{oidc.ResponseTypeAuthorizationCodeFlow},
+				TokenEndpointAuthMethod: oidc.ClientAuthMethodNone,
+			},
+		},
+	}
+
+	mock.Ctx.Providers.OpenIDConnect = oidc.NewOpenIDConnectProvider(config, mock.StorageMock, mock.Ctx.Providers.Templates)
+	mock.Ctx.Request.Header.Set(fasthttp.HeaderXForwardedProto, "https")
+
+	return mock
+}
+
+// newTokenRefreshTestSession returns a refresh token and the stored session it was issued with for the user.
+func newTokenRefreshTestSession(t *testing.T, mock *mocks.MockAutheliaCtx, username string) (token string, session *model.OAuth2Session) {
+	client, err := mock.Ctx.Providers.OpenIDConnect.GetRegisteredClient(mock.Ctx, testTokenClientID)
+
+	require.NoError(t, err)
+
+	s := oidc.NewSession()
+	s.Subject = "2c8a5d8d-5f3c-4a31-a2d6-2f2a4ae8d4a1"
+	s.Username = username
+	s.ClientID = testTokenClientID
+	s.SetExpiresAt(oauthelia2.RefreshToken, time.Now().Add(time.Hour).Round(time.Second))
+
+	requester := &oauthelia2.Request{
+		ID:                "d7b3e44a-3c5c-4e3a-9f21-3a9b2cc0e5a4",
+		RequestedAt:       time.Now().Add(-time.Minute).Round(time.Second),
+		Client:            client,
+		RequestedScope:    oauthelia2.Arguments{oidc.ScopeOfflineAccess},
+		GrantedScope:      oauthelia2.Arguments{oidc.ScopeOfflineAccess},
+		RequestedAudience: oauthelia2.Arguments{},
+		GrantedAudience:   oauthelia2.Arguments{},
+		Form:              url.Values{oidc.FormParameterClientID: []string{testTokenClientID}},
+		Session:           s,
+	}
+
+	var signature string
+
+	token, signature, err = mock.Ctx.Providers.OpenIDConnect.Config.Strategy.Core.GenerateRefreshToken(mock.Ctx, requester)
+
+	require.NoError(t, err)
+
+	session, err = model.NewOAuth2SessionFromRequest(signature, requester)
+
+	require.NoError(t, err)
+
+	session.Active = true
+
+	return token, session
+}
+
+func TestOpenIDConnectTokenPOSTRefreshTokenUser(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		status   int
+		expected string
+		log      string
+	}{
+		{
+			"ShouldFailDisabledUser",
+			authentication.ErrUserDisabled,
+			http.StatusBadRequest,
+			oauthelia2.ErrInvalidGrant.ErrorField,
+			"The user 'john' account is disabled or locked.",
+		},
+		{
+			"ShouldFailDeletedUser",
+			authentication.ErrUserNotFound,
+			http.StatusBadRequest,
+			oauthelia2.ErrInvalidGrant.ErrorField,
+			"The user 'john' was not found.",
+		},
+		{
+			"ShouldFailUserProviderError",
+			fmt.Errorf("failed to connect"),
+			http.StatusInternalServerError,
+			oauthelia2.ErrServerError.ErrorField,
+			"Failed to retrieve the details for user 'john' with error: failed to connect.",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := newTokenRefreshTestMock(t)
+
+			defer mock.Close()
+
+			token, session := newTokenRefreshTestSession(t, mock, testUsername)
+
+			gomock.InOrder(
+				mock.StorageMock.EXPECT().LoadOAuth2Session(gomock.Any(), storage.OAuth2SessionTypeRefreshToken, session.Signature).Return(session, nil),
+				mock.UserProviderMock.EXPECT().GetDetails(testUsername).Return(nil, tc.err),
+			)
+
+			form := url.Values{
+				oidc.FormParameterGrantType:    []string{oidc.GrantTypeRefreshToken},
+				oidc.FormParameterRefreshToken: []string{token},
+				oidc.FormParameterClientID:     []string{testTokenClientID},
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "https://example.com/api/oidc/token", strings.NewReader(form.Encode()))
+			req.Header.Set(fasthttp.HeaderContentType, "application/x-www-form-urlencoded")
+
+			rw := httptest.NewRecorder()
+
+			OpenIDConnectTokenPOST(mock.Ctx, rw, req)
+
+			assert.Equal(t, tc.status, rw.Code)
+
+			body := map[string]any{}
+
+			require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &body))
+
+			assert.Equal(t, tc.expected, body["error"])
+			assert.Nil(t, body["access_token"])
+
+			entry := mock.Hook.LastEntry()
+
+			require.NotNil(t, entry)
+			assert.Contains(t, entry.Message, "Access Response for Request with id")
+			assert.Contains(t, entry.Message, tc.log)
+		})
+	}
+}
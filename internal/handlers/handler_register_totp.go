@@ -7,6 +7,7 @@ import (
 
 	"github.com/valyala/fasthttp"
 
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/regulation"
@@ -259,6 +260,8 @@ func TOTPRegisterPOST(ctx *middlewares.AutheliaCtx) {
 
 	ctxLogEvent(ctx, userSession.Username, eventLogAction2FAAdded, body, map[string]any{eventLogKeyAction: eventLogAction2FAAdded, eventLogKeyCategory: eventLogCategoryOneTimePassword})
 
+	ctxPublishEvent(ctx, schema.SecurityEventCredentialRegistered, userSession.Username, map[string]any{securityEventKeyCategory: eventLogCategoryOneTimePassword})
+
 	ctx.ReplyOK()
 }
 
@@ -358,5 +361,7 @@ func TOTPConfigurationDELETE(ctx *middlewares.AutheliaCtx) {
 
 	ctxLogEvent(ctx, userSession.Username, eventLogAction2FARemoved, body, map[string]any{eventLogKeyAction: eventLogAction2FARemoved, eventLogKeyCategory: eventLogCategoryOneTimePassword})
 
+	ctxPublishEvent(ctx, schema.SecurityEventCredentialRemoved, userSession.Username, map[string]any{securityEventKeyCategory: eventLogCategoryOneTimePassword})
+
 	ctx.ReplyOK()
 }
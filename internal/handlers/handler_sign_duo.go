@@ -36,6 +36,12 @@ func DuoPOST(duoAPI duo.API) middlewares.RequestHandler {
 			return
 		}
 
+		if ctxRegulate(ctx, userSession.Username, regulation.AuthTypeDuo) {
+			respondUnauthorized(ctx, messageMFAValidationFailed)
+
+			return
+		}
+
 		remoteIP := ctx.RemoteIP().String()
 
 		duoDevice, err := ctx.Providers.StorageProvider.LoadPreferredDuoDevice(ctx, userSession.Username)
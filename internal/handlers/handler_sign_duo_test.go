@@ -29,6 +29,8 @@ type SecondFactorDuoPostSuite struct {
 func (s *SecondFactorDuoPostSuite) SetupTest() {
 	s.mock = mocks.NewMockAutheliaCtx(s.T())
 
+	ExpectNoRegulationBans(s.mock)
+
 	userSession, err := s.mock.Ctx.GetSession()
 	s.Assert().NoError(err)
 
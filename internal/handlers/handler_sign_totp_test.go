@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/valyala/fasthttp"
 	"go.uber.org/mock/gomock"
@@ -30,6 +32,8 @@ type HandlerSignTOTPSuite struct {
 
 func (s *HandlerSignTOTPSuite) SetupTest() {
 	s.mock = mocks.NewMockAutheliaCtx(s.T())
+
+	ExpectNoRegulationBans(s.mock)
 	userSession, err := s.mock.Ctx.GetSession()
 	s.Assert().NoError(err)
 
@@ -847,3 +851,55 @@ func TestSignTOTPHandleGetSessionError(t *testing.T) {
 		})
 	}
 }
+
+func TestTimeBasedOneTimePasswordPOSTShouldRejectBannedUser(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+	defer mock.Close()
+
+	userSession, err := mock.Ctx.GetSession()
+	require.NoError(t, err)
+
+	userSession.Username = testUsername
+	userSession.AuthenticationLevel = authentication.OneFactor
+	require.NoError(t, mock.Ctx.SaveSession(userSession))
+
+	mock.Clock.Set(time.Unix(1701295903, 0))
+	mock.Ctx.Clock = &mock.Clock
+	mock.Ctx.Configuration.TOTP = schema.DefaultTOTPConfiguration
+
+	gomock.InOrder(
+		mock.StorageMock.
+			EXPECT().
+			LoadRegulationBans(mock.Ctx, model.RegulationBanKindUser, testUsername, mock.Clock.Now(), mock.Clock.Now()).
+			Return([]model.RegulationBan{
+				{
+					ExpiresAt: sql.NullTime{Time: mock.Clock.Now().Add(time.Hour), Valid: true},
+					Kind:      model.RegulationBanKindUser,
+					Value:     testUsername,
+					Source:    model.RegulationBanSourceManual,
+				},
+			}, nil),
+		mock.StorageMock.
+			EXPECT().
+			AppendAuthenticationLog(mock.Ctx, gomock.Eq(model.AuthenticationAttempt{
+				Username:   testUsername,
+				Successful: false,
+				Banned:     true,
+				Time:       mock.Clock.Now(),
+				Type:       regulation.AuthTypeTOTP,
+				RemoteIP:   model.NewNullIPFromString("0.0.0.0"),
+			})).
+			Return(nil),
+	)
+
+	bodyBytes, err := json.Marshal(bodySignTOTPRequest{
+		Token: "123456",
+	})
+	require.NoError(t, err)
+
+	mock.Ctx.Request.SetBody(bodyBytes)
+
+	TimeBasedOneTimePasswordPOST(mock.Ctx)
+
+	mock.Assert403KO(t, messageMFAValidationFailed)
+}
@@ -816,6 +816,8 @@ func TestWebAuthnAssertionPOST(t *testing.T) {
 
 			defer mock.Close()
 
+			ExpectNoRegulationBans(mock)
+
 			if tc.config != nil {
 				mock.Ctx.Configuration.WebAuthn = *tc.config
 			}
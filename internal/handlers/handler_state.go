@@ -1,6 +1,11 @@
 package handlers
 
 import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/session"
 )
@@ -25,6 +30,23 @@ func StateGET(ctx *middlewares.AutheliaCtx) {
 		AuthenticationLevel: userSession.AuthenticationLevel,
 	}
 
+	// The portal provides the maximum authentication age when the authz endpoints request the user authenticate again.
+	// When the session is older than this it's treated as unauthenticated so the user is prompted to login.
+	if maxAge, err := strconv.Atoi(string(ctx.QueryArgs().Peek(queryArgMaxAge))); err == nil && userSession.IsAuthenticationStale(ctx.Clock.Now(), time.Duration(maxAge)*time.Second) {
+		ctx.Logger.WithFields(map[string]any{"username": userSession.Username, "max_age": maxAge}).Debug("User session is reported as unauthenticated as the last authentication exceeds the maximum authentication age")
+
+		stateResponse.AuthenticationLevel = authentication.NotAuthenticated
+	}
+
+	// The portal provides the second factor methods when the authz endpoints require the user authenticate with specific
+	// second factor methods. When the session didn't use any of them it's treated as one factor so the user is prompted.
+	if methods := ctx.QueryArgs().Peek(queryArgSecondFactorMethods); len(methods) != 0 && stateResponse.AuthenticationLevel == authentication.TwoFactor &&
+		!hasSecondFactorMethod(userSession.AuthenticationMethodRefs, strings.Split(string(methods), ",")) {
+		ctx.Logger.WithFields(map[string]any{"username": userSession.Username, "second_factor_methods": string(methods)}).Debug("User session is reported as one factor as none of the required second factor methods were used")
+
+		stateResponse.AuthenticationLevel = authentication.OneFactor
+	}
+
 	if uri := ctx.GetDefaultRedirectionURL(); uri != nil {
 		stateResponse.DefaultRedirectionURL = uri.String()
 	}
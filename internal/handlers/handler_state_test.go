@@ -3,6 +3,7 @@ package handlers
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/oidc"
 )
 
 type StateGetSuite struct {
@@ -90,6 +92,99 @@ func (s *StateGetSuite) TestShouldReturnAuthenticationLevelFromSession() {
 	assert.Equal(s.T(), expectedBody, actualBody)
 }
 
+func (s *StateGetSuite) TestShouldReturnNotAuthenticatedWhenMaxAgeExceeded() {
+	s.mock.Ctx.Clock = &s.mock.Clock
+	s.mock.Clock.Set(time.Unix(1700000000, 0))
+
+	userSession, err := s.mock.Ctx.GetSession()
+	s.Assert().NoError(err)
+
+	userSession.Username = "john"
+	userSession.AuthenticationLevel = authentication.TwoFactor
+	userSession.FirstFactorAuthnTimestamp = s.mock.Clock.Now().Add(-time.Hour * 2).Unix()
+	userSession.SecondFactorAuthnTimestamp = s.mock.Clock.Now().Add(-time.Minute * 90).Unix()
+	s.Assert().NoError(s.mock.Ctx.SaveSession(userSession))
+
+	type Response struct {
+		Status string
+		Data   StateResponse
+	}
+
+	testCases := []struct {
+		name     string
+		maxAge   string
+		expected authentication.Level
+	}{
+		{"ShouldReturnLevelWithoutMaxAge", "", authentication.TwoFactor},
+		{"ShouldReturnLevelWithinMaxAge", "7200", authentication.TwoFactor},
+		{"ShouldReturnNotAuthenticatedExceedingMaxAge", "3600", authentication.NotAuthenticated},
+		{"ShouldReturnLevelWithInvalidMaxAge", "abc", authentication.TwoFactor},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			s.mock.Ctx.Response.Reset()
+			s.mock.Ctx.Request.URI().QueryArgs().Reset()
+
+			if tc.maxAge != "" {
+				s.mock.Ctx.Request.URI().QueryArgs().Set(queryArgMaxAge, tc.maxAge)
+			}
+
+			StateGET(s.mock.Ctx)
+
+			actualBody := Response{}
+
+			require.NoError(t, json.Unmarshal(s.mock.Ctx.Response.Body(), &actualBody))
+			assert.Equal(t, "john", actualBody.Data.Username)
+			assert.Equal(t, tc.expected, actualBody.Data.AuthenticationLevel)
+		})
+	}
+}
+
+func (s *StateGetSuite) TestShouldReturnOneFactorWhenSecondFactorMethodMissing() {
+	userSession, err := s.mock.Ctx.GetSession()
+	s.Assert().NoError(err)
+
+	userSession.Username = "john"
+	userSession.AuthenticationLevel = authentication.TwoFactor
+	userSession.AuthenticationMethodRefs = oidc.AuthenticationMethodsReferences{UsernameAndPassword: true, TOTP: true}
+	s.Assert().NoError(s.mock.Ctx.SaveSession(userSession))
+
+	type Response struct {
+		Status string
+		Data   StateResponse
+	}
+
+	testCases := []struct {
+		name     string
+		methods  string
+		expected authentication.Level
+	}{
+		{"ShouldReturnLevelWithoutMethods", "", authentication.TwoFactor},
+		{"ShouldReturnLevelWithMethod", "webauthn,totp", authentication.TwoFactor},
+		{"ShouldReturnOneFactorWithoutMethod", "webauthn,webauthn_hardware", authentication.OneFactor},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			s.mock.Ctx.Response.Reset()
+			s.mock.Ctx.Request.URI().QueryArgs().Reset()
+
+			if tc.methods != "" {
+				s.mock.Ctx.Request.URI().QueryArgs().Set(queryArgSecondFactorMethods, tc.methods)
+			}
+
+			StateGET(s.mock.Ctx)
+
+			actualBody := Response{}
+
+			require.NoError(t, json.Unmarshal(s.mock.Ctx.Response.Body(), &actualBody))
+			assert.Equal(t, "john", actualBody.Data.Username)
+			assert.Equal(t, tc.expected, actualBody.Data.AuthenticationLevel)
+		})
+	}
+}
+
 func TestRunStateGetSuite(t *testing.T) {
 	s := new(StateGetSuite)
 	suite.Run(t, s)
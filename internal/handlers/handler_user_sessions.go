@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+// UserSessionsGET returns the active sessions of the current user from the user session index.
+func UserSessionsGET(ctx *middlewares.AutheliaCtx) {
+	var (
+		userSession session.UserSession
+		sessions    []model.UserSession
+		err         error
+	)
+
+	if userSession, err = ctx.GetSession(); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred listing user sessions: %s", errStrUserSessionData)
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if userSession.IsAnonymous() {
+		ctx.Logger.WithError(errUserAnonymous).Error("Error occurred listing user sessions")
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if sessions, err = ctx.Providers.StorageProvider.LoadUserSessions(ctx, userSession.Username, ctx.Clock.Now()); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred listing user sessions for user '%s': error occurred loading the sessions from the storage backend", userSession.Username)
+
+		ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	data := make([]UserSessionResponse, len(sessions))
+
+	for i, s := range sessions {
+		data[i] = UserSessionResponse{
+			ID:           s.PublicID.String(),
+			Current:      s.PublicID == userSession.PublicID,
+			Device:       s.Device,
+			UserAgent:    s.UserAgent,
+			CookieDomain: s.CookieDomain,
+			CreatedAt:    s.CreatedAt,
+			LastActiveAt: s.LastActiveAt,
+			ExpiresAt:    s.ExpiresAt,
+		}
+
+		if s.IP.IP != nil {
+			data[i].IP = s.IP.IP.String()
+		}
+	}
+
+	if err = ctx.SetJSONBody(data); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred listing user sessions for user '%s': %s", userSession.Username, errStrRespBody)
+	}
+}
+
+// UserSessionDELETE revokes a session of the current user in the user session index.
+func UserSessionDELETE(ctx *middlewares.AutheliaCtx) {
+	var (
+		userSession session.UserSession
+		publicID    uuid.UUID
+		err         error
+	)
+
+	if userSession, err = ctx.GetSession(); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred revoking user session: %s", errStrUserSessionData)
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if userSession.IsAnonymous() {
+		ctx.Logger.WithError(errUserAnonymous).Error("Error occurred revoking user session")
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	value, _ := ctx.UserValue("id").(string)
+
+	if publicID, err = uuid.Parse(value); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred revoking user session for user '%s': error occurred parsing the identifier", userSession.Username)
+
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if err = ctx.Providers.StorageProvider.RevokeUserSession(ctx, userSession.Username, publicID, ctx.Clock.Now()); err != nil {
+		if errors.Is(err, storage.ErrNoUserSession) {
+			ctx.Logger.WithError(fmt.Errorf("the session '%s' does not exist or has already been revoked", publicID)).Errorf("Error occurred revoking user session for user '%s'", userSession.Username)
+
+			ctx.SetStatusCode(fasthttp.StatusForbidden)
+		} else {
+			ctx.Logger.WithError(err).Errorf("Error occurred revoking user session for user '%s': error occurred saving the revocation to the storage backend", userSession.Username)
+
+			ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+		}
+
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	ctx.Providers.SessionProvider.ForgetRevocationCheck(publicID)
+
+	handleOpenIDConnectLogout(ctx, userSession.Username, publicID)
+
+	if publicID == userSession.PublicID {
+		if err = ctx.DestroySession(); err != nil {
+			ctx.Logger.WithError(err).Errorf("Error occurred revoking user session for user '%s': error occurred destroying the current session", userSession.Username)
+		}
+	}
+
+	ctx.ReplyOK()
+}
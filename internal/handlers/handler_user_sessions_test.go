@@ -0,0 +1,262 @@
+package handlers
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+func TestUserSessionsGET(t *testing.T) {
+	current := uuid.MustParse("0b7d9f1e-2c3a-4d5e-8f60-718293a4b5c6")
+	other := uuid.MustParse("1c8e0a2f-3d4b-4e6f-9071-8293a4b5c6d7")
+
+	at := time.Unix(1700000000, 0).UTC()
+
+	testCases := []struct {
+		name           string
+		setup          func(t *testing.T, mock *mocks.MockAutheliaCtx)
+		expected       string
+		expectedStatus int
+		expectedf      func(t *testing.T, mock *mocks.MockAutheliaCtx)
+	}{
+		{
+			"ShouldHandleAnonymous",
+			nil,
+			`{"status":"KO","message":"Operation failed."}`,
+			fasthttp.StatusForbidden,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred listing user sessions", "user is anonymous")
+			},
+		},
+		{
+			"ShouldHandleStorageError",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				us, err := mock.Ctx.GetSession()
+
+				require.NoError(t, err)
+
+				us.Username = testUsername
+				us.AuthenticationLevel = authentication.OneFactor
+
+				require.NoError(t, mock.Ctx.SaveSession(us))
+
+				mock.StorageMock.EXPECT().LoadUserSessions(mock.Ctx, testUsername, mock.Clock.Now()).Return(nil, fmt.Errorf("bad block"))
+			},
+			`{"status":"KO","message":"Operation failed."}`,
+			fasthttp.StatusInternalServerError,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred listing user sessions for user 'john': error occurred loading the sessions from the storage backend", "bad block")
+			},
+		},
+		{
+			"ShouldHandleSessions",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				us, err := mock.Ctx.GetSession()
+
+				require.NoError(t, err)
+
+				us.Username = testUsername
+				us.AuthenticationLevel = authentication.OneFactor
+				us.PublicID = current
+
+				require.NoError(t, mock.Ctx.SaveSession(us))
+
+				mock.StorageMock.EXPECT().LoadUserSessions(mock.Ctx, testUsername, mock.Clock.Now()).Return([]model.UserSession{
+					{PublicID: current, Username: testUsername, CookieDomain: exampleDotCom, IP: model.NewIP(net.ParseIP("192.168.0.1")), UserAgent: "Mozilla/5.0", Device: "Unknown on Unknown", CreatedAt: at, LastActiveAt: at, ExpiresAt: at.Add(time.Hour)},
+					{PublicID: other, Username: testUsername, CookieDomain: exampleDotCom, CreatedAt: at, LastActiveAt: at, ExpiresAt: at.Add(time.Hour)},
+				}, nil)
+			},
+			`{"status":"OK","data":[{"id":"0b7d9f1e-2c3a-4d5e-8f60-718293a4b5c6","current":true,"device":"Unknown on Unknown","ip":"192.168.0.1","user_agent":"Mozilla/5.0","cookie_domain":"example.com","created_at":"2023-11-14T22:13:20Z","last_active_at":"2023-11-14T22:13:20Z","expires_at":"2023-11-14T23:13:20Z"},{"id":"1c8e0a2f-3d4b-4e6f-9071-8293a4b5c6d7","current":false,"device":"","ip":"","user_agent":"","cookie_domain":"example.com","created_at":"2023-11-14T22:13:20Z","last_active_at":"2023-11-14T22:13:20Z","expires_at":"2023-11-14T23:13:20Z"}]}`,
+			fasthttp.StatusOK,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			if tc.setup != nil {
+				tc.setup(t, mock)
+			}
+
+			UserSessionsGET(mock.Ctx)
+
+			assert.Equal(t, tc.expectedStatus, mock.Ctx.Response.StatusCode())
+			assert.Equal(t, tc.expected, string(mock.Ctx.Response.Body()))
+
+			if tc.expectedf != nil {
+				tc.expectedf(t, mock)
+			}
+		})
+	}
+}
+
+func TestUserSessionDELETE(t *testing.T) {
+	current := uuid.MustParse("0b7d9f1e-2c3a-4d5e-8f60-718293a4b5c6")
+	other := uuid.MustParse("1c8e0a2f-3d4b-4e6f-9071-8293a4b5c6d7")
+
+	testCases := []struct {
+		name           string
+		setup          func(t *testing.T, mock *mocks.MockAutheliaCtx)
+		have           any
+		expected       string
+		expectedStatus int
+		expectedf      func(t *testing.T, mock *mocks.MockAutheliaCtx)
+	}{
+		{
+			"ShouldHandleAnonymous",
+			nil,
+			other.String(),
+			`{"status":"KO","message":"Operation failed."}`,
+			fasthttp.StatusForbidden,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred revoking user session", "user is anonymous")
+			},
+		},
+		{
+			"ShouldHandleBadID",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				us, err := mock.Ctx.GetSession()
+
+				require.NoError(t, err)
+
+				us.Username = testUsername
+				us.AuthenticationLevel = authentication.OneFactor
+
+				require.NoError(t, mock.Ctx.SaveSession(us))
+			},
+			"abc",
+			`{"status":"KO","message":"Operation failed."}`,
+			fasthttp.StatusBadRequest,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred revoking user session for user 'john': error occurred parsing the identifier", "invalid UUID length: 3")
+			},
+		},
+		{
+			"ShouldHandleNotFound",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				us, err := mock.Ctx.GetSession()
+
+				require.NoError(t, err)
+
+				us.Username = testUsername
+				us.AuthenticationLevel = authentication.OneFactor
+
+				require.NoError(t, mock.Ctx.SaveSession(us))
+
+				mock.StorageMock.EXPECT().RevokeUserSession(mock.Ctx, testUsername, other, mock.Clock.Now()).Return(storage.ErrNoUserSession)
+			},
+			other.String(),
+			`{"status":"KO","message":"Operation failed."}`,
+			fasthttp.StatusForbidden,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred revoking user session for user 'john'", "the session '1c8e0a2f-3d4b-4e6f-9071-8293a4b5c6d7' does not exist or has already been revoked")
+			},
+		},
+		{
+			"ShouldHandleStorageError",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				us, err := mock.Ctx.GetSession()
+
+				require.NoError(t, err)
+
+				us.Username = testUsername
+				us.AuthenticationLevel = authentication.OneFactor
+
+				require.NoError(t, mock.Ctx.SaveSession(us))
+
+				mock.StorageMock.EXPECT().RevokeUserSession(mock.Ctx, testUsername, other, mock.Clock.Now()).Return(fmt.Errorf("bad block"))
+			},
+			other.String(),
+			`{"status":"KO","message":"Operation failed."}`,
+			fasthttp.StatusInternalServerError,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred revoking user session for user 'john': error occurred saving the revocation to the storage backend", "bad block")
+			},
+		},
+		{
+			"ShouldRevokeOtherSession",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				us, err := mock.Ctx.GetSession()
+
+				require.NoError(t, err)
+
+				us.Username = testUsername
+				us.AuthenticationLevel = authentication.OneFactor
+				us.PublicID = current
+
+				require.NoError(t, mock.Ctx.SaveSession(us))
+
+				mock.StorageMock.EXPECT().RevokeUserSession(mock.Ctx, testUsername, other, mock.Clock.Now()).Return(nil)
+			},
+			other.String(),
+			`{"status":"OK"}`,
+			fasthttp.StatusOK,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				us, err := mock.Ctx.GetSession()
+
+				require.NoError(t, err)
+				assert.Equal(t, testUsername, us.Username)
+			},
+		},
+		{
+			"ShouldRevokeCurrentSession",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				us, err := mock.Ctx.GetSession()
+
+				require.NoError(t, err)
+
+				us.Username = testUsername
+				us.AuthenticationLevel = authentication.OneFactor
+				us.PublicID = current
+
+				require.NoError(t, mock.Ctx.SaveSession(us))
+
+				mock.StorageMock.EXPECT().RevokeUserSession(mock.Ctx, testUsername, current, mock.Clock.Now()).Return(nil)
+			},
+			current.String(),
+			`{"status":"OK"}`,
+			fasthttp.StatusOK,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				assert.Contains(t, string(mock.Ctx.Response.Header.PeekCookie("authelia_session")), "authelia_session=;")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			if tc.setup != nil {
+				tc.setup(t, mock)
+			}
+
+			mock.Ctx.SetUserValue("id", tc.have)
+
+			UserSessionDELETE(mock.Ctx)
+
+			assert.Equal(t, tc.expectedStatus, mock.Ctx.Response.StatusCode())
+			assert.Equal(t, tc.expected, string(mock.Ctx.Response.Body()))
+
+			if tc.expectedf != nil {
+				tc.expectedf(t, mock)
+			}
+		})
+	}
+}
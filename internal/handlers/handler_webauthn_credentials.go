@@ -8,6 +8,7 @@ import (
 
 	"github.com/valyala/fasthttp"
 
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/session"
@@ -274,5 +275,7 @@ func WebAuthnCredentialDELETE(ctx *middlewares.AutheliaCtx) {
 
 	ctxLogEvent(ctx, userSession.Username, eventLogAction2FARemoved, body, map[string]any{eventLogKeyAction: eventLogAction2FARemoved, eventLogKeyCategory: eventLogCategoryWebAuthnCredential, eventLogKeyDescription: credential.Description})
 
+	ctxPublishEvent(ctx, schema.SecurityEventCredentialRemoved, userSession.Username, map[string]any{securityEventKeyCategory: eventLogCategoryWebAuthnCredential, securityEventKeyDescription: credential.Description})
+
 	ctx.ReplyOK()
 }
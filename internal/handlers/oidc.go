@@ -5,16 +5,18 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/oidc"
+	"github.com/authelia/authelia/v4/internal/session"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
-func oidcGrantRequests(ar oauthelia2.AuthorizeRequester, consent *model.OAuth2ConsentSession, details oidc.UserDetailer) (extraClaims map[string]any) {
+func oidcGrantRequests(ar oauthelia2.AuthorizeRequester, consent *model.OAuth2ConsentSession, details oidc.UserDetailer, definitions map[string]schema.IdentityProvidersOpenIDConnectScope) (extraClaims map[string]any) {
 	extraClaims = map[string]any{}
 
-	oidcApplyScopeClaims(extraClaims, consent.GrantedScopes, details)
+	oidcApplyScopeClaims(extraClaims, consent.GrantedScopes, details, definitions)
 
 	if ar != nil {
 		for _, scope := range consent.GrantedScopes {
@@ -29,7 +31,7 @@ func oidcGrantRequests(ar oauthelia2.AuthorizeRequester, consent *model.OAuth2Co
 	return extraClaims
 }
 
-func oidcApplyScopeClaims(claims map[string]any, scopes []string, detailer oidc.UserDetailer) {
+func oidcApplyScopeClaims(claims map[string]any, scopes []string, detailer oidc.UserDetailer, definitions map[string]schema.IdentityProvidersOpenIDConnectScope) {
 	for _, scope := range scopes {
 		switch scope {
 		case oidc.ScopeGroups:
@@ -37,6 +39,8 @@ func oidcApplyScopeClaims(claims map[string]any, scopes []string, detailer oidc.
 		case oidc.ScopeProfile:
 			claims[oidc.ClaimPreferredUsername] = detailer.GetUsername()
 			claims[oidc.ClaimFullName] = detailer.GetDisplayName()
+
+			oidcApplyProfileClaims(claims, detailer)
 		case oidc.ScopeEmail:
 			if emails := detailer.GetEmails(); len(emails) != 0 {
 				claims[oidc.ClaimPreferredEmail] = emails[0]
@@ -47,6 +51,28 @@ func oidcApplyScopeClaims(claims map[string]any, scopes []string, detailer oidc.
 				// TODO (james-d-elliott): actually verify emails and record that information.
 				claims[oidc.ClaimEmailVerified] = true
 			}
+		case oidc.ScopePhone:
+			if number := detailer.GetPhoneNumber(); number != "" {
+				claims[oidc.ClaimPhoneNumber] = number
+
+				// Phone numbers are never verified by Authelia.
+				claims[oidc.ClaimPhoneNumberVerified] = false
+			}
+		}
+	}
+
+	oidc.ApplyCustomScopeClaims(claims, scopes, definitions, detailer)
+}
+
+func oidcApplyProfileClaims(claims map[string]any, detailer oidc.UserDetailer) {
+	for claim, value := range map[string]string{
+		oidc.ClaimGivenName:  detailer.GetGivenName(),
+		oidc.ClaimFamilyName: detailer.GetFamilyName(),
+		oidc.ClaimLocale:     detailer.GetLocale(),
+		oidc.ClaimPicture:    detailer.GetPicture(),
+	} {
+		if value != "" {
+			claims[claim] = value
 		}
 	}
 }
@@ -82,13 +108,18 @@ func oidcGetAudience(claims map[string]any) (audience []string, ok bool) {
 	return audience, ok
 }
 
-func oidcApplyUserInfoClaims(clientID string, scopes oauthelia2.Arguments, originalClaims, claims map[string]any, resolver oidcDetailResolver) {
+func oidcApplyUserInfoClaims(clientID string, scopes oauthelia2.Arguments, originalClaims, claims map[string]any, resolver oidcDetailResolver, definitions map[string]schema.IdentityProvidersOpenIDConnectScope) {
 	for claim, value := range originalClaims {
+		if oidc.IsCustomScopeClaim(claim, scopes, definitions) {
+			continue
+		}
+
 		switch claim {
 		case oidc.ClaimJWTID, oidc.ClaimSessionID, oidc.ClaimAccessTokenHash, oidc.ClaimCodeHash, oidc.ClaimExpirationTime, oidc.ClaimNonce, oidc.ClaimStateHash:
 			// Skip special OpenID Connect 1.0 Claims.
 			continue
-		case oidc.ClaimPreferredUsername, oidc.ClaimPreferredEmail, oidc.ClaimEmailVerified, oidc.ClaimEmailAlts, oidc.ClaimGroups, oidc.ClaimFullName:
+		case oidc.ClaimPreferredUsername, oidc.ClaimPreferredEmail, oidc.ClaimEmailVerified, oidc.ClaimEmailAlts, oidc.ClaimGroups, oidc.ClaimFullName,
+			oidc.ClaimGivenName, oidc.ClaimFamilyName, oidc.ClaimLocale, oidc.ClaimPicture, oidc.ClaimPhoneNumber, oidc.ClaimPhoneNumberVerified:
 			continue
 		default:
 			claims[claim] = value
@@ -105,10 +136,10 @@ func oidcApplyUserInfoClaims(clientID string, scopes oauthelia2.Arguments, origi
 
 	claims[oidc.ClaimAudience] = audience
 
-	oidcApplyUserInfoDetailsClaims(scopes, claims, resolver)
+	oidcApplyUserInfoDetailsClaims(scopes, claims, resolver, definitions)
 }
 
-func oidcApplyUserInfoDetailsClaims(scopes oauthelia2.Arguments, claims map[string]any, resolver oidcDetailResolver) {
+func oidcApplyUserInfoDetailsClaims(scopes oauthelia2.Arguments, claims map[string]any, resolver oidcDetailResolver, definitions map[string]schema.IdentityProvidersOpenIDConnectScope) {
 	var (
 		detailer oidc.UserDetailer
 		subject  uuid.UUID
@@ -116,7 +147,7 @@ func oidcApplyUserInfoDetailsClaims(scopes oauthelia2.Arguments, claims map[stri
 		err      error
 	)
 
-	if subject, ok = oidcApplyUserInfoDetailsClaimsGetSubject(scopes, claims); !ok {
+	if subject, ok = oidcApplyUserInfoDetailsClaimsGetSubject(scopes, claims, definitions); !ok {
 		return
 	}
 
@@ -124,11 +155,11 @@ func oidcApplyUserInfoDetailsClaims(scopes oauthelia2.Arguments, claims map[stri
 		return
 	}
 
-	oidcApplyScopeClaims(claims, scopes, detailer)
+	oidcApplyScopeClaims(claims, scopes, detailer, definitions)
 }
 
-func oidcApplyUserInfoDetailsClaimsGetSubject(scopes oauthelia2.Arguments, claims map[string]any) (subject uuid.UUID, ok bool) {
-	if !scopes.HasOneOf(oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeGroups) {
+func oidcApplyUserInfoDetailsClaimsGetSubject(scopes oauthelia2.Arguments, claims map[string]any, definitions map[string]schema.IdentityProvidersOpenIDConnectScope) (subject uuid.UUID, ok bool) {
+	if !scopes.HasOneOf(oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopePhone, oidc.ScopeGroups) && !oidc.HasCustomScope(scopes, definitions) {
 		return uuid.UUID{}, false
 	}
 
@@ -172,4 +203,28 @@ func oidcCtxDetailResolver(ctx *middlewares.AutheliaCtx) oidcDetailResolver {
 	}
 }
 
+// oidcCtxScopeDefinitions returns the custom scope definitions from the configuration.
+func oidcCtxScopeDefinitions(ctx *middlewares.AutheliaCtx) map[string]schema.IdentityProvidersOpenIDConnectScope {
+	if ctx.Configuration.IdentityProviders.OIDC == nil {
+		return nil
+	}
+
+	return ctx.Configuration.IdentityProviders.OIDC.Scopes
+}
+
 type oidcDetailResolver func(subject uuid.UUID) (detailer oidc.UserDetailer, err error)
+
+// oidcCtxHandleRevokedUserSession destroys the current session if it has been revoked and replaces the provided user
+// session with a new anonymous session so the request is handled as if the user is not logged in.
+func oidcCtxHandleRevokedUserSession(ctx *middlewares.AutheliaCtx, userSession *session.UserSession) {
+	provider, err := ctx.GetSessionProvider()
+	if err != nil || !ctx.IsUserSessionRevoked(provider, userSession) {
+		return
+	}
+
+	if err = provider.DestroySession(ctx.RequestCtx); err != nil {
+		ctx.Logger.WithError(err).Error("Error occurred trying to destroy the revoked session")
+	}
+
+	*userSession = provider.NewDefaultUserSession()
+}
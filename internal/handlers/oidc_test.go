@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/oidc"
 	"github.com/authelia/authelia/v4/internal/session"
@@ -20,7 +21,7 @@ func TestShouldGrantAppropriateClaimsForScopeProfile(t *testing.T) {
 		GrantedScopes: []string{oidc.ScopeProfile},
 	}
 
-	extraClaims := oidcGrantRequests(nil, consent, &oidcUserSessionJohn)
+	extraClaims := oidcGrantRequests(nil, consent, &oidcUserSessionJohn, nil)
 
 	assert.Len(t, extraClaims, 2)
 
@@ -31,12 +32,59 @@ func TestShouldGrantAppropriateClaimsForScopeProfile(t *testing.T) {
 	assert.Equal(t, "John Smith", extraClaims[oidc.ClaimFullName])
 }
 
+func TestShouldGrantAppropriateClaimsForScopeProfileAttributes(t *testing.T) {
+	consent := &model.OAuth2ConsentSession{
+		GrantedScopes: []string{oidc.ScopeProfile},
+	}
+
+	userSession := &session.UserSession{
+		Username:    "jane",
+		DisplayName: "Jane Smith",
+		GivenName:   "Jane",
+		FamilyName:  "Smith",
+		PhoneNumber: "+1 555 0100",
+		Locale:      "en-AU",
+	}
+
+	extraClaims := oidcGrantRequests(nil, consent, userSession, nil)
+
+	assert.Equal(t, map[string]any{
+		oidc.ClaimPreferredUsername: "jane",
+		oidc.ClaimFullName:          "Jane Smith",
+		oidc.ClaimGivenName:         "Jane",
+		oidc.ClaimFamilyName:        "Smith",
+		oidc.ClaimLocale:            "en-AU",
+	}, extraClaims)
+}
+
+func TestShouldGrantAppropriateClaimsForScopePhone(t *testing.T) {
+	consent := &model.OAuth2ConsentSession{
+		GrantedScopes: []string{oidc.ScopePhone},
+	}
+
+	userSession := &session.UserSession{
+		Username:    "jane",
+		PhoneNumber: "+1 555 0100",
+	}
+
+	extraClaims := oidcGrantRequests(nil, consent, userSession, nil)
+
+	assert.Equal(t, map[string]any{
+		oidc.ClaimPhoneNumber:         "+1 555 0100",
+		oidc.ClaimPhoneNumberVerified: false,
+	}, extraClaims)
+
+	extraClaims = oidcGrantRequests(nil, consent, &oidcUserSessionJohn, nil)
+
+	assert.Len(t, extraClaims, 0)
+}
+
 func TestShouldGrantAppropriateClaimsForScopeGroups(t *testing.T) {
 	consent := &model.OAuth2ConsentSession{
 		GrantedScopes: []string{oidc.ScopeGroups},
 	}
 
-	extraClaims := oidcGrantRequests(nil, consent, &oidcUserSessionJohn)
+	extraClaims := oidcGrantRequests(nil, consent, &oidcUserSessionJohn, nil)
 
 	assert.Len(t, extraClaims, 1)
 
@@ -45,7 +93,7 @@ func TestShouldGrantAppropriateClaimsForScopeGroups(t *testing.T) {
 	assert.Contains(t, extraClaims[oidc.ClaimGroups], "admin")
 	assert.Contains(t, extraClaims[oidc.ClaimGroups], "dev")
 
-	extraClaims = oidcGrantRequests(nil, consent, &oidcUserSessionFred)
+	extraClaims = oidcGrantRequests(nil, consent, &oidcUserSessionFred, nil)
 
 	assert.Len(t, extraClaims, 1)
 
@@ -59,7 +107,7 @@ func TestShouldGrantAppropriateClaimsForScopeEmail(t *testing.T) {
 		GrantedScopes: []string{oidc.ScopeEmail},
 	}
 
-	extraClaims := oidcGrantRequests(nil, consent, &oidcUserSessionJohn)
+	extraClaims := oidcGrantRequests(nil, consent, &oidcUserSessionJohn, nil)
 
 	assert.Len(t, extraClaims, 3)
 
@@ -73,7 +121,7 @@ func TestShouldGrantAppropriateClaimsForScopeEmail(t *testing.T) {
 	require.Contains(t, extraClaims, oidc.ClaimEmailVerified)
 	assert.Equal(t, true, extraClaims[oidc.ClaimEmailVerified])
 
-	extraClaims = oidcGrantRequests(nil, consent, &oidcUserSessionFred)
+	extraClaims = oidcGrantRequests(nil, consent, &oidcUserSessionFred, nil)
 
 	assert.Len(t, extraClaims, 2)
 
@@ -89,7 +137,7 @@ func TestShouldGrantAppropriateClaimsForScopeOpenIDAndProfile(t *testing.T) {
 		GrantedScopes: []string{oidc.ScopeOpenID, oidc.ScopeProfile},
 	}
 
-	extraClaims := oidcGrantRequests(nil, consent, &oidcUserSessionJohn)
+	extraClaims := oidcGrantRequests(nil, consent, &oidcUserSessionJohn, nil)
 
 	assert.Len(t, extraClaims, 2)
 
@@ -99,7 +147,7 @@ func TestShouldGrantAppropriateClaimsForScopeOpenIDAndProfile(t *testing.T) {
 	require.Contains(t, extraClaims, oidc.ClaimFullName)
 	assert.Equal(t, "John Smith", extraClaims[oidc.ClaimFullName])
 
-	extraClaims = oidcGrantRequests(nil, consent, &oidcUserSessionFred)
+	extraClaims = oidcGrantRequests(nil, consent, &oidcUserSessionFred, nil)
 
 	assert.Len(t, extraClaims, 2)
 
@@ -110,6 +158,34 @@ func TestShouldGrantAppropriateClaimsForScopeOpenIDAndProfile(t *testing.T) {
 	assert.Equal(t, extraClaims[oidc.ClaimFullName], "Fred Smith")
 }
 
+func TestShouldGrantAppropriateClaimsForCustomScope(t *testing.T) {
+	consent := &model.OAuth2ConsentSession{
+		GrantedScopes: []string{oidc.ScopeOpenID, oidc.ScopeProfile, "upn"},
+	}
+
+	definitions := map[string]schema.IdentityProvidersOpenIDConnectScope{
+		"upn": {
+			Claims: map[string]schema.IdentityProvidersOpenIDConnectScopeClaim{
+				oidc.ClaimPreferredUsername: {Attribute: oidc.UserAttributeEmail},
+				"org":                       {Value: "Example"},
+			},
+		},
+	}
+
+	extraClaims := oidcGrantRequests(nil, consent, &oidcUserSessionJohn, definitions)
+
+	assert.Len(t, extraClaims, 3)
+
+	require.Contains(t, extraClaims, oidc.ClaimPreferredUsername)
+	assert.Equal(t, "j.smith@authelia.com", extraClaims[oidc.ClaimPreferredUsername])
+
+	require.Contains(t, extraClaims, oidc.ClaimFullName)
+	assert.Equal(t, "John Smith", extraClaims[oidc.ClaimFullName])
+
+	require.Contains(t, extraClaims, "org")
+	assert.Equal(t, "Example", extraClaims["org"])
+}
+
 func TestOIDCApplyUserInfoClaims(t *testing.T) {
 	testCases := []struct {
 		name               string
@@ -117,6 +193,7 @@ func TestOIDCApplyUserInfoClaims(t *testing.T) {
 		scopes             oauthelia2.Arguments
 		resolver           oidcDetailResolver
 		details            *authentication.UserDetails
+		definitions        map[string]schema.IdentityProvidersOpenIDConnectScope
 		original, expected map[string]any
 	}{
 		{
@@ -203,6 +280,26 @@ func TestOIDCApplyUserInfoClaims(t *testing.T) {
 				oidc.ClaimEmailAlts:         []string{"john.smith@example.com"},
 			},
 		},
+		{
+			name:     "ShouldMapPhoneClaims",
+			clientID: "test",
+			scopes:   []string{oidc.ScopeOpenID, oidc.ScopePhone},
+			details: &authentication.UserDetails{
+				Username:    "john",
+				PhoneNumber: "+1 555 0100",
+			},
+			original: map[string]any{
+				oidc.ClaimSubject:             "6f05a84f-de27-47e7-8b95-351966532c42",
+				oidc.ClaimPhoneNumber:         "+1 555 0199",
+				oidc.ClaimPhoneNumberVerified: true,
+			},
+			expected: map[string]any{
+				oidc.ClaimAudience:            []string{"test"},
+				oidc.ClaimSubject:             "6f05a84f-de27-47e7-8b95-351966532c42",
+				oidc.ClaimPhoneNumber:         "+1 555 0100",
+				oidc.ClaimPhoneNumberVerified: false,
+			},
+		},
 		{
 			name:     "ShouldMapAllClaimsWithExtras",
 			clientID: "test",
@@ -235,6 +332,37 @@ func TestOIDCApplyUserInfoClaims(t *testing.T) {
 				oidc.ClaimEmailAlts:         []string{"john.smith@example.com"},
 			},
 		},
+		{
+			name:     "ShouldMapCustomScopeClaims",
+			clientID: "test",
+			scopes:   []string{oidc.ScopeOpenID, "hr"},
+			definitions: map[string]schema.IdentityProvidersOpenIDConnectScope{
+				"hr": {
+					Claims: map[string]schema.IdentityProvidersOpenIDConnectScopeClaim{
+						"department":  {Attribute: "department"},
+						"employee_id": {Attribute: "employee_id"},
+						"org":         {Value: "Example"},
+					},
+				},
+			},
+			details: &authentication.UserDetails{
+				Username: "john",
+				Extra:    map[string]any{"department": "Engineering"},
+			},
+			original: map[string]any{
+				oidc.ClaimSubject: "6f05a84f-de27-47e7-8b95-351966532c42",
+				"department":      "Sales",
+				"employee_id":     "1234",
+				"other":           "abc",
+			},
+			expected: map[string]any{
+				oidc.ClaimAudience: []string{"test"},
+				oidc.ClaimSubject:  "6f05a84f-de27-47e7-8b95-351966532c42",
+				"department":       "Engineering",
+				"org":              "Example",
+				"other":            "abc",
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -247,7 +375,7 @@ func TestOIDCApplyUserInfoClaims(t *testing.T) {
 				resolver = oidcTestDetailerFromSubject(tc.details)
 			}
 
-			oidcApplyUserInfoClaims(tc.clientID, tc.scopes, tc.original, claims, resolver)
+			oidcApplyUserInfoClaims(tc.clientID, tc.scopes, tc.original, claims, resolver, tc.definitions)
 
 			assert.Equal(t, tc.expected, claims)
 		})
@@ -10,6 +10,7 @@ import (
 	"github.com/valyala/fasthttp"
 
 	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/oidc"
@@ -261,7 +262,9 @@ func markAuthenticationAttempt(ctx *middlewares.AutheliaCtx, successful bool, ba
 		}
 	}
 
-	if err = ctx.Providers.Regulator.Mark(ctx, successful, bannedUntil != nil, username, requestURI, requestMethod, authType); err != nil {
+	var bans []model.RegulationBan
+
+	if bans, err = ctx.Providers.Regulator.Mark(ctx, successful, bannedUntil != nil, username, requestURI, requestMethod, authType); err != nil {
 		ctx.Logger.WithError(err).Errorf("Unable to mark %s authentication attempt by user '%s'", authType, username)
 
 		return err
@@ -269,20 +272,71 @@ func markAuthenticationAttempt(ctx *middlewares.AutheliaCtx, successful bool, ba
 
 	if successful {
 		ctx.Logger.Debugf("Successful %s authentication attempt made by user '%s'", authType, username)
+
+		ctxPublishEvent(ctx, schema.SecurityEventAuthenticationSuccess, username, map[string]any{securityEventKeyMethod: authType})
 	} else {
 		switch {
 		case errAuth != nil:
 			ctx.Logger.WithError(errAuth).Errorf("Unsuccessful %s authentication attempt by user '%s'", authType, username)
+		case bannedUntil != nil && bannedUntil.IsZero():
+			ctx.Logger.Errorf("Unsuccessful %s authentication attempt by user '%s' and they are permanently banned", authType, username)
 		case bannedUntil != nil:
 			ctx.Logger.Errorf("Unsuccessful %s authentication attempt by user '%s' and they are banned until %s", authType, username, bannedUntil)
 		default:
 			ctx.Logger.Errorf("Unsuccessful %s authentication attempt by user '%s'", authType, username)
 		}
+
+		ctxPublishEvent(ctx, schema.SecurityEventAuthenticationFailure, username, map[string]any{securityEventKeyMethod: authType})
+
+		for _, ban := range bans {
+			ctxPublishBanEvent(ctx, username, authType, ban)
+		}
 	}
 
 	return nil
 }
 
+// ctxPublishBanEvent publishes the regulation ban security event for an automatic ban which was the result of the
+// failed authentication attempt which was just marked.
+func ctxPublishBanEvent(ctx *middlewares.AutheliaCtx, username, authType string, ban model.RegulationBan) {
+	ctxPublishEvent(ctx, schema.SecurityEventRegulationBan, username, map[string]any{
+		securityEventKeyMethod:      authType,
+		securityEventKeyBanKind:     ban.Kind,
+		securityEventKeyBanValue:    ban.Value,
+		securityEventKeyBannedUntil: ban.ExpiresAt.Time.UTC().Format(time.RFC3339),
+	})
+}
+
+// ctxRegulate checks if the user, the remote ip, or the subnet of the remote ip is banned. If it's banned the
+// authentication attempt is marked as banned and true is returned.
+func ctxRegulate(ctx *middlewares.AutheliaCtx, username, authType string) (banned bool) {
+	bannedUntil, err := ctx.Providers.Regulator.Regulate(ctx, username)
+	if err == nil {
+		return ctxRegulateRemoteIP(ctx, username, authType)
+	}
+
+	ctx.Logger.WithError(err).Debugf("Rejecting %s authentication attempt by user '%s'", authType, username)
+
+	_ = markAuthenticationAttempt(ctx, false, &bannedUntil, username, authType, nil)
+
+	return true
+}
+
+// ctxRegulateRemoteIP checks if the remote ip or the subnet of the remote ip is banned. If it's banned the authentication
+// attempt is marked as banned and true is returned.
+func ctxRegulateRemoteIP(ctx *middlewares.AutheliaCtx, username, authType string) (banned bool) {
+	bannedUntil, err := ctx.Providers.Regulator.RegulateRemoteIP(ctx)
+	if err == nil {
+		return false
+	}
+
+	ctx.Logger.WithError(err).Debugf("Rejecting %s authentication attempt by user '%s' from remote ip '%s'", authType, username, ctx.RemoteIP())
+
+	_ = markAuthenticationAttempt(ctx, false, &bannedUntil, username, authType, nil)
+
+	return true
+}
+
 func respondUnauthorized(ctx *middlewares.AutheliaCtx, message string) {
 	ctx.SetStatusCode(fasthttp.StatusUnauthorized)
 	ctx.SetJSONError(message)
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/url"
+	"time"
 
 	oauthelia2 "authelia.com/provider/oauth2"
 	"github.com/google/uuid"
@@ -191,3 +192,27 @@ type handlerAuthorizationConsent func(
 	userSession session.UserSession, subject uuid.UUID,
 	rw http.ResponseWriter, r *http.Request,
 	requester oauthelia2.AuthorizeRequester) (consent *model.OAuth2ConsentSession, handled bool)
+
+// UserSessionResponse represents an entry of the response sent by the user sessions endpoint.
+type UserSessionResponse struct {
+	ID           string    `json:"id"`
+	Current      bool      `json:"current"`
+	Device       string    `json:"device"`
+	IP           string    `json:"ip"`
+	UserAgent    string    `json:"user_agent"`
+	CookieDomain string    `json:"cookie_domain"`
+	CreatedAt    time.Time `json:"created_at"`
+	LastActiveAt time.Time `json:"last_active_at"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// KnownDeviceResponse represents an entry of the response sent by the known devices endpoint.
+type KnownDeviceResponse struct {
+	ID         int       `json:"id"`
+	Current    bool      `json:"current"`
+	Device     string    `json:"device"`
+	Subnet     string    `json:"subnet"`
+	UserAgent  string    `json:"user_agent"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/templates"
 )
 
@@ -12,6 +13,8 @@ const (
 	eventLogKeyAction      = "Action"
 	eventLogKeyCategory    = "Category"
 	eventLogKeyDescription = "Description"
+	eventLogKeyDevice      = "Device"
+	eventLogKeyLocation    = "Location"
 
 	eventEmailAction2FABody  = "Second Factor Method"
 	eventLogAction2FAAdded   = "Second Factor Method Added"
@@ -25,10 +28,26 @@ const (
 	eventEmailActionPasswordReset       = "Password Reset"
 	eventEmailActionPasswordResetSuffix = "was successful."
 
+	eventEmailActionKnownDevicePrefix = "a"
+	eventEmailActionKnownDeviceSuffix = "was detected on your account."
+	eventLogActionNewDevice           = "Sign In From a New Device"
+	eventLogActionNewLocation         = "Sign In From a New Location"
+
 	eventLogCategoryOneTimePassword    = "One-Time Password"
 	eventLogCategoryWebAuthnCredential = "WebAuthn Credential" //nolint:gosec
 )
 
+const (
+	securityEventKeyMethod      = "method"
+	securityEventKeyBanKind     = "kind"
+	securityEventKeyBanValue    = "value"
+	securityEventKeyBannedUntil = "banned_until"
+	securityEventKeyCategory    = "category"
+	securityEventKeyDescription = "description"
+	securityEventKeyClientID    = "client_id"
+	securityEventKeyScopes      = "scopes"
+)
+
 type emailEventBody struct {
 	Prefix string
 	Body   string
@@ -75,3 +94,18 @@ func ctxLogEvent(ctx *middlewares.AutheliaCtx, username, description string, bod
 		return
 	}
 }
+
+// ctxPublishEvent publishes a security event to the security event feed if it's configured.
+func ctxPublishEvent(ctx *middlewares.AutheliaCtx, kind, username string, details map[string]any) {
+	if ctx.Providers.Events == nil {
+		return
+	}
+
+	ctx.Providers.Events.Publish(model.SecurityEvent{
+		Type:     kind,
+		Time:     ctx.Clock.Now(),
+		Username: username,
+		RemoteIP: ctx.RemoteIP().String(),
+		Details:  details,
+	})
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/asaskevich/govalidator"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/valyala/fasthttp"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/random"
 	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/storage"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
@@ -415,6 +417,76 @@ func (ctx *AutheliaCtx) DestroySession() error {
 	return provider.DestroySession(ctx.RequestCtx)
 }
 
+// SaveUserSessionIndex adds the user session to the user session index and sets the public id of the user session.
+// The user session must be saved afterwards for the public id to be retained.
+func (ctx *AutheliaCtx) SaveUserSessionIndex(provider *session.Session, userSession *session.UserSession) (err error) {
+	var record *model.UserSession
+
+	if record, err = model.NewUserSession(ctx, userSession.Username, provider.Config.Domain, string(ctx.UserAgent()), provider.GetUserSessionExpiration(*userSession)); err != nil {
+		return fmt.Errorf("unable to create user session index entry: %w", err)
+	}
+
+	if err = ctx.Providers.StorageProvider.SaveUserSession(ctx, *record); err != nil {
+		return fmt.Errorf("unable to save user session index entry: %w", err)
+	}
+
+	userSession.PublicID = record.PublicID
+
+	return nil
+}
+
+// IsUserSessionRevoked checks the user session index to determine if the user session has been revoked. If it has not
+// been revoked the activity of the session is periodically recorded in the index. The index is only checked once per
+// activity interval for each session, revocations made by this instance are applied immediately.
+func (ctx *AutheliaCtx) IsUserSessionRevoked(provider *session.Session, userSession *session.UserSession) (revoked bool) {
+	if userSession.IsAnonymous() || userSession.PublicID == uuid.Nil {
+		return false
+	}
+
+	now := ctx.Clock.Now()
+
+	if ctx.Providers.SessionProvider != nil && !ctx.Providers.SessionProvider.IsRevocationCheckDue(userSession.PublicID, now) {
+		return false
+	}
+
+	var (
+		record *model.UserSession
+		err    error
+	)
+
+	if record, err = ctx.Providers.StorageProvider.LoadUserSession(ctx, userSession.PublicID); err != nil {
+		if !errors.Is(err, storage.ErrNoUserSession) {
+			ctx.Logger.WithError(err).WithField("username", userSession.Username).Error("Error occurred loading the user session index entry")
+		}
+
+		return false
+	}
+
+	if record.Revoked() || record.Username != userSession.Username {
+		ctx.Logger.WithField("username", userSession.Username).WithField("session", record.PublicID).Info("Session for user has been revoked")
+
+		return true
+	}
+
+	if ctx.Providers.SessionProvider != nil {
+		ctx.Providers.SessionProvider.SetRevocationChecked(userSession.PublicID, now, now.Add(userSessionIndexActivityInterval))
+	}
+
+	if now.Sub(record.LastActiveAt) < userSessionIndexActivityInterval {
+		return false
+	}
+
+	record.LastActiveAt = now
+	record.ExpiresAt = now.Add(provider.GetUserSessionExpiration(*userSession))
+	record.IP = model.NewIP(ctx.RemoteIP())
+
+	if err = ctx.Providers.StorageProvider.UpdateUserSessionActivity(ctx, *record); err != nil {
+		ctx.Logger.WithError(err).WithField("username", userSession.Username).Error("Error occurred updating the user session index entry")
+	}
+
+	return false
+}
+
 // GetDefaultRedirectionURL retrieves the default redirection URL for the request.
 func (ctx *AutheliaCtx) GetDefaultRedirectionURL() *url.URL {
 	if provider, err := ctx.GetSessionProvider(); err == nil {
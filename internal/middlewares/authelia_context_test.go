@@ -1,10 +1,13 @@
 package middlewares_test
 
 import (
+	"database/sql"
 	"net"
 	"net/url"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/valyala/fasthttp"
@@ -569,3 +572,60 @@ func TestAutheliaCtx_GetDefaultRedirectionURL(t *testing.T) {
 
 	assert.Equal(t, &url.URL{Scheme: "https", Host: "www.example2.com"}, mock2.Ctx.GetDefaultRedirectionURL())
 }
+
+func TestAutheliaCtx_IsUserSessionRevoked(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+	defer mock.Close()
+
+	provider, err := mock.Ctx.GetSessionProvider()
+	require.NoError(t, err)
+
+	id := uuid.MustParse("7e6f5d4c-3b2a-4190-8f7e-6d5c4b3a2918")
+
+	userSession := &session.UserSession{Username: "john", AuthenticationLevel: 1, PublicID: id}
+
+	record := &model.UserSession{PublicID: id, Username: "john", LastActiveAt: mock.Clock.Now()}
+
+	mock.StorageMock.EXPECT().LoadUserSession(mock.Ctx, id).Return(record, nil)
+
+	assert.False(t, mock.Ctx.IsUserSessionRevoked(provider, userSession))
+
+	// The result is cached for the activity interval so the index is not queried again.
+	assert.False(t, mock.Ctx.IsUserSessionRevoked(provider, userSession))
+
+	mock.Clock.Set(mock.Clock.Now().Add(time.Minute))
+
+	revoked := &model.UserSession{PublicID: id, Username: "john", LastActiveAt: record.LastActiveAt, RevokedAt: sql.NullTime{Time: mock.Clock.Now(), Valid: true}}
+
+	mock.StorageMock.EXPECT().LoadUserSession(mock.Ctx, id).Return(revoked, nil)
+
+	assert.True(t, mock.Ctx.IsUserSessionRevoked(provider, userSession))
+
+	// Revocations are never cached.
+	mock.StorageMock.EXPECT().LoadUserSession(mock.Ctx, id).Return(revoked, nil)
+
+	assert.True(t, mock.Ctx.IsUserSessionRevoked(provider, userSession))
+}
+
+func TestAutheliaCtx_IsUserSessionRevokedShouldCheckAfterForget(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+	defer mock.Close()
+
+	provider, err := mock.Ctx.GetSessionProvider()
+	require.NoError(t, err)
+
+	id := uuid.MustParse("7e6f5d4c-3b2a-4190-8f7e-6d5c4b3a2918")
+
+	userSession := &session.UserSession{Username: "john", AuthenticationLevel: 1, PublicID: id}
+
+	gomock.InOrder(
+		mock.StorageMock.EXPECT().LoadUserSession(mock.Ctx, id).Return(&model.UserSession{PublicID: id, Username: "john", LastActiveAt: mock.Clock.Now()}, nil),
+		mock.StorageMock.EXPECT().LoadUserSession(mock.Ctx, id).Return(&model.UserSession{PublicID: id, Username: "john", RevokedAt: sql.NullTime{Time: mock.Clock.Now(), Valid: true}}, nil),
+	)
+
+	assert.False(t, mock.Ctx.IsUserSessionRevoked(provider, userSession))
+
+	mock.Ctx.Providers.SessionProvider.ForgetRevocationCheck(id)
+
+	assert.True(t, mock.Ctx.IsUserSessionRevoked(provider, userSession))
+}
@@ -2,6 +2,7 @@ package middlewares
 
 import (
 	"errors"
+	"time"
 
 	"github.com/valyala/fasthttp"
 )
@@ -61,6 +62,8 @@ var (
 	headerValueCSPNoneFormPost = []byte("default-src 'none'; script-src 'sha256-skflBqA90WuHvoczvimLdj49ExKdizFjX2Itd6xKZdU='")
 	headerValueCSPSelf         = []byte("default-src 'self'")
 
+	tmplCSPNoneEndSession = "default-src 'none'; frame-src %s; script-src 'sha256-8ztwJSl5ZIiFv5PEQFRQSTXE2osTwrgz5l3+731+0YI='"
+
 	headerValueNoSniff                 = []byte("nosniff")
 	headerValueStrictOriginCrossOrigin = []byte("strict-origin-when-cross-origin")
 	headerValueDENY                    = []byte("DENY")
@@ -74,6 +77,10 @@ var (
 	headerValuePermissionsPolicy       = []byte("accelerometer=(), autoplay=(), camera=(), display-capture=(), geolocation=(), gyroscope=(), keyboard-map=(), magnetometer=(), microphone=(), midi=(), payment=(), picture-in-picture=(), screen-wake-lock=(), sync-xhr=(), xr-spatial-tracking=(), interest-cohort=()")
 )
 
+// userSessionIndexActivityInterval is the minimum interval between updates to the activity of a user session index
+// entry.
+const userSessionIndexActivityInterval = time.Minute
+
 const (
 	strProtoHTTPS = "https"
 	strProtoHTTP  = "http"
@@ -87,6 +94,7 @@ const (
 const (
 	UserValueKeyBaseURL int8 = iota
 	UserValueKeyOpenIDConnectResponseModeFormPost
+	UserValueKeyOpenIDConnectFrameSources
 	UserValueKeyRawURI
 )
 
@@ -1,6 +1,9 @@
 package middlewares
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/valyala/fasthttp"
 )
 
@@ -76,6 +79,8 @@ func SecurityHeadersCSPNoneOpenIDConnect(next fasthttp.RequestHandler) fasthttp.
 
 		if modeFormPost, ok := ctx.UserValue(UserValueKeyOpenIDConnectResponseModeFormPost).(bool); ok && modeFormPost {
 			ctx.Response.Header.SetBytesKV(headerContentSecurityPolicy, headerValueCSPNoneFormPost)
+		} else if sources, ok := ctx.UserValue(UserValueKeyOpenIDConnectFrameSources).([]string); ok && len(sources) != 0 {
+			ctx.Response.Header.SetBytesK(headerContentSecurityPolicy, fmt.Sprintf(tmplCSPNoneEndSession, strings.Join(sources, " ")))
 		} else {
 			ctx.Response.Header.SetBytesKV(headerContentSecurityPolicy, headerValueCSPNone)
 		}
@@ -11,8 +11,24 @@ import (
 // Require1FA check if user has enough permissions to execute the next handler.
 func Require1FA(next RequestHandler) RequestHandler {
 	return func(ctx *AutheliaCtx) {
-		if s, err := ctx.GetSession(); err != nil || s.AuthenticationLevel < authentication.OneFactor {
+		var (
+			provider    *session.Session
+			userSession session.UserSession
+			err         error
+		)
+
+		if userSession, err = ctx.GetSession(); err != nil || userSession.AuthenticationLevel < authentication.OneFactor {
+			ctx.ReplyForbidden()
+			return
+		}
+
+		if provider, err = ctx.GetSessionProvider(); err == nil && ctx.IsUserSessionRevoked(provider, &userSession) {
+			if err = provider.DestroySession(ctx.RequestCtx); err != nil {
+				ctx.Logger.WithError(err).Error("Error occurred trying to destroy the revoked session")
+			}
+
 			ctx.ReplyForbidden()
+
 			return
 		}
 
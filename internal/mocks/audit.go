@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/authelia/authelia/v4/internal/audit (interfaces: Provider)
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -destination audit.go -mock_names Provider=MockAudit github.com/authelia/authelia/v4/internal/audit Provider
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/authelia/authelia/v4/internal/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAudit is a mock of Provider interface.
+type MockAudit struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditMockRecorder
+	isgomock struct{}
+}
+
+// MockAuditMockRecorder is the mock recorder for MockAudit.
+type MockAuditMockRecorder struct {
+	mock *MockAudit
+}
+
+// NewMockAudit creates a new mock instance.
+func NewMockAudit(ctrl *gomock.Controller) *MockAudit {
+	mock := &MockAudit{ctrl: ctrl}
+	mock.recorder = &MockAuditMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAudit) EXPECT() *MockAuditMockRecorder {
+	return m.recorder
+}
+
+// RecordAuthorizationDecision mocks base method.
+func (m *MockAudit) RecordAuthorizationDecision(ctx context.Context, decision model.AuthorizationDecision) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordAuthorizationDecision", ctx, decision)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordAuthorizationDecision indicates an expected call of RecordAuthorizationDecision.
+func (mr *MockAuditMockRecorder) RecordAuthorizationDecision(ctx, decision any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordAuthorizationDecision", reflect.TypeOf((*MockAudit)(nil).RecordAuthorizationDecision), ctx, decision)
+}
+
+// StartupCheck mocks base method.
+func (m *MockAudit) StartupCheck() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartupCheck")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartupCheck indicates an expected call of StartupCheck.
+func (mr *MockAuditMockRecorder) StartupCheck() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartupCheck", reflect.TypeOf((*MockAudit)(nil).StartupCheck))
+}
@@ -197,7 +197,7 @@ func NewMockAutheliaCtx(t *testing.T) *MockAutheliaCtx {
 	providers.Notifier = mockAuthelia.NotifierMock
 
 	providers.Authorizer = authorization.NewAuthorizer(
-		&config)
+		&config, &mockAuthelia.Clock)
 
 	providers.SessionProvider = session.NewProvider(
 		config.Session, nil)
@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	sql "database/sql"
+	net "net"
 	reflect "reflect"
 	time "time"
 
@@ -59,6 +60,20 @@ func (mr *MockStorageMockRecorder) AppendAuthenticationLog(ctx, attempt any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendAuthenticationLog", reflect.TypeOf((*MockStorage)(nil).AppendAuthenticationLog), ctx, attempt)
 }
 
+// AppendAuthorizationDecisions mocks base method.
+func (m *MockStorage) AppendAuthorizationDecisions(ctx context.Context, decisions []model.AuthorizationDecision) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendAuthorizationDecisions", ctx, decisions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AppendAuthorizationDecisions indicates an expected call of AppendAuthorizationDecisions.
+func (mr *MockStorageMockRecorder) AppendAuthorizationDecisions(ctx, decisions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendAuthorizationDecisions", reflect.TypeOf((*MockStorage)(nil).AppendAuthorizationDecisions), ctx, decisions)
+}
+
 // BeginTX mocks base method.
 func (m *MockStorage) BeginTX(ctx context.Context) (context.Context, error) {
 	m.ctrl.T.Helper()
@@ -130,6 +145,20 @@ func (mr *MockStorageMockRecorder) ConsumeOneTimeCode(ctx, code any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeOneTimeCode", reflect.TypeOf((*MockStorage)(nil).ConsumeOneTimeCode), ctx, code)
 }
 
+// DeactivateOAuth2DeviceCodeSession mocks base method.
+func (m *MockStorage) DeactivateOAuth2DeviceCodeSession(ctx context.Context, signature string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateOAuth2DeviceCodeSession", ctx, signature)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeactivateOAuth2DeviceCodeSession indicates an expected call of DeactivateOAuth2DeviceCodeSession.
+func (mr *MockStorageMockRecorder) DeactivateOAuth2DeviceCodeSession(ctx, signature any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateOAuth2DeviceCodeSession", reflect.TypeOf((*MockStorage)(nil).DeactivateOAuth2DeviceCodeSession), ctx, signature)
+}
+
 // DeactivateOAuth2Session mocks base method.
 func (m *MockStorage) DeactivateOAuth2Session(ctx context.Context, sessionType storage.OAuth2SessionType, signature string) error {
 	m.ctrl.T.Helper()
@@ -158,6 +187,34 @@ func (mr *MockStorageMockRecorder) DeactivateOAuth2SessionByRequestID(ctx, sessi
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateOAuth2SessionByRequestID", reflect.TypeOf((*MockStorage)(nil).DeactivateOAuth2SessionByRequestID), ctx, sessionType, requestID)
 }
 
+// DeleteKnownDevice mocks base method.
+func (m *MockStorage) DeleteKnownDevice(ctx context.Context, username string, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteKnownDevice", ctx, username, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteKnownDevice indicates an expected call of DeleteKnownDevice.
+func (mr *MockStorageMockRecorder) DeleteKnownDevice(ctx, username, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKnownDevice", reflect.TypeOf((*MockStorage)(nil).DeleteKnownDevice), ctx, username, id)
+}
+
+// DeleteOAuth2RegisteredClient mocks base method.
+func (m *MockStorage) DeleteOAuth2RegisteredClient(ctx context.Context, clientID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOAuth2RegisteredClient", ctx, clientID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOAuth2RegisteredClient indicates an expected call of DeleteOAuth2RegisteredClient.
+func (mr *MockStorageMockRecorder) DeleteOAuth2RegisteredClient(ctx, clientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOAuth2RegisteredClient", reflect.TypeOf((*MockStorage)(nil).DeleteOAuth2RegisteredClient), ctx, clientID)
+}
+
 // DeletePreferredDuoDevice mocks base method.
 func (m *MockStorage) DeletePreferredDuoDevice(ctx context.Context, username string) error {
 	m.ctrl.T.Helper()
@@ -244,6 +301,36 @@ func (mr *MockStorageMockRecorder) FindIdentityVerification(ctx, jti any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindIdentityVerification", reflect.TypeOf((*MockStorage)(nil).FindIdentityVerification), ctx, jti)
 }
 
+// LoadActiveRegulationBans mocks base method.
+func (m *MockStorage) LoadActiveRegulationBans(ctx context.Context, now time.Time, limit, page int) ([]model.RegulationBan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadActiveRegulationBans", ctx, now, limit, page)
+	ret0, _ := ret[0].([]model.RegulationBan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadActiveRegulationBans indicates an expected call of LoadActiveRegulationBans.
+func (mr *MockStorageMockRecorder) LoadActiveRegulationBans(ctx, now, limit, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadActiveRegulationBans", reflect.TypeOf((*MockStorage)(nil).LoadActiveRegulationBans), ctx, now, limit, page)
+}
+
+// LoadActiveRegulationBansByKind mocks base method.
+func (m *MockStorage) LoadActiveRegulationBansByKind(ctx context.Context, kind string, now time.Time) ([]model.RegulationBan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadActiveRegulationBansByKind", ctx, kind, now)
+	ret0, _ := ret[0].([]model.RegulationBan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadActiveRegulationBansByKind indicates an expected call of LoadActiveRegulationBansByKind.
+func (mr *MockStorageMockRecorder) LoadActiveRegulationBansByKind(ctx, kind, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadActiveRegulationBansByKind", reflect.TypeOf((*MockStorage)(nil).LoadActiveRegulationBansByKind), ctx, kind, now)
+}
+
 // LoadAuthenticationLogs mocks base method.
 func (m *MockStorage) LoadAuthenticationLogs(ctx context.Context, username string, fromDate time.Time, limit, page int) ([]model.AuthenticationAttempt, error) {
 	m.ctrl.T.Helper()
@@ -259,6 +346,51 @@ func (mr *MockStorageMockRecorder) LoadAuthenticationLogs(ctx, username, fromDat
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadAuthenticationLogs", reflect.TypeOf((*MockStorage)(nil).LoadAuthenticationLogs), ctx, username, fromDate, limit, page)
 }
 
+// LoadAuthorizationDecisions mocks base method.
+func (m *MockStorage) LoadAuthorizationDecisions(ctx context.Context, username, domain, decision string, since time.Time, limit, page int) ([]model.AuthorizationDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadAuthorizationDecisions", ctx, username, domain, decision, since, limit, page)
+	ret0, _ := ret[0].([]model.AuthorizationDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadAuthorizationDecisions indicates an expected call of LoadAuthorizationDecisions.
+func (mr *MockStorageMockRecorder) LoadAuthorizationDecisions(ctx, username, domain, decision, since, limit, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadAuthorizationDecisions", reflect.TypeOf((*MockStorage)(nil).LoadAuthorizationDecisions), ctx, username, domain, decision, since, limit, page)
+}
+
+// LoadFailedAuthenticationLogsByRemoteIP mocks base method.
+func (m *MockStorage) LoadFailedAuthenticationLogsByRemoteIP(ctx context.Context, ip net.IP, fromDate time.Time, limit, page int) ([]model.AuthenticationAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadFailedAuthenticationLogsByRemoteIP", ctx, ip, fromDate, limit, page)
+	ret0, _ := ret[0].([]model.AuthenticationAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadFailedAuthenticationLogsByRemoteIP indicates an expected call of LoadFailedAuthenticationLogsByRemoteIP.
+func (mr *MockStorageMockRecorder) LoadFailedAuthenticationLogsByRemoteIP(ctx, ip, fromDate, limit, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadFailedAuthenticationLogsByRemoteIP", reflect.TypeOf((*MockStorage)(nil).LoadFailedAuthenticationLogsByRemoteIP), ctx, ip, fromDate, limit, page)
+}
+
+// LoadFailedAuthenticationLogsByRemoteSubnet mocks base method.
+func (m *MockStorage) LoadFailedAuthenticationLogsByRemoteSubnet(ctx context.Context, subnet string, fromDate time.Time, limit, page int) ([]model.AuthenticationAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadFailedAuthenticationLogsByRemoteSubnet", ctx, subnet, fromDate, limit, page)
+	ret0, _ := ret[0].([]model.AuthenticationAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadFailedAuthenticationLogsByRemoteSubnet indicates an expected call of LoadFailedAuthenticationLogsByRemoteSubnet.
+func (mr *MockStorageMockRecorder) LoadFailedAuthenticationLogsByRemoteSubnet(ctx, subnet, fromDate, limit, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadFailedAuthenticationLogsByRemoteSubnet", reflect.TypeOf((*MockStorage)(nil).LoadFailedAuthenticationLogsByRemoteSubnet), ctx, subnet, fromDate, limit, page)
+}
+
 // LoadIdentityVerification mocks base method.
 func (m *MockStorage) LoadIdentityVerification(ctx context.Context, jti string) (*model.IdentityVerification, error) {
 	m.ctrl.T.Helper()
@@ -274,6 +406,21 @@ func (mr *MockStorageMockRecorder) LoadIdentityVerification(ctx, jti any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadIdentityVerification", reflect.TypeOf((*MockStorage)(nil).LoadIdentityVerification), ctx, jti)
 }
 
+// LoadKnownDevices mocks base method.
+func (m *MockStorage) LoadKnownDevices(ctx context.Context, username string, since time.Time) ([]model.KnownDevice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadKnownDevices", ctx, username, since)
+	ret0, _ := ret[0].([]model.KnownDevice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadKnownDevices indicates an expected call of LoadKnownDevices.
+func (mr *MockStorageMockRecorder) LoadKnownDevices(ctx, username, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadKnownDevices", reflect.TypeOf((*MockStorage)(nil).LoadKnownDevices), ctx, username, since)
+}
+
 // LoadOAuth2BlacklistedJTI mocks base method.
 func (m *MockStorage) LoadOAuth2BlacklistedJTI(ctx context.Context, signature string) (*model.OAuth2BlacklistedJTI, error) {
 	m.ctrl.T.Helper()
@@ -289,6 +436,36 @@ func (mr *MockStorageMockRecorder) LoadOAuth2BlacklistedJTI(ctx, signature any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOAuth2BlacklistedJTI", reflect.TypeOf((*MockStorage)(nil).LoadOAuth2BlacklistedJTI), ctx, signature)
 }
 
+// LoadOAuth2ClientSessions mocks base method.
+func (m *MockStorage) LoadOAuth2ClientSessions(ctx context.Context, sessionID uuid.UUID) ([]model.OAuth2ClientSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadOAuth2ClientSessions", ctx, sessionID)
+	ret0, _ := ret[0].([]model.OAuth2ClientSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadOAuth2ClientSessions indicates an expected call of LoadOAuth2ClientSessions.
+func (mr *MockStorageMockRecorder) LoadOAuth2ClientSessions(ctx, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOAuth2ClientSessions", reflect.TypeOf((*MockStorage)(nil).LoadOAuth2ClientSessions), ctx, sessionID)
+}
+
+// LoadOAuth2ClientSessionsByUsername mocks base method.
+func (m *MockStorage) LoadOAuth2ClientSessionsByUsername(ctx context.Context, username string) ([]model.OAuth2ClientSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadOAuth2ClientSessionsByUsername", ctx, username)
+	ret0, _ := ret[0].([]model.OAuth2ClientSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadOAuth2ClientSessionsByUsername indicates an expected call of LoadOAuth2ClientSessionsByUsername.
+func (mr *MockStorageMockRecorder) LoadOAuth2ClientSessionsByUsername(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOAuth2ClientSessionsByUsername", reflect.TypeOf((*MockStorage)(nil).LoadOAuth2ClientSessionsByUsername), ctx, username)
+}
+
 // LoadOAuth2ConsentPreConfigurations mocks base method.
 func (m *MockStorage) LoadOAuth2ConsentPreConfigurations(ctx context.Context, clientID string, subject uuid.UUID) (*storage.ConsentPreConfigRows, error) {
 	m.ctrl.T.Helper()
@@ -319,6 +496,36 @@ func (mr *MockStorageMockRecorder) LoadOAuth2ConsentSessionByChallengeID(ctx, ch
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOAuth2ConsentSessionByChallengeID", reflect.TypeOf((*MockStorage)(nil).LoadOAuth2ConsentSessionByChallengeID), ctx, challengeID)
 }
 
+// LoadOAuth2DeviceCodeSession mocks base method.
+func (m *MockStorage) LoadOAuth2DeviceCodeSession(ctx context.Context, signature string) (*model.OAuth2DeviceCodeSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadOAuth2DeviceCodeSession", ctx, signature)
+	ret0, _ := ret[0].(*model.OAuth2DeviceCodeSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadOAuth2DeviceCodeSession indicates an expected call of LoadOAuth2DeviceCodeSession.
+func (mr *MockStorageMockRecorder) LoadOAuth2DeviceCodeSession(ctx, signature any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOAuth2DeviceCodeSession", reflect.TypeOf((*MockStorage)(nil).LoadOAuth2DeviceCodeSession), ctx, signature)
+}
+
+// LoadOAuth2DeviceCodeSessionByUserCode mocks base method.
+func (m *MockStorage) LoadOAuth2DeviceCodeSessionByUserCode(ctx context.Context, userCodeSignature string) (*model.OAuth2DeviceCodeSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadOAuth2DeviceCodeSessionByUserCode", ctx, userCodeSignature)
+	ret0, _ := ret[0].(*model.OAuth2DeviceCodeSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadOAuth2DeviceCodeSessionByUserCode indicates an expected call of LoadOAuth2DeviceCodeSessionByUserCode.
+func (mr *MockStorageMockRecorder) LoadOAuth2DeviceCodeSessionByUserCode(ctx, userCodeSignature any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOAuth2DeviceCodeSessionByUserCode", reflect.TypeOf((*MockStorage)(nil).LoadOAuth2DeviceCodeSessionByUserCode), ctx, userCodeSignature)
+}
+
 // LoadOAuth2PARContext mocks base method.
 func (m *MockStorage) LoadOAuth2PARContext(ctx context.Context, signature string) (*model.OAuth2PARContext, error) {
 	m.ctrl.T.Helper()
@@ -334,6 +541,21 @@ func (mr *MockStorageMockRecorder) LoadOAuth2PARContext(ctx, signature any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOAuth2PARContext", reflect.TypeOf((*MockStorage)(nil).LoadOAuth2PARContext), ctx, signature)
 }
 
+// LoadOAuth2RegisteredClient mocks base method.
+func (m *MockStorage) LoadOAuth2RegisteredClient(ctx context.Context, clientID string) (*model.OAuth2RegisteredClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadOAuth2RegisteredClient", ctx, clientID)
+	ret0, _ := ret[0].(*model.OAuth2RegisteredClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadOAuth2RegisteredClient indicates an expected call of LoadOAuth2RegisteredClient.
+func (mr *MockStorageMockRecorder) LoadOAuth2RegisteredClient(ctx, clientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOAuth2RegisteredClient", reflect.TypeOf((*MockStorage)(nil).LoadOAuth2RegisteredClient), ctx, clientID)
+}
+
 // LoadOAuth2Session mocks base method.
 func (m *MockStorage) LoadOAuth2Session(ctx context.Context, sessionType storage.OAuth2SessionType, signature string) (*model.OAuth2Session, error) {
 	m.ctrl.T.Helper()
@@ -439,6 +661,21 @@ func (mr *MockStorageMockRecorder) LoadPreferredDuoDevice(ctx, username any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadPreferredDuoDevice", reflect.TypeOf((*MockStorage)(nil).LoadPreferredDuoDevice), ctx, username)
 }
 
+// LoadRegulationBans mocks base method.
+func (m *MockStorage) LoadRegulationBans(ctx context.Context, kind, value string, now, since time.Time) ([]model.RegulationBan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadRegulationBans", ctx, kind, value, now, since)
+	ret0, _ := ret[0].([]model.RegulationBan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadRegulationBans indicates an expected call of LoadRegulationBans.
+func (mr *MockStorageMockRecorder) LoadRegulationBans(ctx, kind, value, now, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadRegulationBans", reflect.TypeOf((*MockStorage)(nil).LoadRegulationBans), ctx, kind, value, now, since)
+}
+
 // LoadTOTPConfiguration mocks base method.
 func (m *MockStorage) LoadTOTPConfiguration(ctx context.Context, username string) (*model.TOTPConfiguration, error) {
 	m.ctrl.T.Helper()
@@ -469,6 +706,21 @@ func (mr *MockStorageMockRecorder) LoadTOTPConfigurations(ctx, limit, page any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadTOTPConfigurations", reflect.TypeOf((*MockStorage)(nil).LoadTOTPConfigurations), ctx, limit, page)
 }
 
+// LoadUser mocks base method.
+func (m *MockStorage) LoadUser(ctx context.Context, username string) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadUser", ctx, username)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadUser indicates an expected call of LoadUser.
+func (mr *MockStorageMockRecorder) LoadUser(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadUser", reflect.TypeOf((*MockStorage)(nil).LoadUser), ctx, username)
+}
+
 // LoadUserInfo mocks base method.
 func (m *MockStorage) LoadUserInfo(ctx context.Context, username string) (model.UserInfo, error) {
 	m.ctrl.T.Helper()
@@ -529,6 +781,51 @@ func (mr *MockStorageMockRecorder) LoadUserOpaqueIdentifiers(ctx any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadUserOpaqueIdentifiers", reflect.TypeOf((*MockStorage)(nil).LoadUserOpaqueIdentifiers), ctx)
 }
 
+// LoadUserSession mocks base method.
+func (m *MockStorage) LoadUserSession(ctx context.Context, publicID uuid.UUID) (*model.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadUserSession", ctx, publicID)
+	ret0, _ := ret[0].(*model.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadUserSession indicates an expected call of LoadUserSession.
+func (mr *MockStorageMockRecorder) LoadUserSession(ctx, publicID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadUserSession", reflect.TypeOf((*MockStorage)(nil).LoadUserSession), ctx, publicID)
+}
+
+// LoadUserSessions mocks base method.
+func (m *MockStorage) LoadUserSessions(ctx context.Context, username string, now time.Time) ([]model.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadUserSessions", ctx, username, now)
+	ret0, _ := ret[0].([]model.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadUserSessions indicates an expected call of LoadUserSessions.
+func (mr *MockStorageMockRecorder) LoadUserSessions(ctx, username, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadUserSessions", reflect.TypeOf((*MockStorage)(nil).LoadUserSessions), ctx, username, now)
+}
+
+// LoadUsers mocks base method.
+func (m *MockStorage) LoadUsers(ctx context.Context, limit, page int) ([]model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadUsers", ctx, limit, page)
+	ret0, _ := ret[0].([]model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadUsers indicates an expected call of LoadUsers.
+func (mr *MockStorageMockRecorder) LoadUsers(ctx, limit, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadUsers", reflect.TypeOf((*MockStorage)(nil).LoadUsers), ctx, limit, page)
+}
+
 // LoadWebAuthnCredentialByID mocks base method.
 func (m *MockStorage) LoadWebAuthnCredentialByID(ctx context.Context, id int) (*model.WebAuthnCredential, error) {
 	m.ctrl.T.Helper()
@@ -589,6 +886,35 @@ func (mr *MockStorageMockRecorder) LoadWebAuthnUser(ctx, rpid, username any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadWebAuthnUser", reflect.TypeOf((*MockStorage)(nil).LoadWebAuthnUser), ctx, rpid, username)
 }
 
+// LogoutOAuth2ClientSession mocks base method.
+func (m *MockStorage) LogoutOAuth2ClientSession(ctx context.Context, id int, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogoutOAuth2ClientSession", ctx, id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogoutOAuth2ClientSession indicates an expected call of LogoutOAuth2ClientSession.
+func (mr *MockStorageMockRecorder) LogoutOAuth2ClientSession(ctx, id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutOAuth2ClientSession", reflect.TypeOf((*MockStorage)(nil).LogoutOAuth2ClientSession), ctx, id, at)
+}
+
+// PruneAuthorizationDecisions mocks base method.
+func (m *MockStorage) PruneAuthorizationDecisions(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneAuthorizationDecisions", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneAuthorizationDecisions indicates an expected call of PruneAuthorizationDecisions.
+func (mr *MockStorageMockRecorder) PruneAuthorizationDecisions(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneAuthorizationDecisions", reflect.TypeOf((*MockStorage)(nil).PruneAuthorizationDecisions), ctx, before)
+}
+
 // RevokeIdentityVerification mocks base method.
 func (m *MockStorage) RevokeIdentityVerification(ctx context.Context, jti string, ip model.NullIP) error {
 	m.ctrl.T.Helper()
@@ -659,6 +985,50 @@ func (mr *MockStorageMockRecorder) RevokeOneTimeCode(ctx, id, ip any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOneTimeCode", reflect.TypeOf((*MockStorage)(nil).RevokeOneTimeCode), ctx, id, ip)
 }
 
+// RevokeRegulationBans mocks base method.
+func (m *MockStorage) RevokeRegulationBans(ctx context.Context, kind, value string, revokedAt time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRegulationBans", ctx, kind, value, revokedAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeRegulationBans indicates an expected call of RevokeRegulationBans.
+func (mr *MockStorageMockRecorder) RevokeRegulationBans(ctx, kind, value, revokedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRegulationBans", reflect.TypeOf((*MockStorage)(nil).RevokeRegulationBans), ctx, kind, value, revokedAt)
+}
+
+// RevokeUserSession mocks base method.
+func (m *MockStorage) RevokeUserSession(ctx context.Context, username string, publicID uuid.UUID, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserSession", ctx, username, publicID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserSession indicates an expected call of RevokeUserSession.
+func (mr *MockStorageMockRecorder) RevokeUserSession(ctx, username, publicID, revokedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserSession", reflect.TypeOf((*MockStorage)(nil).RevokeUserSession), ctx, username, publicID, revokedAt)
+}
+
+// RevokeUserSessions mocks base method.
+func (m *MockStorage) RevokeUserSessions(ctx context.Context, username string, revokedAt time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserSessions", ctx, username, revokedAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeUserSessions indicates an expected call of RevokeUserSessions.
+func (mr *MockStorageMockRecorder) RevokeUserSessions(ctx, username, revokedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserSessions", reflect.TypeOf((*MockStorage)(nil).RevokeUserSessions), ctx, username, revokedAt)
+}
+
 // Rollback mocks base method.
 func (m *MockStorage) Rollback(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -687,6 +1057,20 @@ func (mr *MockStorageMockRecorder) SaveIdentityVerification(ctx, verification an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveIdentityVerification", reflect.TypeOf((*MockStorage)(nil).SaveIdentityVerification), ctx, verification)
 }
 
+// SaveKnownDevice mocks base method.
+func (m *MockStorage) SaveKnownDevice(ctx context.Context, device model.KnownDevice) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveKnownDevice", ctx, device)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveKnownDevice indicates an expected call of SaveKnownDevice.
+func (mr *MockStorageMockRecorder) SaveKnownDevice(ctx, device any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveKnownDevice", reflect.TypeOf((*MockStorage)(nil).SaveKnownDevice), ctx, device)
+}
+
 // SaveOAuth2BlacklistedJTI mocks base method.
 func (m *MockStorage) SaveOAuth2BlacklistedJTI(ctx context.Context, blacklistedJTI model.OAuth2BlacklistedJTI) error {
 	m.ctrl.T.Helper()
@@ -701,6 +1085,20 @@ func (mr *MockStorageMockRecorder) SaveOAuth2BlacklistedJTI(ctx, blacklistedJTI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOAuth2BlacklistedJTI", reflect.TypeOf((*MockStorage)(nil).SaveOAuth2BlacklistedJTI), ctx, blacklistedJTI)
 }
 
+// SaveOAuth2ClientSession mocks base method.
+func (m *MockStorage) SaveOAuth2ClientSession(ctx context.Context, session model.OAuth2ClientSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveOAuth2ClientSession", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveOAuth2ClientSession indicates an expected call of SaveOAuth2ClientSession.
+func (mr *MockStorageMockRecorder) SaveOAuth2ClientSession(ctx, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOAuth2ClientSession", reflect.TypeOf((*MockStorage)(nil).SaveOAuth2ClientSession), ctx, session)
+}
+
 // SaveOAuth2ConsentPreConfiguration mocks base method.
 func (m *MockStorage) SaveOAuth2ConsentPreConfiguration(ctx context.Context, config model.OAuth2ConsentPreConfig) (int64, error) {
 	m.ctrl.T.Helper()
@@ -772,6 +1170,20 @@ func (mr *MockStorageMockRecorder) SaveOAuth2ConsentSessionSubject(ctx, consent
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOAuth2ConsentSessionSubject", reflect.TypeOf((*MockStorage)(nil).SaveOAuth2ConsentSessionSubject), ctx, consent)
 }
 
+// SaveOAuth2DeviceCodeSession mocks base method.
+func (m *MockStorage) SaveOAuth2DeviceCodeSession(ctx context.Context, session model.OAuth2DeviceCodeSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveOAuth2DeviceCodeSession", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveOAuth2DeviceCodeSession indicates an expected call of SaveOAuth2DeviceCodeSession.
+func (mr *MockStorageMockRecorder) SaveOAuth2DeviceCodeSession(ctx, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOAuth2DeviceCodeSession", reflect.TypeOf((*MockStorage)(nil).SaveOAuth2DeviceCodeSession), ctx, session)
+}
+
 // SaveOAuth2PARContext mocks base method.
 func (m *MockStorage) SaveOAuth2PARContext(ctx context.Context, par model.OAuth2PARContext) error {
 	m.ctrl.T.Helper()
@@ -786,6 +1198,20 @@ func (mr *MockStorageMockRecorder) SaveOAuth2PARContext(ctx, par any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOAuth2PARContext", reflect.TypeOf((*MockStorage)(nil).SaveOAuth2PARContext), ctx, par)
 }
 
+// SaveOAuth2RegisteredClient mocks base method.
+func (m *MockStorage) SaveOAuth2RegisteredClient(ctx context.Context, client model.OAuth2RegisteredClient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveOAuth2RegisteredClient", ctx, client)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveOAuth2RegisteredClient indicates an expected call of SaveOAuth2RegisteredClient.
+func (mr *MockStorageMockRecorder) SaveOAuth2RegisteredClient(ctx, client any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOAuth2RegisteredClient", reflect.TypeOf((*MockStorage)(nil).SaveOAuth2RegisteredClient), ctx, client)
+}
+
 // SaveOAuth2Session mocks base method.
 func (m *MockStorage) SaveOAuth2Session(ctx context.Context, sessionType storage.OAuth2SessionType, session model.OAuth2Session) error {
 	m.ctrl.T.Helper()
@@ -843,6 +1269,20 @@ func (mr *MockStorageMockRecorder) SavePreferredDuoDevice(ctx, device any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePreferredDuoDevice", reflect.TypeOf((*MockStorage)(nil).SavePreferredDuoDevice), ctx, device)
 }
 
+// SaveRegulationBan mocks base method.
+func (m *MockStorage) SaveRegulationBan(ctx context.Context, ban model.RegulationBan) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRegulationBan", ctx, ban)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRegulationBan indicates an expected call of SaveRegulationBan.
+func (mr *MockStorageMockRecorder) SaveRegulationBan(ctx, ban any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRegulationBan", reflect.TypeOf((*MockStorage)(nil).SaveRegulationBan), ctx, ban)
+}
+
 // SaveTOTPConfiguration mocks base method.
 func (m *MockStorage) SaveTOTPConfiguration(ctx context.Context, config model.TOTPConfiguration) error {
 	m.ctrl.T.Helper()
@@ -871,6 +1311,20 @@ func (mr *MockStorageMockRecorder) SaveTOTPHistory(ctx, username, step any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTOTPHistory", reflect.TypeOf((*MockStorage)(nil).SaveTOTPHistory), ctx, username, step)
 }
 
+// SaveUser mocks base method.
+func (m *MockStorage) SaveUser(ctx context.Context, user model.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveUser", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveUser indicates an expected call of SaveUser.
+func (mr *MockStorageMockRecorder) SaveUser(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUser", reflect.TypeOf((*MockStorage)(nil).SaveUser), ctx, user)
+}
+
 // SaveUserOpaqueIdentifier mocks base method.
 func (m *MockStorage) SaveUserOpaqueIdentifier(ctx context.Context, subject model.UserOpaqueIdentifier) error {
 	m.ctrl.T.Helper()
@@ -885,6 +1339,20 @@ func (mr *MockStorageMockRecorder) SaveUserOpaqueIdentifier(ctx, subject any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUserOpaqueIdentifier", reflect.TypeOf((*MockStorage)(nil).SaveUserOpaqueIdentifier), ctx, subject)
 }
 
+// SaveUserSession mocks base method.
+func (m *MockStorage) SaveUserSession(ctx context.Context, session model.UserSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveUserSession", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveUserSession indicates an expected call of SaveUserSession.
+func (mr *MockStorageMockRecorder) SaveUserSession(ctx, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUserSession", reflect.TypeOf((*MockStorage)(nil).SaveUserSession), ctx, session)
+}
+
 // SaveWebAuthnCredential mocks base method.
 func (m *MockStorage) SaveWebAuthnCredential(ctx context.Context, credential model.WebAuthnCredential) error {
 	m.ctrl.T.Helper()
@@ -1060,6 +1528,48 @@ func (mr *MockStorageMockRecorder) StartupCheck() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartupCheck", reflect.TypeOf((*MockStorage)(nil).StartupCheck))
 }
 
+// UpdateKnownDeviceActivity mocks base method.
+func (m *MockStorage) UpdateKnownDeviceActivity(ctx context.Context, device model.KnownDevice) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateKnownDeviceActivity", ctx, device)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateKnownDeviceActivity indicates an expected call of UpdateKnownDeviceActivity.
+func (mr *MockStorageMockRecorder) UpdateKnownDeviceActivity(ctx, device any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateKnownDeviceActivity", reflect.TypeOf((*MockStorage)(nil).UpdateKnownDeviceActivity), ctx, device)
+}
+
+// UpdateOAuth2DeviceCodeSession mocks base method.
+func (m *MockStorage) UpdateOAuth2DeviceCodeSession(ctx context.Context, session model.OAuth2DeviceCodeSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOAuth2DeviceCodeSession", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOAuth2DeviceCodeSession indicates an expected call of UpdateOAuth2DeviceCodeSession.
+func (mr *MockStorageMockRecorder) UpdateOAuth2DeviceCodeSession(ctx, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOAuth2DeviceCodeSession", reflect.TypeOf((*MockStorage)(nil).UpdateOAuth2DeviceCodeSession), ctx, session)
+}
+
+// UpdateOAuth2DeviceCodeSessionCheckedAt mocks base method.
+func (m *MockStorage) UpdateOAuth2DeviceCodeSessionCheckedAt(ctx context.Context, signature string, checkedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOAuth2DeviceCodeSessionCheckedAt", ctx, signature, checkedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOAuth2DeviceCodeSessionCheckedAt indicates an expected call of UpdateOAuth2DeviceCodeSessionCheckedAt.
+func (mr *MockStorageMockRecorder) UpdateOAuth2DeviceCodeSessionCheckedAt(ctx, signature, checkedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOAuth2DeviceCodeSessionCheckedAt", reflect.TypeOf((*MockStorage)(nil).UpdateOAuth2DeviceCodeSessionCheckedAt), ctx, signature, checkedAt)
+}
+
 // UpdateOAuth2PARContext mocks base method.
 func (m *MockStorage) UpdateOAuth2PARContext(ctx context.Context, par model.OAuth2PARContext) error {
 	m.ctrl.T.Helper()
@@ -1074,6 +1584,20 @@ func (mr *MockStorageMockRecorder) UpdateOAuth2PARContext(ctx, par any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOAuth2PARContext", reflect.TypeOf((*MockStorage)(nil).UpdateOAuth2PARContext), ctx, par)
 }
 
+// UpdateOAuth2RegisteredClient mocks base method.
+func (m *MockStorage) UpdateOAuth2RegisteredClient(ctx context.Context, client model.OAuth2RegisteredClient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOAuth2RegisteredClient", ctx, client)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOAuth2RegisteredClient indicates an expected call of UpdateOAuth2RegisteredClient.
+func (mr *MockStorageMockRecorder) UpdateOAuth2RegisteredClient(ctx, client any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOAuth2RegisteredClient", reflect.TypeOf((*MockStorage)(nil).UpdateOAuth2RegisteredClient), ctx, client)
+}
+
 // UpdateTOTPConfigurationSignIn mocks base method.
 func (m *MockStorage) UpdateTOTPConfigurationSignIn(ctx context.Context, id int, lastUsedAt sql.NullTime) error {
 	m.ctrl.T.Helper()
@@ -1088,6 +1612,48 @@ func (mr *MockStorageMockRecorder) UpdateTOTPConfigurationSignIn(ctx, id, lastUs
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTOTPConfigurationSignIn", reflect.TypeOf((*MockStorage)(nil).UpdateTOTPConfigurationSignIn), ctx, id, lastUsedAt)
 }
 
+// UpdateUser mocks base method.
+func (m *MockStorage) UpdateUser(ctx context.Context, user model.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockStorageMockRecorder) UpdateUser(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockStorage)(nil).UpdateUser), ctx, user)
+}
+
+// UpdateUserPassword mocks base method.
+func (m *MockStorage) UpdateUserPassword(ctx context.Context, username, password string, changedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPassword", ctx, username, password, changedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserPassword indicates an expected call of UpdateUserPassword.
+func (mr *MockStorageMockRecorder) UpdateUserPassword(ctx, username, password, changedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPassword", reflect.TypeOf((*MockStorage)(nil).UpdateUserPassword), ctx, username, password, changedAt)
+}
+
+// UpdateUserSessionActivity mocks base method.
+func (m *MockStorage) UpdateUserSessionActivity(ctx context.Context, session model.UserSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserSessionActivity", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserSessionActivity indicates an expected call of UpdateUserSessionActivity.
+func (mr *MockStorageMockRecorder) UpdateUserSessionActivity(ctx, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserSessionActivity", reflect.TypeOf((*MockStorage)(nil).UpdateUserSessionActivity), ctx, session)
+}
+
 // UpdateWebAuthnCredentialDescription mocks base method.
 func (m *MockStorage) UpdateWebAuthnCredentialDescription(ctx context.Context, username string, credentialID int, description string) error {
 	m.ctrl.T.Helper()
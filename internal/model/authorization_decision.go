@@ -0,0 +1,24 @@
+package model
+
+import (
+	"time"
+)
+
+// AuthorizationDecision represents an authorization decision row in the database.
+type AuthorizationDecision struct {
+	ID             int                      `db:"id" json:"-"`
+	Time           time.Time                `db:"time" json:"time"`
+	Decision       string                   `db:"decision" json:"decision"`
+	StatusCode     int                      `db:"status_code" json:"status_code"`
+	Username       string                   `db:"username" json:"username,omitempty"`
+	Groups         StringSlicePipeDelimited `db:"user_groups" json:"groups,omitempty"`
+	ClientID       string                   `db:"client_id" json:"client_id,omitempty"`
+	RemoteIP       NullIP                   `db:"remote_ip" json:"remote_ip"`
+	RequestMethod  string                   `db:"request_method" json:"request_method"`
+	RequestURI     string                   `db:"request_uri" json:"request_uri"`
+	Domain         string                   `db:"domain" json:"domain"`
+	RulePosition   int                      `db:"rule_position" json:"rule_position"`
+	RequiredLevel  string                   `db:"required_level" json:"required_level"`
+	CurrentLevel   string                   `db:"current_level" json:"current_level"`
+	Implementation string                   `db:"implementation" json:"implementation"`
+}
@@ -22,6 +22,18 @@ const (
 	SecondFactorMethodDuo = "mobile_push"
 )
 
+const (
+	// AuthorizationDecisionAllow is the decision when the request is authorized.
+	AuthorizationDecisionAllow = "allow"
+
+	// AuthorizationDecisionDeny is the decision when the request is forbidden.
+	AuthorizationDecisionDeny = "deny"
+
+	// AuthorizationDecisionRedirect is the decision when the request must be authenticated, which is either a redirect
+	// to the portal or an authentication challenge depending on the authentication strategy.
+	AuthorizationDecisionRedirect = "redirect"
+)
+
 var (
 	reSemanticVersion = regexp.MustCompile(`^v?(?P<Major>0|[1-9]\d*)\.(?P<Minor>0|[1-9]\d*)\.(?P<Patch>0|[1-9]\d*)(?:-(?P<PreRelease>(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+(?P<Metadata>[0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
 	reToken64         = regexp.MustCompile(`^[a-zA-Z0-9_.~+/=-]+$`)
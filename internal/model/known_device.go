@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+)
+
+// KnownDevice represents a device a user has previously signed in from. A device is identified by the device id which
+// is stored in the device cookie and the subnet of the remote ip.
+type KnownDevice struct {
+	ID         int       `db:"id"`
+	CreatedAt  time.Time `db:"created_at"`
+	LastUsedAt time.Time `db:"last_used_at"`
+	Username   string    `db:"username"`
+	DeviceID   string    `db:"device_id"`
+	Subnet     string    `db:"subnet"`
+	UserAgent  string    `db:"user_agent"`
+	Device     string    `db:"device"`
+}
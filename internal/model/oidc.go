@@ -103,6 +103,22 @@ func NewOAuth2SessionFromRequest(signature string, r oauthelia2.Requester) (sess
 	}, nil
 }
 
+// NewOAuth2DeviceCodeSessionFromRequest creates a new OAuth2DeviceCodeSession from a device code signature, user code
+// signature, and oauthelia2.Requester.
+func NewOAuth2DeviceCodeSessionFromRequest(signature, userCodeSignature string, r oauthelia2.Requester) (session *OAuth2DeviceCodeSession, err error) {
+	var s *OAuth2Session
+
+	if s, err = NewOAuth2SessionFromRequest(signature, r); err != nil {
+		return nil, err
+	}
+
+	return &OAuth2DeviceCodeSession{
+		OAuth2Session:     *s,
+		UserCodeSignature: userCodeSignature,
+		Status:            OAuth2DeviceCodeStatusPending,
+	}, nil
+}
+
 // NewOAuth2PARContext creates a new Pushed Authorization Request Context as a OAuth2PARContext.
 func NewOAuth2PARContext(contextID string, r oauthelia2.AuthorizeRequester) (context *OAuth2PARContext, err error) {
 	var (
@@ -260,6 +276,36 @@ type OAuth2BlacklistedJTI struct {
 	ExpiresAt time.Time `db:"expires_at"`
 }
 
+// OAuth2RegisteredClient represents an OAuth 2.0 client registered via the Dynamic Client Registration endpoint.
+type OAuth2RegisteredClient struct {
+	ID                               int          `db:"id"`
+	ClientID                         string       `db:"client_id"`
+	CreatedAt                        time.Time    `db:"created_at"`
+	UpdatedAt                        time.Time    `db:"updated_at"`
+	ExpiresAt                        sql.NullTime `db:"expires_at"`
+	RegistrationAccessTokenSignature string       `db:"registration_access_token_signature"`
+	ClientSecret                     string       `db:"client_secret"`
+	Metadata                         []byte       `db:"metadata"`
+}
+
+// Expired returns true if the registered client has an expiration and it is not after the provided time.
+func (c *OAuth2RegisteredClient) Expired(now time.Time) bool {
+	return c.ExpiresAt.Valid && !c.ExpiresAt.Time.After(now)
+}
+
+// OAuth2ClientSession represents an OAuth 2.0 client which holds a session for a user session, used to notify the
+// client when the user session is logged out.
+type OAuth2ClientSession struct {
+	ID          int          `db:"id"`
+	SessionID   uuid.UUID    `db:"session_id"`
+	ClientID    string       `db:"client_id"`
+	Subject     uuid.UUID    `db:"subject"`
+	Username    string       `db:"username"`
+	Issuer      string       `db:"issuer"`
+	CreatedAt   time.Time    `db:"created_at"`
+	LoggedOutAt sql.NullTime `db:"logged_out_at"`
+}
+
 // OAuth2Session represents a OAuth2.0 session.
 type OAuth2Session struct {
 	ID                int                      `db:"id"`
@@ -317,6 +363,40 @@ func (s *OAuth2Session) ToRequest(ctx context.Context, session oauthelia2.Sessio
 	}, nil
 }
 
+// OAuth2DeviceCodeStatus represents the user response status of an OAuth 2.0 Device Authorization Grant session.
+type OAuth2DeviceCodeStatus int
+
+const (
+	// OAuth2DeviceCodeStatusPending represents a session the user has not yet responded to.
+	OAuth2DeviceCodeStatusPending OAuth2DeviceCodeStatus = iota
+
+	// OAuth2DeviceCodeStatusApproved represents a session the user has approved.
+	OAuth2DeviceCodeStatusApproved
+
+	// OAuth2DeviceCodeStatusDenied represents a session the user has denied.
+	OAuth2DeviceCodeStatusDenied
+)
+
+// OAuth2DeviceCodeSession represents an OAuth 2.0 Device Authorization Grant session.
+type OAuth2DeviceCodeSession struct {
+	OAuth2Session
+
+	UserCodeSignature string                 `db:"user_code_signature"`
+	Status            OAuth2DeviceCodeStatus `db:"status"`
+	CheckedAt         sql.NullTime           `db:"checked_at"`
+}
+
+// Expired returns true if the session was requested before the provided lifespan relative to the provided time.
+func (s *OAuth2DeviceCodeSession) Expired(now time.Time, lifespan time.Duration) bool {
+	return !s.RequestedAt.Add(lifespan).After(now)
+}
+
+// PolledWithin returns true if the token endpoint was polled for this session less than the provided interval before
+// the provided time.
+func (s *OAuth2DeviceCodeSession) PolledWithin(now time.Time, interval time.Duration) bool {
+	return s.CheckedAt.Valid && s.CheckedAt.Time.Add(interval).After(now)
+}
+
 // OAuth2PARContext holds relevant information about a Pushed Authorization Request in order to process the authorization.
 type OAuth2PARContext struct {
 	ID                   int                      `db:"id"`
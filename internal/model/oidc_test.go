@@ -161,6 +161,58 @@ func TestOAuth2Session_SetSubject(t *testing.T) {
 	}
 }
 
+func TestNewOAuth2DeviceCodeSessionFromRequest(t *testing.T) {
+	session := &oidc.Session{
+		DefaultSession: &openid.DefaultSession{},
+	}
+
+	actual, err := model.NewOAuth2DeviceCodeSessionFromRequest("abc", "xyz", &oauthelia2.Request{
+		ID: "example",
+		Client: &oauthelia2.DefaultClient{
+			ID: "client_id",
+		},
+		Session:        session,
+		RequestedScope: oauthelia2.Arguments{oidc.ScopeOpenID},
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, actual)
+
+	assert.Equal(t, "abc", actual.Signature)
+	assert.Equal(t, "xyz", actual.UserCodeSignature)
+	assert.Equal(t, "client_id", actual.ClientID)
+	assert.Equal(t, model.OAuth2DeviceCodeStatusPending, actual.Status)
+	assert.False(t, actual.Subject.Valid)
+	assert.False(t, actual.CheckedAt.Valid)
+
+	actual, err = model.NewOAuth2DeviceCodeSessionFromRequest("abc", "xyz", nil)
+
+	assert.Nil(t, actual)
+	assert.EqualError(t, err, "failed to create new *model.OAuth2Session: the oauthelia2.Requester was nil")
+}
+
+func TestOAuth2DeviceCodeSession(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	session := &model.OAuth2DeviceCodeSession{
+		OAuth2Session: model.OAuth2Session{
+			RequestedAt: now.Add(time.Minute * -5),
+		},
+	}
+
+	assert.False(t, session.Expired(now, time.Minute*10))
+	assert.True(t, session.Expired(now, time.Minute*5))
+	assert.True(t, session.Expired(now, time.Minute))
+
+	assert.False(t, session.PolledWithin(now, time.Second*5))
+
+	session.CheckedAt = sql.NullTime{Time: now.Add(time.Second * -3), Valid: true}
+
+	assert.True(t, session.PolledWithin(now, time.Second*5))
+	assert.False(t, session.PolledWithin(now, time.Second*3))
+	assert.False(t, session.PolledWithin(now, time.Second))
+}
+
 func TestOAuth2PARContext_ToAuthorizeRequest(t *testing.T) {
 	const (
 		parclientid = "par-client-id"
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+const (
+	// RegulationBanKindUser is the kind of a ban of a user.
+	RegulationBanKindUser = "user"
+
+	// RegulationBanKindIP is the kind of a ban of a remote ip.
+	RegulationBanKindIP = "ip"
+
+	// RegulationBanKindSubnet is the kind of a ban of a subnet.
+	RegulationBanKindSubnet = "subnet"
+)
+
+const (
+	// RegulationBanSourceAutomatic is the source of a ban created by the regulator.
+	RegulationBanSourceAutomatic = "automatic"
+
+	// RegulationBanSourceManual is the source of a ban created by an administrator.
+	RegulationBanSourceManual = "manual"
+)
+
+// RegulationBan represents a ban of a user, remote ip, or subnet. The value is the username, the remote ip, or the
+// subnet in CIDR notation depending on the kind. A ban without an expiration is permanent.
+type RegulationBan struct {
+	ID        int          `db:"id"`
+	CreatedAt time.Time    `db:"created_at"`
+	ExpiresAt sql.NullTime `db:"expires_at"`
+	RevokedAt sql.NullTime `db:"revoked_at"`
+	Kind      string       `db:"kind"`
+	Value     string       `db:"value"`
+	Source    string       `db:"source"`
+	Reason    string       `db:"reason"`
+}
+
+// IsActive returns true if the ban has not been revoked and has not expired at the given time.
+func (b RegulationBan) IsActive(now time.Time) bool {
+	return !b.RevokedAt.Valid && (!b.ExpiresAt.Valid || b.ExpiresAt.Time.After(now))
+}
+
+// IsPermanent returns true if the ban does not expire.
+func (b RegulationBan) IsPermanent() bool {
+	return !b.ExpiresAt.Valid
+}
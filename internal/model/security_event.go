@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SecurityEvent represents a security event such as a sign-in, ban, or second factor change which is published to the
+// security event feed.
+type SecurityEvent struct {
+	ID       uuid.UUID      `json:"id"`
+	Type     string         `json:"type"`
+	Time     time.Time      `json:"time"`
+	Username string         `json:"username,omitempty"`
+	RemoteIP string         `json:"remote_ip,omitempty"`
+	Details  map[string]any `json:"details,omitempty"`
+}
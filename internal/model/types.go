@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net"
 
@@ -87,6 +88,15 @@ func (ip NullIP) Value() (value driver.Value, err error) {
 	return ip.IP.String(), nil
 }
 
+// MarshalJSON is the NullIP implementation of the json.Marshaler.
+func (ip NullIP) MarshalJSON() (data []byte, err error) {
+	if ip.IP == nil {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(ip.IP.String())
+}
+
 // Scan is the NullIP implementation of the sql.Scanner.
 func (ip *NullIP) Scan(src any) (err error) {
 	if src == nil {
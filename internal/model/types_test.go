@@ -64,12 +64,20 @@ func TestDatabaseModelTypeNullIP(t *testing.T) {
 	assert.True(t, ip.IP.IsLoopback())
 	assert.Equal(t, "127.0.0.0", ip.IP.String())
 
+	data, err := ip.MarshalJSON()
+	assert.NoError(t, err)
+	assert.Equal(t, `"127.0.0.0"`, string(data))
+
 	err = ip.Scan(1)
 
 	assert.EqualError(t, err, "cannot scan model type '*model.NullIP' from type 'int' with value '1'")
 
 	err = ip.Scan(nil)
 	assert.NoError(t, err)
+
+	data, err = ip.MarshalJSON()
+	assert.NoError(t, err)
+	assert.Equal(t, "null", string(data))
 }
 
 func TestDatabaseModelTypeBase64(t *testing.T) {
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// User represents a user stored in the storage database which is used by the SQL authentication backend.
+type User struct {
+	ID                int                      `db:"id"`
+	CreatedAt         time.Time                `db:"created_at"`
+	UpdatedAt         time.Time                `db:"updated_at"`
+	PasswordChangedAt sql.NullTime             `db:"password_changed_at"`
+	Username          string                   `db:"username"`
+	DisplayName       string                   `db:"display_name"`
+	Email             string                   `db:"email"`
+	Groups            StringSlicePipeDelimited `db:"group_names"`
+	Password          string                   `db:"password"`
+	Disabled          bool                     `db:"disabled"`
+}
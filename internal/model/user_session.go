@@ -0,0 +1,54 @@
+package model
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// NewUserSession returns a new UserSession for the user session index.
+func NewUserSession(ctx Context, username, cookieDomain, userAgent string, expiration time.Duration) (session *UserSession, err error) {
+	var publicID uuid.UUID
+
+	if publicID, err = uuid.NewRandomFromReader(ctx.GetRandom()); err != nil {
+		return nil, fmt.Errorf("failed to generate public id: %w", err)
+	}
+
+	now := ctx.GetClock().Now()
+
+	return &UserSession{
+		PublicID:     publicID,
+		CreatedAt:    now,
+		LastActiveAt: now,
+		ExpiresAt:    now.Add(expiration),
+		Username:     username,
+		CookieDomain: cookieDomain,
+		IP:           NewIP(ctx.RemoteIP()),
+		UserAgent:    userAgent,
+		Device:       utils.UserAgentDevice(userAgent),
+	}, nil
+}
+
+// UserSession represents an entry in the user session index which is used to list and revoke the sessions of a user.
+type UserSession struct {
+	ID           int          `db:"id"`
+	PublicID     uuid.UUID    `db:"public_id"`
+	CreatedAt    time.Time    `db:"created_at"`
+	LastActiveAt time.Time    `db:"last_active_at"`
+	ExpiresAt    time.Time    `db:"expires_at"`
+	RevokedAt    sql.NullTime `db:"revoked_at"`
+	Username     string       `db:"username"`
+	CookieDomain string       `db:"cookie_domain"`
+	IP           IP           `db:"ip"`
+	UserAgent    string       `db:"user_agent"`
+	Device       string       `db:"device"`
+}
+
+// Revoked returns true if the session has been revoked.
+func (s *UserSession) Revoked() bool {
+	return s.RevokedAt.Valid
+}
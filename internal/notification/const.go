@@ -5,6 +5,30 @@ const (
 	fileNotifierHeader = "Date: %s\nRecipient: %s\nSubject: %s\n"
 )
 
+const (
+	webhookHeaderContentType = "Content-Type"
+	webhookHeaderID          = "X-Authelia-Notification-ID"
+	webhookHeaderTimestamp   = "X-Authelia-Timestamp"
+	webhookHeaderSignature   = "X-Authelia-Signature"
+	webhookSignaturePrefix   = "sha256="
+	webhookMaxResponseSize   = 1024 * 64
+
+	// webhookBudgetPercent is the percentage of the server write timeout available to send a notification including the
+	// retries.
+	webhookBudgetPercent = 75
+)
+
+const (
+	webhookTypeIdentityVerification = "identity_verification"
+	webhookTypeOneTimeCode          = "one_time_code"
+	webhookTypeEvent                = "event"
+	webhookTypeNotification         = "notification"
+)
+
+const (
+	schemeHTTPS = "https"
+)
+
 const (
 	posixNewLine = "\n"
 )
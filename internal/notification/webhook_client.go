@@ -0,0 +1,216 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// WebhookClientConfig is the configuration of a WebhookClient.
+type WebhookClientConfig struct {
+	URL         *url.URL
+	Timeout     time.Duration
+	Secret      string
+	ContentType string
+
+	// HeaderID is the name of the header which contains the unique identifier of each request.
+	HeaderID string
+
+	Retries       int
+	RetryInterval time.Duration
+
+	// Budget is the amount of time available to send a request including all of its retries. The time is not limited
+	// when it's 0.
+	Budget time.Duration
+
+	TLS *schema.TLS
+}
+
+// NewWebhookClient creates a new *WebhookClient which is used by the webhook notifier and the security events webhook
+// to send signed requests to a HTTP endpoint.
+func NewWebhookClient(config WebhookClientConfig, log *logrus.Entry, certPool *x509.CertPool) *WebhookClient {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if config.TLS != nil {
+		transport.TLSClientConfig = utils.NewTLSConfig(config.TLS, certPool)
+	}
+
+	return &WebhookClient{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout, Transport: transport},
+		tls:    transport.TLSClientConfig,
+		log:    log,
+		sleep:  sleepContext,
+	}
+}
+
+// WebhookClient sends signed requests to a HTTP endpoint and retries the requests which fail with a network error,
+// the 429 status code, or a 5xx status code.
+type WebhookClient struct {
+	config WebhookClientConfig
+	client *http.Client
+	tls    *tls.Config
+	log    *logrus.Entry
+	sleep  func(ctx context.Context, duration time.Duration) error
+}
+
+// StartupCheck ensures a connection to the endpoint can be established without sending a request.
+func (c *WebhookClient) StartupCheck() (err error) {
+	var conn net.Conn
+
+	address := c.config.URL.Host
+
+	if c.config.URL.Port() == "" {
+		if c.config.URL.Scheme == schemeHTTPS {
+			address = net.JoinHostPort(c.config.URL.Hostname(), "443")
+		} else {
+			address = net.JoinHostPort(c.config.URL.Hostname(), "80")
+		}
+	}
+
+	c.log.WithFields(map[string]any{"address": address}).Trace("Dialing Startup Check Connection")
+
+	dialer := &net.Dialer{Timeout: c.config.Timeout}
+
+	if c.config.URL.Scheme == schemeHTTPS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", address, c.tls)
+	} else {
+		conn, err = dialer.Dial("tcp", address)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to dial connection: %w", err)
+	}
+
+	c.log.Trace("Closing Startup Check Connection")
+
+	if err = conn.Close(); err != nil {
+		return fmt.Errorf("failed to close connection: %w", err)
+	}
+
+	return nil
+}
+
+// Send the body to the endpoint retrying the request until it's successful, the retries are exhausted, or the budget
+// is exceeded.
+func (c *WebhookClient) Send(ctx context.Context, id string, timestamp time.Time, body []byte) (err error) {
+	attempts := 1
+
+	if c.config.Retries > 0 {
+		attempts += c.config.Retries
+	}
+
+	interval := c.config.RetryInterval
+
+	if c.config.Budget > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, c.config.Budget)
+
+		defer cancel()
+	}
+
+	for attempt := 1; ; attempt++ {
+		var retry bool
+
+		if retry, err = c.request(ctx, id, timestamp, body); err == nil {
+			return nil
+		}
+
+		if !retry || attempt >= attempts {
+			return fmt.Errorf("failed to send request after %d attempt(s): %w", attempt, err)
+		}
+
+		// A retry which can't complete before the deadline of the context only delays the response.
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= interval {
+			return fmt.Errorf("failed to send request after %d attempt(s) as the time available for retries was exceeded: %w", attempt, err)
+		}
+
+		c.log.WithError(err).WithFields(map[string]any{"attempt": attempt, "id": id}).Debugf("Retrying request in %s", interval)
+
+		if err = c.sleep(ctx, interval); err != nil {
+			return fmt.Errorf("failed to send request after %d attempt(s): %w", attempt, err)
+		}
+
+		interval *= 2
+	}
+}
+
+// request performs a single request returning an error if it was not successful, and if the request should be retried.
+func (c *WebhookClient) request(ctx context.Context, id string, timestamp time.Time, body []byte) (retry bool, err error) {
+	var req *http.Request
+
+	if req, err = http.NewRequestWithContext(ctx, http.MethodPost, c.config.URL.String(), bytes.NewReader(body)); err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+
+	req.Header.Set(webhookHeaderContentType, c.config.ContentType)
+	req.Header.Set(c.config.HeaderID, id)
+	req.Header.Set(webhookHeaderTimestamp, ts)
+
+	if c.config.Secret != "" {
+		req.Header.Set(webhookHeaderSignature, webhookSignaturePrefix+WebhookSignature([]byte(c.config.Secret), ts, body))
+	}
+
+	var resp *http.Response
+
+	if resp, err = c.client.Do(req); err != nil {
+		return true, fmt.Errorf("failed to perform request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, webhookMaxResponseSize))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode >= 500:
+		return true, fmt.Errorf("the endpoint responded with status code %d", resp.StatusCode)
+	default:
+		return false, fmt.Errorf("the endpoint responded with status code %d", resp.StatusCode)
+	}
+}
+
+// WebhookSignature returns the hex encoded HMAC-SHA256 signature of the timestamp and body joined by a period which is
+// sent in the X-Authelia-Signature header.
+func WebhookSignature(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func sleepContext(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
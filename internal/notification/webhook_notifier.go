@@ -0,0 +1,148 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/templates"
+)
+
+// NewWebhookNotifier creates a WebhookNotifier using the notifier configuration. The writeTimeout is the server write
+// timeout, the notification and all of its retries must be completed within a portion of it as the notification is sent
+// while the user waits for the response.
+func NewWebhookNotifier(config *schema.NotifierWebhook, writeTimeout time.Duration, certPool *x509.CertPool) *WebhookNotifier {
+	var payload *template.Template
+
+	if config.Payload != "" {
+		payload, _ = templates.ParseStringTemplate("payload", config.Payload)
+	}
+
+	return &WebhookNotifier{
+		client: NewWebhookClient(WebhookClientConfig{
+			URL:           config.URL,
+			Timeout:       config.Timeout,
+			Secret:        config.Secret,
+			ContentType:   config.ContentType,
+			HeaderID:      webhookHeaderID,
+			Retries:       config.Retries,
+			RetryInterval: config.RetryInterval,
+			Budget:        writeTimeout * webhookBudgetPercent / 100,
+			TLS:           config.TLS,
+		}, logging.Logger().WithFields(map[string]any{"provider": "notifier"}), certPool),
+		payload: payload,
+		clock:   clock.New(),
+	}
+}
+
+// WebhookNotifier a notifier to send notifications to HTTP endpoints such as chat integrations.
+type WebhookNotifier struct {
+	client  *WebhookClient
+	payload *template.Template
+	clock   clock.Provider
+}
+
+// WebhookPayload is the payload sent to the webhook endpoint, and the data available to the payload template.
+type WebhookPayload struct {
+	ID        string                  `json:"id"`
+	Type      string                  `json:"type"`
+	Timestamp time.Time               `json:"timestamp"`
+	Recipient WebhookPayloadRecipient `json:"recipient"`
+	Subject   string                  `json:"subject"`
+	Body      string                  `json:"body"`
+	Data      any                     `json:"data"`
+}
+
+// WebhookPayloadRecipient is the recipient of the notification.
+type WebhookPayloadRecipient struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+// StartupCheck implements model.StartupCheck to perform startup check operations. It only ensures a connection to the
+// endpoint can be established and doesn't send a notification.
+func (n *WebhookNotifier) StartupCheck() (err error) {
+	if err = n.client.StartupCheck(); err != nil {
+		return fmt.Errorf("notifier: webhook: %w", err)
+	}
+
+	return nil
+}
+
+// Send a notification via the WebhookNotifier.
+func (n *WebhookNotifier) Send(ctx context.Context, recipient mail.Address, subject string, et *templates.EmailTemplate, data any) (err error) {
+	buf := &bytes.Buffer{}
+
+	if err = et.Text.Execute(buf, data); err != nil {
+		return fmt.Errorf("notifier: webhook: failed to execute template: %w", err)
+	}
+
+	return n.send(ctx, n.newPayload(webhookType(data), recipient, subject, buf.String(), data))
+}
+
+func (n *WebhookNotifier) newPayload(kind string, recipient mail.Address, subject, body string, data any) WebhookPayload {
+	return WebhookPayload{
+		ID:        uuid.New().String(),
+		Type:      kind,
+		Timestamp: n.clock.Now().UTC(),
+		Recipient: WebhookPayloadRecipient{Name: recipient.Name, Address: recipient.Address},
+		Subject:   subject,
+		Body:      body,
+		Data:      data,
+	}
+}
+
+func (n *WebhookNotifier) send(ctx context.Context, payload WebhookPayload) (err error) {
+	var body []byte
+
+	if body, err = n.render(payload); err != nil {
+		return err
+	}
+
+	if err = n.client.Send(ctx, payload.ID, payload.Timestamp, body); err != nil {
+		return fmt.Errorf("notifier: webhook: %w", err)
+	}
+
+	return nil
+}
+
+func (n *WebhookNotifier) render(payload WebhookPayload) (body []byte, err error) {
+	if n.payload == nil {
+		if body, err = json.Marshal(payload); err != nil {
+			return nil, fmt.Errorf("notifier: webhook: failed to marshal payload: %w", err)
+		}
+
+		return body, nil
+	}
+
+	buf := &bytes.Buffer{}
+
+	if err = n.payload.Execute(buf, payload); err != nil {
+		return nil, fmt.Errorf("notifier: webhook: failed to execute payload template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func webhookType(data any) string {
+	switch data.(type) {
+	case templates.EmailIdentityVerificationJWTValues, *templates.EmailIdentityVerificationJWTValues:
+		return webhookTypeIdentityVerification
+	case templates.EmailIdentityVerificationOTCValues, *templates.EmailIdentityVerificationOTCValues:
+		return webhookTypeOneTimeCode
+	case templates.EmailEventValues, *templates.EmailEventValues:
+		return webhookTypeEvent
+	default:
+		return webhookTypeNotification
+	}
+}
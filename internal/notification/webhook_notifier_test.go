@@ -0,0 +1,242 @@
+package notification
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"net/url"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/templates"
+)
+
+func TestWebhookNotifier_Send(t *testing.T) {
+	var (
+		headers http.Header
+		body    []byte
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header
+
+		var err error
+
+		body, err = io.ReadAll(r.Body)
+		assert.NoError(t, err)
+	}))
+
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(newWebhookNotifierTestConfig(t, server.URL, ""), 0, nil)
+	notifier.clock = clock.NewFixed(time.Unix(1701295903, 0))
+
+	data := templates.EmailIdentityVerificationJWTValues{
+		Title:       "Reset your password",
+		DisplayName: "John Smith",
+		LinkURL:     "https://auth.example.com/reset-password/step2?token=abc",
+	}
+
+	require.NoError(t, notifier.Send(context.Background(), mail.Address{Name: "John Smith", Address: "john@example.com"}, "Reset your password", newWebhookNotifierTestTemplate(), data))
+
+	assert.Equal(t, "application/json", headers.Get("Content-Type"))
+	assert.Equal(t, "sha256="+WebhookSignature([]byte("secret"), headers.Get("X-Authelia-Timestamp"), body), headers.Get("X-Authelia-Signature"))
+
+	payload := map[string]any{}
+
+	require.NoError(t, json.Unmarshal(body, &payload))
+
+	assert.Equal(t, headers.Get("X-Authelia-Notification-ID"), payload["id"])
+	assert.Equal(t, "identity_verification", payload["type"])
+	assert.Equal(t, "Reset your password", payload["subject"])
+	assert.Equal(t, "Hi John Smith, Reset your password", payload["body"])
+	assert.Equal(t, map[string]any{"name": "John Smith", "address": "john@example.com"}, payload["recipient"])
+	assert.Equal(t, "https://auth.example.com/reset-password/step2?token=abc", payload["data"].(map[string]any)["link_url"])
+	assert.Equal(t, "2023-11-29T22:11:43Z", payload["timestamp"])
+	assert.Equal(t, "1701295903", headers.Get("X-Authelia-Timestamp"))
+}
+
+func TestWebhookNotifier_SendPayloadTemplate(t *testing.T) {
+	var (
+		headers http.Header
+		body    []byte
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header
+
+		var err error
+
+		body, err = io.ReadAll(r.Body)
+		assert.NoError(t, err)
+	}))
+
+	defer server.Close()
+
+	config := newWebhookNotifierTestConfig(t, server.URL, `{"text": {{ toJson (printf "*%s*\n%s" .Subject .Body) }}}`)
+	config.Secret = ""
+
+	notifier := NewWebhookNotifier(config, 0, nil)
+
+	data := templates.EmailIdentityVerificationOTCValues{Title: "Confirm your identity", DisplayName: "John Smith", OneTimeCode: "ABC123"}
+
+	require.NoError(t, notifier.Send(context.Background(), mail.Address{Address: "john@example.com"}, "Confirm your identity", newWebhookNotifierTestTemplate(), data))
+
+	assert.Equal(t, "", headers.Get("X-Authelia-Signature"))
+	assert.JSONEq(t, `{"text": "*Confirm your identity*\nHi John Smith, Confirm your identity"}`, string(body))
+}
+
+func TestWebhookNotifier_SendRetries(t *testing.T) {
+	testCases := []struct {
+		name     string
+		statuses []int
+		retries  int
+		requests int
+		sleeps   []time.Duration
+		err      string
+	}{
+		{"ShouldRetryServerErrors", []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK}, 3, 3, []time.Duration{time.Second, time.Second * 2}, ""},
+		{"ShouldStopAfterRetries", []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway}, 1, 2, []time.Duration{time.Second}, "notifier: webhook: failed to send request after 2 attempt(s): the endpoint responded with status code 502"},
+		{"ShouldNotRetryClientErrors", []int{http.StatusBadRequest, http.StatusOK}, 3, 1, nil, "notifier: webhook: failed to send request after 1 attempt(s): the endpoint responded with status code 400"},
+		{"ShouldNotRetryWhenDisabled", []int{http.StatusInternalServerError, http.StatusOK}, -1, 1, nil, "notifier: webhook: failed to send request after 1 attempt(s): the endpoint responded with status code 500"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			requests := 0
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.statuses[requests])
+
+				requests++
+			}))
+
+			defer server.Close()
+
+			config := newWebhookNotifierTestConfig(t, server.URL, "")
+			config.Retries = tc.retries
+
+			notifier := NewWebhookNotifier(config, 0, nil)
+
+			var sleeps []time.Duration
+
+			notifier.client.sleep = func(ctx context.Context, duration time.Duration) error {
+				sleeps = append(sleeps, duration)
+
+				return nil
+			}
+
+			err := notifier.Send(context.Background(), mail.Address{Address: "john@example.com"}, "Event", newWebhookNotifierTestTemplate(), templates.EmailEventValues{Title: "Event"})
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+
+			assert.Equal(t, tc.requests, requests)
+			assert.Equal(t, tc.sleeps, sleeps)
+		})
+	}
+}
+
+func TestWebhookNotifier_SendRetryBudget(t *testing.T) {
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+
+		requests++
+	}))
+
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(newWebhookNotifierTestConfig(t, server.URL, ""), time.Second, nil)
+
+	assert.Equal(t, time.Millisecond*750, notifier.client.config.Budget)
+
+	var sleeps []time.Duration
+
+	notifier.client.sleep = func(ctx context.Context, duration time.Duration) error {
+		sleeps = append(sleeps, duration)
+
+		return nil
+	}
+
+	err := notifier.Send(context.Background(), mail.Address{Address: "john@example.com"}, "Event", newWebhookNotifierTestTemplate(), templates.EmailEventValues{Title: "Event"})
+
+	assert.EqualError(t, err, "notifier: webhook: failed to send request after 1 attempt(s) as the time available for retries was exceeded: the endpoint responded with status code 503")
+	assert.Equal(t, 1, requests)
+	assert.Nil(t, sleeps)
+}
+
+func TestWebhookNotifier_StartupCheck(t *testing.T) {
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+
+	notifier := NewWebhookNotifier(newWebhookNotifierTestConfig(t, server.URL, ""), 0, nil)
+
+	require.NoError(t, notifier.StartupCheck())
+
+	assert.Equal(t, 0, requests)
+
+	server.Close()
+
+	assert.ErrorContains(t, notifier.StartupCheck(), "notifier: webhook: failed to dial connection: ")
+}
+
+func TestWebhookNotifier_StartupCheckTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	defer server.Close()
+
+	config := newWebhookNotifierTestConfig(t, server.URL, "")
+
+	notifier := NewWebhookNotifier(config, 0, nil)
+
+	assert.ErrorContains(t, notifier.StartupCheck(), "notifier: webhook: failed to dial connection: tls: failed to verify certificate: ")
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	config.TLS = &schema.TLS{}
+
+	notifier = NewWebhookNotifier(config, 0, pool)
+
+	assert.NoError(t, notifier.StartupCheck())
+}
+
+func newWebhookNotifierTestConfig(t *testing.T, raw, payload string) *schema.NotifierWebhook {
+	u, err := url.Parse(raw)
+
+	require.NoError(t, err)
+
+	return &schema.NotifierWebhook{
+		URL:           u,
+		Timeout:       time.Second,
+		Secret:        "secret",
+		Payload:       payload,
+		ContentType:   "application/json",
+		Retries:       3,
+		RetryInterval: time.Second,
+	}
+}
+
+func newWebhookNotifierTestTemplate() *templates.EmailTemplate {
+	return &templates.EmailTemplate{
+		Text: template.Must(template.New("text").Parse("Hi {{ .DisplayName }}, {{ .Title }}")),
+	}
+}
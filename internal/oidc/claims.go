@@ -0,0 +1,100 @@
+package oidc
+
+import (
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// ApplyCustomScopeClaims adds the claims of each custom scope in the definitions which is present in the scopes to the
+// claims map. Claims sourced from an attribute the user does not have are omitted.
+func ApplyCustomScopeClaims(claims map[string]any, scopes []string, definitions map[string]schema.IdentityProvidersOpenIDConnectScope, detailer UserDetailer) {
+	if len(definitions) == 0 {
+		return
+	}
+
+	for _, scope := range scopes {
+		definition, ok := definitions[scope]
+		if !ok {
+			continue
+		}
+
+		for claim, source := range definition.Claims {
+			if source.Attribute == "" {
+				claims[claim] = source.Value
+
+				continue
+			}
+
+			if value, ok := GetUserAttribute(detailer, source.Attribute); ok {
+				claims[claim] = value
+			}
+		}
+	}
+}
+
+// IsCustomScopeClaim returns true if the claim is granted by one of the custom scopes in the definitions which is
+// present in the scopes.
+func IsCustomScopeClaim(claim string, scopes []string, definitions map[string]schema.IdentityProvidersOpenIDConnectScope) bool {
+	for _, scope := range scopes {
+		if definition, ok := definitions[scope]; ok {
+			if _, ok = definition.Claims[claim]; ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// HasCustomScope returns true if one of the scopes is a custom scope in the definitions.
+func HasCustomScope(scopes []string, definitions map[string]schema.IdentityProvidersOpenIDConnectScope) bool {
+	for _, scope := range scopes {
+		if _, ok := definitions[scope]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetUserAttribute returns the value of a named user attribute from the UserDetailer. The standard attribute names take
+// precedence over the extra attributes of the user.
+func GetUserAttribute(detailer UserDetailer, attribute string) (value any, ok bool) {
+	switch attribute {
+	case UserAttributeUsername:
+		return detailer.GetUsername(), true
+	case UserAttributeDisplayName:
+		return detailer.GetDisplayName(), true
+	case UserAttributeEmail:
+		if emails := detailer.GetEmails(); len(emails) != 0 {
+			return emails[0], true
+		}
+
+		return nil, false
+	case UserAttributeEmails:
+		return detailer.GetEmails(), true
+	case UserAttributeGroups:
+		return detailer.GetGroups(), true
+	case UserAttributeGivenName:
+		return getUserAttributeString(detailer.GetGivenName())
+	case UserAttributeFamilyName:
+		return getUserAttributeString(detailer.GetFamilyName())
+	case UserAttributePhoneNumber:
+		return getUserAttributeString(detailer.GetPhoneNumber())
+	case UserAttributeLocale:
+		return getUserAttributeString(detailer.GetLocale())
+	case UserAttributePicture:
+		return getUserAttributeString(detailer.GetPicture())
+	default:
+		value, ok = detailer.GetExtra()[attribute]
+
+		return value, ok
+	}
+}
+
+func getUserAttributeString(value string) (any, bool) {
+	if value == "" {
+		return nil, false
+	}
+
+	return value, true
+}
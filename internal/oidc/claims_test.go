@@ -0,0 +1,150 @@
+package oidc_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/oidc"
+)
+
+func TestApplyCustomScopeClaims(t *testing.T) {
+	definitions := map[string]schema.IdentityProvidersOpenIDConnectScope{
+		"hr": {
+			Claims: map[string]schema.IdentityProvidersOpenIDConnectScopeClaim{
+				"department":  {Attribute: "department"},
+				"employee_id": {Attribute: "employee_id"},
+				"org":         {Value: "Example"},
+			},
+		},
+		"upn": {
+			Claims: map[string]schema.IdentityProvidersOpenIDConnectScopeClaim{
+				"preferred_username": {Attribute: oidc.UserAttributeEmail},
+			},
+		},
+	}
+
+	details := &authentication.UserDetails{
+		Username:    "john",
+		DisplayName: "John Smith",
+		Emails:      []string{"john@example.com"},
+		Groups:      []string{"admin"},
+		Extra: map[string]any{
+			"department": "Engineering",
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		scopes      []string
+		definitions map[string]schema.IdentityProvidersOpenIDConnectScope
+		expected    map[string]any
+	}{
+		{
+			"ShouldNotApplyWithoutDefinitions",
+			[]string{oidc.ScopeOpenID, "hr"},
+			nil,
+			map[string]any{},
+		},
+		{
+			"ShouldNotApplyWithoutCustomScope",
+			[]string{oidc.ScopeOpenID, oidc.ScopeProfile},
+			definitions,
+			map[string]any{},
+		},
+		{
+			"ShouldApplyAttributesAndValuesOmittingAbsentAttributes",
+			[]string{oidc.ScopeOpenID, "hr"},
+			definitions,
+			map[string]any{"department": "Engineering", "org": "Example"},
+		},
+		{
+			"ShouldApplyStandardAttributes",
+			[]string{oidc.ScopeOpenID, "upn"},
+			definitions,
+			map[string]any{oidc.ClaimPreferredUsername: "john@example.com"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			claims := map[string]any{}
+
+			oidc.ApplyCustomScopeClaims(claims, tc.scopes, tc.definitions, details)
+
+			assert.Equal(t, tc.expected, claims)
+		})
+	}
+}
+
+func TestGetUserAttribute(t *testing.T) {
+	details := &authentication.UserDetails{
+		Username:    "john",
+		DisplayName: "John Smith",
+		Emails:      []string{"john@example.com", "john.smith@example.com"},
+		Groups:      []string{"admin", "dev"},
+		GivenName:   "John",
+		FamilyName:  "Smith",
+		PhoneNumber: "+1 555 0100",
+		Locale:      "en-US",
+		Picture:     "https://example.com/john.png",
+		Extra: map[string]any{
+			"department": "Engineering",
+			"username":   "ignored",
+		},
+	}
+
+	testCases := []struct {
+		name      string
+		details   *authentication.UserDetails
+		attribute string
+		expected  any
+		ok        bool
+	}{
+		{"ShouldReturnUsername", details, oidc.UserAttributeUsername, "john", true},
+		{"ShouldReturnDisplayName", details, oidc.UserAttributeDisplayName, "John Smith", true},
+		{"ShouldReturnEmail", details, oidc.UserAttributeEmail, "john@example.com", true},
+		{"ShouldNotReturnEmailWhenAbsent", &authentication.UserDetails{}, oidc.UserAttributeEmail, nil, false},
+		{"ShouldReturnEmails", details, oidc.UserAttributeEmails, []string{"john@example.com", "john.smith@example.com"}, true},
+		{"ShouldReturnGroups", details, oidc.UserAttributeGroups, []string{"admin", "dev"}, true},
+		{"ShouldReturnGivenName", details, oidc.UserAttributeGivenName, "John", true},
+		{"ShouldReturnFamilyName", details, oidc.UserAttributeFamilyName, "Smith", true},
+		{"ShouldReturnPhoneNumber", details, oidc.UserAttributePhoneNumber, "+1 555 0100", true},
+		{"ShouldReturnLocale", details, oidc.UserAttributeLocale, "en-US", true},
+		{"ShouldReturnPicture", details, oidc.UserAttributePicture, "https://example.com/john.png", true},
+		{"ShouldNotReturnGivenNameWhenAbsent", &authentication.UserDetails{}, oidc.UserAttributeGivenName, nil, false},
+		{"ShouldReturnExtra", details, "department", "Engineering", true},
+		{"ShouldNotReturnAbsentExtra", details, "employee_id", nil, false},
+		{"ShouldNotReturnExtraWhenNil", &authentication.UserDetails{}, "department", nil, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, ok := oidc.GetUserAttribute(tc.details, tc.attribute)
+
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestIsCustomScopeClaim(t *testing.T) {
+	definitions := map[string]schema.IdentityProvidersOpenIDConnectScope{
+		"hr": {
+			Claims: map[string]schema.IdentityProvidersOpenIDConnectScopeClaim{
+				"department": {Attribute: "department"},
+			},
+		},
+	}
+
+	assert.True(t, oidc.IsCustomScopeClaim("department", []string{oidc.ScopeOpenID, "hr"}, definitions))
+	assert.False(t, oidc.IsCustomScopeClaim("department", []string{oidc.ScopeOpenID}, definitions))
+	assert.False(t, oidc.IsCustomScopeClaim("org", []string{oidc.ScopeOpenID, "hr"}, definitions))
+	assert.False(t, oidc.IsCustomScopeClaim("department", []string{oidc.ScopeOpenID, "hr"}, nil))
+
+	assert.True(t, oidc.HasCustomScope([]string{oidc.ScopeOpenID, "hr"}, definitions))
+	assert.False(t, oidc.HasCustomScope([]string{oidc.ScopeOpenID}, definitions))
+	assert.False(t, oidc.HasCustomScope([]string{"hr"}, nil))
+}
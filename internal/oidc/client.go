@@ -2,6 +2,7 @@ package oidc
 
 import (
 	"context"
+	"net/url"
 	"time"
 
 	oauthelia2 "authelia.com/provider/oauth2"
@@ -42,6 +43,7 @@ func NewClient(config schema.IdentityProvidersOpenIDConnectClient, c *schema.Ide
 		AuthorizationPolicy:   NewClientAuthorizationPolicy(config.AuthorizationPolicy, c),
 		ConsentPolicy:         NewClientConsentPolicy(config.ConsentMode, config.ConsentPreConfiguredDuration),
 		RequestedAudienceMode: NewClientRequestedAudienceMode(config.RequestedAudienceMode),
+		TokenExchangePolicy:   NewClientTokenExchangePolicy(config.TokenExchange),
 
 		AuthorizationSignedResponseAlg:   config.AuthorizationSignedResponseAlg,
 		AuthorizationSignedResponseKeyID: config.AuthorizationSignedResponseKeyID,
@@ -59,6 +61,12 @@ func NewClient(config schema.IdentityProvidersOpenIDConnectClient, c *schema.Ide
 
 		JSONWebKeysURI: config.JSONWebKeysURI,
 		JSONWebKeys:    NewPublicJSONWebKeySetFromSchemaJWK(config.JSONWebKeys),
+
+		PostLogoutRedirectURIs:            config.PostLogoutRedirectURIs,
+		FrontChannelLogoutURI:             config.FrontChannelLogoutURI,
+		FrontChannelLogoutSessionRequired: config.FrontChannelLogoutSessionRequired,
+		BackChannelLogoutURI:              config.BackChannelLogoutURI,
+		BackChannelLogoutSessionRequired:  config.BackChannelLogoutSessionRequired,
 	}
 
 	if len(config.Lifespan) != 0 {
@@ -464,6 +472,39 @@ func (c *RegisteredClient) GetConsentPolicy() (policy ClientConsentPolicy) {
 	return c.ConsentPolicy
 }
 
+// GetTokenExchangePolicy returns the TokenExchangePolicy.
+func (c *RegisteredClient) GetTokenExchangePolicy() (policy ClientTokenExchangePolicy) {
+	return c.TokenExchangePolicy
+}
+
+// GetPostLogoutRedirectURIs returns the URIs the client is permitted to use as the post_logout_redirect_uri with
+// RP-Initiated Logout.
+func (c *RegisteredClient) GetPostLogoutRedirectURIs() (uris []string) {
+	return c.PostLogoutRedirectURIs
+}
+
+// GetFrontChannelLogoutURI returns the Front-Channel Logout URI for the client.
+func (c *RegisteredClient) GetFrontChannelLogoutURI() (uri *url.URL) {
+	return c.FrontChannelLogoutURI
+}
+
+// GetFrontChannelLogoutSessionRequired returns true if the client requires the iss and sid query parameters to be
+// included with the Front-Channel Logout URI.
+func (c *RegisteredClient) GetFrontChannelLogoutSessionRequired() (required bool) {
+	return c.FrontChannelLogoutSessionRequired
+}
+
+// GetBackChannelLogoutURI returns the Back-Channel Logout URI for the client.
+func (c *RegisteredClient) GetBackChannelLogoutURI() (uri *url.URL) {
+	return c.BackChannelLogoutURI
+}
+
+// GetBackChannelLogoutSessionRequired returns true if the client requires the sid claim to be included in the Logout
+// Token.
+func (c *RegisteredClient) GetBackChannelLogoutSessionRequired() (required bool) {
+	return c.BackChannelLogoutSessionRequired
+}
+
 // IsAuthenticationLevelSufficient returns if the provided authentication.Level is sufficient for the client of the AutheliaClient.
 func (c *RegisteredClient) IsAuthenticationLevelSufficient(level authentication.Level, subject authorization.Subject) (sufficient bool) {
 	if level == authentication.NotAuthenticated {
@@ -5,6 +5,7 @@ import (
 
 	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 // NewClientAuthorizationPolicy creates a new ClientAuthorizationPolicy.
@@ -59,6 +60,15 @@ func NewClientRequestedAudienceMode(mode string) ClientRequestedAudienceMode {
 	}
 }
 
+// NewClientTokenExchangePolicy converts the config options into an oidc.ClientTokenExchangePolicy.
+func NewClientTokenExchangePolicy(config schema.IdentityProvidersOpenIDConnectClientTokenExchange) ClientTokenExchangePolicy {
+	return ClientTokenExchangePolicy{
+		SubjectClients: config.SubjectClients,
+		Audience:       config.Audience,
+		Scopes:         config.Scopes,
+	}
+}
+
 // ClientAuthorizationPolicy controls and represents a client policy.
 type ClientAuthorizationPolicy struct {
 	Name          string
@@ -107,6 +117,30 @@ func (p *ClientAuthorizationPolicyRule) IsMatch(subject authorization.Subject) (
 	return p.MatchesSubjects(subject)
 }
 
+// ClientTokenExchangePolicy is the token exchange configuration for a client.
+type ClientTokenExchangePolicy struct {
+	SubjectClients []string
+	Audience       []string
+	Scopes         []string
+}
+
+// IsSubjectPermitted returns true if a subject token issued to the subject client with the subject audience may be
+// exchanged by the client. Subject tokens are permitted when they were issued to one of the explicitly permitted
+// clients, or when the client is one of the audiences of the subject token.
+func (p ClientTokenExchangePolicy) IsSubjectPermitted(clientID, subjectClientID string, subjectAudience []string) (permitted bool) {
+	return utils.IsStringInSlice(subjectClientID, p.SubjectClients) || utils.IsStringInSlice(clientID, subjectAudience)
+}
+
+// IsAudiencePermitted returns true if the audience may be requested for an exchanged token.
+func (p ClientTokenExchangePolicy) IsAudiencePermitted(audience string) (permitted bool) {
+	return utils.IsStringInSlice(audience, p.Audience)
+}
+
+// IsScopePermitted returns true if the scope may be requested for an exchanged token.
+func (p ClientTokenExchangePolicy) IsScopePermitted(scope string) (permitted bool) {
+	return utils.IsStringInSlice(scope, p.Scopes)
+}
+
 // ClientConsentPolicy is the consent configuration for a client.
 type ClientConsentPolicy struct {
 	Mode     ClientConsentMode
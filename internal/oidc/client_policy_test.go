@@ -198,3 +198,34 @@ func TestNewClientRequestedAudienceMode(t *testing.T) {
 
 	assert.Equal(t, "", oidc.ClientConsentMode(-1).String())
 }
+
+func TestNewClientTokenExchangePolicy(t *testing.T) {
+	policy := oidc.NewClientTokenExchangePolicy(schema.IdentityProvidersOpenIDConnectClientTokenExchange{
+		SubjectClients: []string{"frontend"},
+		Audience:       []string{"https://api.example.com"},
+		Scopes:         []string{oidc.ScopeOpenID, oidc.ScopeGroups},
+	})
+
+	testCases := []struct {
+		name            string
+		clientID        string
+		subjectClientID string
+		subjectAudience []string
+		expected        bool
+	}{
+		{"ShouldPermitSubjectClient", "backend", "frontend", nil, true},
+		{"ShouldPermitSubjectAudience", "backend", "other", []string{"backend"}, true},
+		{"ShouldNotPermitOtherSubject", "backend", "other", []string{"frontend"}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, policy.IsSubjectPermitted(tc.clientID, tc.subjectClientID, tc.subjectAudience))
+		})
+	}
+
+	assert.True(t, policy.IsAudiencePermitted("https://api.example.com"))
+	assert.False(t, policy.IsAudiencePermitted("https://other.example.com"))
+	assert.True(t, policy.IsScopePermitted(oidc.ScopeGroups))
+	assert.False(t, policy.IsScopePermitted(oidc.ScopeEmail))
+}
@@ -0,0 +1,211 @@
+package oidc
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// NewClientRegistrationMetadata returns the ClientRegistrationMetadata which represents a
+// schema.IdentityProvidersOpenIDConnectClient.
+func NewClientRegistrationMetadata(config schema.IdentityProvidersOpenIDConnectClient) (metadata ClientRegistrationMetadata) {
+	metadata = ClientRegistrationMetadata{
+		ClientName:                         config.Name,
+		RedirectURIs:                       config.RedirectURIs,
+		RequestURIs:                        config.RequestURIs,
+		GrantTypes:                         config.GrantTypes,
+		ResponseTypes:                      config.ResponseTypes,
+		Scope:                              strings.Join(config.Scopes, " "),
+		TokenEndpointAuthMethod:            config.TokenEndpointAuthMethod,
+		TokenEndpointAuthSigningAlg:        config.TokenEndpointAuthSigningAlg,
+		IDTokenSignedResponseAlg:           config.IDTokenSignedResponseAlg,
+		UserinfoSignedResponseAlg:          config.UserinfoSignedResponseAlg,
+		RequestObjectSigningAlg:            config.RequestObjectSigningAlg,
+		AuthorizationSignedResponseAlg:     config.AuthorizationSignedResponseAlg,
+		RequirePushedAuthorizationRequests: config.RequirePushedAuthorizationRequests,
+		PostLogoutRedirectURIs:             config.PostLogoutRedirectURIs,
+		FrontChannelLogoutSessionRequired:  config.FrontChannelLogoutSessionRequired,
+		BackChannelLogoutSessionRequired:   config.BackChannelLogoutSessionRequired,
+	}
+
+	if config.SectorIdentifierURI != nil {
+		metadata.SectorIdentifierURI = config.SectorIdentifierURI.String()
+	}
+
+	if config.JSONWebKeysURI != nil {
+		metadata.JSONWebKeysURI = config.JSONWebKeysURI.String()
+	}
+
+	if config.FrontChannelLogoutURI != nil {
+		metadata.FrontChannelLogoutURI = config.FrontChannelLogoutURI.String()
+	}
+
+	if config.BackChannelLogoutURI != nil {
+		metadata.BackChannelLogoutURI = config.BackChannelLogoutURI.String()
+	}
+
+	return metadata
+}
+
+// NewClientRegistrationRecord returns the ClientRegistrationRecord which represents a validated
+// schema.IdentityProvidersOpenIDConnectClient.
+func NewClientRegistrationRecord(config schema.IdentityProvidersOpenIDConnectClient) (record ClientRegistrationRecord) {
+	return ClientRegistrationRecord{
+		ClientRegistrationMetadata: NewClientRegistrationMetadata(config),
+		Configuration: &ClientRegistrationConfiguration{
+			Audience:                       config.Audience,
+			ResponseModes:                  config.ResponseModes,
+			RequestedAudienceMode:          config.RequestedAudienceMode,
+			RequirePKCE:                    config.RequirePKCE,
+			PKCEChallengeMethod:            config.PKCEChallengeMethod,
+			AccessTokenSignedResponseAlg:   config.AccessTokenSignedResponseAlg,
+			IntrospectionSignedResponseAlg: config.IntrospectionSignedResponseAlg,
+		},
+	}
+}
+
+// ToClientConfiguration converts the ClientRegistrationRecord into a schema.IdentityProvidersOpenIDConnectClient
+// including the values of the validated client configuration.
+func (r *ClientRegistrationRecord) ToClientConfiguration(id string, secret *schema.PasswordDigest, policy string) (config schema.IdentityProvidersOpenIDConnectClient, err error) {
+	if config, err = r.ClientRegistrationMetadata.ToClientConfiguration(id, secret, policy); err != nil {
+		return config, err
+	}
+
+	if r.Configuration == nil {
+		return config, nil
+	}
+
+	config.Audience = r.Configuration.Audience
+	config.ResponseModes = r.Configuration.ResponseModes
+	config.RequestedAudienceMode = r.Configuration.RequestedAudienceMode
+	config.RequirePKCE = config.RequirePKCE || r.Configuration.RequirePKCE
+	config.AccessTokenSignedResponseAlg = r.Configuration.AccessTokenSignedResponseAlg
+	config.IntrospectionSignedResponseAlg = r.Configuration.IntrospectionSignedResponseAlg
+
+	if r.Configuration.PKCEChallengeMethod != "" {
+		config.PKCEChallengeMethod = r.Configuration.PKCEChallengeMethod
+	}
+
+	return config, nil
+}
+
+// ToClientConfiguration converts the ClientRegistrationMetadata into a schema.IdentityProvidersOpenIDConnectClient
+// with the provided id, secret, and authorization policy. Dynamically registered clients always use the explicit
+// consent mode as they are not trusted by the administrator, and public clients always require PKCE with the 'S256'
+// challenge method.
+func (m *ClientRegistrationMetadata) ToClientConfiguration(id string, secret *schema.PasswordDigest, policy string) (config schema.IdentityProvidersOpenIDConnectClient, err error) {
+	config = schema.IdentityProvidersOpenIDConnectClient{
+		ID:                                 id,
+		Name:                               m.ClientName,
+		Secret:                             secret,
+		Public:                             m.TokenEndpointAuthMethod == ClientAuthMethodNone,
+		RedirectURIs:                       m.RedirectURIs,
+		RequestURIs:                        m.RequestURIs,
+		Scopes:                             strings.Fields(m.Scope),
+		GrantTypes:                         m.GrantTypes,
+		ResponseTypes:                      m.ResponseTypes,
+		AuthorizationPolicy:                policy,
+		ConsentMode:                        ClientConsentModeExplicit.String(),
+		RequirePushedAuthorizationRequests: m.RequirePushedAuthorizationRequests,
+		TokenEndpointAuthMethod:            m.TokenEndpointAuthMethod,
+		TokenEndpointAuthSigningAlg:        m.TokenEndpointAuthSigningAlg,
+		IDTokenSignedResponseAlg:           m.IDTokenSignedResponseAlg,
+		UserinfoSignedResponseAlg:          m.UserinfoSignedResponseAlg,
+		RequestObjectSigningAlg:            m.RequestObjectSigningAlg,
+		AuthorizationSignedResponseAlg:     m.AuthorizationSignedResponseAlg,
+		PostLogoutRedirectURIs:             m.PostLogoutRedirectURIs,
+		FrontChannelLogoutSessionRequired:  m.FrontChannelLogoutSessionRequired,
+		BackChannelLogoutSessionRequired:   m.BackChannelLogoutSessionRequired,
+	}
+
+	if config.Public {
+		config.RequirePKCE = true
+		config.PKCEChallengeMethod = PKCEChallengeMethodSHA256
+	}
+
+	if m.SectorIdentifierURI != "" {
+		if config.SectorIdentifierURI, err = url.ParseRequestURI(m.SectorIdentifierURI); err != nil {
+			return config, ErrInvalidClientMetadata.WithHintf("The 'sector_identifier_uri' value '%s' is not a valid URI.", m.SectorIdentifierURI).WithWrap(err)
+		}
+	}
+
+	if m.JSONWebKeysURI != "" {
+		if config.JSONWebKeysURI, err = url.ParseRequestURI(m.JSONWebKeysURI); err != nil {
+			return config, ErrInvalidClientMetadata.WithHintf("The 'jwks_uri' value '%s' is not a valid URI.", m.JSONWebKeysURI).WithWrap(err)
+		}
+	}
+
+	if m.FrontChannelLogoutURI != "" {
+		if config.FrontChannelLogoutURI, err = url.ParseRequestURI(m.FrontChannelLogoutURI); err != nil {
+			return config, ErrInvalidClientMetadata.WithHintf("The 'frontchannel_logout_uri' value '%s' is not a valid URI.", m.FrontChannelLogoutURI).WithWrap(err)
+		}
+	}
+
+	if m.BackChannelLogoutURI != "" {
+		if config.BackChannelLogoutURI, err = url.ParseRequestURI(m.BackChannelLogoutURI); err != nil {
+			return config, ErrInvalidClientMetadata.WithHintf("The 'backchannel_logout_uri' value '%s' is not a valid URI.", m.BackChannelLogoutURI).WithWrap(err)
+		}
+	}
+
+	return config, nil
+}
+
+// ValidateScopes ensures the requested scopes are all within the list of scopes permitted for dynamically registered
+// clients.
+func (m *ClientRegistrationMetadata) ValidateScopes(allowed []string) (err error) {
+	for _, scope := range strings.Fields(m.Scope) {
+		if !utils.IsStringInSlice(scope, allowed) {
+			return ErrInvalidClientMetadata.WithHintf("The scope '%s' is not permitted for dynamically registered clients.", scope)
+		}
+	}
+
+	return nil
+}
+
+// NewRegisteredClientFromModel returns a Client from a model.OAuth2RegisteredClient.
+func NewRegisteredClientFromModel(registered *model.OAuth2RegisteredClient, config *schema.IdentityProvidersOpenIDConnect) (client Client, err error) {
+	var (
+		record ClientRegistrationRecord
+		secret *schema.PasswordDigest
+		c      schema.IdentityProvidersOpenIDConnectClient
+	)
+
+	if err = json.Unmarshal(registered.Metadata, &record); err != nil {
+		return nil, fmt.Errorf("error occurred decoding the client metadata: %w", err)
+	}
+
+	if registered.ClientSecret != "" {
+		if secret, err = schema.DecodePasswordDigest(registered.ClientSecret); err != nil {
+			return nil, fmt.Errorf("error occurred decoding the client secret: %w", err)
+		}
+	}
+
+	if c, err = record.ToClientConfiguration(registered.ClientID, secret, config.DynamicClientRegistration.AuthorizationPolicy); err != nil {
+		return nil, err
+	}
+
+	return NewClient(c, config), nil
+}
+
+// RegistrationAccessTokenSignature returns the signature of a registration access token which is the only form of the
+// token which is persisted.
+func RegistrationAccessTokenSignature(token string) (signature string) {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(token)))
+}
+
+// IsRegistrationAccessTokenValid returns true if the provided registration access token matches the signature stored
+// for the model.OAuth2RegisteredClient.
+func IsRegistrationAccessTokenValid(registered *model.OAuth2RegisteredClient, token string) (valid bool) {
+	if registered == nil || token == "" {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(RegistrationAccessTokenSignature(token)), []byte(registered.RegistrationAccessTokenSignature)) == 1
+}
@@ -0,0 +1,320 @@
+package oidc_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/oidc"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+func TestClientRegistrationMetadata_ToClientConfiguration(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     oidc.ClientRegistrationMetadata
+		expected func(t *testing.T, config schema.IdentityProvidersOpenIDConnectClient)
+		err      string
+	}{
+		{
+			"ShouldConvertConfidentialClient",
+			oidc.ClientRegistrationMetadata{
+				ClientName:              "Preview",
+				RedirectURIs:            []string{"https://preview.example.com/callback"},
+				Scope:                   "openid profile",
+				SectorIdentifierURI:     "https://preview.example.com/sector.json",
+				TokenEndpointAuthMethod: oidc.ClientAuthMethodClientSecretBasic,
+			},
+			func(t *testing.T, config schema.IdentityProvidersOpenIDConnectClient) {
+				assert.Equal(t, "abc", config.ID)
+				assert.Equal(t, "Preview", config.Name)
+				assert.False(t, config.Public)
+				assert.Equal(t, []string{oidc.ScopeOpenID, oidc.ScopeProfile}, config.Scopes)
+				assert.Equal(t, onefactor, config.AuthorizationPolicy)
+				assert.Equal(t, "explicit", config.ConsentMode)
+				require.NotNil(t, config.SectorIdentifierURI)
+				assert.Equal(t, "https://preview.example.com/sector.json", config.SectorIdentifierURI.String())
+			},
+			"",
+		},
+		{
+			"ShouldConvertPublicClient",
+			oidc.ClientRegistrationMetadata{
+				TokenEndpointAuthMethod: oidc.ClientAuthMethodNone,
+			},
+			func(t *testing.T, config schema.IdentityProvidersOpenIDConnectClient) {
+				assert.True(t, config.Public)
+				assert.True(t, config.RequirePKCE)
+				assert.Equal(t, oidc.PKCEChallengeMethodSHA256, config.PKCEChallengeMethod)
+				assert.Nil(t, config.SectorIdentifierURI)
+				assert.Nil(t, config.JSONWebKeysURI)
+			},
+			"",
+		},
+		{
+			"ShouldFailBadSectorIdentifierURI",
+			oidc.ClientRegistrationMetadata{
+				SectorIdentifierURI: "not a uri",
+			},
+			nil,
+			"invalid_client_metadata",
+		},
+		{
+			"ShouldFailBadJSONWebKeysURI",
+			oidc.ClientRegistrationMetadata{
+				JSONWebKeysURI: "not a uri",
+			},
+			nil,
+			"invalid_client_metadata",
+		},
+		{
+			"ShouldConvertLogoutOptions",
+			oidc.ClientRegistrationMetadata{
+				PostLogoutRedirectURIs:           []string{"https://preview.example.com/logged-out"},
+				FrontChannelLogoutURI:            "https://preview.example.com/frontchannel-logout",
+				BackChannelLogoutURI:             "https://preview.example.com/backchannel-logout",
+				BackChannelLogoutSessionRequired: true,
+			},
+			func(t *testing.T, config schema.IdentityProvidersOpenIDConnectClient) {
+				assert.Equal(t, []string{"https://preview.example.com/logged-out"}, []string(config.PostLogoutRedirectURIs))
+				require.NotNil(t, config.FrontChannelLogoutURI)
+				assert.Equal(t, "https://preview.example.com/frontchannel-logout", config.FrontChannelLogoutURI.String())
+				assert.False(t, config.FrontChannelLogoutSessionRequired)
+				require.NotNil(t, config.BackChannelLogoutURI)
+				assert.Equal(t, "https://preview.example.com/backchannel-logout", config.BackChannelLogoutURI.String())
+				assert.True(t, config.BackChannelLogoutSessionRequired)
+			},
+			"",
+		},
+		{
+			"ShouldFailBadBackChannelLogoutURI",
+			oidc.ClientRegistrationMetadata{
+				BackChannelLogoutURI: "not a uri",
+			},
+			nil,
+			"invalid_client_metadata",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config, err := tc.have.ToClientConfiguration("abc", nil, onefactor)
+
+			if tc.err == "" {
+				require.NoError(t, err)
+				tc.expected(t, config)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestNewClientRegistrationMetadata(t *testing.T) {
+	metadata := oidc.ClientRegistrationMetadata{
+		ClientName:              "Preview",
+		RedirectURIs:            []string{"https://preview.example.com/callback"},
+		Scope:                   "openid groups",
+		JSONWebKeysURI:          "https://preview.example.com/jwks.json",
+		TokenEndpointAuthMethod: oidc.ClientAuthMethodClientSecretPost,
+		BackChannelLogoutURI:    "https://preview.example.com/backchannel-logout",
+	}
+
+	config, err := metadata.ToClientConfiguration("abc", nil, onefactor)
+	require.NoError(t, err)
+
+	assert.Equal(t, metadata, oidc.NewClientRegistrationMetadata(config))
+}
+
+func TestClientRegistrationRecord_ToClientConfiguration(t *testing.T) {
+	config := schema.IdentityProvidersOpenIDConnectClient{
+		ID:                           "abc",
+		Name:                         "Preview",
+		RedirectURIs:                 []string{"https://preview.example.com/callback"},
+		Scopes:                       []string{oidc.ScopeOpenID},
+		GrantTypes:                   []string{oidc.GrantTypeAuthorizationCode},
+		ResponseTypes:                []string{oidc.ResponseTypeAuthorizationCodeFlow},
+		ResponseModes:                []string{oidc.ResponseModeFormPost, oidc.ResponseModeQuery},
+		RequestedAudienceMode:        oidc.ClientRequestedAudienceModeExplicit.String(),
+		RequirePKCE:                  true,
+		PKCEChallengeMethod:          oidc.PKCEChallengeMethodSHA256,
+		AccessTokenSignedResponseAlg: oidc.SigningAlgNone,
+		TokenEndpointAuthMethod:      oidc.ClientAuthMethodClientSecretBasic,
+	}
+
+	data, err := json.Marshal(oidc.NewClientRegistrationRecord(config))
+	require.NoError(t, err)
+
+	var metadata oidc.ClientRegistrationMetadata
+
+	require.NoError(t, json.Unmarshal(data, &metadata))
+	assert.Equal(t, oidc.NewClientRegistrationMetadata(config), metadata)
+
+	var record oidc.ClientRegistrationRecord
+
+	require.NoError(t, json.Unmarshal(data, &record))
+
+	actual, err := record.ToClientConfiguration("abc", nil, onefactor)
+	require.NoError(t, err)
+
+	assert.False(t, actual.Public)
+	assert.Equal(t, []string{oidc.ResponseModeFormPost, oidc.ResponseModeQuery}, actual.ResponseModes)
+	assert.Equal(t, oidc.ClientRequestedAudienceModeExplicit.String(), actual.RequestedAudienceMode)
+	assert.True(t, actual.RequirePKCE)
+	assert.Equal(t, oidc.PKCEChallengeMethodSHA256, actual.PKCEChallengeMethod)
+	assert.Equal(t, oidc.SigningAlgNone, actual.AccessTokenSignedResponseAlg)
+
+	record.Configuration = nil
+
+	actual, err = record.ToClientConfiguration("abc", nil, onefactor)
+	require.NoError(t, err)
+
+	assert.Nil(t, actual.ResponseModes)
+	assert.False(t, actual.RequirePKCE)
+}
+
+func TestClientRegistrationMetadata_ValidateScopes(t *testing.T) {
+	metadata := oidc.ClientRegistrationMetadata{Scope: "openid groups"}
+
+	assert.NoError(t, metadata.ValidateScopes([]string{oidc.ScopeOpenID, oidc.ScopeGroups}))
+	assert.EqualError(t, metadata.ValidateScopes([]string{oidc.ScopeOpenID}), "invalid_client_metadata")
+}
+
+func TestIsRegistrationAccessTokenValid(t *testing.T) {
+	registered := &model.OAuth2RegisteredClient{RegistrationAccessTokenSignature: oidc.RegistrationAccessTokenSignature("token")}
+
+	assert.True(t, oidc.IsRegistrationAccessTokenValid(registered, "token"))
+	assert.False(t, oidc.IsRegistrationAccessTokenValid(registered, "other"))
+	assert.False(t, oidc.IsRegistrationAccessTokenValid(registered, ""))
+	assert.False(t, oidc.IsRegistrationAccessTokenValid(nil, "token"))
+}
+
+func TestStorageClientStore_GetRegisteredClient(t *testing.T) {
+	config := &schema.IdentityProvidersOpenIDConnect{
+		DynamicClientRegistration: schema.IdentityProvidersOpenIDConnectDynamicClientRegistration{
+			Enable:              true,
+			AuthorizationPolicy: onefactor,
+		},
+		Clients: []schema.IdentityProvidersOpenIDConnectClient{
+			{
+				ID:                  myclient,
+				Name:                myclientdesc,
+				AuthorizationPolicy: onefactor,
+				Scopes:              []string{oidc.ScopeOpenID},
+				Secret:              tOpenIDConnectPlainTextClientSecret,
+			},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := mocks.NewMockStorage(ctrl)
+
+	s := oidc.NewStore(config, mock)
+
+	ctx := context.Background()
+
+	client, err := s.GetRegisteredClient(ctx, myclient)
+	require.NoError(t, err)
+	assert.Equal(t, myclient, client.GetID())
+
+	gomock.InOrder(
+		mock.EXPECT().LoadOAuth2RegisteredClient(ctx, "registered").Return(&model.OAuth2RegisteredClient{
+			ClientID:     "registered",
+			ClientSecret: "$plaintext$client-secret",
+			Metadata:     []byte(`{"client_name":"Preview","redirect_uris":["https://preview.example.com/callback"],"scope":"openid profile"}`),
+		}, nil),
+		mock.EXPECT().LoadOAuth2RegisteredClient(ctx, "expired").Return(&model.OAuth2RegisteredClient{
+			ClientID:  "expired",
+			ExpiresAt: sql.NullTime{Time: time.Unix(1000000, 0), Valid: true},
+			Metadata:  []byte(`{}`),
+		}, nil),
+		mock.EXPECT().LoadOAuth2RegisteredClient(ctx, "missing").Return(nil, storage.ErrNoOAuth2RegisteredClient),
+		mock.EXPECT().LoadOAuth2RegisteredClient(ctx, "broken").Return(nil, fmt.Errorf("bad block")),
+		mock.EXPECT().LoadOAuth2RegisteredClient(ctx, "malformed").Return(&model.OAuth2RegisteredClient{
+			ClientID: "malformed",
+			Metadata: []byte(`{`),
+		}, nil),
+	)
+
+	client, err = s.GetRegisteredClient(ctx, "registered")
+	require.NoError(t, err)
+	assert.Equal(t, "registered", client.GetID())
+	assert.Equal(t, "Preview", client.GetName())
+	assert.Equal(t, []string{"https://preview.example.com/callback"}, client.GetRedirectURIs())
+	assert.Equal(t, "$plaintext$client-secret", client.GetClientSecret().(*oidc.ClientSecretDigest).Encode())
+
+	client, err = s.GetRegisteredClient(ctx, "expired")
+	assert.EqualError(t, err, "invalid_client")
+	assert.Nil(t, client)
+
+	client, err = s.GetRegisteredClient(ctx, "missing")
+	assert.EqualError(t, err, "invalid_client")
+	assert.Nil(t, client)
+
+	client, err = s.GetRegisteredClient(ctx, "broken")
+	assert.EqualError(t, err, "server_error")
+	assert.Nil(t, client)
+
+	client, err = s.GetRegisteredClient(ctx, "malformed")
+	assert.EqualError(t, err, "server_error")
+	assert.Nil(t, client)
+}
+
+func TestStorageClientStore_GetRegisteredClientShouldUseContextClock(t *testing.T) {
+	config := &schema.IdentityProvidersOpenIDConnect{
+		DynamicClientRegistration: schema.IdentityProvidersOpenIDConnectDynamicClientRegistration{
+			Enable:              true,
+			AuthorizationPolicy: onefactor,
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := mocks.NewMockStorage(ctrl)
+
+	s := oidc.NewStore(config, mock)
+
+	ctx := &TestContext{Context: context.Background(), Clock: clock.NewFixed(time.Unix(1000000, 0))}
+
+	gomock.InOrder(
+		mock.EXPECT().LoadOAuth2RegisteredClient(ctx, "public").Return(&model.OAuth2RegisteredClient{
+			ClientID:  "public",
+			ExpiresAt: sql.NullTime{Time: time.Unix(2000000, 0), Valid: true},
+			Metadata:  []byte(`{"token_endpoint_auth_method":"none","authelia_configuration":{"response_modes":["form_post"]}}`),
+		}, nil),
+		mock.EXPECT().LoadOAuth2RegisteredClient(ctx, "expired").Return(&model.OAuth2RegisteredClient{
+			ClientID:  "expired",
+			ExpiresAt: sql.NullTime{Time: time.Unix(1000000, 0), Valid: true},
+			Metadata:  []byte(`{}`),
+		}, nil),
+	)
+
+	client, err := s.GetRegisteredClient(ctx, "public")
+	require.NoError(t, err)
+
+	registered, ok := client.(*oidc.RegisteredClient)
+	require.True(t, ok)
+
+	assert.True(t, registered.Public)
+	assert.True(t, registered.RequirePKCE)
+	assert.True(t, registered.RequirePKCEChallengeMethod)
+	assert.Equal(t, oidc.PKCEChallengeMethodSHA256, registered.PKCEChallengeMethod)
+
+	client, err = s.GetRegisteredClient(ctx, "expired")
+	assert.EqualError(t, err, "invalid_client")
+	assert.Nil(t, client)
+}
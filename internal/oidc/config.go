@@ -260,6 +260,19 @@ func (c *Config) LoadHandlers(store *Store) {
 			TokenRevocationStorage: store,
 			Config:                 c,
 		},
+		&RFC8628DeviceCodeGrantHandler{
+			Strategy: c.Strategy.Core,
+			Storage:  store,
+			IDTokenHandleHelper: &openid.IDTokenHandleHelper{
+				IDTokenStrategy: c.Strategy.OpenID,
+			},
+			Config: c,
+		},
+		&RFC8693TokenExchangeGrantHandler{
+			Strategy: c.Strategy.Core,
+			Storage:  store,
+			Config:   c,
+		},
 		&openid.OpenIDConnectExplicitHandler{
 			IDTokenHandleHelper: &openid.IDTokenHandleHelper{
 				IDTokenStrategy: c.Strategy.OpenID,
@@ -11,6 +11,7 @@ const (
 	ScopeOpenID        = "openid"
 	ScopeProfile       = "profile"
 	ScopeEmail         = "email"
+	ScopePhone         = "phone"
 	ScopeGroups        = "groups"
 
 	ScopeAutheliaBearerAuthz = "authelia.bearer.authz"
@@ -37,6 +38,12 @@ const (
 	ClaimPreferredUsername                   = "preferred_username"
 	ClaimPreferredEmail                      = "email"
 	ClaimEmailVerified                       = "email_verified"
+	ClaimPhoneNumber                         = "phone_number"
+	ClaimPhoneNumberVerified                 = "phone_number_verified"
+	ClaimGivenName                           = "given_name"
+	ClaimFamilyName                          = "family_name"
+	ClaimLocale                              = "locale"
+	ClaimPicture                             = "picture"
 	ClaimAuthorizedParty                     = "azp"
 	ClaimAuthenticationContextClassReference = "acr"
 	ClaimAuthenticationMethodsReference      = "amr"
@@ -47,6 +54,27 @@ const (
 	ClaimActive                              = "active"
 	ClaimUsername                            = "username"
 	ClaimTokenIntrospection                  = "token_introspection"
+	ClaimActor                               = "act"
+	ClaimEvents                              = "events"
+)
+
+// User attribute strings which can be used as the source of custom claims.
+const (
+	UserAttributeUsername    = "username"
+	UserAttributeDisplayName = "display_name"
+	UserAttributeEmail       = "email"
+	UserAttributeEmails      = "emails"
+	UserAttributeGroups      = "groups"
+	UserAttributeGivenName   = "given_name"
+	UserAttributeFamilyName  = "family_name"
+	UserAttributePhoneNumber = "phone_number"
+	UserAttributeLocale      = "locale"
+	UserAttributePicture     = "picture"
+)
+
+const (
+	// EventBackChannelLogout is the member name of the events claim which identifies a Logout Token.
+	EventBackChannelLogout = "http://schemas.openid.net/event/backchannel-logout"
 )
 
 const (
@@ -62,6 +90,19 @@ const (
 	lifespanRFC8628CodeDefault                = time.Minute * 10
 	lifespanRFC8628PollingIntervalDefault     = time.Second * 10
 	lifespanVerifiableCredentialsNonceDefault = time.Hour
+	lifespanLogoutTokenDefault                = time.Minute * 2
+	timeoutBackChannelLogoutDefault           = time.Second * 5
+)
+
+const (
+	headerContentType                    = "Content-Type"
+	contentTypeApplicationFormURLEncoded = "application/x-www-form-urlencoded"
+)
+
+const (
+	rfc8628DeviceCodeLength = 64
+	rfc8628UserCodeLength   = 8
+	rfc8628UserCodeCharSet  = "BCDFGHJKLMNPQRSTVWXZ"
 )
 
 const (
@@ -91,6 +132,19 @@ const (
 	GrantTypeRefreshToken      = valueRefreshToken
 	GrantTypeAuthorizationCode = "authorization_code"
 	GrantTypeClientCredentials = "client_credentials"
+	GrantTypeDeviceCode        = "urn:ietf:params:oauth:grant-type:device_code"
+	GrantTypeTokenExchange     = "urn:ietf:params:oauth:grant-type:token-exchange"
+)
+
+// Token Type strings.
+const (
+	TokenTypeBearer = "bearer"
+)
+
+// Token Type Identifier strings.
+// See: https://datatracker.ietf.org/doc/html/rfc8693#section-3
+const (
+	TokenTypeIdentifierAccessToken = "urn:ietf:params:oauth:token-type:access_token"
 )
 
 // Client Auth Method strings.
@@ -166,6 +220,7 @@ const (
 const (
 	FormParameterState        = "state"
 	FormParameterClientID     = valueClientID
+	FormParameterClientSecret = "client_secret"
 	FormParameterRequestURI   = "request_uri"
 	FormParameterRedirectURI  = "redirect_uri"
 	FormParameterResponseMode = "response_mode"
@@ -173,6 +228,19 @@ const (
 	FormParameterScope        = valueScope
 	FormParameterIssuer       = valueIss
 	FormParameterPrompt       = "prompt"
+	FormParameterDeviceCode   = "device_code"
+	FormParameterUserCode     = "user_code"
+	FormParameterGrantType    = "grant_type"
+	FormParameterRefreshToken = valueRefreshToken
+
+	FormParameterSubjectToken       = "subject_token"
+	FormParameterSubjectTokenType   = "subject_token_type"
+	FormParameterActorToken         = "actor_token"
+	FormParameterRequestedTokenType = "requested_token_type"
+
+	FormParameterIDTokenHint           = "id_token_hint"
+	FormParameterPostLogoutRedirectURI = "post_logout_redirect_uri"
+	FormParameterLogoutToken           = "logout_token"
 )
 
 const (
@@ -191,6 +259,9 @@ const (
 	EndpointIntrospection              = "introspection"
 	EndpointRevocation                 = "revocation"
 	EndpointPushedAuthorizationRequest = "pushed-authorization-request"
+	EndpointRegistration               = "registration"
+	EndpointDeviceAuthorization        = "device-authorization"
+	EndpointEndSession                 = "end-session"
 )
 
 // JWT Headers.
@@ -208,11 +279,13 @@ const (
 const (
 	JWTHeaderTypeValueTokenIntrospectionJWT = "token-introspection+jwt"
 	JWTHeaderTypeValueAccessTokenJWT        = "at+jwt"
+	JWTHeaderTypeValueLogoutTokenJWT        = "logout+jwt"
 )
 
 // Paths.
 const (
 	EndpointPathConsent                           = "/consent"
+	EndpointPathDevice                            = "/device"
 	EndpointPathWellKnownOpenIDConfiguration      = "/.well-known/openid-configuration"
 	EndpointPathWellKnownOAuthAuthorizationServer = "/.well-known/oauth-authorization-server"
 	EndpointPathJWKs                              = "/jwks.json"
@@ -226,6 +299,9 @@ const (
 	EndpointPathRevocation    = EndpointPathRoot + "/" + EndpointRevocation
 
 	EndpointPathPushedAuthorizationRequest = EndpointPathRoot + "/" + EndpointPushedAuthorizationRequest
+	EndpointPathRegistration               = EndpointPathRoot + "/" + EndpointRegistration
+	EndpointPathDeviceAuthorization        = EndpointPathRoot + "/" + EndpointDeviceAuthorization
+	EndpointPathEndSession                 = EndpointPathRoot + "/" + EndpointEndSession
 
 	EndpointPathRFC8628UserVerificationURL = EndpointPathRoot + "/device-code/user-verification"
 )
@@ -338,14 +414,15 @@ const (
 )
 
 const (
-	valueScope         = "scope"
-	valueClientID      = "client_id"
-	valueImplicit      = "implicit"
-	valueExplicit      = "explicit"
-	valuePreconfigured = "pre-configured"
-	valueNone          = "none"
-	valueRefreshToken  = "refresh_token"
-	valueIss           = "iss"
+	valueScope           = "scope"
+	valueClientID        = "client_id"
+	valueImplicit        = "implicit"
+	valueExplicit        = "explicit"
+	valuePreconfigured   = "pre-configured"
+	valueNone            = "none"
+	valueRefreshToken    = "refresh_token"
+	valueIss             = "iss"
+	valueIssuedTokenType = "issued_token_type"
 )
 
 const (
@@ -30,6 +30,8 @@ func NewOpenIDConnectWellKnownConfiguration(c *schema.IdentityProvidersOpenIDCon
 					GrantTypeImplicit,
 					GrantTypeClientCredentials,
 					GrantTypeRefreshToken,
+					GrantTypeDeviceCode,
+					GrantTypeTokenExchange,
 				},
 				ResponseModesSupported: []string{
 					ResponseModeFormPost,
@@ -46,6 +48,7 @@ func NewOpenIDConnectWellKnownConfiguration(c *schema.IdentityProvidersOpenIDCon
 					ScopeProfile,
 					ScopeGroups,
 					ScopeEmail,
+					ScopePhone,
 				},
 				ClaimsSupported: []string{
 					ClaimAuthenticationMethodsReference,
@@ -66,6 +69,13 @@ func NewOpenIDConnectWellKnownConfiguration(c *schema.IdentityProvidersOpenIDCon
 					ClaimGroups,
 					ClaimPreferredUsername,
 					ClaimFullName,
+					ClaimGivenName,
+					ClaimFamilyName,
+					ClaimLocale,
+					ClaimPicture,
+					ClaimPhoneNumber,
+					ClaimPhoneNumberVerified,
+					ClaimSessionID,
 				},
 				TokenEndpointAuthMethodsSupported: []string{
 					ClientAuthMethodClientSecretBasic,
@@ -133,6 +143,9 @@ func NewOpenIDConnectWellKnownConfiguration(c *schema.IdentityProvidersOpenIDCon
 			OAuth2IssuerIdentificationDiscoveryOptions: &OAuth2IssuerIdentificationDiscoveryOptions{
 				AuthorizationResponseIssuerParameterSupported: true,
 			},
+			OAuth2DeviceAuthorizationGrantDiscoveryOptions: &OAuth2DeviceAuthorizationGrantDiscoveryOptions{
+				DeviceAuthorizationEndpoint: EndpointPathDeviceAuthorization,
+			},
 		},
 
 		OpenIDConnectDiscoveryOptions: OpenIDConnectDiscoveryOptions{
@@ -163,6 +176,17 @@ func NewOpenIDConnectWellKnownConfiguration(c *schema.IdentityProvidersOpenIDCon
 			RequestURIParameterSupported:  true,
 			RequireRequestURIRegistration: true,
 		},
+		OpenIDConnectFrontChannelLogoutDiscoveryOptions: &OpenIDConnectFrontChannelLogoutDiscoveryOptions{
+			FrontChannelLogoutSupported:        true,
+			FrontChannelLogoutSessionSupported: true,
+		},
+		OpenIDConnectBackChannelLogoutDiscoveryOptions: &OpenIDConnectBackChannelLogoutDiscoveryOptions{
+			BackChannelLogoutSupported:        true,
+			BackChannelLogoutSessionSupported: true,
+		},
+		OpenIDConnectRPInitiatedLogoutDiscoveryOptions: &OpenIDConnectRPInitiatedLogoutDiscoveryOptions{
+			EndSessionEndpoint: EndpointPathEndSession,
+		},
 		OpenIDConnectPromptCreateDiscoveryOptions: &OpenIDConnectPromptCreateDiscoveryOptions{
 			PromptValuesSupported: []string{
 				PromptConsent,
@@ -201,10 +225,26 @@ func NewOpenIDConnectWellKnownConfiguration(c *schema.IdentityProvidersOpenIDCon
 	sort.Sort(SortedSigningAlgs(config.IntrospectionSigningAlgValuesSupported))
 	sort.Sort(SortedSigningAlgs(config.AuthorizationSigningAlgValuesSupported))
 
+	for _, scope := range c.Discovery.Scopes {
+		if !utils.IsStringInSlice(scope, config.ScopesSupported) {
+			config.ScopesSupported = append(config.ScopesSupported, scope)
+		}
+	}
+
+	for _, claim := range c.Discovery.Claims {
+		if !utils.IsStringInSlice(claim, config.ClaimsSupported) {
+			config.ClaimsSupported = append(config.ClaimsSupported, claim)
+		}
+	}
+
 	if c.EnablePKCEPlainChallenge {
 		config.CodeChallengeMethodsSupported = append(config.CodeChallengeMethodsSupported, PKCEChallengeMethodPlain)
 	}
 
+	if c.DynamicClientRegistration.Enable {
+		config.RegistrationEndpoint = EndpointPathRegistration
+	}
+
 	return config
 }
 
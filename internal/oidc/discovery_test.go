@@ -168,17 +168,25 @@ func TestNewOpenIDConnectProvider_GetOpenIDConnectWellKnownConfiguration(t *test
 	assert.Equal(t, "https://example.com/api/oidc/userinfo", disco.UserinfoEndpoint)
 	assert.Equal(t, "https://example.com/api/oidc/introspection", disco.IntrospectionEndpoint)
 	assert.Equal(t, "https://example.com/api/oidc/revocation", disco.RevocationEndpoint)
+	assert.Equal(t, "https://example.com/api/oidc/device-authorization", disco.DeviceAuthorizationEndpoint)
+	assert.Equal(t, "https://example.com/api/oidc/end-session", disco.EndSessionEndpoint)
 	assert.Equal(t, "", disco.RegistrationEndpoint)
 
+	assert.True(t, disco.FrontChannelLogoutSupported)
+	assert.True(t, disco.FrontChannelLogoutSessionSupported)
+	assert.True(t, disco.BackChannelLogoutSupported)
+	assert.True(t, disco.BackChannelLogoutSessionSupported)
+
 	assert.Len(t, disco.CodeChallengeMethodsSupported, 1)
 	assert.Contains(t, disco.CodeChallengeMethodsSupported, oidc.PKCEChallengeMethodSHA256)
 
-	assert.Len(t, disco.ScopesSupported, 5)
+	assert.Len(t, disco.ScopesSupported, 6)
 	assert.Contains(t, disco.ScopesSupported, oidc.ScopeOpenID)
 	assert.Contains(t, disco.ScopesSupported, oidc.ScopeOfflineAccess)
 	assert.Contains(t, disco.ScopesSupported, oidc.ScopeProfile)
 	assert.Contains(t, disco.ScopesSupported, oidc.ScopeGroups)
 	assert.Contains(t, disco.ScopesSupported, oidc.ScopeEmail)
+	assert.Contains(t, disco.ScopesSupported, oidc.ScopePhone)
 
 	assert.Len(t, disco.ResponseModesSupported, 7)
 	assert.Contains(t, disco.ResponseModesSupported, oidc.ResponseModeFormPost)
@@ -217,14 +225,14 @@ func TestNewOpenIDConnectProvider_GetOpenIDConnectWellKnownConfiguration(t *test
 	assert.Contains(t, disco.RevocationEndpointAuthMethodsSupported, oidc.ClientAuthMethodNone)
 
 	assert.Equal(t, []string{oidc.ClientAuthMethodClientSecretBasic, oidc.ClientAuthMethodClientSecretPost, oidc.ClientAuthMethodClientSecretJWT, oidc.ClientAuthMethodPrivateKeyJWT}, disco.IntrospectionEndpointAuthMethodsSupported)
-	assert.Equal(t, []string{oidc.GrantTypeAuthorizationCode, oidc.GrantTypeImplicit, oidc.GrantTypeClientCredentials, oidc.GrantTypeRefreshToken}, disco.GrantTypesSupported)
+	assert.Equal(t, []string{oidc.GrantTypeAuthorizationCode, oidc.GrantTypeImplicit, oidc.GrantTypeClientCredentials, oidc.GrantTypeRefreshToken, oidc.GrantTypeDeviceCode, oidc.GrantTypeTokenExchange}, disco.GrantTypesSupported)
 	assert.Equal(t, []string{oidc.SigningAlgHMACUsingSHA256, oidc.SigningAlgHMACUsingSHA384, oidc.SigningAlgHMACUsingSHA512, oidc.SigningAlgRSAUsingSHA256, oidc.SigningAlgRSAUsingSHA384, oidc.SigningAlgRSAUsingSHA512, oidc.SigningAlgECDSAUsingP256AndSHA256, oidc.SigningAlgECDSAUsingP384AndSHA384, oidc.SigningAlgECDSAUsingP521AndSHA512, oidc.SigningAlgRSAPSSUsingSHA256, oidc.SigningAlgRSAPSSUsingSHA384, oidc.SigningAlgRSAPSSUsingSHA512}, disco.RevocationEndpointAuthSigningAlgValuesSupported)
 	assert.Equal(t, []string{oidc.SigningAlgHMACUsingSHA256, oidc.SigningAlgHMACUsingSHA384, oidc.SigningAlgHMACUsingSHA512, oidc.SigningAlgRSAUsingSHA256, oidc.SigningAlgRSAUsingSHA384, oidc.SigningAlgRSAUsingSHA512, oidc.SigningAlgECDSAUsingP256AndSHA256, oidc.SigningAlgECDSAUsingP384AndSHA384, oidc.SigningAlgECDSAUsingP521AndSHA512, oidc.SigningAlgRSAPSSUsingSHA256, oidc.SigningAlgRSAPSSUsingSHA384, oidc.SigningAlgRSAPSSUsingSHA512}, disco.TokenEndpointAuthSigningAlgValuesSupported)
 	assert.Equal(t, []string{oidc.SigningAlgRSAUsingSHA256, oidc.SigningAlgNone}, disco.IDTokenSigningAlgValuesSupported)
 	assert.Equal(t, []string{oidc.SigningAlgRSAUsingSHA256, oidc.SigningAlgNone}, disco.UserinfoSigningAlgValuesSupported)
 	assert.Equal(t, []string{oidc.SigningAlgRSAUsingSHA256, oidc.SigningAlgRSAUsingSHA384, oidc.SigningAlgRSAUsingSHA512, oidc.SigningAlgECDSAUsingP256AndSHA256, oidc.SigningAlgECDSAUsingP384AndSHA384, oidc.SigningAlgECDSAUsingP521AndSHA512, oidc.SigningAlgRSAPSSUsingSHA256, oidc.SigningAlgRSAPSSUsingSHA384, oidc.SigningAlgRSAPSSUsingSHA512, oidc.SigningAlgNone}, disco.RequestObjectSigningAlgValuesSupported)
 
-	assert.Len(t, disco.ClaimsSupported, 18)
+	assert.Len(t, disco.ClaimsSupported, 25)
 	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimAuthenticationMethodsReference)
 	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimAudience)
 	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimAuthorizedParty)
@@ -243,6 +251,13 @@ func TestNewOpenIDConnectProvider_GetOpenIDConnectWellKnownConfiguration(t *test
 	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimGroups)
 	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimPreferredUsername)
 	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimFullName)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimGivenName)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimFamilyName)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimLocale)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimPicture)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimPhoneNumber)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimPhoneNumberVerified)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimSessionID)
 
 	assert.Len(t, disco.PromptValuesSupported, 4)
 	assert.Contains(t, disco.PromptValuesSupported, oidc.PromptConsent)
@@ -278,17 +293,19 @@ func TestNewOpenIDConnectProvider_GetOAuth2WellKnownConfiguration(t *testing.T)
 	assert.Equal(t, "https://example.com/api/oidc/token", disco.TokenEndpoint)
 	assert.Equal(t, "https://example.com/api/oidc/introspection", disco.IntrospectionEndpoint)
 	assert.Equal(t, "https://example.com/api/oidc/revocation", disco.RevocationEndpoint)
+	assert.Equal(t, "https://example.com/api/oidc/device-authorization", disco.DeviceAuthorizationEndpoint)
 	assert.Equal(t, "", disco.RegistrationEndpoint)
 
 	require.Len(t, disco.CodeChallengeMethodsSupported, 1)
 	assert.Equal(t, "S256", disco.CodeChallengeMethodsSupported[0])
 
-	assert.Len(t, disco.ScopesSupported, 5)
+	assert.Len(t, disco.ScopesSupported, 6)
 	assert.Contains(t, disco.ScopesSupported, oidc.ScopeOpenID)
 	assert.Contains(t, disco.ScopesSupported, oidc.ScopeOfflineAccess)
 	assert.Contains(t, disco.ScopesSupported, oidc.ScopeProfile)
 	assert.Contains(t, disco.ScopesSupported, oidc.ScopeGroups)
 	assert.Contains(t, disco.ScopesSupported, oidc.ScopeEmail)
+	assert.Contains(t, disco.ScopesSupported, oidc.ScopePhone)
 
 	assert.Len(t, disco.ResponseModesSupported, 7)
 	assert.Contains(t, disco.ResponseModesSupported, oidc.ResponseModeFormPost)
@@ -319,13 +336,15 @@ func TestNewOpenIDConnectProvider_GetOAuth2WellKnownConfiguration(t *testing.T)
 	assert.Contains(t, disco.TokenEndpointAuthMethodsSupported, oidc.ClientAuthMethodPrivateKeyJWT)
 	assert.Contains(t, disco.TokenEndpointAuthMethodsSupported, oidc.ClientAuthMethodNone)
 
-	assert.Len(t, disco.GrantTypesSupported, 4)
+	assert.Len(t, disco.GrantTypesSupported, 6)
 	assert.Contains(t, disco.GrantTypesSupported, oidc.GrantTypeAuthorizationCode)
 	assert.Contains(t, disco.GrantTypesSupported, oidc.GrantTypeImplicit)
 	assert.Contains(t, disco.GrantTypesSupported, oidc.GrantTypeClientCredentials)
 	assert.Contains(t, disco.GrantTypesSupported, oidc.GrantTypeRefreshToken)
+	assert.Contains(t, disco.GrantTypesSupported, oidc.GrantTypeDeviceCode)
+	assert.Contains(t, disco.GrantTypesSupported, oidc.GrantTypeTokenExchange)
 
-	assert.Len(t, disco.ClaimsSupported, 18)
+	assert.Len(t, disco.ClaimsSupported, 25)
 	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimAuthenticationMethodsReference)
 	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimAudience)
 	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimAuthorizedParty)
@@ -344,6 +363,13 @@ func TestNewOpenIDConnectProvider_GetOAuth2WellKnownConfiguration(t *testing.T)
 	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimGroups)
 	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimPreferredUsername)
 	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimFullName)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimGivenName)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimFamilyName)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimLocale)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimPicture)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimPhoneNumber)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimPhoneNumberVerified)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimSessionID)
 }
 
 func TestNewOpenIDConnectProvider_GetOpenIDConnectWellKnownConfigurationWithPlainPKCE(t *testing.T) {
@@ -373,6 +399,34 @@ func TestNewOpenIDConnectProvider_GetOpenIDConnectWellKnownConfigurationWithPlai
 	assert.Equal(t, oidc.PKCEChallengeMethodPlain, disco.CodeChallengeMethodsSupported[1])
 }
 
+func TestNewOpenIDConnectProvider_GetWellKnownConfigurationWithDynamicClientRegistration(t *testing.T) {
+	provider := oidc.NewOpenIDConnectProvider(&schema.IdentityProvidersOpenIDConnect{
+		IssuerCertificateChain: schema.X509CertificateChain{},
+		IssuerPrivateKey:       x509PrivateKeyRSA2048,
+		HMACSecret:             "asbdhaaskmdlkamdklasmdlkams",
+		DynamicClientRegistration: schema.IdentityProvidersOpenIDConnectDynamicClientRegistration{
+			Enable:             true,
+			InitialAccessToken: "abc123",
+		},
+	}, nil, nil)
+
+	require.NotNil(t, provider)
+
+	assert.Equal(t, "https://example.com/api/oidc/registration", provider.GetOpenIDConnectWellKnownConfiguration(examplecom).RegistrationEndpoint)
+	assert.Equal(t, "https://example.com/api/oidc/registration", provider.GetOAuth2WellKnownConfiguration(examplecom).RegistrationEndpoint)
+
+	provider = oidc.NewOpenIDConnectProvider(&schema.IdentityProvidersOpenIDConnect{
+		IssuerCertificateChain: schema.X509CertificateChain{},
+		IssuerPrivateKey:       x509PrivateKeyRSA2048,
+		HMACSecret:             "asbdhaaskmdlkamdklasmdlkams",
+	}, nil, nil)
+
+	require.NotNil(t, provider)
+
+	assert.Equal(t, "", provider.GetOpenIDConnectWellKnownConfiguration(examplecom).RegistrationEndpoint)
+	assert.Equal(t, "", provider.GetOAuth2WellKnownConfiguration(examplecom).RegistrationEndpoint)
+}
+
 func TestNewOpenIDConnectWellKnownConfiguration_Copy(t *testing.T) {
 	config := &oidc.OpenIDConnectWellKnownConfiguration{
 		OAuth2WellKnownConfiguration: oidc.OAuth2WellKnownConfiguration{
@@ -502,3 +556,18 @@ func TestNewOpenIDConnectWellKnownConfiguration_Copy(t *testing.T) {
 
 	assert.Equal(t, config.OAuth2WellKnownConfiguration, y)
 }
+
+func TestNewOpenIDConnectWellKnownConfigurationCustomScopes(t *testing.T) {
+	c := schema.IdentityProvidersOpenIDConnect{
+		Discovery: schema.IdentityProvidersOpenIDConnectDiscovery{
+			Scopes: []string{"hr"},
+			Claims: []string{"department", oidc.ClaimPreferredUsername},
+		},
+	}
+
+	actual := oidc.NewOpenIDConnectWellKnownConfiguration(&c)
+
+	assert.Equal(t, []string{oidc.ScopeOfflineAccess, oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeGroups, oidc.ScopeEmail, oidc.ScopePhone, "hr"}, actual.ScopesSupported)
+	assert.Contains(t, actual.ClaimsSupported, "department")
+	assert.Len(t, actual.ClaimsSupported, len(oidc.NewOpenIDConnectWellKnownConfiguration(&schema.IdentityProvidersOpenIDConnect{}).ClaimsSupported)+1)
+}
@@ -2,6 +2,7 @@ package oidc
 
 import (
 	"errors"
+	"net/http"
 
 	oauthelia2 "authelia.com/provider/oauth2"
 )
@@ -30,4 +31,57 @@ var (
 	ErrConsentMalformedChallengeID = oauthelia2.ErrServerError.WithHint("Malformed consent session challenge ID.")
 
 	ErrClientAuthorizationUserAccessDenied = oauthelia2.ErrAccessDenied.WithHint("The user was denied access to this client.")
+
+	// ErrInvalidClientMetadata is sent when the value of one or more client metadata fields is invalid during dynamic
+	// client registration.
+	ErrInvalidClientMetadata = &oauthelia2.RFC6749Error{
+		ErrorField:       "invalid_client_metadata",
+		DescriptionField: "The value of one of the client metadata fields is invalid and the server has rejected this request.",
+		CodeField:        http.StatusBadRequest,
+	}
+
+	// ErrInvalidRedirectURI is sent when the value of one or more redirection URIs is invalid during dynamic client
+	// registration.
+	ErrInvalidRedirectURI = &oauthelia2.RFC6749Error{
+		ErrorField:       "invalid_redirect_uri",
+		DescriptionField: "The value of one or more redirection URIs is invalid.",
+		CodeField:        http.StatusBadRequest,
+	}
+
+	// ErrInvalidRegistrationToken is sent when the initial access token or registration access token is missing or
+	// invalid during dynamic client registration or management.
+	ErrInvalidRegistrationToken = &oauthelia2.RFC6749Error{
+		ErrorField:       "invalid_token",
+		DescriptionField: "The access token provided is expired, revoked, malformed, or invalid for other reasons.",
+		CodeField:        http.StatusUnauthorized,
+	}
+
+	// ErrAuthorizationPending is sent when the Device Authorization Grant user authorization is still pending.
+	ErrAuthorizationPending = &oauthelia2.RFC6749Error{
+		ErrorField:       "authorization_pending",
+		DescriptionField: "The authorization request is still pending as the end user hasn't yet completed the user interaction steps.",
+		CodeField:        http.StatusBadRequest,
+	}
+
+	// ErrSlowDown is sent when the Device Authorization Grant is being polled more frequently than the interval allows.
+	ErrSlowDown = &oauthelia2.RFC6749Error{
+		ErrorField:       "slow_down",
+		DescriptionField: "The authorization request is still pending and polling should continue, but the interval must be increased.",
+		CodeField:        http.StatusBadRequest,
+	}
+
+	// ErrExpiredToken is sent when the Device Authorization Grant device code has expired.
+	ErrExpiredToken = &oauthelia2.RFC6749Error{
+		ErrorField:       "expired_token",
+		DescriptionField: "The device code has expired, and the device authorization session has concluded.",
+		CodeField:        http.StatusBadRequest,
+	}
+
+	// ErrInvalidTarget is sent when the Token Exchange Grant requests an audience which the client is not permitted to
+	// request.
+	ErrInvalidTarget = &oauthelia2.RFC6749Error{
+		ErrorField:       "invalid_target",
+		DescriptionField: "The authorization server is unwilling or unable to issue a token for any target service indicated by the audience parameter.",
+		CodeField:        http.StatusBadRequest,
+	}
 )
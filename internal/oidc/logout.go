@@ -0,0 +1,245 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	fjwt "authelia.com/provider/oauth2/token/jwt"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/authelia/authelia/v4/internal/model"
+)
+
+// DecodeIDTokenHint decodes an ID Token previously issued by this provider which was provided as the 'id_token_hint'
+// parameter of a RP-Initiated Logout request. The signature and issuer are validated, however the expiration is
+// intentionally not validated as the ID Token is commonly expired by the time the user logs out.
+//
+// See: https://openid.net/specs/openid-connect-rpinitiated-1_0.html#RPLogout
+func (p *OpenIDConnectProvider) DecodeIDTokenHint(ctx context.Context, issuer, hint string) (claims *IDTokenHintClaims, err error) {
+	var jwk *JWK
+
+	if jwk, err = p.KeyManager.GetByTokenString(ctx, hint); err != nil {
+		return nil, fmt.Errorf("error getting jwk from id token hint: %w", err)
+	}
+
+	parser := jwt.NewParser(jwt.WithoutClaimsValidation(), jwt.WithValidMethods([]string{jwk.Algorithm()}))
+
+	mapped := jwt.MapClaims{}
+
+	if _, err = parser.ParseWithClaims(hint, mapped, func(_ *jwt.Token) (any, error) {
+		return jwk.key.Public(), nil
+	}); err != nil {
+		return nil, fmt.Errorf("error decoding id token hint: %w", err)
+	}
+
+	claims = &IDTokenHintClaims{}
+
+	if claims.Issuer, err = mapped.GetIssuer(); err != nil {
+		return nil, fmt.Errorf("error decoding id token hint: claim '%s' is malformed: %w", ClaimIssuer, err)
+	}
+
+	if claims.Issuer != issuer {
+		return nil, fmt.Errorf("error decoding id token hint: claim '%s' with value '%s' does not match the issuer '%s'", ClaimIssuer, claims.Issuer, issuer)
+	}
+
+	if claims.Subject, err = mapped.GetSubject(); err != nil {
+		return nil, fmt.Errorf("error decoding id token hint: claim '%s' is malformed: %w", ClaimSubject, err)
+	}
+
+	if claims.Audience, err = mapped.GetAudience(); err != nil {
+		return nil, fmt.Errorf("error decoding id token hint: claim '%s' is malformed: %w", ClaimAudience, err)
+	}
+
+	claims.SessionID, _ = mapped[ClaimSessionID].(string)
+
+	return claims, nil
+}
+
+// NewLogoutToken returns a signed Logout Token which informs the client that the user session recorded by the
+// model.OAuth2ClientSession has ended.
+//
+// See: https://openid.net/specs/openid-connect-backchannel-1_0.html#LogoutToken
+func (p *OpenIDConnectProvider) NewLogoutToken(ctx context.Context, client Client, session model.OAuth2ClientSession, now time.Time) (token string, err error) {
+	jwk := p.KeyManager.Get(ctx, client.GetIDTokenSignedResponseKeyID(), client.GetIDTokenSignedResponseAlg())
+
+	if jwk == nil {
+		return "", fmt.Errorf("error getting jwk for client with id '%s': no key matches the id token signing options", client.GetID())
+	}
+
+	claims := fjwt.MapClaims{
+		ClaimJWTID:          uuid.New().String(),
+		ClaimIssuer:         session.Issuer,
+		ClaimAudience:       []string{client.GetID()},
+		ClaimIssuedAt:       now.UTC().Unix(),
+		ClaimExpirationTime: now.UTC().Add(lifespanLogoutTokenDefault).Unix(),
+		ClaimSubject:        session.Subject.String(),
+		ClaimSessionID:      session.SessionID.String(),
+		ClaimEvents: map[string]any{
+			EventBackChannelLogout: map[string]any{},
+		},
+	}
+
+	headers := &fjwt.Headers{
+		Extra: map[string]any{
+			JWTHeaderKeyIdentifier: jwk.KeyID(),
+			JWTHeaderKeyType:       JWTHeaderTypeValueLogoutTokenJWT,
+		},
+	}
+
+	if token, _, err = jwk.Strategy().Generate(ctx, claims, headers); err != nil {
+		return "", fmt.Errorf("error generating logout token for client with id '%s': %w", client.GetID(), err)
+	}
+
+	return token, nil
+}
+
+// BackChannelLogout performs a Back-Channel Logout by sending the Logout Token to the Back-Channel Logout URI of the
+// client.
+//
+// See: https://openid.net/specs/openid-connect-backchannel-1_0.html#BCRequest
+func (p *OpenIDConnectProvider) BackChannelLogout(ctx context.Context, client Client, token string) (err error) {
+	uri := client.GetBackChannelLogoutURI()
+
+	if uri == nil {
+		return fmt.Errorf("error performing back-channel logout for client with id '%s': the client does not have a back-channel logout uri", client.GetID())
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeoutBackChannelLogoutDefault)
+
+	defer cancel()
+
+	form := url.Values{FormParameterLogoutToken: []string{token}}
+
+	var (
+		req  *http.Request
+		resp *http.Response
+	)
+
+	if req, err = http.NewRequestWithContext(ctx, http.MethodPost, uri.String(), strings.NewReader(form.Encode())); err != nil {
+		return fmt.Errorf("error performing back-channel logout for client with id '%s': %w", client.GetID(), err)
+	}
+
+	req.Header.Set(headerContentType, contentTypeApplicationFormURLEncoded)
+
+	if resp, err = p.Config.GetHTTPClient(ctx).HTTPClient.Do(req); err != nil {
+		return fmt.Errorf("error performing back-channel logout for client with id '%s': %w", client.GetID(), err)
+	}
+
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	default:
+		return fmt.Errorf("error performing back-channel logout for client with id '%s': the back-channel logout uri '%s' responded with status code %d", client.GetID(), uri.String(), resp.StatusCode)
+	}
+}
+
+// LogoutClientSessions informs each client holding one of the provided sessions that the user session has ended. The
+// Back-Channel Logout requests are dispatched concurrently in the background and the returned channel receives the
+// error of each request which fails, and is closed once every request has completed or timed out. The Front-Channel
+// Logout URIs are returned so they can be rendered by the user agent if one is available. Each session is marked as
+// logged out regardless of whether the client could be informed successfully, and the other errors are returned joined
+// together.
+func (p *OpenIDConnectProvider) LogoutClientSessions(ctx context.Context, sessions []model.OAuth2ClientSession, now time.Time) (frontchannel []*url.URL, backchannel <-chan error, err error) {
+	var (
+		errs     []error
+		requests []backChannelLogoutRequest
+		client   Client
+		token    string
+	)
+
+	for _, session := range sessions {
+		if client, err = p.GetRegisteredClient(ctx, session.ClientID); err != nil {
+			errs = append(errs, fmt.Errorf("error performing logout for client with id '%s': %w", session.ClientID, err))
+		} else {
+			if client.GetBackChannelLogoutURI() != nil {
+				if token, err = p.NewLogoutToken(ctx, client, session, now); err != nil {
+					errs = append(errs, err)
+				} else {
+					requests = append(requests, backChannelLogoutRequest{client: client, token: token})
+				}
+			}
+
+			if uri := NewFrontChannelLogoutURI(client, session); uri != nil {
+				frontchannel = append(frontchannel, uri)
+			}
+		}
+
+		if err = p.provider.LogoutOAuth2ClientSession(ctx, session.ID, now); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return frontchannel, p.dispatchBackChannelLogouts(requests), errors.Join(errs...)
+}
+
+type backChannelLogoutRequest struct {
+	client Client
+	token  string
+}
+
+// dispatchBackChannelLogouts performs each Back-Channel Logout request in its own goroutine. The requests are not bound
+// to the context of the caller as they're expected to outlive it, instead each request is bound by the Back-Channel
+// Logout timeout.
+func (p *OpenIDConnectProvider) dispatchBackChannelLogouts(requests []backChannelLogoutRequest) <-chan error {
+	results := make(chan error, len(requests))
+
+	wg := &sync.WaitGroup{}
+
+	for _, request := range requests {
+		wg.Add(1)
+
+		go func(request backChannelLogoutRequest) {
+			defer wg.Done()
+
+			if err := p.BackChannelLogout(context.Background(), request.client, request.token); err != nil {
+				results <- err
+			}
+		}(request)
+	}
+
+	go func() {
+		wg.Wait()
+
+		close(results)
+	}()
+
+	return results
+}
+
+// NewFrontChannelLogoutURI returns the Front-Channel Logout URI of the client for the model.OAuth2ClientSession
+// including the 'iss' and 'sid' query parameters when the client requires them, or nil if the client does not have a
+// Front-Channel Logout URI.
+//
+// See: https://openid.net/specs/openid-connect-frontchannel-1_0.html#RPLogout
+func NewFrontChannelLogoutURI(client Client, session model.OAuth2ClientSession) (uri *url.URL) {
+	if client.GetFrontChannelLogoutURI() == nil {
+		return nil
+	}
+
+	value := *client.GetFrontChannelLogoutURI()
+
+	uri = &value
+
+	if client.GetFrontChannelLogoutSessionRequired() {
+		query := uri.Query()
+
+		query.Set(ClaimIssuer, session.Issuer)
+		query.Set(ClaimSessionID, session.SessionID.String())
+
+		uri.RawQuery = query.Encode()
+	}
+
+	return uri
+}
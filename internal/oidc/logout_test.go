@@ -0,0 +1,331 @@
+package oidc_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	fjwt "authelia.com/provider/oauth2/token/jwt"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/oidc"
+)
+
+func newLogoutTestConfig(backchannel, frontchannel *url.URL) *schema.IdentityProvidersOpenIDConnect {
+	return &schema.IdentityProvidersOpenIDConnect{
+		HMACSecret: badhmac,
+		JSONWebKeys: []schema.JWK{
+			{
+				KeyID:            "kid-RS256-sig",
+				Use:              oidc.KeyUseSignature,
+				Algorithm:        oidc.SigningAlgRSAUsingSHA256,
+				Key:              x509PrivateKeyRSA2048,
+				CertificateChain: x509CertificateChainRSA2048,
+			},
+		},
+		Discovery: schema.IdentityProvidersOpenIDConnectDiscovery{
+			DefaultKeyIDs: map[string]string{oidc.SigningAlgRSAUsingSHA256: "kid-RS256-sig"},
+		},
+		Clients: []schema.IdentityProvidersOpenIDConnectClient{
+			{
+				ID:                                myclient,
+				Secret:                            tOpenIDConnectPlainTextClientSecret,
+				AuthorizationPolicy:               onefactor,
+				RedirectURIs:                      []string{examplecom},
+				IDTokenSignedResponseAlg:          oidc.SigningAlgRSAUsingSHA256,
+				FrontChannelLogoutURI:             frontchannel,
+				FrontChannelLogoutSessionRequired: true,
+				BackChannelLogoutURI:              backchannel,
+			},
+		},
+	}
+}
+
+func TestNewFrontChannelLogoutURI(t *testing.T) {
+	session := model.OAuth2ClientSession{
+		SessionID: uuid.MustParse("0f5b6c0e-7bd4-4c0e-9e53-1b5c3a0d2a11"),
+		Issuer:    "https://auth.example.com",
+	}
+
+	testCases := []struct {
+		name     string
+		client   *oidc.RegisteredClient
+		expected string
+	}{
+		{
+			"ShouldReturnNilWithoutURI",
+			&oidc.RegisteredClient{ID: myclient},
+			"",
+		},
+		{
+			"ShouldReturnURIWithoutSessionParameters",
+			&oidc.RegisteredClient{ID: myclient, FrontChannelLogoutURI: MustParseRequestURI("https://app.example.com/logout?a=b")},
+			"https://app.example.com/logout?a=b",
+		},
+		{
+			"ShouldReturnURIWithSessionParameters",
+			&oidc.RegisteredClient{ID: myclient, FrontChannelLogoutURI: MustParseRequestURI("https://app.example.com/logout?a=b"), FrontChannelLogoutSessionRequired: true},
+			"https://app.example.com/logout?a=b&iss=https%3A%2F%2Fauth.example.com&sid=0f5b6c0e-7bd4-4c0e-9e53-1b5c3a0d2a11",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := oidc.NewFrontChannelLogoutURI(tc.client, session)
+
+			if tc.expected == "" {
+				assert.Nil(t, actual)
+			} else {
+				require.NotNil(t, actual)
+				assert.Equal(t, tc.expected, actual.String())
+				assert.Equal(t, "a=b", tc.client.FrontChannelLogoutURI.RawQuery)
+			}
+		})
+	}
+}
+
+func TestOpenIDConnectProvider_NewLogoutToken(t *testing.T) {
+	provider := oidc.NewOpenIDConnectProvider(newLogoutTestConfig(MustParseRequestURI("https://app.example.com/backchannel"), nil), nil, nil)
+	require.NotNil(t, provider)
+
+	ctx := context.Background()
+
+	client, err := provider.GetRegisteredClient(ctx, myclient)
+	require.NoError(t, err)
+
+	now := time.Unix(1700000000, 0)
+
+	session := model.OAuth2ClientSession{
+		SessionID: uuid.MustParse("0f5b6c0e-7bd4-4c0e-9e53-1b5c3a0d2a11"),
+		ClientID:  myclient,
+		Subject:   uuid.MustParse("3b1a1c2e-2f0d-4f3c-8d6e-5a4b3c2d1e0f"),
+		Issuer:    "https://auth.example.com",
+	}
+
+	token, err := provider.NewLogoutToken(ctx, client, session, now)
+	require.NoError(t, err)
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+	require.NoError(t, err)
+
+	assert.Equal(t, oidc.JWTHeaderTypeValueLogoutTokenJWT, parsed.Header[oidc.JWTHeaderKeyType])
+	assert.Equal(t, "kid-RS256-sig", parsed.Header[oidc.JWTHeaderKeyIdentifier])
+
+	claims, ok := parsed.Claims.(jwt.MapClaims)
+	require.True(t, ok)
+
+	assert.Equal(t, "https://auth.example.com", claims[oidc.ClaimIssuer])
+	assert.Equal(t, "3b1a1c2e-2f0d-4f3c-8d6e-5a4b3c2d1e0f", claims[oidc.ClaimSubject])
+	assert.Equal(t, "0f5b6c0e-7bd4-4c0e-9e53-1b5c3a0d2a11", claims[oidc.ClaimSessionID])
+	assert.Equal(t, []any{myclient}, claims[oidc.ClaimAudience])
+	assert.Equal(t, float64(1700000000), claims[oidc.ClaimIssuedAt])
+	assert.Equal(t, float64(1700000120), claims[oidc.ClaimExpirationTime])
+	assert.NotEmpty(t, claims[oidc.ClaimJWTID])
+	assert.Nil(t, claims[oidc.ClaimNonce])
+	assert.Equal(t, map[string]any{oidc.EventBackChannelLogout: map[string]any{}}, claims[oidc.ClaimEvents])
+}
+
+func TestOpenIDConnectProvider_DecodeIDTokenHint(t *testing.T) {
+	provider := oidc.NewOpenIDConnectProvider(newLogoutTestConfig(nil, nil), nil, nil)
+	require.NotNil(t, provider)
+
+	ctx := context.Background()
+
+	headers := &fjwt.Headers{Extra: map[string]any{oidc.JWTHeaderKeyIdentifier: "kid-RS256-sig"}}
+
+	expired, _, err := provider.KeyManager.Generate(ctx, fjwt.MapClaims{
+		oidc.ClaimIssuer:         "https://auth.example.com",
+		oidc.ClaimSubject:        "3b1a1c2e-2f0d-4f3c-8d6e-5a4b3c2d1e0f",
+		oidc.ClaimAudience:       []string{myclient},
+		oidc.ClaimSessionID:      "0f5b6c0e-7bd4-4c0e-9e53-1b5c3a0d2a11",
+		oidc.ClaimExpirationTime: time.Now().Add(-time.Hour).Unix(),
+	}, headers)
+	require.NoError(t, err)
+
+	claims, err := provider.DecodeIDTokenHint(ctx, "https://auth.example.com", expired)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://auth.example.com", claims.Issuer)
+	assert.Equal(t, "3b1a1c2e-2f0d-4f3c-8d6e-5a4b3c2d1e0f", claims.Subject)
+	assert.Equal(t, "0f5b6c0e-7bd4-4c0e-9e53-1b5c3a0d2a11", claims.SessionID)
+	assert.Equal(t, []string{myclient}, claims.Audience)
+
+	claims, err = provider.DecodeIDTokenHint(ctx, "https://other.example.com", expired)
+	assert.Nil(t, claims)
+	assert.EqualError(t, err, "error decoding id token hint: claim 'iss' with value 'https://auth.example.com' does not match the issuer 'https://other.example.com'")
+
+	claims, err = provider.DecodeIDTokenHint(ctx, "https://auth.example.com", expired[:len(expired)-4]+"abcd")
+	assert.Nil(t, claims)
+	assert.ErrorContains(t, err, "error decoding id token hint: ")
+
+	claims, err = provider.DecodeIDTokenHint(ctx, "https://auth.example.com", "not-a-token")
+	assert.Nil(t, claims)
+	assert.ErrorContains(t, err, "error getting jwk from id token hint: ")
+}
+
+func TestOpenIDConnectProvider_LogoutClientSessions(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received []string
+		status   = http.StatusOK
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/x-www-form-urlencoded", r.Header.Get("Content-Type"))
+
+		require.NoError(t, r.ParseForm())
+
+		mu.Lock()
+
+		received = append(received, r.PostForm.Get(oidc.FormParameterLogoutToken))
+
+		code := status
+
+		mu.Unlock()
+
+		rw.WriteHeader(code)
+	}))
+
+	defer server.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := mocks.NewMockStorage(ctrl)
+
+	provider := oidc.NewOpenIDConnectProvider(newLogoutTestConfig(MustParseRequestURI(server.URL+"/backchannel"), MustParseRequestURI("https://app.example.com/frontchannel")), mock, nil)
+	require.NotNil(t, provider)
+
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+
+	sessions := []model.OAuth2ClientSession{
+		{
+			ID:        1,
+			SessionID: uuid.MustParse("0f5b6c0e-7bd4-4c0e-9e53-1b5c3a0d2a11"),
+			ClientID:  myclient,
+			Subject:   uuid.MustParse("3b1a1c2e-2f0d-4f3c-8d6e-5a4b3c2d1e0f"),
+			Issuer:    "https://auth.example.com",
+		},
+	}
+
+	count := func() int {
+		mu.Lock()
+
+		defer mu.Unlock()
+
+		return len(received)
+	}
+
+	drain := func(backchannel <-chan error) (errs []error) {
+		for err := range backchannel {
+			errs = append(errs, err)
+		}
+
+		return errs
+	}
+
+	mock.EXPECT().LogoutOAuth2ClientSession(ctx, 1, now).Return(nil)
+
+	frontchannel, backchannel, err := provider.LogoutClientSessions(ctx, sessions, now)
+	require.NoError(t, err)
+	require.Len(t, frontchannel, 1)
+	assert.Equal(t, "https://app.example.com/frontchannel?iss=https%3A%2F%2Fauth.example.com&sid=0f5b6c0e-7bd4-4c0e-9e53-1b5c3a0d2a11", frontchannel[0].String())
+	assert.Len(t, drain(backchannel), 0)
+	assert.Equal(t, 1, count())
+
+	mu.Lock()
+
+	status = http.StatusBadRequest
+
+	mu.Unlock()
+
+	mock.EXPECT().LogoutOAuth2ClientSession(ctx, 1, now).Return(errors.New("bad conn"))
+
+	frontchannel, backchannel, err = provider.LogoutClientSessions(ctx, sessions, now)
+	assert.Len(t, frontchannel, 1)
+	assert.EqualError(t, err, "bad conn")
+
+	errs := drain(backchannel)
+
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "responded with status code 400")
+	assert.Equal(t, 2, count())
+
+	sessions[0].ClientID = "not-a-client"
+
+	mock.EXPECT().LogoutOAuth2ClientSession(ctx, 1, now).Return(nil)
+
+	frontchannel, backchannel, err = provider.LogoutClientSessions(ctx, sessions, now)
+	assert.Len(t, frontchannel, 0)
+	assert.ErrorContains(t, err, "error performing logout for client with id 'not-a-client': ")
+	assert.Len(t, drain(backchannel), 0)
+	assert.Equal(t, 2, count())
+}
+
+func TestOpenIDConnectProvider_LogoutClientSessionsShouldNotWaitForBackChannel(t *testing.T) {
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		<-release
+
+		rw.WriteHeader(http.StatusOK)
+	}))
+
+	defer server.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := mocks.NewMockStorage(ctrl)
+
+	provider := oidc.NewOpenIDConnectProvider(newLogoutTestConfig(MustParseRequestURI(server.URL+"/backchannel"), nil), mock, nil)
+	require.NotNil(t, provider)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	now := time.Unix(1700000000, 0)
+
+	sessions := []model.OAuth2ClientSession{
+		{
+			ID:        1,
+			SessionID: uuid.MustParse("0f5b6c0e-7bd4-4c0e-9e53-1b5c3a0d2a11"),
+			ClientID:  myclient,
+			Subject:   uuid.MustParse("3b1a1c2e-2f0d-4f3c-8d6e-5a4b3c2d1e0f"),
+			Issuer:    "https://auth.example.com",
+		},
+	}
+
+	mock.EXPECT().LogoutOAuth2ClientSession(ctx, 1, now).Return(nil)
+
+	frontchannel, backchannel, err := provider.LogoutClientSessions(ctx, sessions, now)
+	require.NoError(t, err)
+	assert.Len(t, frontchannel, 0)
+
+	// The requests must outlive the context of the caller.
+	cancel()
+
+	select {
+	case <-backchannel:
+		t.Fatal("expected the back-channel logout to still be in progress")
+	default:
+	}
+
+	close(release)
+
+	for err = range backchannel {
+		assert.NoError(t, err)
+	}
+}
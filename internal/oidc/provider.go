@@ -46,6 +46,14 @@ func (p *OpenIDConnectProvider) GetOAuth2WellKnownConfiguration(issuer string) O
 	options.IntrospectionEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathIntrospection)
 	options.RevocationEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathRevocation)
 
+	if options.RegistrationEndpoint != "" {
+		options.RegistrationEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathRegistration)
+	}
+
+	if options.OAuth2DeviceAuthorizationGrantDiscoveryOptions != nil {
+		options.DeviceAuthorizationEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathDeviceAuthorization)
+	}
+
 	return options
 }
 
@@ -63,5 +71,17 @@ func (p *OpenIDConnectProvider) GetOpenIDConnectWellKnownConfiguration(issuer st
 	options.IntrospectionEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathIntrospection)
 	options.RevocationEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathRevocation)
 
+	if options.RegistrationEndpoint != "" {
+		options.RegistrationEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathRegistration)
+	}
+
+	if options.OAuth2DeviceAuthorizationGrantDiscoveryOptions != nil {
+		options.DeviceAuthorizationEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathDeviceAuthorization)
+	}
+
+	if options.OpenIDConnectRPInitiatedLogoutDiscoveryOptions != nil {
+		options.EndSessionEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathEndSession)
+	}
+
 	return options
 }
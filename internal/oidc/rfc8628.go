@@ -0,0 +1,243 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	oauthelia2 "authelia.com/provider/oauth2"
+
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/random"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+// NewRFC8628Codes generates a new device code and user code pair for the Device Authorization Grant. The user code
+// uses a reduced character set without vowels to avoid ambiguity and accidental words, and is split into two halves
+// to make it easier to transcribe.
+//
+// See: https://datatracker.ietf.org/doc/html/rfc8628#section-6.1
+func NewRFC8628Codes(rand random.Provider) (deviceCode, userCode string, err error) {
+	if deviceCode, err = rand.StringCustomErr(rfc8628DeviceCodeLength, random.CharSetAlphaNumeric); err != nil {
+		return "", "", fmt.Errorf("error occurred generating the device code: %w", err)
+	}
+
+	if userCode, err = rand.StringCustomErr(rfc8628UserCodeLength, rfc8628UserCodeCharSet); err != nil {
+		return "", "", fmt.Errorf("error occurred generating the user code: %w", err)
+	}
+
+	return deviceCode, fmt.Sprintf("%s-%s", userCode[:rfc8628UserCodeLength/2], userCode[rfc8628UserCodeLength/2:]), nil
+}
+
+// NormalizeRFC8628UserCode normalizes a user code as entered by a user by removing the separators and whitespace and
+// converting it to uppercase.
+func NormalizeRFC8628UserCode(userCode string) (normalized string) {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-', ' ', '\t':
+			return -1
+		default:
+			return r
+		}
+	}, strings.ToUpper(userCode))
+}
+
+// RFC8628DeviceCodeSignature returns the signature of a device code which is the only form of the code which is
+// persisted.
+func RFC8628DeviceCodeSignature(deviceCode string) (signature string) {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(deviceCode)))
+}
+
+// RFC8628UserCodeSignature returns the signature of a normalized user code which is the only form of the code which
+// is persisted.
+func RFC8628UserCodeSignature(userCode string) (signature string) {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(NormalizeRFC8628UserCode(userCode))))
+}
+
+// CanHandleTokenEndpointRequest returns true if the grant type is the Device Authorization Grant.
+func (h *RFC8628DeviceCodeGrantHandler) CanHandleTokenEndpointRequest(ctx context.Context, requester oauthelia2.AccessRequester) (handle bool) {
+	return requester.GetGrantTypes().ExactOne(GrantTypeDeviceCode)
+}
+
+// CanSkipClientAuth returns false as client authentication is always performed for the Device Authorization Grant.
+func (h *RFC8628DeviceCodeGrantHandler) CanSkipClientAuth(ctx context.Context, requester oauthelia2.AccessRequester) (skip bool) {
+	return false
+}
+
+// HandleTokenEndpointRequest validates the Device Access Token Request and restores the session the user approved.
+//
+// See: https://datatracker.ietf.org/doc/html/rfc8628#section-3.4
+func (h *RFC8628DeviceCodeGrantHandler) HandleTokenEndpointRequest(ctx context.Context, requester oauthelia2.AccessRequester) (err error) {
+	if !h.CanHandleTokenEndpointRequest(ctx, requester) {
+		return oauthelia2.ErrUnknownRequest
+	}
+
+	client := requester.GetClient()
+
+	if !client.GetGrantTypes().Has(GrantTypeDeviceCode) {
+		return oauthelia2.ErrUnauthorizedClient.WithHintf("The OAuth 2.0 Client is not allowed to use the authorization grant '%s'.", GrantTypeDeviceCode)
+	}
+
+	code := requester.GetRequestForm().Get(FormParameterDeviceCode)
+
+	if code == "" {
+		return oauthelia2.ErrInvalidRequest.WithHint("The 'device_code' parameter is missing.")
+	}
+
+	signature := RFC8628DeviceCodeSignature(code)
+
+	var session *model.OAuth2DeviceCodeSession
+
+	if session, err = h.Storage.provider.LoadOAuth2DeviceCodeSession(ctx, signature); err != nil {
+		if errors.Is(err, storage.ErrNoOAuth2DeviceCodeSession) {
+			return oauthelia2.ErrInvalidGrant.WithHint("The device code is not valid.")
+		}
+
+		return oauthelia2.ErrServerError.WithWrap(err).WithDebugf("Failed to load the device code session with error: %s.", err.Error())
+	}
+
+	if session.ClientID != client.GetID() {
+		return oauthelia2.ErrInvalidGrant.WithHint("The OAuth 2.0 Client ID from this request does not match the one from the device authorization request.")
+	}
+
+	if !session.Active {
+		return oauthelia2.ErrInvalidGrant.WithHint("The device code has already been used.")
+	}
+
+	now := h.now(ctx)
+
+	if session.Expired(now, h.Config.GetRFC8628CodeLifespan(ctx)) {
+		return ErrExpiredToken
+	}
+
+	polled := session.PolledWithin(now, h.Config.GetRFC8628TokenPollingInterval(ctx))
+
+	if err = h.Storage.provider.UpdateOAuth2DeviceCodeSessionCheckedAt(ctx, signature, now); err != nil {
+		return oauthelia2.ErrServerError.WithWrap(err).WithDebugf("Failed to update the device code session with error: %s.", err.Error())
+	}
+
+	if polled {
+		return ErrSlowDown
+	}
+
+	switch session.Status {
+	case model.OAuth2DeviceCodeStatusApproved:
+		break
+	case model.OAuth2DeviceCodeStatusDenied:
+		return oauthelia2.ErrAccessDenied.WithHint("The end user denied the authorization request.")
+	default:
+		return ErrAuthorizationPending
+	}
+
+	var original *oauthelia2.Request
+
+	if original, err = session.ToRequest(ctx, requester.GetSession(), h.Storage); err != nil {
+		return oauthelia2.ErrServerError.WithWrap(err).WithDebugf("Failed to restore the device code session with error: %s.", err.Error())
+	}
+
+	requester.SetID(original.GetID())
+	requester.SetSession(original.GetSession())
+	requester.SetRequestedScopes(original.GetRequestedScopes())
+	requester.SetRequestedAudience(original.GetRequestedAudience())
+
+	for _, scope := range original.GetGrantedScopes() {
+		requester.GrantScope(scope)
+	}
+
+	for _, audience := range original.GetGrantedAudience() {
+		requester.GrantAudience(audience)
+	}
+
+	requester.GetSession().SetExpiresAt(oauthelia2.AccessToken, now.UTC().Add(h.lifespan(client, oauthelia2.AccessToken, h.Config.GetAccessTokenLifespan(ctx))).Round(time.Second))
+
+	if h.canIssueRefreshToken(requester) {
+		requester.GetSession().SetExpiresAt(oauthelia2.RefreshToken, now.UTC().Add(h.lifespan(client, oauthelia2.RefreshToken, h.Config.GetRefreshTokenLifespan(ctx))).Round(time.Second))
+	}
+
+	return nil
+}
+
+// PopulateTokenEndpointResponse issues the tokens for an approved Device Access Token Request and consumes the device
+// code.
+//
+// See: https://datatracker.ietf.org/doc/html/rfc8628#section-3.5
+func (h *RFC8628DeviceCodeGrantHandler) PopulateTokenEndpointResponse(ctx context.Context, requester oauthelia2.AccessRequester, responder oauthelia2.AccessResponder) (err error) {
+	if !h.CanHandleTokenEndpointRequest(ctx, requester) {
+		return oauthelia2.ErrUnknownRequest
+	}
+
+	var (
+		accessToken, accessSignature, refreshToken, refreshSignature string
+	)
+
+	if accessToken, accessSignature, err = h.Strategy.GenerateAccessToken(ctx, requester); err != nil {
+		return oauthelia2.ErrServerError.WithWrap(err).WithDebugf("Failed to generate the access token with error: %s.", err.Error())
+	}
+
+	if h.canIssueRefreshToken(requester) {
+		if refreshToken, refreshSignature, err = h.Strategy.GenerateRefreshToken(ctx, requester); err != nil {
+			return oauthelia2.ErrServerError.WithWrap(err).WithDebugf("Failed to generate the refresh token with error: %s.", err.Error())
+		}
+	}
+
+	if err = h.Storage.provider.DeactivateOAuth2DeviceCodeSession(ctx, RFC8628DeviceCodeSignature(requester.GetRequestForm().Get(FormParameterDeviceCode))); err != nil {
+		if errors.Is(err, storage.ErrNoOAuth2DeviceCodeSession) {
+			return oauthelia2.ErrInvalidGrant.WithHint("The device code has already been used.")
+		}
+
+		return oauthelia2.ErrServerError.WithWrap(err).WithDebugf("Failed to consume the device code with error: %s.", err.Error())
+	}
+
+	if err = h.Storage.CreateAccessTokenSession(ctx, accessSignature, requester.Sanitize([]string{})); err != nil {
+		return oauthelia2.ErrServerError.WithWrap(err).WithDebugf("Failed to save the access token session with error: %s.", err.Error())
+	}
+
+	if refreshSignature != "" {
+		if err = h.Storage.CreateRefreshTokenSession(ctx, refreshSignature, requester.Sanitize([]string{})); err != nil {
+			return oauthelia2.ErrServerError.WithWrap(err).WithDebugf("Failed to save the refresh token session with error: %s.", err.Error())
+		}
+	}
+
+	responder.SetAccessToken(accessToken)
+	responder.SetTokenType(TokenTypeBearer)
+	responder.SetExpiresIn(requester.GetSession().GetExpiresAt(oauthelia2.AccessToken).Sub(h.now(ctx)).Round(time.Second))
+	responder.SetScopes(requester.GetGrantedScopes())
+
+	if refreshToken != "" {
+		responder.SetExtra(valueRefreshToken, refreshToken)
+	}
+
+	if requester.GetGrantedScopes().Has(ScopeOpenID) {
+		lifespan := h.lifespan(requester.GetClient(), oauthelia2.IDToken, h.Config.GetIDTokenLifespan(ctx))
+
+		if err = h.IDTokenHandleHelper.IssueExplicitIDToken(ctx, lifespan, requester, responder); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *RFC8628DeviceCodeGrantHandler) canIssueRefreshToken(requester oauthelia2.Requester) bool {
+	return requester.GetGrantedScopes().HasOneOf(ScopeOffline, ScopeOfflineAccess) &&
+		requester.GetClient().GetGrantTypes().Has(GrantTypeRefreshToken)
+}
+
+func (h *RFC8628DeviceCodeGrantHandler) lifespan(client oauthelia2.Client, tt oauthelia2.TokenType, fallback time.Duration) time.Duration {
+	if c, ok := client.(Client); ok {
+		return c.GetEffectiveLifespan(oauthelia2.GrantType(GrantTypeDeviceCode), tt, fallback)
+	}
+
+	return fallback
+}
+
+func (h *RFC8628DeviceCodeGrantHandler) now(ctx context.Context) time.Time {
+	if octx := h.Config.GetContext(ctx); octx != nil {
+		return octx.GetClock().Now()
+	}
+
+	return time.Now()
+}
@@ -0,0 +1,295 @@
+package oidc_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	oauthelia2 "authelia.com/provider/oauth2"
+	"authelia.com/provider/oauth2/handler/oauth2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/oidc"
+	"github.com/authelia/authelia/v4/internal/random"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+func TestNewRFC8628Codes(t *testing.T) {
+	deviceCode, userCode, err := oidc.NewRFC8628Codes(random.NewMathematical())
+
+	require.NoError(t, err)
+
+	assert.Regexp(t, regexp.MustCompile(`^[a-zA-Z0-9]{64}$`), deviceCode)
+	assert.Regexp(t, regexp.MustCompile(`^[BCDFGHJKLMNPQRSTVWXZ]{4}-[BCDFGHJKLMNPQRSTVWXZ]{4}$`), userCode)
+}
+
+func TestNormalizeRFC8628UserCode(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected string
+	}{
+		{"ShouldNotModifyNormalized", "BCDFGHJK", "BCDFGHJK"},
+		{"ShouldRemoveSeparator", "BCDF-GHJK", "BCDFGHJK"},
+		{"ShouldRemoveWhitespace", " bcdf ghjk\t", "BCDFGHJK"},
+		{"ShouldUppercase", "bcdf-ghjk", "BCDFGHJK"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, oidc.NormalizeRFC8628UserCode(tc.have))
+		})
+	}
+}
+
+func TestRFC8628Signatures(t *testing.T) {
+	assert.Equal(t, "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", oidc.RFC8628DeviceCodeSignature("test"))
+	assert.Equal(t, oidc.RFC8628UserCodeSignature("BCDFGHJK"), oidc.RFC8628UserCodeSignature("bcdf-ghjk"))
+	assert.NotEqual(t, oidc.RFC8628UserCodeSignature("BCDFGHJK"), oidc.RFC8628UserCodeSignature("BCDFGHJL"))
+}
+
+func TestRFC8628DeviceCodeGrantHandler_CanHandleTokenEndpointRequest(t *testing.T) {
+	handler := &oidc.RFC8628DeviceCodeGrantHandler{}
+
+	ctx := context.Background()
+
+	assert.True(t, handler.CanHandleTokenEndpointRequest(ctx, &oauthelia2.AccessRequest{GrantTypes: oauthelia2.Arguments{oidc.GrantTypeDeviceCode}}))
+	assert.False(t, handler.CanHandleTokenEndpointRequest(ctx, &oauthelia2.AccessRequest{GrantTypes: oauthelia2.Arguments{oidc.GrantTypeAuthorizationCode}}))
+	assert.False(t, handler.CanHandleTokenEndpointRequest(ctx, &oauthelia2.AccessRequest{GrantTypes: oauthelia2.Arguments{oidc.GrantTypeDeviceCode, oidc.GrantTypeRefreshToken}}))
+	assert.False(t, handler.CanSkipClientAuth(ctx, &oauthelia2.AccessRequest{GrantTypes: oauthelia2.Arguments{oidc.GrantTypeDeviceCode}}))
+}
+
+func TestRFC8628DeviceCodeGrantHandler_HandleTokenEndpointRequest(t *testing.T) {
+	signature := oidc.RFC8628DeviceCodeSignature("abc123")
+
+	testCases := []struct {
+		name       string
+		grantTypes []string
+		code       string
+		setup      func(mock *mocks.MockStorage)
+		err        string
+	}{
+		{
+			"ShouldFailUnauthorizedClient",
+			[]string{oidc.GrantTypeAuthorizationCode},
+			"abc123",
+			nil,
+			"unauthorized_client",
+		},
+		{
+			"ShouldFailMissingDeviceCode",
+			[]string{oidc.GrantTypeDeviceCode},
+			"",
+			nil,
+			"invalid_request",
+		},
+		{
+			"ShouldFailUnknownDeviceCode",
+			[]string{oidc.GrantTypeDeviceCode},
+			"abc123",
+			func(mock *mocks.MockStorage) {
+				mock.EXPECT().LoadOAuth2DeviceCodeSession(gomock.Any(), signature).Return(nil, storage.ErrNoOAuth2DeviceCodeSession)
+			},
+			"invalid_grant",
+		},
+		{
+			"ShouldFailStorageError",
+			[]string{oidc.GrantTypeDeviceCode},
+			"abc123",
+			func(mock *mocks.MockStorage) {
+				mock.EXPECT().LoadOAuth2DeviceCodeSession(gomock.Any(), signature).Return(nil, fmt.Errorf("bad block"))
+			},
+			"server_error",
+		},
+		{
+			"ShouldFailClientMismatch",
+			[]string{oidc.GrantTypeDeviceCode},
+			"abc123",
+			func(mock *mocks.MockStorage) {
+				mock.EXPECT().LoadOAuth2DeviceCodeSession(gomock.Any(), signature).Return(&model.OAuth2DeviceCodeSession{
+					OAuth2Session: model.OAuth2Session{ClientID: "other", Active: true, RequestedAt: time.Now()},
+				}, nil)
+			},
+			"invalid_grant",
+		},
+		{
+			"ShouldFailUsed",
+			[]string{oidc.GrantTypeDeviceCode},
+			"abc123",
+			func(mock *mocks.MockStorage) {
+				mock.EXPECT().LoadOAuth2DeviceCodeSession(gomock.Any(), signature).Return(&model.OAuth2DeviceCodeSession{
+					OAuth2Session: model.OAuth2Session{ClientID: myclient, Active: false, RequestedAt: time.Now()},
+				}, nil)
+			},
+			"invalid_grant",
+		},
+		{
+			"ShouldFailExpired",
+			[]string{oidc.GrantTypeDeviceCode},
+			"abc123",
+			func(mock *mocks.MockStorage) {
+				mock.EXPECT().LoadOAuth2DeviceCodeSession(gomock.Any(), signature).Return(&model.OAuth2DeviceCodeSession{
+					OAuth2Session: model.OAuth2Session{ClientID: myclient, Active: true, RequestedAt: time.Now().Add(-time.Hour)},
+				}, nil)
+			},
+			"expired_token",
+		},
+		{
+			"ShouldFailSlowDown",
+			[]string{oidc.GrantTypeDeviceCode},
+			"abc123",
+			func(mock *mocks.MockStorage) {
+				mock.EXPECT().LoadOAuth2DeviceCodeSession(gomock.Any(), signature).Return(&model.OAuth2DeviceCodeSession{
+					OAuth2Session: model.OAuth2Session{ClientID: myclient, Active: true, RequestedAt: time.Now()},
+					CheckedAt:     sql.NullTime{Time: time.Now().Add(-time.Second), Valid: true},
+				}, nil)
+				mock.EXPECT().UpdateOAuth2DeviceCodeSessionCheckedAt(gomock.Any(), signature, gomock.Any()).Return(nil)
+			},
+			"slow_down",
+		},
+		{
+			"ShouldFailPending",
+			[]string{oidc.GrantTypeDeviceCode},
+			"abc123",
+			func(mock *mocks.MockStorage) {
+				mock.EXPECT().LoadOAuth2DeviceCodeSession(gomock.Any(), signature).Return(&model.OAuth2DeviceCodeSession{
+					OAuth2Session: model.OAuth2Session{ClientID: myclient, Active: true, RequestedAt: time.Now()},
+					CheckedAt:     sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true},
+				}, nil)
+				mock.EXPECT().UpdateOAuth2DeviceCodeSessionCheckedAt(gomock.Any(), signature, gomock.Any()).Return(nil)
+			},
+			"authorization_pending",
+		},
+		{
+			"ShouldFailDenied",
+			[]string{oidc.GrantTypeDeviceCode},
+			"abc123",
+			func(mock *mocks.MockStorage) {
+				mock.EXPECT().LoadOAuth2DeviceCodeSession(gomock.Any(), signature).Return(&model.OAuth2DeviceCodeSession{
+					OAuth2Session: model.OAuth2Session{ClientID: myclient, Active: true, RequestedAt: time.Now()},
+					Status:        model.OAuth2DeviceCodeStatusDenied,
+				}, nil)
+				mock.EXPECT().UpdateOAuth2DeviceCodeSessionCheckedAt(gomock.Any(), signature, gomock.Any()).Return(nil)
+			},
+			"access_denied",
+		},
+		{
+			"ShouldFailUpdateCheckedAt",
+			[]string{oidc.GrantTypeDeviceCode},
+			"abc123",
+			func(mock *mocks.MockStorage) {
+				mock.EXPECT().LoadOAuth2DeviceCodeSession(gomock.Any(), signature).Return(&model.OAuth2DeviceCodeSession{
+					OAuth2Session: model.OAuth2Session{ClientID: myclient, Active: true, RequestedAt: time.Now()},
+				}, nil)
+				mock.EXPECT().UpdateOAuth2DeviceCodeSessionCheckedAt(gomock.Any(), signature, gomock.Any()).Return(fmt.Errorf("bad block"))
+			},
+			"server_error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mock := mocks.NewMockStorage(ctrl)
+
+			if tc.setup != nil {
+				tc.setup(mock)
+			}
+
+			handler := &oidc.RFC8628DeviceCodeGrantHandler{
+				Storage: oidc.NewStore(&schema.IdentityProvidersOpenIDConnect{}, mock),
+				Config:  &oidc.Config{},
+			}
+
+			requester := &oauthelia2.AccessRequest{
+				GrantTypes: oauthelia2.Arguments{oidc.GrantTypeDeviceCode},
+				Request: oauthelia2.Request{
+					Client: &oauthelia2.DefaultClient{
+						ID:         myclient,
+						GrantTypes: tc.grantTypes,
+					},
+					Form:    url.Values{oidc.FormParameterDeviceCode: []string{tc.code}},
+					Session: oidc.NewSession(),
+				},
+			}
+
+			assert.EqualError(t, handler.HandleTokenEndpointRequest(context.Background(), requester), tc.err)
+		})
+	}
+}
+
+type testRFC8628CoreStrategy struct {
+	*mocks.MockAccessTokenStrategy
+	oauth2.RefreshTokenStrategy
+	oauth2.AuthorizeCodeStrategy
+}
+
+func TestRFC8628DeviceCodeGrantHandler_PopulateTokenEndpointResponse(t *testing.T) {
+	signature := oidc.RFC8628DeviceCodeSignature("abc123")
+
+	testCases := []struct {
+		name  string
+		setup func(mock *mocks.MockStorage)
+		err   string
+	}{
+		{
+			"ShouldFailAlreadyConsumed",
+			func(mock *mocks.MockStorage) {
+				mock.EXPECT().DeactivateOAuth2DeviceCodeSession(gomock.Any(), signature).Return(storage.ErrNoOAuth2DeviceCodeSession)
+			},
+			"invalid_grant",
+		},
+		{
+			"ShouldFailStorageError",
+			func(mock *mocks.MockStorage) {
+				mock.EXPECT().DeactivateOAuth2DeviceCodeSession(gomock.Any(), signature).Return(fmt.Errorf("bad block"))
+			},
+			"server_error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mock := mocks.NewMockStorage(ctrl)
+			strategy := mocks.NewMockAccessTokenStrategy(ctrl)
+
+			strategy.EXPECT().GenerateAccessToken(gomock.Any(), gomock.Any()).Return("token", "signature", nil)
+
+			tc.setup(mock)
+
+			handler := &oidc.RFC8628DeviceCodeGrantHandler{
+				Strategy: &testRFC8628CoreStrategy{MockAccessTokenStrategy: strategy},
+				Storage:  oidc.NewStore(&schema.IdentityProvidersOpenIDConnect{}, mock),
+				Config:   &oidc.Config{},
+			}
+
+			requester := &oauthelia2.AccessRequest{
+				GrantTypes: oauthelia2.Arguments{oidc.GrantTypeDeviceCode},
+				Request: oauthelia2.Request{
+					Client: &oauthelia2.DefaultClient{
+						ID:         myclient,
+						GrantTypes: []string{oidc.GrantTypeDeviceCode},
+					},
+					Form:    url.Values{oidc.FormParameterDeviceCode: []string{"abc123"}},
+					Session: oidc.NewSession(),
+				},
+			}
+
+			assert.EqualError(t, handler.PopulateTokenEndpointResponse(context.Background(), requester, oauthelia2.NewAccessResponse()), tc.err)
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	oauthelia2 "authelia.com/provider/oauth2"
+)
+
+// CanHandleTokenEndpointRequest returns true if the grant type is the Token Exchange Grant.
+func (h *RFC8693TokenExchangeGrantHandler) CanHandleTokenEndpointRequest(ctx context.Context, requester oauthelia2.AccessRequester) (handle bool) {
+	return requester.GetGrantTypes().ExactOne(GrantTypeTokenExchange)
+}
+
+// CanSkipClientAuth returns false as client authentication is always performed for the Token Exchange Grant.
+func (h *RFC8693TokenExchangeGrantHandler) CanSkipClientAuth(ctx context.Context, requester oauthelia2.AccessRequester) (skip bool) {
+	return false
+}
+
+// HandleTokenEndpointRequest validates the Token Exchange Request against the subject token and the Token Exchange
+// policy of the client, and prepares a session for the down-scoped token which records the client as the actor.
+//
+// See: https://datatracker.ietf.org/doc/html/rfc8693#section-2.1
+func (h *RFC8693TokenExchangeGrantHandler) HandleTokenEndpointRequest(ctx context.Context, requester oauthelia2.AccessRequester) (err error) {
+	if !h.CanHandleTokenEndpointRequest(ctx, requester) {
+		return oauthelia2.ErrUnknownRequest
+	}
+
+	if !requester.GetClient().GetGrantTypes().Has(GrantTypeTokenExchange) {
+		return oauthelia2.ErrUnauthorizedClient.WithHintf("The OAuth 2.0 Client is not allowed to use the authorization grant '%s'.", GrantTypeTokenExchange)
+	}
+
+	client, ok := requester.GetClient().(Client)
+	if !ok {
+		return oauthelia2.ErrUnauthorizedClient.WithHint("The OAuth 2.0 Client does not have a Token Exchange policy.")
+	}
+
+	form := requester.GetRequestForm()
+
+	token := form.Get(FormParameterSubjectToken)
+
+	switch {
+	case token == "":
+		return oauthelia2.ErrInvalidRequest.WithHint("The 'subject_token' parameter is missing.")
+	case form.Get(FormParameterSubjectTokenType) != TokenTypeIdentifierAccessToken:
+		return oauthelia2.ErrInvalidRequest.WithHintf("The 'subject_token_type' parameter must be '%s'.", TokenTypeIdentifierAccessToken)
+	case form.Get(FormParameterActorToken) != "":
+		return oauthelia2.ErrInvalidRequest.WithHint("The 'actor_token' parameter is not supported, the authenticated client is always the actor.")
+	case form.Has(FormParameterRequestedTokenType) && form.Get(FormParameterRequestedTokenType) != TokenTypeIdentifierAccessToken:
+		return oauthelia2.ErrInvalidRequest.WithHintf("The 'requested_token_type' parameter must be '%s'.", TokenTypeIdentifierAccessToken)
+	}
+
+	var subject oauthelia2.Requester
+
+	if subject, err = h.Storage.GetAccessTokenSession(ctx, h.Strategy.AccessTokenSignature(ctx, token), NewSession()); err != nil {
+		switch {
+		case errors.Is(err, oauthelia2.ErrNotFound), errors.Is(err, oauthelia2.ErrInactiveToken):
+			return oauthelia2.ErrInvalidGrant.WithHint("The subject token is not valid.")
+		default:
+			return oauthelia2.ErrServerError.WithWrap(err).WithDebugf("Failed to load the subject token session with error: %s.", err.Error())
+		}
+	}
+
+	if err = h.Strategy.ValidateAccessToken(ctx, subject, token); err != nil {
+		return oauthelia2.ErrInvalidGrant.WithWrap(err).WithHint("The subject token is not valid.")
+	}
+
+	policy := client.GetTokenExchangePolicy()
+
+	if !policy.IsSubjectPermitted(client.GetID(), subject.GetClient().GetID(), subject.GetGrantedAudience()) {
+		return oauthelia2.ErrInvalidGrant.WithHint("The OAuth 2.0 Client is not permitted to exchange the subject token.")
+	}
+
+	original, ok := subject.GetSession().(*Session)
+	if !ok {
+		return oauthelia2.ErrServerError.WithDebug("Failed to restore the subject token session.")
+	}
+
+	if err = h.handleScopes(requester, subject, policy); err != nil {
+		return err
+	}
+
+	if err = h.handleAudience(requester, policy); err != nil {
+		return err
+	}
+
+	session := original.Clone().(*Session)
+
+	session.ClientID = client.GetID()
+	session.Actor = NewActorClaim(client.GetID(), original.Actor)
+
+	expiresAt := h.now(ctx).UTC().Add(client.GetEffectiveLifespan(oauthelia2.GrantType(GrantTypeTokenExchange), oauthelia2.AccessToken, h.Config.GetAccessTokenLifespan(ctx))).Round(time.Second)
+
+	// The exchanged token must never outlive the token it was exchanged for.
+	if subjectExpiresAt := original.GetExpiresAt(oauthelia2.AccessToken); !subjectExpiresAt.IsZero() && subjectExpiresAt.Before(expiresAt) {
+		expiresAt = subjectExpiresAt
+	}
+
+	session.SetExpiresAt(oauthelia2.AccessToken, expiresAt)
+
+	requester.SetSession(session)
+
+	return nil
+}
+
+// PopulateTokenEndpointResponse issues the down-scoped access token for a validated Token Exchange Request.
+//
+// See: https://datatracker.ietf.org/doc/html/rfc8693#section-2.2
+func (h *RFC8693TokenExchangeGrantHandler) PopulateTokenEndpointResponse(ctx context.Context, requester oauthelia2.AccessRequester, responder oauthelia2.AccessResponder) (err error) {
+	if !h.CanHandleTokenEndpointRequest(ctx, requester) {
+		return oauthelia2.ErrUnknownRequest
+	}
+
+	var accessToken, accessSignature string
+
+	if accessToken, accessSignature, err = h.Strategy.GenerateAccessToken(ctx, requester); err != nil {
+		return oauthelia2.ErrServerError.WithWrap(err).WithDebugf("Failed to generate the access token with error: %s.", err.Error())
+	}
+
+	if err = h.Storage.CreateAccessTokenSession(ctx, accessSignature, requester.Sanitize([]string{})); err != nil {
+		return oauthelia2.ErrServerError.WithWrap(err).WithDebugf("Failed to save the access token session with error: %s.", err.Error())
+	}
+
+	responder.SetAccessToken(accessToken)
+	responder.SetTokenType(TokenTypeBearer)
+	responder.SetExpiresIn(requester.GetSession().GetExpiresAt(oauthelia2.AccessToken).Sub(h.now(ctx)).Round(time.Second))
+	responder.SetScopes(requester.GetGrantedScopes())
+	responder.SetExtra(valueIssuedTokenType, TokenTypeIdentifierAccessToken)
+
+	return nil
+}
+
+// handleScopes grants the requested scopes, or the permitted subset of the scopes granted to the subject token if none
+// were requested. Every scope must have been granted to the subject token and be permitted by the policy.
+func (h *RFC8693TokenExchangeGrantHandler) handleScopes(requester oauthelia2.AccessRequester, subject oauthelia2.Requester, policy ClientTokenExchangePolicy) (err error) {
+	scopes := requester.GetRequestedScopes()
+
+	if len(scopes) == 0 {
+		scopes = oauthelia2.Arguments{}
+
+		for _, scope := range subject.GetGrantedScopes() {
+			if policy.IsScopePermitted(scope) {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+
+	for _, scope := range scopes {
+		if !subject.GetGrantedScopes().Has(scope) {
+			return oauthelia2.ErrInvalidScope.WithHintf("The requested scope '%s' was not granted to the subject token.", scope)
+		}
+
+		if !policy.IsScopePermitted(scope) {
+			return oauthelia2.ErrInvalidScope.WithHintf("The OAuth 2.0 Client is not permitted to request the scope '%s' in a token exchange.", scope)
+		}
+	}
+
+	for _, scope := range scopes {
+		requester.GrantScope(scope)
+	}
+
+	return nil
+}
+
+// handleAudience grants the requested audience, or every audience permitted by the policy if none was requested.
+func (h *RFC8693TokenExchangeGrantHandler) handleAudience(requester oauthelia2.AccessRequester, policy ClientTokenExchangePolicy) (err error) {
+	audience := requester.GetRequestedAudience()
+
+	if len(audience) == 0 {
+		audience = policy.Audience
+	}
+
+	for _, aud := range audience {
+		if !policy.IsAudiencePermitted(aud) {
+			return ErrInvalidTarget.WithHintf("The OAuth 2.0 Client is not permitted to request the audience '%s' in a token exchange.", aud)
+		}
+	}
+
+	for _, aud := range audience {
+		requester.GrantAudience(aud)
+	}
+
+	return nil
+}
+
+func (h *RFC8693TokenExchangeGrantHandler) now(ctx context.Context) time.Time {
+	if octx := h.Config.GetContext(ctx); octx != nil {
+		return octx.GetClock().Now()
+	}
+
+	return time.Now()
+}
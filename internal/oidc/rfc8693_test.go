@@ -0,0 +1,330 @@
+package oidc_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	oauthelia2 "authelia.com/provider/oauth2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/oidc"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+func TestRFC8693TokenExchangeGrantHandler_CanHandleTokenEndpointRequest(t *testing.T) {
+	handler := &oidc.RFC8693TokenExchangeGrantHandler{}
+
+	ctx := context.Background()
+
+	assert.True(t, handler.CanHandleTokenEndpointRequest(ctx, &oauthelia2.AccessRequest{GrantTypes: oauthelia2.Arguments{oidc.GrantTypeTokenExchange}}))
+	assert.False(t, handler.CanHandleTokenEndpointRequest(ctx, &oauthelia2.AccessRequest{GrantTypes: oauthelia2.Arguments{oidc.GrantTypeClientCredentials}}))
+	assert.False(t, handler.CanHandleTokenEndpointRequest(ctx, &oauthelia2.AccessRequest{GrantTypes: oauthelia2.Arguments{oidc.GrantTypeTokenExchange, oidc.GrantTypeRefreshToken}}))
+	assert.False(t, handler.CanSkipClientAuth(ctx, &oauthelia2.AccessRequest{GrantTypes: oauthelia2.Arguments{oidc.GrantTypeTokenExchange}}))
+}
+
+func TestRFC8693TokenExchangeGrantHandler_HandleTokenEndpointRequest(t *testing.T) {
+	config := &schema.IdentityProvidersOpenIDConnect{
+		Clients: []schema.IdentityProvidersOpenIDConnectClient{
+			{
+				ID:       "frontend",
+				Audience: []string{"frontend"},
+				Scopes:   []string{oidc.ScopeOpenID, oidc.ScopeGroups, oidc.ScopeEmail},
+			},
+		},
+	}
+
+	subject := func(clientID string, scopes, audience []string, act map[string]any) *model.OAuth2Session {
+		session := oidc.NewSession()
+		session.Subject = "2c8a5d8d-5f3c-4a31-a2d6-2f2a4ae8d4a1"
+		session.Username = "john"
+		session.ClientID = clientID
+		session.Actor = act
+		session.SetExpiresAt(oauthelia2.AccessToken, time.Now().Add(time.Minute).Round(time.Second))
+
+		s, err := model.NewOAuth2SessionFromRequest("sig", &oauthelia2.Request{
+			ID:              "d7b3e44a-3c5c-4e3a-9f21-3a9b2cc0e5a4",
+			Client:          &oidc.RegisteredClient{ID: clientID},
+			GrantedScope:    scopes,
+			GrantedAudience: audience,
+			Session:         session,
+		})
+
+		require.NoError(t, err)
+
+		s.Active = true
+
+		return s
+	}
+
+	testCases := []struct {
+		name       string
+		grantTypes []string
+		policy     oidc.ClientTokenExchangePolicy
+		form       url.Values
+		scopes     []string
+		audience   []string
+		setup      func(mock *mocks.MockStorage, strategy *mocks.MockAccessTokenStrategy)
+		err        string
+		expected   func(t *testing.T, requester oauthelia2.AccessRequester)
+	}{
+		{
+			"ShouldFailUnauthorizedClient",
+			[]string{oidc.GrantTypeClientCredentials},
+			oidc.ClientTokenExchangePolicy{},
+			url.Values{oidc.FormParameterSubjectToken: []string{"abc123"}, oidc.FormParameterSubjectTokenType: []string{oidc.TokenTypeIdentifierAccessToken}},
+			nil,
+			nil,
+			nil,
+			"unauthorized_client",
+			nil,
+		},
+		{
+			"ShouldFailMissingSubjectToken",
+			[]string{oidc.GrantTypeTokenExchange},
+			oidc.ClientTokenExchangePolicy{},
+			url.Values{oidc.FormParameterSubjectTokenType: []string{oidc.TokenTypeIdentifierAccessToken}},
+			nil,
+			nil,
+			nil,
+			"invalid_request",
+			nil,
+		},
+		{
+			"ShouldFailBadSubjectTokenType",
+			[]string{oidc.GrantTypeTokenExchange},
+			oidc.ClientTokenExchangePolicy{},
+			url.Values{oidc.FormParameterSubjectToken: []string{"abc123"}, oidc.FormParameterSubjectTokenType: []string{"urn:ietf:params:oauth:token-type:id_token"}},
+			nil,
+			nil,
+			nil,
+			"invalid_request",
+			nil,
+		},
+		{
+			"ShouldFailActorToken",
+			[]string{oidc.GrantTypeTokenExchange},
+			oidc.ClientTokenExchangePolicy{},
+			url.Values{oidc.FormParameterSubjectToken: []string{"abc123"}, oidc.FormParameterSubjectTokenType: []string{oidc.TokenTypeIdentifierAccessToken}, oidc.FormParameterActorToken: []string{"xyz"}},
+			nil,
+			nil,
+			nil,
+			"invalid_request",
+			nil,
+		},
+		{
+			"ShouldFailBadRequestedTokenType",
+			[]string{oidc.GrantTypeTokenExchange},
+			oidc.ClientTokenExchangePolicy{},
+			url.Values{oidc.FormParameterSubjectToken: []string{"abc123"}, oidc.FormParameterSubjectTokenType: []string{oidc.TokenTypeIdentifierAccessToken}, oidc.FormParameterRequestedTokenType: []string{"urn:ietf:params:oauth:token-type:refresh_token"}},
+			nil,
+			nil,
+			nil,
+			"invalid_request",
+			nil,
+		},
+		{
+			"ShouldFailUnknownSubjectToken",
+			[]string{oidc.GrantTypeTokenExchange},
+			oidc.ClientTokenExchangePolicy{},
+			url.Values{oidc.FormParameterSubjectToken: []string{"abc123"}, oidc.FormParameterSubjectTokenType: []string{oidc.TokenTypeIdentifierAccessToken}},
+			nil,
+			nil,
+			func(mock *mocks.MockStorage, strategy *mocks.MockAccessTokenStrategy) {
+				strategy.EXPECT().AccessTokenSignature(gomock.Any(), "abc123").Return("sig")
+				mock.EXPECT().LoadOAuth2Session(gomock.Any(), storage.OAuth2SessionTypeAccessToken, "sig").Return(nil, sql.ErrNoRows)
+			},
+			"invalid_grant",
+			nil,
+		},
+		{
+			"ShouldFailStorageError",
+			[]string{oidc.GrantTypeTokenExchange},
+			oidc.ClientTokenExchangePolicy{},
+			url.Values{oidc.FormParameterSubjectToken: []string{"abc123"}, oidc.FormParameterSubjectTokenType: []string{oidc.TokenTypeIdentifierAccessToken}},
+			nil,
+			nil,
+			func(mock *mocks.MockStorage, strategy *mocks.MockAccessTokenStrategy) {
+				strategy.EXPECT().AccessTokenSignature(gomock.Any(), "abc123").Return("sig")
+				mock.EXPECT().LoadOAuth2Session(gomock.Any(), storage.OAuth2SessionTypeAccessToken, "sig").Return(nil, fmt.Errorf("bad block"))
+			},
+			"server_error",
+			nil,
+		},
+		{
+			"ShouldFailInvalidSubjectToken",
+			[]string{oidc.GrantTypeTokenExchange},
+			oidc.ClientTokenExchangePolicy{SubjectClients: []string{"frontend"}},
+			url.Values{oidc.FormParameterSubjectToken: []string{"abc123"}, oidc.FormParameterSubjectTokenType: []string{oidc.TokenTypeIdentifierAccessToken}},
+			nil,
+			nil,
+			func(mock *mocks.MockStorage, strategy *mocks.MockAccessTokenStrategy) {
+				strategy.EXPECT().AccessTokenSignature(gomock.Any(), "abc123").Return("sig")
+				mock.EXPECT().LoadOAuth2Session(gomock.Any(), storage.OAuth2SessionTypeAccessToken, "sig").Return(subject("frontend", []string{oidc.ScopeOpenID}, nil, nil), nil)
+				strategy.EXPECT().ValidateAccessToken(gomock.Any(), gomock.Any(), "abc123").Return(oauthelia2.ErrTokenExpired)
+			},
+			"invalid_grant",
+			nil,
+		},
+		{
+			"ShouldFailSubjectNotPermitted",
+			[]string{oidc.GrantTypeTokenExchange},
+			oidc.ClientTokenExchangePolicy{},
+			url.Values{oidc.FormParameterSubjectToken: []string{"abc123"}, oidc.FormParameterSubjectTokenType: []string{oidc.TokenTypeIdentifierAccessToken}},
+			nil,
+			nil,
+			func(mock *mocks.MockStorage, strategy *mocks.MockAccessTokenStrategy) {
+				strategy.EXPECT().AccessTokenSignature(gomock.Any(), "abc123").Return("sig")
+				mock.EXPECT().LoadOAuth2Session(gomock.Any(), storage.OAuth2SessionTypeAccessToken, "sig").Return(subject("frontend", []string{oidc.ScopeOpenID}, []string{"frontend"}, nil), nil)
+				strategy.EXPECT().ValidateAccessToken(gomock.Any(), gomock.Any(), "abc123").Return(nil)
+			},
+			"invalid_grant",
+			nil,
+		},
+		{
+			"ShouldFailScopeNotGrantedToSubject",
+			[]string{oidc.GrantTypeTokenExchange},
+			oidc.ClientTokenExchangePolicy{SubjectClients: []string{"frontend"}, Scopes: []string{oidc.ScopeGroups, oidc.ScopeEmail}},
+			url.Values{oidc.FormParameterSubjectToken: []string{"abc123"}, oidc.FormParameterSubjectTokenType: []string{oidc.TokenTypeIdentifierAccessToken}},
+			[]string{oidc.ScopeEmail},
+			nil,
+			func(mock *mocks.MockStorage, strategy *mocks.MockAccessTokenStrategy) {
+				strategy.EXPECT().AccessTokenSignature(gomock.Any(), "abc123").Return("sig")
+				mock.EXPECT().LoadOAuth2Session(gomock.Any(), storage.OAuth2SessionTypeAccessToken, "sig").Return(subject("frontend", []string{oidc.ScopeOpenID, oidc.ScopeGroups}, nil, nil), nil)
+				strategy.EXPECT().ValidateAccessToken(gomock.Any(), gomock.Any(), "abc123").Return(nil)
+			},
+			"invalid_scope",
+			nil,
+		},
+		{
+			"ShouldFailScopeNotPermitted",
+			[]string{oidc.GrantTypeTokenExchange},
+			oidc.ClientTokenExchangePolicy{SubjectClients: []string{"frontend"}, Scopes: []string{oidc.ScopeGroups}},
+			url.Values{oidc.FormParameterSubjectToken: []string{"abc123"}, oidc.FormParameterSubjectTokenType: []string{oidc.TokenTypeIdentifierAccessToken}},
+			[]string{oidc.ScopeOpenID},
+			nil,
+			func(mock *mocks.MockStorage, strategy *mocks.MockAccessTokenStrategy) {
+				strategy.EXPECT().AccessTokenSignature(gomock.Any(), "abc123").Return("sig")
+				mock.EXPECT().LoadOAuth2Session(gomock.Any(), storage.OAuth2SessionTypeAccessToken, "sig").Return(subject("frontend", []string{oidc.ScopeOpenID, oidc.ScopeGroups}, nil, nil), nil)
+				strategy.EXPECT().ValidateAccessToken(gomock.Any(), gomock.Any(), "abc123").Return(nil)
+			},
+			"invalid_scope",
+			nil,
+		},
+		{
+			"ShouldFailAudienceNotPermitted",
+			[]string{oidc.GrantTypeTokenExchange},
+			oidc.ClientTokenExchangePolicy{SubjectClients: []string{"frontend"}, Audience: []string{"https://api.example.com"}},
+			url.Values{oidc.FormParameterSubjectToken: []string{"abc123"}, oidc.FormParameterSubjectTokenType: []string{oidc.TokenTypeIdentifierAccessToken}},
+			nil,
+			[]string{"https://other.example.com"},
+			func(mock *mocks.MockStorage, strategy *mocks.MockAccessTokenStrategy) {
+				strategy.EXPECT().AccessTokenSignature(gomock.Any(), "abc123").Return("sig")
+				mock.EXPECT().LoadOAuth2Session(gomock.Any(), storage.OAuth2SessionTypeAccessToken, "sig").Return(subject("frontend", []string{oidc.ScopeOpenID}, nil, nil), nil)
+				strategy.EXPECT().ValidateAccessToken(gomock.Any(), gomock.Any(), "abc123").Return(nil)
+			},
+			"invalid_target",
+			nil,
+		},
+		{
+			"ShouldSucceedDownScoped",
+			[]string{oidc.GrantTypeTokenExchange},
+			oidc.ClientTokenExchangePolicy{Audience: []string{"https://api.example.com"}, Scopes: []string{oidc.ScopeGroups}},
+			url.Values{oidc.FormParameterSubjectToken: []string{"abc123"}, oidc.FormParameterSubjectTokenType: []string{oidc.TokenTypeIdentifierAccessToken}},
+			nil,
+			nil,
+			func(mock *mocks.MockStorage, strategy *mocks.MockAccessTokenStrategy) {
+				strategy.EXPECT().AccessTokenSignature(gomock.Any(), "abc123").Return("sig")
+				mock.EXPECT().LoadOAuth2Session(gomock.Any(), storage.OAuth2SessionTypeAccessToken, "sig").Return(subject("frontend", []string{oidc.ScopeOpenID, oidc.ScopeGroups}, []string{myclient}, nil), nil)
+				strategy.EXPECT().ValidateAccessToken(gomock.Any(), gomock.Any(), "abc123").Return(nil)
+			},
+			"",
+			func(t *testing.T, requester oauthelia2.AccessRequester) {
+				assert.Equal(t, oauthelia2.Arguments{oidc.ScopeGroups}, requester.GetGrantedScopes())
+				assert.Equal(t, oauthelia2.Arguments{"https://api.example.com"}, requester.GetGrantedAudience())
+
+				session, ok := requester.GetSession().(*oidc.Session)
+				require.True(t, ok)
+
+				assert.Equal(t, "2c8a5d8d-5f3c-4a31-a2d6-2f2a4ae8d4a1", session.Subject)
+				assert.Equal(t, "john", session.Username)
+				assert.Equal(t, myclient, session.ClientID)
+				assert.Equal(t, oidc.NewActorClaim(myclient, nil), session.Actor)
+				assert.True(t, session.GetExpiresAt(oauthelia2.AccessToken).Before(time.Now().Add(time.Minute*30)))
+			},
+		},
+		{
+			"ShouldSucceedNestedActor",
+			[]string{oidc.GrantTypeTokenExchange},
+			oidc.ClientTokenExchangePolicy{SubjectClients: []string{"frontend"}, Audience: []string{"https://api.example.com"}, Scopes: []string{oidc.ScopeGroups}},
+			url.Values{oidc.FormParameterSubjectToken: []string{"abc123"}, oidc.FormParameterSubjectTokenType: []string{oidc.TokenTypeIdentifierAccessToken}},
+			[]string{oidc.ScopeGroups},
+			[]string{"https://api.example.com"},
+			func(mock *mocks.MockStorage, strategy *mocks.MockAccessTokenStrategy) {
+				strategy.EXPECT().AccessTokenSignature(gomock.Any(), "abc123").Return("sig")
+				mock.EXPECT().LoadOAuth2Session(gomock.Any(), storage.OAuth2SessionTypeAccessToken, "sig").Return(subject("frontend", []string{oidc.ScopeOpenID, oidc.ScopeGroups}, nil, oidc.NewActorClaim("gateway", nil)), nil)
+				strategy.EXPECT().ValidateAccessToken(gomock.Any(), gomock.Any(), "abc123").Return(nil)
+			},
+			"",
+			func(t *testing.T, requester oauthelia2.AccessRequester) {
+				session, ok := requester.GetSession().(*oidc.Session)
+				require.True(t, ok)
+
+				assert.Equal(t, myclient, session.Actor[oidc.ClaimSubject])
+				assert.Equal(t, map[string]any{oidc.ClaimSubject: "gateway", oidc.ClaimClientIdentifier: "gateway"}, session.Actor[oidc.ClaimActor])
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mock := mocks.NewMockStorage(ctrl)
+			strategy := mocks.NewMockAccessTokenStrategy(ctrl)
+
+			if tc.setup != nil {
+				tc.setup(mock, strategy)
+			}
+
+			handler := &oidc.RFC8693TokenExchangeGrantHandler{
+				Strategy: strategy,
+				Storage:  oidc.NewStore(config, mock),
+				Config:   &oidc.Config{},
+			}
+
+			requester := &oauthelia2.AccessRequest{
+				GrantTypes: oauthelia2.Arguments{oidc.GrantTypeTokenExchange},
+				Request: oauthelia2.Request{
+					Client: &oidc.RegisteredClient{
+						ID:                  myclient,
+						GrantTypes:          tc.grantTypes,
+						TokenExchangePolicy: tc.policy,
+					},
+					RequestedScope:    tc.scopes,
+					RequestedAudience: tc.audience,
+					Form:              tc.form,
+					Session:           oidc.NewSession(),
+				},
+			}
+
+			err := handler.HandleTokenEndpointRequest(context.Background(), requester)
+
+			if tc.err == "" {
+				require.NoError(t, err)
+				tc.expected(t, requester)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
@@ -31,6 +31,13 @@ func NewSession() (session *Session) {
 // NewSessionWithAuthorizeRequest uses details from an AuthorizeRequester to generate an OpenIDSession.
 func NewSessionWithAuthorizeRequest(ctx Context, issuer *url.URL, kid, username string, amr []string, extra map[string]any,
 	authTime time.Time, consent *model.OAuth2ConsentSession, requester oauthelia2.AuthorizeRequester) (session *Session) {
+	return NewSessionWithRequester(ctx, issuer, kid, username, amr, extra, authTime, consent, requester)
+}
+
+// NewSessionWithRequester uses details from a Requester to generate an OpenIDSession. This is used by flows which are
+// not initiated at the Authorization Endpoint such as the Device Authorization Grant.
+func NewSessionWithRequester(ctx Context, issuer *url.URL, kid, username string, amr []string, extra map[string]any,
+	authTime time.Time, consent *model.OAuth2ConsentSession, requester oauthelia2.Requester) (session *Session) {
 	if extra == nil {
 		extra = map[string]any{}
 	}
@@ -70,6 +77,23 @@ func NewSessionWithAuthorizeRequest(ctx Context, issuer *url.URL, kid, username
 	return session
 }
 
+// NewActorClaim returns the value of the 'act' claim for a token issued to the acting client via the Token Exchange
+// Grant, nesting the claim of the previous actor if the subject token was itself the result of a token exchange.
+//
+// See: https://datatracker.ietf.org/doc/html/rfc8693#section-4.1
+func NewActorClaim(clientID string, previous map[string]any) (actor map[string]any) {
+	actor = map[string]any{
+		ClaimSubject:          clientID,
+		ClaimClientIdentifier: clientID,
+	}
+
+	if len(previous) != 0 {
+		actor[ClaimActor] = previous
+	}
+
+	return actor
+}
+
 // Session holds OpenID Connect 1.0 Session information.
 type Session struct {
 	*openid.DefaultSession `json:"id_token"`
@@ -80,6 +104,7 @@ type Session struct {
 	ClientCredentials     bool           `json:"client_credentials"`
 	ExcludeNotBeforeClaim bool           `json:"exclude_nbf_claim"`
 	AllowedTopLevelClaims []string       `json:"allowed_top_level_claims"`
+	Actor                 map[string]any `json:"act,omitempty"`
 	Extra                 map[string]any `json:"extra"`
 }
 
@@ -113,7 +138,7 @@ func (s *Session) GetJWTClaims() jwt.JWTClaimsContainer {
 
 	for _, cl := range s.AllowedTopLevelClaims {
 		switch cl {
-		case ClaimJWTID, ClaimIssuer, ClaimSubject, ClaimAudience, ClaimExpirationTime, ClaimNotBefore, ClaimIssuedAt, ClaimClientIdentifier, ClaimScopeNonStandard, ClaimExtra:
+		case ClaimJWTID, ClaimIssuer, ClaimSubject, ClaimAudience, ClaimExpirationTime, ClaimNotBefore, ClaimIssuedAt, ClaimClientIdentifier, ClaimScopeNonStandard, ClaimExtra, ClaimActor:
 			continue
 		case ClaimAuthenticationMethodsReference:
 			amr = true
@@ -153,6 +178,10 @@ func (s *Session) GetJWTClaims() jwt.JWTClaimsContainer {
 		claims.Extra[ClaimClientIdentifier] = s.ClientID
 	}
 
+	if len(s.Actor) != 0 {
+		claims.Extra[ClaimActor] = s.Actor
+	}
+
 	return claims
 }
 
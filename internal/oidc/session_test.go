@@ -115,6 +115,11 @@ func TestSession_GetJWTClaims(t *testing.T) {
 			&oidc.Session{DefaultSession: openid.NewDefaultSession(), ClientID: abc},
 			&jwt.JWTClaims{Extra: map[string]any{oidc.ClaimClientIdentifier: abc}},
 		},
+		{
+			"ShouldIncludeActor",
+			&oidc.Session{DefaultSession: openid.NewDefaultSession(), ClientID: abc, Actor: oidc.NewActorClaim(abc, oidc.NewActorClaim("xyz", nil))},
+			&jwt.JWTClaims{Extra: map[string]any{oidc.ClaimClientIdentifier: abc, oidc.ClaimActor: map[string]any{oidc.ClaimSubject: abc, oidc.ClaimClientIdentifier: abc, oidc.ClaimActor: map[string]any{oidc.ClaimSubject: "xyz", oidc.ClaimClientIdentifier: "xyz"}}}},
+		},
 		{
 			"ShouldAllowTopLevelClaims",
 			&oidc.Session{DefaultSession: &openid.DefaultSession{
@@ -21,13 +21,27 @@ import (
 // NewStore returns a Store when provided with a schema.OpenIDConnect and storage.Provider.
 func NewStore(config *schema.IdentityProvidersOpenIDConnect, provider storage.Provider) (store *Store) {
 	store = &Store{
-		ClientStore: NewMemoryClientStore(config),
-		provider:    provider,
+		provider: provider,
+	}
+
+	if config.DynamicClientRegistration.Enable {
+		store.ClientStore = NewStorageClientStore(config, provider)
+	} else {
+		store.ClientStore = NewMemoryClientStore(config)
 	}
 
 	return store
 }
 
+// NewStorageClientStore returns a StorageClientStore when provided with a schema.OpenIDConnect and storage.Provider.
+func NewStorageClientStore(config *schema.IdentityProvidersOpenIDConnect, provider storage.Provider) (store *StorageClientStore) {
+	return &StorageClientStore{
+		MemoryClientStore: NewMemoryClientStore(config),
+		config:            config,
+		provider:          provider,
+	}
+}
+
 func NewMemoryClientStore(config *schema.IdentityProvidersOpenIDConnect) (store *MemoryClientStore) {
 	logger := logging.Logger()
 
@@ -55,6 +69,46 @@ func (s *MemoryClientStore) GetRegisteredClient(_ context.Context, id string) (c
 	return client, nil
 }
 
+// GetRegisteredClient returns a Client matching the provided id. Clients from the configuration take precedence over
+// dynamically registered clients.
+func (s *StorageClientStore) GetRegisteredClient(ctx context.Context, id string) (client Client, err error) {
+	if client, err = s.MemoryClientStore.GetRegisteredClient(ctx, id); err == nil {
+		return client, nil
+	}
+
+	var registered *model.OAuth2RegisteredClient
+
+	if registered, err = s.provider.LoadOAuth2RegisteredClient(ctx, id); err != nil {
+		if errors.Is(err, storage.ErrNoOAuth2RegisteredClient) {
+			return nil, oauthelia2.ErrInvalidClient.WithDebugf("Client with id '%s' does not appear to be a registered client.", id)
+		}
+
+		return nil, oauthelia2.ErrServerError.WithWrap(err).WithDebugf("Failed to load the client with id '%s' from the storage backend.", id)
+	}
+
+	if registered.Expired(s.now(ctx)) {
+		return nil, oauthelia2.ErrInvalidClient.WithDebugf("Client with id '%s' is a dynamically registered client which has expired.", id)
+	}
+
+	if client, err = NewRegisteredClientFromModel(registered, s.config); err != nil {
+		return nil, oauthelia2.ErrServerError.WithWrap(err).WithDebugf("Failed to decode the client with id '%s'.", id)
+	}
+
+	return client, nil
+}
+
+func (s *StorageClientStore) now(ctx context.Context) time.Time {
+	if octx, ok := ctx.Value(model.CtxKeyAutheliaCtx).(Context); ok {
+		return octx.GetClock().Now()
+	}
+
+	if octx, ok := ctx.(Context); ok {
+		return octx.GetClock().Now()
+	}
+
+	return time.Now()
+}
+
 // GenerateOpaqueUserID either retrieves or creates an opaque user id from a sectorID and username.
 func (s *Store) GenerateOpaqueUserID(ctx context.Context, sectorID, username string) (opaqueID *model.UserOpaqueIdentifier, err error) {
 	if opaqueID, err = s.provider.LoadUserOpaqueIdentifierBySignature(ctx, "openid", sectorID, username); err != nil {
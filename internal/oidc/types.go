@@ -7,6 +7,8 @@ import (
 	"time"
 
 	oauthelia2 "authelia.com/provider/oauth2"
+	"authelia.com/provider/oauth2/handler/oauth2"
+	"authelia.com/provider/oauth2/handler/openid"
 	fjwt "authelia.com/provider/oauth2/token/jwt"
 	"github.com/go-jose/go-jose/v4"
 	"github.com/golang-jwt/jwt/v5"
@@ -54,6 +56,89 @@ type MemoryClientStore struct {
 	clients map[string]Client
 }
 
+// StorageClientStore is an implementation of the ClientStore which stores the clients from the configuration in memory
+// and loads the dynamically registered clients from the storage.Provider.
+type StorageClientStore struct {
+	*MemoryClientStore
+
+	config   *schema.IdentityProvidersOpenIDConnect
+	provider storage.Provider
+}
+
+// ClientRegistrationMetadata represents the Client Metadata used with the OAuth 2.0 Dynamic Client Registration
+// Protocol and OAuth 2.0 Dynamic Client Registration Management Protocol.
+//
+// See: https://datatracker.ietf.org/doc/html/rfc7591#section-2
+type ClientRegistrationMetadata struct {
+	ClientName                         string   `json:"client_name,omitempty"`
+	RedirectURIs                       []string `json:"redirect_uris,omitempty"`
+	RequestURIs                        []string `json:"request_uris,omitempty"`
+	GrantTypes                         []string `json:"grant_types,omitempty"`
+	ResponseTypes                      []string `json:"response_types,omitempty"`
+	Scope                              string   `json:"scope,omitempty"`
+	SectorIdentifierURI                string   `json:"sector_identifier_uri,omitempty"`
+	JSONWebKeysURI                     string   `json:"jwks_uri,omitempty"`
+	TokenEndpointAuthMethod            string   `json:"token_endpoint_auth_method,omitempty"`
+	TokenEndpointAuthSigningAlg        string   `json:"token_endpoint_auth_signing_alg,omitempty"`
+	IDTokenSignedResponseAlg           string   `json:"id_token_signed_response_alg,omitempty"`
+	UserinfoSignedResponseAlg          string   `json:"userinfo_signed_response_alg,omitempty"`
+	RequestObjectSigningAlg            string   `json:"request_object_signing_alg,omitempty"`
+	AuthorizationSignedResponseAlg     string   `json:"authorization_signed_response_alg,omitempty"`
+	RequirePushedAuthorizationRequests bool     `json:"require_pushed_authorization_requests,omitempty"`
+	PostLogoutRedirectURIs             []string `json:"post_logout_redirect_uris,omitempty"`
+	FrontChannelLogoutURI              string   `json:"frontchannel_logout_uri,omitempty"`
+	FrontChannelLogoutSessionRequired  bool     `json:"frontchannel_logout_session_required,omitempty"`
+	BackChannelLogoutURI               string   `json:"backchannel_logout_uri,omitempty"`
+	BackChannelLogoutSessionRequired   bool     `json:"backchannel_logout_session_required,omitempty"`
+}
+
+// ClientRegistrationRecord represents the persisted form of a dynamically registered client. In addition to the Client
+// Metadata it holds the values of the validated client configuration which can't be represented by the Client Metadata
+// so the client is loaded with the same configuration it was registered with.
+type ClientRegistrationRecord struct {
+	ClientRegistrationMetadata
+
+	Configuration *ClientRegistrationConfiguration `json:"authelia_configuration,omitempty"`
+}
+
+// ClientRegistrationConfiguration represents the values of the validated configuration of a dynamically registered
+// client which are not part of the Client Metadata.
+type ClientRegistrationConfiguration struct {
+	Audience                       []string `json:"audience,omitempty"`
+	ResponseModes                  []string `json:"response_modes,omitempty"`
+	RequestedAudienceMode          string   `json:"requested_audience_mode,omitempty"`
+	RequirePKCE                    bool     `json:"require_pkce,omitempty"`
+	PKCEChallengeMethod            string   `json:"pkce_challenge_method,omitempty"`
+	AccessTokenSignedResponseAlg   string   `json:"access_token_signed_response_alg,omitempty"`
+	IntrospectionSignedResponseAlg string   `json:"introspection_signed_response_alg,omitempty"`
+}
+
+// ClientRegistrationResponse represents the Client Information Response returned by the OAuth 2.0 Dynamic Client
+// Registration endpoint and the Client Configuration endpoint.
+//
+// See: https://datatracker.ietf.org/doc/html/rfc7591#section-3.2.1 and
+// https://datatracker.ietf.org/doc/html/rfc7592#section-3
+type ClientRegistrationResponse struct {
+	ClientID                string `json:"client_id"`
+	ClientSecret            string `json:"client_secret,omitempty"`
+	ClientIDIssuedAt        int64  `json:"client_id_issued_at"`
+	ClientSecretExpiresAt   int64  `json:"client_secret_expires_at"`
+	RegistrationAccessToken string `json:"registration_access_token,omitempty"`
+	RegistrationClientURI   string `json:"registration_client_uri"`
+
+	ClientRegistrationMetadata
+}
+
+// ClientRegistrationUpdateRequest represents the Client Update Request sent to the Client Configuration endpoint.
+//
+// See: https://datatracker.ietf.org/doc/html/rfc7592#section-2.2
+type ClientRegistrationUpdateRequest struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret,omitempty"`
+
+	ClientRegistrationMetadata
+}
+
 // RegisteredClient represents a registered client.
 type RegisteredClient struct {
 	ID                   string
@@ -108,10 +193,17 @@ type RegisteredClient struct {
 
 	ConsentPolicy         ClientConsentPolicy
 	RequestedAudienceMode ClientRequestedAudienceMode
+	TokenExchangePolicy   ClientTokenExchangePolicy
 
 	RequestURIs    []string
 	JSONWebKeys    *jose.JSONWebKeySet
 	JSONWebKeysURI *url.URL
+
+	PostLogoutRedirectURIs            []string
+	FrontChannelLogoutURI             *url.URL
+	FrontChannelLogoutSessionRequired bool
+	BackChannelLogoutURI              *url.URL
+	BackChannelLogoutSessionRequired  bool
 }
 
 // Client represents the internal client definitions.
@@ -149,6 +241,13 @@ type Client interface {
 
 	GetConsentResponseBody(consent *model.OAuth2ConsentSession) (body ConsentGetResponseBody)
 	GetConsentPolicy() ClientConsentPolicy
+	GetTokenExchangePolicy() ClientTokenExchangePolicy
+
+	GetPostLogoutRedirectURIs() (uris []string)
+	GetFrontChannelLogoutURI() (uri *url.URL)
+	GetFrontChannelLogoutSessionRequired() (required bool)
+	GetBackChannelLogoutURI() (uri *url.URL)
+	GetBackChannelLogoutSessionRequired() (required bool)
 	IsAuthenticationLevelSufficient(level authentication.Level, subject authorization.Subject) (sufficient bool)
 	GetAuthorizationPolicyRequiredLevel(subject authorization.Subject) (level authorization.Level)
 	GetAuthorizationPolicy() (policy ClientAuthorizationPolicy)
@@ -191,6 +290,15 @@ type IDTokenClaimsSession interface {
 	GetIDTokenClaims() *fjwt.IDTokenClaims
 }
 
+// IDTokenHintClaims represents the claims of a validated 'id_token_hint' used to identify the End-User and the client
+// during RP-Initiated Logout.
+type IDTokenHintClaims struct {
+	Issuer    string
+	Subject   string
+	SessionID string
+	Audience  []string
+}
+
 // Configurator is an internal extension to the oauthelia2.Configurator.
 type Configurator interface {
 	oauthelia2.Configurator
@@ -221,6 +329,12 @@ type UserDetailer interface {
 	GetGroups() (groups []string)
 	GetDisplayName() (name string)
 	GetEmails() (emails []string)
+	GetGivenName() (name string)
+	GetFamilyName() (name string)
+	GetPhoneNumber() (number string)
+	GetLocale() (locale string)
+	GetPicture() (picture string)
+	GetExtra() (extra map[string]any)
 }
 
 // ConsentGetResponseBody schema of the response body of the consent GET endpoint.
@@ -245,6 +359,46 @@ type ConsentPostResponseBody struct {
 	RedirectURI string `json:"redirect_uri"`
 }
 
+// DeviceCodeUserVerificationPostRequestBody schema of the request body of the device code user verification POST
+// endpoint.
+type DeviceCodeUserVerificationPostRequestBody struct {
+	UserCode string `json:"user_code"`
+	Consent  bool   `json:"consent"`
+}
+
+// RFC8628DeviceAuthorizeResponse represents the Device Authorization Response.
+//
+// See: https://datatracker.ietf.org/doc/html/rfc8628#section-3.2
+type RFC8628DeviceAuthorizeResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int64  `json:"expires_in"`
+	Interval                int64  `json:"interval,omitempty"`
+}
+
+// RFC8628DeviceCodeGrantHandler handles the Device Access Token Request for the Device Authorization Grant at the
+// Token Endpoint.
+//
+// See: https://datatracker.ietf.org/doc/html/rfc8628#section-3.4
+type RFC8628DeviceCodeGrantHandler struct {
+	Strategy            oauth2.CoreStrategy
+	Storage             *Store
+	IDTokenHandleHelper *openid.IDTokenHandleHelper
+	Config              *Config
+}
+
+// RFC8693TokenExchangeGrantHandler handles the Token Exchange Request for the Token Exchange Grant at the Token
+// Endpoint.
+//
+// See: https://datatracker.ietf.org/doc/html/rfc8693#section-2
+type RFC8693TokenExchangeGrantHandler struct {
+	Strategy oauth2.AccessTokenStrategy
+	Storage  *Store
+	Config   *Config
+}
+
 /*
 CommonDiscoveryOptions represents the discovery options used in both OAuth 2.0 and OpenID Connect.
 See Also:
@@ -991,4 +1145,7 @@ var (
 	_ oauthelia2.RequestedAudienceImplicitClient                   = (*RegisteredClient)(nil)
 	_ oauthelia2.JWTProfileClient                                  = (*RegisteredClient)(nil)
 	_ oauthelia2.IntrospectionJWTResponseClient                    = (*RegisteredClient)(nil)
+
+	_ oauthelia2.TokenEndpointHandler = (*RFC8628DeviceCodeGrantHandler)(nil)
+	_ oauthelia2.TokenEndpointHandler = (*RFC8693TokenExchangeGrantHandler)(nil)
 )
@@ -2,8 +2,20 @@ package regulation
 
 import "fmt"
 
-// ErrUserIsBanned user is banned error message.
-var ErrUserIsBanned = fmt.Errorf("user is banned")
+var (
+	// ErrUserIsBanned user is banned error message.
+	ErrUserIsBanned = fmt.Errorf("user is banned")
+
+	// ErrIPIsBanned remote ip is banned error message.
+	ErrIPIsBanned = fmt.Errorf("remote ip is banned")
+
+	// ErrSubnetIsBanned subnet of the remote ip is banned error message.
+	ErrSubnetIsBanned = fmt.Errorf("subnet of the remote ip is banned")
+)
+
+const (
+	banReasonAutomatic = "Exceeded the maximum number of failed authentication attempts"
+)
 
 const (
 	// AuthType1FA is the string representing an auth log for first-factor authentication.
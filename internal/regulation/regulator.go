@@ -1,7 +1,8 @@
 package regulation
 
 import (
-	"context"
+	"database/sql"
+	"net"
 	"strings"
 	"time"
 
@@ -14,70 +15,261 @@ import (
 // NewRegulator create a regulator instance.
 func NewRegulator(config schema.Regulation, store storage.RegulatorProvider, clock clock.Provider) *Regulator {
 	return &Regulator{
-		enabled: config.MaxRetries > 0,
-		store:   store,
-		clock:   clock,
-		config:  config,
+		enabled:  config.MaxRetries > 0 || config.IP.MaxRetries > 0 || config.Subnet.MaxRetries > 0,
+		store:    store,
+		clock:    clock,
+		config:   config,
+		networks: parseNetworks(config.AllowedNetworks),
 	}
 }
 
 // Mark an authentication attempt.
-// We split Mark and Regulate in order to avoid timing attacks.
-func (r *Regulator) Mark(ctx Context, successful, banned bool, username, requestURI, requestMethod, authType string) error {
+// We split Mark and Regulate in order to avoid timing attacks. The automatic bans which are the result of a failed
+// attempt are returned.
+func (r *Regulator) Mark(ctx Context, successful, banned bool, username, requestURI, requestMethod, authType string) (bans []model.RegulationBan, err error) {
 	ctx.RecordAuthn(successful, banned, strings.ToLower(authType))
 
-	return r.store.AppendAuthenticationLog(ctx, model.AuthenticationAttempt{
+	ip := ctx.RemoteIP()
+
+	attempt := model.AuthenticationAttempt{
 		Time:          r.clock.Now(),
 		Successful:    successful,
 		Banned:        banned,
 		Username:      username,
 		Type:          authType,
-		RemoteIP:      model.NewNullIP(ctx.RemoteIP()),
+		RemoteIP:      model.NewNullIP(ip),
 		RequestURI:    requestURI,
 		RequestMethod: requestMethod,
-	})
+	}
+
+	if r.config.Subnet.MaxRetries > 0 && ip != nil {
+		attempt.RemoteSubnet = sql.NullString{String: r.subnet(ip), Valid: true}
+	}
+
+	if err = r.store.AppendAuthenticationLog(ctx, attempt); err != nil {
+		return nil, err
+	}
+
+	if successful || banned || !r.isCounted(ip) {
+		return nil, nil
+	}
+
+	// Regulating after a failed attempt saves the bans which are the result of this attempt.
+	if ban, created := r.regulateUser(ctx, username, attempt.Time, true); created {
+		bans = append(bans, *ban)
+	}
+
+	if ip != nil {
+		if ban, created, _ := r.regulateRemoteIP(ctx, ip, attempt.Time, true); created {
+			bans = append(bans, *ban)
+		}
+	}
+
+	return bans, nil
 }
 
 // Regulate the authentication attempts for a given user.
-// This method returns ErrUserIsBanned if the user is banned along with the time until when the user is banned.
-func (r *Regulator) Regulate(ctx context.Context, username string) (time.Time, error) {
-	// If there is regulation configuration, no regulation applies.
-	if !r.enabled {
+// This method returns ErrUserIsBanned if the user is banned along with the time until when the user is banned. The
+// time is zero if the user is permanently banned.
+func (r *Regulator) Regulate(ctx Context, username string) (time.Time, error) {
+	if ban, _ := r.regulateUser(ctx, username, r.clock.Now(), r.isCounted(ctx.RemoteIP())); ban != nil {
+		return ban.ExpiresAt.Time, ErrUserIsBanned
+	}
+
+	return time.Time{}, nil
+}
+
+// RegulateRemoteIP regulates the authentication attempts from the remote ip of a request and its subnet regardless of
+// the user. This method returns ErrIPIsBanned or ErrSubnetIsBanned if the remote ip is banned along with the time until
+// when the remote ip is banned. The time is zero if the remote ip is permanently banned.
+func (r *Regulator) RegulateRemoteIP(ctx Context) (time.Time, error) {
+	ip := ctx.RemoteIP()
+
+	if ip == nil {
 		return time.Time{}, nil
 	}
 
-	attempts, err := r.store.LoadAuthenticationLogs(ctx, username, r.clock.Now().Add(-r.config.BanTime), 10, 0)
+	now, counted := r.clock.Now(), r.isCounted(ip)
+
+	ban, _, err := r.regulateRemoteIP(ctx, ip, now, counted)
 	if err != nil {
-		return time.Time{}, nil
+		return ban.ExpiresAt.Time, err
+	}
+
+	if ban = r.regulateRemoteIPNetworks(ctx, ip, now, counted); ban != nil {
+		return ban.ExpiresAt.Time, ErrSubnetIsBanned
 	}
 
-	latestFailedAttempts := make([]model.AuthenticationAttempt, 0, r.config.MaxRetries)
+	return time.Time{}, nil
+}
 
-	for _, attempt := range attempts {
-		if attempt.Successful || len(latestFailedAttempts) >= r.config.MaxRetries {
-			// We stop appending failed attempts once we find the first successful attempts or we reach
-			// the configured number of retries, meaning the user is already banned.
-			break
-		} else {
-			latestFailedAttempts = append(latestFailedAttempts, attempt)
+func (r *Regulator) regulateUser(ctx Context, username string, now time.Time, counted bool) (ban *model.RegulationBan, created bool) {
+	return r.regulate(ctx, model.RegulationBanKindUser, username, now, counted, r.config.MaxRetries, r.config.FindTime, r.config.BanTime, func(fromDate time.Time) ([]model.AuthenticationAttempt, error) {
+		attempts, err := r.store.LoadAuthenticationLogs(ctx, username, fromDate, 10, 0)
+		if err != nil {
+			return nil, err
 		}
+
+		// Only the failed attempts since the latest successful attempt count towards a ban.
+		for i, attempt := range attempts {
+			if attempt.Successful {
+				return attempts[:i], nil
+			}
+		}
+
+		return attempts, nil
+	})
+}
+
+func (r *Regulator) regulateRemoteIP(ctx Context, ip net.IP, now time.Time, counted bool) (ban *model.RegulationBan, created bool, err error) {
+	if ban, created = r.regulate(ctx, model.RegulationBanKindIP, ip.String(), now, counted, r.config.IP.MaxRetries, r.config.IP.FindTime, r.config.IP.BanTime, func(fromDate time.Time) ([]model.AuthenticationAttempt, error) {
+		return r.store.LoadFailedAuthenticationLogsByRemoteIP(ctx, ip, fromDate, r.config.IP.MaxRetries, 0)
+	}); ban != nil {
+		return ban, created, ErrIPIsBanned
 	}
 
-	// If the number of failed attempts within the ban time is less than the max number of retries
-	// then the user is not banned.
-	if len(latestFailedAttempts) < r.config.MaxRetries {
-		return time.Time{}, nil
+	subnet := r.subnet(ip)
+
+	if ban, created = r.regulate(ctx, model.RegulationBanKindSubnet, subnet, now, counted, r.config.Subnet.MaxRetries, r.config.Subnet.FindTime, r.config.Subnet.BanTime, func(fromDate time.Time) ([]model.AuthenticationAttempt, error) {
+		return r.store.LoadFailedAuthenticationLogsByRemoteSubnet(ctx, subnet, fromDate, r.config.Subnet.MaxRetries, 0)
+	}); ban != nil {
+		return ban, created, ErrSubnetIsBanned
 	}
 
-	// Now we compute the time between the latest attempt and the MaxRetry-th one. If it's
-	// within the FindTime then it means that the user has been banned.
-	durationBetweenLatestAttempts := latestFailedAttempts[0].Time.Sub(
-		latestFailedAttempts[r.config.MaxRetries-1].Time)
+	return nil, false, nil
+}
 
-	if durationBetweenLatestAttempts < r.config.FindTime {
-		bannedUntil := latestFailedAttempts[0].Time.Add(r.config.BanTime)
-		return bannedUntil, ErrUserIsBanned
+// regulateRemoteIPNetworks returns the active subnet ban which contains the ip if there is one. The subnet bans are
+// looked up by their value using the configured prefix lengths, so this matches the subnet bans with other prefix
+// lengths such as the manual bans of a larger network.
+func (r *Regulator) regulateRemoteIPNetworks(ctx Context, ip net.IP, now time.Time, counted bool) (ban *model.RegulationBan) {
+	bans, err := r.store.LoadActiveRegulationBansByKind(ctx, model.RegulationBanKindSubnet, now)
+	if err != nil {
+		return nil
 	}
 
-	return time.Time{}, nil
+	for i := range bans {
+		if !bans[i].IsActive(now) || (!counted && bans[i].Source != model.RegulationBanSourceManual) {
+			continue
+		}
+
+		if _, network, err := net.ParseCIDR(bans[i].Value); err == nil && network.Contains(ip) {
+			return &bans[i]
+		}
+	}
+
+	return nil
+}
+
+// regulate determines if a user, remote ip, or subnet is banned and returns the ban if it is. It's banned if it has an
+// active manual ban, or when counted is true if it has an active automatic ban or the failed attempts which occurred
+// after the latest revoked ban result in a ban in which case an automatic ban is saved and created is true if it was
+// saved successfully. The failed attempts are only considered when maxRetries is greater than 0.
+func (r *Regulator) regulate(ctx Context, kind, value string, now time.Time, counted bool, maxRetries int, findTime, banTime time.Duration, load func(fromDate time.Time) ([]model.AuthenticationAttempt, error)) (ban *model.RegulationBan, created bool) {
+	fromDate := now.Add(-banTime)
+
+	if bans, err := r.store.LoadRegulationBans(ctx, kind, value, now, fromDate); err == nil {
+		for i := range bans {
+			switch {
+			case bans[i].IsActive(now) && (counted || bans[i].Source == model.RegulationBanSourceManual):
+				return &bans[i], false
+			case bans[i].RevokedAt.Valid && bans[i].RevokedAt.Time.After(fromDate):
+				fromDate = bans[i].RevokedAt.Time
+			}
+		}
+	}
+
+	if !counted || maxRetries <= 0 {
+		return nil, false
+	}
+
+	attempts, err := load(fromDate)
+	if err != nil {
+		return nil, false
+	}
+
+	bannedUntil, banned := isBanned(attempts, maxRetries, findTime, banTime)
+	if !banned {
+		return nil, false
+	}
+
+	ban = &model.RegulationBan{
+		CreatedAt: now,
+		ExpiresAt: sql.NullTime{Time: bannedUntil, Valid: true},
+		Kind:      kind,
+		Value:     value,
+		Source:    model.RegulationBanSourceAutomatic,
+		Reason:    banReasonAutomatic,
+	}
+
+	return ban, r.store.SaveRegulationBan(ctx, *ban) == nil
+}
+
+// isCounted returns true if the failed attempts from the ip count towards the automatic bans, which is the case when the
+// regulation is enabled and the ip is not within the allowed networks. The manual bans are enforced regardless.
+func (r *Regulator) isCounted(ip net.IP) bool {
+	return r.enabled && !r.isAllowed(ip)
+}
+
+func (r *Regulator) isAllowed(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range r.networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// subnet returns the subnet of the ip in CIDR notation using the configured prefix lengths.
+func (r *Regulator) subnet(ip net.IP) string {
+	var mask net.IPMask
+
+	if ip4 := ip.To4(); ip4 != nil {
+		ip, mask = ip4, net.CIDRMask(r.config.Subnet.IPv4Prefix, net.IPv4len*8)
+	} else {
+		mask = net.CIDRMask(r.config.Subnet.IPv6Prefix, net.IPv6len*8)
+	}
+
+	network := &net.IPNet{IP: ip.Mask(mask), Mask: mask}
+
+	return network.String()
+}
+
+// isBanned determines if the failed attempts which are ordered from the latest to the oldest result in a ban, which is
+// the case when the latest maxRetries attempts occurred within the findTime.
+func isBanned(attempts []model.AuthenticationAttempt, maxRetries int, findTime, banTime time.Duration) (bannedUntil time.Time, banned bool) {
+	if len(attempts) < maxRetries {
+		return time.Time{}, false
+	}
+
+	if attempts[0].Time.Sub(attempts[maxRetries-1].Time) < findTime {
+		return attempts[0].Time.Add(banTime), true
+	}
+
+	return time.Time{}, false
+}
+
+func parseNetworks(values []string) (networks []*net.IPNet) {
+	for _, value := range values {
+		if !strings.Contains(value, "/") {
+			if ip := net.ParseIP(value); ip != nil {
+				if ip.To4() != nil {
+					value += "/32"
+				} else {
+					value += "/128"
+				}
+			}
+		}
+
+		if _, network, err := net.ParseCIDR(value); err == nil {
+			networks = append(networks, network)
+		}
+	}
+
+	return networks
 }
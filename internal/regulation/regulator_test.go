@@ -1,6 +1,7 @@
 package regulation_test
 
 import (
+	"database/sql"
 	"fmt"
 	"net"
 	"testing"
@@ -29,6 +30,7 @@ func (s *RegulatorSuite) SetupTest() {
 		MaxRetries: 3,
 		BanTime:    time.Second * 180,
 		FindTime:   time.Second * 30,
+		Subnet:     schema.RegulationSubnet{IPv4Prefix: 24, IPv6Prefix: 64},
 	}
 
 	s.mock.Ctx.Request.Header.Set(fasthttp.HeaderXForwardedFor, "127.0.0.1")
@@ -52,12 +54,16 @@ func (s *RegulatorSuite) TestShouldMark() {
 		RequestMethod: fasthttp.MethodGet,
 	})
 
-	s.NoError(regulator.Mark(s.mock.Ctx, true, false, "john", "https://google.com", fasthttp.MethodGet, "1fa"))
+	bans, err := regulator.Mark(s.mock.Ctx, true, false, "john", "https://google.com", fasthttp.MethodGet, "1fa")
+
+	s.NoError(err)
+	s.Empty(bans)
 }
 
 func (s *RegulatorSuite) TestShouldHandleRegulateError() {
 	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
 
+	s.mock.StorageMock.EXPECT().LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindUser, "john", s.mock.Clock.Now(), s.mock.Clock.Now().Add(-s.mock.Ctx.Configuration.Regulation.BanTime)).Return(nil, nil)
 	s.mock.StorageMock.EXPECT().LoadAuthenticationLogs(s.mock.Ctx, "john", s.mock.Clock.Now().Add(-s.mock.Ctx.Configuration.Regulation.BanTime), 10, 0).Return(nil, fmt.Errorf("failed"))
 
 	until, err := regulator.Regulate(s.mock.Ctx, "john")
@@ -75,6 +81,10 @@ func (s *RegulatorSuite) TestShouldNotThrowWhenUserIsLegitimate() {
 		},
 	}
 
+	s.mock.StorageMock.EXPECT().
+		LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindUser, "john", gomock.Any(), gomock.Any()).
+		Return(nil, nil)
+
 	s.mock.StorageMock.EXPECT().
 		LoadAuthenticationLogs(s.mock.Ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
 		Return(attemptsInDB, nil)
@@ -106,6 +116,10 @@ func (s *RegulatorSuite) TestShouldNotThrowWhenFailedAuthenticationNotInFindTime
 		},
 	}
 
+	s.mock.StorageMock.EXPECT().
+		LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindUser, "john", gomock.Any(), gomock.Any()).
+		Return(nil, nil)
+
 	s.mock.StorageMock.EXPECT().
 		LoadAuthenticationLogs(s.mock.Ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
 		Return(attemptsInDB, nil)
@@ -142,10 +156,18 @@ func (s *RegulatorSuite) TestShouldBanUserIfLatestAttemptsAreWithinFinTime() {
 		},
 	}
 
+	s.mock.StorageMock.EXPECT().
+		LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindUser, "john", gomock.Any(), gomock.Any()).
+		Return(nil, nil)
+
 	s.mock.StorageMock.EXPECT().
 		LoadAuthenticationLogs(s.mock.Ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
 		Return(attemptsInDB, nil)
 
+	s.mock.StorageMock.EXPECT().
+		SaveRegulationBan(s.mock.Ctx, gomock.Any()).
+		Return(nil)
+
 	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
 
 	_, err := regulator.Regulate(s.mock.Ctx, "john")
@@ -175,10 +197,18 @@ func (s *RegulatorSuite) TestShouldCheckUserIsStillBanned() {
 		},
 	}
 
+	s.mock.StorageMock.EXPECT().
+		LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindUser, "john", gomock.Any(), gomock.Any()).
+		Return(nil, nil)
+
 	s.mock.StorageMock.EXPECT().
 		LoadAuthenticationLogs(s.mock.Ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
 		Return(attemptsInDB, nil)
 
+	s.mock.StorageMock.EXPECT().
+		SaveRegulationBan(s.mock.Ctx, gomock.Any()).
+		Return(nil)
+
 	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
 
 	_, err := regulator.Regulate(s.mock.Ctx, "john")
@@ -199,6 +229,10 @@ func (s *RegulatorSuite) TestShouldCheckUserIsNotYetBanned() {
 		},
 	}
 
+	s.mock.StorageMock.EXPECT().
+		LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindUser, "john", gomock.Any(), gomock.Any()).
+		Return(nil, nil)
+
 	s.mock.StorageMock.EXPECT().
 		LoadAuthenticationLogs(s.mock.Ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
 		Return(attemptsInDB, nil)
@@ -231,6 +265,10 @@ func (s *RegulatorSuite) TestShouldCheckUserWasAboutToBeBanned() {
 		},
 	}
 
+	s.mock.StorageMock.EXPECT().
+		LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindUser, "john", gomock.Any(), gomock.Any()).
+		Return(nil, nil)
+
 	s.mock.StorageMock.EXPECT().
 		LoadAuthenticationLogs(s.mock.Ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
 		Return(attemptsInDB, nil)
@@ -267,6 +305,10 @@ func (s *RegulatorSuite) TestShouldCheckRegulationHasBeenResetOnSuccessfulAttemp
 		},
 	}
 
+	s.mock.StorageMock.EXPECT().
+		LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindUser, "john", gomock.Any(), gomock.Any()).
+		Return(nil, nil)
+
 	s.mock.StorageMock.EXPECT().
 		LoadAuthenticationLogs(s.mock.Ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
 		Return(attemptsInDB, nil)
@@ -302,10 +344,19 @@ func (s *RegulatorSuite) TestShouldHaveRegulatorDisabled() {
 		},
 	}
 
+	s.mock.StorageMock.EXPECT().
+		LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindUser, "john", gomock.Any(), gomock.Any()).
+		Return(nil, nil).
+		Times(2)
+
 	s.mock.StorageMock.EXPECT().
 		LoadAuthenticationLogs(s.mock.Ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
 		Return(attemptsInDB, nil)
 
+	s.mock.StorageMock.EXPECT().
+		SaveRegulationBan(s.mock.Ctx, gomock.Any()).
+		Return(nil)
+
 	// Check Disabled Functionality.
 	config := schema.Regulation{
 		MaxRetries: 0,
@@ -328,3 +379,642 @@ func (s *RegulatorSuite) TestShouldHaveRegulatorDisabled() {
 	_, err = regulator.Regulate(s.mock.Ctx, "john")
 	assert.Equal(s.T(), regulation.ErrUserIsBanned, err)
 }
+
+func (s *RegulatorSuite) TestShouldMarkRemoteSubnet() {
+	s.mock.Ctx.Configuration.Regulation.Subnet = schema.RegulationSubnet{MaxRetries: 10, FindTime: time.Minute, BanTime: time.Minute * 5, IPv4Prefix: 24, IPv6Prefix: 64}
+	s.mock.Ctx.Request.Header.Set(fasthttp.HeaderXForwardedFor, "192.168.1.10")
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	s.mock.StorageMock.EXPECT().AppendAuthenticationLog(s.mock.Ctx, model.AuthenticationAttempt{
+		Time:          s.mock.Clock.Now(),
+		Successful:    false,
+		Banned:        false,
+		Username:      "john",
+		Type:          "1fa",
+		RemoteIP:      model.NewNullIP(net.ParseIP("192.168.1.10")),
+		RemoteSubnet:  sql.NullString{String: "192.168.1.0/24", Valid: true},
+		RequestURI:    "https://google.com",
+		RequestMethod: fasthttp.MethodGet,
+	})
+
+	s.mock.StorageMock.EXPECT().LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindUser, "john", gomock.Any(), gomock.Any()).Return(nil, nil)
+	s.mock.StorageMock.EXPECT().LoadAuthenticationLogs(s.mock.Ctx, "john", gomock.Any(), 10, 0).Return(nil, nil)
+	s.mock.StorageMock.EXPECT().LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindIP, "192.168.1.10", gomock.Any(), gomock.Any()).Return(nil, nil)
+	s.mock.StorageMock.EXPECT().LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindSubnet, "192.168.1.0/24", gomock.Any(), gomock.Any()).Return(nil, nil)
+	s.mock.StorageMock.EXPECT().LoadFailedAuthenticationLogsByRemoteSubnet(s.mock.Ctx, "192.168.1.0/24", gomock.Any(), 10, 0).Return(nil, nil)
+
+	bans, err := regulator.Mark(s.mock.Ctx, false, false, "john", "https://google.com", fasthttp.MethodGet, "1fa")
+
+	s.NoError(err)
+	s.Empty(bans)
+}
+
+func (s *RegulatorSuite) TestShouldNotBanUserFromAllowedNetwork() {
+	s.mock.Ctx.Configuration.Regulation.AllowedNetworks = []string{"10.0.0.0/8", "127.0.0.1"}
+
+	s.mock.StorageMock.EXPECT().
+		LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindUser, "john", s.mock.Clock.Now(), s.mock.Clock.Now().Add(-s.mock.Ctx.Configuration.Regulation.BanTime)).
+		Return([]model.RegulationBan{
+			{ExpiresAt: sql.NullTime{Time: s.mock.Clock.Now().Add(time.Hour), Valid: true}, Kind: model.RegulationBanKindUser, Value: "john", Source: model.RegulationBanSourceAutomatic},
+		}, nil)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	until, err := regulator.Regulate(s.mock.Ctx, "john")
+
+	s.NoError(err)
+	s.Equal(time.Time{}, until)
+}
+
+func (s *RegulatorSuite) TestShouldBanUserWithActiveBan() {
+	s.mock.StorageMock.EXPECT().
+		LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindUser, "john", s.mock.Clock.Now(), s.mock.Clock.Now().Add(-s.mock.Ctx.Configuration.Regulation.BanTime)).
+		Return([]model.RegulationBan{
+			{ExpiresAt: sql.NullTime{Time: s.mock.Clock.Now().Add(time.Hour), Valid: true}, Kind: model.RegulationBanKindUser, Value: "john", Source: model.RegulationBanSourceManual},
+		}, nil)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	until, err := regulator.Regulate(s.mock.Ctx, "john")
+
+	s.ErrorIs(err, regulation.ErrUserIsBanned)
+	s.Equal(s.mock.Clock.Now().Add(time.Hour), until)
+}
+
+func (s *RegulatorSuite) TestShouldNotBanUserWhenAttemptsBeforeRevokedBan() {
+	revoked := s.mock.Clock.Now().Add(-time.Second * 5)
+
+	gomock.InOrder(
+		s.mock.StorageMock.EXPECT().
+			LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindUser, "john", s.mock.Clock.Now(), s.mock.Clock.Now().Add(-s.mock.Ctx.Configuration.Regulation.BanTime)).
+			Return([]model.RegulationBan{
+				{ExpiresAt: sql.NullTime{Time: s.mock.Clock.Now().Add(time.Minute), Valid: true}, RevokedAt: sql.NullTime{Time: revoked, Valid: true}, Kind: model.RegulationBanKindUser, Value: "john"},
+			}, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadAuthenticationLogs(s.mock.Ctx, "john", revoked, 10, 0).
+			Return([]model.AuthenticationAttempt{{Username: "john", Time: s.mock.Clock.Now().Add(-time.Second)}}, nil),
+	)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	until, err := regulator.Regulate(s.mock.Ctx, "john")
+
+	s.NoError(err)
+	s.Equal(time.Time{}, until)
+}
+
+func (s *RegulatorSuite) TestShouldSaveBanWhenMarkResultsInBan() {
+	s.mock.Ctx.Configuration.Regulation.MaxRetries = 1
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	gomock.InOrder(
+		s.mock.StorageMock.EXPECT().
+			AppendAuthenticationLog(s.mock.Ctx, gomock.Any()).
+			Return(nil),
+		s.mock.StorageMock.EXPECT().
+			LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindUser, "john", s.mock.Clock.Now(), s.mock.Clock.Now().Add(-s.mock.Ctx.Configuration.Regulation.BanTime)).
+			Return(nil, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadAuthenticationLogs(s.mock.Ctx, "john", s.mock.Clock.Now().Add(-s.mock.Ctx.Configuration.Regulation.BanTime), 10, 0).
+			Return([]model.AuthenticationAttempt{{Username: "john", Time: s.mock.Clock.Now()}}, nil),
+		s.mock.StorageMock.EXPECT().
+			SaveRegulationBan(s.mock.Ctx, model.RegulationBan{
+				CreatedAt: s.mock.Clock.Now(),
+				ExpiresAt: sql.NullTime{Time: s.mock.Clock.Now().Add(s.mock.Ctx.Configuration.Regulation.BanTime), Valid: true},
+				Kind:      model.RegulationBanKindUser,
+				Value:     "john",
+				Source:    model.RegulationBanSourceAutomatic,
+				Reason:    "Exceeded the maximum number of failed authentication attempts",
+			}).
+			Return(nil),
+		s.mock.StorageMock.EXPECT().
+			LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindIP, "127.0.0.1", s.mock.Clock.Now(), s.mock.Clock.Now()).
+			Return(nil, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindSubnet, "127.0.0.0/24", s.mock.Clock.Now(), s.mock.Clock.Now()).
+			Return(nil, nil),
+	)
+
+	bans, err := regulator.Mark(s.mock.Ctx, false, false, "john", "https://google.com", fasthttp.MethodGet, "1fa")
+
+	s.NoError(err)
+	s.Require().Len(bans, 1)
+	s.Equal(model.RegulationBanKindUser, bans[0].Kind)
+	s.Equal("john", bans[0].Value)
+	s.Equal(s.mock.Clock.Now().Add(s.mock.Ctx.Configuration.Regulation.BanTime), bans[0].ExpiresAt.Time)
+}
+
+func (s *RegulatorSuite) TestShouldReturnRemoteIPBanWhenMarkResultsInBan() {
+	s.mock.Ctx.Configuration.Regulation.IP = schema.RegulationIP{MaxRetries: 1, FindTime: time.Minute, BanTime: time.Minute * 5}
+	s.mock.Ctx.Request.Header.Set(fasthttp.HeaderXForwardedFor, "192.168.1.10")
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	gomock.InOrder(
+		s.mock.StorageMock.EXPECT().
+			AppendAuthenticationLog(s.mock.Ctx, gomock.Any()).
+			Return(nil),
+		s.mock.StorageMock.EXPECT().
+			LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindUser, "john", s.mock.Clock.Now(), s.mock.Clock.Now().Add(-s.mock.Ctx.Configuration.Regulation.BanTime)).
+			Return(nil, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadAuthenticationLogs(s.mock.Ctx, "john", s.mock.Clock.Now().Add(-s.mock.Ctx.Configuration.Regulation.BanTime), 10, 0).
+			Return(nil, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindIP, "192.168.1.10", s.mock.Clock.Now(), s.mock.Clock.Now().Add(-time.Minute*5)).
+			Return(nil, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadFailedAuthenticationLogsByRemoteIP(s.mock.Ctx, net.ParseIP("192.168.1.10"), s.mock.Clock.Now().Add(-time.Minute*5), 1, 0).
+			Return([]model.AuthenticationAttempt{{Time: s.mock.Clock.Now()}}, nil),
+		s.mock.StorageMock.EXPECT().
+			SaveRegulationBan(s.mock.Ctx, gomock.Any()).
+			Return(fmt.Errorf("failed")),
+	)
+
+	bans, err := regulator.Mark(s.mock.Ctx, false, false, "john", "https://google.com", fasthttp.MethodGet, "1fa")
+
+	s.NoError(err)
+	s.Empty(bans)
+
+	gomock.InOrder(
+		s.mock.StorageMock.EXPECT().
+			AppendAuthenticationLog(s.mock.Ctx, gomock.Any()).
+			Return(nil),
+		s.mock.StorageMock.EXPECT().
+			LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindUser, "john", s.mock.Clock.Now(), s.mock.Clock.Now().Add(-s.mock.Ctx.Configuration.Regulation.BanTime)).
+			Return(nil, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadAuthenticationLogs(s.mock.Ctx, "john", s.mock.Clock.Now().Add(-s.mock.Ctx.Configuration.Regulation.BanTime), 10, 0).
+			Return(nil, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindIP, "192.168.1.10", s.mock.Clock.Now(), s.mock.Clock.Now().Add(-time.Minute*5)).
+			Return(nil, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadFailedAuthenticationLogsByRemoteIP(s.mock.Ctx, net.ParseIP("192.168.1.10"), s.mock.Clock.Now().Add(-time.Minute*5), 1, 0).
+			Return([]model.AuthenticationAttempt{{Time: s.mock.Clock.Now()}}, nil),
+		s.mock.StorageMock.EXPECT().
+			SaveRegulationBan(s.mock.Ctx, gomock.Any()).
+			Return(nil),
+	)
+
+	bans, err = regulator.Mark(s.mock.Ctx, false, false, "john", "https://google.com", fasthttp.MethodGet, "1fa")
+
+	s.NoError(err)
+	s.Require().Len(bans, 1)
+	s.Equal(model.RegulationBanKindIP, bans[0].Kind)
+	s.Equal("192.168.1.10", bans[0].Value)
+	s.Equal(s.mock.Clock.Now().Add(time.Minute*5), bans[0].ExpiresAt.Time)
+}
+
+func (s *RegulatorSuite) TestShouldRegulateRemoteIP() {
+	failed := func(ago ...time.Duration) (attempts []model.AuthenticationAttempt) {
+		for _, d := range ago {
+			attempts = append(attempts, model.AuthenticationAttempt{Successful: false, Time: s.mock.Clock.Now().Add(-d)})
+		}
+
+		return attempts
+	}
+
+	bans := func(kind, value string, since time.Duration, bans []model.RegulationBan) *gomock.Call {
+		return s.mock.StorageMock.EXPECT().
+			LoadRegulationBans(s.mock.Ctx, kind, value, s.mock.Clock.Now(), s.mock.Clock.Now().Add(-since)).
+			Return(bans, nil)
+	}
+
+	networks := func(bans []model.RegulationBan) *gomock.Call {
+		return s.mock.StorageMock.EXPECT().
+			LoadActiveRegulationBansByKind(s.mock.Ctx, model.RegulationBanKindSubnet, s.mock.Clock.Now()).
+			Return(bans, nil)
+	}
+
+	save := func(kind, value string, until time.Time) *gomock.Call {
+		return s.mock.StorageMock.EXPECT().
+			SaveRegulationBan(s.mock.Ctx, model.RegulationBan{
+				CreatedAt: s.mock.Clock.Now(),
+				ExpiresAt: sql.NullTime{Time: until, Valid: true},
+				Kind:      kind,
+				Value:     value,
+				Source:    model.RegulationBanSourceAutomatic,
+				Reason:    "Exceeded the maximum number of failed authentication attempts",
+			}).
+			Return(nil)
+	}
+
+	testCases := []struct {
+		name     string
+		ip       string
+		allowed  []string
+		setup    func(config schema.Regulation)
+		expected time.Time
+		err      error
+	}{
+		{
+			"ShouldBanIP",
+			"192.168.1.10",
+			nil,
+			func(config schema.Regulation) {
+				gomock.InOrder(
+					bans(model.RegulationBanKindIP, "192.168.1.10", config.IP.BanTime, nil),
+					s.mock.StorageMock.EXPECT().
+						LoadFailedAuthenticationLogsByRemoteIP(s.mock.Ctx, net.ParseIP("192.168.1.10"), s.mock.Clock.Now().Add(-config.IP.BanTime), 3, 0).
+						Return(failed(time.Second, time.Second*5, time.Second*20), nil),
+					save(model.RegulationBanKindIP, "192.168.1.10", s.mock.Clock.Now().Add(-time.Second).Add(time.Minute*10)),
+				)
+			},
+			s.mock.Clock.Now().Add(-time.Second).Add(time.Minute * 10),
+			regulation.ErrIPIsBanned,
+		},
+		{
+			"ShouldNotBanIPWhenNotWithinFindTime",
+			"192.168.1.10",
+			nil,
+			func(config schema.Regulation) {
+				gomock.InOrder(
+					bans(model.RegulationBanKindIP, "192.168.1.10", config.IP.BanTime, nil),
+					s.mock.StorageMock.EXPECT().
+						LoadFailedAuthenticationLogsByRemoteIP(s.mock.Ctx, net.ParseIP("192.168.1.10"), s.mock.Clock.Now().Add(-config.IP.BanTime), 3, 0).
+						Return(failed(time.Second, time.Second*5, time.Minute*2), nil),
+					bans(model.RegulationBanKindSubnet, "192.168.1.0/24", config.Subnet.BanTime, nil),
+					s.mock.StorageMock.EXPECT().
+						LoadFailedAuthenticationLogsByRemoteSubnet(s.mock.Ctx, "192.168.1.0/24", s.mock.Clock.Now().Add(-config.Subnet.BanTime), 5, 0).
+						Return(failed(time.Second, time.Second*5, time.Minute*2), nil),
+					networks(nil),
+				)
+			},
+			time.Time{},
+			nil,
+		},
+		{
+			"ShouldBanSubnet",
+			"2001:db8::1",
+			nil,
+			func(config schema.Regulation) {
+				gomock.InOrder(
+					bans(model.RegulationBanKindIP, "2001:db8::1", config.IP.BanTime, nil),
+					s.mock.StorageMock.EXPECT().
+						LoadFailedAuthenticationLogsByRemoteIP(s.mock.Ctx, net.ParseIP("2001:db8::1"), s.mock.Clock.Now().Add(-config.IP.BanTime), 3, 0).
+						Return(nil, nil),
+					bans(model.RegulationBanKindSubnet, "2001:db8::/64", config.Subnet.BanTime, nil),
+					s.mock.StorageMock.EXPECT().
+						LoadFailedAuthenticationLogsByRemoteSubnet(s.mock.Ctx, "2001:db8::/64", s.mock.Clock.Now().Add(-config.Subnet.BanTime), 5, 0).
+						Return(failed(time.Second*2, time.Second*3, time.Second*4, time.Second*5, time.Second*6), nil),
+					save(model.RegulationBanKindSubnet, "2001:db8::/64", s.mock.Clock.Now().Add(-time.Second*2).Add(time.Hour)),
+				)
+			},
+			s.mock.Clock.Now().Add(-time.Second * 2).Add(time.Hour),
+			regulation.ErrSubnetIsBanned,
+		},
+		{
+			"ShouldBanIPWithPermanentBan",
+			"192.168.1.10",
+			nil,
+			func(config schema.Regulation) {
+				bans(model.RegulationBanKindIP, "192.168.1.10", config.IP.BanTime, []model.RegulationBan{
+					{Kind: model.RegulationBanKindIP, Value: "192.168.1.10", Source: model.RegulationBanSourceManual},
+				})
+			},
+			time.Time{},
+			regulation.ErrIPIsBanned,
+		},
+		{
+			"ShouldBanSubnetWithActiveBan",
+			"192.168.1.10",
+			nil,
+			func(config schema.Regulation) {
+				gomock.InOrder(
+					bans(model.RegulationBanKindIP, "192.168.1.10", config.IP.BanTime, nil),
+					s.mock.StorageMock.EXPECT().
+						LoadFailedAuthenticationLogsByRemoteIP(s.mock.Ctx, net.ParseIP("192.168.1.10"), s.mock.Clock.Now().Add(-config.IP.BanTime), 3, 0).
+						Return(nil, nil),
+					bans(model.RegulationBanKindSubnet, "192.168.1.0/24", config.Subnet.BanTime, []model.RegulationBan{
+						{ExpiresAt: sql.NullTime{Time: s.mock.Clock.Now().Add(time.Minute), Valid: true}, Kind: model.RegulationBanKindSubnet, Value: "192.168.1.0/24"},
+					}),
+				)
+			},
+			s.mock.Clock.Now().Add(time.Minute),
+			regulation.ErrSubnetIsBanned,
+		},
+		{
+			"ShouldNotBanIPWhenAttemptsBeforeRevokedBan",
+			"192.168.1.10",
+			nil,
+			func(config schema.Regulation) {
+				revoked := s.mock.Clock.Now().Add(-time.Second * 10)
+
+				gomock.InOrder(
+					bans(model.RegulationBanKindIP, "192.168.1.10", config.IP.BanTime, []model.RegulationBan{
+						{ExpiresAt: sql.NullTime{Time: s.mock.Clock.Now().Add(time.Minute), Valid: true}, RevokedAt: sql.NullTime{Time: revoked, Valid: true}, Kind: model.RegulationBanKindIP, Value: "192.168.1.10"},
+					}),
+					s.mock.StorageMock.EXPECT().
+						LoadFailedAuthenticationLogsByRemoteIP(s.mock.Ctx, net.ParseIP("192.168.1.10"), revoked, 3, 0).
+						Return(failed(time.Second), nil),
+					bans(model.RegulationBanKindSubnet, "192.168.1.0/24", config.Subnet.BanTime, nil),
+					s.mock.StorageMock.EXPECT().
+						LoadFailedAuthenticationLogsByRemoteSubnet(s.mock.Ctx, "192.168.1.0/24", s.mock.Clock.Now().Add(-config.Subnet.BanTime), 5, 0).
+						Return(nil, nil),
+					networks(nil),
+				)
+			},
+			time.Time{},
+			nil,
+		},
+		{
+			"ShouldHandleStorageErrors",
+			"192.168.1.10",
+			nil,
+			func(config schema.Regulation) {
+				gomock.InOrder(
+					s.mock.StorageMock.EXPECT().
+						LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindIP, "192.168.1.10", s.mock.Clock.Now(), s.mock.Clock.Now().Add(-config.IP.BanTime)).
+						Return(nil, fmt.Errorf("failed")),
+					s.mock.StorageMock.EXPECT().
+						LoadFailedAuthenticationLogsByRemoteIP(s.mock.Ctx, net.ParseIP("192.168.1.10"), s.mock.Clock.Now().Add(-config.IP.BanTime), 3, 0).
+						Return(nil, fmt.Errorf("failed")),
+					s.mock.StorageMock.EXPECT().
+						LoadRegulationBans(s.mock.Ctx, model.RegulationBanKindSubnet, "192.168.1.0/24", s.mock.Clock.Now(), s.mock.Clock.Now().Add(-config.Subnet.BanTime)).
+						Return(nil, fmt.Errorf("failed")),
+					s.mock.StorageMock.EXPECT().
+						LoadFailedAuthenticationLogsByRemoteSubnet(s.mock.Ctx, "192.168.1.0/24", s.mock.Clock.Now().Add(-config.Subnet.BanTime), 5, 0).
+						Return(nil, fmt.Errorf("failed")),
+					s.mock.StorageMock.EXPECT().
+						LoadActiveRegulationBansByKind(s.mock.Ctx, model.RegulationBanKindSubnet, s.mock.Clock.Now()).
+						Return(nil, fmt.Errorf("failed")),
+				)
+			},
+			time.Time{},
+			nil,
+		},
+		{
+			"ShouldNotBanAllowedNetwork",
+			"192.168.1.10",
+			[]string{"192.168.0.0/16"},
+			func(config schema.Regulation) {
+				gomock.InOrder(
+					bans(model.RegulationBanKindIP, "192.168.1.10", config.IP.BanTime, []model.RegulationBan{
+						{ExpiresAt: sql.NullTime{Time: s.mock.Clock.Now().Add(time.Minute), Valid: true}, Kind: model.RegulationBanKindIP, Value: "192.168.1.10", Source: model.RegulationBanSourceAutomatic},
+					}),
+					bans(model.RegulationBanKindSubnet, "192.168.1.0/24", config.Subnet.BanTime, nil),
+					networks([]model.RegulationBan{
+						{ExpiresAt: sql.NullTime{Time: s.mock.Clock.Now().Add(time.Minute), Valid: true}, Kind: model.RegulationBanKindSubnet, Value: "192.168.0.0/16", Source: model.RegulationBanSourceAutomatic},
+					}),
+				)
+			},
+			time.Time{},
+			nil,
+		},
+		{
+			"ShouldBanIPWithinManualSubnetBanWithOtherPrefix",
+			"10.0.20.30",
+			nil,
+			func(config schema.Regulation) {
+				gomock.InOrder(
+					bans(model.RegulationBanKindIP, "10.0.20.30", config.IP.BanTime, nil),
+					s.mock.StorageMock.EXPECT().
+						LoadFailedAuthenticationLogsByRemoteIP(s.mock.Ctx, net.ParseIP("10.0.20.30"), s.mock.Clock.Now().Add(-config.IP.BanTime), 3, 0).
+						Return(nil, nil),
+					bans(model.RegulationBanKindSubnet, "10.0.20.0/24", config.Subnet.BanTime, nil),
+					s.mock.StorageMock.EXPECT().
+						LoadFailedAuthenticationLogsByRemoteSubnet(s.mock.Ctx, "10.0.20.0/24", s.mock.Clock.Now().Add(-config.Subnet.BanTime), 5, 0).
+						Return(nil, nil),
+					networks([]model.RegulationBan{
+						{ExpiresAt: sql.NullTime{Time: s.mock.Clock.Now().Add(time.Hour), Valid: true}, Kind: model.RegulationBanKindSubnet, Value: "192.168.0.0/16", Source: model.RegulationBanSourceManual},
+						{ExpiresAt: sql.NullTime{Time: s.mock.Clock.Now().Add(time.Minute * 30), Valid: true}, Kind: model.RegulationBanKindSubnet, Value: "10.0.0.0/16", Source: model.RegulationBanSourceManual},
+					}),
+				)
+			},
+			s.mock.Clock.Now().Add(time.Minute * 30),
+			regulation.ErrSubnetIsBanned,
+		},
+		{
+			"ShouldNotBanIPOutsideManualSubnetBanWithOtherPrefix",
+			"10.1.20.30",
+			nil,
+			func(config schema.Regulation) {
+				gomock.InOrder(
+					bans(model.RegulationBanKindIP, "10.1.20.30", config.IP.BanTime, nil),
+					s.mock.StorageMock.EXPECT().
+						LoadFailedAuthenticationLogsByRemoteIP(s.mock.Ctx, net.ParseIP("10.1.20.30"), s.mock.Clock.Now().Add(-config.IP.BanTime), 3, 0).
+						Return(nil, nil),
+					bans(model.RegulationBanKindSubnet, "10.1.20.0/24", config.Subnet.BanTime, nil),
+					s.mock.StorageMock.EXPECT().
+						LoadFailedAuthenticationLogsByRemoteSubnet(s.mock.Ctx, "10.1.20.0/24", s.mock.Clock.Now().Add(-config.Subnet.BanTime), 5, 0).
+						Return(nil, nil),
+					networks([]model.RegulationBan{
+						{ExpiresAt: sql.NullTime{Time: s.mock.Clock.Now().Add(time.Minute * 30), Valid: true}, Kind: model.RegulationBanKindSubnet, Value: "10.0.0.0/16", Source: model.RegulationBanSourceManual},
+					}),
+				)
+			},
+			time.Time{},
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.mock.Ctx.Request.Header.Set(fasthttp.HeaderXForwardedFor, tc.ip)
+
+			config := schema.Regulation{AllowedNetworks: tc.allowed}
+
+			if tc.setup != nil {
+				config.IP = schema.RegulationIP{MaxRetries: 3, FindTime: time.Minute, BanTime: time.Minute * 10}
+				config.Subnet = schema.RegulationSubnet{MaxRetries: 5, FindTime: time.Minute, BanTime: time.Hour, IPv4Prefix: 24, IPv6Prefix: 64}
+
+				tc.setup(config)
+			}
+
+			regulator := regulation.NewRegulator(config, s.mock.StorageMock, &s.mock.Clock)
+
+			until, err := regulator.RegulateRemoteIP(s.mock.Ctx)
+
+			s.Equal(tc.expected, until)
+
+			if tc.err == nil {
+				s.NoError(err)
+			} else {
+				s.ErrorIs(err, tc.err)
+			}
+		})
+	}
+}
+
+func (s *RegulatorSuite) TestShouldEnforceManualBans() {
+	manual := func(kind, value string) []model.RegulationBan {
+		return []model.RegulationBan{
+			{ExpiresAt: sql.NullTime{Time: s.mock.Clock.Now().Add(time.Hour), Valid: true}, Kind: kind, Value: value, Source: model.RegulationBanSourceManual},
+		}
+	}
+
+	automatic := func(kind, value string) []model.RegulationBan {
+		return []model.RegulationBan{
+			{ExpiresAt: sql.NullTime{Time: s.mock.Clock.Now().Add(time.Hour), Valid: true}, Kind: kind, Value: value, Source: model.RegulationBanSourceAutomatic},
+		}
+	}
+
+	bans := func(kind, value string, result []model.RegulationBan) *gomock.Call {
+		return s.mock.StorageMock.EXPECT().
+			LoadRegulationBans(s.mock.Ctx, kind, value, s.mock.Clock.Now(), s.mock.Clock.Now()).
+			Return(result, nil)
+	}
+
+	networks := func(result []model.RegulationBan) *gomock.Call {
+		return s.mock.StorageMock.EXPECT().
+			LoadActiveRegulationBansByKind(s.mock.Ctx, model.RegulationBanKindSubnet, s.mock.Clock.Now()).
+			Return(result, nil)
+	}
+
+	testCases := []struct {
+		name     string
+		ip       string
+		allowed  []string
+		user     bool
+		setup    func()
+		expected time.Time
+		err      error
+	}{
+		{
+			"ShouldBanUserWithManualBanWhenDisabled",
+			"192.168.1.10",
+			nil,
+			true,
+			func() {
+				bans(model.RegulationBanKindUser, "john", manual(model.RegulationBanKindUser, "john"))
+			},
+			s.mock.Clock.Now().Add(time.Hour),
+			regulation.ErrUserIsBanned,
+		},
+		{
+			"ShouldBanUserWithManualBanFromAllowedNetwork",
+			"192.168.1.10",
+			[]string{"192.168.0.0/16"},
+			true,
+			func() {
+				bans(model.RegulationBanKindUser, "john", manual(model.RegulationBanKindUser, "john"))
+			},
+			s.mock.Clock.Now().Add(time.Hour),
+			regulation.ErrUserIsBanned,
+		},
+		{
+			"ShouldNotBanUserWithAutomaticBanWhenDisabled",
+			"192.168.1.10",
+			nil,
+			true,
+			func() {
+				bans(model.RegulationBanKindUser, "john", automatic(model.RegulationBanKindUser, "john"))
+			},
+			time.Time{},
+			nil,
+		},
+		{
+			"ShouldBanIPWithManualBanWhenDisabled",
+			"192.168.1.10",
+			nil,
+			false,
+			func() {
+				bans(model.RegulationBanKindIP, "192.168.1.10", manual(model.RegulationBanKindIP, "192.168.1.10"))
+			},
+			s.mock.Clock.Now().Add(time.Hour),
+			regulation.ErrIPIsBanned,
+		},
+		{
+			"ShouldBanSubnetWithManualBanFromAllowedNetwork",
+			"192.168.1.10",
+			[]string{"192.168.0.0/16"},
+			false,
+			func() {
+				gomock.InOrder(
+					bans(model.RegulationBanKindIP, "192.168.1.10", automatic(model.RegulationBanKindIP, "192.168.1.10")),
+					bans(model.RegulationBanKindSubnet, "192.168.1.0/24", manual(model.RegulationBanKindSubnet, "192.168.1.0/24")),
+				)
+			},
+			s.mock.Clock.Now().Add(time.Hour),
+			regulation.ErrSubnetIsBanned,
+		},
+		{
+			"ShouldNotBanRemoteIPWhenDisabled",
+			"192.168.1.10",
+			nil,
+			false,
+			func() {
+				gomock.InOrder(
+					bans(model.RegulationBanKindIP, "192.168.1.10", nil),
+					bans(model.RegulationBanKindSubnet, "192.168.1.0/24", nil),
+					networks(nil),
+				)
+			},
+			time.Time{},
+			nil,
+		},
+		{
+			"ShouldBanIPWithinManualSubnetBanWhenDisabled",
+			"10.0.20.30",
+			nil,
+			false,
+			func() {
+				gomock.InOrder(
+					bans(model.RegulationBanKindIP, "10.0.20.30", nil),
+					bans(model.RegulationBanKindSubnet, "10.0.20.0/24", nil),
+					networks(manual(model.RegulationBanKindSubnet, "10.0.0.0/16")),
+				)
+			},
+			s.mock.Clock.Now().Add(time.Hour),
+			regulation.ErrSubnetIsBanned,
+		},
+		{
+			"ShouldNotBanIPWithinAutomaticSubnetBanWhenDisabled",
+			"10.0.20.30",
+			nil,
+			false,
+			func() {
+				gomock.InOrder(
+					bans(model.RegulationBanKindIP, "10.0.20.30", nil),
+					bans(model.RegulationBanKindSubnet, "10.0.20.0/24", nil),
+					networks(automatic(model.RegulationBanKindSubnet, "10.0.0.0/16")),
+				)
+			},
+			time.Time{},
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.mock.Ctx.Request.Header.Set(fasthttp.HeaderXForwardedFor, tc.ip)
+
+			config := schema.Regulation{
+				AllowedNetworks: tc.allowed,
+				Subnet:          schema.RegulationSubnet{IPv4Prefix: 24, IPv6Prefix: 64},
+			}
+
+			if tc.allowed != nil {
+				config.MaxRetries = 3
+				config.IP.MaxRetries = 3
+				config.Subnet.MaxRetries = 3
+			}
+
+			tc.setup()
+
+			regulator := regulation.NewRegulator(config, s.mock.StorageMock, &s.mock.Clock)
+
+			var (
+				until time.Time
+				err   error
+			)
+
+			if tc.user {
+				until, err = regulator.Regulate(s.mock.Ctx, "john")
+			} else {
+				until, err = regulator.RegulateRemoteIP(s.mock.Ctx)
+			}
+
+			s.Equal(tc.expected, until)
+
+			if tc.err == nil {
+				s.NoError(err)
+			} else {
+				s.ErrorIs(err, tc.err)
+			}
+		})
+	}
+}
@@ -19,6 +19,9 @@ type Regulator struct {
 	store storage.RegulatorProvider
 
 	clock clock.Provider
+
+	// The networks which are never banned.
+	networks []*net.IPNet
 }
 
 // Context represents a regulator context.
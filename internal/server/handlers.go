@@ -270,6 +270,16 @@ func handleRouter(config *schema.Configuration, providers middlewares.Providers)
 	r.POST("/api/user/info", middleware1FA(handlers.UserInfoPOST))
 	r.POST("/api/user/info/2fa_method", middleware1FA(handlers.MethodPreferencePOST))
 
+	// User Session Index.
+	r.GET("/api/user/sessions", middleware1FA(handlers.UserSessionsGET))
+	r.DELETE("/api/user/sessions/{id}", middleware1FA(handlers.UserSessionDELETE))
+
+	if config.KnownDevices.Enabled {
+		// Known Devices.
+		r.GET("/api/user/devices", middleware1FA(handlers.KnownDevicesGET))
+		r.DELETE("/api/user/devices/{id}", middleware1FA(handlers.KnownDeviceDELETE))
+	}
+
 	// User Session Elevation.
 	middlewareDelaySecond := middlewares.ArbitraryDelay(time.Second)
 
@@ -342,6 +352,9 @@ func handleRouter(config *schema.Configuration, providers middlewares.Providers)
 		r.GET("/api/oidc/consent", bridgeOIDC(handlers.OpenIDConnectConsentGET))
 		r.POST("/api/oidc/consent", bridgeOIDC(handlers.OpenIDConnectConsentPOST))
 
+		r.GET(oidc.EndpointPathRFC8628UserVerificationURL, bridgeOIDC(handlers.OpenIDConnectDeviceCodeUserVerificationGET))
+		r.POST(oidc.EndpointPathRFC8628UserVerificationURL, bridgeOIDC(handlers.OpenIDConnectDeviceCodeUserVerificationPOST))
+
 		allowedOrigins := utils.StringSliceFromURLs(config.IdentityProviders.OIDC.CORS.AllowedOrigins)
 
 		r.OPTIONS(oidc.EndpointPathWellKnownOpenIDConfiguration, policyCORSPublicGET.HandleOPTIONS)
@@ -393,6 +406,8 @@ func handleRouter(config *schema.Configuration, providers middlewares.Providers)
 		r.OPTIONS(oidc.EndpointPathToken, policyCORSToken.HandleOPTIONS)
 		r.POST(oidc.EndpointPathToken, middlewares.Wrap(middlewares.NewMetricsRequestOpenIDConnect(providers.Metrics, oidc.EndpointToken), policyCORSToken.Middleware(bridgeOIDC(middlewares.NewHTTPToAutheliaHandlerAdaptor(handlers.OpenIDConnectTokenPOST)))))
 
+		r.POST(oidc.EndpointPathDeviceAuthorization, middlewares.Wrap(middlewares.NewMetricsRequestOpenIDConnect(providers.Metrics, oidc.EndpointDeviceAuthorization), bridgeOIDC(middlewares.NewHTTPToAutheliaHandlerAdaptor(handlers.OpenIDConnectDeviceAuthorizationPOST))))
+
 		policyCORSUserinfo := middlewares.NewCORSPolicyBuilder().
 			WithAllowCredentials(true).
 			WithAllowedMethods(fasthttp.MethodOptions, fasthttp.MethodGet, fasthttp.MethodPost).
@@ -431,6 +446,18 @@ func handleRouter(config *schema.Configuration, providers middlewares.Providers)
 		// TODO (james-d-elliott): Remove in GA. This is a legacy implementation of the above endpoint.
 		r.OPTIONS("/api/oidc/revoke", policyCORSRevocation.HandleOPTIONS)
 		r.POST("/api/oidc/revoke", middlewares.Wrap(middlewares.NewMetricsRequestOpenIDConnect(providers.Metrics, oidc.EndpointRevocation), policyCORSRevocation.Middleware(bridgeOIDC(middlewares.NewHTTPToAutheliaHandlerAdaptor(handlers.OAuthRevocationPOST)))))
+
+		r.GET(oidc.EndpointPathEndSession, middlewares.Wrap(middlewares.NewMetricsRequestOpenIDConnect(providers.Metrics, oidc.EndpointEndSession), bridgeOIDC(handlers.OpenIDConnectEndSession)))
+		r.POST(oidc.EndpointPathEndSession, middlewares.Wrap(middlewares.NewMetricsRequestOpenIDConnect(providers.Metrics, oidc.EndpointEndSession), bridgeOIDC(handlers.OpenIDConnectEndSession)))
+
+		if config.IdentityProviders.OIDC.DynamicClientRegistration.Enable {
+			pathRegistrationClient := oidc.EndpointPathRegistration + "/{client_id}"
+
+			r.POST(oidc.EndpointPathRegistration, middlewares.Wrap(middlewares.NewMetricsRequestOpenIDConnect(providers.Metrics, oidc.EndpointRegistration), bridgeOIDC(middlewares.NewHTTPToAutheliaHandlerAdaptor(handlers.OpenIDConnectRegistrationPOST))))
+			r.GET(pathRegistrationClient, middlewares.Wrap(middlewares.NewMetricsRequestOpenIDConnect(providers.Metrics, oidc.EndpointRegistration), bridgeOIDC(middlewares.NewHTTPToAutheliaHandlerAdaptor(handlers.OpenIDConnectRegistrationClientGET))))
+			r.PUT(pathRegistrationClient, middlewares.Wrap(middlewares.NewMetricsRequestOpenIDConnect(providers.Metrics, oidc.EndpointRegistration), bridgeOIDC(middlewares.NewHTTPToAutheliaHandlerAdaptor(handlers.OpenIDConnectRegistrationClientPUT))))
+			r.DELETE(pathRegistrationClient, middlewares.Wrap(middlewares.NewMetricsRequestOpenIDConnect(providers.Metrics, oidc.EndpointRegistration), bridgeOIDC(middlewares.NewHTTPToAutheliaHandlerAdaptor(handlers.OpenIDConnectRegistrationClientDELETE))))
+		}
 	}
 
 	r.RedirectFixedPath = false
@@ -13,6 +13,8 @@ import (
 // Provider contains a list of domain sessions.
 type Provider struct {
 	sessions map[string]*Session
+
+	revocations revocationCache
 }
 
 // NewProvider instantiate a session provider given a configuration.
@@ -0,0 +1,63 @@
+package session
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// revocationCache records the user sessions which have recently been confirmed as not revoked so the session index
+// does not have to be queried on every request.
+type revocationCache struct {
+	mu sync.Mutex
+
+	checked map[uuid.UUID]time.Time
+	sweep   time.Time
+}
+
+// IsRevocationCheckDue returns true if the user session with the provided public id has not been confirmed as not
+// revoked or the confirmation has expired.
+func (p *Provider) IsRevocationCheckDue(id uuid.UUID, now time.Time) (due bool) {
+	p.revocations.mu.Lock()
+
+	defer p.revocations.mu.Unlock()
+
+	until, ok := p.revocations.checked[id]
+
+	return !ok || !now.Before(until)
+}
+
+// SetRevocationChecked records the user session with the provided public id as not revoked until the provided time.
+// Expired records are periodically removed.
+func (p *Provider) SetRevocationChecked(id uuid.UUID, now, until time.Time) {
+	p.revocations.mu.Lock()
+
+	defer p.revocations.mu.Unlock()
+
+	if p.revocations.checked == nil {
+		p.revocations.checked = map[uuid.UUID]time.Time{}
+	}
+
+	if now.After(p.revocations.sweep) {
+		for key, value := range p.revocations.checked {
+			if !now.Before(value) {
+				delete(p.revocations.checked, key)
+			}
+		}
+
+		p.revocations.sweep = until
+	}
+
+	p.revocations.checked[id] = until
+}
+
+// ForgetRevocationCheck removes the record for the user session with the provided public id so the next request for
+// the session checks the session index.
+func (p *Provider) ForgetRevocationCheck(id uuid.UUID) {
+	p.revocations.mu.Lock()
+
+	defer p.revocations.mu.Unlock()
+
+	delete(p.revocations.checked, id)
+}
@@ -104,6 +104,15 @@ func (p *Session) UpdateExpiration(ctx *fasthttp.RequestCtx, expiration time.Dur
 	return p.sessionHolder.Save(ctx, store)
 }
 
+// GetUserSessionExpiration returns the expected expiration of the given user session.
+func (p *Session) GetUserSessionExpiration(userSession UserSession) time.Duration {
+	if userSession.KeepMeLoggedIn {
+		return p.Config.RememberMe
+	}
+
+	return p.Config.Expiration
+}
+
 // GetExpiration get the expiration of the current session.
 func (p *Session) GetExpiration(ctx *fasthttp.RequestCtx) (time.Duration, error) {
 	store, err := p.sessionHolder.Get(ctx)
@@ -6,6 +6,7 @@ import (
 
 	session "github.com/fasthttp/session/v2"
 	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/google/uuid"
 
 	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/oidc"
@@ -21,12 +22,22 @@ type ProviderConfig struct {
 type UserSession struct {
 	CookieDomain string
 
+	// PublicID is the identifier of this session in the user session index.
+	PublicID uuid.UUID
+
 	Username    string
 	DisplayName string
 	// TODO(c.michaud): move groups out of the session.
 	Groups []string
 	Emails []string
 
+	GivenName   string
+	FamilyName  string
+	PhoneNumber string
+	Locale      string
+	Picture     string
+	Extra       map[string]any
+
 	KeepMeLoggedIn      bool
 	AuthenticationLevel authentication.Level
 	LastActivity        int64
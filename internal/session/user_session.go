@@ -35,9 +35,21 @@ func (s *UserSession) SetOneFactor(now time.Time, details *authentication.UserDe
 	s.Groups = details.Groups
 	s.Emails = details.Emails
 
+	s.SetAttributes(details)
+
 	s.AuthenticationMethodRefs.UsernameAndPassword = true
 }
 
+// SetAttributes sets the optional profile attributes and extra attributes from the user details.
+func (s *UserSession) SetAttributes(details *authentication.UserDetails) {
+	s.GivenName = details.GivenName
+	s.FamilyName = details.FamilyName
+	s.PhoneNumber = details.PhoneNumber
+	s.Locale = details.Locale
+	s.Picture = details.Picture
+	s.Extra = details.Extra
+}
+
 func (s *UserSession) setTwoFactor(now time.Time) {
 	s.SecondFactorAuthnTimestamp = now.Unix()
 	s.LastActivity = now.Unix()
@@ -84,6 +96,29 @@ func (s *UserSession) AuthenticatedTime(level authorization.Level) (authenticate
 	}
 }
 
+// LastAuthenticatedTime returns the time this session most recently authenticated a factor successfully. If the session
+// is not authenticated the zero time is returned.
+func (s *UserSession) LastAuthenticatedTime() (authenticatedTime time.Time) {
+	switch s.AuthenticationLevel {
+	case authentication.OneFactor:
+		return time.Unix(s.FirstFactorAuthnTimestamp, 0).UTC()
+	case authentication.TwoFactor:
+		return time.Unix(s.SecondFactorAuthnTimestamp, 0).UTC()
+	default:
+		return time.Time{}
+	}
+}
+
+// IsAuthenticationStale returns true if the session is authenticated and the most recent successful authentication of a
+// factor is older than the maximum age. A maximum age of zero or less is never stale.
+func (s *UserSession) IsAuthenticationStale(now time.Time, maxAge time.Duration) (stale bool) {
+	if maxAge <= 0 || s.AuthenticationLevel == authentication.NotAuthenticated {
+		return false
+	}
+
+	return now.Sub(s.LastAuthenticatedTime()) > maxAge
+}
+
 // Identity value of the user session.
 func (s *UserSession) Identity() Identity {
 	identity := Identity{
@@ -113,3 +148,27 @@ func (s *UserSession) GetDisplayName() (name string) {
 func (s *UserSession) GetEmails() (emails []string) {
 	return s.Emails
 }
+
+func (s *UserSession) GetGivenName() (name string) {
+	return s.GivenName
+}
+
+func (s *UserSession) GetFamilyName() (name string) {
+	return s.FamilyName
+}
+
+func (s *UserSession) GetPhoneNumber() (number string) {
+	return s.PhoneNumber
+}
+
+func (s *UserSession) GetLocale() (locale string) {
+	return s.Locale
+}
+
+func (s *UserSession) GetPicture() (picture string) {
+	return s.Picture
+}
+
+func (s *UserSession) GetExtra() (extra map[string]any) {
+	return s.Extra
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/oidc"
 )
 
@@ -89,3 +90,25 @@ func TestUserSession_Misc(t *testing.T) {
 	assert.Equal(t, []string{"abc@example.com", "xyz@example.com"}, session.GetEmails())
 	assert.Equal(t, []string{"agroup", "bgroup"}, session.GetGroups())
 }
+
+func TestUserSession_IsAuthenticationStale(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	session := &UserSession{}
+
+	assert.True(t, session.LastAuthenticatedTime().IsZero())
+	assert.False(t, session.IsAuthenticationStale(now, time.Minute))
+
+	session.SetOneFactor(now.Add(-time.Hour), &authentication.UserDetails{Username: "john"}, false)
+
+	assert.Equal(t, now.Add(-time.Hour).UTC(), session.LastAuthenticatedTime())
+	assert.True(t, session.IsAuthenticationStale(now, time.Minute))
+	assert.False(t, session.IsAuthenticationStale(now, time.Hour))
+	assert.False(t, session.IsAuthenticationStale(now, 0))
+
+	session.SetTwoFactorTOTP(now.Add(-time.Minute))
+
+	assert.Equal(t, now.Add(-time.Minute).UTC(), session.LastAuthenticatedTime())
+	assert.False(t, session.IsAuthenticationStale(now, time.Minute))
+	assert.True(t, session.IsAuthenticationStale(now, time.Second*59))
+}
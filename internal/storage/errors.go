@@ -17,6 +17,21 @@ var (
 	// ErrNoDuoDevice error thrown when no Duo device and method has been found in DB.
 	ErrNoDuoDevice = errors.New("no Duo device and method saved")
 
+	// ErrNoUser error thrown when no user has been found in DB.
+	ErrNoUser = errors.New("no user found")
+
+	// ErrNoUserSession error thrown when no user session has been found in DB.
+	ErrNoUserSession = errors.New("no user session found")
+
+	// ErrNoKnownDevice error thrown when no known device has been found in DB.
+	ErrNoKnownDevice = errors.New("no known device found")
+
+	// ErrNoOAuth2RegisteredClient error thrown when no dynamically registered OAuth 2.0 client has been found in DB.
+	ErrNoOAuth2RegisteredClient = errors.New("no registered client found")
+
+	// ErrNoOAuth2DeviceCodeSession error thrown when no OAuth 2.0 device code session has been found in DB.
+	ErrNoOAuth2DeviceCodeSession = errors.New("no device code session found")
+
 	// ErrNoAvailableMigrations is returned when no available migrations can be found.
 	ErrNoAvailableMigrations = errors.New("no available migrations")
 
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS oauth2_client_session (
+    id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+    session_id CHAR(36) NOT NULL,
+    client_id VARCHAR(255) NOT NULL,
+    subject CHAR(36) NOT NULL,
+    username VARCHAR(100) NOT NULL,
+    issuer VARCHAR(512) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    logged_out_at TIMESTAMP NULL DEFAULT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_520_ci;
+
+CREATE UNIQUE INDEX oauth2_client_session_session_id_client_id_key ON oauth2_client_session (session_id, client_id);
+CREATE INDEX oauth2_client_session_username_idx ON oauth2_client_session (username);
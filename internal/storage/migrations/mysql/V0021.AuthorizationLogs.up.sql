@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS authorization_logs (
+    id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+    time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    decision VARCHAR(10) NOT NULL,
+    status_code INTEGER NOT NULL,
+    username VARCHAR(100) NOT NULL DEFAULT '',
+    user_groups TEXT NULL DEFAULT NULL,
+    client_id VARCHAR(255) NOT NULL DEFAULT '',
+    remote_ip VARCHAR(39) NULL DEFAULT NULL,
+    request_method VARCHAR(10) NOT NULL DEFAULT '',
+    request_uri TEXT NOT NULL,
+    domain VARCHAR(255) NOT NULL,
+    rule_position INTEGER NOT NULL DEFAULT 0,
+    required_level VARCHAR(10) NOT NULL,
+    current_level VARCHAR(20) NOT NULL,
+    implementation VARCHAR(20) NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_520_ci;
+
+CREATE INDEX authorization_logs_time_idx ON authorization_logs (time);
+CREATE INDEX authorization_logs_username_idx ON authorization_logs (username, time);
+CREATE INDEX authorization_logs_domain_idx ON authorization_logs (domain, time);
@@ -0,0 +1,3 @@
+ALTER TABLE authentication_logs
+    DROP INDEX authentication_logs_remote_subnet_idx,
+    DROP COLUMN remote_subnet;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS regulation_bans (
+    id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NULL DEFAULT NULL,
+    revoked_at TIMESTAMP NULL DEFAULT NULL,
+    kind VARCHAR(10) NOT NULL,
+    value VARCHAR(100) NOT NULL,
+    source VARCHAR(10) NOT NULL,
+    reason TEXT NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_520_ci;
+
+CREATE INDEX regulation_bans_kind_value_idx ON regulation_bans (kind, value);
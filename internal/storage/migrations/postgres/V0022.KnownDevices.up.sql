@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS known_devices (
+    id SERIAL CONSTRAINT known_devices_pkey PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    username VARCHAR(100) NOT NULL,
+    device_id CHAR(64) NOT NULL,
+    subnet VARCHAR(43) NOT NULL,
+    user_agent TEXT NOT NULL,
+    device VARCHAR(255) NOT NULL
+);
+
+CREATE UNIQUE INDEX known_devices_username_device_id_subnet_key ON known_devices (username, device_id, subnet);
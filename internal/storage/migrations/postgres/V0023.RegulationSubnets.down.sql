@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS authentication_logs_remote_subnet_idx;
+
+ALTER TABLE authentication_logs DROP COLUMN remote_subnet;
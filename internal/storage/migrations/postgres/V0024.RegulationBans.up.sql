@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS regulation_bans (
+    id SERIAL CONSTRAINT regulation_bans_pkey PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP WITH TIME ZONE NULL DEFAULT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE NULL DEFAULT NULL,
+    kind VARCHAR(10) NOT NULL,
+    value VARCHAR(100) NOT NULL,
+    source VARCHAR(10) NOT NULL,
+    reason TEXT NOT NULL
+);
+
+CREATE INDEX regulation_bans_kind_value_idx ON regulation_bans (kind, value);
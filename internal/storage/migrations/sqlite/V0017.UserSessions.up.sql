@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS user_sessions (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    public_id CHAR(36) NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_active_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at DATETIME NOT NULL,
+    revoked_at DATETIME NULL DEFAULT NULL,
+    username VARCHAR(100) NOT NULL,
+    cookie_domain VARCHAR(255) NOT NULL,
+    ip VARCHAR(39) NOT NULL,
+    user_agent TEXT NOT NULL,
+    device VARCHAR(255) NOT NULL
+);
+
+CREATE UNIQUE INDEX user_sessions_public_id_key ON user_sessions (public_id);
+CREATE INDEX user_sessions_username_idx ON user_sessions (username);
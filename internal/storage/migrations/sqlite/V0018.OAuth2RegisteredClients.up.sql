@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS oauth2_registered_client (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    client_id VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at DATETIME NULL DEFAULT NULL,
+    registration_access_token_signature VARCHAR(64) NOT NULL,
+    client_secret TEXT NOT NULL,
+    metadata TEXT NOT NULL
+);
+
+CREATE UNIQUE INDEX oauth2_registered_client_client_id_key ON oauth2_registered_client (client_id);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS known_devices (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_used_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    username VARCHAR(100) NOT NULL,
+    device_id CHAR(64) NOT NULL,
+    subnet VARCHAR(43) NOT NULL,
+    user_agent TEXT NOT NULL,
+    device VARCHAR(255) NOT NULL
+);
+
+CREATE UNIQUE INDEX known_devices_username_device_id_subnet_key ON known_devices (username, device_id, subnet);
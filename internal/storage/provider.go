@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"net"
 	"time"
 
 	"authelia.com/provider/oauth2/storage"
@@ -33,6 +34,74 @@ type Provider interface {
 	// LoadUserInfo loads the model.UserInfo from the storage provider.
 	LoadUserInfo(ctx context.Context, username string) (info model.UserInfo, err error)
 
+	/*
+		Implementation for Users.
+	*/
+
+	// SaveUser saves a new user to the storage provider.
+	SaveUser(ctx context.Context, user model.User) (err error)
+
+	// UpdateUser updates the display name, email, groups, and disabled status of a user in the storage provider.
+	UpdateUser(ctx context.Context, user model.User) (err error)
+
+	// UpdateUserPassword updates the password of a user in the storage provider.
+	UpdateUserPassword(ctx context.Context, username, password string, changedAt time.Time) (err error)
+
+	// LoadUser loads a user from the storage provider.
+	LoadUser(ctx context.Context, username string) (user *model.User, err error)
+
+	// LoadUsers loads a set of users from the storage provider.
+	LoadUsers(ctx context.Context, limit, page int) (users []model.User, err error)
+
+	/*
+		Implementation for User Sessions.
+	*/
+
+	// SaveUserSession saves a new entry to the user session index.
+	SaveUserSession(ctx context.Context, session model.UserSession) (err error)
+
+	// UpdateUserSessionActivity updates the last activity, expiration, and remote ip of an entry in the user session index.
+	UpdateUserSessionActivity(ctx context.Context, session model.UserSession) (err error)
+
+	// RevokeUserSession marks an entry in the user session index belonging to the given user as revoked.
+	RevokeUserSession(ctx context.Context, username string, publicID uuid.UUID, revokedAt time.Time) (err error)
+
+	// RevokeUserSessions marks every unrevoked entry in the user session index belonging to the given user as revoked.
+	RevokeUserSessions(ctx context.Context, username string, revokedAt time.Time) (count int64, err error)
+
+	// LoadUserSession loads an entry from the user session index.
+	LoadUserSession(ctx context.Context, publicID uuid.UUID) (session *model.UserSession, err error)
+
+	// LoadUserSessions loads the unrevoked and unexpired entries from the user session index for the given user.
+	LoadUserSessions(ctx context.Context, username string, now time.Time) (sessions []model.UserSession, err error)
+
+	/*
+		Implementation for Known Devices.
+	*/
+
+	// SaveKnownDevice saves a new known device to the storage provider.
+	SaveKnownDevice(ctx context.Context, device model.KnownDevice) (err error)
+
+	// UpdateKnownDeviceActivity updates the last used time and user agent of a known device in the storage provider.
+	UpdateKnownDeviceActivity(ctx context.Context, device model.KnownDevice) (err error)
+
+	// DeleteKnownDevice deletes a known device belonging to the given user from the storage provider.
+	DeleteKnownDevice(ctx context.Context, username string, id int) (err error)
+
+	// LoadKnownDevices loads the known devices of the given user which have been used since a time from the storage
+	// provider.
+	LoadKnownDevices(ctx context.Context, username string, since time.Time) (devices []model.KnownDevice, err error)
+
+	/*
+		Implementation for Regulation Bans.
+	*/
+
+	// LoadActiveRegulationBans loads the bans which are active at the given time from the storage provider (paginated).
+	LoadActiveRegulationBans(ctx context.Context, now time.Time, limit, page int) (bans []model.RegulationBan, err error)
+
+	// RevokeRegulationBans marks every ban of a user, remote ip, or subnet which is active at the given time as revoked.
+	RevokeRegulationBans(ctx context.Context, kind, value string, revokedAt time.Time) (count int64, err error)
+
 	/*
 		Implementation for User Opaque Identifiers.
 	*/
@@ -261,6 +330,82 @@ type Provider interface {
 	// LoadOAuth2BlacklistedJTI loads an OAuth2.0 blacklisted JTI from the storage provider.
 	LoadOAuth2BlacklistedJTI(ctx context.Context, signature string) (blacklistedJTI *model.OAuth2BlacklistedJTI, err error)
 
+	/*
+		Implementation for OAuth2.0 Dynamic Client Registration.
+	*/
+
+	// SaveOAuth2RegisteredClient saves a dynamically registered OAuth2.0 client to the storage provider.
+	SaveOAuth2RegisteredClient(ctx context.Context, client model.OAuth2RegisteredClient) (err error)
+
+	// UpdateOAuth2RegisteredClient updates the metadata and credentials of a dynamically registered OAuth2.0 client.
+	UpdateOAuth2RegisteredClient(ctx context.Context, client model.OAuth2RegisteredClient) (err error)
+
+	// LoadOAuth2RegisteredClient loads a dynamically registered OAuth2.0 client from the storage provider.
+	LoadOAuth2RegisteredClient(ctx context.Context, clientID string) (client *model.OAuth2RegisteredClient, err error)
+
+	// DeleteOAuth2RegisteredClient deletes a dynamically registered OAuth2.0 client from the storage provider.
+	DeleteOAuth2RegisteredClient(ctx context.Context, clientID string) (err error)
+
+	/*
+		Implementation for OAuth2.0 Device Authorization Grant.
+	*/
+
+	// SaveOAuth2DeviceCodeSession saves an OAuth2.0 device code session to the storage provider.
+	SaveOAuth2DeviceCodeSession(ctx context.Context, session model.OAuth2DeviceCodeSession) (err error)
+
+	// UpdateOAuth2DeviceCodeSession updates the user response of an OAuth2.0 device code session in the storage provider.
+	UpdateOAuth2DeviceCodeSession(ctx context.Context, session model.OAuth2DeviceCodeSession) (err error)
+
+	// UpdateOAuth2DeviceCodeSessionCheckedAt updates the time an OAuth2.0 device code session was last polled in the
+	// storage provider.
+	UpdateOAuth2DeviceCodeSessionCheckedAt(ctx context.Context, signature string, checkedAt time.Time) (err error)
+
+	// DeactivateOAuth2DeviceCodeSession marks an active OAuth2.0 device code session as inactive in the storage provider.
+	// It returns ErrNoOAuth2DeviceCodeSession if the session does not exist or is already inactive.
+	DeactivateOAuth2DeviceCodeSession(ctx context.Context, signature string) (err error)
+
+	// LoadOAuth2DeviceCodeSession loads an OAuth2.0 device code session from the storage provider given the device
+	// code signature.
+	LoadOAuth2DeviceCodeSession(ctx context.Context, signature string) (session *model.OAuth2DeviceCodeSession, err error)
+
+	// LoadOAuth2DeviceCodeSessionByUserCode loads an OAuth2.0 device code session from the storage provider given the
+	// user code signature.
+	LoadOAuth2DeviceCodeSessionByUserCode(ctx context.Context, userCodeSignature string) (session *model.OAuth2DeviceCodeSession, err error)
+
+	/*
+		Implementation for OpenID Connect 1.0 Logout.
+	*/
+
+	// SaveOAuth2ClientSession saves an OAuth2.0 client session which records that a client holds a session for the user
+	// session to the storage provider.
+	SaveOAuth2ClientSession(ctx context.Context, session model.OAuth2ClientSession) (err error)
+
+	// LoadOAuth2ClientSessions loads the OAuth2.0 client sessions which have not been logged out for a user session from
+	// the storage provider.
+	LoadOAuth2ClientSessions(ctx context.Context, sessionID uuid.UUID) (sessions []model.OAuth2ClientSession, err error)
+
+	// LoadOAuth2ClientSessionsByUsername loads the OAuth2.0 client sessions which have not been logged out for a user from
+	// the storage provider.
+	LoadOAuth2ClientSessionsByUsername(ctx context.Context, username string) (sessions []model.OAuth2ClientSession, err error)
+
+	// LogoutOAuth2ClientSession marks an OAuth2.0 client session as logged out in the storage provider.
+	LogoutOAuth2ClientSession(ctx context.Context, id int, at time.Time) (err error)
+
+	/*
+		Implementation for Authorization Decision Audit Logs.
+	*/
+
+	// AppendAuthorizationDecisions saves a batch of authorization decisions to the storage provider in a single
+	// transaction.
+	AppendAuthorizationDecisions(ctx context.Context, decisions []model.AuthorizationDecision) (err error)
+
+	// LoadAuthorizationDecisions loads authorization decisions made since a time from the storage provider (paginated).
+	// The username, domain, and decision are only used to filter the results when they're not empty.
+	LoadAuthorizationDecisions(ctx context.Context, username, domain, decision string, since time.Time, limit, page int) (decisions []model.AuthorizationDecision, err error)
+
+	// PruneAuthorizationDecisions deletes the authorization decisions made before a time from the storage provider.
+	PruneAuthorizationDecisions(ctx context.Context, before time.Time) (count int64, err error)
+
 	/*
 		Implementation for Schema controls.
 	*/
@@ -305,4 +450,23 @@ type RegulatorProvider interface {
 
 	// LoadAuthenticationLogs loads authentication attempts from the storage provider (paginated).
 	LoadAuthenticationLogs(ctx context.Context, username string, fromDate time.Time, limit, page int) (attempts []model.AuthenticationAttempt, err error)
+
+	// LoadFailedAuthenticationLogsByRemoteIP loads the failed authentication attempts from a remote ip which did not
+	// occur while banned from the storage provider (paginated).
+	LoadFailedAuthenticationLogsByRemoteIP(ctx context.Context, ip net.IP, fromDate time.Time, limit, page int) (attempts []model.AuthenticationAttempt, err error)
+
+	// LoadFailedAuthenticationLogsByRemoteSubnet loads the failed authentication attempts from a subnet which did not
+	// occur while banned from the storage provider (paginated).
+	LoadFailedAuthenticationLogsByRemoteSubnet(ctx context.Context, subnet string, fromDate time.Time, limit, page int) (attempts []model.AuthenticationAttempt, err error)
+
+	// SaveRegulationBan saves a ban of a user, remote ip, or subnet to the storage provider.
+	SaveRegulationBan(ctx context.Context, ban model.RegulationBan) (err error)
+
+	// LoadRegulationBans loads the bans of a user, remote ip, or subnet which are active at the given time or which
+	// have been revoked since a time from the storage provider.
+	LoadRegulationBans(ctx context.Context, kind, value string, now, since time.Time) (bans []model.RegulationBan, err error)
+
+	// LoadActiveRegulationBansByKind loads the bans of a kind which are active at the given time from the storage
+	// provider.
+	LoadActiveRegulationBansByKind(ctx context.Context, kind string, now time.Time) (bans []model.RegulationBan, err error)
 }
@@ -6,6 +6,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"net"
 	"strconv"
 	"strings"
 	"time"
@@ -39,6 +40,19 @@ func NewSQLProvider(config *schema.Configuration, name, driverName, dataSourceNa
 		sqlInsertAuthenticationAttempt:            fmt.Sprintf(queryFmtInsertAuthenticationLogEntry, tableAuthenticationLogs),
 		sqlSelectAuthenticationAttemptsByUsername: fmt.Sprintf(queryFmtSelect1FAAuthenticationLogEntryByUsername, tableAuthenticationLogs),
 
+		sqlSelectFailedAuthenticationAttemptsByRemoteIP:     fmt.Sprintf(queryFmtSelectFailedAuthenticationLogEntryByRemoteIP, tableAuthenticationLogs),
+		sqlSelectFailedAuthenticationAttemptsByRemoteSubnet: fmt.Sprintf(queryFmtSelectFailedAuthenticationLogEntryByRemoteSubnet, tableAuthenticationLogs),
+
+		sqlInsertRegulationBan:              fmt.Sprintf(queryFmtInsertRegulationBan, tableRegulationBans),
+		sqlRevokeRegulationBans:             fmt.Sprintf(queryFmtRevokeRegulationBans, tableRegulationBans),
+		sqlSelectRegulationBansByValue:      fmt.Sprintf(queryFmtSelectRegulationBansByValue, tableRegulationBans),
+		sqlSelectRegulationBansActive:       fmt.Sprintf(queryFmtSelectRegulationBansActive, tableRegulationBans),
+		sqlSelectRegulationBansActiveByKind: fmt.Sprintf(queryFmtSelectRegulationBansActiveByKind, tableRegulationBans),
+
+		sqlInsertAuthorizationDecision:        fmt.Sprintf(queryFmtInsertAuthorizationLogEntry, tableAuthorizationLogs),
+		sqlSelectAuthorizationDecisions:       fmt.Sprintf(queryFmtSelectAuthorizationLogEntries, tableAuthorizationLogs),
+		sqlDeleteAuthorizationDecisionsBefore: fmt.Sprintf(queryFmtDeleteAuthorizationLogEntriesBefore, tableAuthorizationLogs),
+
 		sqlInsertIdentityVerification:  fmt.Sprintf(queryFmtInsertIdentityVerification, tableIdentityVerification),
 		sqlConsumeIdentityVerification: fmt.Sprintf(queryFmtConsumeIdentityVerification, tableIdentityVerification),
 		sqlRevokeIdentityVerification:  fmt.Sprintf(queryFmtRevokeIdentityVerification, tableIdentityVerification),
@@ -85,6 +99,24 @@ func NewSQLProvider(config *schema.Configuration, name, driverName, dataSourceNa
 		sqlSelectPreferred2FAMethod: fmt.Sprintf(queryFmtSelectPreferred2FAMethod, tableUserPreferences),
 		sqlSelectUserInfo:           fmt.Sprintf(queryFmtSelectUserInfo, tableTOTPConfigurations, tableWebAuthnCredentials, tableDuoDevices, tableUserPreferences),
 
+		sqlInsertUser:         fmt.Sprintf(queryFmtInsertUser, tableUsers),
+		sqlUpdateUser:         fmt.Sprintf(queryFmtUpdateUser, tableUsers),
+		sqlUpdateUserPassword: fmt.Sprintf(queryFmtUpdateUserPassword, tableUsers),
+		sqlSelectUser:         fmt.Sprintf(queryFmtSelectUser, tableUsers),
+		sqlSelectUsers:        fmt.Sprintf(queryFmtSelectUsers, tableUsers),
+
+		sqlInsertUserSession:                  fmt.Sprintf(queryFmtInsertUserSession, tableUserSessions),
+		sqlUpdateUserSessionActivity:          fmt.Sprintf(queryFmtUpdateUserSessionActivity, tableUserSessions),
+		sqlRevokeUserSession:                  fmt.Sprintf(queryFmtRevokeUserSession, tableUserSessions),
+		sqlRevokeUserSessionsByUsername:       fmt.Sprintf(queryFmtRevokeUserSessionsByUsername, tableUserSessions),
+		sqlSelectUserSession:                  fmt.Sprintf(queryFmtSelectUserSession, tableUserSessions),
+		sqlSelectUserSessionsActiveByUsername: fmt.Sprintf(queryFmtSelectUserSessionsActiveByUsername, tableUserSessions),
+
+		sqlInsertKnownDevice:            fmt.Sprintf(queryFmtInsertKnownDevice, tableKnownDevices),
+		sqlUpdateKnownDeviceActivity:    fmt.Sprintf(queryFmtUpdateKnownDeviceActivity, tableKnownDevices),
+		sqlDeleteKnownDevice:            fmt.Sprintf(queryFmtDeleteKnownDevice, tableKnownDevices),
+		sqlSelectKnownDevicesByUsername: fmt.Sprintf(queryFmtSelectKnownDevicesByUsername, tableKnownDevices),
+
 		sqlInsertUserOpaqueIdentifier:            fmt.Sprintf(queryFmtInsertUserOpaqueIdentifier, tableUserOpaqueIdentifier),
 		sqlSelectUserOpaqueIdentifier:            fmt.Sprintf(queryFmtSelectUserOpaqueIdentifier, tableUserOpaqueIdentifier),
 		sqlSelectUserOpaqueIdentifiers:           fmt.Sprintf(queryFmtSelectUserOpaqueIdentifiers, tableUserOpaqueIdentifier),
@@ -93,6 +125,23 @@ func NewSQLProvider(config *schema.Configuration, name, driverName, dataSourceNa
 		sqlUpsertOAuth2BlacklistedJTI: fmt.Sprintf(queryFmtUpsertOAuth2BlacklistedJTI, tableOAuth2BlacklistedJTI),
 		sqlSelectOAuth2BlacklistedJTI: fmt.Sprintf(queryFmtSelectOAuth2BlacklistedJTI, tableOAuth2BlacklistedJTI),
 
+		sqlInsertOAuth2RegisteredClient: fmt.Sprintf(queryFmtInsertOAuth2RegisteredClient, tableOAuth2RegisteredClient),
+		sqlUpdateOAuth2RegisteredClient: fmt.Sprintf(queryFmtUpdateOAuth2RegisteredClient, tableOAuth2RegisteredClient),
+		sqlSelectOAuth2RegisteredClient: fmt.Sprintf(queryFmtSelectOAuth2RegisteredClient, tableOAuth2RegisteredClient),
+		sqlDeleteOAuth2RegisteredClient: fmt.Sprintf(queryFmtDeleteOAuth2RegisteredClient, tableOAuth2RegisteredClient),
+
+		sqlInsertOAuth2DeviceCodeSession:           fmt.Sprintf(queryFmtInsertOAuth2DeviceCodeSession, tableOAuth2DeviceCodeSession),
+		sqlUpdateOAuth2DeviceCodeSession:           fmt.Sprintf(queryFmtUpdateOAuth2DeviceCodeSession, tableOAuth2DeviceCodeSession),
+		sqlUpdateOAuth2DeviceCodeSessionCheckedAt:  fmt.Sprintf(queryFmtUpdateOAuth2DeviceCodeSessionCheckedAt, tableOAuth2DeviceCodeSession),
+		sqlDeactivateOAuth2DeviceCodeSession:       fmt.Sprintf(queryFmtDeactivateOAuth2DeviceCodeSession, tableOAuth2DeviceCodeSession),
+		sqlSelectOAuth2DeviceCodeSession:           fmt.Sprintf(queryFmtSelectOAuth2DeviceCodeSession, tableOAuth2DeviceCodeSession),
+		sqlSelectOAuth2DeviceCodeSessionByUserCode: fmt.Sprintf(queryFmtSelectOAuth2DeviceCodeSessionByUserCode, tableOAuth2DeviceCodeSession),
+
+		sqlUpsertOAuth2ClientSession:            fmt.Sprintf(queryFmtUpsertOAuth2ClientSession, tableOAuth2ClientSession),
+		sqlSelectOAuth2ClientSessions:           fmt.Sprintf(queryFmtSelectOAuth2ClientSessions, tableOAuth2ClientSession),
+		sqlSelectOAuth2ClientSessionsByUsername: fmt.Sprintf(queryFmtSelectOAuth2ClientSessionsByUsername, tableOAuth2ClientSession),
+		sqlUpdateOAuth2ClientSessionLoggedOut:   fmt.Sprintf(queryFmtUpdateOAuth2ClientSessionLoggedOut, tableOAuth2ClientSession),
+
 		sqlInsertOAuth2PARContext: fmt.Sprintf(queryFmtInsertOAuth2PARContext, tableOAuth2PARContext),
 		sqlUpdateOAuth2PARContext: fmt.Sprintf(queryFmtUpdateOAuth2PARContext, tableOAuth2PARContext),
 		sqlSelectOAuth2PARContext: fmt.Sprintf(queryFmtSelectOAuth2PARContext, tableOAuth2PARContext),
@@ -173,6 +222,21 @@ type SQLProvider struct {
 	sqlInsertAuthenticationAttempt            string
 	sqlSelectAuthenticationAttemptsByUsername string
 
+	sqlSelectFailedAuthenticationAttemptsByRemoteIP     string
+	sqlSelectFailedAuthenticationAttemptsByRemoteSubnet string
+
+	// Table: regulation_bans.
+	sqlInsertRegulationBan              string
+	sqlRevokeRegulationBans             string
+	sqlSelectRegulationBansByValue      string
+	sqlSelectRegulationBansActive       string
+	sqlSelectRegulationBansActiveByKind string
+
+	// Table: authorization_logs.
+	sqlInsertAuthorizationDecision        string
+	sqlSelectAuthorizationDecisions       string
+	sqlDeleteAuthorizationDecisionsBefore string
+
 	// Table: identity_verification.
 	sqlInsertIdentityVerification  string
 	sqlConsumeIdentityVerification string
@@ -229,6 +293,27 @@ type SQLProvider struct {
 	sqlSelectPreferred2FAMethod string
 	sqlSelectUserInfo           string
 
+	// Table: users.
+	sqlInsertUser         string
+	sqlUpdateUser         string
+	sqlUpdateUserPassword string
+	sqlSelectUser         string
+	sqlSelectUsers        string
+
+	// Table: user_sessions.
+	sqlInsertUserSession                  string
+	sqlUpdateUserSessionActivity          string
+	sqlRevokeUserSession                  string
+	sqlRevokeUserSessionsByUsername       string
+	sqlSelectUserSession                  string
+	sqlSelectUserSessionsActiveByUsername string
+
+	// Table: known_devices.
+	sqlInsertKnownDevice            string
+	sqlUpdateKnownDeviceActivity    string
+	sqlDeleteKnownDevice            string
+	sqlSelectKnownDevicesByUsername string
+
 	// Table: user_opaque_identifier.
 	sqlInsertUserOpaqueIdentifier            string
 	sqlSelectUserOpaqueIdentifier            string
@@ -304,6 +389,25 @@ type SQLProvider struct {
 	sqlUpsertOAuth2BlacklistedJTI string
 	sqlSelectOAuth2BlacklistedJTI string
 
+	sqlInsertOAuth2RegisteredClient string
+	sqlUpdateOAuth2RegisteredClient string
+	sqlSelectOAuth2RegisteredClient string
+	sqlDeleteOAuth2RegisteredClient string
+
+	// Table: oauth2_device_code_session.
+	sqlInsertOAuth2DeviceCodeSession           string
+	sqlUpdateOAuth2DeviceCodeSession           string
+	sqlUpdateOAuth2DeviceCodeSessionCheckedAt  string
+	sqlDeactivateOAuth2DeviceCodeSession       string
+	sqlSelectOAuth2DeviceCodeSession           string
+	sqlSelectOAuth2DeviceCodeSessionByUserCode string
+
+	// Table: oauth2_client_session.
+	sqlUpsertOAuth2ClientSession            string
+	sqlSelectOAuth2ClientSessions           string
+	sqlSelectOAuth2ClientSessionsByUsername string
+	sqlUpdateOAuth2ClientSessionLoggedOut   string
+
 	// Utility.
 	sqlSelectExistingTables string
 	sqlFmtRenameTable       string
@@ -442,6 +546,204 @@ func (p *SQLProvider) LoadUserInfo(ctx context.Context, username string) (info m
 	}
 }
 
+// SaveUser saves a new user to the storage provider.
+func (p *SQLProvider) SaveUser(ctx context.Context, user model.User) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlInsertUser,
+		user.CreatedAt, user.CreatedAt, user.Username, user.DisplayName, user.Email, user.Groups, user.Password, user.Disabled); err != nil {
+		return fmt.Errorf("error inserting user '%s': %w", user.Username, err)
+	}
+
+	return nil
+}
+
+// UpdateUser updates the display name, email, groups, and disabled status of a user in the storage provider.
+func (p *SQLProvider) UpdateUser(ctx context.Context, user model.User) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlUpdateUser,
+		user.UpdatedAt, user.DisplayName, user.Email, user.Groups, user.Disabled, user.Username); err != nil {
+		return fmt.Errorf("error updating user '%s': %w", user.Username, err)
+	}
+
+	return nil
+}
+
+// UpdateUserPassword updates the password of a user in the storage provider.
+func (p *SQLProvider) UpdateUserPassword(ctx context.Context, username, password string, changedAt time.Time) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlUpdateUserPassword, changedAt, changedAt, password, username); err != nil {
+		return fmt.Errorf("error updating password for user '%s': %w", username, err)
+	}
+
+	return nil
+}
+
+// LoadUser loads a user from the storage provider.
+func (p *SQLProvider) LoadUser(ctx context.Context, username string) (user *model.User, err error) {
+	user = &model.User{}
+
+	if err = p.db.GetContext(ctx, user, p.sqlSelectUser, username); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoUser
+		}
+
+		return nil, fmt.Errorf("error selecting user '%s': %w", username, err)
+	}
+
+	return user, nil
+}
+
+// LoadUsers loads a set of users from the storage provider.
+func (p *SQLProvider) LoadUsers(ctx context.Context, limit, page int) (users []model.User, err error) {
+	users = make([]model.User, 0, limit)
+
+	if err = p.db.SelectContext(ctx, &users, p.sqlSelectUsers, limit, limit*page); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error selecting users: %w", err)
+	}
+
+	return users, nil
+}
+
+// SaveUserSession saves a new entry to the user session index.
+func (p *SQLProvider) SaveUserSession(ctx context.Context, session model.UserSession) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlInsertUserSession,
+		session.PublicID, session.CreatedAt, session.LastActiveAt, session.ExpiresAt, session.Username, session.CookieDomain, session.IP, session.UserAgent, session.Device); err != nil {
+		return fmt.Errorf("error inserting user session with public id '%s' for user '%s': %w", session.PublicID, session.Username, err)
+	}
+
+	return nil
+}
+
+// UpdateUserSessionActivity updates the last activity, expiration, and remote ip of an entry in the user session index.
+func (p *SQLProvider) UpdateUserSessionActivity(ctx context.Context, session model.UserSession) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlUpdateUserSessionActivity,
+		session.LastActiveAt, session.ExpiresAt, session.IP, session.PublicID); err != nil {
+		return fmt.Errorf("error updating user session activity with public id '%s' for user '%s': %w", session.PublicID, session.Username, err)
+	}
+
+	return nil
+}
+
+// RevokeUserSession marks an entry in the user session index belonging to the given user as revoked.
+func (p *SQLProvider) RevokeUserSession(ctx context.Context, username string, publicID uuid.UUID, revokedAt time.Time) (err error) {
+	var result sql.Result
+
+	if result, err = p.db.ExecContext(ctx, p.sqlRevokeUserSession, revokedAt, publicID, username); err != nil {
+		return fmt.Errorf("error revoking user session with public id '%s' for user '%s': %w", publicID, username, err)
+	}
+
+	var affected int64
+
+	if affected, err = result.RowsAffected(); err != nil {
+		return fmt.Errorf("error revoking user session with public id '%s' for user '%s': %w", publicID, username, err)
+	}
+
+	if affected == 0 {
+		return ErrNoUserSession
+	}
+
+	return nil
+}
+
+// RevokeUserSessions marks every unrevoked entry in the user session index belonging to the given user as revoked.
+func (p *SQLProvider) RevokeUserSessions(ctx context.Context, username string, revokedAt time.Time) (count int64, err error) {
+	var result sql.Result
+
+	if result, err = p.db.ExecContext(ctx, p.sqlRevokeUserSessionsByUsername, revokedAt, username); err != nil {
+		return 0, fmt.Errorf("error revoking user sessions for user '%s': %w", username, err)
+	}
+
+	if count, err = result.RowsAffected(); err != nil {
+		return 0, fmt.Errorf("error revoking user sessions for user '%s': %w", username, err)
+	}
+
+	return count, nil
+}
+
+// LoadUserSession loads an entry from the user session index.
+func (p *SQLProvider) LoadUserSession(ctx context.Context, publicID uuid.UUID) (session *model.UserSession, err error) {
+	session = &model.UserSession{}
+
+	if err = p.db.GetContext(ctx, session, p.sqlSelectUserSession, publicID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoUserSession
+		}
+
+		return nil, fmt.Errorf("error selecting user session with public id '%s': %w", publicID, err)
+	}
+
+	return session, nil
+}
+
+// LoadUserSessions loads the unrevoked and unexpired entries from the user session index for the given user.
+func (p *SQLProvider) LoadUserSessions(ctx context.Context, username string, now time.Time) (sessions []model.UserSession, err error) {
+	if err = p.db.SelectContext(ctx, &sessions, p.sqlSelectUserSessionsActiveByUsername, username, now); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error selecting user sessions for user '%s': %w", username, err)
+	}
+
+	return sessions, nil
+}
+
+// SaveKnownDevice saves a new known device to the storage provider.
+func (p *SQLProvider) SaveKnownDevice(ctx context.Context, device model.KnownDevice) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlInsertKnownDevice,
+		device.CreatedAt, device.LastUsedAt, device.Username, device.DeviceID, device.Subnet, device.UserAgent, device.Device); err != nil {
+		return fmt.Errorf("error inserting known device with subnet '%s' for user '%s': %w", device.Subnet, device.Username, err)
+	}
+
+	return nil
+}
+
+// UpdateKnownDeviceActivity updates the last used time and user agent of a known device in the storage provider.
+func (p *SQLProvider) UpdateKnownDeviceActivity(ctx context.Context, device model.KnownDevice) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlUpdateKnownDeviceActivity,
+		device.LastUsedAt, device.UserAgent, device.Device, device.ID); err != nil {
+		return fmt.Errorf("error updating known device activity with id '%d' for user '%s': %w", device.ID, device.Username, err)
+	}
+
+	return nil
+}
+
+// DeleteKnownDevice deletes a known device belonging to the given user from the storage provider.
+func (p *SQLProvider) DeleteKnownDevice(ctx context.Context, username string, id int) (err error) {
+	var result sql.Result
+
+	if result, err = p.db.ExecContext(ctx, p.sqlDeleteKnownDevice, id, username); err != nil {
+		return fmt.Errorf("error deleting known device with id '%d' for user '%s': %w", id, username, err)
+	}
+
+	var affected int64
+
+	if affected, err = result.RowsAffected(); err != nil {
+		return fmt.Errorf("error deleting known device with id '%d' for user '%s': %w", id, username, err)
+	}
+
+	if affected == 0 {
+		return ErrNoKnownDevice
+	}
+
+	return nil
+}
+
+// LoadKnownDevices loads the known devices of the given user which have been used since a time from the storage
+// provider.
+func (p *SQLProvider) LoadKnownDevices(ctx context.Context, username string, since time.Time) (devices []model.KnownDevice, err error) {
+	if err = p.db.SelectContext(ctx, &devices, p.sqlSelectKnownDevicesByUsername, username, since); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error selecting known devices for user '%s': %w", username, err)
+	}
+
+	return devices, nil
+}
+
 // SaveUserOpaqueIdentifier saves a new opaque user identifier to the storage provider.
 func (p *SQLProvider) SaveUserOpaqueIdentifier(ctx context.Context, subject model.UserOpaqueIdentifier) (err error) {
 	if _, err = p.db.ExecContext(ctx, p.sqlInsertUserOpaqueIdentifier, subject.Service, subject.SectorID, subject.Username, subject.Identifier); err != nil {
@@ -1310,11 +1612,207 @@ func (p *SQLProvider) LoadOAuth2BlacklistedJTI(ctx context.Context, signature st
 	return blacklistedJTI, nil
 }
 
+// SaveOAuth2RegisteredClient saves a dynamically registered OAuth2.0 client to the storage provider.
+func (p *SQLProvider) SaveOAuth2RegisteredClient(ctx context.Context, client model.OAuth2RegisteredClient) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlInsertOAuth2RegisteredClient,
+		client.ClientID, client.CreatedAt, client.UpdatedAt, client.ExpiresAt,
+		client.RegistrationAccessTokenSignature, client.ClientSecret, client.Metadata); err != nil {
+		return fmt.Errorf("error inserting oauth2 registered client with id '%s': %w", client.ClientID, err)
+	}
+
+	return nil
+}
+
+// UpdateOAuth2RegisteredClient updates the metadata and credentials of a dynamically registered OAuth2.0 client.
+func (p *SQLProvider) UpdateOAuth2RegisteredClient(ctx context.Context, client model.OAuth2RegisteredClient) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlUpdateOAuth2RegisteredClient,
+		client.UpdatedAt, client.RegistrationAccessTokenSignature, client.ClientSecret, client.Metadata, client.ClientID); err != nil {
+		return fmt.Errorf("error updating oauth2 registered client with id '%s': %w", client.ClientID, err)
+	}
+
+	return nil
+}
+
+// LoadOAuth2RegisteredClient loads a dynamically registered OAuth2.0 client from the storage provider.
+func (p *SQLProvider) LoadOAuth2RegisteredClient(ctx context.Context, clientID string) (client *model.OAuth2RegisteredClient, err error) {
+	client = &model.OAuth2RegisteredClient{}
+
+	if err = p.db.GetContext(ctx, client, p.sqlSelectOAuth2RegisteredClient, clientID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoOAuth2RegisteredClient
+		}
+
+		return nil, fmt.Errorf("error selecting oauth2 registered client with id '%s': %w", clientID, err)
+	}
+
+	return client, nil
+}
+
+// DeleteOAuth2RegisteredClient deletes a dynamically registered OAuth2.0 client from the storage provider.
+func (p *SQLProvider) DeleteOAuth2RegisteredClient(ctx context.Context, clientID string) (err error) {
+	var result sql.Result
+
+	if result, err = p.db.ExecContext(ctx, p.sqlDeleteOAuth2RegisteredClient, clientID); err != nil {
+		return fmt.Errorf("error deleting oauth2 registered client with id '%s': %w", clientID, err)
+	}
+
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrNoOAuth2RegisteredClient
+	}
+
+	return nil
+}
+
+// SaveOAuth2DeviceCodeSession saves an OAuth2.0 device code session to the storage provider.
+func (p *SQLProvider) SaveOAuth2DeviceCodeSession(ctx context.Context, session model.OAuth2DeviceCodeSession) (err error) {
+	if session.Session, err = p.encrypt(session.Session); err != nil {
+		return fmt.Errorf("error encrypting oauth2 device code session data with signature '%s' and request id '%s': %w", session.Signature, session.RequestID, err)
+	}
+
+	if _, err = p.db.ExecContext(ctx, p.sqlInsertOAuth2DeviceCodeSession,
+		session.ChallengeID, session.RequestID, session.ClientID, session.Signature, session.UserCodeSignature,
+		session.Status, session.Subject, session.RequestedAt, session.RequestedScopes, session.GrantedScopes,
+		session.RequestedAudience, session.GrantedAudience,
+		session.Active, session.Revoked, session.Form, session.Session); err != nil {
+		return fmt.Errorf("error inserting oauth2 device code session data with signature '%s' and request id '%s': %w", session.Signature, session.RequestID, err)
+	}
+
+	return nil
+}
+
+// UpdateOAuth2DeviceCodeSession updates the user response of an OAuth2.0 device code session in the storage provider.
+func (p *SQLProvider) UpdateOAuth2DeviceCodeSession(ctx context.Context, session model.OAuth2DeviceCodeSession) (err error) {
+	if session.Session, err = p.encrypt(session.Session); err != nil {
+		return fmt.Errorf("error encrypting oauth2 device code session data with signature '%s' and request id '%s': %w", session.Signature, session.RequestID, err)
+	}
+
+	var result sql.Result
+
+	if result, err = p.db.ExecContext(ctx, p.sqlUpdateOAuth2DeviceCodeSession,
+		session.ChallengeID, session.Status, session.Subject, session.GrantedScopes, session.GrantedAudience,
+		session.Session, session.Signature); err != nil {
+		return fmt.Errorf("error updating oauth2 device code session data with signature '%s' and request id '%s': %w", session.Signature, session.RequestID, err)
+	}
+
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrNoOAuth2DeviceCodeSession
+	}
+
+	return nil
+}
+
+// UpdateOAuth2DeviceCodeSessionCheckedAt updates the time an OAuth2.0 device code session was last polled in the
+// storage provider.
+func (p *SQLProvider) UpdateOAuth2DeviceCodeSessionCheckedAt(ctx context.Context, signature string, checkedAt time.Time) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlUpdateOAuth2DeviceCodeSessionCheckedAt, checkedAt, signature); err != nil {
+		return fmt.Errorf("error updating oauth2 device code session checked at time with signature '%s': %w", signature, err)
+	}
+
+	return nil
+}
+
+// DeactivateOAuth2DeviceCodeSession marks an active OAuth2.0 device code session as inactive in the storage provider.
+// It returns ErrNoOAuth2DeviceCodeSession if the session does not exist or is already inactive, which ensures a
+// device code is only exchanged once even when it's polled concurrently.
+func (p *SQLProvider) DeactivateOAuth2DeviceCodeSession(ctx context.Context, signature string) (err error) {
+	var result sql.Result
+
+	if result, err = p.db.ExecContext(ctx, p.sqlDeactivateOAuth2DeviceCodeSession, signature); err != nil {
+		return fmt.Errorf("error deactivating oauth2 device code session with signature '%s': %w", signature, err)
+	}
+
+	var affected int64
+
+	if affected, err = result.RowsAffected(); err != nil {
+		return fmt.Errorf("error deactivating oauth2 device code session with signature '%s': %w", signature, err)
+	}
+
+	if affected != 1 {
+		return ErrNoOAuth2DeviceCodeSession
+	}
+
+	return nil
+}
+
+// LoadOAuth2DeviceCodeSession loads an OAuth2.0 device code session from the storage provider given the device
+// code signature.
+func (p *SQLProvider) LoadOAuth2DeviceCodeSession(ctx context.Context, signature string) (session *model.OAuth2DeviceCodeSession, err error) {
+	return p.loadOAuth2DeviceCodeSession(ctx, p.sqlSelectOAuth2DeviceCodeSession, signature)
+}
+
+// LoadOAuth2DeviceCodeSessionByUserCode loads an OAuth2.0 device code session from the storage provider given the
+// user code signature.
+func (p *SQLProvider) LoadOAuth2DeviceCodeSessionByUserCode(ctx context.Context, userCodeSignature string) (session *model.OAuth2DeviceCodeSession, err error) {
+	return p.loadOAuth2DeviceCodeSession(ctx, p.sqlSelectOAuth2DeviceCodeSessionByUserCode, userCodeSignature)
+}
+
+func (p *SQLProvider) loadOAuth2DeviceCodeSession(ctx context.Context, query, signature string) (session *model.OAuth2DeviceCodeSession, err error) {
+	session = &model.OAuth2DeviceCodeSession{}
+
+	if err = p.db.GetContext(ctx, session, query, signature); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoOAuth2DeviceCodeSession
+		}
+
+		return nil, fmt.Errorf("error selecting oauth2 device code session with signature '%s': %w", signature, err)
+	}
+
+	if session.Session, err = p.decrypt(session.Session); err != nil {
+		return nil, fmt.Errorf("error decrypting the oauth2 device code session data with signature '%s' and request id '%s': %w", signature, session.RequestID, err)
+	}
+
+	return session, nil
+}
+
+// SaveOAuth2ClientSession saves an OAuth2.0 client session which records that a client holds a session for the user
+// session to the storage provider.
+func (p *SQLProvider) SaveOAuth2ClientSession(ctx context.Context, session model.OAuth2ClientSession) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlUpsertOAuth2ClientSession,
+		session.SessionID, session.ClientID, session.Subject, session.Username, session.Issuer, session.CreatedAt); err != nil {
+		return fmt.Errorf("error upserting oauth2 client session for session id '%s' and client id '%s': %w", session.SessionID, session.ClientID, err)
+	}
+
+	return nil
+}
+
+// LoadOAuth2ClientSessions loads the OAuth2.0 client sessions which have not been logged out for a user session from
+// the storage provider.
+func (p *SQLProvider) LoadOAuth2ClientSessions(ctx context.Context, sessionID uuid.UUID) (sessions []model.OAuth2ClientSession, err error) {
+	sessions = make([]model.OAuth2ClientSession, 0)
+
+	if err = p.db.SelectContext(ctx, &sessions, p.sqlSelectOAuth2ClientSessions, sessionID); err != nil {
+		return nil, fmt.Errorf("error selecting oauth2 client sessions for session id '%s': %w", sessionID, err)
+	}
+
+	return sessions, nil
+}
+
+// LoadOAuth2ClientSessionsByUsername loads the OAuth2.0 client sessions which have not been logged out for a user from
+// the storage provider.
+func (p *SQLProvider) LoadOAuth2ClientSessionsByUsername(ctx context.Context, username string) (sessions []model.OAuth2ClientSession, err error) {
+	sessions = make([]model.OAuth2ClientSession, 0)
+
+	if err = p.db.SelectContext(ctx, &sessions, p.sqlSelectOAuth2ClientSessionsByUsername, username); err != nil {
+		return nil, fmt.Errorf("error selecting oauth2 client sessions for user '%s': %w", username, err)
+	}
+
+	return sessions, nil
+}
+
+// LogoutOAuth2ClientSession marks an OAuth2.0 client session as logged out in the storage provider.
+func (p *SQLProvider) LogoutOAuth2ClientSession(ctx context.Context, id int, at time.Time) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlUpdateOAuth2ClientSessionLoggedOut, at, id); err != nil {
+		return fmt.Errorf("error updating oauth2 client session with id '%d' as logged out: %w", id, err)
+	}
+
+	return nil
+}
+
 // AppendAuthenticationLog saves an authentication attempt to the storage provider.
 func (p *SQLProvider) AppendAuthenticationLog(ctx context.Context, attempt model.AuthenticationAttempt) (err error) {
 	if _, err = p.db.ExecContext(ctx, p.sqlInsertAuthenticationAttempt,
 		attempt.Time, attempt.Successful, attempt.Banned, attempt.Username,
-		attempt.Type, attempt.RemoteIP, attempt.RequestURI, attempt.RequestMethod); err != nil {
+		attempt.Type, attempt.RemoteIP, attempt.RemoteSubnet, attempt.RequestURI, attempt.RequestMethod); err != nil {
 		return fmt.Errorf("error inserting authentication attempt for user '%s': %w", attempt.Username, err)
 	}
 
@@ -1335,3 +1833,158 @@ func (p *SQLProvider) LoadAuthenticationLogs(ctx context.Context, username strin
 
 	return attempts, nil
 }
+
+// LoadFailedAuthenticationLogsByRemoteIP loads the failed authentication attempts from a remote ip which did not occur
+// while banned from the storage provider (paginated).
+func (p *SQLProvider) LoadFailedAuthenticationLogsByRemoteIP(ctx context.Context, ip net.IP, fromDate time.Time, limit, page int) (attempts []model.AuthenticationAttempt, err error) {
+	attempts = make([]model.AuthenticationAttempt, 0, limit)
+
+	if err = p.db.SelectContext(ctx, &attempts, p.sqlSelectFailedAuthenticationAttemptsByRemoteIP, fromDate, model.NewNullIP(ip), limit, limit*page); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoAuthenticationLogs
+		}
+
+		return nil, fmt.Errorf("error selecting authentication logs for remote ip '%s': %w", ip, err)
+	}
+
+	return attempts, nil
+}
+
+// LoadFailedAuthenticationLogsByRemoteSubnet loads the failed authentication attempts from a subnet which did not
+// occur while banned from the storage provider (paginated).
+func (p *SQLProvider) LoadFailedAuthenticationLogsByRemoteSubnet(ctx context.Context, subnet string, fromDate time.Time, limit, page int) (attempts []model.AuthenticationAttempt, err error) {
+	attempts = make([]model.AuthenticationAttempt, 0, limit)
+
+	if err = p.db.SelectContext(ctx, &attempts, p.sqlSelectFailedAuthenticationAttemptsByRemoteSubnet, fromDate, subnet, limit, limit*page); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoAuthenticationLogs
+		}
+
+		return nil, fmt.Errorf("error selecting authentication logs for subnet '%s': %w", subnet, err)
+	}
+
+	return attempts, nil
+}
+
+// SaveRegulationBan saves a ban of a user, remote ip, or subnet to the storage provider.
+func (p *SQLProvider) SaveRegulationBan(ctx context.Context, ban model.RegulationBan) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlInsertRegulationBan,
+		ban.CreatedAt, ban.ExpiresAt, ban.RevokedAt, ban.Kind, ban.Value, ban.Source, ban.Reason); err != nil {
+		return fmt.Errorf("error inserting regulation ban of %s '%s': %w", ban.Kind, ban.Value, err)
+	}
+
+	return nil
+}
+
+// LoadRegulationBans loads the bans of a user, remote ip, or subnet which are active at the given time or which have
+// been revoked since a time from the storage provider.
+func (p *SQLProvider) LoadRegulationBans(ctx context.Context, kind, value string, now, since time.Time) (bans []model.RegulationBan, err error) {
+	if err = p.db.SelectContext(ctx, &bans, p.sqlSelectRegulationBansByValue, kind, value, now, since); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error selecting regulation bans of %s '%s': %w", kind, value, err)
+	}
+
+	return bans, nil
+}
+
+// LoadActiveRegulationBans loads the bans which are active at the given time from the storage provider (paginated).
+func (p *SQLProvider) LoadActiveRegulationBans(ctx context.Context, now time.Time, limit, page int) (bans []model.RegulationBan, err error) {
+	bans = make([]model.RegulationBan, 0, limit)
+
+	if err = p.db.SelectContext(ctx, &bans, p.sqlSelectRegulationBansActive, now, limit, limit*page); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error selecting active regulation bans: %w", err)
+	}
+
+	return bans, nil
+}
+
+// LoadActiveRegulationBansByKind loads the bans of a kind which are active at the given time from the storage provider.
+func (p *SQLProvider) LoadActiveRegulationBansByKind(ctx context.Context, kind string, now time.Time) (bans []model.RegulationBan, err error) {
+	if err = p.db.SelectContext(ctx, &bans, p.sqlSelectRegulationBansActiveByKind, kind, now); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error selecting active regulation bans of kind '%s': %w", kind, err)
+	}
+
+	return bans, nil
+}
+
+// RevokeRegulationBans marks every ban of a user, remote ip, or subnet which is active at the given time as revoked.
+func (p *SQLProvider) RevokeRegulationBans(ctx context.Context, kind, value string, revokedAt time.Time) (count int64, err error) {
+	var result sql.Result
+
+	if result, err = p.db.ExecContext(ctx, p.sqlRevokeRegulationBans, revokedAt, kind, value, revokedAt); err != nil {
+		return 0, fmt.Errorf("error revoking regulation bans of %s '%s': %w", kind, value, err)
+	}
+
+	if count, err = result.RowsAffected(); err != nil {
+		return 0, fmt.Errorf("error revoking regulation bans of %s '%s': %w", kind, value, err)
+	}
+
+	return count, nil
+}
+
+// AppendAuthorizationDecisions saves a batch of authorization decisions to the storage provider in a single
+// transaction.
+func (p *SQLProvider) AppendAuthorizationDecisions(ctx context.Context, decisions []model.AuthorizationDecision) (err error) {
+	var tx *sqlx.Tx
+
+	if tx, err = p.db.BeginTxx(ctx, nil); err != nil {
+		return fmt.Errorf("error beginning transaction to insert authorization decisions: %w", err)
+	}
+
+	for _, decision := range decisions {
+		if _, err = tx.ExecContext(ctx, p.sqlInsertAuthorizationDecision,
+			decision.Time, decision.Decision, decision.StatusCode, decision.Username, decision.Groups, decision.ClientID,
+			decision.RemoteIP, decision.RequestMethod, decision.RequestURI, decision.Domain, decision.RulePosition,
+			decision.RequiredLevel, decision.CurrentLevel, decision.Implementation); err != nil {
+			if rerr := tx.Rollback(); rerr != nil {
+				return fmt.Errorf("error inserting authorization decision for domain '%s': rollback error %v: %w", decision.Domain, rerr, err)
+			}
+
+			return fmt.Errorf("error inserting authorization decision for domain '%s': %w", decision.Domain, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction to insert authorization decisions: %w", err)
+	}
+
+	return nil
+}
+
+// LoadAuthorizationDecisions loads authorization decisions made since a time from the storage provider (paginated).
+// The username, domain, and decision are only used to filter the results when they're not empty.
+func (p *SQLProvider) LoadAuthorizationDecisions(ctx context.Context, username, domain, decision string, since time.Time, limit, page int) (decisions []model.AuthorizationDecision, err error) {
+	decisions = make([]model.AuthorizationDecision, 0, limit)
+
+	if err = p.db.SelectContext(ctx, &decisions, p.sqlSelectAuthorizationDecisions, since, username, domain, decision, limit, limit*page); err != nil {
+		return nil, fmt.Errorf("error selecting authorization decisions: %w", err)
+	}
+
+	return decisions, nil
+}
+
+// PruneAuthorizationDecisions deletes the authorization decisions made before a time from the storage provider.
+func (p *SQLProvider) PruneAuthorizationDecisions(ctx context.Context, before time.Time) (count int64, err error) {
+	var result sql.Result
+
+	if result, err = p.db.ExecContext(ctx, p.sqlDeleteAuthorizationDecisionsBefore, before); err != nil {
+		return 0, fmt.Errorf("error deleting authorization decisions before '%s': %w", before.Format(time.RFC3339), err)
+	}
+
+	if count, err = result.RowsAffected(); err != nil {
+		return 0, fmt.Errorf("error deleting authorization decisions before '%s': %w", before.Format(time.RFC3339), err)
+	}
+
+	return count, nil
+}
@@ -35,6 +35,7 @@ func NewPostgreSQLProvider(config *schema.Configuration, caCertPool *x509.CertPo
 	provider.sqlUpsertPreferred2FAMethod = fmt.Sprintf(queryFmtUpsertPreferred2FAMethodPostgreSQL, tableUserPreferences)
 	provider.sqlUpsertEncryptionValue = fmt.Sprintf(queryFmtUpsertEncryptionValuePostgreSQL, tableEncryption)
 	provider.sqlUpsertOAuth2BlacklistedJTI = fmt.Sprintf(queryFmtUpsertOAuth2BlacklistedJTIPostgreSQL, tableOAuth2BlacklistedJTI)
+	provider.sqlUpsertOAuth2ClientSession = fmt.Sprintf(queryFmtUpsertOAuth2ClientSessionPostgreSQL, tableOAuth2ClientSession)
 	provider.sqlInsertOAuth2ConsentPreConfiguration = fmt.Sprintf(queryFmtInsertOAuth2ConsentPreConfigurationPostgreSQL, tableOAuth2ConsentPreConfiguration)
 
 	// PostgreSQL requires rebinding of any query that contains a '?' placeholder to use the '$#' notation placeholders.
@@ -43,6 +44,24 @@ func NewPostgreSQLProvider(config *schema.Configuration, caCertPool *x509.CertPo
 	provider.sqlSelectPreferred2FAMethod = provider.db.Rebind(provider.sqlSelectPreferred2FAMethod)
 	provider.sqlSelectUserInfo = provider.db.Rebind(provider.sqlSelectUserInfo)
 
+	provider.sqlInsertUser = provider.db.Rebind(provider.sqlInsertUser)
+	provider.sqlUpdateUser = provider.db.Rebind(provider.sqlUpdateUser)
+	provider.sqlUpdateUserPassword = provider.db.Rebind(provider.sqlUpdateUserPassword)
+	provider.sqlSelectUser = provider.db.Rebind(provider.sqlSelectUser)
+	provider.sqlSelectUsers = provider.db.Rebind(provider.sqlSelectUsers)
+
+	provider.sqlInsertUserSession = provider.db.Rebind(provider.sqlInsertUserSession)
+	provider.sqlUpdateUserSessionActivity = provider.db.Rebind(provider.sqlUpdateUserSessionActivity)
+	provider.sqlRevokeUserSession = provider.db.Rebind(provider.sqlRevokeUserSession)
+	provider.sqlRevokeUserSessionsByUsername = provider.db.Rebind(provider.sqlRevokeUserSessionsByUsername)
+	provider.sqlSelectUserSession = provider.db.Rebind(provider.sqlSelectUserSession)
+	provider.sqlSelectUserSessionsActiveByUsername = provider.db.Rebind(provider.sqlSelectUserSessionsActiveByUsername)
+
+	provider.sqlInsertKnownDevice = provider.db.Rebind(provider.sqlInsertKnownDevice)
+	provider.sqlUpdateKnownDeviceActivity = provider.db.Rebind(provider.sqlUpdateKnownDeviceActivity)
+	provider.sqlDeleteKnownDevice = provider.db.Rebind(provider.sqlDeleteKnownDevice)
+	provider.sqlSelectKnownDevicesByUsername = provider.db.Rebind(provider.sqlSelectKnownDevicesByUsername)
+
 	provider.sqlInsertUserOpaqueIdentifier = provider.db.Rebind(provider.sqlInsertUserOpaqueIdentifier)
 	provider.sqlSelectUserOpaqueIdentifier = provider.db.Rebind(provider.sqlSelectUserOpaqueIdentifier)
 	provider.sqlSelectUserOpaqueIdentifierBySignature = provider.db.Rebind(provider.sqlSelectUserOpaqueIdentifierBySignature)
@@ -88,6 +107,18 @@ func NewPostgreSQLProvider(config *schema.Configuration, caCertPool *x509.CertPo
 
 	provider.sqlInsertAuthenticationAttempt = provider.db.Rebind(provider.sqlInsertAuthenticationAttempt)
 	provider.sqlSelectAuthenticationAttemptsByUsername = provider.db.Rebind(provider.sqlSelectAuthenticationAttemptsByUsername)
+	provider.sqlSelectFailedAuthenticationAttemptsByRemoteIP = provider.db.Rebind(provider.sqlSelectFailedAuthenticationAttemptsByRemoteIP)
+	provider.sqlSelectFailedAuthenticationAttemptsByRemoteSubnet = provider.db.Rebind(provider.sqlSelectFailedAuthenticationAttemptsByRemoteSubnet)
+
+	provider.sqlInsertRegulationBan = provider.db.Rebind(provider.sqlInsertRegulationBan)
+	provider.sqlRevokeRegulationBans = provider.db.Rebind(provider.sqlRevokeRegulationBans)
+	provider.sqlSelectRegulationBansByValue = provider.db.Rebind(provider.sqlSelectRegulationBansByValue)
+	provider.sqlSelectRegulationBansActive = provider.db.Rebind(provider.sqlSelectRegulationBansActive)
+	provider.sqlSelectRegulationBansActiveByKind = provider.db.Rebind(provider.sqlSelectRegulationBansActiveByKind)
+
+	provider.sqlInsertAuthorizationDecision = provider.db.Rebind(provider.sqlInsertAuthorizationDecision)
+	provider.sqlSelectAuthorizationDecisions = provider.db.Rebind(provider.sqlSelectAuthorizationDecisions)
+	provider.sqlDeleteAuthorizationDecisionsBefore = provider.db.Rebind(provider.sqlDeleteAuthorizationDecisionsBefore)
 
 	provider.sqlInsertMigration = provider.db.Rebind(provider.sqlInsertMigration)
 	provider.sqlSelectMigrations = provider.db.Rebind(provider.sqlSelectMigrations)
@@ -145,6 +176,22 @@ func NewPostgreSQLProvider(config *schema.Configuration, caCertPool *x509.CertPo
 
 	provider.sqlSelectOAuth2BlacklistedJTI = provider.db.Rebind(provider.sqlSelectOAuth2BlacklistedJTI)
 
+	provider.sqlInsertOAuth2RegisteredClient = provider.db.Rebind(provider.sqlInsertOAuth2RegisteredClient)
+	provider.sqlUpdateOAuth2RegisteredClient = provider.db.Rebind(provider.sqlUpdateOAuth2RegisteredClient)
+	provider.sqlSelectOAuth2RegisteredClient = provider.db.Rebind(provider.sqlSelectOAuth2RegisteredClient)
+	provider.sqlDeleteOAuth2RegisteredClient = provider.db.Rebind(provider.sqlDeleteOAuth2RegisteredClient)
+
+	provider.sqlInsertOAuth2DeviceCodeSession = provider.db.Rebind(provider.sqlInsertOAuth2DeviceCodeSession)
+	provider.sqlUpdateOAuth2DeviceCodeSession = provider.db.Rebind(provider.sqlUpdateOAuth2DeviceCodeSession)
+	provider.sqlUpdateOAuth2DeviceCodeSessionCheckedAt = provider.db.Rebind(provider.sqlUpdateOAuth2DeviceCodeSessionCheckedAt)
+	provider.sqlDeactivateOAuth2DeviceCodeSession = provider.db.Rebind(provider.sqlDeactivateOAuth2DeviceCodeSession)
+	provider.sqlSelectOAuth2DeviceCodeSession = provider.db.Rebind(provider.sqlSelectOAuth2DeviceCodeSession)
+	provider.sqlSelectOAuth2DeviceCodeSessionByUserCode = provider.db.Rebind(provider.sqlSelectOAuth2DeviceCodeSessionByUserCode)
+
+	provider.sqlSelectOAuth2ClientSessions = provider.db.Rebind(provider.sqlSelectOAuth2ClientSessions)
+	provider.sqlSelectOAuth2ClientSessionsByUsername = provider.db.Rebind(provider.sqlSelectOAuth2ClientSessionsByUsername)
+	provider.sqlUpdateOAuth2ClientSessionLoggedOut = provider.db.Rebind(provider.sqlUpdateOAuth2ClientSessionLoggedOut)
+
 	provider.schema = config.Storage.PostgreSQL.Schema
 
 	return provider
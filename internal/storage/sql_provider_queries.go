@@ -275,8 +275,8 @@ const (
 
 const (
 	queryFmtInsertAuthenticationLogEntry = `
-		INSERT INTO %s (time, successful, banned, username, auth_type, remote_ip, request_uri, request_method)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?);`
+		INSERT INTO %s (time, successful, banned, username, auth_type, remote_ip, remote_subnet, request_uri, request_method)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);`
 
 	queryFmtSelect1FAAuthenticationLogEntryByUsername = `
 		SELECT time, successful, username
@@ -285,6 +285,74 @@ const (
 		ORDER BY time DESC
 		LIMIT ?
 		OFFSET ?;`
+
+	queryFmtSelectFailedAuthenticationLogEntryByRemoteIP = `
+		SELECT time, successful, username, auth_type, remote_ip
+		FROM %s
+		WHERE time > ? AND remote_ip = ? AND successful = FALSE AND banned = FALSE
+		ORDER BY time DESC
+		LIMIT ?
+		OFFSET ?;`
+
+	queryFmtSelectFailedAuthenticationLogEntryByRemoteSubnet = `
+		SELECT time, successful, username, auth_type, remote_ip, remote_subnet
+		FROM %s
+		WHERE time > ? AND remote_subnet = ? AND successful = FALSE AND banned = FALSE
+		ORDER BY time DESC
+		LIMIT ?
+		OFFSET ?;`
+)
+
+const (
+	queryFmtInsertRegulationBan = `
+		INSERT INTO %s (created_at, expires_at, revoked_at, kind, value, source, reason)
+		VALUES (?, ?, ?, ?, ?, ?, ?);`
+
+	queryFmtRevokeRegulationBans = `
+		UPDATE %s
+		SET revoked_at = ?
+		WHERE kind = ? AND value = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?);`
+
+	queryFmtSelectRegulationBansByValue = `
+		SELECT id, created_at, expires_at, revoked_at, kind, value, source, reason
+		FROM %s
+		WHERE kind = ? AND value = ? AND ((revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)) OR revoked_at > ?)
+		ORDER BY created_at DESC, id DESC;`
+
+	queryFmtSelectRegulationBansActive = `
+		SELECT id, created_at, expires_at, revoked_at, kind, value, source, reason
+		FROM %s
+		WHERE revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)
+		ORDER BY created_at DESC, id DESC
+		LIMIT ?
+		OFFSET ?;`
+
+	queryFmtSelectRegulationBansActiveByKind = `
+		SELECT id, created_at, expires_at, revoked_at, kind, value, source, reason
+		FROM %s
+		WHERE kind = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)
+		ORDER BY created_at DESC, id DESC;`
+)
+
+const (
+	queryFmtInsertAuthorizationLogEntry = `
+		INSERT INTO %s (time, decision, status_code, username, user_groups, client_id, remote_ip, request_method,
+			request_uri, domain, rule_position, required_level, current_level, implementation)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+
+	queryFmtSelectAuthorizationLogEntries = `
+		SELECT id, time, decision, status_code, username, user_groups, client_id, remote_ip, request_method, request_uri,
+			domain, rule_position, required_level, current_level, implementation
+		FROM %s
+		WHERE time >= ? AND username = COALESCE(NULLIF(?, ''), username) AND domain = COALESCE(NULLIF(?, ''), domain)
+			AND decision = COALESCE(NULLIF(?, ''), decision)
+		ORDER BY time DESC, id DESC
+		LIMIT ?
+		OFFSET ?;`
+
+	queryFmtDeleteAuthorizationLogEntriesBefore = `
+		DELETE FROM %s
+		WHERE time < ?;`
 )
 
 const (
@@ -383,6 +451,11 @@ const (
 		SET active = FALSE
 		WHERE signature = ?;`
 
+	queryFmtDeactivateOAuth2DeviceCodeSession = `
+		UPDATE %s
+		SET active = FALSE
+		WHERE signature = ? AND active = TRUE;`
+
 	queryFmtDeactivateOAuth2SessionByRequestID = `
 		UPDATE %s
 		SET active = FALSE
@@ -413,6 +486,79 @@ const (
 		FROM %s
 		WHERE signature = ?;`
 
+	queryFmtInsertOAuth2RegisteredClient = `
+		INSERT INTO %s (client_id, created_at, updated_at, expires_at, registration_access_token_signature, client_secret, metadata)
+		VALUES (?, ?, ?, ?, ?, ?, ?);`
+
+	queryFmtUpdateOAuth2RegisteredClient = `
+		UPDATE %s
+		SET updated_at = ?, registration_access_token_signature = ?, client_secret = ?, metadata = ?
+		WHERE client_id = ?;`
+
+	queryFmtSelectOAuth2RegisteredClient = `
+		SELECT id, client_id, created_at, updated_at, expires_at, registration_access_token_signature, client_secret, metadata
+		FROM %s
+		WHERE client_id = ?;`
+
+	queryFmtDeleteOAuth2RegisteredClient = `
+		DELETE FROM %s
+		WHERE client_id = ?;`
+
+	queryFmtInsertOAuth2DeviceCodeSession = `
+		INSERT INTO %s (challenge_id, request_id, client_id, signature, user_code_signature, status, subject, requested_at,
+		requested_scopes, granted_scopes, requested_audience, granted_audience,
+		active, revoked, form_data, session_data)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+
+	queryFmtUpdateOAuth2DeviceCodeSession = `
+		UPDATE %s
+		SET challenge_id = ?, status = ?, subject = ?, granted_scopes = ?, granted_audience = ?, session_data = ?
+		WHERE signature = ? AND active = TRUE AND revoked = FALSE;`
+
+	queryFmtUpdateOAuth2DeviceCodeSessionCheckedAt = `
+		UPDATE %s
+		SET checked_at = ?
+		WHERE signature = ?;`
+
+	queryFmtSelectOAuth2DeviceCodeSession = `
+		SELECT id, challenge_id, request_id, client_id, signature, user_code_signature, status, subject, requested_at,
+		checked_at, requested_scopes, granted_scopes, requested_audience, granted_audience,
+		active, revoked, form_data, session_data
+		FROM %s
+		WHERE signature = ? AND revoked = FALSE;`
+
+	queryFmtSelectOAuth2DeviceCodeSessionByUserCode = `
+		SELECT id, challenge_id, request_id, client_id, signature, user_code_signature, status, subject, requested_at,
+		checked_at, requested_scopes, granted_scopes, requested_audience, granted_audience,
+		active, revoked, form_data, session_data
+		FROM %s
+		WHERE user_code_signature = ? AND revoked = FALSE;`
+
+	queryFmtUpsertOAuth2ClientSession = `
+		REPLACE INTO %s (session_id, client_id, subject, username, issuer, created_at)
+		VALUES (?, ?, ?, ?, ?, ?);`
+
+	queryFmtUpsertOAuth2ClientSessionPostgreSQL = `
+		INSERT INTO %s (session_id, client_id, subject, username, issuer, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (session_id, client_id)
+			DO UPDATE SET subject = $3, username = $4, issuer = $5, created_at = $6, logged_out_at = NULL;`
+
+	queryFmtSelectOAuth2ClientSessions = `
+		SELECT id, session_id, client_id, subject, username, issuer, created_at, logged_out_at
+		FROM %s
+		WHERE session_id = ? AND logged_out_at IS NULL;`
+
+	queryFmtSelectOAuth2ClientSessionsByUsername = `
+		SELECT id, session_id, client_id, subject, username, issuer, created_at, logged_out_at
+		FROM %s
+		WHERE username = ? AND logged_out_at IS NULL;`
+
+	queryFmtUpdateOAuth2ClientSessionLoggedOut = `
+		UPDATE %s
+		SET logged_out_at = ?
+		WHERE id = ?;`
+
 	queryFmtUpsertOAuth2BlacklistedJTI = `
 		REPLACE INTO %s (signature, expires_at)
 		VALUES(?, ?);`
@@ -452,3 +598,84 @@ const (
 		SELECT id, service, sector_id, username, identifier
 		FROM %s;`
 )
+
+const (
+	queryFmtInsertUser = `
+		INSERT INTO %s (created_at, updated_at, username, display_name, email, group_names, password, disabled)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?);`
+
+	queryFmtUpdateUser = `
+		UPDATE %s
+		SET updated_at = ?, display_name = ?, email = ?, group_names = ?, disabled = ?
+		WHERE username = ?;`
+
+	queryFmtUpdateUserPassword = `
+		UPDATE %s
+		SET updated_at = ?, password_changed_at = ?, password = ?
+		WHERE username = ?;`
+
+	queryFmtSelectUser = `
+		SELECT id, created_at, updated_at, password_changed_at, username, display_name, email, group_names, password, disabled
+		FROM %s
+		WHERE username = ?;`
+
+	queryFmtSelectUsers = `
+		SELECT id, created_at, updated_at, password_changed_at, username, display_name, email, group_names, password, disabled
+		FROM %s
+		ORDER BY id
+		LIMIT ?
+		OFFSET ?;`
+)
+
+const (
+	queryFmtInsertUserSession = `
+		INSERT INTO %s (public_id, created_at, last_active_at, expires_at, username, cookie_domain, ip, user_agent, device)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);`
+
+	queryFmtUpdateUserSessionActivity = `
+		UPDATE %s
+		SET last_active_at = ?, expires_at = ?, ip = ?
+		WHERE public_id = ?;`
+
+	queryFmtRevokeUserSession = `
+		UPDATE %s
+		SET revoked_at = ?
+		WHERE public_id = ? AND username = ? AND revoked_at IS NULL;`
+
+	queryFmtRevokeUserSessionsByUsername = `
+		UPDATE %s
+		SET revoked_at = ?
+		WHERE username = ? AND revoked_at IS NULL;`
+
+	queryFmtSelectUserSession = `
+		SELECT id, public_id, created_at, last_active_at, expires_at, revoked_at, username, cookie_domain, ip, user_agent, device
+		FROM %s
+		WHERE public_id = ?;`
+
+	queryFmtSelectUserSessionsActiveByUsername = `
+		SELECT id, public_id, created_at, last_active_at, expires_at, revoked_at, username, cookie_domain, ip, user_agent, device
+		FROM %s
+		WHERE username = ? AND revoked_at IS NULL AND expires_at > ?
+		ORDER BY last_active_at DESC;`
+)
+
+const (
+	queryFmtInsertKnownDevice = `
+		INSERT INTO %s (created_at, last_used_at, username, device_id, subnet, user_agent, device)
+		VALUES (?, ?, ?, ?, ?, ?, ?);`
+
+	queryFmtUpdateKnownDeviceActivity = `
+		UPDATE %s
+		SET last_used_at = ?, user_agent = ?, device = ?
+		WHERE id = ?;`
+
+	queryFmtDeleteKnownDevice = `
+		DELETE FROM %s
+		WHERE id = ? AND username = ?;`
+
+	queryFmtSelectKnownDevicesByUsername = `
+		SELECT id, created_at, last_used_at, username, device_id, subnet, user_agent, device
+		FROM %s
+		WHERE username = ? AND last_used_at >= ?
+		ORDER BY last_used_at DESC, id DESC;`
+)
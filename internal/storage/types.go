@@ -115,6 +115,7 @@ const (
 	OAuth2SessionTypePAR
 	OAuth2SessionTypePKCEChallenge
 	OAuth2SessionTypeRefreshToken
+	OAuth2SessionTypeDeviceCode
 )
 
 // String returns a string representation of this OAuth2SessionType.
@@ -132,6 +133,8 @@ func (s OAuth2SessionType) String() string {
 		return "pkce challenge"
 	case OAuth2SessionTypeRefreshToken:
 		return "refresh token"
+	case OAuth2SessionTypeDeviceCode:
+		return "device code"
 	default:
 		return "invalid"
 	}
@@ -152,6 +155,8 @@ func (s OAuth2SessionType) Table() string {
 		return tableOAuth2PKCERequestSession
 	case OAuth2SessionTypeRefreshToken:
 		return tableOAuth2RefreshTokenSession
+	case OAuth2SessionTypeDeviceCode:
+		return tableOAuth2DeviceCodeSession
 	default:
 		return ""
 	}
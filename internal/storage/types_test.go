@@ -82,6 +82,9 @@ func TestOAuth2SessionType(t *testing.T) {
 	assert.Equal(t, "refresh token", OAuth2SessionTypeRefreshToken.String())
 	assert.Equal(t, tableOAuth2RefreshTokenSession, OAuth2SessionTypeRefreshToken.Table())
 
+	assert.Equal(t, "device code", OAuth2SessionTypeDeviceCode.String())
+	assert.Equal(t, tableOAuth2DeviceCodeSession, OAuth2SessionTypeDeviceCode.Table())
+
 	assert.Equal(t, "invalid", OAuth2SessionType(-1).String())
 	assert.Equal(t, "", OAuth2SessionType(-1).Table())
 }
@@ -12,6 +12,8 @@ const (
 	TemplateNameEmailEvent                   = "Event"
 
 	TemplateNameOIDCAuthorizeFormPost = "AuthorizeResponseFormPost.html"
+	TemplateNameOIDCEndSession        = "EndSession.html"
+	TemplateNameOIDCEndSessionConfirm = "EndSessionConfirm.html"
 )
 
 // Template Category Names.
@@ -7,6 +7,7 @@ import (
 	"encoding/base32"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"hash"
 	"net/url"
@@ -88,6 +89,8 @@ func FuncMap() map[string]any {
 		"uuidv4":      FuncUUIDv4,
 		"urlquery":    url.QueryEscape,
 		"urlunquery":  url.QueryUnescape,
+		"toJson":      FuncToJSON,
+		"mustToJson":  FuncMustToJSON,
 	}
 }
 
@@ -471,6 +474,23 @@ func FuncUUIDv4() string {
 	return uuid.New().String()
 }
 
+// FuncToJSON is a helper function that provides similar functionality to the helm toJson func.
+func FuncToJSON(v any) string {
+	output, _ := json.Marshal(v)
+
+	return string(output)
+}
+
+// FuncMustToJSON is a helper function that provides similar functionality to the helm mustToJson func.
+func FuncMustToJSON(v any) (string, error) {
+	output, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return string(output), nil
+}
+
 // FuncFileContent returns the file content.
 func FuncFileContent(path string) (data string, err error) {
 	var raw []byte
@@ -723,6 +723,19 @@ func TestFuncUUIDv4(t *testing.T) {
 	assert.Len(t, FuncUUIDv4(), 36)
 }
 
+func TestFuncToJSON(t *testing.T) {
+	assert.Equal(t, `"line one\nline \"two\""`, FuncToJSON("line one\nline \"two\""))
+	assert.Equal(t, `{"a":["b","c"]}`, FuncToJSON(map[string]any{"a": []string{"b", "c"}}))
+	assert.Equal(t, "", FuncToJSON(make(chan int)))
+
+	output, err := FuncMustToJSON([]string{"a"})
+	assert.NoError(t, err)
+	assert.Equal(t, `["a"]`, output)
+
+	_, err = FuncMustToJSON(make(chan int))
+	assert.EqualError(t, err, "json: unsupported type: chan int")
+}
+
 func TestFuncFileContent(t *testing.T) {
 	testCases := []struct {
 		name           string
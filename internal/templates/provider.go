@@ -104,6 +104,18 @@ func (p *Provider) GetOpenIDConnectAuthorizeResponseFormPostTemplate() (t *th.Te
 	return p.templates.oidc.formpost
 }
 
+// GetOpenIDConnectEndSessionTemplate returns a Template used to generate the OpenID Connect 1.0 RP-Initiated Logout
+// response which performs the Front-Channel Logout.
+func (p *Provider) GetOpenIDConnectEndSessionTemplate() (t *th.Template) {
+	return p.templates.oidc.endsession
+}
+
+// GetOpenIDConnectEndSessionConfirmTemplate returns a Template used to generate the OpenID Connect 1.0 RP-Initiated
+// Logout response which asks the user to confirm the logout.
+func (p *Provider) GetOpenIDConnectEndSessionConfirmTemplate() (t *th.Template) {
+	return p.templates.oidc.endsessionconfirm
+}
+
 func (p *Provider) load() (err error) {
 	var errs []error
 
@@ -130,6 +142,24 @@ func (p *Provider) load() (err error) {
 		errs = append(errs, err)
 	}
 
+	if data, err = embedFS.ReadFile(path.Join("embed", TemplateCategoryOpenIDConnect, TemplateNameOIDCEndSession)); err != nil {
+		errs = append(errs, err)
+	} else if p.templates.oidc.endsession, err = th.
+		New("oidc/EndSession.html").
+		Funcs(FuncMap()).
+		Parse(string(data)); err != nil {
+		errs = append(errs, err)
+	}
+
+	if data, err = embedFS.ReadFile(path.Join("embed", TemplateCategoryOpenIDConnect, TemplateNameOIDCEndSessionConfirm)); err != nil {
+		errs = append(errs, err)
+	} else if p.templates.oidc.endsessionconfirm, err = th.
+		New("oidc/EndSessionConfirm.html").
+		Funcs(FuncMap()).
+		Parse(string(data)); err != nil {
+		errs = append(errs, err)
+	}
+
 	if len(errs) != 0 {
 		for i, e := range errs {
 			if i == 0 {
@@ -14,7 +14,9 @@ type Templates struct {
 }
 
 type OpenIDConnectTemplates struct {
-	formpost *th.Template
+	formpost          *th.Template
+	endsession        *th.Template
+	endsessionconfirm *th.Template
 }
 
 // AssetTemplates are templates for specific key assets.
@@ -57,34 +59,34 @@ type EmailTemplate struct {
 
 // EmailEventValues are the values used for event templates.
 type EmailEventValues struct {
-	Title       string
-	BodyPrefix  string
-	BodySuffix  string
-	BodyEvent   string
-	DisplayName string
-	Details     map[string]any
-	RemoteIP    string
+	Title       string         `json:"title"`
+	BodyPrefix  string         `json:"body_prefix"`
+	BodySuffix  string         `json:"body_suffix"`
+	BodyEvent   string         `json:"body_event"`
+	DisplayName string         `json:"display_name"`
+	Details     map[string]any `json:"details"`
+	RemoteIP    string         `json:"remote_ip"`
 }
 
 // EmailIdentityVerificationJWTValues are the values used for the identity verification JWT templates.
 type EmailIdentityVerificationJWTValues struct {
-	Title              string
-	DisplayName        string
-	Domain             string
-	RemoteIP           string
-	LinkURL            string
-	LinkText           string
-	RevocationLinkURL  string
-	RevocationLinkText string
+	Title              string `json:"title"`
+	DisplayName        string `json:"display_name"`
+	Domain             string `json:"domain"`
+	RemoteIP           string `json:"remote_ip"`
+	LinkURL            string `json:"link_url"`
+	LinkText           string `json:"link_text"`
+	RevocationLinkURL  string `json:"revocation_link_url"`
+	RevocationLinkText string `json:"revocation_link_text"`
 }
 
 // EmailIdentityVerificationOTCValues are the values used for the identity verification OTP templates.
 type EmailIdentityVerificationOTCValues struct {
-	Title              string
-	DisplayName        string
-	Domain             string
-	RemoteIP           string
-	OneTimeCode        string
-	RevocationLinkURL  string
-	RevocationLinkText string
+	Title              string `json:"title"`
+	DisplayName        string `json:"display_name"`
+	Domain             string `json:"domain"`
+	RemoteIP           string `json:"remote_ip"`
+	OneTimeCode        string `json:"one_time_code"`
+	RevocationLinkURL  string `json:"revocation_link_url"`
+	RevocationLinkText string `json:"revocation_link_text"`
 }
@@ -68,6 +68,12 @@ func readTemplate(name, ext, category, overridePath string) (tPath string, embed
 	return tPath, true, data, nil
 }
 
+// ParseStringTemplate parses a text template from a configured string value such as the value of a HTTP header or the
+// body of a HTTP request.
+func ParseStringTemplate(name, value string) (t *tt.Template, err error) {
+	return tt.New(name).Funcs(FuncMap()).Parse(value)
+}
+
 func parseTextTemplate(name, tPath string, embed bool, data []byte) (t *tt.Template, err error) {
 	if t, err = tt.New(name + extText).Funcs(FuncMap()).Parse(string(data)); err != nil {
 		if embed {
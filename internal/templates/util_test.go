@@ -4,6 +4,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"text/template"
 
@@ -102,3 +103,38 @@ func TestParseMiscTemplates(t *testing.T) {
 		})
 	}
 }
+
+func TestParseStringTemplate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		data     any
+		expected string
+		err      string
+	}{
+		{"ShouldRenderValue", `{{ .Name }}`, map[string]string{"Name": "john"}, "john", ""},
+		{"ShouldRenderFunctions", `{{ .Name | upper }}`, map[string]string{"Name": "john"}, "JOHN", ""},
+		{"ShouldFailInvalidTemplate", `{{ .Name `, nil, "", "template: example:1: unclosed action"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpl, err := ParseStringTemplate("example", tc.have)
+
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				assert.Nil(t, tmpl)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			buf := &strings.Builder{}
+
+			require.NoError(t, tmpl.Execute(buf, tc.data))
+
+			assert.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
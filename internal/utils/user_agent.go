@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"strings"
+)
+
+// UserAgentDevice returns a short human readable description of the browser and operating system described by a
+// User-Agent header value, i.e. 'Firefox on Linux'. It's a best effort description and unknown values are described as
+// 'Unknown'.
+func UserAgentDevice(userAgent string) (device string) {
+	if len(userAgent) == 0 {
+		return userAgentUnknown
+	}
+
+	return userAgentMatch(userAgent, userAgentBrowsers) + " on " + userAgentMatch(userAgent, userAgentPlatforms)
+}
+
+func userAgentMatch(userAgent string, matchers []userAgentMatcher) (name string) {
+	for _, matcher := range matchers {
+		if strings.Contains(userAgent, matcher.token) {
+			return matcher.name
+		}
+	}
+
+	return userAgentUnknown
+}
+
+type userAgentMatcher struct {
+	token string
+	name  string
+}
+
+const userAgentUnknown = "Unknown"
+
+// The order of these matchers is significant as many User-Agent values include the tokens of other browsers and
+// platforms for compatibility reasons.
+var (
+	userAgentBrowsers = []userAgentMatcher{
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"Vivaldi/", "Vivaldi"},
+		{"SamsungBrowser/", "Samsung Internet"},
+		{"Firefox/", "Firefox"},
+		{"FxiOS/", "Firefox"},
+		{"CriOS/", "Chrome"},
+		{"Chromium/", "Chromium"},
+		{"Chrome/", "Chrome"},
+		{"Safari/", "Safari"},
+		{"curl/", "curl"},
+	}
+
+	userAgentPlatforms = []userAgentMatcher{
+		{"Android", "Android"},
+		{"iPhone", "iOS"},
+		{"iPad", "iPadOS"},
+		{"CrOS", "ChromeOS"},
+		{"Windows", "Windows"},
+		{"Mac OS X", "macOS"},
+		{"Macintosh", "macOS"},
+		{"Linux", "Linux"},
+		{"FreeBSD", "FreeBSD"},
+	}
+)
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserAgentDevice(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected string
+	}{
+		{
+			"ShouldDescribeFirefoxLinux",
+			"Mozilla/5.0 (X11; Linux x86_64; rv:131.0) Gecko/20100101 Firefox/131.0",
+			"Firefox on Linux",
+		},
+		{
+			"ShouldDescribeChromeWindows",
+			"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/129.0.0.0 Safari/537.36",
+			"Chrome on Windows",
+		},
+		{
+			"ShouldDescribeEdgeWindows",
+			"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/129.0.0.0 Safari/537.36 Edg/129.0.0.0",
+			"Edge on Windows",
+		},
+		{
+			"ShouldDescribeSafariIOS",
+			"Mozilla/5.0 (iPhone; CPU iPhone OS 17_6 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.6 Mobile/15E148 Safari/604.1",
+			"Safari on iOS",
+		},
+		{
+			"ShouldDescribeChromeAndroid",
+			"Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/129.0.0.0 Mobile Safari/537.36",
+			"Chrome on Android",
+		},
+		{
+			"ShouldDescribeUnknownBrowser",
+			"Example/1.0",
+			"Unknown on Unknown",
+		},
+		{
+			"ShouldDescribeEmpty",
+			"",
+			"Unknown",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, UserAgentDevice(tc.have))
+		})
+	}
+}
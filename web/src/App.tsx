@@ -9,6 +9,7 @@ import { Route, BrowserRouter as Router, Routes } from "react-router-dom";
 import NotificationBar from "@components/NotificationBar";
 import {
     ConsentRoute,
+    DeviceCodeRoute,
     IndexRoute,
     LogoutRoute,
     ResetPasswordStep1Route,
@@ -29,6 +30,7 @@ import LoginPortal from "@views/LoginPortal/LoginPortal";
 import "@fortawesome/fontawesome-svg-core/styles.css";
 
 const ConsentView = lazy(() => import("@views/LoginPortal/ConsentView/ConsentView"));
+const DeviceCodeView = lazy(() => import("@views/LoginPortal/DeviceCodeView/DeviceCodeView"));
 const SignOut = lazy(() => import("@views/LoginPortal/SignOut/SignOut"));
 const ResetPasswordStep1 = lazy(() => import("@views/ResetPassword/ResetPasswordStep1"));
 const ResetPasswordStep2 = lazy(() => import("@views/ResetPassword/ResetPasswordStep2"));
@@ -65,6 +67,7 @@ const App: React.FC<Props> = (props: Props) => {
                                     <Route path={ResetPasswordStep2Route} element={<ResetPasswordStep2 />} />
                                     <Route path={LogoutRoute} element={<SignOut />} />
                                     <Route path={ConsentRoute} element={<ConsentView />} />
+                                    <Route path={DeviceCodeRoute} element={<DeviceCodeView />} />
                                     <Route path={RevokeOneTimeCodeRoute} element={<RevokeOneTimeCodeView />} />
                                     <Route path={RevokeResetPasswordRoute} element={<RevokeResetPasswordTokenView />} />
                                     <Route path={`${SettingsRoute}/*`} element={<SettingsRouter />} />
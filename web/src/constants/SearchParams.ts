@@ -5,3 +5,9 @@ export const IdentityToken: string = "token";
 export const RedirectionURL: string = "rd";
 
 export const RequestMethod: string = "rm";
+
+export const MaxAuthenticationAge: string = "max_age";
+
+export const SecondFactorMethods: string = "second_factor_methods";
+
+export const UserCode: string = "user_code";
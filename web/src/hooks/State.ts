@@ -1,6 +1,10 @@
+import { useCallback } from "react";
+
 import { useRemoteCall } from "@hooks/RemoteCall";
 import { getState } from "@services/State";
 
-export function useAutheliaState() {
-    return useRemoteCall(getState, []);
+export function useAutheliaState(maxAge?: string, secondFactorMethods?: string) {
+    const fn = useCallback(() => getState(maxAge, secondFactorMethods), [maxAge, secondFactorMethods]);
+
+    return useRemoteCall(fn, [maxAge, secondFactorMethods]);
 }
@@ -0,0 +1,28 @@
+import { DeviceCodeUserVerificationPath } from "@services/Api";
+import { Get, PostWithOptionalResponse } from "@services/Client";
+import { ConsentGetResponseBody } from "@services/Consent";
+
+interface DeviceCodeUserVerificationPostRequestBody {
+    user_code: string;
+    consent: boolean;
+}
+
+export function getDeviceCodeUserVerification(userCode: string) {
+    return Get<ConsentGetResponseBody>(DeviceCodeUserVerificationPath + "?user_code=" + encodeURIComponent(userCode));
+}
+
+export function acceptDeviceCode(userCode: string) {
+    const body: DeviceCodeUserVerificationPostRequestBody = {
+        user_code: userCode,
+        consent: true,
+    };
+    return PostWithOptionalResponse(DeviceCodeUserVerificationPath, body);
+}
+
+export function rejectDeviceCode(userCode: string) {
+    const body: DeviceCodeUserVerificationPostRequestBody = {
+        user_code: userCode,
+        consent: false,
+    };
+    return PostWithOptionalResponse(DeviceCodeUserVerificationPath, body);
+}
@@ -12,6 +12,22 @@ export interface AutheliaState {
     authentication_level: AuthenticationLevel;
 }
 
-export async function getState(): Promise<AutheliaState> {
+export async function getState(maxAge?: string, secondFactorMethods?: string): Promise<AutheliaState> {
+    const params = new URLSearchParams();
+
+    if (maxAge) {
+        params.set("max_age", maxAge);
+    }
+
+    if (secondFactorMethods) {
+        params.set("second_factor_methods", secondFactorMethods);
+    }
+
+    const query = params.toString();
+
+    if (query) {
+        return Get<AutheliaState>(`${StatePath}?${query}`);
+    }
+
     return Get<AutheliaState>(StatePath);
 }
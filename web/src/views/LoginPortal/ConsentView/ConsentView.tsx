@@ -1,6 +1,6 @@
 import React, { Fragment, ReactNode, useEffect, useState } from "react";
 
-import { AccountBox, Autorenew, CheckBox, Contacts, Drafts, Group, LockOpen } from "@mui/icons-material";
+import { AccountBox, Autorenew, CheckBox, Contacts, Drafts, Group, LockOpen, Phone } from "@mui/icons-material";
 import {
     Button,
     Checkbox,
@@ -29,7 +29,7 @@ import LoadingPage from "@views/LoadingPage/LoadingPage";
 
 export interface Props {}
 
-function scopeNameToAvatar(id: string) {
+export function scopeNameToAvatar(id: string) {
     switch (id) {
         case "openid":
             return <AccountBox />;
@@ -41,6 +41,8 @@ function scopeNameToAvatar(id: string) {
             return <Group />;
         case "email":
             return <Drafts />;
+        case "phone":
+            return <Phone />;
         case "authelia.bearer.authz":
             return <LockOpen />;
         default:
@@ -48,6 +50,27 @@ function scopeNameToAvatar(id: string) {
     }
 }
 
+export function scopeNameToDescription(id: string, translate: (key: string) => string): string {
+    switch (id) {
+        case "openid":
+            return translate("Use OpenID to verify your identity");
+        case "offline_access":
+            return translate("Automatically refresh these permissions without user interaction");
+        case "profile":
+            return translate("Access your profile information");
+        case "groups":
+            return translate("Access your group membership");
+        case "email":
+            return translate("Access your email addresses");
+        case "phone":
+            return translate("Access your phone number");
+        case "authelia.bearer.authz":
+            return translate("Access protected resources logged in as you");
+        default:
+            return id;
+    }
+}
+
 const ConsentView = function (props: Props) {
     const { t: translate } = useTranslation();
 
@@ -98,25 +121,6 @@ const ConsentView = function (props: Props) {
         }
     }, [fetchUserInfoError, resetNotification, createErrorNotification, translate]);
 
-    const translateScopeNameToDescription = (id: string): string => {
-        switch (id) {
-            case "openid":
-                return translate("Use OpenID to verify your identity");
-            case "offline_access":
-                return translate("Automatically refresh these permissions without user interaction");
-            case "profile":
-                return translate("Access your profile information");
-            case "groups":
-                return translate("Access your group membership");
-            case "email":
-                return translate("Access your email addresses");
-            case "authelia.bearer.authz":
-                return translate("Access protected resources logged in as you");
-            default:
-                return id;
-        }
-    };
-
     const handleAcceptConsent = async () => {
         // This case should not happen in theory because the buttons are disabled when response is undefined.
         if (!response) {
@@ -176,7 +180,7 @@ const ConsentView = function (props: Props) {
                                     <Tooltip title={translate("Scope", { name: scope })}>
                                         <ListItem id={"scope-" + scope} dense>
                                             <ListItemIcon>{scopeNameToAvatar(scope)}</ListItemIcon>
-                                            <ListItemText primary={translateScopeNameToDescription(scope)} />
+                                            <ListItemText primary={scopeNameToDescription(scope, translate)} />
                                         </ListItem>
                                     </Tooltip>
                                 ))}
@@ -0,0 +1,236 @@
+import React, { FormEvent, useCallback, useEffect, useState } from "react";
+
+import {
+    Button,
+    List,
+    ListItem,
+    ListItemIcon,
+    ListItemText,
+    TextField,
+    Theme,
+    Tooltip,
+    Typography,
+} from "@mui/material";
+import Grid from "@mui/material/Grid2";
+import makeStyles from "@mui/styles/makeStyles";
+import { useTranslation } from "react-i18next";
+import { useSearchParams } from "react-router-dom";
+
+import { IndexRoute } from "@constants/Routes";
+import { RedirectionURL, UserCode } from "@constants/SearchParams";
+import { useNotifications } from "@hooks/NotificationsContext";
+import { useRouterNavigate } from "@hooks/RouterNavigate";
+import { useAutheliaState } from "@hooks/State";
+import LoginLayout from "@layouts/LoginLayout";
+import { ConsentGetResponseBody } from "@services/Consent";
+import { acceptDeviceCode, getDeviceCodeUserVerification, rejectDeviceCode } from "@services/DeviceCode";
+import { AuthenticationLevel } from "@services/State";
+import LoadingPage from "@views/LoadingPage/LoadingPage";
+import { scopeNameToAvatar, scopeNameToDescription } from "@views/LoginPortal/ConsentView/ConsentView";
+
+export interface Props {}
+
+const DeviceCodeView = function (props: Props) {
+    const { t: translate } = useTranslation();
+
+    const styles = useStyles();
+    const navigate = useRouterNavigate();
+    const [searchParams] = useSearchParams();
+    const { createErrorNotification, createSuccessNotification } = useNotifications();
+    const [state, fetchState, , fetchStateError] = useAutheliaState();
+
+    const [userCode, setUserCode] = useState(searchParams.get(UserCode) ?? "");
+    const [submitted, setSubmitted] = useState(searchParams.get(UserCode) !== null);
+    const [response, setResponse] = useState<ConsentGetResponseBody>();
+    const [completed, setCompleted] = useState(false);
+
+    useEffect(() => {
+        fetchState();
+    }, [fetchState]);
+
+    useEffect(() => {
+        if (fetchStateError || (state && state.authentication_level < AuthenticationLevel.OneFactor)) {
+            navigate(IndexRoute, false, new URLSearchParams({ [RedirectionURL]: window.location.href }));
+        }
+    }, [state, fetchStateError, navigate]);
+
+    useEffect(() => {
+        if (!submitted || !state || state.authentication_level < AuthenticationLevel.OneFactor) {
+            return;
+        }
+
+        getDeviceCodeUserVerification(userCode)
+            .then((r) => {
+                setResponse(r);
+            })
+            .catch((err) => {
+                console.error(err);
+                setSubmitted(false);
+                createErrorNotification(translate("The code is invalid or has expired"));
+            });
+    }, [submitted, state, userCode, createErrorNotification, translate]);
+
+    const handleSubmit = (e: FormEvent) => {
+        e.preventDefault();
+
+        if (userCode.trim() === "") {
+            return;
+        }
+
+        setSubmitted(true);
+    };
+
+    const handleResponse = useCallback(
+        async (consent: boolean) => {
+            try {
+                if (consent) {
+                    await acceptDeviceCode(userCode);
+                } else {
+                    await rejectDeviceCode(userCode);
+                }
+
+                setCompleted(true);
+                createSuccessNotification(translate("You may now return to your device"));
+            } catch (err) {
+                console.error(err);
+                createErrorNotification(translate("There was an issue responding to the device request"));
+            }
+        },
+        [userCode, createErrorNotification, createSuccessNotification, translate],
+    );
+
+    if (!state || state.authentication_level < AuthenticationLevel.OneFactor) {
+        return <LoadingPage />;
+    }
+
+    if (completed) {
+        return (
+            <LoginLayout id="device-code-complete-stage" title={translate("Device Authorization")}>
+                <Typography>{translate("You may now return to your device")}</Typography>
+            </LoginLayout>
+        );
+    }
+
+    if (!submitted || response === undefined) {
+        return (
+            <LoginLayout
+                id="device-code-stage"
+                title={translate("Device Authorization")}
+                subtitle={translate("Enter the code displayed on your device")}
+            >
+                <form onSubmit={handleSubmit}>
+                    <Grid container spacing={2}>
+                        <Grid size={{ xs: 12 }}>
+                            <TextField
+                                id="user-code-textfield"
+                                label={translate("Code")}
+                                variant="outlined"
+                                fullWidth
+                                required
+                                autoFocus
+                                disabled={submitted}
+                                value={userCode}
+                                onChange={(v) => setUserCode(v.target.value.toUpperCase())}
+                            />
+                        </Grid>
+                        <Grid size={{ xs: 12 }}>
+                            <Button
+                                id="user-code-button"
+                                type="submit"
+                                color="primary"
+                                variant="contained"
+                                fullWidth
+                                disabled={submitted}
+                            >
+                                {translate("Continue")}
+                            </Button>
+                        </Grid>
+                    </Grid>
+                </form>
+            </LoginLayout>
+        );
+    }
+
+    return (
+        <LoginLayout
+            id="device-code-consent-stage"
+            title={translate("Device Authorization")}
+            subtitle={translate("Consent Request")}
+        >
+            <Grid container alignItems={"center"} justifyContent="center">
+                <Grid size={{ xs: 12 }}>
+                    <Tooltip title={translate("Client ID", { client_id: response.client_id })}>
+                        <Typography className={styles.clientDescription}>
+                            {response.client_description !== "" ? response.client_description : response.client_id}
+                        </Typography>
+                    </Tooltip>
+                </Grid>
+                <Grid size={{ xs: 12 }}>
+                    <div>{translate("The above application is requesting the following permissions")}:</div>
+                </Grid>
+                <Grid size={{ xs: 12 }}>
+                    <div className={styles.scopesListContainer}>
+                        <List className={styles.scopesList}>
+                            {response.scopes.map((scope: string) => (
+                                <Tooltip key={scope} title={translate("Scope", { name: scope })}>
+                                    <ListItem id={"scope-" + scope} dense>
+                                        <ListItemIcon>{scopeNameToAvatar(scope)}</ListItemIcon>
+                                        <ListItemText primary={scopeNameToDescription(scope, translate)} />
+                                    </ListItem>
+                                </Tooltip>
+                            ))}
+                        </List>
+                    </div>
+                </Grid>
+                <Grid size={{ xs: 12 }}>
+                    <Grid container spacing={1}>
+                        <Grid size={{ xs: 6 }}>
+                            <Button
+                                id="accept-button"
+                                className={styles.button}
+                                onClick={() => handleResponse(true)}
+                                color="primary"
+                                variant="contained"
+                            >
+                                {translate("Accept")}
+                            </Button>
+                        </Grid>
+                        <Grid size={{ xs: 6 }}>
+                            <Button
+                                id="deny-button"
+                                className={styles.button}
+                                onClick={() => handleResponse(false)}
+                                color="secondary"
+                                variant="contained"
+                            >
+                                {translate("Deny")}
+                            </Button>
+                        </Grid>
+                    </Grid>
+                </Grid>
+            </Grid>
+        </LoginLayout>
+    );
+};
+
+const useStyles = makeStyles((theme: Theme) => ({
+    clientDescription: {
+        fontWeight: 600,
+    },
+    scopesListContainer: {
+        textAlign: "center",
+    },
+    scopesList: {
+        display: "inline-block",
+        backgroundColor: theme.palette.background.paper,
+        marginTop: theme.spacing(2),
+        marginBottom: theme.spacing(2),
+    },
+    button: {
+        marginLeft: theme.spacing(),
+        marginRight: theme.spacing(),
+        width: "100%",
+    },
+}));
+
+export default DeviceCodeView;
@@ -11,7 +11,7 @@ import {
     SecondFactorTOTPSubRoute,
     SecondFactorWebAuthnSubRoute,
 } from "@constants/Routes";
-import { RedirectionURL } from "@constants/SearchParams";
+import { MaxAuthenticationAge, RedirectionURL, SecondFactorMethods } from "@constants/SearchParams";
 import { useLocalStorageMethodContext } from "@contexts/LocalStorageMethodContext";
 import { useConfiguration } from "@hooks/Configuration";
 import { useNotifications } from "@hooks/NotificationsContext";
@@ -23,6 +23,7 @@ import { useUserInfoPOST } from "@hooks/UserInfo";
 import { SecondFactorMethod } from "@models/Methods";
 import { checkSafeRedirection } from "@services/SafeRedirection";
 import { AuthenticationLevel } from "@services/State";
+import { Method2FA, isMethod2FA, toSecondFactorMethod } from "@services/UserInfo";
 import LoadingPage from "@views/LoadingPage/LoadingPage";
 
 const AuthenticatedView = lazy(() => import("@views/LoginPortal/AuthenticatedView/AuthenticatedView"));
@@ -43,6 +44,8 @@ const RedirectionErrorMessage =
 const LoginPortal = function (props: Props) {
     const location = useLocation();
     const redirectionURL = useQueryParam(RedirectionURL);
+    const maxAuthenticationAge = useQueryParam(MaxAuthenticationAge);
+    const secondFactorMethods = useQueryParam(SecondFactorMethods);
     const { createErrorNotification } = useNotifications();
     const [firstFactorDisabled, setFirstFactorDisabled] = useState(true);
     const [broadcastRedirect, setBroadcastRedirect] = useState(false);
@@ -50,7 +53,7 @@ const LoginPortal = function (props: Props) {
     const { localStorageMethod } = useLocalStorageMethodContext();
     const { t: translate } = useTranslation();
 
-    const [state, fetchState, , fetchStateError] = useAutheliaState();
+    const [state, fetchState, , fetchStateError] = useAutheliaState(maxAuthenticationAge, secondFactorMethods);
     const [userInfo, fetchUserInfo, , fetchUserInfoError] = useUserInfoPOST();
     const [configuration, fetchConfiguration, , fetchConfigurationError] = useConfiguration();
 
@@ -133,7 +136,7 @@ const LoginPortal = function (props: Props) {
                 if (configuration.available_methods.size === 0) {
                     navigate(AuthenticatedRoute, false);
                 } else {
-                    const method = localStorageMethod || userInfo.method;
+                    const method = getSecondFactorMethod(localStorageMethod || userInfo.method, secondFactorMethods);
 
                     if (method === SecondFactorMethod.WebAuthn) {
                         navigate(`${SecondFactorRoute}${SecondFactorWebAuthnSubRoute}`);
@@ -156,6 +159,7 @@ const LoginPortal = function (props: Props) {
         redirector,
         broadcastRedirect,
         localStorageMethod,
+        secondFactorMethods,
         translate,
     ]);
 
@@ -218,6 +222,26 @@ const LoginPortal = function (props: Props) {
     );
 };
 
+// getSecondFactorMethod returns the preferred method unless the access control rule requires specific second factor
+// methods which don't include it, in which case the first required method is returned.
+function getSecondFactorMethod(preferred: SecondFactorMethod, required?: string): SecondFactorMethod {
+    if (!required) {
+        return preferred;
+    }
+
+    const methods = required
+        .split(",")
+        .map((method) => (method === "webauthn_hardware" ? "webauthn" : method))
+        .filter(isMethod2FA)
+        .map((method) => toSecondFactorMethod(method as Method2FA));
+
+    if (methods.length === 0 || methods.includes(preferred)) {
+        return preferred;
+    }
+
+    return methods[0];
+}
+
 interface ComponentOrLoadingProps {
     ready: boolean;
 